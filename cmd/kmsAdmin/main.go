@@ -0,0 +1,271 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+func main() {
+	app := &cli.App{
+		Name:  "kms-admin",
+		Usage: "Control a running EigenX KMS server through its admin API",
+		Description: `Talks to the admin API of a kms-server started with --admin-socket or
+--admin-address. Every command acts on one key (--key-id) and prints the JSON
+response.
+
+Operator-set-wide actions need every operator's node: a reshare only completes
+when a threshold of operators run it at the same block, so trigger all of them
+with the same --block.`,
+		Version: "1.0.0",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "socket",
+				Usage:   "Admin Unix socket of the server",
+				EnvVars: []string{config.EnvKMSAdminSocket},
+			},
+			&cli.StringFlag{
+				Name:    "address",
+				Usage:   "host:port of the server's mutual TLS admin listener, instead of --socket",
+				EnvVars: []string{config.EnvKMSAdminAddress},
+			},
+			&cli.StringFlag{
+				Name:  "tls-cert",
+				Usage: "PEM client certificate, signed by the server's admin client CA",
+			},
+			&cli.StringFlag{
+				Name:  "tls-key",
+				Usage: "PEM private key of --tls-cert",
+			},
+			&cli.StringFlag{
+				Name:  "tls-ca",
+				Usage: "PEM CA to verify the server certificate with (default: system roots)",
+			},
+			&cli.StringFlag{
+				Name:  "key-id",
+				Usage: "Key to act on",
+				Value: types.DefaultKeyID,
+			},
+		},
+		Commands: []*cli.Command{
+			{
+				Name:   "status",
+				Usage:  "Show every key's versions with their MPKs, auto-heal state, last boundary, platform RPC URL and sessions",
+				Action: getCommand("/v1/status"),
+			},
+			{
+				Name:   "keys",
+				Usage:  "Show the state of every key the server holds",
+				Action: getCommand("/v1/admin/keys"),
+			},
+			{
+				Name:   "state",
+				Usage:  "Show the reshare pause, pending trigger, active and poisoned versions and the MPK abort tracker",
+				Action: keyGetCommand("state"),
+			},
+			{
+				Name:   "sessions",
+				Usage:  "List the in-progress DKG and reshare sessions",
+				Action: keyGetCommand("sessions"),
+			},
+			{
+				Name:   "generated-shares",
+				Usage:  "List the retained rounds of dealt reshare shares and their recipients (never the shares)",
+				Action: keyGetCommand("generated-shares"),
+			},
+			{
+				Name:   "retention",
+				Usage:  "Report what the key retention policy would prune now",
+				Action: keyGetCommand("retention"),
+			},
+			{
+				Name:   "pause-reshare",
+				Usage:  "Pause the scheduled reshare; survives a restart",
+				Action: keyPostCommand("reshare/pause", nil),
+			},
+			{
+				Name:   "resume-reshare",
+				Usage:  "Resume the scheduled reshare",
+				Action: keyPostCommand("reshare/resume", nil),
+			},
+			{
+				Name:  "trigger-reshare",
+				Usage: "Run a reshare at the next block, or at --block",
+				Flags: []cli.Flag{
+					&cli.Int64Flag{
+						Name:  "block",
+						Usage: "Block number to reshare at; must be the same on every operator (0 = the next block)",
+					},
+				},
+				Action: keyPostCommand("reshare/trigger", func(c *cli.Context) interface{} {
+					return types.AdminReshareTriggerRequest{BlockNumber: c.Int64("block")}
+				}),
+			},
+			{
+				Name:   "rollback",
+				Usage:  "Make a non-poisoned version of the active generation the active key version",
+				Flags:  []cli.Flag{versionFlag()},
+				Action: keyPostCommand("rollback", versionRequest),
+			},
+			{
+				Name:   "poison",
+				Usage:  "Mark a key version poisoned so it is never activated or served again",
+				Flags:  []cli.Flag{versionFlag()},
+				Action: keyPostCommand("poison", versionRequest),
+			},
+		},
+	}
+
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func versionFlag() cli.Flag {
+	return &cli.Int64Flag{
+		Name:     "version",
+		Usage:    "Key version (the unix timestamp it was created at)",
+		Required: true,
+	}
+}
+
+func versionRequest(c *cli.Context) interface{} {
+	return types.AdminVersionRequest{Version: c.Int64("version")}
+}
+
+func keyPath(c *cli.Context, route string) string {
+	return "/v1/admin/keys/" + url.PathEscape(c.String("key-id")) + "/" + route
+}
+
+func getCommand(path string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		return runRequest(c, http.MethodGet, path, nil)
+	}
+}
+
+func keyGetCommand(route string) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		return runRequest(c, http.MethodGet, keyPath(c, route), nil)
+	}
+}
+
+func keyPostCommand(route string, body func(*cli.Context) interface{}) cli.ActionFunc {
+	return func(c *cli.Context) error {
+		var payload interface{}
+		if body != nil {
+			payload = body(c)
+		}
+		return runRequest(c, http.MethodPost, keyPath(c, route), payload)
+	}
+}
+
+// runRequest sends one admin request and prints the indented JSON response.
+func runRequest(c *cli.Context, method, path string, payload interface{}) error {
+	client, baseURL, err := newAdminClient(c.String("socket"), c.String("address"),
+		c.String("tls-cert"), c.String("tls-key"), c.String("tls-ca"))
+	if err != nil {
+		return err
+	}
+	out, err := doRequest(client, method, baseURL+path, payload)
+	if err != nil {
+		return err
+	}
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, out, "", "  "); err != nil {
+		return fmt.Errorf("invalid response: %w", err)
+	}
+	fmt.Println(pretty.String())
+	return nil
+}
+
+// newAdminClient returns a client for the admin Unix socket, or for the mutual TLS
+// listener at address, and the base URL to send requests to.
+func newAdminClient(socket, address, certFile, keyFile, caFile string) (*http.Client, string, error) {
+	switch {
+	case socket != "" && address != "":
+		return nil, "", fmt.Errorf("use either --socket or --address, not both")
+	case socket != "":
+		transport := &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				var d net.Dialer
+				return d.DialContext(ctx, "unix", socket)
+			},
+		}
+		return &http.Client{Transport: transport, Timeout: 30 * time.Second}, "http://kms-admin", nil
+	case address != "":
+		if certFile == "" || keyFile == "" {
+			return nil, "", fmt.Errorf("--address requires --tls-cert and --tls-key")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig := &tls.Config{
+			Certificates: []tls.Certificate{cert},
+			MinVersion:   tls.VersionTLS13,
+		}
+		if caFile != "" {
+			caPEM, err := os.ReadFile(caFile)
+			if err != nil {
+				return nil, "", fmt.Errorf("failed to read server CA: %w", err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(caPEM) {
+				return nil, "", fmt.Errorf("server CA %s holds no PEM certificates", caFile)
+			}
+		}
+		transport := &http.Transport{TLSClientConfig: tlsConfig}
+		return &http.Client{Transport: transport, Timeout: 30 * time.Second}, "https://" + address, nil
+	default:
+		return nil, "", fmt.Errorf("--socket or --address is required")
+	}
+}
+
+// doRequest sends the request and returns the response body, or an error carrying the
+// server's message for a non-2xx status.
+func doRequest(client *http.Client, method, target string, payload interface{}) ([]byte, error) {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequest(method, target, body)
+	if err != nil {
+		return nil, err
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("admin request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	out, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, fmt.Errorf("admin request failed with status %d: %s", resp.StatusCode, bytes.TrimSpace(out))
+	}
+	return out, nil
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAdminClient(t *testing.T) {
+	t.Run("requires a listener", func(t *testing.T) {
+		_, _, err := newAdminClient("", "", "", "", "")
+		require.ErrorContains(t, err, "required")
+	})
+
+	t.Run("rejects both listeners", func(t *testing.T) {
+		_, _, err := newAdminClient("/run/kms/admin.sock", "127.0.0.1:9443", "", "", "")
+		require.ErrorContains(t, err, "not both")
+	})
+
+	t.Run("address requires a client certificate", func(t *testing.T) {
+		_, _, err := newAdminClient("", "127.0.0.1:9443", "", "", "")
+		require.ErrorContains(t, err, "--tls-cert")
+	})
+}
+
+func TestDoRequestOverSocket(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "admin.sock")
+	ln, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/admin/keys/default/rollback", func(w http.ResponseWriter, r *http.Request) {
+		var req types.AdminVersionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Version != 42 {
+			http.Error(w, "bad version", http.StatusBadRequest)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(types.AdminKeyState{ActiveVersion: req.Version})
+	})
+	srv := &http.Server{Handler: mux}
+	go func() { _ = srv.Serve(ln) }()
+	t.Cleanup(func() { _ = srv.Close() })
+
+	client, baseURL, err := newAdminClient(socket, "", "", "", "")
+	require.NoError(t, err)
+
+	out, err := doRequest(client, http.MethodPost, baseURL+"/v1/admin/keys/default/rollback", types.AdminVersionRequest{Version: 42})
+	require.NoError(t, err)
+	var state types.AdminKeyState
+	require.NoError(t, json.Unmarshal(out, &state))
+	require.Equal(t, int64(42), state.ActiveVersion)
+
+	_, err = doRequest(client, http.MethodPost, baseURL+"/v1/admin/keys/default/rollback", types.AdminVersionRequest{Version: 7})
+	require.ErrorContains(t, err, "status 400: bad version")
+}
@@ -9,7 +9,10 @@ import (
 	"testing"
 
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/clients/kmsClient"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/derive"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -46,6 +49,7 @@ func TestCacheable_AppPrivateKeyNeverCached(t *testing.T) {
 	// written); any other key is cacheable. This is the invariant both cache
 	// sites in run() gate on.
 	assert.False(t, cacheable(appPrivateKeyKey), "app_private_key root must never be cached")
+	assert.False(t, cacheable(appMnemonicKey), "mnemonic derived from the root must never be cached")
 	assert.True(t, cacheable("DB_PASSWORD"), "ordinary env keys are cacheable")
 	assert.True(t, cacheable("API_KEY"), "ordinary env keys are cacheable")
 }
@@ -57,7 +61,7 @@ func TestEmitAppPrivateKey_RefusesUnverified(t *testing.T) {
 		AppPrivateKey: types.G1Point{CompressedBytes: make([]byte, appPrivateKeyG1Bytes)},
 		Verified:      false,
 	}
-	_, err := emitAppPrivateKey(result, "0xapp")
+	_, err := emitAppPrivateKey(result, "0xapp", 0)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "not verified")
 }
@@ -69,8 +73,9 @@ func TestEmitAppPrivateKey_RejectsWrongLength(t *testing.T) {
 		result := &kmsClient.SecretsResult{
 			AppPrivateKey: types.G1Point{CompressedBytes: make([]byte, n)},
 			Verified:      true,
+			Generation:    generationOf(0),
 		}
-		_, err := emitAppPrivateKey(result, "0xapp")
+		_, err := emitAppPrivateKey(result, "0xapp", 0)
 		require.Errorf(t, err, "expected error for %d-byte key", n)
 		assert.Contains(t, err.Error(), "want 48")
 	}
@@ -79,7 +84,8 @@ func TestEmitAppPrivateKey_RejectsWrongLength(t *testing.T) {
 	_, err := emitAppPrivateKey(&kmsClient.SecretsResult{
 		AppPrivateKey: types.G1Point{CompressedBytes: nil},
 		Verified:      true,
-	}, "0xapp")
+		Generation:    generationOf(0),
+	}, "0xapp", 0)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "want 48")
 }
@@ -92,13 +98,77 @@ func TestEmitAppPrivateKey_EmitsVerified48Byte(t *testing.T) {
 	result := &kmsClient.SecretsResult{
 		AppPrivateKey: types.G1Point{CompressedBytes: raw},
 		Verified:      true,
+		Generation:    generationOf(0),
 	}
-	out, err := emitAppPrivateKey(result, "0xapp")
+	out, err := emitAppPrivateKey(result, "0xapp", 0)
 	require.NoError(t, err)
 	assert.Equal(t, map[string]string{appPrivateKeyKey: hex.EncodeToString(raw)}, out)
 	assert.False(t, strings.Contains(out[appPrivateKeyKey], " "), "hex must be bare")
 }
 
+// generationOf returns a pointer to generation, for SecretsResult.Generation.
+func generationOf(generation uint32) *uint32 {
+	return &generation
+}
+
+func TestEmitAppPrivateKey_RefusesOtherGeneration(t *testing.T) {
+	// A rotation gives the app another root; the pinned generation's root is the
+	// only one emitted, and an unknown generation is refused as well.
+	for _, generation := range []*uint32{nil, generationOf(1)} {
+		result := &kmsClient.SecretsResult{
+			AppPrivateKey: types.G1Point{CompressedBytes: make([]byte, appPrivateKeyG1Bytes)},
+			Verified:      true,
+			Generation:    generation,
+		}
+		_, err := emitAppPrivateKey(result, "0xapp", 0)
+		require.ErrorIs(t, err, kmsClient.ErrDerivationGenerationMismatch)
+		_, err = emitAppMnemonic(result, "0xapp", 0)
+		require.ErrorIs(t, err, kmsClient.ErrDerivationGenerationMismatch)
+	}
+
+	result := &kmsClient.SecretsResult{
+		AppPrivateKey: types.G1Point{CompressedBytes: make([]byte, appPrivateKeyG1Bytes)},
+		Verified:      true,
+		Generation:    generationOf(1),
+	}
+	_, err := emitAppPrivateKey(result, "0xapp", 1)
+	require.NoError(t, err)
+}
+
+func TestEmitAppMnemonic_RefusesUnverified(t *testing.T) {
+	qID, err := crypto.HashToG1("0xapp")
+	require.NoError(t, err)
+	appKey, err := crypto.ScalarMulG1(*qID, new(fr.Element).SetUint64(7))
+	require.NoError(t, err)
+
+	_, err = emitAppMnemonic(&kmsClient.SecretsResult{AppPrivateKey: *appKey}, "0xapp", 0)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not verified")
+}
+
+func TestEmitAppMnemonic_EmitsDerivedMnemonic(t *testing.T) {
+	qID, err := crypto.HashToG1("0xapp")
+	require.NoError(t, err)
+	appKey, err := crypto.ScalarMulG1(*qID, new(fr.Element).SetUint64(7))
+	require.NoError(t, err)
+	result := &kmsClient.SecretsResult{AppPrivateKey: *appKey, Verified: true, Generation: generationOf(0)}
+
+	out, err := emitAppMnemonic(result, "0xapp", 0)
+	require.NoError(t, err)
+	root, err := derive.NewRoot(*appKey)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{appMnemonicKey: root.Mnemonic()}, out)
+	assert.Len(t, strings.Fields(out[appMnemonicKey]), 24)
+
+	// A verified but malformed key is still rejected
+	_, err = emitAppMnemonic(&kmsClient.SecretsResult{
+		AppPrivateKey: types.G1Point{CompressedBytes: make([]byte, 32)},
+		Verified:      true,
+		Generation:    generationOf(0),
+	}, "0xapp", 0)
+	require.Error(t, err)
+}
+
 func TestCacheRoundTrip(t *testing.T) {
 	// Point the cache at a temp dir so the test doesn't touch /run/eigenx.
 	orig := envCacheDir
@@ -124,8 +124,8 @@ func (s *singleOperatorContractCaller) GetOperatorSetMembersWithPeering(avsAddre
 // app_private_key inside this TEE (docs/references/new_kms.md).
 //
 // Key selects which environment variable to return from the assembled stack
-// env, or the reserved sentinel appPrivateKeyKey to return the app_private_key
-// itself. CDH calls the helper once per sealed env var in the pod spec, each
+// env, or one of the reserved sentinels appPrivateKeyKey / appMnemonicKey to return
+// the app_private_key itself or the mnemonic derived from it. CDH calls the helper once per sealed env var in the pod spec, each
 // carrying the Key it wants. The first call attests + fetches and caches the
 // whole env map to tmpfs; later calls for the same stack read the cache (one
 // attestation per pod, not per key). See env_cache.go.
@@ -146,8 +146,11 @@ type Request struct {
 	// from stdin — for the same SSRF/redirect reasons as the KMS coords.
 	PlatformSecretsURL     string `json:"-"`
 	PlatformInternalAPIKey string `json:"-"`
+	// DeriveGeneration is the master secret generation the reserved sentinels are
+	// pinned to. Sourced ONLY from SNP-bound cc_init_data, like the platform fields.
+	DeriveGeneration uint32 `json:"-"`
 	// Key is the environment-variable name to return from the assembled stack
-	// env, or the reserved sentinel appPrivateKeyKey to return the app_private_key.
+	// env, or a reserved sentinel (appPrivateKeyKey, appMnemonicKey).
 	Key string `json:"key"`
 }
 
@@ -167,6 +170,11 @@ type initdataKMSConfig struct {
 	StackID                string `toml:"stack_id"`
 	PlatformSecretsURL     string `toml:"platform_secrets_url"`
 	PlatformInternalAPIKey string `toml:"platform_internal_api_key"`
+	// DeriveGeneration pins the app_private_key and mnemonic sentinels to one
+	// master secret generation, so a rotation does not silently hand the app a
+	// different root and different wallets; defaults to 0, the genesis generation.
+	// See appPrivateKeyKey.
+	DeriveGeneration uint32 `toml:"derive_generation"`
 }
 
 const (
@@ -191,15 +199,30 @@ const (
 // ever recoverable inside an attested TEE. Because it is not part of the
 // release env, it bypasses the merged-env assembly, the IBE-decrypt, and the
 // tmpfs env cache entirely (the root must never be written to disk).
+//
+// The root is S·H(appID), so a master secret rotation (a new S) changes it and
+// everything derived from it. Both sentinels are therefore recovered from the
+// generation eigenx.toml pins with derive_generation, which operators serve until
+// it retires. To migrate after a rotation, a release pinned to the old generation
+// moves what its keys hold to the keys of the new one (recoverable with
+// derive_generation set to it) before the old generation retires; later releases
+// pin the new generation. A pinned generation that has retired fails loudly.
 const appPrivateKeyKey = "__EIGENX_APP_PRIVATE_KEY__"
 
+// appMnemonicKey is a reserved Key value that makes the helper emit the app's
+// BIP-39 mnemonic, derived from the app_private_key by pkg/derive. Wallet
+// software imports it directly, so every instance of the app gets the same
+// accounts without handling the G1 root. It is handled exactly like
+// appPrivateKeyKey: same attestation path, never cached.
+const appMnemonicKey = "__EIGENX_APP_MNEMONIC__"
+
 // cacheable reports whether a request key may be served from / written to the
-// tmpfs env cache. The app_private_key root is never cached: it is not part of
-// the release env and must not be persisted to disk, so its request always
-// re-attests. This single predicate is the one place the invariant lives — add
+// tmpfs env cache. The app_private_key root and the mnemonic derived from it
+// are never cached: they are not part of the release env and must not be
+// persisted to disk, so their requests always re-attest. This single predicate is the one place the invariant lives — add
 // any future reserved sentinels to the exclusion here.
 func cacheable(key string) bool {
-	return key != appPrivateKeyKey
+	return key != appPrivateKeyKey && key != appMnemonicKey
 }
 
 func main() {
@@ -522,6 +545,7 @@ func applyInitdataKMSConfig(req *Request, cfg *initdataKMSConfig) error {
 	req.StackID = cfg.StackID
 	req.PlatformSecretsURL = cfg.PlatformSecretsURL
 	req.PlatformInternalAPIKey = cfg.PlatformInternalAPIKey
+	req.DeriveGeneration = cfg.DeriveGeneration
 	return nil
 }
 
@@ -690,12 +714,17 @@ const appPrivateKeyG1Bytes = 48
 // app_private_key (hex of its compressed G1 bytes) keyed by the sentinel. It is
 // the root secret a signing daemon seeds from, so it is emitted only when it was
 // validated against the master public key — never on the degraded (no-BFT-retry)
-// path where a Byzantine operator could yield a corrupted key — and only when it
-// has the exact G1 point length.
-func emitAppPrivateKey(result *kmsClient.SecretsResult, appID string) (map[string]string, error) {
+// path where a Byzantine operator could yield a corrupted key — only when it
+// belongs to the pinned master secret generation, and only when it has the exact
+// G1 point length.
+func emitAppPrivateKey(result *kmsClient.SecretsResult, appID string, generation uint32) (map[string]string, error) {
 	if !result.Verified {
 		return nil, fmt.Errorf("refusing to emit app_private_key for app %q: not verified against master public key (degraded recovery)", appID)
 	}
+	if result.Generation == nil || *result.Generation != generation {
+		return nil, fmt.Errorf("refusing to emit app_private_key for app %q: %w: not recovered from the pinned generation %d (derive_generation)",
+			appID, kmsClient.ErrDerivationGenerationMismatch, generation)
+	}
 	if len(result.AppPrivateKey.CompressedBytes) != appPrivateKeyG1Bytes {
 		return nil, fmt.Errorf("KMS returned app_private_key of %d bytes for app %q, want %d", len(result.AppPrivateKey.CompressedBytes), appID, appPrivateKeyG1Bytes)
 	}
@@ -704,6 +733,21 @@ func emitAppPrivateKey(result *kmsClient.SecretsResult, appID string) (map[strin
 	}, nil
 }
 
+// emitAppMnemonic returns the app's BIP-39 mnemonic keyed by the sentinel. The
+// mnemonic carries the whole wallet hierarchy, so it gets the same verified-only
+// gate as the raw key: a corrupted key would derive wallets that silently differ
+// from the app's real ones.
+func emitAppMnemonic(result *kmsClient.SecretsResult, appID string, generation uint32) (map[string]string, error) {
+	if _, err := emitAppPrivateKey(result, appID, generation); err != nil {
+		return nil, err
+	}
+	root, err := result.DeriveRoot(generation)
+	if err != nil {
+		return nil, fmt.Errorf("derive mnemonic for app %q: %w", appID, err)
+	}
+	return map[string]string{appMnemonicKey: root.Mnemonic()}, nil
+}
+
 // assembleEnvFromSecrets IBE-decrypts each platform secret with the
 // threshold-recovered app-private-key and returns the app's environment as a
 // flat name→plaintext map. The IBE identity is the stackID (the ecloud CLI
@@ -724,8 +768,8 @@ func assembleEnvFromSecrets(stackID string, appPrivateKey types.G1Point, secrets
 
 // resolveEnv turns a recovered SecretsResult into the app's environment map.
 // The secrets-fetch is injected so the sentinel/no-fetch path is unit-testable
-// without a live KMS client or network. On the app_private_key sentinel it
-// returns the raw key and never fetches; otherwise it fetches the stack's
+// without a live KMS client or network. On the app_private_key or mnemonic
+// sentinel it returns the raw key or mnemonic and never fetches; otherwise it fetches the stack's
 // sealed secrets and IBE-decrypts each under the stackID identity.
 func resolveEnv(
 	req *Request,
@@ -735,7 +779,10 @@ func resolveEnv(
 	// Root-key request: emit the threshold-recovered app_private_key itself and
 	// stop — it does not depend on the platform secrets, so return before the fetch.
 	if req.Key == appPrivateKeyKey {
-		return emitAppPrivateKey(result, req.StackID)
+		return emitAppPrivateKey(result, req.StackID, req.DeriveGeneration)
+	}
+	if req.Key == appMnemonicKey {
+		return emitAppMnemonic(result, req.StackID, req.DeriveGeneration)
 	}
 
 	// Stack model: the KMS platform path returns ONLY the recovered
@@ -761,8 +808,9 @@ func resolveEnv(
 // the environment is NOT carried in the KMS response. resolveEnv fetches the
 // stack's sealed secrets from the ecloud-platform InternalSecretsService and
 // IBE-decrypts each under the stackID identity, so the plaintext only ever
-// exists inside this attested TEE. When the sentinel key (appPrivateKeyKey) is
-// requested, resolveEnv returns the raw app_private_key and skips the fetch.
+// exists inside this attested TEE. When a sentinel key (appPrivateKeyKey,
+// appMnemonicKey) is requested, resolveEnv returns the raw app_private_key or
+// its mnemonic and skips the fetch.
 func retrieveAndDecrypt(
 	req *Request,
 	evidence, ccInitData, rsaPubPEM []byte,
@@ -815,6 +863,11 @@ func retrieveAndDecrypt(
 	if err != nil {
 		return nil, fmt.Errorf("create KMS client: %w", err)
 	}
+	// The sentinels' root is recovered from the pinned generation rather than the
+	// active one (see appPrivateKeyKey)
+	if req.Key == appPrivateKeyKey || req.Key == appMnemonicKey {
+		client = client.WithGeneration(req.DeriveGeneration)
+	}
 
 	// PEM-encode the RSA private key so we can hand it to the client (which
 	// needs PEM bytes to decrypt the partial-signature blobs in transit).
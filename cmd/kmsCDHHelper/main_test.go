@@ -242,11 +242,13 @@ func TestApplyInitdataKMSConfig_PlatformFields(t *testing.T) {
 			StackID:                "stack-123",
 			PlatformSecretsURL:     "http://platform.internal:9003",
 			PlatformInternalAPIKey: "internal-key",
+			DeriveGeneration:       2,
 		}
 		require.NoError(t, applyInitdataKMSConfig(req, cfg))
 		assert.Equal(t, "stack-123", req.StackID)
 		assert.Equal(t, "http://platform.internal:9003", req.PlatformSecretsURL)
 		assert.Equal(t, "internal-key", req.PlatformInternalAPIKey)
+		assert.Equal(t, uint32(2), req.DeriveGeneration)
 	})
 
 	t.Run("missing stack_id fails closed", func(t *testing.T) {
@@ -640,6 +642,7 @@ func TestResolveEnv_SentinelSkipsFetch(t *testing.T) {
 	// and a 48-byte compressed G1.
 	result := &kmsClient.SecretsResult{
 		Verified:      true,
+		Generation:    generationOf(0),
 		AppPrivateKey: types.G1Point{CompressedBytes: make([]byte, appPrivateKeyG1Bytes)},
 	}
 	req := &Request{StackID: "stack-1", Key: appPrivateKeyKey}
@@ -680,6 +683,28 @@ func TestResolveEnv_SentinelUnverifiedErrors(t *testing.T) {
 	assert.False(t, fetchCalled, "sentinel path must NOT fetch even when unverified")
 }
 
+func TestResolveEnv_MnemonicSentinelSkipsFetch(t *testing.T) {
+	qID, err := crypto.HashToG1("stack-1")
+	require.NoError(t, err)
+	appKey, err := crypto.ScalarMulG1(*qID, new(fr.Element).SetUint64(7))
+	require.NoError(t, err)
+	result := &kmsClient.SecretsResult{Verified: true, Generation: generationOf(0), AppPrivateKey: *appKey}
+	req := &Request{StackID: "stack-1", Key: appMnemonicKey}
+
+	fetchCalled := false
+	fetch := func(baseURL, apiKey, stackID string) ([]stackSecret, error) {
+		fetchCalled = true
+		return nil, nil
+	}
+
+	env, err := resolveEnv(req, result, fetch)
+	require.NoError(t, err)
+	assert.False(t, fetchCalled, "mnemonic sentinel path must NOT fetch platform secrets")
+	assert.NotEmpty(t, env[appMnemonicKey])
+	_, ok := env[appPrivateKeyKey]
+	assert.False(t, ok, "mnemonic sentinel must not also emit the raw key")
+}
+
 func TestResolveEnv_NormalPathFetchesAndAssembles(t *testing.T) {
 	const stackID = "stack-xyz"
 	masterSecret, err := new(fr.Element).SetRandom()
@@ -1,15 +1,19 @@
 package main
 
 import (
+	"bufio"
 	"crypto/ecdsa"
+	"crypto/mlkem"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
 	"strings"
 
 	"github.com/Layr-Labs/chain-indexer/pkg/clients/ethereum"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli/v2"
@@ -19,6 +23,8 @@ import (
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/logger"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 )
 
 func main() {
@@ -53,6 +59,26 @@ This client can:
 				Usage: "Operator set ID",
 				Value: 0,
 			},
+			&cli.StringFlag{
+				Name:  "key-id",
+				Usage: "Key ID to address on servers holding several keys (empty = the default key)",
+			},
+			&cli.UintFlag{
+				Name:  "generation",
+				Usage: "Master secret generation to use (default: the active, newest generation)",
+			},
+			&cli.StringFlag{
+				Name:  "commitment-registry-address",
+				Usage: "EigenKMSCommitmentRegistry address to verify the master public key against (requires --base-rpc-url)",
+			},
+			&cli.StringFlag{
+				Name:  "base-rpc-url",
+				Usage: "RPC URL of the chain holding --commitment-registry-address",
+			},
+			&cli.StringFlag{
+				Name:  "mpk-pin-file",
+				Usage: "File pinning the master public key on first use; a changed key without an on-chain record (see --commitment-registry-address) is refused",
+			},
 		},
 		Commands: []*cli.Command{
 			{
@@ -65,14 +91,22 @@ This client can:
 						Required: true,
 					},
 					&cli.StringFlag{
-						Name:     "data",
-						Usage:    "Data to encrypt (as string)",
-						Required: true,
+						Name:  "data",
+						Usage: "Data to encrypt (as string); exactly one of --data and --in is required",
 					},
 					&cli.StringFlag{
-						Name:  "output",
-						Usage: "Output file for encrypted data",
-						Value: "",
+						Name:  "in",
+						Usage: "File to encrypt as a stream, written to --out as a binary chunked ciphertext",
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"out"},
+						Usage:   "Output file for encrypted data (hex for --data, binary for --in, which requires it)",
+						Value:   "",
+					},
+					&cli.BoolFlag{
+						Name:  "post-quantum",
+						Usage: "Encrypt --data as a post-quantum hybrid ciphertext, also sealed to the app's ML-KEM-768 key",
 					},
 				},
 				Action: encryptCommand,
@@ -87,9 +121,12 @@ This client can:
 						Required: true,
 					},
 					&cli.StringFlag{
-						Name:     "encrypted-data",
-						Usage:    "Encrypted data (hex string) or path to file",
-						Required: true,
+						Name:  "encrypted-data",
+						Usage: "Encrypted data (hex string) or path to file; exactly one of --encrypted-data and --in is required",
+					},
+					&cli.StringFlag{
+						Name:  "in",
+						Usage: "Binary ciphertext file written by encrypt --in, decrypted as a stream to --out",
 					},
 					&cli.IntFlag{
 						Name:  "threshold",
@@ -97,9 +134,10 @@ This client can:
 						Value: 0,
 					},
 					&cli.StringFlag{
-						Name:  "output",
-						Usage: "Output file for decrypted data",
-						Value: "",
+						Name:    "output",
+						Aliases: []string{"out"},
+						Usage:   "Output file for decrypted data (required with --in)",
+						Value:   "",
 					},
 					&cli.StringFlag{
 						Name:  "attestation",
@@ -119,9 +157,40 @@ This client can:
 				},
 				Action: decryptCommand,
 			},
+			{
+				Name:  "reencrypt",
+				Usage: "Re-encrypt data to the active master secret generation (or --generation) before the generation it was encrypted to is retired",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:     "app-id",
+						Usage:    "Application ID the data is encrypted for",
+						Required: true,
+					},
+					&cli.StringFlag{
+						Name:  "encrypted-data",
+						Usage: "Encrypted data (hex string) or path to file; exactly one of --encrypted-data and --in is required",
+					},
+					&cli.StringFlag{
+						Name:  "in",
+						Usage: "Binary ciphertext file written by encrypt --in, re-encrypted as a stream to --out",
+					},
+					&cli.IntFlag{
+						Name:  "threshold",
+						Usage: "Number of signatures needed (default: calculated from operators)",
+						Value: 0,
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"out"},
+						Usage:   "Output file for the re-encrypted data (required with --in)",
+						Value:   "",
+					},
+				},
+				Action: reencryptCommand,
+			},
 			{
 				Name:  "get-pubkey",
-				Usage: "Get master public key for an application",
+				Usage: "Get master public key and app signing public key for an application",
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:     "app-id",
@@ -185,9 +254,37 @@ func createClient(c *cli.Context) (*kmsClient.Client, error) {
 	config := &kmsClient.ClientConfig{
 		AVSAddress:     avsAddress,
 		OperatorSetID:  operatorSetID,
+		KeyID:          c.String("key-id"),
 		Logger:         zapLogger,
 		ContractCaller: contractCaller,
 	}
+	if c.IsSet("generation") {
+		generation := uint32(c.Uint("generation"))
+		config.Generation = &generation
+	}
+	if registry := c.String("commitment-registry-address"); registry != "" {
+		if !common.IsHexAddress(registry) {
+			return nil, fmt.Errorf("invalid commitment-registry-address %q", registry)
+		}
+		baseRPCURL := c.String("base-rpc-url")
+		if baseRPCURL == "" {
+			return nil, fmt.Errorf("base-rpc-url is required with commitment-registry-address")
+		}
+		baseClient, err := ethereum.NewEthereumClient(&ethereum.EthereumClientConfig{
+			BaseUrl:   baseRPCURL,
+			BlockType: ethereum.BlockType_Latest,
+		}, zapLogger).GetEthereumContractCaller()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get Base contract caller: %w", err)
+		}
+		registryCaller, err := caller.NewContractCaller(baseClient, nil, zapLogger)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create commitment registry caller: %w", err)
+		}
+		config.CommitmentReader = registryCaller
+		config.CommitmentRegistryAddress = common.HexToAddress(registry)
+	}
+	config.MPKPinFile = c.String("mpk-pin-file")
 
 	client, err := kmsClient.NewClient(config)
 	if err != nil {
@@ -201,8 +298,19 @@ func createClient(c *cli.Context) (*kmsClient.Client, error) {
 func encryptCommand(c *cli.Context) error {
 	appID := c.String("app-id")
 	data := c.String("data")
+	inFile := c.String("in")
 	outputFile := c.String("output")
 
+	if c.IsSet("data") == c.IsSet("in") {
+		return fmt.Errorf("exactly one of --data and --in is required")
+	}
+	if inFile != "" && outputFile == "" {
+		return fmt.Errorf("--out is required with --in")
+	}
+	if inFile != "" && c.Bool("post-quantum") {
+		return fmt.Errorf("--post-quantum is not supported with --in")
+	}
+
 	fmt.Printf("🔐 Encrypting data for app: %s\n", appID)
 
 	// Create KMS client
@@ -217,8 +325,17 @@ func encryptCommand(c *cli.Context) error {
 		return fmt.Errorf("failed to get operators: %w", err)
 	}
 
-	// Encrypt data using IBE
-	encryptedData, err := client.Encrypt(appID, []byte(data), operators)
+	if inFile != "" {
+		return encryptFile(client, appID, inFile, outputFile, operators)
+	}
+
+	// Encrypt data using IBE, hybrid with ML-KEM when asked
+	var encryptedData []byte
+	if c.Bool("post-quantum") {
+		encryptedData, err = client.EncryptPQ(appID, []byte(data), operators)
+	} else {
+		encryptedData, err = client.Encrypt(appID, []byte(data), operators)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to encrypt data: %w", err)
 	}
@@ -246,10 +363,18 @@ func encryptCommand(c *cli.Context) error {
 func decryptCommand(c *cli.Context) error {
 	appID := c.String("app-id")
 	encryptedInput := c.String("encrypted-data")
+	inFile := c.String("in")
 	threshold := c.Int("threshold")
 	outputFile := c.String("output")
 	attestationMethod := c.String("attestation")
 
+	if c.IsSet("encrypted-data") == c.IsSet("in") {
+		return fmt.Errorf("exactly one of --encrypted-data and --in is required")
+	}
+	if inFile != "" && outputFile == "" {
+		return fmt.Errorf("--out is required with --in")
+	}
+
 	// Validate the attestation method up front so a typo fails fast before any
 	// file or network work. Empty selects the legacy no-attestation /app/sign
 	// flow; "ecdsa" is the only attested method meaningful from a CLI
@@ -269,6 +394,10 @@ func decryptCommand(c *cli.Context) error {
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
+	if inFile != "" {
+		return decryptFile(c, client, appID, inFile, outputFile, threshold)
+	}
+
 	// Parse encrypted data (hex string or file) up front — independent of the
 	// attestation path and cheap to fail on.
 	encryptedData, err := parseEncryptedInput(encryptedInput)
@@ -350,36 +479,232 @@ func decryptWithoutAttestation(client *kmsClient.Client, appID string, encrypted
 // decrypts the user-supplied ciphertext with it. Unlike the no-attestation
 // path, this requires the operators to have ECDSA attestation enabled and the
 // app to exist on-chain (the operator fetches the app's release while serving
-// the request).
+// the request). A post-quantum ciphertext also fetches the operators' PQ keys
+// from the attested /v1/app/pq-key endpoint.
 func decryptWithECDSAAttestation(c *cli.Context, client *kmsClient.Client, appID string, encryptedData []byte) ([]byte, error) {
+	isPQ, err := crypto.IsPQCiphertext(encryptedData)
+	if err != nil {
+		return nil, fmt.Errorf("invalid encrypted data: %w", err)
+	}
+
+	// The app private key is that of the generation the data was encrypted to
+	if generation, ok := crypto.CiphertextGeneration(encryptedData); ok {
+		client = client.WithGeneration(generation)
+	}
+
+	appPrivateKey, err := retrieveAppPrivateKeyWithECDSA(c, client, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	var pqKeys map[common.Address]*mlkem.DecapsulationKey768
+	if isPQ {
+		opts, err := ecdsaSecretsOptions(c)
+		if err != nil {
+			return nil, err
+		}
+		pqKeys, err = client.RetrieveAppPQKeys(appID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve PQ keys: %w", err)
+		}
+	}
+
+	decryptedData, err := crypto.DecryptForAppPQ(appID, *appPrivateKey, pqKeys, encryptedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data: %w", err)
+	}
+	return decryptedData, nil
+}
+
+// retrieveAppPrivateKeyWithECDSA recovers the application private key from the
+// attested /secrets endpoint, as decryptWithECDSAAttestation describes.
+func retrieveAppPrivateKeyWithECDSA(c *cli.Context, client *kmsClient.Client, appID string) (*types.G1Point, error) {
+	opts, err := ecdsaSecretsOptions(c)
+	if err != nil {
+		return nil, err
+	}
+	result, err := client.RetrieveSecretsWithOptions(appID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve secrets: %w", err)
+	}
+	return &result.AppPrivateKey, nil
+}
+
+// ecdsaSecretsOptions builds the options of one ECDSA-attested request.
+func ecdsaSecretsOptions(c *cli.Context) (*kmsClient.SecretsOptions, error) {
 	key, err := loadECDSAKey(c.String("ecdsa-private-key"), c.String("ecdsa-private-key-file"))
 	if err != nil {
 		return nil, err
 	}
 
-	// The /secrets endpoint encrypts each partial signature to a per-request
-	// RSA public key. This keypair is transport-level only and never leaves
-	// this process, so we generate an ephemeral one per invocation.
+	// Attested endpoints encrypt their responses to a per-request RSA public
+	// key. This keypair is transport-level only and never leaves this process,
+	// so we generate an ephemeral one per request.
 	rsaPrivPEM, rsaPubPEM, err := encryption.GenerateKeyPair(2048)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate ephemeral RSA key pair: %w", err)
 	}
 
-	result, err := client.RetrieveSecretsWithOptions(appID, &kmsClient.SecretsOptions{
+	return &kmsClient.SecretsOptions{
 		AttestationMethod: "ecdsa",
 		ECDSAPrivateKey:   key,
 		RSAPrivateKeyPEM:  rsaPrivPEM,
 		RSAPublicKeyPEM:   rsaPubPEM,
-	})
+	}, nil
+}
+
+// encryptFile encrypts inFile as a stream into outputFile, a binary chunked
+// ciphertext, so files of any size encrypt without being held in memory.
+func encryptFile(client *kmsClient.Client, appID, inFile, outputFile string, operators *peering.OperatorSetPeers) error {
+	in, err := os.Open(inFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to retrieve secrets: %w", err)
+		return fmt.Errorf("failed to open --in file: %w", err)
 	}
+	defer func() { _ = in.Close() }()
 
-	decryptedData, err := crypto.DecryptForApp(appID, result.AppPrivateKey, encryptedData)
+	cleanPath, err := prepareOutputPath(outputFile)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt data: %w", err)
+		return fmt.Errorf("invalid --out path: %w", err)
+	}
+	var n int64
+	if err := writeSecretFileStream(cleanPath, func(w io.Writer) error {
+		var encErr error
+		n, encErr = client.EncryptStream(appID, w, in, operators)
+		return encErr
+	}); err != nil {
+		return fmt.Errorf("failed to encrypt data: %w", err)
 	}
-	return decryptedData, nil
+
+	fmt.Printf("✅ Encrypted %d bytes written to: %s\n", n, cleanPath)
+	fmt.Fprintf(os.Stderr, "note: output file written with mode 0600 (owner read/write only); verify perms if you chmod it wider\n")
+	return nil
+}
+
+// decryptFile decrypts the chunked ciphertext in inFile as a stream into
+// outputFile, recovering the app private key through the selected attestation
+// path. outputFile is only written once every chunk has authenticated.
+func decryptFile(c *cli.Context, client *kmsClient.Client, appID, inFile, outputFile string, threshold int) error {
+	in, err := os.Open(inFile)
+	if err != nil {
+		return fmt.Errorf("failed to open --in file: %w", err)
+	}
+	defer func() { _ = in.Close() }()
+
+	cleanPath, err := prepareOutputPath(outputFile)
+	if err != nil {
+		return fmt.Errorf("invalid --out path: %w", err)
+	}
+	var n int64
+	if err := writeSecretFileStream(cleanPath, func(w io.Writer) error {
+		if c.String("attestation") == "ecdsa" {
+			br := bufio.NewReader(in)
+			generation, ok, err := crypto.PeekCiphertextGeneration(br)
+			if err != nil {
+				return err
+			}
+			if ok {
+				client = client.WithGeneration(generation)
+			}
+			appPrivateKey, err := retrieveAppPrivateKeyWithECDSA(c, client, appID)
+			if err != nil {
+				return err
+			}
+			r, err := crypto.NewDecryptReader(br, appID, *appPrivateKey)
+			if err != nil {
+				return err
+			}
+			n, err = io.Copy(w, r)
+			return err
+		}
+
+		operators, err := client.GetOperators()
+		if err != nil {
+			return fmt.Errorf("failed to get operators: %w", err)
+		}
+		n, err = client.DecryptStream(appID, w, in, operators, threshold)
+		return err
+	}); err != nil {
+		return fmt.Errorf("failed to decrypt data: %w", err)
+	}
+
+	fmt.Printf("✅ Decrypted %d bytes written to: %s\n", n, cleanPath)
+	fmt.Fprintf(os.Stderr, "note: output file written with mode 0600 (owner read/write only); verify perms if you chmod it wider\n")
+	return nil
+}
+
+// reencryptCommand handles the reencrypt subcommand. It decrypts through the
+// unauthenticated /app/sign endpoint, like decrypt without --attestation.
+func reencryptCommand(c *cli.Context) error {
+	appID := c.String("app-id")
+	encryptedInput := c.String("encrypted-data")
+	inFile := c.String("in")
+	threshold := c.Int("threshold")
+	outputFile := c.String("output")
+
+	if c.IsSet("encrypted-data") == c.IsSet("in") {
+		return fmt.Errorf("exactly one of --encrypted-data and --in is required")
+	}
+	if inFile != "" && outputFile == "" {
+		return fmt.Errorf("--out is required with --in")
+	}
+
+	fmt.Printf("🔁 Re-encrypting data for app: %s\n", appID)
+
+	client, err := createClient(c)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	operators, err := client.GetOperators()
+	if err != nil {
+		return fmt.Errorf("failed to get operators: %w", err)
+	}
+
+	if inFile != "" {
+		in, err := os.Open(inFile)
+		if err != nil {
+			return fmt.Errorf("failed to open --in file: %w", err)
+		}
+		defer func() { _ = in.Close() }()
+
+		cleanPath, err := prepareOutputPath(outputFile)
+		if err != nil {
+			return fmt.Errorf("invalid --out path: %w", err)
+		}
+		var n int64
+		if err := writeSecretFileStream(cleanPath, func(w io.Writer) error {
+			var reErr error
+			n, reErr = client.ReencryptStream(appID, w, in, operators, threshold)
+			return reErr
+		}); err != nil {
+			return fmt.Errorf("failed to re-encrypt data: %w", err)
+		}
+		fmt.Printf("✅ Re-encrypted %d bytes written to: %s\n", n, cleanPath)
+		return nil
+	}
+
+	encryptedData, err := parseEncryptedInput(encryptedInput)
+	if err != nil {
+		return err
+	}
+	reencrypted, err := client.Reencrypt(appID, encryptedData, operators, threshold)
+	if err != nil {
+		return fmt.Errorf("failed to re-encrypt data: %w", err)
+	}
+
+	reencryptedHex := hexutil.Encode(reencrypted)
+	if outputFile != "" {
+		cleanPath, pathErr := prepareOutputPath(outputFile)
+		if pathErr != nil {
+			return fmt.Errorf("invalid --output path: %w", pathErr)
+		}
+		if err := writeSecretFile(cleanPath, []byte(reencryptedHex)); err != nil {
+			return fmt.Errorf("failed to write to file: %w", err)
+		}
+		fmt.Printf("✅ Re-encrypted data written to: %s\n", cleanPath)
+	} else {
+		fmt.Printf("✅ Re-encrypted data: %s\n", reencryptedHex)
+	}
+	return nil
 }
 
 // getPubkeyCommand handles the get-pubkey subcommand
@@ -404,6 +729,13 @@ func getPubkeyCommand(c *cli.Context) error {
 	fmt.Printf("✅ Master Public Key:\n")
 	fmt.Printf("  %s\n", hex.EncodeToString(masterPubKey.CompressedBytes))
 
+	signingPubKey, err := crypto.AppSigningPublicKey(appID, *masterPubKey)
+	if err != nil {
+		return fmt.Errorf("failed to derive app signing public key: %w", err)
+	}
+	fmt.Printf("✅ App Signing Public Key (message signatures verify against this):\n")
+	fmt.Printf("  %s\n", hex.EncodeToString(signingPubKey.CompressedBytes))
+
 	return nil
 }
 
@@ -438,6 +770,31 @@ func writeSecretFile(path string, data []byte) (err error) {
 	return nil
 }
 
+// writeSecretFileStream writes what write produces to path with mode 0600, like
+// writeSecretFile, but through a temporary file in the same directory that only
+// replaces path once write succeeds. A stream that fails partway, such as a
+// ciphertext whose later chunks do not authenticate, leaves nothing at path.
+func writeSecretFileStream(path string, write func(io.Writer) error) (err error) {
+	// CreateTemp creates the file with mode 0600, and the rename keeps it
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+	if err := write(tmp); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
 // prepareOutputPath cleans and absolutizes a user-supplied output path. It
 // rejects empty paths and paths that resolve to a directory (no file name).
 // The path root is intentionally not restricted: this is a CLI, the user
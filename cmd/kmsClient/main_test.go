@@ -2,6 +2,8 @@ package main
 
 import (
 	"encoding/hex"
+	"errors"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
@@ -107,6 +109,40 @@ func TestWriteSecretFile(t *testing.T) {
 	})
 }
 
+func TestWriteSecretFileStream(t *testing.T) {
+	t.Run("replaces file with mode 0600 on success", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "secret.bin")
+		require.NoError(t, os.WriteFile(path, []byte("stale"), 0644))
+
+		require.NoError(t, writeSecretFileStream(path, func(w io.Writer) error {
+			_, err := w.Write([]byte("fresh"))
+			return err
+		}))
+
+		fi, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0600), fi.Mode().Perm())
+		got, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, []byte("fresh"), got)
+	})
+
+	t.Run("leaves nothing behind on failure", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "secret.bin")
+
+		err := writeSecretFileStream(path, func(w io.Writer) error {
+			_, _ = w.Write([]byte("partial plaintext"))
+			return errors.New("chunk 3 did not authenticate")
+		})
+		require.ErrorContains(t, err, "chunk 3")
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+}
+
 func TestPrepareOutputPath(t *testing.T) {
 	cwd, err := os.Getwd()
 	require.NoError(t, err)
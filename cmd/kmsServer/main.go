@@ -17,19 +17,18 @@ import (
 	"github.com/Layr-Labs/chain-indexer/pkg/contracts"
 	"github.com/Layr-Labs/chain-indexer/pkg/transactionLogParser"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/attestation"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/authz"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/blockHandler"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/clients/web3signer"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller/caller"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/logger"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/metrics"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/node"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering/peeringDataFetcher"
-	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
-	persistenceBadger "github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/badger"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/chainpolleradapter"
-	persistenceMemory "github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
-	persistenceRedis "github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/redis"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/registrarabi"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/tracing"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/transactionSigner"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/transportSigner"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/transportSigner/inMemoryTransportSigner"
@@ -136,6 +135,11 @@ This server implements:
 				Value:   0,
 				EnvVars: []string{config.EnvKMSOperatorSetID},
 			},
+			&cli.StringSliceFlag{
+				Name:    "key",
+				Usage:   "Serve a master key as '<key-id>:<operator-set-id>', under /v1/keys/<key-id>/. Apps are bound to a key by their AppController operator set. Can be specified multiple times; empty = a single default key for --operator-set-id.",
+				EnvVars: []string{config.EnvKMSKeys},
+			},
 			&cli.BoolFlag{
 				Name:    "verbose",
 				Usage:   "Enable verbose logging",
@@ -193,6 +197,47 @@ This server implements:
 				Usage:   "Custom prefix for Redis keys (for multi-tenant setups)",
 				EnvVars: []string{config.EnvKMSRedisKeyPrefix},
 			},
+			// Encryption at rest configuration
+			&cli.StringFlag{
+				Name:    "kek-source",
+				Usage:   "Key-encryption key for persisted key shares: 'keyfile', 'aws-kms', or 'passphrase' (empty = shares stored in plaintext)",
+				EnvVars: []string{config.EnvKMSKEKSource},
+			},
+			&cli.StringFlag{
+				Name:    "kek-keyfile",
+				Usage:   "File holding a 32-byte KEK (raw or hex) for --kek-source=keyfile",
+				EnvVars: []string{config.EnvKMSKEKKeyfile},
+			},
+			&cli.StringFlag{
+				Name:    "kek-passphrase",
+				Usage:   "Passphrase the KEK is derived from (Argon2id) for --kek-source=passphrase. Prefer the environment variable.",
+				EnvVars: []string{config.EnvKMSKEKPassphrase},
+			},
+			&cli.StringFlag{
+				Name:    "kek-aws-kms-key-id",
+				Usage:   "AWS KMS key ID, ARN or alias for --kek-source=aws-kms",
+				EnvVars: []string{config.EnvKMSKEKAWSKMSKeyID},
+			},
+			&cli.StringFlag{
+				Name:    "kek-aws-kms-endpoint",
+				Usage:   "Override the AWS KMS endpoint (for KMS-compatible services)",
+				EnvVars: []string{config.EnvKMSKEKAWSKMSEndpoint},
+			},
+			&cli.StringFlag{
+				Name:    "kek-aws-region",
+				Usage:   "AWS region for --kek-source=aws-kms (defaults to the AWS SDK region)",
+				EnvVars: []string{config.EnvKMSKEKAWSRegion},
+			},
+			&cli.StringSliceFlag{
+				Name:    "kek-previous",
+				Usage:   "Retired KEK still needed to read records until re-wrapped: 'keyfile:<path>', 'aws-kms:<key-id>' or 'passphrase-env:<ENV_VAR>'. Can be specified multiple times.",
+				EnvVars: []string{config.EnvKMSKEKPrevious},
+			},
+			&cli.BoolFlag{
+				Name:    "kek-migrate-plaintext",
+				Usage:   "Seal key shares stored in plaintext before --kek-source was set. One-time: without it plaintext records are rejected, so unset it once the migration has run.",
+				EnvVars: []string{config.EnvKMSKEKMigratePlaintext},
+			},
 			// Attestation configuration
 			&cli.StringFlag{
 				Name:    "gcp-project-id",
@@ -239,13 +284,134 @@ This server implements:
 				Usage:   "Accepted SEV-SNP MEASUREMENT (48-byte hex) to pin. On AWS this pins OVMF firmware version + vCPU shape, NOT image identity. Repeatable; empty = not enforced.",
 				EnvVars: []string{config.EnvKMSEigenXSNPMeasurements},
 			},
+			&cli.StringSliceFlag{
+				Name:    "eigenx-snp-policy-rules",
+				Usage:   "Accepted SHA-256 (hex) of the kata genpolicy rules in cc_init_data's policy.rego, everything before the policy_data line. Enables ContainerPolicy enforcement for eigenx-snp and rejects documents with other rules. Repeatable; empty = releases pinning a ContainerPolicy are refused.",
+				EnvVars: []string{config.EnvKMSEigenXSNPPolicyRules},
+			},
+			&cli.BoolFlag{
+				Name:    "enable-eigenx-tdx-attestation",
+				Usage:   "Enable raw Intel TDX quote attestation (verifies Intel chain + supplied PCS collateral)",
+				Value:   false,
+				EnvVars: []string{config.EnvKMSEnableEigenXTDXAttestation},
+			},
+			&cli.StringSliceFlag{
+				Name:    "eigenx-tdx-measurement",
+				Usage:   "Accepted TD boot state <mrtd>:<rtmr0>:<rtmr1>:<rtmr2> (48-byte hex each) to accept. Repeatable; required with --enable-eigenx-tdx-attestation.",
+				EnvVars: []string{config.EnvKMSEigenXTDXMeasurements},
+			},
+			&cli.BoolFlag{
+				Name:    "enable-nitro-attestation",
+				Usage:   "Enable AWS Nitro Enclaves attestation documents (verifies the AWS Nitro root chain + PCR0 image)",
+				Value:   false,
+				EnvVars: []string{config.EnvKMSEnableNitroAttestation},
+			},
 			&cli.StringSliceFlag{
 				Name:    "app-allowlist",
 				Usage:   "Restrict /app/sign and /secrets to these app IDs (empty = allow all). Can be specified multiple times.",
 				EnvVars: []string{config.EnvKMSAppAllowlist},
 			},
+			&cli.StringFlag{
+				Name:    "authz-policy-file",
+				Usage:   "YAML file of CEL authorization rules for /secrets, run after the default release rules unless it sets replace_default_rules; re-read while running (empty = default rules only)",
+				EnvVars: []string{config.EnvKMSAuthzPolicyFile},
+			},
+			&cli.DurationFlag{
+				Name:    "authz-policy-reload-interval",
+				Usage:   "How often the authorization policy file is re-read (0 = 10s)",
+				EnvVars: []string{config.EnvKMSAuthzPolicyReloadInterval},
+			},
+			&cli.StringFlag{
+				Name:    "metrics-address",
+				Usage:   "host:port for the Prometheus /metrics listener, separate from --port (e.g. 127.0.0.1:9090). Empty disables metrics.",
+				EnvVars: []string{config.EnvKMSMetricsAddress},
+			},
+			&cli.DurationFlag{
+				Name:    "key-retention-max-age",
+				Usage:   "Oldest attestation time /secrets and /app/sign serve; key versions no attestation time in this window resolves to are deleted (0 = keep all)",
+				EnvVars: []string{config.EnvKMSKeyRetentionMaxAge},
+			},
+			&cli.IntFlag{
+				Name:    "key-retention-max-versions",
+				Usage:   "Key versions kept per master secret generation, newest first (0 = no limit)",
+				EnvVars: []string{config.EnvKMSKeyRetentionMaxVersions},
+			},
+			&cli.BoolFlag{
+				Name:    "key-retention-dry-run",
+				Usage:   "Log the key versions the retention policy would delete without deleting them",
+				EnvVars: []string{config.EnvKMSKeyRetentionDryRun},
+			},
+			&cli.StringFlag{
+				Name:    "admin-socket",
+				Usage:   "Unix socket path for the admin control API, readable only by the server's user (e.g. /run/kms/admin.sock). Empty disables it unless --admin-address is set.",
+				EnvVars: []string{config.EnvKMSAdminSocket},
+			},
+			&cli.StringFlag{
+				Name:    "admin-address",
+				Usage:   "host:port for the admin control API over mutual TLS, instead of --admin-socket. Requires --admin-tls-cert, --admin-tls-key and --admin-tls-client-ca.",
+				EnvVars: []string{config.EnvKMSAdminAddress},
+			},
+			&cli.StringFlag{
+				Name:    "admin-tls-cert",
+				Usage:   "PEM certificate the admin listener presents",
+				EnvVars: []string{config.EnvKMSAdminTLSCert},
+			},
+			&cli.StringFlag{
+				Name:    "admin-tls-key",
+				Usage:   "PEM private key of --admin-tls-cert",
+				EnvVars: []string{config.EnvKMSAdminTLSKey},
+			},
+			&cli.StringFlag{
+				Name:    "admin-tls-client-ca",
+				Usage:   "PEM CA that admin client certificates must be signed by",
+				EnvVars: []string{config.EnvKMSAdminTLSClientCA},
+			},
+			&cli.StringFlag{
+				Name:    "otlp-endpoint",
+				Usage:   "host:port of the OTLP collector to export protocol traces to (e.g. otel-collector:4317). Empty disables tracing.",
+				EnvVars: []string{config.EnvKMSOTLPEndpoint},
+			},
+			&cli.StringFlag{
+				Name:    "otlp-protocol",
+				Usage:   "OTLP transport for --otlp-endpoint: grpc (default) or http",
+				EnvVars: []string{config.EnvKMSOTLPProtocol},
+			},
+			&cli.BoolFlag{
+				Name:    "otlp-insecure",
+				Usage:   "Export traces to --otlp-endpoint without TLS",
+				EnvVars: []string{config.EnvKMSOTLPInsecure},
+			},
 		},
 		Action: runKMSServer,
+		Commands: []*cli.Command{
+			{
+				Name:  "rewrap-kek",
+				Usage: "Re-wrap shares under previous KEKs with the current KEK, and seal plaintext shares with --kek-migrate-plaintext",
+				Description: `Completes a KEK rotation: configure the new KEK with --kek-source and list the
+old one under --kek-previous, then run this command. With --kek-migrate-plaintext
+it also seals the records of a store written before encryption at rest was
+enabled. Uses the same persistence and KEK flags as the server. Safe against a
+live Redis-backed node; a Badger database is locked by a running node, which
+performs the same pass on startup.`,
+				Action: runRewrapKEK,
+			},
+			{
+				Name:  "prune-key-versions",
+				Usage: "Delete persisted key versions outside the key retention policy and print a report",
+				Description: `Applies --key-retention-max-age and --key-retention-max-versions to every key's
+persisted versions. The active, pending and last-known-good versions are never
+deleted. With --dry-run (or --key-retention-dry-run) it only prints the report.
+Uses the same persistence and KEK flags as the server; a running node prunes its
+own versions at every reshare boundary.`,
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "Print what would be deleted without deleting anything",
+					},
+				},
+				Action: runPruneKeyVersions,
+			},
+		},
 	}
 
 	if err := app.Run(os.Args); err != nil {
@@ -277,15 +443,22 @@ func runKMSServer(c *cli.Context) error {
 
 	l.Sugar().Infow("Using chain", "name", kmsConfig.ChainName, "chain_id", kmsConfig.ChainID)
 
-	// Create node config from KMS config (operators fetched dynamically when needed)
-	nodeConfig := node.Config{
-		OperatorAddress: kmsConfig.OperatorAddress,
-		Port:            kmsConfig.Port,
-		ChainID:         kmsConfig.ChainID,
-		AVSAddress:      kmsConfig.AVSAddress,
-		OperatorSetId:   kmsConfig.OperatorSetId,
-		AppAllowlist:    kmsConfig.AppAllowlist,
+	// Metrics are only collected when a listener is configured to serve them.
+	var kmsMetrics *metrics.Metrics
+	if kmsConfig.MetricsAddress != "" {
+		kmsMetrics = metrics.NewMetrics()
+	}
+
+	// Spans are only recorded when a collector is configured to export them to.
+	shutdownTracing, err := tracing.Setup(c.Context, kmsConfig.Tracing, kmsConfig.OperatorAddress)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %w", err)
 	}
+	defer func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = shutdownTracing(ctx)
+	}()
 
 	// Create Ethereum client
 	ethClient := ethereum.NewEthereumClient(&ethereum.EthereumClientConfig{
@@ -310,8 +483,6 @@ func runKMSServer(c *cli.Context) error {
 		l.Sugar().Fatalw("Failed to get Base contract caller", "error", err)
 	}
 
-	bh := blockHandler.NewBlockHandler(l)
-
 	// Create transport signer based on OperatorConfig
 	var transportSignerInstance transportSigner.ITransportSigner
 	var transactionSignerInstance transactionSigner.ITransactionSigner
@@ -373,10 +544,12 @@ func runKMSServer(c *cli.Context) error {
 	enableECDSA := c.Bool("enable-ecdsa-attestation")
 	enableTPM := c.Bool("enable-tpm-attestation")
 	enableEigenXSNP := c.Bool("enable-eigenx-snp-attestation")
+	enableEigenXTDX := c.Bool("enable-eigenx-tdx-attestation")
+	enableNitro := c.Bool("enable-nitro-attestation")
 
 	// Validate at least one method is enabled
-	if !enableGCP && !enableECDSA && !enableTPM && !enableEigenXSNP {
-		return fmt.Errorf("at least one attestation method must be enabled (--enable-gcp-attestation, --enable-ecdsa-attestation, --enable-tpm-attestation, or --enable-eigenx-snp-attestation)")
+	if !enableGCP && !enableECDSA && !enableTPM && !enableEigenXSNP && !enableEigenXTDX && !enableNitro {
+		return fmt.Errorf("at least one attestation method must be enabled (--enable-gcp-attestation, --enable-ecdsa-attestation, --enable-tpm-attestation, --enable-eigenx-snp-attestation, --enable-eigenx-tdx-attestation, or --enable-nitro-attestation)")
 	}
 
 	// Create slog logger for attestation
@@ -489,6 +662,24 @@ func runKMSServer(c *cli.Context) error {
 				"count", len(measurements))
 		}
 
+		// Optional genpolicy rules pin: the rules are what make kata-agent
+		// enforce policy_data, so the container launch spec is only surfaced
+		// from documents whose rules are listed.
+		if rulesHex := c.StringSlice("eigenx-snp-policy-rules"); len(rulesHex) > 0 {
+			digests := make([][]byte, 0, len(rulesHex))
+			for _, h := range rulesHex {
+				b, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(h), "0x"))
+				if err != nil {
+					return fmt.Errorf("invalid --eigenx-snp-policy-rules %q: %w", h, err)
+				}
+				digests = append(digests, b)
+			}
+			if err := eigenXSNPMethod.SetPolicyRulesAllowlist(digests); err != nil {
+				return fmt.Errorf("set eigenx-snp policy rules allowlist: %w", err)
+			}
+			l.Sugar().Infow("eigenx-snp ContainerPolicy enforcement enabled", "policy_rules", len(digests))
+		}
+
 		if err := attestationManager.RegisterMethod(eigenXSNPMethod); err != nil {
 			return fmt.Errorf("failed to register eigenx-snp attestation method: %w", err)
 		}
@@ -497,12 +688,78 @@ func runKMSServer(c *cli.Context) error {
 			"method_name", eigenXSNPMethod.Name())
 	}
 
+	// Register eigenx-tdx attestation if enabled
+	if enableEigenXTDX {
+		// Intel PCS collateral (TCB info, QE identity, CRLs) travels in the
+		// evidence and is verified against the Intel SGX root, so /secrets never
+		// waits on a PCS round-trip.
+		eigenXTDXMethod := attestation.NewEigenXTDXAttestationMethod(slogger)
+
+		// Boot pin: MRTD and RTMR[0..2] cover the TD firmware, kernel and
+		// command line that later extend RTMR[3] with the image. Without it the
+		// method rejects every quote, so refuse to start instead.
+		bootStates := c.StringSlice("eigenx-tdx-measurement")
+		if len(bootStates) == 0 {
+			return fmt.Errorf("--enable-eigenx-tdx-attestation requires at least one --eigenx-tdx-measurement")
+		}
+		measurements := make([]attestation.TDXBootMeasurement, 0, len(bootStates))
+		for _, s := range bootStates {
+			bm, err := attestation.ParseTDXBootMeasurement(s)
+			if err != nil {
+				return fmt.Errorf("invalid --eigenx-tdx-measurement %q: %w", s, err)
+			}
+			measurements = append(measurements, bm)
+		}
+		if err := eigenXTDXMethod.SetBootMeasurementAllowlist(measurements); err != nil {
+			return fmt.Errorf("set eigenx-tdx boot measurement allowlist: %w", err)
+		}
+		l.Sugar().Infow("eigenx-tdx boot measurement pin enabled",
+			"count", len(measurements))
+
+		if err := attestationManager.RegisterMethod(eigenXTDXMethod); err != nil {
+			return fmt.Errorf("failed to register eigenx-tdx attestation method: %w", err)
+		}
+
+		l.Sugar().Infow("eigenx-tdx attestation method enabled",
+			"method_name", eigenXTDXMethod.Name())
+	}
+
+	// Register Nitro attestation if enabled
+	if enableNitro {
+		nitroMethod := attestation.NewNitroAttestationMethod(slogger)
+		if err := attestationManager.RegisterMethod(nitroMethod); err != nil {
+			return fmt.Errorf("failed to register nitro attestation method: %w", err)
+		}
+
+		l.Sugar().Infow("Nitro attestation method enabled",
+			"method_name", nitroMethod.Name())
+	}
+
 	// Log summary of enabled methods
 	enabledMethods := attestationManager.ListMethods()
 	l.Sugar().Infow("Attestation manager initialized",
 		"enabled_methods", enabledMethods,
 		"method_count", len(enabledMethods))
 
+	// Operator authorization rules, shared by every key. A policy file that does not
+	// load stops startup; later edits are picked up while running.
+	var authzPolicy *authz.Engine
+	if kmsConfig.Authz.Enabled() {
+		authzPolicy, err = authz.NewEngine(kmsConfig.Authz.PolicyFile, l)
+		if err != nil {
+			return fmt.Errorf("failed to load authorization policy: %w", err)
+		}
+		reloadInterval := kmsConfig.Authz.ReloadInterval
+		if reloadInterval == 0 {
+			reloadInterval = config.DefaultAuthzPolicyReloadInterval
+		}
+		go authzPolicy.Watch(c.Context, reloadInterval)
+		l.Sugar().Infow("Authorization policy loaded",
+			"path", authzPolicy.Path(),
+			"rules", authzPolicy.Policy().Rules(),
+			"reload_interval", reloadInterval)
+	}
+
 	baseContractCaller, err := caller.NewContractCaller(l2Client, transactionSignerInstance, l)
 	if err != nil {
 		l.Sugar().Fatalw("Failed to create Base contract caller", "error", err)
@@ -518,7 +775,7 @@ func runKMSServer(c *cli.Context) error {
 	// app's on-chain release. The handler reads releases via baseContractCaller, but
 	// the AppController contract lives on L1 — so bind the adapter to the L1 client
 	// and inject it into baseContractCaller. Without this, /secrets fails every app
-	// with "appController not initialized" (404 Release not found). The address is
+	// with "appController not initialized" (denied by the release-required rule). The address is
 	// optional config: if unset, /secrets release lookups stay disabled (e.g. for a
 	// signing-only deployment).
 	if appControllerAddr := c.String("app-controller-address"); appControllerAddr != "" {
@@ -538,6 +795,10 @@ func runKMSServer(c *cli.Context) error {
 		// block-timestamp lookup in resolveLatestRelease.
 		baseContractCaller.SetAppControllerBlockClient(l1Client)
 		l.Sugar().Infow("AppController wired for /secrets release resolution", "address", addr.Hex())
+	} else if len(kmsConfig.Keys) > 0 {
+		// Apps are bound to keys through the AppController; without it every
+		// request would fail the binding check.
+		return fmt.Errorf("--key requires --app-controller-address")
 	} else {
 		l.Sugar().Warn("KMS_APP_CONTROLLER_ADDRESS not set — /secrets on-chain release resolution disabled")
 	}
@@ -551,54 +812,6 @@ func runKMSServer(c *cli.Context) error {
 		"base_rpc_url", kmsConfig.BaseRpcUrl,
 		"commitment_registry_address", commitmentRegistryAddr.Hex())
 
-	// Create node persistence layer based on configuration
-	var nodePersistence persistence.INodePersistence
-	switch kmsConfig.PersistenceConfig.Type {
-	case "badger":
-		var err error
-		nodePersistence, err = persistenceBadger.NewBadgerPersistence(
-			kmsConfig.PersistenceConfig.DataPath,
-			l,
-		)
-		if err != nil {
-			l.Sugar().Fatalw("Failed to create Badger persistence", "error", err)
-		}
-		l.Sugar().Infow("Using Badger persistence",
-			"path", kmsConfig.PersistenceConfig.DataPath)
-	case "redis":
-		var err error
-		nodePersistence, err = persistenceRedis.NewRedisPersistence(
-			&persistenceRedis.RedisConfig{
-				Address:   kmsConfig.PersistenceConfig.RedisConfig.Address,
-				Password:  kmsConfig.PersistenceConfig.RedisConfig.Password,
-				DB:        kmsConfig.PersistenceConfig.RedisConfig.DB,
-				KeyPrefix: kmsConfig.PersistenceConfig.RedisConfig.KeyPrefix,
-			},
-			l,
-		)
-		if err != nil {
-			l.Sugar().Fatalw("Failed to create Redis persistence", "error", err)
-		}
-		logFields := []interface{}{
-			"address", kmsConfig.PersistenceConfig.RedisConfig.Address,
-			"db", kmsConfig.PersistenceConfig.RedisConfig.DB,
-		}
-		if kmsConfig.PersistenceConfig.RedisConfig.KeyPrefix != "" {
-			logFields = append(logFields, "key_prefix", kmsConfig.PersistenceConfig.RedisConfig.KeyPrefix)
-		}
-		l.Sugar().Infow("Using Redis persistence", logFields...)
-	default:
-		nodePersistence = persistenceMemory.NewMemoryPersistence()
-		l.Sugar().Warn("⚠️  Using in-memory persistence - data will be lost on restart")
-	}
-
-	defer func() { _ = nodePersistence.Close() }()
-
-	// Health check persistence
-	if err := nodePersistence.HealthCheck(); err != nil {
-		l.Sugar().Fatalw("Persistence health check failed", "error", err)
-	}
-
 	// Resolve the EigenKMSRegistrar address on L1 so the chain poller can fetch and
 	// decode its logs (notably AvsConfigSet). The registrar is deployed on the L1
 	// chain (the --rpc-url chain that the poller runs against) and is resolved via
@@ -626,42 +839,108 @@ func runKMSServer(c *cli.Context) error {
 	cs := inMemoryContractStore.NewInMemoryContractStore([]*contracts.Contract{registrarContract}, l)
 	logParser := transactionLogParser.NewTransactionLogParser(cs, l)
 
-	// Durable poller persistence over the node's INodePersistence (R1 adapter), so
-	// the last-processed block survives restarts.
-	pollerStore := chainpolleradapter.NewChainPollerPersistenceAdapter(nodePersistence)
-
-	poller, err := EVMChainPoller.NewEVMChainPoller(
-		ethClient,
-		logParser,
-		&EVMChainPoller.EVMChainPollerConfig{
-			ChainId:              chainIndexerConfig.ChainId(kmsConfig.ChainID),
-			PollingInterval:      config.GetDefaultPollerIntervalForChainId(kmsConfig.ChainID),
-			InterestingContracts: []string{registrarAddr.Hex()},
-			AvsAddress:           kmsConfig.AVSAddress,
-		},
-		pollerStore, bh, l)
-	if err != nil {
-		l.Sugar().Fatalw("Failed to create EVM chain poller", "error", err)
-	}
-
-	// Create and configure the node with attestation manager
-	n, err := node.NewNode(
-		nodeConfig,
-		pdf,
-		bh,
-		poller,
-		transportSignerInstance,
-		attestationManager,
-		baseContractCaller,
-		// platformConfigCaller: the EigenKMSRegistrar (and its AvsConfig) lives on L1, the
-		// same chain the AvsConfigSet event logs come from, so the startup seed must read L1.
-		l1ContractCaller,
-		commitmentRegistryAddr,
-		nodePersistence,
-		l,
-	)
+	// Each key is held by its own node, with its own persistence namespace, block
+	// handler and poller; the nodes share the signer, contract callers and listener.
+	keys := kmsConfig.EffectiveKeys()
+	nodes := make([]*node.Node, 0, len(keys))
+	blockHandlers := make([]*blockHandler.BlockHandler, 0, len(keys))
+	for _, key := range keys {
+		// Create node persistence layer based on configuration
+		nodePersistence, err := newNodePersistence(ctx, kmsConfig.PersistenceConfig.ForKey(key.ID), kmsConfig.OperatorAddress, key.ID, l)
+		if err != nil {
+			l.Sugar().Fatalw("Failed to create persistence", "key_id", key.ID, "error", err)
+		}
+
+		defer func() { _ = nodePersistence.Close() }()
+
+		// Health check persistence
+		if err := nodePersistence.HealthCheck(); err != nil {
+			l.Sugar().Fatalw("Persistence health check failed", "key_id", key.ID, "error", err)
+		}
+
+		// Finish any pending KEK rotation, and the one-time plaintext migration when
+		// requested, before the node loads its key shares.
+		if migrated, rewrapped, err := sealPersistedRecords(nodePersistence, kmsConfig.PersistenceConfig.Encryption); err != nil {
+			l.Sugar().Fatalw("Failed to update encrypted records", "key_id", key.ID, "error", err)
+		} else if migrated > 0 || rewrapped > 0 {
+			l.Sugar().Infow("Updated encrypted records", "key_id", key.ID, "sealed_plaintext", migrated, "rewrapped", rewrapped)
+		}
+
+		// The block handler delivers each log to a single consumer, so every node
+		// gets its own handler and poller.
+		bh := blockHandler.NewBlockHandler(l)
+		blockHandlers = append(blockHandlers, bh)
+
+		// Durable poller persistence over the node's INodePersistence (R1 adapter), so
+		// the last-processed block survives restarts.
+		pollerStore := chainpolleradapter.NewChainPollerPersistenceAdapter(nodePersistence)
+
+		poller, err := EVMChainPoller.NewEVMChainPoller(
+			ethClient,
+			logParser,
+			&EVMChainPoller.EVMChainPollerConfig{
+				ChainId:              chainIndexerConfig.ChainId(kmsConfig.ChainID),
+				PollingInterval:      config.GetDefaultPollerIntervalForChainId(kmsConfig.ChainID),
+				InterestingContracts: []string{registrarAddr.Hex()},
+				AvsAddress:           kmsConfig.AVSAddress,
+			},
+			pollerStore, bh, l)
+		if err != nil {
+			l.Sugar().Fatalw("Failed to create EVM chain poller", "key_id", key.ID, "error", err)
+		}
+
+		// Create node config from KMS config (operators fetched dynamically when needed)
+		nodeConfig := node.Config{
+			OperatorAddress: kmsConfig.OperatorAddress,
+			Port:            kmsConfig.Port,
+			ChainID:         kmsConfig.ChainID,
+			AVSAddress:      kmsConfig.AVSAddress,
+			OperatorSetId:   key.OperatorSetId,
+			KeyID:           key.ID,
+			AppAllowlist:    kmsConfig.AppAllowlist,
+			BindAppsToKey:   len(kmsConfig.Keys) > 0,
+			SharedListener:  true,
+			Metrics:         kmsMetrics,
+			Retention:       kmsConfig.Retention,
+			AuthzPolicy:     authzPolicy,
+		}
+
+		// Create and configure the node with attestation manager
+		n, err := node.NewNode(
+			nodeConfig,
+			pdf,
+			bh,
+			poller,
+			transportSignerInstance,
+			attestationManager,
+			baseContractCaller,
+			// platformConfigCaller: the EigenKMSRegistrar (and its AvsConfig) lives on L1, the
+			// same chain the AvsConfigSet event logs come from, so the startup seed must read L1.
+			l1ContractCaller,
+			commitmentRegistryAddr,
+			nodePersistence,
+			l,
+		)
+		if err != nil {
+			l.Sugar().Fatalw("Failed to create node", "key_id", key.ID, "error", err)
+		}
+		nodes = append(nodes, n)
+	}
+
+	// One counter for the process: the block handlers cannot each register it.
+	if err := kmsMetrics.RegisterDroppedLogCount(func() uint64 {
+		var total uint64
+		for _, bh := range blockHandlers {
+			total += bh.DroppedLogCount()
+		}
+		return total
+	}); err != nil {
+		return fmt.Errorf("failed to register block handler metrics: %w", err)
+	}
+
+	router, err := node.NewKeyRouter(kmsConfig.Port, nodes, l)
 	if err != nil {
-		l.Sugar().Fatalw("Failed to create node", "error", err)
+		return fmt.Errorf("failed to create key router: %w", err)
 	}
 
 	if c.Bool("verbose") {
@@ -676,8 +955,32 @@ func runKMSServer(c *cli.Context) error {
 	// Start the node server
 	l.Sugar().Infow("Starting KMS Server", "operator_address", kmsConfig.OperatorAddress, "port", kmsConfig.Port)
 
-	if err := n.Start(); err != nil {
-		return fmt.Errorf("failed to start node: %w", err)
+	for _, n := range nodes {
+		if err := n.Start(); err != nil {
+			return fmt.Errorf("failed to start node for key %q: %w", n.KeyID, err)
+		}
+	}
+	if err := router.Start(); err != nil {
+		return fmt.Errorf("failed to start HTTP server: %w", err)
+	}
+
+	if kmsMetrics != nil {
+		metricsServer := metrics.NewServer(kmsConfig.MetricsAddress, kmsMetrics, l)
+		if err := metricsServer.Start(); err != nil {
+			return fmt.Errorf("failed to start metrics server: %w", err)
+		}
+		defer func() { _ = metricsServer.Stop() }()
+	}
+
+	if kmsConfig.Admin.Enabled() {
+		adminServer, err := node.NewAdminServer(kmsConfig.Admin, nodes, l)
+		if err != nil {
+			return fmt.Errorf("failed to create admin server: %w", err)
+		}
+		if err := adminServer.Start(); err != nil {
+			return fmt.Errorf("failed to start admin server: %w", err)
+		}
+		defer func() { _ = adminServer.Stop() }()
 	}
 
 	// Node scheduler handles DKG and reshare automatically based on config
@@ -686,7 +989,9 @@ func runKMSServer(c *cli.Context) error {
 		"secrets", "POST /secrets",
 		"app_sign", "POST /app/sign",
 		"dkg", "POST /dkg/*",
-		"reshare", "POST /reshare/*")
+		"reshare", "POST /reshare/*",
+		"health", "GET /healthz, GET /readyz",
+		"keys", "GET /v1/keys, /v1/keys/{id}/*")
 	l.Sugar().Info("Press Ctrl+C to stop")
 
 	// Keep the server running
@@ -741,6 +1046,29 @@ func parseKMSConfig(c *cli.Context) (*config.KMSServerConfig, error) {
 		}
 	}
 
+	// Add encryption config if a KEK is configured
+	if kekSource := c.String("kek-source"); kekSource != "" {
+		persistenceConfig.Encryption = &config.EncryptionConfig{
+			KEKSource:        kekSource,
+			KeyfilePath:      c.String("kek-keyfile"),
+			Passphrase:       c.String("kek-passphrase"),
+			AWSKMSKeyID:      c.String("kek-aws-kms-key-id"),
+			AWSKMSEndpoint:   c.String("kek-aws-kms-endpoint"),
+			AWSRegion:        c.String("kek-aws-region"),
+			PreviousKEKs:     c.StringSlice("kek-previous"),
+			MigratePlaintext: c.Bool("kek-migrate-plaintext"),
+		}
+	}
+
+	var keys []config.KeyConfig
+	for _, spec := range c.StringSlice("key") {
+		key, err := config.ParseKeyConfig(spec)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
 	return &config.KMSServerConfig{
 		OperatorAddress:           c.String("operator-address"),
 		Port:                      c.Int("port"),
@@ -748,6 +1076,7 @@ func parseKMSConfig(c *cli.Context) (*config.KMSServerConfig, error) {
 		RpcUrl:                    c.String("rpc-url"),
 		AVSAddress:                c.String("avs-address"),
 		OperatorSetId:             uint32(c.Uint("operator-set-id")),
+		Keys:                      keys,
 		Debug:                     c.Bool("verbose"),
 		Verbose:                   c.Bool("verbose"),
 		BaseRpcUrl:                c.String("base-rpc-url"),
@@ -755,5 +1084,27 @@ func parseKMSConfig(c *cli.Context) (*config.KMSServerConfig, error) {
 		OperatorConfig:            operatorConfig,
 		PersistenceConfig:         persistenceConfig,
 		AppAllowlist:              c.StringSlice("app-allowlist"),
+		MetricsAddress:            c.String("metrics-address"),
+		Authz: config.AuthzConfig{
+			PolicyFile:     c.String("authz-policy-file"),
+			ReloadInterval: c.Duration("authz-policy-reload-interval"),
+		},
+		Retention: config.RetentionConfig{
+			MaxAge:      c.Duration("key-retention-max-age"),
+			MaxVersions: c.Int("key-retention-max-versions"),
+			DryRun:      c.Bool("key-retention-dry-run"),
+		},
+		Admin: config.AdminConfig{
+			SocketPath:   c.String("admin-socket"),
+			Address:      c.String("admin-address"),
+			TLSCertFile:  c.String("admin-tls-cert"),
+			TLSKeyFile:   c.String("admin-tls-key"),
+			ClientCAFile: c.String("admin-tls-client-ca"),
+		},
+		Tracing: config.TracingConfig{
+			Endpoint: c.String("otlp-endpoint"),
+			Protocol: c.String("otlp-protocol"),
+			Insecure: c.Bool("otlp-insecure"),
+		},
 	}, nil
 }
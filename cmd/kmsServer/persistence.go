@@ -0,0 +1,229 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/logger"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/node"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	persistenceBadger "github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/badger"
+	persistenceEncrypted "github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/encrypted"
+	persistenceMemory "github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	persistenceRedis "github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/redis"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+	"go.uber.org/zap"
+)
+
+// newNodePersistence creates the persistence backend described by pc, wrapped with
+// encryption at rest when a KEK is configured. pc must already be scoped to keyID.
+func newNodePersistence(ctx context.Context, pc config.PersistenceConfig, operatorAddress, keyID string, l *zap.Logger) (persistence.INodePersistence, error) {
+	var nodePersistence persistence.INodePersistence
+	switch pc.Type {
+	case "badger":
+		var err error
+		nodePersistence, err = persistenceBadger.NewBadgerPersistence(
+			pc.DataPath,
+			l,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Badger persistence: %w", err)
+		}
+		l.Sugar().Infow("Using Badger persistence",
+			"path", pc.DataPath)
+	case "redis":
+		var err error
+		nodePersistence, err = persistenceRedis.NewRedisPersistence(
+			&persistenceRedis.RedisConfig{
+				Address:   pc.RedisConfig.Address,
+				Password:  pc.RedisConfig.Password,
+				DB:        pc.RedisConfig.DB,
+				KeyPrefix: pc.RedisConfig.KeyPrefix,
+			},
+			l,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create Redis persistence: %w", err)
+		}
+		logFields := []interface{}{
+			"address", pc.RedisConfig.Address,
+			"db", pc.RedisConfig.DB,
+		}
+		if pc.RedisConfig.KeyPrefix != "" {
+			logFields = append(logFields, "key_prefix", pc.RedisConfig.KeyPrefix)
+		}
+		l.Sugar().Infow("Using Redis persistence", logFields...)
+	default:
+		nodePersistence = persistenceMemory.NewMemoryPersistence()
+		l.Sugar().Warn("⚠️  Using in-memory persistence - data will be lost on restart")
+	}
+
+	encCfg := pc.Encryption
+	if encCfg == nil {
+		if pc.Type != "memory" {
+			l.Sugar().Warn("⚠️  Encryption at rest disabled - key shares are persisted in plaintext (set KMS_KEK_SOURCE)")
+		}
+		return nodePersistence, nil
+	}
+
+	// The operator address salts passphrase-derived KEKs: stable for the life of
+	// the database and distinct per operator.
+	salt := common.HexToAddress(operatorAddress).Bytes()
+	kek, previous, err := persistenceEncrypted.NewKEKsFromConfig(ctx, encCfg, salt)
+	if err != nil {
+		_ = nodePersistence.Close()
+		return nil, err
+	}
+	scope := persistenceEncrypted.RecordScope{
+		OperatorAddress: common.HexToAddress(operatorAddress),
+		KeyID:           keyID,
+		Namespace:       pc.Namespace(),
+	}
+	encrypted, err := persistenceEncrypted.NewEncryptedPersistence(nodePersistence, kek, scope, l, previous...)
+	if err != nil {
+		_ = nodePersistence.Close()
+		return nil, err
+	}
+	l.Sugar().Infow("Encryption at rest enabled",
+		"kek_source", encCfg.KEKSource,
+		"kek_id", kek.ID(),
+		"previous_kek_count", len(previous))
+	return encrypted, nil
+}
+
+// sealPersistedRecords brings stored records up to date with the current KEK:
+// records under a previous KEK are re-wrapped and, when encCfg asks for the one-time
+// migration, plaintext records from before encryption was enabled are sealed. A
+// no-op without encryption at rest.
+func sealPersistedRecords(nodePersistence persistence.INodePersistence, encCfg *config.EncryptionConfig) (migrated, rewrapped int, err error) {
+	encrypted, ok := nodePersistence.(*persistenceEncrypted.EncryptedPersistence)
+	if !ok {
+		return 0, 0, nil
+	}
+	if encCfg != nil && encCfg.MigratePlaintext {
+		migrated, err = encrypted.MigratePlaintext()
+		if err != nil {
+			return migrated, 0, fmt.Errorf("failed to seal plaintext records: %w", err)
+		}
+	}
+	rewrapped, err = encrypted.Rewrap()
+	if err != nil {
+		return migrated, rewrapped, fmt.Errorf("failed to re-wrap records: %w", err)
+	}
+	return migrated, rewrapped, nil
+}
+
+// runRewrapKEK re-wraps records under previous KEKs with the current KEK, and seals
+// plaintext records with --kek-migrate-plaintext, then exits. Redis-backed nodes can
+// be rewrapped while they keep serving; Badger holds an exclusive lock on its data
+// directory, so a running Badger node performs the same pass itself on startup
+// instead.
+func runRewrapKEK(c *cli.Context) error {
+	l, err := logger.NewLogger(&logger.LoggerConfig{Debug: c.Bool("verbose")})
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer func() { _ = l.Sync() }()
+
+	kmsConfig, err := parseKMSConfig(c)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	pc := kmsConfig.PersistenceConfig
+	if err := pc.Validate(); err != nil {
+		return fmt.Errorf("invalid persistence configuration: %w", err)
+	}
+	if pc.Encryption == nil {
+		return fmt.Errorf("no KEK configured: set --kek-source")
+	}
+
+	// Every key has its own namespace; re-wrap them all.
+	for _, key := range kmsConfig.EffectiveKeys() {
+		if err := rewrapKeyPersistence(c.Context, kmsConfig, key, l); err != nil {
+			return fmt.Errorf("key %q: %w", key.ID, err)
+		}
+	}
+	return nil
+}
+
+func rewrapKeyPersistence(ctx context.Context, kmsConfig *config.KMSServerConfig, key config.KeyConfig, l *zap.Logger) error {
+	nodePersistence, err := newNodePersistence(ctx, kmsConfig.PersistenceConfig.ForKey(key.ID), kmsConfig.OperatorAddress, key.ID, l)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = nodePersistence.Close() }()
+
+	migrated, rewrapped, err := sealPersistedRecords(nodePersistence, kmsConfig.PersistenceConfig.Encryption)
+	if err != nil {
+		return err
+	}
+	l.Sugar().Infow("KEK re-wrap complete", "key_id", key.ID, "sealed_plaintext", migrated, "rewrapped", rewrapped)
+	return nil
+}
+
+// runPruneKeyVersions applies the key retention policy to every key's persisted
+// versions and prints one JSON report per key. Like rewrap-kek it opens the
+// persistence directly, so a Badger database must not be in use by a running node.
+func runPruneKeyVersions(c *cli.Context) error {
+	l, err := logger.NewLogger(&logger.LoggerConfig{Debug: c.Bool("verbose")})
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+	defer func() { _ = l.Sync() }()
+
+	kmsConfig, err := parseKMSConfig(c)
+	if err != nil {
+		return fmt.Errorf("configuration error: %w", err)
+	}
+	if err := kmsConfig.PersistenceConfig.Validate(); err != nil {
+		return fmt.Errorf("invalid persistence configuration: %w", err)
+	}
+	retention := kmsConfig.Retention
+	if err := retention.Validate(); err != nil {
+		return fmt.Errorf("invalid retention configuration: %w", err)
+	}
+	if !retention.Enabled() {
+		return fmt.Errorf("no retention policy configured: set --key-retention-max-age or --key-retention-max-versions")
+	}
+	dryRun := retention.DryRun || c.Bool("dry-run")
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	for _, key := range kmsConfig.EffectiveKeys() {
+		report, err := pruneKeyPersistence(c.Context, kmsConfig, key, dryRun, l)
+		if report != nil {
+			if encErr := enc.Encode(report); encErr != nil {
+				return fmt.Errorf("failed to write report: %w", encErr)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("key %q: %w", key.ID, err)
+		}
+	}
+	return nil
+}
+
+func pruneKeyPersistence(ctx context.Context, kmsConfig *config.KMSServerConfig, key config.KeyConfig, dryRun bool, l *zap.Logger) (*types.KeyRetentionReport, error) {
+	nodePersistence, err := newNodePersistence(ctx, kmsConfig.PersistenceConfig.ForKey(key.ID), kmsConfig.OperatorAddress, key.ID, l)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = nodePersistence.Close() }()
+
+	report, err := node.PruneKeyVersions(nodePersistence, kmsConfig.Retention, time.Now().Unix(), dryRun)
+	if report != nil {
+		report.KeyID = key.ID
+		l.Sugar().Infow("Key retention pass complete",
+			"key_id", key.ID,
+			"dry_run", dryRun,
+			"pruned", len(report.Pruned),
+			"retained", report.Retained)
+	}
+	return report, err
+}
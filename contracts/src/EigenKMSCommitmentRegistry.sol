@@ -22,6 +22,34 @@ contract EigenKMSCommitmentRegistry is
     EigenKMSCommitmentRegistryStorage,
     IEigenKMSCommitmentRegistryErrors
 {
+    /// @notice Minimum notice for a rotation. A schedule is frozen from ROTATION_NOTICE
+    ///         before its start time until ROTATION_NOTICE after it, so every operator
+    ///         reads the same schedule at the boundary the rotation runs at.
+    uint64 public constant ROTATION_NOTICE = 1 hours;
+
+    /// @notice How long after an epoch's session a player may complain about its share
+    uint64 public constant COMPLAINT_PERIOD = 1 hours;
+
+    /// @notice How long a dealer has to answer a share complaint
+    uint64 public constant ANSWER_WINDOW = 1 hours;
+
+    /// @dev Low 40 bits of an epoch: the session timestamp (the key's tag sits above them)
+    uint64 internal constant SESSION_TIME_MASK = (1 << 40) - 1;
+
+    /// @dev BLS12-381 scalar field modulus r
+    uint256 internal constant FR_MODULUS = 0x73eda753299d7d483339d80809a1d80553bda402fffe5bfeffffffff00000001;
+
+    /// @dev (p - 1) / 2 for the BLS12-381 base field, split into its top 16 and low 32 bytes
+    uint256 internal constant FP_HALF_HI = 0x0d0088f51cbff34d258dd3db21a5d66b;
+    uint256 internal constant FP_HALF_LO = 0xb23ba5c279c2895fb39869507b587b120f55ffff58a9ffffdcff7fffffffd555;
+
+    /// @dev EIP-2537 BLS12_G2MSM precompile
+    address internal constant G2_MSM = address(0x0e);
+
+    /// @dev BLS12-381 G2 generator in EIP-2537 encoding
+    bytes internal constant G2_GENERATOR =
+        hex"00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb80000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be";
+
     /// @custom:oz-upgrades-unsafe-allow constructor
     constructor() {
         _disableInitializers();
@@ -164,8 +192,222 @@ contract EigenKMSCommitmentRegistry is
         // Future: Add slashing logic via AVS service manager
     }
 
+    /**
+     * @notice Complain that a dealer's share to the caller does not verify against the
+     *         dealer's commitments
+     * @dev Opens an optimistic challenge: the dealer must reveal the share with
+     *      answerShareComplaint within ANSWER_WINDOW, or anyone can prove the invalid share
+     *      with proveInvalidShare. Complaints are accepted until COMPLAINT_PERIOD after the
+     *      epoch's session, so a dealer only has to keep its dealt shares that long.
+     * @param epoch The epoch of the dealing
+     * @param dealer The operator who dealt the share
+     */
+    function complainShare(uint64 epoch, address dealer) external override {
+        if (commitments[epoch][dealer].commitmentHash == bytes32(0)) revert NoCommitment();
+        if (msg.sender == dealer) revert SelfComplaint();
+        if (block.timestamp > (epoch & SESSION_TIME_MASK) + COMPLAINT_PERIOD) revert ComplaintPeriodOver();
+
+        ShareComplaint storage complaint = shareComplaints[epoch][dealer][msg.sender];
+        if (complaint.deadline != 0) revert ComplaintAlreadyOpen();
+        complaint.deadline = uint64(block.timestamp) + ANSWER_WINDOW;
+
+        emit ShareComplaintOpened(epoch, dealer, msg.sender, complaint.deadline);
+    }
+
+    /**
+     * @notice Answer a share complaint against the caller by revealing the share it dealt
+     *         the player
+     * @dev The commitments must hash to the caller's on-chain commitment hash the way the
+     *      node computes it: sha256 over the gnark-crypto compressed points, with the 8-byte
+     *      source version appended for a reshare. The share is then checked with the EIP-2537
+     *      G2 MSM precompile: share * G2 == sum(commitmentPoints[k] * x^k), where x is the
+     *      player's evaluation point keccak256(player) mod r. The share becomes public, as it
+     *      does in the off-chain justification.
+     * @param epoch The epoch of the dealing
+     * @param player The complaining player
+     * @param share The share dealt to the player
+     * @param commitmentPoints The caller's commitments, in EIP-2537 G2 encoding
+     * @param sourceVersion Key version a reshare dealt from (0 for a DKG)
+     */
+    function answerShareComplaint(
+        uint64 epoch,
+        address player,
+        uint256 share,
+        bytes[] calldata commitmentPoints,
+        uint64 sourceVersion
+    ) external override {
+        ShareComplaint storage complaint = shareComplaints[epoch][msg.sender][player];
+        if (complaint.deadline == 0) revert NoComplaint();
+        if (complaint.answered) revert ComplaintAlreadyAnswered();
+        if (block.timestamp > complaint.deadline) revert AnswerWindowOver();
+        if (_hashCommitments(commitmentPoints, sourceVersion) != commitments[epoch][msg.sender].commitmentHash) {
+            revert CommitmentMismatch();
+        }
+        if (!_verifyShare(player, share, commitmentPoints)) revert ShareInvalid();
+
+        complaint.answered = true;
+        emit ShareComplaintAnswered(epoch, msg.sender, player, share);
+    }
+
+    /**
+     * @notice Prove that a dealer dealt an invalid share, by a share complaint it left
+     *         unanswered past the deadline
+     * @dev Callable by anyone. Idempotent per (epoch, dealer) pair, like proveEquivocation.
+     * @param epoch The epoch of the dealing
+     * @param dealer The operator who dealt the share
+     * @param player The complaining player
+     */
+    function proveInvalidShare(uint64 epoch, address dealer, address player) external override {
+        ShareComplaint memory complaint = shareComplaints[epoch][dealer][player];
+        if (complaint.deadline == 0) revert NoComplaint();
+        if (complaint.answered) revert ComplaintAlreadyAnswered();
+        if (block.timestamp <= complaint.deadline) revert AnswerWindowOpen();
+        if (invalidShareProven[epoch][dealer]) revert InvalidShareAlreadyProven();
+
+        invalidShareProven[epoch][dealer] = true;
+        emit InvalidShareProven(epoch, dealer, player);
+
+        // Note: Actual slashing would integrate with EigenLayer here, as for equivocation
+    }
+
+    /**
+     * @notice Query a player's share complaint against a dealer
+     * @param epoch The epoch of the dealing
+     * @param dealer The operator who dealt the share
+     * @param player The complaining player
+     * @return deadline Unix time by which the dealer must answer (0 = no complaint)
+     * @return answered Whether the dealer answered with a share that verifies
+     */
+    function getShareComplaint(
+        uint64 epoch,
+        address dealer,
+        address player
+    ) external view override returns (uint64 deadline, bool answered) {
+        ShareComplaint memory complaint = shareComplaints[epoch][dealer][player];
+        return (complaint.deadline, complaint.answered);
+    }
+
+    /**
+     * @notice Schedule a master secret rotation for a key held by the operator set
+     * @dev Only callable by owner. Operators run the rotation DKG at the first reshare
+     *      boundary at or after `at`; a rotation that fails there is not retried and must
+     *      be scheduled again. Rescheduling the same generation is allowed so a failed
+     *      rotation can be retried, moving to an older one is not. A schedule cannot be
+     *      changed within ROTATION_NOTICE of its start time.
+     * @param keyId Key ID the rotation applies to
+     * @param generation Generation to rotate to
+     * @param at Unix time from which the rotation runs
+     * @param retireAfter Seconds the previous generation keeps serving (0 = node default)
+     */
+    function scheduleRotation(
+        string calldata keyId,
+        uint32 generation,
+        uint64 at,
+        uint64 retireAfter
+    ) external override onlyOwner {
+        if (generation == 0 || at < block.timestamp + ROTATION_NOTICE) revert InvalidRotation();
+
+        RotationSchedule storage current = rotations[keccak256(bytes(keyId))];
+        if (generation < current.generation) revert InvalidRotation();
+        if (
+            current.generation != 0 && block.timestamp + ROTATION_NOTICE >= current.at
+                && block.timestamp < current.at + ROTATION_NOTICE
+        ) revert RotationLocked();
+
+        current.generation = generation;
+        current.at = at;
+        current.retireAfter = retireAfter;
+
+        emit RotationScheduled(keyId, generation, at, retireAfter);
+    }
+
+    /**
+     * @notice Query the master secret rotation scheduled for a key
+     * @param keyId Key ID to query
+     * @return generation Generation to rotate to (0 = none scheduled)
+     * @return at Unix time from which the rotation runs
+     * @return retireAfter Seconds the previous generation keeps serving (0 = node default)
+     */
+    function getRotation(
+        string calldata keyId
+    ) external view override returns (uint32 generation, uint64 at, uint64 retireAfter) {
+        RotationSchedule memory r = rotations[keccak256(bytes(keyId))];
+        return (r.generation, r.at, r.retireAfter);
+    }
+
     // ============ INTERNAL FUNCTIONS ============
 
+    /**
+     * @dev Hash EIP-2537 encoded commitments as the node hashes their compressed form
+     *      (HashCommitment, or HashReshareCommitment when sourceVersion is set)
+     */
+    function _hashCommitments(bytes[] calldata points, uint64 sourceVersion) internal pure returns (bytes32) {
+        bytes memory packed;
+        for (uint256 i = 0; i < points.length; i++) {
+            packed = bytes.concat(packed, _compressG2(points[i]));
+        }
+        if (sourceVersion != 0) packed = bytes.concat(packed, bytes8(sourceVersion));
+        return sha256(packed);
+    }
+
+    /**
+     * @dev gnark-crypto's compressed G2 form of an EIP-2537 point: x.c1 || x.c0, with the
+     *      top three bits of the first byte flagging compression, infinity, and whether y
+     *      is the lexicographically larger of y and -y
+     */
+    function _compressG2(bytes calldata point) internal pure returns (bytes memory compressed) {
+        if (point.length != 256) revert InvalidG2Point();
+
+        bool infinity = true;
+        for (uint256 i = 0; i < 256; i += 32) {
+            if (bytes32(point[i:i + 32]) != bytes32(0)) {
+                infinity = false;
+                break;
+            }
+        }
+        if (infinity) {
+            compressed = new bytes(96);
+            compressed[0] = 0xc0;
+            return compressed;
+        }
+
+        compressed = bytes.concat(point[80:128], point[16:64]);
+        bool yc1Zero = bytes32(point[192:224]) == bytes32(0) && bytes32(point[224:256]) == bytes32(0);
+        bool largest = yc1Zero ? _fpLargest(point[128:192]) : _fpLargest(point[192:256]);
+        compressed[0] = compressed[0] | (largest ? bytes1(0xa0) : bytes1(0x80));
+    }
+
+    /// @dev Whether a 64-byte EIP-2537 base field element is greater than (p - 1) / 2
+    function _fpLargest(bytes calldata element) internal pure returns (bool) {
+        uint256 hi = uint256(bytes32(element[0:32]));
+        uint256 lo = uint256(bytes32(element[32:64]));
+        return hi > FP_HALF_HI || (hi == FP_HALF_HI && lo > FP_HALF_LO);
+    }
+
+    /**
+     * @dev Check share against commitments for player, as dkg.VerifyShareFor does:
+     *      share * G2 == sum(points[k] * x^k) with x = keccak256(player) mod r
+     */
+    function _verifyShare(address player, uint256 share, bytes[] calldata points) internal view returns (bool) {
+        if (share >= FR_MODULUS || points.length == 0) return false;
+
+        uint256 x = uint256(keccak256(abi.encodePacked(player))) % FR_MODULUS;
+        uint256 power = 1;
+        bytes memory input;
+        for (uint256 k = 0; k < points.length; k++) {
+            input = bytes.concat(input, points[k], bytes32(power));
+            power = mulmod(power, x, FR_MODULUS);
+        }
+        return keccak256(_g2MSM(input)) == keccak256(_g2MSM(bytes.concat(G2_GENERATOR, bytes32(share))));
+    }
+
+    /// @dev Run the EIP-2537 G2 multi-scalar multiplication precompile
+    function _g2MSM(bytes memory input) internal view returns (bytes memory output) {
+        bool ok;
+        (ok, output) = G2_MSM.staticcall(input);
+        if (!ok || output.length != 256) revert InvalidG2Point();
+    }
+
     /**
      * @dev Check if an operator is valid in the configured operator set
      * @param operator The operator address to check
@@ -31,10 +31,19 @@ abstract contract EigenKMSCommitmentRegistryStorage is IEigenKMSCommitmentRegist
     /// @dev Prevents replaying the same equivocation proof to slash an operator multiple times
     mapping(uint64 => mapping(address => bool)) public equivocationProven;
 
+    /// @notice Mapping: keccak256(keyId) => master secret rotation scheduled for that key
+    mapping(bytes32 => RotationSchedule) internal rotations;
+
+    /// @notice Mapping: epoch => dealer => player => the player's share complaint
+    mapping(uint64 => mapping(address => mapping(address => ShareComplaint))) internal shareComplaints;
+
+    /// @notice Mapping: epoch => dealer => whether an invalid share has already been proven
+    mapping(uint64 => mapping(address => bool)) public invalidShareProven;
+
     /**
      * @dev This empty reserved space is put in place to allow future versions to add new
      * variables without shifting down storage in the inheritance chain.
      * See https://docs.openzeppelin.com/contracts/4.x/upgradeable#storage_gaps
      */
-    uint256[43] private __gap;
+    uint256[40] private __gap;
 }
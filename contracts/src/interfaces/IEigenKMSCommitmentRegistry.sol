@@ -22,6 +22,19 @@ interface IEigenKMSCommitmentRegistry {
         bytes32[] proof;
     }
 
+    /// @notice A player's complaint that a dealer's share to it does not verify
+    struct ShareComplaint {
+        uint64 deadline; // Unix time by which the dealer must answer (0 = no complaint)
+        bool answered; // The dealer revealed a share that verifies against its commitments
+    }
+
+    /// @notice A master secret rotation scheduled for one key of the operator set
+    struct RotationSchedule {
+        uint32 generation; // Generation to rotate to (0 = none scheduled)
+        uint64 at; // Unix time from which the rotation DKG runs
+        uint64 retireAfter; // Seconds the previous generation keeps serving (0 = node default)
+    }
+
     /// @notice Emitted when an operator submits their commitment
     event CommitmentSubmitted(
         uint64 indexed epoch, address indexed operator, bytes32 commitmentHash, bytes32 ackMerkleRoot
@@ -30,9 +43,21 @@ interface IEigenKMSCommitmentRegistry {
     /// @notice Emitted when equivocation is proven
     event EquivocationProven(uint64 indexed epoch, address indexed dealer, address player1, address player2);
 
+    /// @notice Emitted when a player complains about the share a dealer dealt it
+    event ShareComplaintOpened(uint64 indexed epoch, address indexed dealer, address player, uint64 deadline);
+
+    /// @notice Emitted when a dealer answers a share complaint with a share that verifies
+    event ShareComplaintAnswered(uint64 indexed epoch, address indexed dealer, address player, uint256 share);
+
+    /// @notice Emitted when a dealer leaves a share complaint unanswered past its deadline
+    event InvalidShareProven(uint64 indexed epoch, address indexed dealer, address player);
+
     /// @notice Emitted when curve type is updated
     event CurveTypeUpdated(uint8 oldCurveType, uint8 newCurveType);
 
+    /// @notice Emitted when a master secret rotation is scheduled for a key
+    event RotationScheduled(string keyId, uint32 generation, uint64 at, uint64 retireAfter);
+
     /// @notice Submit commitment hash and acknowledgement merkle root for an epoch
     function submitCommitment(uint64 epoch, bytes32 _commitmentHash, bytes32 _ackMerkleRoot) external;
 
@@ -49,4 +74,34 @@ interface IEigenKMSCommitmentRegistry {
 
     /// @notice Prove equivocation by an operator
     function proveEquivocation(uint64 epoch, address dealer, AckData calldata ack1, AckData calldata ack2) external;
+
+    /// @notice Complain that a dealer's share to the caller does not verify
+    function complainShare(uint64 epoch, address dealer) external;
+
+    /// @notice Answer a share complaint by revealing the share dealt to the player
+    function answerShareComplaint(
+        uint64 epoch,
+        address player,
+        uint256 share,
+        bytes[] calldata commitmentPoints,
+        uint64 sourceVersion
+    ) external;
+
+    /// @notice Prove an invalid share by a share complaint the dealer left unanswered
+    function proveInvalidShare(uint64 epoch, address dealer, address player) external;
+
+    /// @notice Query a player's share complaint against a dealer
+    function getShareComplaint(
+        uint64 epoch,
+        address dealer,
+        address player
+    ) external view returns (uint64 deadline, bool answered);
+
+    /// @notice Schedule a master secret rotation for a key
+    function scheduleRotation(string calldata keyId, uint32 generation, uint64 at, uint64 retireAfter) external;
+
+    /// @notice Query the rotation scheduled for a key
+    function getRotation(
+        string calldata keyId
+    ) external view returns (uint32 generation, uint64 at, uint64 retireAfter);
 }
@@ -62,7 +62,64 @@ interface IEigenKMSCommitmentRegistryErrors {
     /// @dev Selector: 0xc00719db
     error Ack2Invalid();
 
+    /// @notice Thrown when a dealer complains about its own share
+    /// @dev Selector: 0xbf5e7d9d
+    error SelfComplaint();
+
+    /// @notice Thrown when a share complaint is opened more than COMPLAINT_PERIOD after
+    ///         the epoch's session
+    /// @dev Selector: 0xa53f20b6
+    error ComplaintPeriodOver();
+
+    /// @notice Thrown when the player already complained about this dealer's share
+    /// @dev Selector: 0x1d5bc557
+    error ComplaintAlreadyOpen();
+
+    /// @notice Thrown when the player has no share complaint against the dealer
+    /// @dev Selector: 0x09471ede
+    error NoComplaint();
+
+    /// @notice Thrown when the share complaint was already answered
+    /// @dev Selector: 0x97f170bb
+    error ComplaintAlreadyAnswered();
+
+    /// @notice Thrown when a share complaint is answered after its deadline
+    /// @dev Selector: 0x41976c0f
+    error AnswerWindowOver();
+
+    /// @notice Thrown when an invalid share is proven before the complaint's deadline
+    /// @dev Selector: 0x4ae0acd3
+    error AnswerWindowOpen();
+
+    /// @notice Thrown when an invalid share for this dealer and epoch has already been proven
+    /// @dev Selector: 0xaf283c6b
+    error InvalidShareAlreadyProven();
+
+    /// @notice Thrown when the commitments in an answer do not hash to the dealer's
+    ///         on-chain commitment hash
+    /// @dev Selector: 0x5054097b
+    error CommitmentMismatch();
+
+    /// @notice Thrown when the share in an answer does not verify against the commitments
+    /// @dev Selector: 0x6082db36
+    error ShareInvalid();
+
+    /// @notice Thrown when a commitment is not a 256-byte EIP-2537 G2 point the
+    ///         precompile accepts
+    /// @dev Selector: 0x9f1cd1ca
+    error InvalidG2Point();
+
     /// @notice Thrown when curve type is invalid (must be 1 or 2)
     /// @dev Selector: 0xfdea7c09
     error InvalidCurveType();
+
+    /// @notice Thrown when a rotation has no generation, is scheduled with less than
+    ///         ROTATION_NOTICE of notice, or moves a key to an older generation
+    /// @dev Selector: 0xa137952b
+    error InvalidRotation();
+
+    /// @notice Thrown when the key's scheduled rotation is within ROTATION_NOTICE of its
+    ///         start time and can no longer be changed
+    /// @dev Selector: 0x47fa0746
+    error RotationLocked();
 }
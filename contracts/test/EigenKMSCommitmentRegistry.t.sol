@@ -609,6 +609,287 @@ contract EigenKMSCommitmentRegistryTest is Test, IEigenKMSCommitmentRegistryErro
         registry.setCurveType(2);
     }
 
+    /// @notice Test scheduling a rotation by owner
+    function test_ScheduleRotation_Success() public {
+        uint64 at = uint64(block.timestamp) + 1 days;
+
+        vm.expectEmit(false, false, false, true);
+        emit RotationScheduled("default", 1, at, 3600);
+
+        registry.scheduleRotation("default", 1, at, 3600);
+
+        (uint32 generation, uint64 storedAt, uint64 retireAfter) = registry.getRotation("default");
+        assertEq(generation, 1);
+        assertEq(storedAt, at);
+        assertEq(retireAfter, 3600);
+
+        (generation,,) = registry.getRotation("other");
+        assertEq(generation, 0, "Rotations are scheduled per key");
+    }
+
+    /// @notice Test scheduleRotation rejects missing generations, short notice and older generations
+    function test_ScheduleRotation_RevertInvalid() public {
+        uint64 at = uint64(block.timestamp) + 1 days;
+
+        vm.expectRevert(InvalidRotation.selector);
+        registry.scheduleRotation("default", 0, at, 0);
+
+        vm.expectRevert(InvalidRotation.selector);
+        registry.scheduleRotation("default", 1, uint64(block.timestamp) + 10 minutes, 0);
+
+        registry.scheduleRotation("default", 2, at, 0);
+
+        vm.expectRevert(InvalidRotation.selector);
+        registry.scheduleRotation("default", 1, at, 0);
+    }
+
+    /// @notice Test a schedule is frozen around its start time and can be retried after it
+    function test_ScheduleRotation_Locked() public {
+        uint64 at = uint64(block.timestamp) + 1 days;
+        registry.scheduleRotation("default", 1, at, 0);
+
+        // Still outside the notice window: the schedule can move
+        registry.scheduleRotation("default", 1, at + 1 hours, 0);
+        at += 1 hours;
+
+        vm.warp(at - 30 minutes);
+        vm.expectRevert(RotationLocked.selector);
+        registry.scheduleRotation("default", 1, at + 1 days, 0);
+
+        vm.warp(at + 30 minutes);
+        vm.expectRevert(RotationLocked.selector);
+        registry.scheduleRotation("default", 1, at + 1 days, 0);
+
+        // A rotation that failed can be scheduled again once the window has passed
+        vm.warp(at + 2 hours);
+        registry.scheduleRotation("default", 1, at + 1 days, 0);
+        (, uint64 storedAt,) = registry.getRotation("default");
+        assertEq(storedAt, at + 1 days);
+    }
+
+    /// @notice Test scheduleRotation only callable by owner
+    function test_ScheduleRotation_OnlyOwner() public {
+        vm.prank(operator1);
+        vm.expectRevert(); // OwnableUpgradeable: caller is not the owner
+        registry.scheduleRotation("default", 1, uint64(block.timestamp) + 1 days, 0);
+    }
+
+    // ============ SHARE COMPLAINTS ============
+
+    // A degree-1 dealing by operator1: commitments to a(X) = 13 + 22X in EIP-2537 encoding,
+    // and the share a(x) it owes operator2, where x = keccak256(operator2) mod r.
+    bytes32 internal constant DEALING_HASH = 0xe8e0f188d7741d435f2809f5aef32ac1461f66e2535b7a63747cfc9d502e43dc;
+    bytes32 internal constant RESHARE_DEALING_HASH = 0x99399451fa6b65d2805102c9b860d09c901f6398bd0f4ac1d841abe699e7c359; // source version 7
+    uint256 internal constant DEALT_SHARE = 0x71366c573b283f1b12d6bf6da8c69fde2010769b0139e3d00e1fd62d446d9227;
+    uint256 internal constant PLAYER_X = 0x05256203f70d773b699565cacd7d645b8d18056422f6feb800a45b309a621247;
+    address internal constant G2_MSM = address(0x0e);
+
+    function _dealingPoints() internal pure returns (bytes[] memory points) {
+        points = new bytes[](2);
+        points[0] = hex"00000000000000000000000000000000152110e866f1a6e8c5348f6e005dbd93de671b7d0fbfa04d6614bcdd27a3cb2a70f0deacb3608ba95226268481a0be7c"
+            hex"000000000000000000000000000000000bf78a97086750eb166986ed8e428ca1d23ae3bbf8b2ee67451d7dd84445311e8bc8ab558b0bc008199f577195fc39b7"
+            hex"000000000000000000000000000000000845be51ad0d708657bfb0da8eec64cd7779c50d90b59a3ac6a2045cad0561d654af9a84dd105cea5409d2adf286b561"
+            hex"000000000000000000000000000000000a298f69fd652551e12219252baacab101768fc6651309450e49c7d3bb52b7547f218d12de64961aa7f059025b8e0cb5";
+        points[1] = hex"00000000000000000000000000000000129c4945fe62538d2806fff056adac24f3bba8e17e42d82122affe6ad2123d68784348a79755f194fde3b3d448924032"
+            hex"000000000000000000000000000000000528590e82f409ea8ce953f0c59d15080185dc6e3219b69fcaa3a2c8fc9d0b9e0bc1e75ec6c52638e6eaa4584005b538"
+            hex"0000000000000000000000000000000018dc3e893f74729d27dd44f45a5a4f433dcd09a3b485e9d1c2bd0eb5e0e4c9024d928ddc426fdecae931e89885ee4db4"
+            hex"000000000000000000000000000000000d6ee02e1fc7e52a8e1ef17e753065882c6fcc14da61da7ffe955fe84a9d2af9ba57562c69db3088652931bf124b0d53";
+    }
+
+    /// @dev Serve the precompile's two results for the dealing, sum(C_k * x^k) and share * G2,
+    ///      equal when the share should verify
+    function _mockShareCheck(bool verifies) internal {
+        bytes[] memory points = _dealingPoints();
+        bytes memory commitmentSum = new bytes(256);
+        bytes memory shareTimesG2 = new bytes(256);
+        commitmentSum[255] = 0x01;
+        shareTimesG2[255] = verifies ? bytes1(0x01) : bytes1(0x02);
+        vm.mockCall(
+            G2_MSM, bytes.concat(points[0], bytes32(uint256(1)), points[1], bytes32(PLAYER_X)), commitmentSum
+        );
+        vm.mockCall(G2_MSM, bytes.concat(_g2Generator(), bytes32(DEALT_SHARE)), shareTimesG2);
+    }
+
+    function _g2Generator() internal pure returns (bytes memory) {
+        return hex"00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8"
+            hex"0000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e"
+            hex"000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801"
+            hex"000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be";
+    }
+
+    function _openComplaint() internal returns (uint64 epoch) {
+        vm.warp(1_700_000_000);
+        epoch = uint64(block.timestamp);
+        vm.prank(operator1);
+        registry.submitCommitment(epoch, DEALING_HASH, keccak256("root"));
+        vm.prank(operator2);
+        registry.complainShare(epoch, operator1);
+    }
+
+    /// @notice Test complainShare opens a complaint with an answer deadline
+    function test_ComplainShare_Success() public {
+        vm.warp(1_700_000_000);
+        uint64 epoch = uint64(block.timestamp);
+        vm.prank(operator1);
+        registry.submitCommitment(epoch, DEALING_HASH, keccak256("root"));
+
+        uint64 deadline = uint64(block.timestamp) + registry.ANSWER_WINDOW();
+        vm.expectEmit(true, true, false, true);
+        emit ShareComplaintOpened(epoch, operator1, operator2, deadline);
+        vm.prank(operator2);
+        registry.complainShare(epoch, operator1);
+
+        (uint64 storedDeadline, bool answered) = registry.getShareComplaint(epoch, operator1, operator2);
+        assertEq(storedDeadline, deadline);
+        assertFalse(answered);
+
+        vm.prank(operator2);
+        vm.expectRevert(ComplaintAlreadyOpen.selector);
+        registry.complainShare(epoch, operator1);
+    }
+
+    /// @notice Test complainShare needs a commitment, another player and an open period
+    function test_ComplainShare_RevertInvalid() public {
+        vm.warp(1_700_000_000);
+        uint64 epoch = uint64(block.timestamp);
+
+        vm.prank(operator2);
+        vm.expectRevert(NoCommitment.selector);
+        registry.complainShare(epoch, operator1);
+
+        vm.prank(operator1);
+        registry.submitCommitment(epoch, DEALING_HASH, keccak256("root"));
+
+        vm.prank(operator1);
+        vm.expectRevert(SelfComplaint.selector);
+        registry.complainShare(epoch, operator1);
+
+        vm.warp(epoch + registry.COMPLAINT_PERIOD() + 1);
+        vm.prank(operator2);
+        vm.expectRevert(ComplaintPeriodOver.selector);
+        registry.complainShare(epoch, operator1);
+    }
+
+    /// @notice Test the complaint period is measured from the session, not the key's epoch tag
+    function test_ComplainShare_TaggedEpoch() public {
+        vm.warp(1_700_000_000);
+        uint64 epoch = (uint64(5) << 40) | uint64(block.timestamp);
+        vm.prank(operator1);
+        registry.submitCommitment(epoch, DEALING_HASH, keccak256("root"));
+
+        vm.prank(operator2);
+        registry.complainShare(epoch, operator1);
+    }
+
+    /// @notice Test an unanswered complaint proves the invalid share once its deadline passes
+    function test_ProveInvalidShare_Success() public {
+        uint64 epoch = _openComplaint();
+        (uint64 deadline,) = registry.getShareComplaint(epoch, operator1, operator2);
+
+        vm.expectRevert(AnswerWindowOpen.selector);
+        registry.proveInvalidShare(epoch, operator1, operator2);
+
+        vm.warp(deadline + 1);
+        vm.expectEmit(true, true, false, true);
+        emit InvalidShareProven(epoch, operator1, operator2);
+        vm.prank(operator3);
+        registry.proveInvalidShare(epoch, operator1, operator2);
+        assertTrue(registry.invalidShareProven(epoch, operator1));
+
+        vm.expectRevert(InvalidShareAlreadyProven.selector);
+        registry.proveInvalidShare(epoch, operator1, operator2);
+
+        vm.expectRevert(NoComplaint.selector);
+        registry.proveInvalidShare(epoch, operator1, operator3);
+    }
+
+    /// @notice Test a dealer answers with a share that verifies, which dismisses the complaint
+    function test_AnswerShareComplaint_Success() public {
+        uint64 epoch = _openComplaint();
+        _mockShareCheck(true);
+
+        vm.expectEmit(true, true, false, true);
+        emit ShareComplaintAnswered(epoch, operator1, operator2, DEALT_SHARE);
+        vm.prank(operator1);
+        registry.answerShareComplaint(epoch, operator2, DEALT_SHARE, _dealingPoints(), 0);
+
+        (uint64 deadline, bool answered) = registry.getShareComplaint(epoch, operator1, operator2);
+        assertTrue(answered);
+
+        vm.prank(operator1);
+        vm.expectRevert(ComplaintAlreadyAnswered.selector);
+        registry.answerShareComplaint(epoch, operator2, DEALT_SHARE, _dealingPoints(), 0);
+
+        vm.warp(deadline + 1);
+        vm.expectRevert(ComplaintAlreadyAnswered.selector);
+        registry.proveInvalidShare(epoch, operator1, operator2);
+    }
+
+    /// @notice Test a reshare dealer's commitments are matched with the source version appended
+    function test_AnswerShareComplaint_Reshare() public {
+        vm.warp(1_700_000_000);
+        uint64 epoch = uint64(block.timestamp);
+        vm.prank(operator1);
+        registry.submitCommitment(epoch, RESHARE_DEALING_HASH, keccak256("root"));
+        vm.prank(operator2);
+        registry.complainShare(epoch, operator1);
+        _mockShareCheck(true);
+
+        vm.prank(operator1);
+        vm.expectRevert(CommitmentMismatch.selector);
+        registry.answerShareComplaint(epoch, operator2, DEALT_SHARE, _dealingPoints(), 0);
+
+        vm.prank(operator1);
+        registry.answerShareComplaint(epoch, operator2, DEALT_SHARE, _dealingPoints(), 7);
+        (, bool answered) = registry.getShareComplaint(epoch, operator1, operator2);
+        assertTrue(answered);
+    }
+
+    /// @notice Test answers with other commitments, a share that does not verify, or late are rejected
+    function test_AnswerShareComplaint_RevertInvalid() public {
+        uint64 epoch = _openComplaint();
+        _mockShareCheck(false);
+        bytes[] memory points = _dealingPoints();
+
+        vm.prank(operator1);
+        vm.expectRevert(NoComplaint.selector);
+        registry.answerShareComplaint(epoch, operator3, DEALT_SHARE, points, 0);
+
+        bytes[] memory swapped = new bytes[](2);
+        swapped[0] = points[1];
+        swapped[1] = points[0];
+        vm.prank(operator1);
+        vm.expectRevert(CommitmentMismatch.selector);
+        registry.answerShareComplaint(epoch, operator2, DEALT_SHARE, swapped, 0);
+
+        points[0] = new bytes(96);
+        vm.prank(operator1);
+        vm.expectRevert(InvalidG2Point.selector);
+        registry.answerShareComplaint(epoch, operator2, DEALT_SHARE, points, 0);
+
+        vm.prank(operator1);
+        vm.expectRevert(ShareInvalid.selector);
+        registry.answerShareComplaint(epoch, operator2, DEALT_SHARE, _dealingPoints(), 0);
+
+        (uint64 deadline,) = registry.getShareComplaint(epoch, operator1, operator2);
+        vm.warp(deadline + 1);
+        vm.prank(operator1);
+        vm.expectRevert(AnswerWindowOver.selector);
+        registry.answerShareComplaint(epoch, operator2, DEALT_SHARE, _dealingPoints(), 0);
+    }
+
+    /// @notice Emitted when a player complains about a dealer's share
+    event ShareComplaintOpened(uint64 indexed epoch, address indexed dealer, address player, uint64 deadline);
+
+    /// @notice Emitted when a dealer answers a share complaint
+    event ShareComplaintAnswered(uint64 indexed epoch, address indexed dealer, address player, uint256 share);
+
+    /// @notice Emitted when an invalid share is proven
+    event InvalidShareProven(uint64 indexed epoch, address indexed dealer, address player);
+
     /// @notice Emitted when curve type is updated
     event CurveTypeUpdated(uint8 oldCurveType, uint8 newCurveType);
+
+    /// @notice Emitted when a rotation is scheduled
+    event RotationScheduled(string keyId, uint32 generation, uint64 at, uint64 retireAfter);
 }
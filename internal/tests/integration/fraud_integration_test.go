@@ -0,0 +1,174 @@
+package integration
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/chain-indexer/pkg/clients/ethereum"
+	"github.com/Layr-Labs/eigenx-kms-go/internal/tests"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller/caller"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/fraud"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/logger"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/merkle"
+	persistenceMemory "github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/transactionSigner"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_FraudEquivocationOnChain has a dealer commit an ack root over acks for two different
+// commitment hashes, then checks that another operator's fraud.Reporter proves the
+// equivocation against the commitment registry on an L2 anvil.
+func Test_FraudEquivocationOnChain(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping fraud proof integration test in short mode")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+	defer cancel()
+
+	l, err := logger.NewLogger(&logger.LoggerConfig{
+		Debug: false,
+	})
+	require.NoError(t, err)
+
+	root := tests.GetProjectRootPath()
+	chainConfig, err := tests.ReadChainConfig(root)
+	require.NoError(t, err)
+	registryAddress := common.HexToAddress(chainConfig.EigenCommitmentRegistryAddress)
+
+	// ------------------------------------------------------------------------
+	// Start L2 Anvil (Base)
+	// ------------------------------------------------------------------------
+	_ = tests.KillallAnvils()
+
+	l2Anvil, err := tests.StartL2Anvil(root, ctx)
+	require.NoError(t, err)
+	defer func() {
+		if err := tests.KillAnvil(l2Anvil); err != nil {
+			t.Logf("Warning: failed to kill L2 Anvil: %v", err)
+		}
+		_ = tests.KillallAnvils()
+	}()
+
+	l2Client := ethereum.NewEthereumClient(&ethereum.EthereumClientConfig{
+		BaseUrl:   L2RpcUrl,
+		BlockType: ethereum.BlockType_Latest,
+	}, l)
+
+	anvilWg := &sync.WaitGroup{}
+	anvilWg.Add(1)
+	startErrorsChan := make(chan error, 1)
+	anvilCtx, anvilCancel := context.WithTimeout(ctx, 30*time.Second)
+	go tests.WaitForAnvil(anvilWg, anvilCtx, t, l2Client, startErrorsChan)
+	anvilWg.Wait()
+	anvilCancel()
+
+	select {
+	case err := <-startErrorsChan:
+		if err != nil {
+			t.Fatalf("Failed to start L2 Anvil: %v", err)
+		}
+	default:
+	}
+	close(startErrorsChan)
+
+	l2EthClient, err := l2Client.GetEthereumContractCaller()
+	require.NoError(t, err)
+
+	newOperatorCaller := func(privateKey string) *caller.ContractCaller {
+		txSigner, err := transactionSigner.NewPrivateKeySigner(privateKey, l2EthClient, l)
+		require.NoError(t, err)
+		cc, err := caller.NewContractCaller(l2EthClient, txSigner, l)
+		require.NoError(t, err)
+		return cc
+	}
+
+	dealer := common.HexToAddress(chainConfig.OperatorAccountAddress1)
+	dealerCaller := newOperatorCaller(chainConfig.OperatorAccountPrivateKey1)
+	reporter := common.HexToAddress(chainConfig.OperatorAccountAddress2)
+	reporterCaller := newOperatorCaller(chainConfig.OperatorAccountPrivateKey2)
+	secondReporter := common.HexToAddress(chainConfig.OperatorAccountAddress3)
+	secondReporterCaller := newOperatorCaller(chainConfig.OperatorAccountPrivateKey3)
+
+	players := []common.Address{
+		reporter,
+		secondReporter,
+		common.HexToAddress(chainConfig.OperatorAccountAddress4),
+		common.HexToAddress(chainConfig.OperatorAccountAddress5),
+	}
+
+	// Epochs are unique per run so the test does not collide with state from the anvil
+	// snapshot or an earlier run against the same chain.
+	epoch := time.Now().Unix()
+	honestHash := crypto.Keccak256Hash([]byte("commitments shown to the first half"))
+	forkedHash := crypto.Keccak256Hash([]byte("commitments shown to the second half"))
+
+	acks := make([]*types.Acknowledgement, len(players))
+	for i, player := range players {
+		commitmentHash := honestHash
+		if i >= len(players)/2 {
+			commitmentHash = forkedHash
+		}
+		acks[i] = &types.Acknowledgement{
+			DealerAddress:    dealer,
+			PlayerAddress:    player,
+			SessionTimestamp: epoch,
+			ShareHash:        crypto.Keccak256Hash(player.Bytes(), []byte("share")),
+			CommitmentHash:   commitmentHash,
+		}
+	}
+
+	// The dealer commits to the ack root exactly as a node does at the end of DKG.
+	tree, err := merkle.BuildMerkleTree(acks)
+	require.NoError(t, err)
+	_, err = dealerCaller.SubmitCommitment(ctx, registryAddress, epoch, honestHash, tree.Root)
+	require.NoError(t, err)
+
+	t.Run("reporter proves equivocation", func(t *testing.T) {
+		store := persistenceMemory.NewMemoryPersistence()
+		defer func() { _ = store.Close() }()
+
+		evidence, err := fraud.NewEquivocationEvidence("dkg", epoch, dealer, reporter, acks)
+		require.NoError(t, err)
+		require.Equal(t, tree.Root, evidence.AckMerkleRoot)
+
+		require.NoError(t, fraud.NewReporter(store, reporterCaller, registryAddress, l).Report(ctx, evidence))
+		require.NotEmpty(t, evidence.SubmissionTxHash)
+
+		proven, err := reporterCaller.IsEquivocationProven(ctx, registryAddress, epoch, dealer)
+		require.NoError(t, err)
+		require.True(t, proven)
+
+		stored, err := store.ListFraudEvidence()
+		require.NoError(t, err)
+		require.Len(t, stored, 1)
+		require.Equal(t, evidence.SubmissionTxHash, stored[0].SubmissionTxHash)
+	})
+
+	t.Run("second reporter skips an already proven dealer", func(t *testing.T) {
+		store := persistenceMemory.NewMemoryPersistence()
+		defer func() { _ = store.Close() }()
+
+		evidence, err := fraud.NewEquivocationEvidence("dkg", epoch, dealer, secondReporter, acks)
+		require.NoError(t, err)
+
+		require.NoError(t, fraud.NewReporter(store, secondReporterCaller, registryAddress, l).Report(ctx, evidence))
+		require.Empty(t, evidence.SubmissionTxHash, "no transaction is sent for a proven dealer")
+
+		stored, err := store.ListFraudEvidence()
+		require.NoError(t, err)
+		require.Len(t, stored, 1, "evidence is still recorded locally")
+	})
+
+	t.Run("consistent dealer yields no evidence", func(t *testing.T) {
+		consistent := make([]*types.Acknowledgement, len(players)/2)
+		copy(consistent, acks[:len(players)/2])
+		_, err := fraud.NewEquivocationEvidence("dkg", epoch, dealer, reporter, consistent)
+		require.ErrorIs(t, err, fraud.ErrNoEquivocation)
+	})
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/merkle"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/reshare"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/testutil"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -169,7 +170,7 @@ func testAcknowledgementWithNewFields(t *testing.T) {
 	}
 
 	// Create acknowledgement using DKG function
-	ack := crypto.CreateAcknowledgement(playerAddr, dealerAddr, epoch, share, commitments, signer)
+	ack := crypto.CreateAcknowledgement(playerAddr, dealerAddr, types.DefaultKeyID, epoch, share, commitments, signer)
 	require.NotNil(t, ack)
 
 	// Verify all fields are set correctly
@@ -189,7 +190,7 @@ func testAcknowledgementWithNewFields(t *testing.T) {
 	require.Equal(t, expectedCommitmentHash, ack.CommitmentHash)
 
 	// Create acknowledgement using Reshare function
-	reshareAck := crypto.CreateAcknowledgement(playerAddr, dealerAddr, epoch, share, commitments, signer)
+	reshareAck := crypto.CreateAcknowledgement(playerAddr, dealerAddr, types.DefaultKeyID, epoch, share, commitments, signer)
 	require.NotNil(t, reshareAck)
 
 	// Both should produce same result
@@ -0,0 +1,80 @@
+package integration
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/testutil"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// Test_PQKeysPerOperator checks that every operator derives its PQ keys from a seed of
+// its own that it never hands to another: operators announce different keys for an app,
+// a restarted operator keeps its key, and an operator replaced by a fresh one announces
+// a new key rather than taking over another's.
+func Test_PQKeysPerOperator(t *testing.T) {
+	const n = 3
+	const appID = "pq-per-operator-app"
+	cluster := testutil.NewTestClusterWithPersistence(t, n, func(int) persistence.INodePersistence {
+		return memory.NewMemoryPersistence()
+	})
+	defer cluster.Close()
+
+	heldSeed := func(i int) []byte {
+		seed, err := cluster.Persistences[i].LoadPQKeySeed()
+		require.NoError(t, err)
+		if seed == nil {
+			return nil
+		}
+		return seed.Seed
+	}
+
+	announced := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		announced[i] = announcedPQPublicKey(t, cluster.ServerURLs[i], appID)
+		require.NotNil(t, heldSeed(i), "node %d did not persist its PQ key seed", i)
+		for j := 0; j < i; j++ {
+			require.False(t, bytes.Equal(announced[i], announced[j]), "nodes %d and %d announce the same PQ key", i, j)
+			require.False(t, bytes.Equal(heldSeed(i), heldSeed(j)), "nodes %d and %d hold the same PQ key seed", i, j)
+		}
+	}
+
+	// No operator serves its seed to another
+	resp, err := http.Post(cluster.ServerURLs[0]+"/pq/seed/request", "application/json", bytes.NewReader([]byte("{}")))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// A restarted operator reloads its seed
+	cluster.RestartNode(t, 1)
+	require.Equal(t, announced[1], announcedPQPublicKey(t, cluster.ServerURLs[1], appID))
+
+	// A fresh operator generates a seed of its own
+	cluster.Persistences[2] = memory.NewMemoryPersistence()
+	cluster.RestartNode(t, 2)
+	fresh := announcedPQPublicKey(t, cluster.ServerURLs[2], appID)
+	for i := 0; i < n; i++ {
+		require.False(t, bytes.Equal(fresh, announced[i]), "fresh node announces node %d's PQ key", i)
+	}
+}
+
+// announcedPQPublicKey returns the PQ public key of appID the operator at url announces.
+func announcedPQPublicKey(t *testing.T, url, appID string) []byte {
+	t.Helper()
+	resp, err := http.Get(url + "/v1/app/pq-public-key?app_id=" + appID)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var authMsg types.AuthenticatedMessage
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&authMsg))
+	var key types.AppPQPublicKey
+	require.NoError(t, json.Unmarshal(authMsg.Payload, &key))
+	require.Equal(t, appID, key.AppID)
+	return key.EncapsulationKey
+}
@@ -0,0 +1,101 @@
+package integration
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// crashingPersistence stands in for a node process killed mid-protocol: once the
+// reshare session reaches crashPhase it is saved, and the save never returns, as a
+// killed process would not.
+type crashingPersistence struct {
+	persistence.INodePersistence
+
+	crashPhase int
+	crashed    chan struct{}
+	once       sync.Once
+}
+
+func (p *crashingPersistence) SaveProtocolSession(session *persistence.ProtocolSessionState) error {
+	if session.Type != "reshare" || session.Phase < p.crashPhase {
+		return p.INodePersistence.SaveProtocolSession(session)
+	}
+	if err := p.INodePersistence.SaveProtocolSession(session); err != nil {
+		return err
+	}
+	p.once.Do(func() { close(p.crashed) })
+	select {}
+}
+
+// Test_SessionResumeAfterRestart kills a node in a reshare after it has submitted its
+// commitment on-chain, restarts it from its persistence, and checks that it finishes
+// the same session: it ends on the version its peers reached, with a share consistent
+// with theirs.
+func Test_SessionResumeAfterRestart(t *testing.T) {
+	const n = 3
+	const crashed = n - 1
+	inner := memory.NewMemoryPersistence()
+	crashing := &crashingPersistence{INodePersistence: inner, crashPhase: 4, crashed: make(chan struct{})}
+	cluster := testutil.NewTestClusterWithPersistence(t, n, func(i int) persistence.INodePersistence {
+		if i == crashed {
+			return crashing
+		}
+		return memory.NewMemoryPersistence()
+	})
+	defer cluster.Close()
+
+	before := currentVersions(cluster)
+	reshareTS := time.Now().Unix()
+
+	errs := make(chan error, n-1)
+	for i := 0; i < n; i++ {
+		go func(idx int) {
+			err := cluster.Nodes[idx].RunReshareAsExistingOperator(reshareTS, 0)
+			if idx != crashed {
+				errs <- err
+			}
+		}(i)
+	}
+
+	select {
+	case <-crashing.crashed:
+	case <-time.After(60 * time.Second):
+		t.Fatal("node did not reach the crash point")
+	}
+	saved, err := inner.LoadProtocolSession(reshareTS)
+	require.NoError(t, err)
+	require.NotNil(t, saved, "crashed node left no session to resume")
+	require.Equal(t, 4, saved.Phase)
+
+	// The restarted node reads the state the crashed one left behind
+	cluster.Persistences[crashed] = inner
+	restarted := cluster.RestartNode(t, crashed)
+
+	for i := 0; i < n-1; i++ {
+		require.NoError(t, <-errs)
+	}
+	for i := 0; i < n-1; i++ {
+		v := cluster.Nodes[i].GetKeyStore().GetActiveVersion()
+		require.NotNil(t, v)
+		require.NotEqual(t, before[i], v.Version, "node %d did not reshare", i)
+	}
+	want := cluster.Nodes[0].GetKeyStore().GetActiveVersion().Version
+
+	require.Eventually(t, func() bool {
+		v := restarted.GetKeyStore().GetActiveVersion()
+		return v != nil && v.Version == want
+	}, 90*time.Second, 500*time.Millisecond, "restarted node did not finish the session it crashed in")
+
+	assertClusterConsistent(t, cluster, "after resume")
+
+	require.Eventually(t, func() bool {
+		sessions, err := inner.ListProtocolSessions()
+		return err == nil && len(sessions) == 0
+	}, 10*time.Second, 100*time.Millisecond, "resumed session not cleaned up")
+}
@@ -0,0 +1,201 @@
+package attestation
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// This file holds the small CBOR (RFC 8949) subset that COSE_Sign1 attestation
+// documents use: unsigned and negative integers, byte and text strings, arrays,
+// maps, tags and the simple values false/true/null. Everything else — floats,
+// indefinite lengths, undefined — is rejected, as are trailing bytes, so a
+// document decodes one way only.
+//
+// Decoded values are Go values: uint64, int64 (negative integers only), []byte,
+// string, []any, map[any]any (keys uint64, int64 or string), bool, nil and
+// cborTag.
+
+const (
+	cborMajorUint   = 0
+	cborMajorNegInt = 1
+	cborMajorBytes  = 2
+	cborMajorText   = 3
+	cborMajorArray  = 4
+	cborMajorMap    = 5
+	cborMajorTag    = 6
+	cborMajorSimple = 7
+
+	// cborMaxDepth bounds nesting so a hostile document cannot exhaust the stack.
+	cborMaxDepth = 16
+)
+
+// cborTag is a tagged data item (major type 6).
+type cborTag struct {
+	Number uint64
+	Value  any
+}
+
+// decodeCBOR decodes exactly one data item from b.
+func decodeCBOR(b []byte) (any, error) {
+	d := &cborDecoder{b: b}
+	v, err := d.decode(0)
+	if err != nil {
+		return nil, err
+	}
+	if d.off != len(b) {
+		return nil, fmt.Errorf("cbor: %d trailing bytes", len(b)-d.off)
+	}
+	return v, nil
+}
+
+type cborDecoder struct {
+	b   []byte
+	off int
+}
+
+func (d *cborDecoder) decode(depth int) (any, error) {
+	if depth > cborMaxDepth {
+		return nil, fmt.Errorf("cbor: nesting exceeds %d levels", cborMaxDepth)
+	}
+	major, arg, err := d.head()
+	if err != nil {
+		return nil, err
+	}
+	switch major {
+	case cborMajorUint:
+		return arg, nil
+	case cborMajorNegInt:
+		if arg > 1<<63-1 {
+			return nil, fmt.Errorf("cbor: negative integer out of range")
+		}
+		return -1 - int64(arg), nil
+	case cborMajorBytes, cborMajorText:
+		if arg > uint64(len(d.b)-d.off) {
+			return nil, fmt.Errorf("cbor: string length %d exceeds input", arg)
+		}
+		s := d.b[d.off : d.off+int(arg)]
+		d.off += int(arg)
+		if major == cborMajorText {
+			return string(s), nil
+		}
+		return bytes.Clone(s), nil
+	case cborMajorArray:
+		// Every item takes at least one byte, so a longer count is malformed.
+		if arg > uint64(len(d.b)-d.off) {
+			return nil, fmt.Errorf("cbor: array length %d exceeds input", arg)
+		}
+		arr := make([]any, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			v, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
+		}
+		return arr, nil
+	case cborMajorMap:
+		if arg > uint64(len(d.b)-d.off)/2 {
+			return nil, fmt.Errorf("cbor: map length %d exceeds input", arg)
+		}
+		m := make(map[any]any, arg)
+		for i := uint64(0); i < arg; i++ {
+			k, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			switch k.(type) {
+			case uint64, int64, string:
+			default:
+				return nil, fmt.Errorf("cbor: unsupported map key type %T", k)
+			}
+			if _, dup := m[k]; dup {
+				return nil, fmt.Errorf("cbor: duplicate map key %v", k)
+			}
+			v, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			m[k] = v
+		}
+		return m, nil
+	case cborMajorTag:
+		v, err := d.decode(depth + 1)
+		if err != nil {
+			return nil, err
+		}
+		return cborTag{Number: arg, Value: v}, nil
+	default: // cborMajorSimple
+		switch arg {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22:
+			return nil, nil
+		default:
+			return nil, fmt.Errorf("cbor: unsupported simple value or float %d", arg)
+		}
+	}
+}
+
+// head reads an initial byte and its argument.
+func (d *cborDecoder) head() (major byte, arg uint64, err error) {
+	if d.off >= len(d.b) {
+		return 0, 0, fmt.Errorf("cbor: unexpected end of input")
+	}
+	ib := d.b[d.off]
+	d.off++
+	major, info := ib>>5, ib&0x1f
+	var n int
+	switch {
+	case info < 24:
+		return major, uint64(info), nil
+	case info == 24:
+		n = 1
+	case info == 25:
+		n = 2
+	case info == 26:
+		n = 4
+	case info == 27:
+		n = 8
+	default:
+		return 0, 0, fmt.Errorf("cbor: unsupported additional information %d", info)
+	}
+	if major == cborMajorSimple && n > 1 {
+		return 0, 0, fmt.Errorf("cbor: floating-point values are not supported")
+	}
+	if len(d.b)-d.off < n {
+		return 0, 0, fmt.Errorf("cbor: unexpected end of input")
+	}
+	var buf [8]byte
+	copy(buf[8-n:], d.b[d.off:d.off+n])
+	d.off += n
+	return major, binary.BigEndian.Uint64(buf[:]), nil
+}
+
+// appendCBORHead appends the shortest initial byte + argument for major/arg.
+func appendCBORHead(dst []byte, major byte, arg uint64) []byte {
+	switch {
+	case arg < 24:
+		return append(dst, major<<5|byte(arg))
+	case arg <= 0xff:
+		return append(dst, major<<5|24, byte(arg))
+	case arg <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(dst, major<<5|25), uint16(arg))
+	case arg <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(dst, major<<5|26), uint32(arg))
+	default:
+		return binary.BigEndian.AppendUint64(append(dst, major<<5|27), arg)
+	}
+}
+
+// appendCBORBytes appends a byte string.
+func appendCBORBytes(dst, b []byte) []byte {
+	return append(appendCBORHead(dst, cborMajorBytes, uint64(len(b))), b...)
+}
+
+// appendCBORText appends a text string.
+func appendCBORText(dst []byte, s string) []byte {
+	return append(appendCBORHead(dst, cborMajorText, uint64(len(s))), s...)
+}
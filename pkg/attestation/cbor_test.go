@@ -0,0 +1,50 @@
+package attestation
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DecodeCBOR(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		doc := encodeTestCBOR(t, cborTag{Number: 18, Value: []any{
+			uint64(1 << 40), -35, []byte("bytes"), "text", nil,
+			[]cborKV{{1, "one"}, {"two", []any{}}, {-1, []byte{}}},
+		}})
+		v, err := decodeCBOR(doc)
+		require.NoError(t, err)
+		assert.Equal(t, cborTag{Number: 18, Value: []any{
+			uint64(1 << 40), int64(-35), []byte("bytes"), "text", nil,
+			map[any]any{uint64(1): "one", "two": []any{}, int64(-1): []byte{}},
+		}}, v)
+	})
+
+	t.Run("rejects", func(t *testing.T) {
+		nested := append(bytes.Repeat([]byte{0x81}, cborMaxDepth+1), 0x00)
+		tests := []struct {
+			name    string
+			input   []byte
+			wantErr string
+		}{
+			{"empty input", nil, "unexpected end of input"},
+			{"trailing bytes", []byte{0x00, 0x00}, "trailing bytes"},
+			{"indefinite-length array", []byte{0x9f, 0x00, 0xff}, "unsupported additional information 31"},
+			{"float", []byte{0xfb, 0, 0, 0, 0, 0, 0, 0, 0}, "floating-point values are not supported"},
+			{"undefined", []byte{0xf7}, "unsupported simple value"},
+			{"truncated string", []byte{0x45, 'a', 'b'}, "exceeds input"},
+			{"huge array count", []byte{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, "exceeds input"},
+			{"array map key", []byte{0xa1, 0x80, 0x00}, "unsupported map key type"},
+			{"duplicate map key", []byte{0xa2, 0x01, 0x00, 0x01, 0x00}, "duplicate map key"},
+			{"deep nesting", nested, "nesting exceeds"},
+		}
+		for _, tc := range tests {
+			t.Run(tc.name, func(t *testing.T) {
+				_, err := decodeCBOR(tc.input)
+				require.ErrorContains(t, err, tc.wantErr)
+			})
+		}
+	})
+}
@@ -19,6 +19,7 @@ import (
 
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 	"github.com/google/go-sev-guest/abi"
+	"github.com/google/go-sev-guest/kds"
 	spb "github.com/google/go-sev-guest/proto/sevsnp"
 	"github.com/google/go-sev-guest/validate"
 	"github.com/google/go-sev-guest/verify"
@@ -99,8 +100,10 @@ func (f snpAttestationVerifierFunc) SnpAttestation(a *spb.Attestation, o *verify
 //  2. Workload-identity binding: REPORT_DATA[32..64] == SHA-384(cc_init_data)[0..32].
 //  3. cc_init_data integrity: parse [data]."policy.rego" and extract the OCI
 //     image ref, surfacing claims.Registry (defense-in-depth) and
-//     claims.ImageDigest = "sha256:<hex>" for the existing release-match check
-//     in pkg/node/handlers.go.
+//     claims.ImageDigest = "sha256:<hex>" for the release-match rules of the
+//     authorization policy.
+//  4. Launch spec: when the rego's rules are allowlisted, surface the app
+//     container's args and env from its policy_data as claims.ContainerPolicy.
 type EigenXSNPAttestationMethod struct {
 	verifier  SnpAttestationVerifier
 	validator SnpAttestationValidator
@@ -130,6 +133,12 @@ type EigenXSNPAttestationMethod struct {
 	// Empty = not enforced; the method logs a loud per-request warning so the
 	// firmware/shape pin isn't silently absent.
 	measurementAllowlist [][]byte
+	// policyRulesAllowlist holds the accepted SHA-256 digests of a genpolicy
+	// document's rules (policy.rego up to policy_data). Those rules are what make
+	// kata-agent enforce policy_data, so the container launch spec is surfaced
+	// only from a document whose rules are listed. Empty = ContainerPolicy is
+	// not surfaced, and releases that pin one are refused.
+	policyRulesAllowlist [][]byte
 	logger               *slog.Logger
 }
 
@@ -218,6 +227,50 @@ func (m *EigenXSNPAttestationMethod) SetMeasurementAllowlist(measurements [][]by
 	return nil
 }
 
+// SetPolicyRulesAllowlist enables ContainerPolicy claims for cc_init_data whose
+// policy.rego rules hash to one of the given 32-byte SHA-256 digests. Multiple
+// entries support a kata-agent upgrade that changes genpolicy's rules.
+func (m *EigenXSNPAttestationMethod) SetPolicyRulesAllowlist(digests [][]byte) error {
+	for i, d := range digests {
+		if len(d) != sha256.Size {
+			return fmt.Errorf("policy rules digest[%d] is %d bytes, want %d", i, len(d), sha256.Size)
+		}
+	}
+	m.policyRulesAllowlist = digests
+	return nil
+}
+
+// initDataContainerPolicy derives claims.ContainerPolicy from the policy.rego
+// that kata-agent enforces. Without a rules allowlist it surfaces nothing; with
+// one, a document whose rules are not listed is rejected, since the guest may not
+// enforce the launch spec (or the image) its policy_data states.
+func (m *EigenXSNPAttestationMethod) initDataContainerPolicy(ccInitData []byte, imageRef string) (types.ContainerPolicy, error) {
+	if len(m.policyRulesAllowlist) == 0 {
+		return types.ContainerPolicy{}, nil
+	}
+	rego, err := initDataRego(ccInitData)
+	if err != nil {
+		return types.ContainerPolicy{}, err
+	}
+	rules, data, err := splitKataPolicy(rego)
+	if err != nil {
+		return types.ContainerPolicy{}, err
+	}
+	digest := sha256.Sum256([]byte(rules))
+	allowed := false
+	for _, d := range m.policyRulesAllowlist {
+		if subtle.ConstantTimeCompare(digest[:], d) == 1 {
+			allowed = true
+			break
+		}
+	}
+	if !allowed {
+		return types.ContainerPolicy{}, fmt.Errorf("policy.rego rules digest %s is not in the allowlist (%d entries)",
+			hex.EncodeToString(digest[:]), len(m.policyRulesAllowlist))
+	}
+	return kataContainerPolicy(data, imageRef)
+}
+
 // checkMeasurementAllowed enforces set-membership of the report's MEASUREMENT
 // against the allowlist (constant-time per candidate). We do this here rather
 // than via validate.Options.Measurement because that field accepts only a
@@ -476,12 +529,24 @@ func (m *EigenXSNPAttestationMethod) Verify(request *AttestationRequest) (*types
 		return nil, fmt.Errorf("parse cc_init_data: %w", err)
 	}
 
+	// Step 5: derive the container launch spec from the same rego's policy_data,
+	// which kata-agent matches every CreateContainerRequest against.
+	containerPolicy, err := m.initDataContainerPolicy(request.CCInitData, registry+"@sha256:"+digestHex)
+	if err != nil {
+		return nil, fmt.Errorf("cc_init_data container policy: %w", err)
+	}
+
 	claims := &types.AttestationClaims{
-		AppID:       request.AppID,
-		ImageDigest: "sha256:" + digestHex,
-		Registry:    registry,
-		Nonce:       nonceLowerHex,
-		ExtraData:   request.ExtraData,
+		AppID:           request.AppID,
+		ImageDigest:     "sha256:" + digestHex,
+		Registry:        registry,
+		Nonce:           nonceLowerHex,
+		ExtraData:       request.ExtraData,
+		ContainerPolicy: containerPolicy,
+		TCB:             snpTCBComponents(reportProto.GetReportedTcb(), reportProto.GetCpuid1EaxFms()),
+		Measurements: map[string]string{
+			"measurement": hex.EncodeToString(reportProto.GetMeasurement()),
+		},
 		// SEV-SNP reports do not carry iat/exp/jti — the freshness guarantee
 		// comes from the random ephemeral RSA key bound into REPORT_DATA, not
 		// from a token timestamp. Leaving JTI empty disables the replay-cache
@@ -492,10 +557,36 @@ func (m *EigenXSNPAttestationMethod) Verify(request *AttestationRequest) (*types
 		"app_id", claims.AppID,
 		"image_digest", claims.ImageDigest,
 		"registry", claims.Registry,
+		"container_policy", claims.ContainerPolicy,
+		"tcb", claims.TCB,
 	)
 	return claims, nil
 }
 
+// snpTCBComponents splits the report's REPORTED_TCB into per-component security
+// patch levels. Turin moved the fields (and added FMC); the product line comes
+// from the report's CPUID FMS, which pre-v3 reports leave zero — those are
+// Milan/Genoa, which use the legacy layout.
+func snpTCBComponents(tcb uint64, fms uint32) map[string]uint64 {
+	b := func(i int) uint64 { return (tcb >> (8 * i)) & 0xff }
+	if kds.ProductLineFromFms(fms) == "Turin" {
+		return map[string]uint64{
+			"fmc":        b(0),
+			"bootloader": b(1),
+			"tee":        b(2),
+			"snp":        b(3),
+			"microcode":  b(7),
+		}
+	}
+	parts := kds.DecomposeTCBVersion(kds.TCBVersion(tcb))
+	return map[string]uint64{
+		"bootloader": uint64(parts.BlSpl),
+		"tee":        uint64(parts.TeeSpl),
+		"snp":        uint64(parts.SnpSpl),
+		"microcode":  uint64(parts.UcodeSpl),
+	}
+}
+
 // rawSNPEvidence is the AA-emitted evidence wrapper. attestation_report is
 // the raw 0x4A0 SEV-SNP report bytes (Go decodes JSON base64 strings into
 // []byte automatically); cert_chain is an array of PEM-encoded AMD certs
@@ -669,21 +760,9 @@ type initDataDoc struct {
 // this is fail-closed: a workload that doesn't pin its image in the rego must
 // not pass the workload-identity check.
 func parseInitDataPolicy(ccInitData []byte) (string, string, error) {
-	tomlBytes, err := decodeInitDataWire(ccInitData)
+	regoStr, err := initDataRego(ccInitData)
 	if err != nil {
-		return "", "", fmt.Errorf("decode wire format: %w", err)
-	}
-	var doc initDataDoc
-	if err := tomlv2.Unmarshal(tomlBytes, &doc); err != nil {
-		return "", "", fmt.Errorf("decode TOML: %w", err)
-	}
-	rawRego, ok := doc.Data[initDataPolicyKey]
-	if !ok {
-		return "", "", fmt.Errorf("[data].%q not found in cc_init_data", initDataPolicyKey)
-	}
-	regoStr, ok := rawRego.(string)
-	if !ok {
-		return "", "", fmt.Errorf("[data].%q is %T, want string", initDataPolicyKey, rawRego)
+		return "", "", err
 	}
 	// Strip rego comment lines before matching the image ref. Rego uses
 	// `#` for line comments. Without this filter, a stale OCI ref left
@@ -706,6 +785,105 @@ func parseInitDataPolicy(ccInitData []byte) (string, string, error) {
 	}), match[2], nil
 }
 
+// initDataRego returns the [data]."policy.rego" document of cc_init_data.
+func initDataRego(ccInitData []byte) (string, error) {
+	tomlBytes, err := decodeInitDataWire(ccInitData)
+	if err != nil {
+		return "", fmt.Errorf("decode wire format: %w", err)
+	}
+	var doc initDataDoc
+	if err := tomlv2.Unmarshal(tomlBytes, &doc); err != nil {
+		return "", fmt.Errorf("decode TOML: %w", err)
+	}
+	rawRego, ok := doc.Data[initDataPolicyKey]
+	if !ok {
+		return "", fmt.Errorf("[data].%q not found in cc_init_data", initDataPolicyKey)
+	}
+	regoStr, ok := rawRego.(string)
+	if !ok {
+		return "", fmt.Errorf("[data].%q is %T, want string", initDataPolicyKey, rawRego)
+	}
+	return regoStr, nil
+}
+
+// kataPolicyDataPrefix starts the data section of a kata genpolicy document: the
+// JSON object the policy's rules match every CreateContainerRequest against.
+const kataPolicyDataPrefix = "policy_data := "
+
+// kataPolicyData is the part of a genpolicy policy_data object that pins how a
+// container is launched.
+type kataPolicyData struct {
+	Containers []struct {
+		OCI struct {
+			Process struct {
+				Args []string `json:"Args"`
+				Env  []string `json:"Env"`
+			} `json:"Process"`
+			Annotations map[string]string `json:"Annotations"`
+		} `json:"OCI"`
+	} `json:"containers"`
+}
+
+// splitKataPolicy splits a genpolicy document into its rules, everything before
+// the line starting policy_data, and the policy_data object, which must end the
+// document: rego after it could redefine a rule.
+func splitKataPolicy(rego string) (string, *kataPolicyData, error) {
+	idx := strings.Index(rego, "\n"+kataPolicyDataPrefix)
+	if idx < 0 {
+		return "", nil, fmt.Errorf("policy.rego has no %q section", strings.TrimSpace(kataPolicyDataPrefix))
+	}
+	rules := rego[:idx+1]
+	dec := json.NewDecoder(strings.NewReader(rego[idx+1+len(kataPolicyDataPrefix):]))
+	var data kataPolicyData
+	if err := dec.Decode(&data); err != nil {
+		return "", nil, fmt.Errorf("decode policy_data: %w", err)
+	}
+	rest, err := io.ReadAll(dec.Buffered())
+	if err != nil {
+		return "", nil, fmt.Errorf("read policy.rego: %w", err)
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return "", nil, fmt.Errorf("policy.rego continues after policy_data")
+	}
+	return rules, &data, nil
+}
+
+// kataContainerPolicy returns the launch spec policy_data pins for the one
+// container running imageRef: its OCI process args and environment. kata-agent
+// refuses any CreateContainerRequest that differs, so these are what the guest
+// runs. Kubernetes has no command override or restart policy for the agent to
+// check, so those fields stay empty.
+func kataContainerPolicy(data *kataPolicyData, imageRef string) (types.ContainerPolicy, error) {
+	var found []int
+	for i, c := range data.Containers {
+		if c.OCI.Annotations["io.kubernetes.cri.container-type"] == "sandbox" {
+			continue
+		}
+		if c.OCI.Annotations["io.kubernetes.cri.image-name"] == imageRef {
+			found = append(found, i)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return types.ContainerPolicy{}, fmt.Errorf("policy_data has no container running %s", imageRef)
+	case 1:
+	default:
+		// Either entry would satisfy the agent, so neither launch spec is binding.
+		return types.ContainerPolicy{}, fmt.Errorf("policy_data has %d containers running %s", len(found), imageRef)
+	}
+
+	process := data.Containers[found[0]].OCI.Process
+	cp := types.ContainerPolicy{Args: process.Args}
+	if len(process.Env) > 0 {
+		cp.Env = make(map[string]string, len(process.Env))
+		for _, kv := range process.Env {
+			k, v, _ := strings.Cut(kv, "=")
+			cp.Env[k] = v
+		}
+	}
+	return cp, nil
+}
+
 // stripRegoComments returns rego with all whole-line `#` comments
 // removed. This is intentionally narrow — only lines whose first
 // non-whitespace character is `#`. We do NOT try to strip inline
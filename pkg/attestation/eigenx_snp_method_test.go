@@ -15,6 +15,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 	"github.com/google/go-sev-guest/abi"
 	spb "github.com/google/go-sev-guest/proto/sevsnp"
 	"github.com/google/go-sev-guest/validate"
@@ -380,6 +381,140 @@ func TestSetMeasurementAllowlist_RejectsWrongSize(t *testing.T) {
 	assert.Contains(t, err.Error(), "48")
 }
 
+// testKataRules stands in for the rules section of a kata genpolicy document.
+const testKataRules = `package agent_policy
+
+default CreateContainerRequest := false
+
+CreateContainerRequest if {
+    some p_container in policy_data.containers
+    input.OCI.Process.Args == p_container.OCI.Process.Args
+}
+
+`
+
+// genpolicyInitDataTOML wraps rules and a policy_data object in a cc_init_data
+// document.
+func genpolicyInitDataTOML(t *testing.T, rules, policyData string) []byte {
+	t.Helper()
+	doc := "algorithm = \"sha384\"\nversion = \"0.1.0\"\n\n[data]\n\"policy.rego\" = '''\n" +
+		rules + "policy_data := " + policyData + "\n'''\n"
+	return []byte(doc)
+}
+
+// kataPolicyDataJSON is a policy_data object with a sandbox (pause) container and
+// one container per image, each launched with args and env.
+func kataPolicyDataJSON(t *testing.T, images ...string) string {
+	t.Helper()
+	containers := []any{map[string]any{
+		"OCI": map[string]any{
+			"Process":     map[string]any{"Args": []string{"/pause"}, "Env": []string{"PATH=/usr/bin"}},
+			"Annotations": map[string]string{"io.kubernetes.cri.container-type": "sandbox"},
+		},
+	}}
+	for _, image := range images {
+		containers = append(containers, map[string]any{
+			"OCI": map[string]any{
+				"Process": map[string]any{
+					"Args": []string{"/entrypoint.sh", "start"},
+					"Env":  []string{"PATH=/usr/bin", "MODE=prod", "EMPTY="},
+				},
+				"Annotations": map[string]string{
+					"io.kubernetes.cri.container-type": "container",
+					"io.kubernetes.cri.image-name":     image,
+				},
+			},
+		})
+	}
+	b, err := json.MarshalIndent(map[string]any{"containers": containers}, "", "  ")
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestEigenXSNPVerify_ContainerPolicy(t *testing.T) {
+	rsaKey := []byte("test-rsa-public-key-pem")
+	digestHex := "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+	imageRef := "ghcr.io/example/app@sha256:" + digestHex
+	ccInitData := genpolicyInitDataTOML(t, testKataRules, kataPolicyDataJSON(t, imageRef))
+	rd := expectedReportData(rsaKey, nil, ccInitData)
+	request := &AttestationRequest{
+		Method:       "eigenx-snp",
+		AppID:        "my-app",
+		Attestation:  b64(buildEvidenceJSON(t, buildSNPReport(rd), nil)),
+		RSAPubKeyTmp: rsaKey,
+		CCInitData:   ccInitData,
+	}
+
+	m := newSNPMethodWithFake(t, &fakeSNPVerifier{})
+	claims, err := m.Verify(request)
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:"+digestHex, claims.ImageDigest)
+	assert.Zero(t, claims.ContainerPolicy, "without a rules allowlist no launch spec is surfaced")
+
+	rulesDigest := sha256.Sum256([]byte(testKataRules))
+	require.NoError(t, m.SetPolicyRulesAllowlist([][]byte{rulesDigest[:]}))
+	claims, err = m.Verify(request)
+	require.NoError(t, err)
+	assert.Equal(t, types.ContainerPolicy{
+		Args: []string{"/entrypoint.sh", "start"},
+		Env:  map[string]string{"PATH": "/usr/bin", "MODE": "prod", "EMPTY": ""},
+	}, claims.ContainerPolicy)
+
+	other := sha256.Sum256([]byte("package agent_policy\n\ndefault CreateContainerRequest := true\n"))
+	require.NoError(t, m.SetPolicyRulesAllowlist([][]byte{other[:]}))
+	_, err = m.Verify(request)
+	require.ErrorContains(t, err, "not in the allowlist", "rules the operator has not vetted may not enforce policy_data")
+}
+
+func TestEigenXSNPInitDataContainerPolicy_Rejections(t *testing.T) {
+	imageRef := "ghcr.io/example/app@sha256:" + strings.Repeat("ab", 32)
+	rulesDigest := sha256.Sum256([]byte(testKataRules))
+	m := newSNPMethodWithFake(t, &fakeSNPVerifier{})
+	require.NoError(t, m.SetPolicyRulesAllowlist([][]byte{rulesDigest[:]}))
+
+	for _, tc := range []struct {
+		name       string
+		ccInitData []byte
+		wantErr    string
+	}{
+		{
+			name:       "no policy_data",
+			ccInitData: validInitDataTOML(t, "ghcr.io/example/app", strings.Repeat("ab", 32)),
+			wantErr:    "no \"policy_data :=\" section",
+		},
+		{
+			name:       "rego after policy_data",
+			ccInitData: genpolicyInitDataTOML(t, testKataRules, kataPolicyDataJSON(t, imageRef)+"\nCreateContainerRequest := true"),
+			wantErr:    "continues after policy_data",
+		},
+		{
+			name:       "image not in policy_data",
+			ccInitData: genpolicyInitDataTOML(t, testKataRules, kataPolicyDataJSON(t, "ghcr.io/example/other@sha256:"+strings.Repeat("cd", 32))),
+			wantErr:    "no container running",
+		},
+		{
+			name:       "image listed twice",
+			ccInitData: genpolicyInitDataTOML(t, testKataRules, kataPolicyDataJSON(t, imageRef, imageRef)),
+			wantErr:    "2 containers running",
+		},
+		{
+			name:       "malformed policy_data",
+			ccInitData: genpolicyInitDataTOML(t, testKataRules, "{\"containers\": ["),
+			wantErr:    "decode policy_data",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := m.initDataContainerPolicy(tc.ccInitData, imageRef)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestSetPolicyRulesAllowlist_RejectsWrongSize(t *testing.T) {
+	m := newSNPMethodWithFake(t, &fakeSNPVerifier{})
+	require.ErrorContains(t, m.SetPolicyRulesAllowlist([][]byte{make([]byte, 48)}), "want 32")
+}
+
 func TestEigenXSNPVerify_NonceMismatch(t *testing.T) {
 	rsaKey := []byte("real-rsa-key")
 	digestHex := "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
@@ -628,3 +763,29 @@ func TestStripRegoComments(t *testing.T) {
 	assert.Contains(t, out, `input.image == "ghcr.io/x/y@sha256:abc"`)
 	assert.Contains(t, out, "# trailing comment", "inline comments preserved")
 }
+
+// TestSNPTCBComponents pins both REPORTED_TCB layouts: Milan/Genoa (and pre-v3
+// reports, which carry no CPUID) and Turin, which moved every field and added FMC.
+func TestSNPTCBComponents(t *testing.T) {
+	// bytes little-endian: 0x01 0x02 0x03 0x04 0x05 0x06 0x07 0x08
+	const tcb = uint64(0x0807060504030201)
+
+	milan := abi.MaskedCpuid1EaxFromSevProduct(&spb.SevProduct{Name: spb.SevProduct_SEV_PRODUCT_MILAN})
+	for _, fms := range []uint32{0, milan} {
+		assert.Equal(t, map[string]uint64{
+			"bootloader": 0x01,
+			"tee":        0x02,
+			"snp":        0x07,
+			"microcode":  0x08,
+		}, snpTCBComponents(tcb, fms))
+	}
+
+	turin := abi.MaskedCpuid1EaxFromSevProduct(&spb.SevProduct{Name: spb.SevProduct_SEV_PRODUCT_TURIN})
+	assert.Equal(t, map[string]uint64{
+		"fmc":        0x01,
+		"bootloader": 0x02,
+		"tee":        0x03,
+		"snp":        0x04,
+		"microcode":  0x08,
+	}, snpTCBComponents(tcb, turin))
+}
@@ -0,0 +1,380 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-tdx-guest/abi"
+	pb "github.com/google/go-tdx-guest/proto/tdx"
+	tdxtest "github.com/google/go-tdx-guest/testing"
+	"github.com/google/go-tdx-guest/testing/testdata"
+	"github.com/google/go-tdx-guest/verify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// goldenTDXTime is when go-tdx-guest's sample quote and PCS collateral are all
+// within their validity periods (the collateral expires in July 2023).
+var goldenTDXTime = time.Date(2023, time.July, 1, 1, 0, 0, 0, time.UTC)
+
+// fakeTDXVerifier short-circuits go-tdx-guest's Intel chain validation so the
+// tests can cover REPORTDATA binding and the RTMR[3] image replay with quotes
+// whose REPORTDATA Intel never signed. The real verifier runs against the
+// golden quote in TestEigenXTDXVerify_GoldenQuote.
+type fakeTDXVerifier struct {
+	err     error
+	gotOpts *verify.Options
+}
+
+func (f *fakeTDXVerifier) TdxQuote(_ any, opts *verify.Options) error {
+	f.gotOpts = opts
+	return f.err
+}
+
+func newTDXMethod(t *testing.T, v TdxQuoteVerifier) *EigenXTDXAttestationMethod {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	m := newEigenXTDXMethod(v, logger)
+	m.now = func() time.Time { return goldenTDXTime }
+	require.NoError(t, m.SetBootMeasurementAllowlist([]TDXBootMeasurement{goldenTDXBootMeasurement(t)}))
+	return m
+}
+
+// goldenTDXBootMeasurement is the boot state of the golden quote, which
+// buildTDXQuote keeps unless a test mutates it.
+func goldenTDXBootMeasurement(t *testing.T) TDXBootMeasurement {
+	t.Helper()
+	parsed, err := abi.QuoteToProto(testdata.RawQuote)
+	require.NoError(t, err)
+	body := parsed.(*pb.QuoteV4).GetTdQuoteBody()
+	return TDXBootMeasurement{MRTD: body.GetMrTd(), RTMR0: body.GetRtmrs()[0], RTMR1: body.GetRtmrs()[1], RTMR2: body.GetRtmrs()[2]}
+}
+
+// goldenTDXCollateral is the Intel PCS collateral that go-tdx-guest ships for
+// its sample quote, with the issuer chains URL-encoded as PCS sends them.
+func goldenTDXCollateral() tdxCollateral {
+	return tdxCollateral{
+		TcbInfo:               testdata.TcbInfoBody,
+		TcbInfoIssuerChain:    tdxtest.TcbInfoHeader["Tcb-Info-Issuer-Chain"][0],
+		QeIdentity:            testdata.QeIdentityBody,
+		QeIdentityIssuerChain: tdxtest.QeIdentityHeader["Sgx-Enclave-Identity-Issuer-Chain"][0],
+		PckCrl:                testdata.PckCrlBody,
+		PckCrlIssuerChain:     tdxtest.PckCrlHeader["Sgx-Pck-Crl-Issuer-Chain"][0],
+		RootCaCrl:             testdata.RootCrlBody,
+	}
+}
+
+// buildTDXQuote returns the golden quote with its TD quote body changed by
+// mutate. Its signature no longer verifies, so it is only for fakeTDXVerifier.
+func buildTDXQuote(t *testing.T, mutate func(body *pb.TDQuoteBody)) []byte {
+	t.Helper()
+	parsed, err := abi.QuoteToProto(testdata.RawQuote)
+	require.NoError(t, err)
+	quote := parsed.(*pb.QuoteV4)
+	mutate(quote.GetTdQuoteBody())
+	raw, err := abi.QuoteToAbiBytes(quote)
+	require.NoError(t, err)
+	return raw
+}
+
+func buildTDXEvidenceJSON(t *testing.T, quote []byte, imageRef string, collateral tdxCollateral) []byte {
+	t.Helper()
+	b, err := json.Marshal(rawTDXEvidence{Quote: quote, ImageRef: imageRef, Collateral: collateral})
+	require.NoError(t, err)
+	return b
+}
+
+// imageRTMR replays the launcher's single RTMR[3] extension by hand:
+// RTMR = SHA-384(RTMR || SHA-384(event)) from the all-zero reset value.
+func imageRTMR(imageRef string) []byte {
+	event := sha512.Sum384([]byte(imageRef))
+	rtmr := sha512.Sum384(append(make([]byte, 48), event[:]...))
+	return rtmr[:]
+}
+
+func TestEigenXTDXMethodName(t *testing.T) {
+	m := newTDXMethod(t, &fakeTDXVerifier{})
+	assert.Equal(t, "eigenx-tdx", m.Name())
+}
+
+func TestEigenXTDXVerify_GoldenQuote(t *testing.T) {
+	m := newTDXMethod(t, tdxQuoteVerifierFunc(verify.TdxQuote))
+	request := func(quote []byte, collateral tdxCollateral) *AttestationRequest {
+		return &AttestationRequest{
+			Method:       "eigenx-tdx",
+			AppID:        "my-app",
+			Attestation:  b64(buildTDXEvidenceJSON(t, quote, "ghcr.io/example/app@sha256:"+string(bytes.Repeat([]byte("a"), 64)), collateral)),
+			RSAPubKeyTmp: []byte("test-rsa-public-key-pem"),
+		}
+	}
+
+	t.Run("chain, collateral and signature verify", func(t *testing.T) {
+		// Reaching the TCB level match means the PCK chain, both CRLs, the
+		// Intel-signed TCB info and QE identity, and the quote signature all
+		// verified. The sample collateral lists newer TCB levels than the sample
+		// quote, so go-tdx-guest's own tests expect this exact failure here.
+		_, err := m.Verify(request(testdata.RawQuote, goldenTDXCollateral()))
+		require.ErrorContains(t, err, "no matching TCB level found")
+	})
+
+	t.Run("PEM issuer chains", func(t *testing.T) {
+		collateral := goldenTDXCollateral()
+		for _, chain := range []*string{&collateral.TcbInfoIssuerChain, &collateral.QeIdentityIssuerChain, &collateral.PckCrlIssuerChain} {
+			pemChain, err := url.QueryUnescape(*chain)
+			require.NoError(t, err)
+			*chain = pemChain
+		}
+		_, err := m.Verify(request(testdata.RawQuote, collateral))
+		require.ErrorContains(t, err, "no matching TCB level found")
+	})
+
+	t.Run("tampered quote body", func(t *testing.T) {
+		quote := buildTDXQuote(t, func(body *pb.TDQuoteBody) {
+			body.ReportData = bytes.Repeat([]byte{0x01}, abi.ReportDataSize)
+		})
+		_, err := m.Verify(request(quote, goldenTDXCollateral()))
+		require.ErrorContains(t, err, "unable to verify message digest")
+	})
+
+	t.Run("forged TCB info", func(t *testing.T) {
+		collateral := goldenTDXCollateral()
+		collateral.TcbInfo = bytes.Replace(collateral.TcbInfo, []byte(`"nextUpdate":"2023-07-18`), []byte(`"nextUpdate":"2033-07-18`), 1)
+		require.NotEqual(t, testdata.TcbInfoBody, collateral.TcbInfo)
+		_, err := m.Verify(request(testdata.RawQuote, collateral))
+		require.ErrorContains(t, err, "tcbInfo response verification failed")
+	})
+
+	t.Run("expired collateral", func(t *testing.T) {
+		expired := newTDXMethod(t, tdxQuoteVerifierFunc(verify.TdxQuote))
+		expired.now = func() time.Time { return goldenTDXTime.AddDate(30, 0, 0) }
+		_, err := expired.Verify(request(testdata.RawQuote, goldenTDXCollateral()))
+		require.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("missing collateral", func(t *testing.T) {
+		collateral := goldenTDXCollateral()
+		collateral.RootCaCrl = nil
+		_, err := m.Verify(request(testdata.RawQuote, collateral))
+		require.ErrorContains(t, err, "root_ca_crl is required")
+	})
+}
+
+func TestEigenXTDXVerify_ValidEvidence(t *testing.T) {
+	rsaKey := []byte("test-rsa-public-key-pem")
+	extraData := []byte("binding-payload")
+	ccInitData := []byte("algorithm = \"sha384\"\n")
+	digestHex := "0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+	registry := "ghcr.io/example/app"
+	imageRef := registry + "@sha256:" + digestHex
+
+	rd := expectedReportData(rsaKey, extraData, ccInitData)
+	quote := buildTDXQuote(t, func(body *pb.TDQuoteBody) {
+		body.ReportData = rd[:]
+		body.Rtmrs[3] = imageRTMR(imageRef)
+		body.TeeTcbSvn[0], body.TeeTcbSvn[1] = 5, 1
+	})
+	parsed, err := abi.QuoteToProto(quote)
+	require.NoError(t, err)
+	header := parsed.(*pb.QuoteV4).GetHeader()
+
+	fake := &fakeTDXVerifier{}
+	m := newTDXMethod(t, fake)
+	claims, err := m.Verify(&AttestationRequest{
+		Method:       "eigenx-tdx",
+		AppID:        "my-app",
+		Attestation:  b64(buildTDXEvidenceJSON(t, quote, imageRef, goldenTDXCollateral())),
+		RSAPubKeyTmp: rsaKey,
+		ExtraData:    extraData,
+		CCInitData:   ccInitData,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "my-app", claims.AppID)
+	assert.Equal(t, "sha256:"+digestHex, claims.ImageDigest)
+	assert.Equal(t, registry, claims.Registry)
+	assert.Equal(t, map[string]uint64{
+		"tdx_module":       5,
+		"tdx_module_major": 1,
+		"qe":               uint64(binary.LittleEndian.Uint16(header.GetQeSvn())),
+		"pce":              uint64(binary.LittleEndian.Uint16(header.GetPceSvn())),
+	}, claims.TCB)
+	assert.Equal(t, hex.EncodeToString(imageRTMR(imageRef)), claims.Measurements["rtmr3"])
+	assert.Len(t, claims.Measurements, 5)
+	assert.Equal(t, string(rd[:32]), claims.Nonce)
+	assert.Equal(t, extraData, claims.ExtraData)
+	assert.Empty(t, claims.JTI)
+
+	// Collateral comes from the evidence, with revocation checked, at verify time
+	require.NotNil(t, fake.gotOpts)
+	assert.True(t, fake.gotOpts.GetCollateral)
+	assert.True(t, fake.gotOpts.CheckRevocations)
+	assert.Equal(t, goldenTDXTime, fake.gotOpts.Now)
+	_, body, err := fake.gotOpts.Getter.Get("https://api.trustedservices.intel.com/tdx/certification/v4/tcb?fmspc=50806f000000")
+	require.NoError(t, err)
+	assert.Equal(t, testdata.TcbInfoBody, body)
+	_, _, err = fake.gotOpts.Getter.Get("https://example.com/collateral")
+	assert.ErrorContains(t, err, "no supplied collateral")
+
+	// Without cc_init_data, REPORTDATA binds SHA-384 of the empty document
+	rd = expectedReportData(rsaKey, nil, nil)
+	quote = buildTDXQuote(t, func(body *pb.TDQuoteBody) {
+		body.ReportData = rd[:]
+		body.Rtmrs[3] = imageRTMR(imageRef)
+	})
+	_, err = m.Verify(&AttestationRequest{
+		AppID:        "my-app",
+		Attestation:  b64(buildTDXEvidenceJSON(t, quote, imageRef, goldenTDXCollateral())),
+		RSAPubKeyTmp: rsaKey,
+	})
+	require.NoError(t, err)
+}
+
+func TestEigenXTDXVerify_Rejections(t *testing.T) {
+	rsaKey := []byte("test-rsa-public-key-pem")
+	imageRef := "ghcr.io/example/app@sha256:0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+	rd := expectedReportData(rsaKey, nil, nil)
+	validBody := func(body *pb.TDQuoteBody) {
+		body.ReportData = rd[:]
+		body.Rtmrs[3] = imageRTMR(imageRef)
+	}
+
+	tests := []struct {
+		name     string
+		mutate   func(body *pb.TDQuoteBody)
+		imageRef string
+		rsaKey   []byte
+		verifier *fakeTDXVerifier
+		wantErr  string
+	}{
+		{
+			name:    "missing RSA key",
+			mutate:  validBody,
+			wantErr: "RSAPubKeyTmp is required",
+		},
+		{
+			name:     "Intel verification failure",
+			mutate:   validBody,
+			verifier: &fakeTDXVerifier{err: verify.ErrHashVerificationFail},
+			wantErr:  "TDX quote verification failed",
+		},
+		{
+			name: "debug TD",
+			mutate: func(body *pb.TDQuoteBody) {
+				validBody(body)
+				body.TdAttributes[0] |= 0x01
+			},
+			wantErr: "TD attributes",
+		},
+		{
+			name: "REPORTDATA not bound to the RSA key",
+			mutate: func(body *pb.TDQuoteBody) {
+				validBody(body)
+				other := expectedReportData([]byte("another-key"), nil, nil)
+				body.ReportData = other[:]
+			},
+			wantErr: "REPORTDATA mismatch",
+		},
+		{
+			name:     "RTMR[3] extended with another image",
+			mutate:   validBody,
+			imageRef: "ghcr.io/example/other@sha256:0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20",
+			wantErr:  "RTMR[3] mismatch",
+		},
+		{
+			name:     "image_ref not pinned by digest",
+			mutate:   validBody,
+			imageRef: "ghcr.io/example/app:latest",
+			wantErr:  "is not a `<registry>@sha256:<hex>` reference",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			verifier := tc.verifier
+			if verifier == nil {
+				verifier = &fakeTDXVerifier{}
+			}
+			ref := imageRef
+			if tc.imageRef != "" {
+				ref = tc.imageRef
+			}
+			key := rsaKey
+			if tc.name == "missing RSA key" {
+				key = nil
+			}
+			m := newTDXMethod(t, verifier)
+			_, err := m.Verify(&AttestationRequest{
+				AppID:        "my-app",
+				Attestation:  b64(buildTDXEvidenceJSON(t, buildTDXQuote(t, tc.mutate), ref, goldenTDXCollateral())),
+				RSAPubKeyTmp: key,
+			})
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestEigenXTDXVerify_BootMeasurementAllowlist(t *testing.T) {
+	rsaKey := []byte("test-rsa-public-key-pem")
+	imageRef := "ghcr.io/example/app@sha256:0102030405060708090a0b0c0d0e0f101112131415161718191a1b1c1d1e1f20"
+	rd := expectedReportData(rsaKey, nil, nil)
+	quote := buildTDXQuote(t, func(body *pb.TDQuoteBody) {
+		body.ReportData = rd[:]
+		body.Rtmrs[3] = imageRTMR(imageRef)
+	})
+	parsed, err := abi.QuoteToProto(quote)
+	require.NoError(t, err)
+	body := parsed.(*pb.QuoteV4).GetTdQuoteBody()
+	booted := TDXBootMeasurement{MRTD: body.GetMrTd(), RTMR0: body.GetRtmrs()[0], RTMR1: body.GetRtmrs()[1], RTMR2: body.GetRtmrs()[2]}
+	otherKernel := booted
+	otherKernel.RTMR1 = bytes.Repeat([]byte{0xaa}, 48)
+
+	req := &AttestationRequest{
+		AppID:        "my-app",
+		Attestation:  b64(buildTDXEvidenceJSON(t, quote, imageRef, goldenTDXCollateral())),
+		RSAPubKeyTmp: rsaKey,
+	}
+	m := newTDXMethod(t, &fakeTDXVerifier{})
+
+	// Fails closed until an allowlist is set
+	unpinned := newEigenXTDXMethod(&fakeTDXVerifier{}, slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+	unpinned.now = func() time.Time { return goldenTDXTime }
+	_, err = unpinned.Verify(req)
+	require.ErrorContains(t, err, "allowlist is not configured")
+	require.NoError(t, unpinned.SetBootMeasurementAllowlist(nil))
+	_, err = unpinned.Verify(req)
+	require.ErrorContains(t, err, "allowlist is not configured")
+
+	require.NoError(t, m.SetBootMeasurementAllowlist([]TDXBootMeasurement{otherKernel}))
+	_, err = m.Verify(req)
+	require.ErrorContains(t, err, "not in the allowlist")
+
+	require.NoError(t, m.SetBootMeasurementAllowlist([]TDXBootMeasurement{otherKernel, booted}))
+	_, err = m.Verify(req)
+	require.NoError(t, err)
+
+	require.ErrorContains(t, m.SetBootMeasurementAllowlist([]TDXBootMeasurement{{MRTD: body.GetMrTd()}}), "RTMR0 is 0 bytes")
+}
+
+func TestParseTDXBootMeasurement(t *testing.T) {
+	reg := func(b byte) string { return string(bytes.Repeat([]byte{b}, 96)) }
+
+	bm, err := ParseTDXBootMeasurement("0x" + reg('1') + ":" + reg('2') + ":" + reg('3') + ":" + reg('4'))
+	require.NoError(t, err)
+	assert.Equal(t, bytes.Repeat([]byte{0x11}, 48), bm.MRTD)
+	assert.Equal(t, bytes.Repeat([]byte{0x44}, 48), bm.RTMR2)
+
+	_, err = ParseTDXBootMeasurement(reg('1') + ":" + reg('2'))
+	require.ErrorContains(t, err, "got 2 fields")
+	_, err = ParseTDXBootMeasurement(reg('1') + ":" + reg('2') + ":" + reg('3') + ":abcd")
+	require.ErrorContains(t, err, "RTMR2 is 2 bytes")
+	_, err = ParseTDXBootMeasurement(reg('1') + ":" + reg('2') + ":zz:" + reg('4'))
+	require.ErrorContains(t, err, "field 2")
+}
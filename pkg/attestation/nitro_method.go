@@ -0,0 +1,400 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// NitroMethodName is the registered identifier for this attestation method.
+const NitroMethodName = "nitro"
+
+// awsNitroRootPEM is the AWS Nitro Enclaves Root-G1 certificate
+// (CN=aws.nitro-enclaves), published at
+// https://aws-nitro-enclaves.amazonaws.com/AWS_NitroEnclaves_Root-G1.zip. Its
+// SHA-256 fingerprint is
+// 64:1A:03:21:A3:E2:44:EF:E4:56:46:31:95:D6:06:31:7E:D7:CD:CC:3C:17:56:E0:98:93:F3:C6:8F:79:BB:5B.
+const awsNitroRootPEM = `-----BEGIN CERTIFICATE-----
+MIICETCCAZagAwIBAgIRAPkxdWgbkK/hHUbMtOTn+FYwCgYIKoZIzj0EAwMwSTEL
+MAkGA1UEBhMCVVMxDzANBgNVBAoMBkFtYXpvbjEMMAoGA1UECwwDQVdTMRswGQYD
+VQQDDBJhd3Mubml0cm8tZW5jbGF2ZXMwHhcNMTkxMDI4MTMyODA1WhcNNDkxMDI4
+MTQyODA1WjBJMQswCQYDVQQGEwJVUzEPMA0GA1UECgwGQW1hem9uMQwwCgYDVQQL
+DANBV1MxGzAZBgNVBAMMEmF3cy5uaXRyby1lbmNsYXZlczB2MBAGByqGSM49AgEG
+BSuBBAAiA2IABPwCVOumCMHzaHDimtqQvkY4MpJzbolL//Zy2YlES1BR5TSksfbb
+48C8WBoyt7F2Bw7eEtaaP+ohG2bnUs990d0JX28TcPQXCEPZ3BABIeTPYwEoCWZE
+h8l5YoQwTcU/9KNCMEAwDwYDVR0TAQH/BAUwAwEB/zAdBgNVHQ4EFgQUkCW1DdkF
+R+eWw5b6cp3PmanfS5YwDgYDVR0PAQH/BAQDAgGGMAoGCCqGSM49BAMDA2kAMGYC
+MQCjfy+Rocm9Xue4YnwWmNJVA44fA0P5W2OpYow9OYCVRaEevL8uO1XYru5xtMPW
+rfMCMQCi85sWBbJwKKXdS6BptQFuZbT73o/gBh1qUxl/nNr12UO8Yfwr6wPLb+6N
+IwLz3/Y=
+-----END CERTIFICATE-----
+`
+
+const (
+	// coseSign1Tag is the optional CBOR tag of a COSE_Sign1 message (RFC 9052).
+	coseSign1Tag = 18
+	// coseHeaderAlg is the protected header label for the signature algorithm.
+	coseHeaderAlg = 1
+	// coseAlgES384 is ECDSA with SHA-384, the only algorithm the NSM signs with.
+	coseAlgES384 = -35
+
+	// Size limits the Nitro Secure Module enforces on the caller-chosen fields.
+	nitroMaxPublicKeySize = 1024
+	nitroMaxUserDataSize  = 512
+	nitroMaxNonceSize     = 512
+)
+
+// NitroUserData returns the user_data an enclave must request its attestation
+// document with to bind extraData: SHA-256(extraData). The document itself
+// carries at most 512 bytes of user_data, and extra_data may be up to 1 MB.
+func NitroUserData(extraData []byte) []byte {
+	h := sha256.Sum256(extraData)
+	return h[:]
+}
+
+// NitroAttestationMethod implements AttestationMethod for AWS Nitro Enclaves
+// attestation documents: the COSE_Sign1 (ES384) document the Nitro Secure
+// Module returns, submitted as raw CBOR bytes.
+//
+// The document's certificate must chain to the embedded AWS Nitro root through
+// the document's own cabundle and must sign the document. The method then
+// enforces:
+//
+//  1. Not a debug enclave: PCR0 is non-zero. Nitro zeroes every PCR of an
+//     enclave started with --debug-mode, whose console the parent can read.
+//  2. Key binding: public_key == the ephemeral RSA public key (PEM).
+//  3. Extra data binding: user_data == NitroUserData(extra_data).
+//  4. Image identity: claims.ImageDigest = "nitro-pcr0:<hex PCR0>", the
+//     measurement of the whole enclave image file. A signed image also
+//     surfaces claims.Registry = "nitro-pcr8:<hex PCR8>", the measurement of
+//     its signing certificate. Both are matched against the on-chain release
+//     by pkg/node/handlers.go like any other method's claims.
+type NitroAttestationMethod struct {
+	// roots is the pool the document's certificate chain must verify against.
+	roots  *x509.CertPool
+	now    func() time.Time
+	logger *slog.Logger
+}
+
+// NewNitroAttestationMethod creates a Nitro attestation method that trusts the
+// embedded AWS Nitro Enclaves root.
+func NewNitroAttestationMethod(logger *slog.Logger) *NitroAttestationMethod {
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM([]byte(awsNitroRootPEM)) {
+		// The PEM is a compile-time constant; failing to parse it is a build defect.
+		panic("attestation: embedded AWS Nitro root certificate does not parse")
+	}
+	return newNitroMethod(roots, logger)
+}
+
+// newNitroMethod creates a Nitro attestation method trusting roots (tests use a
+// test CA).
+func newNitroMethod(roots *x509.CertPool, logger *slog.Logger) *NitroAttestationMethod {
+	return &NitroAttestationMethod{
+		roots:  roots,
+		now:    time.Now,
+		logger: logger.With("component", "nitro_attestation"),
+	}
+}
+
+// Name returns the identifier for this attestation method.
+func (m *NitroAttestationMethod) Name() string {
+	return NitroMethodName
+}
+
+// Verify validates a Nitro attestation document and returns the extracted claims.
+func (m *NitroAttestationMethod) Verify(request *AttestationRequest) (*types.AttestationClaims, error) {
+	if request == nil {
+		return nil, fmt.Errorf("attestation request is nil")
+	}
+	if len(request.Attestation) == 0 {
+		return nil, fmt.Errorf("empty attestation document")
+	}
+	if len(request.RSAPubKeyTmp) == 0 {
+		return nil, fmt.Errorf("RSAPubKeyTmp is required for public_key binding")
+	}
+
+	// Step 1: parse the COSE_Sign1 envelope and its payload.
+	doc, err := parseNitroDocument(request.Attestation)
+	if err != nil {
+		return nil, fmt.Errorf("parse Nitro attestation document: %w", err)
+	}
+
+	m.logger.Info("Nitro attestation document parsed",
+		"app_id", request.AppID,
+		"module_id", doc.ModuleID,
+		"timestamp", doc.Timestamp.UTC().Format(time.RFC3339),
+		"pcr0_hex", hex.EncodeToString(doc.PCRs[0]),
+		"pcr8_hex", hex.EncodeToString(doc.PCRs[8]),
+	)
+
+	// Step 2: certificate chain to the Nitro root, then the document signature.
+	if err := doc.verify(m.roots, m.now()); err != nil {
+		return nil, fmt.Errorf("Nitro attestation document verification failed: %w", err)
+	}
+
+	// Step 3: image measurement. A debug-mode enclave reports all-zero PCRs.
+	pcr0 := doc.PCRs[0]
+	if len(pcr0) != sha512.Size384 {
+		return nil, fmt.Errorf("PCR0 is %d bytes, want %d", len(pcr0), sha512.Size384)
+	}
+	if isAllZero(pcr0) {
+		return nil, fmt.Errorf("PCR0 is zero: enclave runs in debug mode")
+	}
+
+	// Step 4: bindings. public_key must be the caller's ephemeral RSA key, so a
+	// replayed document cannot unwrap the response.
+	if subtle.ConstantTimeCompare(doc.PublicKey, request.RSAPubKeyTmp) != 1 {
+		return nil, fmt.Errorf("public_key mismatch: rsa_pubkey not bound to Nitro attestation document")
+	}
+	if subtle.ConstantTimeCompare(doc.UserData, NitroUserData(request.ExtraData)) != 1 {
+		return nil, fmt.Errorf("user_data mismatch: extra_data not bound to Nitro attestation document")
+	}
+
+	claims := &types.AttestationClaims{
+		AppID:       request.AppID,
+		ImageDigest: "nitro-pcr0:" + hex.EncodeToString(pcr0),
+		IssuedAt:    doc.Timestamp.Unix(),
+		ExtraData:   request.ExtraData,
+		// A Nitro document reports no security versions; the enclave's trusted
+		// base is its boot chain, which PCR1 (kernel and bootstrap) pins.
+		Measurements: nitroMeasurements(doc.PCRs),
+	}
+	if pcr8 := doc.PCRs[8]; len(pcr8) > 0 && !isAllZero(pcr8) {
+		claims.Registry = "nitro-pcr8:" + hex.EncodeToString(pcr8)
+	}
+	if len(doc.Nonce) > 0 {
+		claims.Nonce = hex.EncodeToString(doc.Nonce)
+	}
+	return claims, nil
+}
+
+// nitroMeasurements returns the PCRs a Nitro enclave's launch sets: the image
+// (0), kernel and bootstrap (1), application (2), parent instance role (3) and
+// instance ID (4), and the image signer (8). Unset ones are left out.
+func nitroMeasurements(pcrs map[uint64][]byte) map[string]string {
+	out := make(map[string]string)
+	for _, i := range []uint64{0, 1, 2, 3, 4, 8} {
+		if pcr := pcrs[i]; len(pcr) > 0 && !isAllZero(pcr) {
+			out[fmt.Sprintf("pcr%d", i)] = hex.EncodeToString(pcr)
+		}
+	}
+	return out
+}
+
+// nitroDocument is a parsed, not yet verified, Nitro attestation document.
+type nitroDocument struct {
+	ModuleID    string
+	Timestamp   time.Time
+	PCRs        map[uint64][]byte
+	Certificate *x509.Certificate
+	CABundle    []*x509.Certificate
+	PublicKey   []byte
+	UserData    []byte
+	Nonce       []byte
+
+	// COSE_Sign1 fields the signature covers.
+	protected []byte
+	payload   []byte
+	signature []byte
+}
+
+// parseNitroDocument decodes a COSE_Sign1 message and the attestation document
+// in its payload, checking the field types and sizes AWS specifies.
+func parseNitroDocument(b []byte) (*nitroDocument, error) {
+	v, err := decodeCBOR(b)
+	if err != nil {
+		return nil, err
+	}
+	if tag, ok := v.(cborTag); ok {
+		if tag.Number != coseSign1Tag {
+			return nil, fmt.Errorf("unexpected CBOR tag %d, want COSE_Sign1 (%d)", tag.Number, coseSign1Tag)
+		}
+		v = tag.Value
+	}
+	msg, ok := v.([]any)
+	if !ok || len(msg) != 4 {
+		return nil, fmt.Errorf("not a COSE_Sign1 message")
+	}
+	doc := &nitroDocument{}
+	doc.protected, ok = msg[0].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("COSE_Sign1 protected header is not a byte string")
+	}
+	if _, ok = msg[1].(map[any]any); !ok {
+		return nil, fmt.Errorf("COSE_Sign1 unprotected header is not a map")
+	}
+	if doc.payload, ok = msg[2].([]byte); !ok {
+		return nil, fmt.Errorf("COSE_Sign1 payload is not a byte string")
+	}
+	if doc.signature, ok = msg[3].([]byte); !ok {
+		return nil, fmt.Errorf("COSE_Sign1 signature is not a byte string")
+	}
+
+	hv, err := decodeCBOR(doc.protected)
+	if err != nil {
+		return nil, fmt.Errorf("protected header: %w", err)
+	}
+	header, ok := hv.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("protected header is not a map")
+	}
+	if alg, _ := header[uint64(coseHeaderAlg)].(int64); alg != coseAlgES384 {
+		return nil, fmt.Errorf("unsupported COSE algorithm %v, want ES384 (%d)", header[uint64(coseHeaderAlg)], coseAlgES384)
+	}
+
+	pv, err := decodeCBOR(doc.payload)
+	if err != nil {
+		return nil, fmt.Errorf("payload: %w", err)
+	}
+	payload, ok := pv.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("payload is not a map")
+	}
+	if err := doc.parsePayload(payload); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+func (doc *nitroDocument) parsePayload(p map[any]any) error {
+	var ok bool
+	if doc.ModuleID, ok = p["module_id"].(string); !ok || doc.ModuleID == "" {
+		return fmt.Errorf("module_id is required")
+	}
+	if digest, _ := p["digest"].(string); digest != "SHA384" {
+		return fmt.Errorf("digest is %q, want SHA384", p["digest"])
+	}
+	ts, ok := p["timestamp"].(uint64)
+	if !ok || ts == 0 || ts > 1<<62 {
+		return fmt.Errorf("timestamp is required")
+	}
+	doc.Timestamp = time.UnixMilli(int64(ts))
+
+	pcrs, ok := p["pcrs"].(map[any]any)
+	if !ok || len(pcrs) == 0 || len(pcrs) > 32 {
+		return fmt.Errorf("pcrs must hold 1 to 32 entries")
+	}
+	doc.PCRs = make(map[uint64][]byte, len(pcrs))
+	for k, v := range pcrs {
+		idx, ok := k.(uint64)
+		if !ok || idx >= 32 {
+			return fmt.Errorf("invalid PCR index %v", k)
+		}
+		value, ok := v.([]byte)
+		if !ok || (len(value) != 32 && len(value) != 48 && len(value) != 64) {
+			return fmt.Errorf("PCR%d is not a 32, 48 or 64 byte value", idx)
+		}
+		doc.PCRs[idx] = value
+	}
+
+	der, ok := p["certificate"].([]byte)
+	if !ok || len(der) == 0 {
+		return fmt.Errorf("certificate is required")
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return fmt.Errorf("parse certificate: %w", err)
+	}
+	doc.Certificate = cert
+
+	bundle, ok := p["cabundle"].([]any)
+	if !ok || len(bundle) == 0 {
+		return fmt.Errorf("cabundle is required")
+	}
+	for i, entry := range bundle {
+		der, ok := entry.([]byte)
+		if !ok {
+			return fmt.Errorf("cabundle[%d] is not a byte string", i)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return fmt.Errorf("parse cabundle[%d]: %w", i, err)
+		}
+		doc.CABundle = append(doc.CABundle, cert)
+	}
+
+	if doc.PublicKey, err = optionalBytes(p, "public_key", nitroMaxPublicKeySize); err != nil {
+		return err
+	}
+	if doc.UserData, err = optionalBytes(p, "user_data", nitroMaxUserDataSize); err != nil {
+		return err
+	}
+	if doc.Nonce, err = optionalBytes(p, "nonce", nitroMaxNonceSize); err != nil {
+		return err
+	}
+	return nil
+}
+
+// optionalBytes reads a byte-string-or-null payload field of at most max bytes.
+func optionalBytes(p map[any]any, key string, max int) ([]byte, error) {
+	v, present := p[key]
+	if !present || v == nil {
+		return nil, nil
+	}
+	b, ok := v.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a byte string", key)
+	}
+	if len(b) > max {
+		return nil, fmt.Errorf("%s is %d bytes, max %d", key, len(b), max)
+	}
+	return b, nil
+}
+
+// verify checks the document certificate against roots through cabundle at now,
+// then the ES384 signature over the COSE Sig_structure.
+func (doc *nitroDocument) verify(roots *x509.CertPool, now time.Time) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range doc.CABundle {
+		intermediates.AddCert(cert)
+	}
+	if _, err := doc.Certificate.Verify(x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}); err != nil {
+		return fmt.Errorf("certificate chain: %w", err)
+	}
+
+	pub, ok := doc.Certificate.PublicKey.(*ecdsa.PublicKey)
+	if !ok || pub.Curve != elliptic.P384() {
+		return fmt.Errorf("document certificate key is not ECDSA P-384")
+	}
+	if len(doc.signature) != 96 {
+		return fmt.Errorf("signature is %d bytes, want 96", len(doc.signature))
+	}
+	r := new(big.Int).SetBytes(doc.signature[:48])
+	s := new(big.Int).SetBytes(doc.signature[48:])
+	digest := sha512.Sum384(coseSign1SigStructure(doc.protected, doc.payload))
+	if !ecdsa.Verify(pub, digest[:], r, s) {
+		return fmt.Errorf("signature does not verify")
+	}
+	return nil
+}
+
+// coseSign1SigStructure encodes the COSE Sig_structure a COSE_Sign1 signature
+// covers: ["Signature1", protected, external_aad (empty), payload].
+func coseSign1SigStructure(protected, payload []byte) []byte {
+	b := appendCBORHead(nil, cborMajorArray, 4)
+	b = appendCBORText(b, "Signature1")
+	b = appendCBORBytes(b, protected)
+	b = appendCBORBytes(b, nil)
+	return appendCBORBytes(b, payload)
+}
+
+func isAllZero(b []byte) bool {
+	return len(bytes.Trim(b, "\x00")) == 0
+}
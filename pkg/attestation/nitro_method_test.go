@@ -0,0 +1,403 @@
+package attestation
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"log/slog"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The recorded document in testdata/nitro was issued under a test CA shaped
+// like the AWS hierarchy (root, zonal intermediate, enclave certificate valid
+// for three hours) at nitroRecordedTime, for the RSA key in rsa_public_key.pem
+// and nitroRecordedExtraData, with nonce "0123456789abcdef".
+var (
+	nitroRecordedTime      = time.Date(2026, time.March, 2, 10, 15, 0, 0, time.UTC)
+	nitroRecordedExtraData = []byte("nitro-recorded-extra-data")
+	nitroRecordedPCR0      = "8f1c3a4e5d6b7a8998a7b6c5d4e3f2011f2e3d4c5b6a79880f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a6978"
+	nitroRecordedPCR8      = "4c2a7e915d3b6f08a1c9e2d47b5f3a6081d2c4e6f8a0b1c3d5e7f9a2b4c6d8e0f1a3b5c7d9e1f2a4b6c8d0e2f4a6b8c0"
+)
+
+func readNitroTestdata(t *testing.T, name string) []byte {
+	t.Helper()
+	b, err := os.ReadFile(filepath.Join("testdata", "nitro", name))
+	require.NoError(t, err)
+	return b
+}
+
+func nitroRootPool(t *testing.T, pemBytes []byte) *x509.CertPool {
+	t.Helper()
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(pemBytes))
+	return pool
+}
+
+func newNitroTestMethod(t *testing.T, roots *x509.CertPool, now time.Time) *NitroAttestationMethod {
+	t.Helper()
+	logger := slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError}))
+	m := newNitroMethod(roots, logger)
+	m.now = func() time.Time { return now }
+	return m
+}
+
+// nitroTestCA issues documents the way the Nitro hypervisor does: a root, one
+// intermediate and a short-lived P-384 certificate that signs the document.
+type nitroTestCA struct {
+	root            *x509.Certificate
+	intermediate    *x509.Certificate
+	leaf            *x509.Certificate
+	leafKey         *ecdsa.PrivateKey
+	rootPEM         []byte
+	intermediateKey *ecdsa.PrivateKey
+}
+
+func newNitroTestCA(t *testing.T, issuedAt time.Time) *nitroTestCA {
+	t.Helper()
+	issue := func(cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, notAfter time.Time, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+		serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber:          serial,
+			Subject:               pkix.Name{CommonName: cn, Organization: []string{"Amazon"}, OrganizationalUnit: []string{"AWS"}},
+			NotBefore:             issuedAt.Add(-time.Minute),
+			NotAfter:              notAfter,
+			BasicConstraintsValid: true,
+			IsCA:                  isCA,
+			KeyUsage:              x509.KeyUsageDigitalSignature,
+		}
+		if isCA {
+			tmpl.KeyUsage |= x509.KeyUsageCertSign | x509.KeyUsageCRLSign
+		}
+		signer, signerKey := parent, parentKey
+		if signer == nil {
+			signer, signerKey = tmpl, key
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tmpl, signer, &key.PublicKey, signerKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(der)
+		require.NoError(t, err)
+		return cert, key
+	}
+
+	ca := &nitroTestCA{}
+	var rootKey *ecdsa.PrivateKey
+	ca.root, rootKey = issue("test.nitro-enclaves", nil, nil, issuedAt.AddDate(30, 0, 0), true)
+	ca.intermediate, ca.intermediateKey = issue("test.us-east-1.aws.nitro-enclaves", ca.root, rootKey, issuedAt.AddDate(0, 0, 30), true)
+	ca.leaf, ca.leafKey = issue("i-0123456789abcdef0-enc0123456789abcdef.us-east-1.aws", ca.intermediate, ca.intermediateKey, issuedAt.Add(3*time.Hour), false)
+	ca.rootPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.root.Raw})
+	return ca
+}
+
+// cborKV is one entry of a test CBOR map; a slice of them keeps encoding order.
+type cborKV struct {
+	k, v any
+}
+
+// encodeTestCBOR encodes the values the Nitro tests build documents from.
+func encodeTestCBOR(t *testing.T, v any) []byte {
+	t.Helper()
+	switch v := v.(type) {
+	case nil:
+		return []byte{0xf6}
+	case int:
+		if v < 0 {
+			return appendCBORHead(nil, cborMajorNegInt, uint64(-1-v))
+		}
+		return appendCBORHead(nil, cborMajorUint, uint64(v))
+	case uint64:
+		return appendCBORHead(nil, cborMajorUint, v)
+	case []byte:
+		return appendCBORBytes(nil, v)
+	case string:
+		return appendCBORText(nil, v)
+	case []any:
+		b := appendCBORHead(nil, cborMajorArray, uint64(len(v)))
+		for _, item := range v {
+			b = append(b, encodeTestCBOR(t, item)...)
+		}
+		return b
+	case []cborKV:
+		b := appendCBORHead(nil, cborMajorMap, uint64(len(v)))
+		for _, kv := range v {
+			b = append(b, encodeTestCBOR(t, kv.k)...)
+			b = append(b, encodeTestCBOR(t, kv.v)...)
+		}
+		return b
+	case cborTag:
+		return append(appendCBORHead(nil, cborMajorTag, v.Number), encodeTestCBOR(t, v.Value)...)
+	default:
+		t.Fatalf("encodeTestCBOR: unsupported type %T", v)
+		return nil
+	}
+}
+
+// nitroTestDocument holds the fields of a document before it is encoded and
+// signed; tests change them to build rejected documents.
+type nitroTestDocument struct {
+	protected []cborKV
+	timestamp time.Time
+	digest    string
+	pcrs      map[uint64][]byte
+	cert      []byte
+	cabundle  [][]byte
+	publicKey []byte
+	userData  []byte
+	nonce     []byte
+	tagged    bool
+}
+
+func newNitroTestDocument(ca *nitroTestCA, issuedAt time.Time, rsaPubKey, extraData []byte) *nitroTestDocument {
+	pcrs := make(map[uint64][]byte, 16)
+	for i := uint64(0); i < 16; i++ {
+		pcrs[i] = make([]byte, sha512.Size384)
+	}
+	pcrs[0], _ = hex.DecodeString(nitroRecordedPCR0)
+	pcrs[1] = bytes.Repeat([]byte{0x11}, sha512.Size384)
+	pcrs[2] = bytes.Repeat([]byte{0x22}, sha512.Size384)
+	return &nitroTestDocument{
+		protected: []cborKV{{coseHeaderAlg, coseAlgES384}},
+		timestamp: issuedAt,
+		digest:    "SHA384",
+		pcrs:      pcrs,
+		cert:      ca.leaf.Raw,
+		cabundle:  [][]byte{ca.root.Raw, ca.intermediate.Raw},
+		publicKey: rsaPubKey,
+		userData:  NitroUserData(extraData),
+		tagged:    true,
+	}
+}
+
+func (d *nitroTestDocument) sign(t *testing.T, key *ecdsa.PrivateKey) []byte {
+	t.Helper()
+	pcrs := make([]cborKV, 0, len(d.pcrs))
+	for i := uint64(0); i < 32; i++ {
+		if v, ok := d.pcrs[i]; ok {
+			pcrs = append(pcrs, cborKV{i, v})
+		}
+	}
+	bundle := make([]any, len(d.cabundle))
+	for i, c := range d.cabundle {
+		bundle[i] = c
+	}
+	orNil := func(b []byte) any {
+		if b == nil {
+			return nil
+		}
+		return b
+	}
+	payload := encodeTestCBOR(t, []cborKV{
+		{"module_id", "i-0123456789abcdef0-enc0123456789abcdef"},
+		{"digest", d.digest},
+		{"timestamp", uint64(d.timestamp.UnixMilli())},
+		{"pcrs", pcrs},
+		{"certificate", d.cert},
+		{"cabundle", bundle},
+		{"public_key", orNil(d.publicKey)},
+		{"user_data", orNil(d.userData)},
+		{"nonce", orNil(d.nonce)},
+	})
+	protected := encodeTestCBOR(t, d.protected)
+
+	digest := sha512.Sum384(coseSign1SigStructure(protected, payload))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	require.NoError(t, err)
+	sig := make([]byte, 96)
+	r.FillBytes(sig[:48])
+	s.FillBytes(sig[48:])
+
+	var msg any = []any{protected, []cborKV{}, payload, sig}
+	if d.tagged {
+		msg = cborTag{Number: coseSign1Tag, Value: msg}
+	}
+	return encodeTestCBOR(t, msg)
+}
+
+func TestNitroMethodName(t *testing.T) {
+	m := NewNitroAttestationMethod(slog.New(slog.NewTextHandler(os.Stderr, nil)))
+	assert.Equal(t, "nitro", m.Name())
+}
+
+func TestNitroVerify_RecordedDocument(t *testing.T) {
+	doc := readNitroTestdata(t, "attestation_document.cbor")
+	rsaPubKey := readNitroTestdata(t, "rsa_public_key.pem")
+	roots := nitroRootPool(t, readNitroTestdata(t, "root.pem"))
+	request := func() *AttestationRequest {
+		return &AttestationRequest{
+			Method:       "nitro",
+			AppID:        "my-app",
+			Attestation:  doc,
+			RSAPubKeyTmp: rsaPubKey,
+			ExtraData:    nitroRecordedExtraData,
+		}
+	}
+
+	claims, err := newNitroTestMethod(t, roots, nitroRecordedTime.Add(time.Minute)).Verify(request())
+	require.NoError(t, err)
+	assert.Equal(t, "my-app", claims.AppID)
+	assert.Equal(t, "nitro-pcr0:"+nitroRecordedPCR0, claims.ImageDigest)
+	assert.Equal(t, "nitro-pcr8:"+nitroRecordedPCR8, claims.Registry)
+	assert.Equal(t, nitroRecordedPCR0, claims.Measurements["pcr0"])
+	assert.Equal(t, nitroRecordedPCR8, claims.Measurements["pcr8"])
+	assert.Contains(t, claims.Measurements, "pcr1", "the boot chain is what a Nitro policy pins instead of a TCB version")
+	assert.Empty(t, claims.TCB, "a Nitro document reports no security versions")
+	assert.Equal(t, nitroRecordedTime.Unix(), claims.IssuedAt)
+	assert.Equal(t, hex.EncodeToString([]byte("0123456789abcdef")), claims.Nonce)
+	assert.Equal(t, nitroRecordedExtraData, claims.ExtraData)
+	assert.Empty(t, claims.JTI)
+
+	t.Run("AWS root does not trust the test CA", func(t *testing.T) {
+		m := NewNitroAttestationMethod(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelError})))
+		m.now = func() time.Time { return nitroRecordedTime.Add(time.Minute) }
+		_, err := m.Verify(request())
+		require.ErrorContains(t, err, "certificate signed by unknown authority")
+	})
+
+	t.Run("expired enclave certificate", func(t *testing.T) {
+		_, err := newNitroTestMethod(t, roots, nitroRecordedTime.Add(4*time.Hour)).Verify(request())
+		require.ErrorContains(t, err, "certificate has expired")
+	})
+
+	t.Run("other RSA key", func(t *testing.T) {
+		req := request()
+		req.RSAPubKeyTmp = []byte("-----BEGIN PUBLIC KEY-----\nother\n-----END PUBLIC KEY-----\n")
+		_, err := newNitroTestMethod(t, roots, nitroRecordedTime).Verify(req)
+		require.ErrorContains(t, err, "public_key mismatch")
+	})
+
+	t.Run("other extra data", func(t *testing.T) {
+		req := request()
+		req.ExtraData = []byte("substituted")
+		_, err := newNitroTestMethod(t, roots, nitroRecordedTime).Verify(req)
+		require.ErrorContains(t, err, "user_data mismatch")
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		req := request()
+		req.Attestation = bytes.Replace(doc, []byte("i-0123456789abcdef0"), []byte("i-0fedcba9876543210"), 1)
+		require.NotEqual(t, doc, req.Attestation)
+		_, err := newNitroTestMethod(t, roots, nitroRecordedTime).Verify(req)
+		require.ErrorContains(t, err, "signature does not verify")
+	})
+}
+
+func TestNitroVerify_Rejections(t *testing.T) {
+	now := time.Date(2026, time.April, 1, 12, 0, 0, 0, time.UTC)
+	ca := newNitroTestCA(t, now)
+	rsaPubKey := []byte("test-rsa-public-key-pem")
+	roots := nitroRootPool(t, ca.rootPEM)
+
+	verify := func(t *testing.T, attestation []byte) error {
+		t.Helper()
+		_, err := newNitroTestMethod(t, roots, now).Verify(&AttestationRequest{
+			AppID:        "my-app",
+			Attestation:  attestation,
+			RSAPubKeyTmp: rsaPubKey,
+		})
+		return err
+	}
+
+	t.Run("untagged document with no signer", func(t *testing.T) {
+		d := newNitroTestDocument(ca, now, rsaPubKey, nil)
+		d.tagged = false
+		d.pcrs[8] = make([]byte, sha512.Size384)
+		claims, err := newNitroTestMethod(t, roots, now).Verify(&AttestationRequest{
+			AppID:        "my-app",
+			Attestation:  d.sign(t, ca.leafKey),
+			RSAPubKeyTmp: rsaPubKey,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, claims.Registry)
+	})
+
+	tests := []struct {
+		name    string
+		mutate  func(d *nitroTestDocument)
+		signer  *ecdsa.PrivateKey
+		wantErr string
+	}{
+		{
+			name:    "debug-mode enclave",
+			mutate:  func(d *nitroTestDocument) { d.pcrs[0] = make([]byte, sha512.Size384) },
+			wantErr: "debug mode",
+		},
+		{
+			name:    "signed by another key",
+			signer:  ca.intermediateKey,
+			wantErr: "signature does not verify",
+		},
+		{
+			name:    "unsupported algorithm",
+			mutate:  func(d *nitroTestDocument) { d.protected = []cborKV{{coseHeaderAlg, -7}} },
+			wantErr: "unsupported COSE algorithm",
+		},
+		{
+			name:    "wrong digest",
+			mutate:  func(d *nitroTestDocument) { d.digest = "SHA256" },
+			wantErr: "want SHA384",
+		},
+		{
+			name:    "intermediate missing from cabundle",
+			mutate:  func(d *nitroTestDocument) { d.cabundle = d.cabundle[:1] },
+			wantErr: "certificate chain",
+		},
+		{
+			name:    "no public_key",
+			mutate:  func(d *nitroTestDocument) { d.publicKey = nil },
+			wantErr: "public_key mismatch",
+		},
+		{
+			name:    "oversized user_data",
+			mutate:  func(d *nitroTestDocument) { d.userData = make([]byte, 513) },
+			wantErr: "user_data is 513 bytes",
+		},
+		{
+			name:    "no certificate",
+			mutate:  func(d *nitroTestDocument) { d.cert = nil },
+			wantErr: "certificate is required",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			d := newNitroTestDocument(ca, now, rsaPubKey, nil)
+			if tc.mutate != nil {
+				tc.mutate(d)
+			}
+			signer := tc.signer
+			if signer == nil {
+				signer = ca.leafKey
+			}
+			require.ErrorContains(t, verify(t, d.sign(t, signer)), tc.wantErr)
+		})
+	}
+
+	t.Run("trailing bytes", func(t *testing.T) {
+		doc := newNitroTestDocument(ca, now, rsaPubKey, nil).sign(t, ca.leafKey)
+		require.ErrorContains(t, verify(t, append(doc, 0x00)), "trailing bytes")
+	})
+
+	t.Run("not a COSE_Sign1 message", func(t *testing.T) {
+		require.ErrorContains(t, verify(t, encodeTestCBOR(t, []any{[]byte{}, []byte{}})), "not a COSE_Sign1 message")
+	})
+}
+
+func TestNitroUserData(t *testing.T) {
+	assert.Len(t, NitroUserData(nil), 32)
+	assert.NotEqual(t, NitroUserData(nil), NitroUserData([]byte("x")))
+}
@@ -0,0 +1,343 @@
+// Package authz evaluates operator-supplied authorization rules for /secrets and the
+// endpoints authorized like it.
+//
+// A policy is an ordered list of rules, each a CEL expression over the verified
+// attestation claims, the app's on-chain release, the request and the serving
+// node. The first rule whose expression is true decides the request; when none
+// matches the policy's default effect applies.
+//
+// The node authenticates a request (attestation, app ID binding, replay, key
+// binding, ecdsa ownership) before a policy sees it; whether the authenticated
+// app may have its key is the policy's decision alone. The default policy
+// (default_policy.yaml) holds the release checks: app allowlist, release
+// presence, image digest, registry and container policy. A policy file's rules
+// run after them unless it replaces them.
+package authz
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/google/cel-go/cel"
+	"gopkg.in/yaml.v3"
+)
+
+// Effect is what a matching rule does to a request.
+type Effect string
+
+const (
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
+// DefaultRuleName names the decision taken when no rule matches. Rules may not
+// use it.
+const DefaultRuleName = "default"
+
+// ruleCostLimit bounds the evaluation cost of one rule, so a rule iterating over
+// request-controlled lists cannot stall /secrets.
+const ruleCostLimit = 100_000
+
+// Document is the on-disk form of a policy (YAML or JSON).
+type Document struct {
+	// Default is the effect when no rule matches. Empty means allow.
+	Default Effect `yaml:"default"`
+	// ReplaceDefaultRules drops the default policy's rules instead of running
+	// Rules after them. A policy that sets it decides alone, including whether the
+	// attested image must match the release.
+	ReplaceDefaultRules bool `yaml:"replace_default_rules"`
+	// Rules are evaluated in order; the first match decides.
+	Rules []Rule `yaml:"rules"`
+}
+
+//go:embed default_policy.yaml
+var defaultPolicyYAML []byte
+
+// defaultRules are the rules of the default policy document.
+var defaultRules = sync.OnceValue(func() []Rule {
+	doc, err := decode(defaultPolicyYAML)
+	if err != nil {
+		panic(fmt.Sprintf("authz: invalid default policy: %v", err))
+	}
+	return doc.Rules
+})
+
+// defaultPolicy is the compiled default policy document.
+var defaultPolicy = sync.OnceValue(func() *Policy {
+	// The document holds the default rules, so it replaces rather than repeats them.
+	p, err := Compile(Document{Default: EffectAllow, ReplaceDefaultRules: true, Rules: defaultRules()})
+	if err != nil {
+		panic(fmt.Sprintf("authz: invalid default policy: %v", err))
+	}
+	return p
+})
+
+// Rule is one named CEL condition and the effect it has when true.
+type Rule struct {
+	Name   string `yaml:"name"`
+	Effect Effect `yaml:"effect"`
+	// Match is a CEL expression of type bool over the variables request, node,
+	// claims and release (see Input).
+	Match string `yaml:"match"`
+}
+
+// Input is what a policy decides on.
+//
+// Rules see it as four CEL maps:
+//
+//	request: endpoint, app_id, attestation_method, stack_id
+//	node:    operator_address, chain_id, key_id, app_allowlist {app_id: true}
+//	         (null when every app is allowed)
+//	claims:  app_id, image_digest, registry, nonce, issued_at, expires_at,
+//	         container_policy {args, cmd_override, env, env_override,
+//	         restart_policy}, tcb {component: version},
+//	         measurements {register: hex}
+//	release: image_digest, registry, timestamp, container_policy; null on the
+//	         platform (stack_id) path and when the app has no release
+type Input struct {
+	// Endpoint is the route being authorized, e.g. "/secrets" or
+	// "/v1/app/sign-message", without a key's route prefix.
+	Endpoint          string
+	AppID             string
+	AttestationMethod string
+	StackID           string
+
+	OperatorAddress string
+	ChainID         uint64
+	KeyID           string
+	// AppAllowlist is the node's app allowlist; nil allows every app.
+	AppAllowlist map[string]bool
+
+	Claims  *types.AttestationClaims
+	Release *types.Release
+}
+
+// Decision is the outcome of evaluating a policy.
+type Decision struct {
+	Allow bool
+	// Rule is the name of the rule that decided, or DefaultRuleName.
+	Rule string
+}
+
+// Policy is a compiled Document.
+type Policy struct {
+	defaultAllow bool
+	rules        []compiledRule
+}
+
+type compiledRule struct {
+	name  string
+	allow bool
+	prg   cel.Program
+}
+
+// DefaultPolicy returns the policy a node enforces without a policy file: the
+// rules of default_policy.yaml, allowing what none of them denies.
+func DefaultPolicy() *Policy {
+	return defaultPolicy()
+}
+
+// Parse decodes and compiles a policy document. Unknown fields are an error, so a
+// misspelled key cannot silently drop a rule.
+func Parse(data []byte) (*Policy, error) {
+	doc, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	return Compile(doc)
+}
+
+func decode(data []byte) (Document, error) {
+	var doc Document
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&doc); err != nil {
+		return Document{}, fmt.Errorf("decode policy: %w", err)
+	}
+	return doc, nil
+}
+
+// Compile type-checks every rule of doc, after the default policy's rules unless
+// doc replaces them.
+func Compile(doc Document) (*Policy, error) {
+	env, err := newEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Policy{}
+	switch doc.Default {
+	case "", EffectAllow:
+		p.defaultAllow = true
+	case EffectDeny:
+	default:
+		return nil, fmt.Errorf("invalid default effect %q (want %q or %q)", doc.Default, EffectAllow, EffectDeny)
+	}
+
+	rules := doc.Rules
+	if !doc.ReplaceDefaultRules {
+		rules = append(slices.Clone(defaultRules()), doc.Rules...)
+	}
+	// Errors index the document's own rules.
+	offset := len(rules) - len(doc.Rules)
+	seen := make(map[string]bool, len(rules))
+	for i, rule := range rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("rule %d: name is required", i-offset)
+		}
+		if rule.Name == DefaultRuleName {
+			return nil, fmt.Errorf("rule %d: name %q is reserved", i-offset, DefaultRuleName)
+		}
+		if seen[rule.Name] {
+			return nil, fmt.Errorf("rule %q: duplicate name (set replace_default_rules to redefine a default rule)", rule.Name)
+		}
+		seen[rule.Name] = true
+
+		var allow bool
+		switch rule.Effect {
+		case EffectAllow:
+			allow = true
+		case EffectDeny:
+		default:
+			return nil, fmt.Errorf("rule %q: invalid effect %q (want %q or %q)", rule.Name, rule.Effect, EffectAllow, EffectDeny)
+		}
+
+		if strings.TrimSpace(rule.Match) == "" {
+			return nil, fmt.Errorf("rule %q: match is required", rule.Name)
+		}
+		ast, iss := env.Compile(rule.Match)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, iss.Err())
+		}
+		if t := ast.OutputType(); t != cel.BoolType && t != cel.DynType {
+			return nil, fmt.Errorf("rule %q: match has type %s, want bool", rule.Name, t)
+		}
+		prg, err := env.Program(ast, cel.CostLimit(ruleCostLimit))
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		p.rules = append(p.rules, compiledRule{name: rule.Name, allow: allow, prg: prg})
+	}
+	return p, nil
+}
+
+func newEnv() (*cel.Env, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("request", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("node", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("claims", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("release", cel.DynType),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create CEL environment: %w", err)
+	}
+	return env, nil
+}
+
+// Rules returns the number of rules in the policy, including the default rules it
+// runs.
+func (p *Policy) Rules() int {
+	return len(p.rules)
+}
+
+// Evaluate decides in against the policy. A rule that fails to evaluate (a
+// missing key, a type error, the cost limit) denies the request and returns the
+// error; rules that must tolerate absent claims should guard with has().
+func (p *Policy) Evaluate(in *Input) (Decision, error) {
+	vars := in.activation()
+	for _, rule := range p.rules {
+		out, _, err := rule.prg.Eval(vars)
+		if err != nil {
+			return Decision{Rule: rule.name}, fmt.Errorf("rule %q: %w", rule.name, err)
+		}
+		matched, ok := out.Value().(bool)
+		if !ok {
+			return Decision{Rule: rule.name}, fmt.Errorf("rule %q: evaluated to %s, want bool", rule.name, out.Type().TypeName())
+		}
+		if matched {
+			return Decision{Allow: rule.allow, Rule: rule.name}, nil
+		}
+	}
+	return Decision{Allow: p.defaultAllow, Rule: DefaultRuleName}, nil
+}
+
+// activation builds the CEL variables for in.
+func (in *Input) activation() map[string]any {
+	node := map[string]any{
+		"operator_address": in.OperatorAddress,
+		"chain_id":         int64(in.ChainID),
+		"key_id":           in.KeyID,
+		"app_allowlist":    nil,
+	}
+	if in.AppAllowlist != nil {
+		node["app_allowlist"] = in.AppAllowlist
+	}
+	vars := map[string]any{
+		"request": map[string]any{
+			"endpoint":           in.Endpoint,
+			"app_id":             in.AppID,
+			"attestation_method": in.AttestationMethod,
+			"stack_id":           in.StackID,
+		},
+		"node":    node,
+		"claims":  map[string]any{},
+		"release": nil,
+	}
+	if c := in.Claims; c != nil {
+		tcb := make(map[string]any, len(c.TCB))
+		for component, version := range c.TCB {
+			tcb[component] = int64(version)
+		}
+		vars["claims"] = map[string]any{
+			"app_id":           c.AppID,
+			"image_digest":     c.ImageDigest,
+			"registry":         c.Registry,
+			"nonce":            c.Nonce,
+			"issued_at":        c.IssuedAt,
+			"expires_at":       c.ExpiresAt,
+			"container_policy": containerPolicyValue(c.ContainerPolicy),
+			"tcb":              tcb,
+			"measurements":     nonNilMap(c.Measurements),
+		}
+	}
+	if r := in.Release; r != nil {
+		vars["release"] = map[string]any{
+			"image_digest":     r.ImageDigest,
+			"registry":         r.Registry,
+			"timestamp":        r.Timestamp,
+			"container_policy": containerPolicyValue(r.ContainerPolicy),
+		}
+	}
+	return vars
+}
+
+func containerPolicyValue(cp types.ContainerPolicy) map[string]any {
+	return map[string]any{
+		"args":           nonNilStrings(cp.Args),
+		"cmd_override":   nonNilStrings(cp.CmdOverride),
+		"env":            nonNilMap(cp.Env),
+		"env_override":   nonNilMap(cp.EnvOverride),
+		"restart_policy": cp.RestartPolicy,
+	}
+}
+
+// nonNilStrings and nonNilMap give rules empty collections rather than null, so
+// size() and in work on fields a method does not surface.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func nonNilMap(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
@@ -0,0 +1,396 @@
+package authz
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// examplePolicy holds the rules docs/017_authorizationPolicy.md walks through.
+const examplePolicy = `
+rules:
+  - name: deny-ecdsa-on-mainnet
+    effect: deny
+    match: request.attestation_method == "ecdsa" && node.chain_id == 1
+  - name: eigenx-snp-only-for-listed-apps
+    effect: deny
+    match: >-
+      request.attestation_method == "eigenx-snp" &&
+      !(request.app_id in ["0x1111111111111111111111111111111111111111"])
+  - name: snp-minimum-firmware
+    effect: deny
+    match: request.attestation_method == "eigenx-snp" && claims.tcb.snp < 22
+`
+
+func snpInput(appID string, snpSPL uint64) *Input {
+	return &Input{
+		Endpoint:          "/secrets",
+		AppID:             appID,
+		AttestationMethod: "eigenx-snp",
+		ChainID:           1,
+		Claims: &types.AttestationClaims{
+			AppID:       appID,
+			ImageDigest: "sha256:abcd",
+			TCB:         map[string]uint64{"bootloader": 10, "tee": 0, "snp": snpSPL, "microcode": 213},
+		},
+		Release: &types.Release{ImageDigest: "sha256:abcd"},
+	}
+}
+
+func TestParse_ExamplePolicy(t *testing.T) {
+	p, err := Parse([]byte(examplePolicy))
+	require.NoError(t, err)
+	assert.Equal(t, len(defaultRules())+3, p.Rules(), "the example's rules run after the default rules")
+
+	const listed = "0x1111111111111111111111111111111111111111"
+	for _, tc := range []struct {
+		name      string
+		in        *Input
+		wantAllow bool
+		wantRule  string
+	}{
+		{
+			name:      "listed snp app on current firmware",
+			in:        snpInput(listed, 24),
+			wantAllow: true,
+			wantRule:  DefaultRuleName,
+		},
+		{
+			name:     "unlisted snp app",
+			in:       snpInput("0x2222222222222222222222222222222222222222", 24),
+			wantRule: "eigenx-snp-only-for-listed-apps",
+		},
+		{
+			name:     "listed snp app on old firmware",
+			in:       snpInput(listed, 21),
+			wantRule: "snp-minimum-firmware",
+		},
+		{
+			name:     "ecdsa on mainnet",
+			in:       &Input{AppID: listed, AttestationMethod: "ecdsa", ChainID: 1, Release: &types.Release{}},
+			wantRule: "deny-ecdsa-on-mainnet",
+		},
+		{
+			name:      "ecdsa on sepolia",
+			in:        &Input{AppID: listed, AttestationMethod: "ecdsa", ChainID: 11155111, Release: &types.Release{}},
+			wantAllow: true,
+			wantRule:  DefaultRuleName,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := p.Evaluate(tc.in)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantAllow, d.Allow)
+			assert.Equal(t, tc.wantRule, d.Rule)
+		})
+	}
+}
+
+// releaseInput is a gcp request whose claims match its release.
+func releaseInput(mutate func(*Input)) *Input {
+	in := &Input{
+		Endpoint:          "/secrets",
+		AppID:             "app",
+		AttestationMethod: "gcp",
+		Claims: &types.AttestationClaims{
+			AppID:       "app",
+			ImageDigest: "sha256:abcd",
+			Registry:    "ghcr.io/example/app",
+			ContainerPolicy: types.ContainerPolicy{
+				Args:          []string{"serve"},
+				Env:           map[string]string{"MODE": "prod", "EXTRA": "1"},
+				RestartPolicy: "always",
+			},
+		},
+		Release: &types.Release{
+			ImageDigest: "sha256:abcd",
+			Registry:    "ghcr.io/example/app",
+			ContainerPolicy: types.ContainerPolicy{
+				Args:          []string{"serve"},
+				Env:           map[string]string{"MODE": "prod"},
+				RestartPolicy: "always",
+			},
+		},
+	}
+	if mutate != nil {
+		mutate(in)
+	}
+	return in
+}
+
+func TestDefaultPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		in        *Input
+		wantAllow bool
+		wantRule  string
+	}{
+		{
+			name:      "matching release",
+			in:        releaseInput(nil),
+			wantAllow: true,
+			wantRule:  DefaultRuleName,
+		},
+		{
+			name:      "allowlisted app",
+			in:        releaseInput(func(in *Input) { in.AppAllowlist = map[string]bool{"app": true} }),
+			wantAllow: true,
+			wantRule:  DefaultRuleName,
+		},
+		{
+			name:     "app not in allowlist",
+			in:       releaseInput(func(in *Input) { in.AppAllowlist = map[string]bool{"other": true} }),
+			wantRule: "app-allowlist",
+		},
+		{
+			name:     "no release",
+			in:       releaseInput(func(in *Input) { in.Release = nil }),
+			wantRule: "release-required",
+		},
+		{
+			name:     "image digest mismatch",
+			in:       releaseInput(func(in *Input) { in.Claims.ImageDigest = "sha256:ffff" }),
+			wantRule: "image-digest",
+		},
+		{
+			name:     "registry mismatch",
+			in:       releaseInput(func(in *Input) { in.Claims.Registry = "docker.io/evil/app" }),
+			wantRule: "registry",
+		},
+		{
+			name:      "registry not surfaced",
+			in:        releaseInput(func(in *Input) { in.Claims.Registry = "" }),
+			wantAllow: true,
+			wantRule:  DefaultRuleName,
+		},
+		{
+			name:     "args mismatch",
+			in:       releaseInput(func(in *Input) { in.Claims.ContainerPolicy.Args = []string{"debug"} }),
+			wantRule: "container-args",
+		},
+		{
+			name: "cmd override mismatch",
+			in: releaseInput(func(in *Input) {
+				in.Release.ContainerPolicy.CmdOverride = []string{"/bin/app"}
+			}),
+			wantRule: "container-cmd-override",
+		},
+		{
+			name:     "env value mismatch",
+			in:       releaseInput(func(in *Input) { in.Claims.ContainerPolicy.Env["MODE"] = "dev" }),
+			wantRule: "container-env",
+		},
+		{
+			name:     "env key missing",
+			in:       releaseInput(func(in *Input) { delete(in.Claims.ContainerPolicy.Env, "MODE") }),
+			wantRule: "container-env",
+		},
+		{
+			name: "env override missing",
+			in: releaseInput(func(in *Input) {
+				in.Release.ContainerPolicy.EnvOverride = map[string]string{"LOG": "info"}
+			}),
+			wantRule: "container-env-override",
+		},
+		{
+			name:     "restart policy mismatch",
+			in:       releaseInput(func(in *Input) { in.Claims.ContainerPolicy.RestartPolicy = "never" }),
+			wantRule: "container-restart-policy",
+		},
+		{
+			name: "container policy pinned for a method that cannot prove it",
+			in: releaseInput(func(in *Input) {
+				in.AttestationMethod = "nitro"
+				in.Claims.ContainerPolicy = types.ContainerPolicy{}
+			}),
+			wantRule: "container-policy-unsurfaced",
+		},
+		{
+			name: "ecdsa skips the image checks",
+			in: releaseInput(func(in *Input) {
+				in.AttestationMethod = "ecdsa"
+				in.Claims = &types.AttestationClaims{AppID: "app", ImageDigest: "ecdsa:unverified"}
+			}),
+			wantAllow: true,
+			wantRule:  DefaultRuleName,
+		},
+		{
+			name: "ecdsa without a release",
+			in: releaseInput(func(in *Input) {
+				in.AttestationMethod = "ecdsa"
+				in.Release = nil
+			}),
+			wantAllow: true,
+			wantRule:  DefaultRuleName,
+		},
+		{
+			name: "platform path",
+			in: releaseInput(func(in *Input) {
+				in.StackID = "stack"
+				in.Release = nil
+			}),
+			wantAllow: true,
+			wantRule:  DefaultRuleName,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := DefaultPolicy().Evaluate(tc.in)
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantAllow, d.Allow)
+			assert.Equal(t, tc.wantRule, d.Rule)
+		})
+	}
+
+	var e *Engine
+	d, err := e.Evaluate(releaseInput(func(in *Input) { in.Release = nil }))
+	require.NoError(t, err, "a nil engine applies the default policy")
+	assert.Equal(t, "release-required", d.Rule)
+}
+
+func TestCompile_DefaultRulesRunFirst(t *testing.T) {
+	const allowAll = `
+rules:
+  - name: allow-everything
+    effect: allow
+    match: "true"
+`
+	p, err := Parse([]byte(allowAll))
+	require.NoError(t, err)
+	d, err := p.Evaluate(releaseInput(func(in *Input) { in.Claims.ImageDigest = "sha256:ffff" }))
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Rule: "image-digest"}, d, "an allow rule cannot admit what a default rule denies")
+
+	p, err = Parse([]byte("replace_default_rules: true\n" + allowAll))
+	require.NoError(t, err)
+	assert.Equal(t, 1, p.Rules())
+	d, err = p.Evaluate(releaseInput(func(in *Input) { in.Claims.ImageDigest = "sha256:ffff" }))
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Allow: true, Rule: "allow-everything"}, d, "a replacing policy decides alone")
+
+	_, err = Parse([]byte("rules:\n  - name: registry\n    effect: deny\n    match: \"false\"\n"))
+	require.ErrorContains(t, err, "replace_default_rules", "redefining a default rule requires replacing them")
+}
+
+func TestEvaluate_FirstMatchWins(t *testing.T) {
+	p, err := Parse([]byte(`
+default: deny
+replace_default_rules: true
+rules:
+  - name: allow-gcp
+    effect: allow
+    match: request.attestation_method == "gcp"
+  - name: deny-app
+    effect: deny
+    match: request.app_id == "app"
+`))
+	require.NoError(t, err)
+
+	d, err := p.Evaluate(&Input{AppID: "app", AttestationMethod: "gcp"})
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Allow: true, Rule: "allow-gcp"}, d)
+
+	d, err = p.Evaluate(&Input{AppID: "other", AttestationMethod: "tpm"})
+	require.NoError(t, err)
+	assert.Equal(t, Decision{Allow: false, Rule: DefaultRuleName}, d)
+}
+
+func TestEvaluate_Variables(t *testing.T) {
+	in := &Input{
+		Endpoint:          "/v1/app/sign-message",
+		AppID:             "app",
+		AttestationMethod: "gcp",
+		OperatorAddress:   "0xabc",
+		KeyID:             "default",
+		AppAllowlist:      map[string]bool{"app": true},
+		Claims: &types.AttestationClaims{
+			Registry:        "ghcr.io/example/app",
+			IssuedAt:        1700000000,
+			ContainerPolicy: types.ContainerPolicy{Args: []string{"serve"}, Env: map[string]string{"MODE": "prod"}},
+			Measurements:    map[string]string{"pcr1": "ab"},
+		},
+	}
+	for _, match := range []string{
+		`request.endpoint == "/v1/app/sign-message"`,
+		`node.operator_address == "0xabc" && node.key_id == "default"`,
+		`"app" in node.app_allowlist && !("other" in node.app_allowlist)`,
+		`claims.registry.startsWith("ghcr.io/") && claims.issued_at > 0`,
+		`claims.container_policy.args == ["serve"] && claims.container_policy.env["MODE"] == "prod"`,
+		`size(claims.container_policy.cmd_override) == 0 && size(claims.tcb) == 0`,
+		`claims.measurements.pcr1 == "ab"`,
+		`release == null && request.stack_id == ""`,
+	} {
+		t.Run(match, func(t *testing.T) {
+			p, err := Compile(Document{ReplaceDefaultRules: true, Rules: []Rule{{Name: "r", Effect: EffectDeny, Match: match}}})
+			require.NoError(t, err)
+			d, err := p.Evaluate(in)
+			require.NoError(t, err)
+			assert.Equal(t, "r", d.Rule, "expression should match")
+		})
+	}
+}
+
+func TestEvaluate_ErrorDenies(t *testing.T) {
+	// Nitro claims carry no TCB, so an unguarded lookup fails to evaluate.
+	p, err := Parse([]byte(`
+replace_default_rules: true
+rules:
+  - name: min-tcb
+    effect: deny
+    match: claims.tcb.snp < 22
+`))
+	require.NoError(t, err)
+
+	d, err := p.Evaluate(&Input{AttestationMethod: "nitro", Claims: &types.AttestationClaims{}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "min-tcb")
+	assert.False(t, d.Allow)
+	assert.Equal(t, "min-tcb", d.Rule)
+
+	guarded, err := Parse([]byte(`
+replace_default_rules: true
+rules:
+  - name: min-tcb
+    effect: deny
+    match: has(claims.tcb.snp) && claims.tcb.snp < 22
+`))
+	require.NoError(t, err)
+	d, err = guarded.Evaluate(&Input{AttestationMethod: "nitro", Claims: &types.AttestationClaims{}})
+	require.NoError(t, err)
+	assert.True(t, d.Allow)
+}
+
+func TestParse_Rejections(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		doc     string
+		wantErr string
+	}{
+		{"empty document", ``, "decode policy"},
+		{"unknown field", "rules:\n  - name: r\n    effect: deny\n    when: true\n", "field when not found"},
+		{"invalid default", "default: maybe\n", "invalid default effect"},
+		{"missing name", "rules:\n  - effect: deny\n    match: true\n", "name is required"},
+		{"reserved name", "rules:\n  - name: default\n    effect: deny\n    match: true\n", "reserved"},
+		{"duplicate name", "rules:\n  - name: r\n    effect: deny\n    match: true\n  - name: r\n    effect: allow\n    match: false\n", "duplicate name"},
+		{"invalid effect", "rules:\n  - name: r\n    effect: block\n    match: true\n", "invalid effect"},
+		{"missing match", "rules:\n  - name: r\n    effect: deny\n", "match is required"},
+		{"syntax error", "rules:\n  - name: r\n    effect: deny\n    match: request.app_id ==\n", `rule "r"`},
+		{"undeclared variable", "rules:\n  - name: r\n    effect: deny\n    match: attestation.method == 'gcp'\n", "undeclared reference"},
+		{"not a bool", "rules:\n  - name: r\n    effect: deny\n    match: size(request.app_id)\n", "want bool"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.doc))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestParse_JSON(t *testing.T) {
+	p, err := Parse([]byte(`{"default": "deny", "replace_default_rules": true, "rules": [{"name": "allow-tpm", "effect": "allow", "match": "request.attestation_method == 'tpm'"}]}`))
+	require.NoError(t, err)
+	d, err := p.Evaluate(&Input{AttestationMethod: "tpm"})
+	require.NoError(t, err)
+	assert.True(t, d.Allow)
+}
@@ -0,0 +1,102 @@
+package authz
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Engine serves the current policy of a policy file and reloads it when the file
+// changes. A nil *Engine evaluates DefaultPolicy, so callers need not special-case
+// an unconfigured policy.
+type Engine struct {
+	path   string
+	logger *zap.Logger
+
+	policy atomic.Pointer[Policy]
+
+	// mu serializes reloads; raw is the file content policy was compiled from.
+	mu  sync.Mutex
+	raw []byte
+}
+
+// NewEngine loads the policy at path. An invalid policy is an error, so a node
+// never starts on rules it cannot enforce.
+func NewEngine(path string, logger *zap.Logger) (*Engine, error) {
+	e := &Engine{path: path, logger: logger}
+	if _, err := e.Reload(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Path returns the policy file the engine serves.
+func (e *Engine) Path() string {
+	return e.path
+}
+
+// Policy returns the policy in force.
+func (e *Engine) Policy() *Policy {
+	if e == nil {
+		return DefaultPolicy()
+	}
+	return e.policy.Load()
+}
+
+// Evaluate decides in against the policy in force.
+func (e *Engine) Evaluate(in *Input) (Decision, error) {
+	return e.Policy().Evaluate(in)
+}
+
+// Reload re-reads the policy file and swaps in its policy when the content
+// changed. It reports whether it swapped. On error the previous policy stays in
+// force.
+func (e *Engine) Reload() (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	raw, err := os.ReadFile(e.path)
+	if err != nil {
+		return false, fmt.Errorf("read authorization policy %s: %w", e.path, err)
+	}
+	if e.raw != nil && bytes.Equal(raw, e.raw) {
+		return false, nil
+	}
+	p, err := Parse(raw)
+	if err != nil {
+		return false, fmt.Errorf("load authorization policy %s: %w", e.path, err)
+	}
+	e.policy.Store(p)
+	e.raw = raw
+	return true, nil
+}
+
+// Watch reloads the policy file every interval until ctx is done. A file that
+// fails to load is logged and the previous policy keeps serving.
+func (e *Engine) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			changed, err := e.Reload()
+			if err != nil {
+				e.logger.Sugar().Errorw("Authorization policy reload failed; keeping previous policy",
+					"path", e.path, "error", err)
+				continue
+			}
+			if changed {
+				e.logger.Sugar().Infow("Authorization policy reloaded",
+					"path", e.path, "rules", e.policy.Load().Rules())
+			}
+		}
+	}
+}
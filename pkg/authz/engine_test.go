@@ -0,0 +1,136 @@
+package authz
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const denyTPMPolicy = `
+rules:
+  - name: deny-tpm
+    effect: deny
+    match: request.attestation_method == "tpm"
+`
+
+const denyGCPPolicy = `
+rules:
+  - name: deny-gcp
+    effect: deny
+    match: request.attestation_method == "gcp"
+`
+
+func writePolicy(t *testing.T, path, doc string) {
+	t.Helper()
+	// Write and rename, as operators should, so a reload never sees half a file.
+	tmp := path + ".tmp"
+	require.NoError(t, os.WriteFile(tmp, []byte(doc), 0o600))
+	require.NoError(t, os.Rename(tmp, path))
+}
+
+func decide(t *testing.T, e *Engine, method string) Decision {
+	t.Helper()
+	// A platform request has no release, so only the rules under test decide it.
+	d, err := e.Evaluate(&Input{AttestationMethod: method, StackID: "stack"})
+	require.NoError(t, err)
+	return d
+}
+
+func TestNewEngine(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := NewEngine(filepath.Join(dir, "missing.yaml"), zap.NewNop())
+	require.Error(t, err, "a missing policy file must stop startup")
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	writePolicy(t, invalid, "rules:\n  - name: r\n    effect: deny\n    match: nope(\n")
+	_, err = NewEngine(invalid, zap.NewNop())
+	require.Error(t, err, "an invalid policy must stop startup")
+
+	path := filepath.Join(dir, "authz.yaml")
+	writePolicy(t, path, denyTPMPolicy)
+	e, err := NewEngine(path, zap.NewNop())
+	require.NoError(t, err)
+	assert.Equal(t, path, e.Path())
+	assert.Equal(t, Decision{Rule: "deny-tpm"}, decide(t, e, "tpm"))
+}
+
+func TestEngine_Reload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "authz.yaml")
+	writePolicy(t, path, denyTPMPolicy)
+	e, err := NewEngine(path, zap.NewNop())
+	require.NoError(t, err)
+
+	changed, err := e.Reload()
+	require.NoError(t, err)
+	assert.False(t, changed, "unchanged file")
+
+	writePolicy(t, path, denyGCPPolicy)
+	changed, err = e.Reload()
+	require.NoError(t, err)
+	assert.True(t, changed)
+	assert.True(t, decide(t, e, "tpm").Allow)
+	assert.Equal(t, Decision{Rule: "deny-gcp"}, decide(t, e, "gcp"))
+
+	// A broken edit keeps the previous policy in force.
+	writePolicy(t, path, "rules: [")
+	_, err = e.Reload()
+	require.Error(t, err)
+	assert.Equal(t, Decision{Rule: "deny-gcp"}, decide(t, e, "gcp"))
+
+	require.NoError(t, os.Remove(path))
+	_, err = e.Reload()
+	require.Error(t, err)
+	assert.Equal(t, Decision{Rule: "deny-gcp"}, decide(t, e, "gcp"))
+}
+
+func TestEngine_Watch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "authz.yaml")
+	writePolicy(t, path, denyTPMPolicy)
+	core, logs := observer.New(zapcore.InfoLevel)
+	e, err := NewEngine(path, zap.New(core))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		e.Watch(ctx, 10*time.Millisecond)
+		close(done)
+	}()
+
+	writePolicy(t, path, denyGCPPolicy)
+	require.Eventually(t, func() bool {
+		return decide(t, e, "tpm").Allow
+	}, 5*time.Second, 10*time.Millisecond, "edited policy should be picked up")
+	assert.Equal(t, Decision{Rule: "deny-gcp"}, decide(t, e, "gcp"))
+
+	// An edit that fails to load is logged and the last good policy keeps serving.
+	writePolicy(t, path, "rules: [")
+	require.Eventually(t, func() bool {
+		return logs.FilterMessageSnippet("reload failed").Len() > 0
+	}, 5*time.Second, 10*time.Millisecond, "broken edit should be reported")
+	assert.Equal(t, Decision{Rule: "deny-gcp"}, decide(t, e, "gcp"))
+	assert.True(t, decide(t, e, "tpm").Allow)
+
+	// Watching continues: the next good edit is picked up.
+	writePolicy(t, path, denyTPMPolicy)
+	require.Eventually(t, func() bool {
+		return !decide(t, e, "tpm").Allow
+	}, 5*time.Second, 10*time.Millisecond, "fixed policy should be picked up")
+	assert.True(t, decide(t, e, "gcp").Allow)
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Watch did not return after its context was cancelled")
+	}
+}
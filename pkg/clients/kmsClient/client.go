@@ -1,11 +1,14 @@
 package kmsClient
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/ecdsa"
+	"crypto/mlkem"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -35,18 +38,36 @@ type ContractCaller interface {
 type ClientConfig struct {
 	AVSAddress     string
 	OperatorSetID  uint32
+	KeyID          string  // Optional: key to address on servers holding several keys; empty = the default key
+	Generation     *uint32 // Optional: master secret generation to encrypt to and to decrypt ciphertexts without a generation header with; nil = the active (newest) one
 	Logger         *zap.Logger
 	ContractCaller ContractCaller
 	HTTPClient     *http.Client // Optional: if nil, creates default client with 30s timeout
+
+	// Optional: check the master public key against the dealers' commitment hashes in
+	// the EigenKMSCommitmentRegistry at CommitmentRegistryAddress (see
+	// verifyMasterPublicKeyOnChain). Both or neither must be set.
+	CommitmentReader          CommitmentReader
+	CommitmentRegistryAddress common.Address
+
+	// Optional: file pinning the master public key on first use (see
+	// checkPinnedMasterPublicKey). Empty disables pinning.
+	MPKPinFile string
 }
 
 // Client provides a reusable library interface for KMS operations
 type Client struct {
 	avsAddress     string
 	operatorSetID  uint32
+	keyID          string
+	generation     *uint32
 	contractCaller ContractCaller
 	httpClient     *http.Client // TODO(security): VULN-002 SSRF — validate op.SocketAddress before requests (reject private/loopback IPs, enforce https in prod)
 	logger         *zap.Logger
+
+	commitmentReader CommitmentReader
+	registryAddress  common.Address
+	mpkPinFile       string
 }
 
 // SecretsResult contains the recovered secrets and private key
@@ -61,12 +82,26 @@ type SecretsResult struct {
 	// Verified is false on the degraded path (master public key unavailable, so
 	// AppPrivateKey was recovered without VerifyAppPrivateKey).
 	Verified bool
+	// Generation is the master secret generation AppPrivateKey belongs to; nil when it
+	// could not be determined. A rotation gives the app a new private key in the new
+	// generation, so keys derived from it change too (see DeriveRoot).
+	Generation *uint32
+	// InvalidOperators lists operators whose partial signature failed verification
+	// against their public key share; they were excluded from recovery.
+	InvalidOperators []common.Address
+
+	// Plaintexts holds, when SecretsOptions.Ciphertexts was set, the decryption of each
+	// ciphertext in order. AppPrivateKey and PartialSigs are then empty.
+	Plaintexts [][]byte
+	// Env is, when SecretsOptions.Ciphertexts was set, EncryptedEnv decrypted; nil when
+	// there is no encrypted environment.
+	Env []byte
 }
 
 // SecretsOptions configures secret retrieval behavior
 type SecretsOptions struct {
 	// AttestationMethod specifies which attestation method to use
-	// Options: "gcp" (default), "intel", "ecdsa", "tpm"
+	// Options: "gcp" (default), "intel", "ecdsa", "tpm", "eigenx-snp", "eigenx-tdx", "nitro"
 	AttestationMethod string
 
 	// For GCP/Intel attestation (production)
@@ -80,7 +115,15 @@ type SecretsOptions struct {
 
 	// For eigenx-snp attestation
 	RawSNPEvidence []byte // Raw AA evidence JSON (attestation_report + cert_chain) — wire-encoded as base64 by Go's []byte JSON marshalling
-	CCInitData     []byte // CoCo init-data document bytes (e.g. /run/peerpod/initdata)
+	CCInitData     []byte // CoCo init-data document bytes (e.g. /run/peerpod/initdata); optional for eigenx-tdx
+
+	// For eigenx-tdx attestation
+	RawTDXEvidence []byte // Evidence JSON (quote + image_ref + Intel PCS collateral)
+
+	// For nitro attestation: the NSM attestation document (COSE_Sign1 CBOR),
+	// requested with public_key = RSAPublicKeyPEM and user_data =
+	// attestation.NitroUserData(ExtraData)
+	NitroAttestationDocument []byte
 
 	// RSA key pair for encrypting partial signatures in transit
 	RSAPrivateKeyPEM []byte // Required: RSA private key in PEM format
@@ -92,6 +135,17 @@ type SecretsOptions struct {
 	// on-chain AppController. On that path the KMS returns only the recovered
 	// app-private-key (no env). Empty preserves the on-chain behavior.
 	StackID string
+
+	// Ciphertexts, when set, switches to threshold decryption: operators return
+	// decryption shares of these IBE ciphertexts (and of the encrypted environment)
+	// instead of partial signatures, so the app private key is never reconstructed.
+	// At most types.MaxCiphertextsPerRequest.
+	Ciphertexts [][]byte
+
+	// PQKeys are operators' decapsulation keys for the app (see RetrieveAppPQKeys),
+	// needed to decrypt post-quantum (version 3) ciphertexts among Ciphertexts or as
+	// the encrypted environment.
+	PQKeys map[common.Address]*mlkem.DecapsulationKey768
 }
 
 // NewClient creates a new KMS client instance with dependency injection
@@ -108,6 +162,9 @@ func NewClient(config *ClientConfig) (*Client, error) {
 	if config.ContractCaller == nil {
 		return nil, fmt.Errorf("contract caller is required")
 	}
+	if (config.CommitmentReader == nil) != (config.CommitmentRegistryAddress == common.Address{}) {
+		return nil, fmt.Errorf("commitment reader and commitment registry address must be set together")
+	}
 
 	// Use provided HTTP client or create default with 30s timeout
 	httpClient := config.HTTPClient
@@ -118,11 +175,16 @@ func NewClient(config *ClientConfig) (*Client, error) {
 	}
 
 	return &Client{
-		avsAddress:     config.AVSAddress,
-		operatorSetID:  config.OperatorSetID,
-		contractCaller: config.ContractCaller,
-		httpClient:     httpClient,
-		logger:         config.Logger,
+		avsAddress:       config.AVSAddress,
+		operatorSetID:    config.OperatorSetID,
+		keyID:            config.KeyID,
+		generation:       config.Generation,
+		contractCaller:   config.ContractCaller,
+		httpClient:       httpClient,
+		logger:           config.Logger,
+		commitmentReader: config.CommitmentReader,
+		registryAddress:  config.CommitmentRegistryAddress,
+		mpkPinFile:       config.MPKPinFile,
 	}, nil
 }
 
@@ -155,108 +217,75 @@ func (c *Client) GetOperators() (*peering.OperatorSetPeers, error) {
 	return operators, nil
 }
 
-// GetMasterPublicKey fetches the master public key from operators concurrently
+// GetMasterPublicKey fetches the master public key from operators concurrently. When
+// configured, the key agreed on is then checked against the commitment registry and
+// the pin file; a key failing either check is an ErrMasterPublicKeyMismatch.
 func (c *Client) GetMasterPublicKey(operators *peering.OperatorSetPeers) (*types.G2Point, error) {
+	masterPubKey, _, err := c.getMasterPublicKey(operators)
+	return masterPubKey, err
+}
+
+// getMasterPublicKey implements GetMasterPublicKey and also returns the master secret
+// generation of the key, which ciphertexts encrypted to it are tagged with.
+func (c *Client) getMasterPublicKey(operators *peering.OperatorSetPeers) (*types.G2Point, uint32, error) {
 	if operators == nil || len(operators.Peers) == 0 {
-		return nil, fmt.Errorf("no operators provided")
+		return nil, 0, fmt.Errorf("no operators provided")
 	}
 
 	c.logger.Sugar().Infow("Collecting commitments from operators", "count", len(operators.Peers))
 
+	responses := c.fetchPubkeys(operators, 0)
+	masterPubKey, generation, err := c.agreeMasterPublicKey(operators, responses)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err := c.checkMasterPublicKey(operators, responses, masterPubKey); err != nil {
+		return nil, 0, err
+	}
+	return masterPubKey, generation, nil
+}
+
+// agreeMasterPublicKey determines the master public key and its generation from the
+// operators' /pubkey responses, by threshold agreement on their pre-computed keys or
+// else by aggregating their commitments.
+func (c *Client) agreeMasterPublicKey(operators *peering.OperatorSetPeers, responses []pubkeyResponse) (*types.G2Point, uint32, error) {
+
 	type result struct {
 		commitments     []types.G2Point
 		masterPublicKey *types.G2Point
+		generation      uint32
 		opAddress       string
 	}
 
-	resultChan := make(chan result, len(operators.Peers))
-	var wg sync.WaitGroup
-
 	// Collect commitments and pre-computed MPK from all operators concurrently
-	for i, operator := range operators.Peers {
-		wg.Add(1)
-		go func(idx int, op *peering.OperatorSetPeer) {
-			defer wg.Done()
-
-			resp, err := c.httpClient.Get(op.SocketAddress + "/pubkey")
-			if err != nil {
-				c.logger.Sugar().Warnw("Failed to contact operator",
-					"operator_index", idx,
-					"address", op.SocketAddress,
-					"error", err,
-				)
-				return
-			}
-			defer func() { _ = resp.Body.Close() }()
-
-			if resp.StatusCode != http.StatusOK {
-				// Cap the error-body read: the helper runs in a memory-
-				// constrained peer-pod, and a misbehaving operator returning
-				// gigabytes of bytes on a non-200 response shouldn't OOM us.
-				// 64 KiB is enough to surface any reasonable error message.
-				body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
-				c.logger.Sugar().Warnw("Operator returned error",
-					"operator_index", idx,
-					"status_code", resp.StatusCode,
-					"body", string(body),
-				)
-				return
-			}
-
-			var response struct {
-				OperatorAddress string          `json:"operatorAddress"`
-				Commitments     []types.G2Point `json:"commitments"`
-				MasterPublicKey *types.G2Point  `json:"masterPublicKey"`
-				Version         int64           `json:"version"`
-				IsActive        bool            `json:"isActive"`
-			}
-
-			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-				c.logger.Sugar().Warnw("Failed to decode response from operator",
-					"operator_index", idx,
-					"error", err,
-				)
-				return
-			}
-
-			if !response.IsActive {
-				c.logger.Sugar().Warnw("Operator does not have active key version", "operator_index", idx)
-				return
-			}
-
-			if len(response.Commitments) == 0 {
-				c.logger.Sugar().Warnw("Operator has no commitments", "operator_index", idx)
-				return
-			}
-
-			c.logger.Sugar().Debugw("Collected commitments from operator",
-				"operator_index", idx,
-				"operator_address", response.OperatorAddress,
-			)
-			resultChan <- result{commitments: response.Commitments, masterPublicKey: response.MasterPublicKey, opAddress: response.OperatorAddress}
-		}(i, operator)
-	}
-
-	// Wait for all goroutines to complete
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	// Collect results
 	var allCommitments [][]types.G2Point
 	var results []result
-	for res := range resultChan {
-		allCommitments = append(allCommitments, res.commitments)
-		results = append(results, res)
+	for _, response := range responses {
+		if !response.IsActive {
+			c.logger.Sugar().Warnw("Operator does not have active key version", "operator_address", response.OperatorAddress)
+			continue
+		}
+
+		if len(response.Commitments) == 0 {
+			c.logger.Sugar().Warnw("Operator has no commitments", "operator_address", response.OperatorAddress)
+			continue
+		}
+
+		c.logger.Sugar().Debugw("Collected commitments from operator",
+			"operator_address", response.OperatorAddress,
+		)
+		allCommitments = append(allCommitments, response.Commitments)
+		results = append(results, result{commitments: response.Commitments, masterPublicKey: response.MasterPublicKey, generation: response.Generation, opAddress: response.OperatorAddress})
 	}
 
 	if len(results) == 0 {
-		return nil, fmt.Errorf("failed to collect commitments from any operator")
+		return nil, 0, fmt.Errorf("failed to collect commitments from any operator")
 	}
 
-	// Try threshold agreement on pre-computed MPK first
+	// Try threshold agreement on pre-computed MPK first. The generation is part of the
+	// vote, so the one ciphertexts are tagged with is agreed by a threshold too.
 	mpkVotes := make(map[string]*types.G2Point)
+	mpkGenerations := make(map[string]uint32)
 	mpkCounts := make(map[string]int)
 	hasMPK := false
 	for _, res := range results {
@@ -268,8 +297,9 @@ func (c *Client) GetMasterPublicKey(operators *peering.OperatorSetPeers) (*types
 				continue
 			}
 			hasMPK = true
-			key := hex.EncodeToString(res.masterPublicKey.CompressedBytes)
+			key := fmt.Sprintf("%d/%s", res.generation, hex.EncodeToString(res.masterPublicKey.CompressedBytes))
 			mpkVotes[key] = res.masterPublicKey
+			mpkGenerations[key] = res.generation
 			mpkCounts[key]++
 		}
 	}
@@ -289,10 +319,10 @@ func (c *Client) GetMasterPublicKey(operators *peering.OperatorSetPeers) (*types
 						"threshold", threshold,
 						"total_responses", len(results),
 					)
-					return mpkVotes[key], nil
+					return mpkVotes[key], mpkGenerations[key], nil
 				}
 			}
-			return nil, fmt.Errorf("failed to reach threshold agreement on master public key: needed %d, got max %d agreeing out of %d responses",
+			return nil, 0, fmt.Errorf("failed to reach threshold agreement on master public key: needed %d, got max %d agreeing out of %d responses",
 				threshold, maxMPKVotes(mpkCounts), len(results))
 		}
 		// Not enough operators returned MPK for threshold agreement (e.g. rolling upgrade)
@@ -306,12 +336,55 @@ func (c *Client) GetMasterPublicKey(operators *peering.OperatorSetPeers) (*types
 	}
 
 	// Fallback: aggregate commitments (backward compatibility with nodes that don't return MPK)
+	generation := results[0].generation
+	for _, res := range results[1:] {
+		if res.generation != generation {
+			return nil, 0, fmt.Errorf("operators disagree on the master secret generation: %d and %d", generation, res.generation)
+		}
+	}
 	masterPubKey, err := crypto.ComputeMasterPublicKey(allCommitments)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compute master public key: %w", err)
+		return nil, 0, fmt.Errorf("failed to compute master public key: %w", err)
 	}
 
-	return masterPubKey, nil
+	return masterPubKey, generation, nil
+}
+
+// WithGeneration returns a copy of the client that addresses master secret generation
+// instead of the one it was configured with.
+func (c *Client) WithGeneration(generation uint32) *Client {
+	dup := *c
+	dup.generation = &generation
+	return &dup
+}
+
+// forCiphertext returns the client to decrypt ciphertext with: a copy addressing the
+// generation named in its generation header, or c for a ciphertext without one.
+func (c *Client) forCiphertext(ciphertext []byte) *Client {
+	generation, ok := crypto.CiphertextGeneration(ciphertext)
+	if !ok {
+		return c
+	}
+	if c.generation == nil || *c.generation != generation {
+		c.logger.Sugar().Debugw("Decrypting with the ciphertext's master secret generation", "generation", generation)
+	}
+	return c.WithGeneration(generation)
+}
+
+// forCiphertexts is forCiphertext for ciphertexts that are decrypted together, which
+// must all be encrypted to the same generation.
+func (c *Client) forCiphertexts(ciphertexts [][]byte) (*Client, error) {
+	if len(ciphertexts) == 0 {
+		return c, nil
+	}
+	generation, ok := crypto.CiphertextGeneration(ciphertexts[0])
+	for i, ciphertext := range ciphertexts[1:] {
+		g, tagged := crypto.CiphertextGeneration(ciphertext)
+		if tagged != ok || g != generation {
+			return nil, fmt.Errorf("ciphertext %d is encrypted to another master secret generation than ciphertext 0", i+1)
+		}
+	}
+	return c.forCiphertext(ciphertexts[0]), nil
 }
 
 // Encrypt encrypts data for an application using IBE
@@ -326,12 +399,12 @@ func (c *Client) Encrypt(appID string, data []byte, operators *peering.OperatorS
 	c.logger.Sugar().Infow("Encrypting data for app", "app_id", appID)
 
 	// Get master public key from operators
-	masterPubKey, err := c.GetMasterPublicKey(operators)
+	masterPubKey, generation, err := c.getMasterPublicKey(operators)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get master public key: %w", err)
 	}
 
-	c.logger.Sugar().Debug("Retrieved master public key from operators")
+	c.logger.Sugar().Debugw("Retrieved master public key from operators", "generation", generation)
 
 	// Encrypt data using IBE
 	encryptedData, err := crypto.EncryptForApp(appID, *masterPubKey, data)
@@ -340,7 +413,41 @@ func (c *Client) Encrypt(appID string, data []byte, operators *peering.OperatorS
 	}
 
 	c.logger.Sugar().Info("Successfully encrypted data")
-	return encryptedData, nil
+	return crypto.WithGeneration(generation, encryptedData), nil
+}
+
+// EncryptStream encrypts everything read from src for an application as a chunked IBE
+// ciphertext written to dst, holding one chunk in memory at a time. It returns the
+// number of plaintext bytes encrypted.
+func (c *Client) EncryptStream(appID string, dst io.Writer, src io.Reader, operators *peering.OperatorSetPeers) (int64, error) {
+	if appID == "" {
+		return 0, fmt.Errorf("app ID is required")
+	}
+
+	c.logger.Sugar().Infow("Encrypting stream for app", "app_id", appID)
+
+	masterPubKey, generation, err := c.getMasterPublicKey(operators)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get master public key: %w", err)
+	}
+
+	if _, err := dst.Write(crypto.GenerationHeader(generation)); err != nil {
+		return 0, fmt.Errorf("failed to write ciphertext: %w", err)
+	}
+	w, err := crypto.NewEncryptWriter(dst, appID, *masterPubKey)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encrypt data: %w", err)
+	}
+	n, err := io.Copy(w, src)
+	if err != nil {
+		return n, fmt.Errorf("failed to encrypt data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return n, fmt.Errorf("failed to encrypt data: %w", err)
+	}
+
+	c.logger.Sugar().Infow("Successfully encrypted stream", "bytes", n)
+	return n, nil
 }
 
 // CollectPartialSignatures collects partial signatures from threshold number of operators concurrently
@@ -381,6 +488,7 @@ func (c *Client) CollectPartialSignatures(appID string, operators *peering.Opera
 			req := types.AppSignRequest{
 				AppID:           appID,
 				AttestationTime: attestationTime,
+				Generation:      c.generation,
 			}
 
 			reqBody, err := json.Marshal(req)
@@ -392,7 +500,7 @@ func (c *Client) CollectPartialSignatures(appID string, operators *peering.Opera
 				return
 			}
 
-			resp, err := c.httpClient.Post(op.SocketAddress+"/app/sign", "application/json", bytes.NewReader(reqBody))
+			resp, err := c.httpClient.Post(c.operatorURL(op.SocketAddress, "/app/sign"), "application/json", bytes.NewReader(reqBody))
 			if err != nil {
 				c.logger.Sugar().Warnw("Failed to contact operator",
 					"operator_index", idx,
@@ -465,23 +573,26 @@ func (c *Client) CollectPartialSignatures(appID string, operators *peering.Opera
 		close(resultChan)
 	}()
 
-	// Collect all available results (not just threshold) to enable retry with
-	// alternative subsets if some operators provided invalid signatures (KMS-012)
 	partialSigs := make(map[common.Address]types.G1Point)
-	// TODO(security): only count cryptographically verified partial signatures.
-	// Current code counts non-zero shares and relies on later interpolation failure.
-	// With KMS-012 retry logic, a single malicious operator can now trigger up to
-	// DefaultMaxRecoveryAttempts (1000) Lagrange interpolation + pairing calls.
 	for res := range resultChan {
 		partialSigs[res.operatorAddr] = res.signature
 	}
 
+	// Only count partial signatures that verify against the operator's public key
+	// share, so any threshold-sized subset of the result recovers the key.
+	partialSigs, invalid, verified := c.filterVerifiedPartialSignatures(appID, operators, attestationTime, partialSigs)
 	if len(partialSigs) < threshold {
+		if len(invalid) > 0 {
+			return nil, fmt.Errorf("insufficient valid partial signatures: collected %d, needed %d (invalid from: %s)",
+				len(partialSigs), threshold, formatAddresses(invalid))
+		}
 		return nil, fmt.Errorf("insufficient partial signatures: collected %d, needed %d", len(partialSigs), threshold)
 	}
 
 	c.logger.Sugar().Infow("Successfully collected partial signatures",
 		"collected", len(partialSigs),
+		"invalid", len(invalid),
+		"verified", verified,
 		"threshold", threshold,
 	)
 	return partialSigs, nil
@@ -506,8 +617,9 @@ func (c *Client) Decrypt(appID string, encryptedData []byte, operators *peering.
 		threshold = (2*len(operators.Peers) + 2) / 3
 	}
 
-	// Collect partial signatures from all available operators
-	partialSigs, err := c.CollectPartialSignatures(appID, operators, threshold)
+	// Collect partial signatures from all available operators, for the generation the
+	// data was encrypted to
+	partialSigs, err := c.forCiphertext(encryptedData).CollectPartialSignatures(appID, operators, threshold)
 	if err != nil {
 		return nil, fmt.Errorf("failed to collect partial signatures: %w", err)
 	}
@@ -522,9 +634,73 @@ func (c *Client) Decrypt(appID string, encryptedData []byte, operators *peering.
 	return decryptedData, nil
 }
 
+// DecryptStream decrypts a chunked IBE ciphertext read from src into dst, holding one
+// chunk in memory at a time, and returns the number of plaintext bytes written. The
+// application private key is recovered from operators' partial signatures and checked
+// against the master public key before any of src is read.
+//
+// Plaintext is written as chunks authenticate, so on error dst may hold a prefix of it;
+// only a nil error means dst holds the whole plaintext.
+func (c *Client) DecryptStream(appID string, dst io.Writer, src io.Reader, operators *peering.OperatorSetPeers, threshold int) (int64, error) {
+	if appID == "" {
+		return 0, fmt.Errorf("app ID is required")
+	}
+	if operators == nil || len(operators.Peers) == 0 {
+		return 0, fmt.Errorf("no operators provided")
+	}
+
+	// Address the generation the stream was encrypted to
+	br := bufio.NewReader(src)
+	generation, ok, err := crypto.PeekCiphertextGeneration(br)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	if ok {
+		c = c.WithGeneration(generation)
+	}
+
+	c.logger.Sugar().Infow("Decrypting stream for app", "app_id", appID)
+
+	if threshold == 0 {
+		threshold = (2*len(operators.Peers) + 2) / 3
+	}
+
+	masterPubKey, err := c.GetMasterPublicKey(operators)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get master public key: %w", err)
+	}
+	partialSigs, err := c.CollectPartialSignatures(appID, operators, threshold)
+	if err != nil {
+		return 0, fmt.Errorf("failed to collect partial signatures: %w", err)
+	}
+
+	// There is no whole ciphertext to trial-decrypt, so candidate keys are checked
+	// against the master public key instead
+	appPrivKey, err := crypto.RecoverAppPrivateKeyWithRetry(appID, partialSigs, threshold, func(candidate *types.G1Point) bool {
+		valid, verifyErr := crypto.VerifyAppPrivateKey(appID, *candidate, *masterPubKey)
+		return verifyErr == nil && valid
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to recover app private key: %w", err)
+	}
+
+	r, err := crypto.NewDecryptReader(br, appID, *appPrivKey)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	n, err := io.Copy(dst, r)
+	if err != nil {
+		return n, fmt.Errorf("failed to decrypt data: %w", err)
+	}
+
+	c.logger.Sugar().Infow("Successfully decrypted stream", "bytes", n)
+	return n, nil
+}
+
 // decryptWithRetry attempts decryption using different threshold-sized subsets of
-// partial signatures. This provides BFT: if some operators returned invalid partial
-// signatures, the function rotates through alternative subsets until decryption succeeds.
+// partial signatures. When the signatures were verified per operator (see
+// filterVerifiedPartialSignatures) the first subset succeeds; the rotation through
+// alternative subsets only matters when operators could not be verified individually.
 //
 // If the ciphertext itself is malformed (invalid format, wrong version, etc.), the
 // function fails fast without retrying, since no subset of partial signatures can fix
@@ -556,30 +732,20 @@ func decryptWithRetry(appID string, partialSigs map[common.Address]types.G1Point
 // RetrieveSecretsWithOptions implements secret retrieval with configurable attestation method
 // RSA key pair must be provided in opts for encrypting partial signatures in transit
 func (c *Client) RetrieveSecretsWithOptions(appID string, opts *SecretsOptions) (*SecretsResult, error) {
-	if opts == nil {
-		return nil, fmt.Errorf("options are required")
-	}
-	if opts.AttestationMethod == "" {
-		opts.AttestationMethod = "gcp"
-	}
-	if len(opts.RSAPrivateKeyPEM) == 0 || len(opts.RSAPublicKeyPEM) == 0 {
-		return nil, fmt.Errorf("RSA key pair is required in options")
+	if err := validateSecretsOptions(opts); err != nil {
+		return nil, err
 	}
-	if len(opts.ExtraData) > types.MaxExtraDataSize {
-		return nil, fmt.Errorf("extra_data exceeds 1MB limit (%d bytes)", len(opts.ExtraData))
+	if len(opts.Ciphertexts) > types.MaxCiphertextsPerRequest {
+		return nil, fmt.Errorf("at most %d ciphertexts per request, got %d", types.MaxCiphertextsPerRequest, len(opts.Ciphertexts))
 	}
-	if opts.AttestationMethod == "eigenx-snp" {
-		if len(opts.RawSNPEvidence) == 0 {
-			return nil, fmt.Errorf("RawSNPEvidence is required for eigenx-snp attestation method")
-		}
-		if len(opts.CCInitData) == 0 {
-			return nil, fmt.Errorf("CCInitData is required for eigenx-snp attestation method")
-		}
-		// Mirror the server-side cap in handlers.go so we don't waste bandwidth
-		// marshalling a payload the operator will reject.
-		if len(opts.CCInitData) > types.MaxExtraDataSize {
-			return nil, fmt.Errorf("CCInitData exceeds 1MB limit (%d bytes)", len(opts.CCInitData))
+	if len(opts.Ciphertexts) > 0 {
+		// Decryption shares are only useful from the generation the ciphertexts were
+		// encrypted to
+		dc, err := c.forCiphertexts(opts.Ciphertexts)
+		if err != nil {
+			return nil, err
 		}
+		c = dc
 	}
 
 	c.logger.Sugar().Infow("Starting secret retrieval",
@@ -596,65 +762,36 @@ func (c *Client) RetrieveSecretsWithOptions(appID string, opts *SecretsOptions)
 	threshold := dkg.CalculateThreshold(len(operators.Peers))
 
 	// Step 2: Create attestation based on method
-	var req types.SecretsRequestV1
-
-	switch opts.AttestationMethod {
-	case "ecdsa":
-		req, err = c.createECDSAAttestationRequest(appID, opts, opts.RSAPublicKeyPEM)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create ECDSA attestation: %w", err)
-		}
-		req.ExtraData = opts.ExtraData
-
-	case "gcp", "intel":
-		attestationClaims := types.AttestationClaims{
-			AppID:       appID,
-			ImageDigest: opts.ImageDigest,
-			IssuedAt:    time.Now().Unix(),
-			PublicKey:   opts.RSAPublicKeyPEM,
-		}
-		attestationBytes, err := json.Marshal(attestationClaims)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create attestation: %w", err)
-		}
-
-		req = types.SecretsRequestV1{
-			AppID:             appID,
-			AttestationMethod: opts.AttestationMethod,
-			Attestation:       attestationBytes,
-			RSAPubKeyTmp:      opts.RSAPublicKeyPEM,
-			AttestationTime:   time.Now().Unix(),
-			ExtraData:         opts.ExtraData,
-		}
-
-	case "tpm":
-		if len(opts.TPMAttestationBytes) == 0 {
-			return nil, fmt.Errorf("TPM attestation bytes are required for tpm method")
-		}
-		req = types.SecretsRequestV1{
-			AppID:             appID,
-			AttestationMethod: "tpm",
-			Attestation:       opts.TPMAttestationBytes,
-			RSAPubKeyTmp:      opts.RSAPublicKeyPEM,
-			AttestationTime:   time.Now().Unix(),
-			ExtraData:         opts.ExtraData,
-		}
-
-	case "eigenx-snp":
-		req = c.createEigenXSNPAttestationRequest(appID, opts)
+	req, err := c.createAttestationRequest(appID, opts)
+	if err != nil {
+		return nil, err
+	}
 
-	default:
-		return nil, fmt.Errorf("unsupported attestation method: %s", opts.AttestationMethod)
+	// Threshold decryption asks for decryption shares instead of partial signatures
+	if len(opts.Ciphertexts) > 0 {
+		return c.retrieveSecretsDecryptions(appID, opts, operators, req)
 	}
 
 	// Step 3: Request secrets from all KMS servers and collect partial signatures
-	responses, partialSigs, err := c.GetEncryptedSecretsFromKMSNodesWithPartialSigs(operators, req, opts.RSAPrivateKeyPEM)
+	responsesByOperator, partialSigs, err := c.collectSecretsResponses(operators, req, opts.RSAPrivateKeyPEM)
 	if err != nil {
 		return nil, fmt.Errorf("failed to collect secrets: %w", err)
 	}
 
-	// Step 4: Verify we have threshold responses
+	// Step 4: Drop operators whose partial signature does not verify against their
+	// public key share, then verify we have threshold responses from the rest.
+	partialSigs, invalidOperators, sigsVerified := c.filterVerifiedPartialSignatures(appID, operators, req.AttestationTime, partialSigs)
+	responses := make([]types.SecretsResponseV1, 0, len(partialSigs))
+	for _, op := range operators.Peers {
+		if _, ok := partialSigs[op.OperatorAddress]; ok {
+			responses = append(responses, responsesByOperator[op.OperatorAddress])
+		}
+	}
 	if len(responses) < threshold {
+		if len(invalidOperators) > 0 {
+			return nil, fmt.Errorf("insufficient valid responses: got %d, need %d (invalid partial signatures from: %s)",
+				len(responses), threshold, formatAddresses(invalidOperators))
+		}
 		return nil, fmt.Errorf("insufficient responses: got %d, need %d", len(responses), threshold)
 	}
 
@@ -676,24 +813,49 @@ func (c *Client) RetrieveSecretsWithOptions(appID string, opts *SecretsOptions)
 		c.logger.Sugar().Info("Verified threshold agreement on environment data")
 	}
 
-	// Step 6: Recover application private key with retry to tolerate invalid partial signatures.
-	// Attempt pairing-based validation using master public key. If the master public key
-	// cannot be fetched (e.g., key rotation race), fall back to single-attempt recovery.
+	// Step 6: Recover application private key.
+	// With individually verified partial signatures any threshold subset recovers the
+	// key, so a single interpolation suffices; it is still checked against the master
+	// public key. Otherwise fall back to subset search validated by the master public
+	// key, or, if that cannot be fetched either, to single-attempt recovery.
 	// partialSigs is already a map[common.Address]types.G1Point with correct node IDs
 	var appPrivateKey *types.G1Point
 	verified := false
-	masterPubKey, masterPKErr := c.GetMasterPublicKey(operators)
-	if masterPKErr == nil {
-		appPrivateKey, err = crypto.RecoverAppPrivateKeyWithRetry(appID, partialSigs, threshold, func(candidate *types.G1Point) bool {
+	var keyGeneration *uint32
+	masterPubKey, generation, masterPKErr := c.getMasterPublicKey(operators)
+	if errors.Is(masterPKErr, ErrMasterPublicKeyMismatch) {
+		// Served a key that failed on-chain verification or broke its pin: recovery
+		// must not go on as if the key were merely unavailable.
+		return nil, fmt.Errorf("failed to get master public key: %w", masterPKErr)
+	}
+	switch {
+	case masterPKErr == nil:
+		validate := func(candidate *types.G1Point) bool {
 			valid, verifyErr := crypto.VerifyAppPrivateKey(appID, *candidate, *masterPubKey)
 			return verifyErr == nil && valid
-		})
+		}
+		if sigsVerified {
+			appPrivateKey, err = crypto.RecoverAppPrivateKey(appID, partialSigs, threshold)
+			if err == nil && !validate(appPrivateKey) {
+				err = fmt.Errorf("recovered app private key does not match master public key")
+			}
+		} else {
+			appPrivateKey, err = crypto.RecoverAppPrivateKeyWithRetry(appID, partialSigs, threshold, validate)
+		}
 		// A non-error result means a candidate passed the pairing check against
 		// the master public key, so success on this branch is a verified key.
 		if err == nil {
 			verified = true
+			keyGeneration = &generation
 		}
-	} else {
+	case sigsVerified:
+		// Each partial signature passed its pairing check against threshold-agreed
+		// group commitments, whose constant term is the master public key, so the
+		// interpolated key is verified even though /pubkey MPK agreement failed.
+		appPrivateKey, err = crypto.RecoverAppPrivateKey(appID, partialSigs, threshold)
+		verified = err == nil
+		keyGeneration = c.generation
+	default:
 		c.logger.Sugar().Warnw("SECURITY DEGRADED: failed to get master public key, falling back to single-attempt recovery without BFT retry — invalid partial signatures will not be tolerated",
 			"error", masterPKErr)
 		appPrivateKey, err = crypto.RecoverAppPrivateKey(appID, partialSigs, threshold)
@@ -719,16 +881,131 @@ func (c *Client) RetrieveSecretsWithOptions(appID string, opts *SecretsOptions)
 		// platform (stack_id) path the KMS returns just the key share and these
 		// are always empty for honest operators — the stack's secrets are fetched
 		// out-of-band from the platform. Retained for on-chain caller compatibility.
-		EncryptedEnv:    responses[0].EncryptedEnv,
-		PublicEnv:       responses[0].PublicEnv,
-		PartialSigs:     partialSigs,
-		ResponseCount:   len(responses),
-		ThresholdNeeded: threshold,
-		ExtraData:       opts.ExtraData,
-		Verified:        verified,
+		EncryptedEnv:     responses[0].EncryptedEnv,
+		PublicEnv:        responses[0].PublicEnv,
+		PartialSigs:      partialSigs,
+		InvalidOperators: invalidOperators,
+		ResponseCount:    len(responses),
+		ThresholdNeeded:  threshold,
+		ExtraData:        opts.ExtraData,
+		Verified:         verified,
+		Generation:       keyGeneration,
 	}, nil
 }
 
+// validateSecretsOptions checks the options of an attested request, defaulting the
+// attestation method to "gcp".
+func validateSecretsOptions(opts *SecretsOptions) error {
+	if opts == nil {
+		return fmt.Errorf("options are required")
+	}
+	if opts.AttestationMethod == "" {
+		opts.AttestationMethod = "gcp"
+	}
+	if len(opts.RSAPrivateKeyPEM) == 0 || len(opts.RSAPublicKeyPEM) == 0 {
+		return fmt.Errorf("RSA key pair is required in options")
+	}
+	if len(opts.ExtraData) > types.MaxExtraDataSize {
+		return fmt.Errorf("extra_data exceeds 1MB limit (%d bytes)", len(opts.ExtraData))
+	}
+	if opts.AttestationMethod == "eigenx-snp" {
+		if len(opts.RawSNPEvidence) == 0 {
+			return fmt.Errorf("RawSNPEvidence is required for eigenx-snp attestation method")
+		}
+		if len(opts.CCInitData) == 0 {
+			return fmt.Errorf("CCInitData is required for eigenx-snp attestation method")
+		}
+		// Mirror the server-side cap in handlers.go so we don't waste bandwidth
+		// marshalling a payload the operator will reject.
+		if len(opts.CCInitData) > types.MaxExtraDataSize {
+			return fmt.Errorf("CCInitData exceeds 1MB limit (%d bytes)", len(opts.CCInitData))
+		}
+	}
+	if opts.AttestationMethod == "eigenx-tdx" {
+		if len(opts.RawTDXEvidence) == 0 {
+			return fmt.Errorf("RawTDXEvidence is required for eigenx-tdx attestation method")
+		}
+		if len(opts.CCInitData) > types.MaxExtraDataSize {
+			return fmt.Errorf("CCInitData exceeds 1MB limit (%d bytes)", len(opts.CCInitData))
+		}
+	}
+	if opts.AttestationMethod == "nitro" && len(opts.NitroAttestationDocument) == 0 {
+		return fmt.Errorf("NitroAttestationDocument is required for nitro attestation method")
+	}
+	return nil
+}
+
+// createAttestationRequest builds the attested request for opts.AttestationMethod that
+// /secrets and /v1/app/sign-message authorize.
+func (c *Client) createAttestationRequest(appID string, opts *SecretsOptions) (types.SecretsRequestV1, error) {
+	var req types.SecretsRequestV1
+	var err error
+
+	switch opts.AttestationMethod {
+	case "ecdsa":
+		req, err = c.createECDSAAttestationRequest(appID, opts, opts.RSAPublicKeyPEM)
+		if err != nil {
+			return types.SecretsRequestV1{}, fmt.Errorf("failed to create ECDSA attestation: %w", err)
+		}
+		req.ExtraData = opts.ExtraData
+
+	case "gcp", "intel":
+		attestationClaims := types.AttestationClaims{
+			AppID:       appID,
+			ImageDigest: opts.ImageDigest,
+			IssuedAt:    time.Now().Unix(),
+			PublicKey:   opts.RSAPublicKeyPEM,
+		}
+		attestationBytes, err := json.Marshal(attestationClaims)
+		if err != nil {
+			return types.SecretsRequestV1{}, fmt.Errorf("failed to create attestation: %w", err)
+		}
+
+		req = types.SecretsRequestV1{
+			AppID:             appID,
+			AttestationMethod: opts.AttestationMethod,
+			Attestation:       attestationBytes,
+			RSAPubKeyTmp:      opts.RSAPublicKeyPEM,
+			AttestationTime:   time.Now().Unix(),
+			ExtraData:         opts.ExtraData,
+		}
+
+	case "tpm":
+		if len(opts.TPMAttestationBytes) == 0 {
+			return types.SecretsRequestV1{}, fmt.Errorf("TPM attestation bytes are required for tpm method")
+		}
+		req = types.SecretsRequestV1{
+			AppID:             appID,
+			AttestationMethod: "tpm",
+			Attestation:       opts.TPMAttestationBytes,
+			RSAPubKeyTmp:      opts.RSAPublicKeyPEM,
+			AttestationTime:   time.Now().Unix(),
+			ExtraData:         opts.ExtraData,
+		}
+
+	case "eigenx-snp":
+		req = c.createEigenXSNPAttestationRequest(appID, opts)
+
+	case "eigenx-tdx":
+		req = c.createEigenXTDXAttestationRequest(appID, opts)
+
+	case "nitro":
+		req = types.SecretsRequestV1{
+			AppID:             appID,
+			StackID:           opts.StackID,
+			AttestationMethod: "nitro",
+			Attestation:       opts.NitroAttestationDocument,
+			RSAPubKeyTmp:      opts.RSAPublicKeyPEM,
+			AttestationTime:   time.Now().Unix(),
+			ExtraData:         opts.ExtraData,
+		}
+
+	default:
+		return types.SecretsRequestV1{}, fmt.Errorf("unsupported attestation method: %s", opts.AttestationMethod)
+	}
+	return req, nil
+}
+
 // GetEncryptedSecretsFromKMSNodesWithPartialSigs requests secrets from all KMS operators concurrently
 // and decrypts partial signatures using the provided RSA private key
 // Returns responses and partial signatures mapped by node ID
@@ -737,14 +1014,30 @@ func (c *Client) GetEncryptedSecretsFromKMSNodesWithPartialSigs(
 	req types.SecretsRequestV1,
 	rsaPrivateKeyPEM []byte,
 ) ([]types.SecretsResponseV1, map[common.Address]types.G1Point, error) {
+	responsesByOperator, partialSigs, err := c.collectSecretsResponses(operators, req, rsaPrivateKeyPEM)
+	if err != nil {
+		return nil, nil, err
+	}
+	responses := make([]types.SecretsResponseV1, 0, len(responsesByOperator))
+	for _, resp := range responsesByOperator {
+		responses = append(responses, resp)
+	}
+	return responses, partialSigs, nil
+}
+
+// collectSecretsResponses requests secrets from all operators concurrently and returns
+// each operator's response and decrypted partial signature, keyed by operator address.
+func (c *Client) collectSecretsResponses(
+	operators *peering.OperatorSetPeers,
+	req types.SecretsRequestV1,
+	rsaPrivateKeyPEM []byte,
+) (map[common.Address]types.SecretsResponseV1, map[common.Address]types.G1Point, error) {
 	rsaEncryption := encryption.NewRSAEncryption()
 
 	type result struct {
 		response     types.SecretsResponseV1
 		partialSig   types.G1Point
 		operatorAddr common.Address
-		opAddress    string
-		opIndex      int
 	}
 
 	resultChan := make(chan result, len(operators.Peers))
@@ -789,19 +1082,14 @@ func (c *Client) GetEncryptedSecretsFromKMSNodesWithPartialSigs(
 				return
 			}
 
-			// Convert operator address to node ID
-			operatorAddr := op.OperatorAddress
-
 			c.logger.Sugar().Debugw("Received valid response from operator",
 				"operator_index", idx+1,
-				"operator_address", operatorAddr.Hex())
+				"operator_address", op.OperatorAddress.Hex())
 
 			resultChan <- result{
 				response:     *resp,
 				partialSig:   partialSig,
 				operatorAddr: op.OperatorAddress,
-				opAddress:    op.OperatorAddress.Hex(),
-				opIndex:      idx,
 			}
 		}(i, peer)
 	}
@@ -813,11 +1101,11 @@ func (c *Client) GetEncryptedSecretsFromKMSNodesWithPartialSigs(
 	}()
 
 	// Collect results
-	var responses []types.SecretsResponseV1
+	responses := make(map[common.Address]types.SecretsResponseV1)
 	partialSigs := make(map[common.Address]types.G1Point)
 
 	for res := range resultChan {
-		responses = append(responses, res.response)
+		responses[res.operatorAddr] = res.response
 		partialSigs[res.operatorAddr] = res.partialSig
 	}
 
@@ -828,14 +1116,20 @@ func (c *Client) GetEncryptedSecretsFromKMSNodesWithPartialSigs(
 	return responses, partialSigs, nil
 }
 
+// operatorURL returns the URL of path on an operator, scoped to the client's key.
+func (c *Client) operatorURL(socket, path string) string {
+	return socket + types.KeyRoutePrefix(c.keyID) + path
+}
+
 // requestSecretsFromKMS makes an HTTP request to a single KMS server
 func (c *Client) requestSecretsFromKMS(serverURL string, req types.SecretsRequestV1) (*types.SecretsResponseV1, error) {
+	req.Generation = c.generation
 	reqBody, err := json.Marshal(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	resp, err := c.httpClient.Post(serverURL+"/secrets", "application/json", bytes.NewBuffer(reqBody))
+	resp, err := c.httpClient.Post(c.operatorURL(serverURL, "/secrets"), "application/json", bytes.NewBuffer(reqBody))
 	if err != nil {
 		return nil, fmt.Errorf("HTTP request failed: %w", err)
 	}
@@ -966,6 +1260,30 @@ func (c *Client) createEigenXSNPAttestationRequest(appID string, opts *SecretsOp
 	}
 }
 
+// createEigenXTDXAttestationRequest creates a SecretsRequestV1 carrying a raw
+// Intel TDX quote and the PCS collateral to verify it. The KMS server-side
+// eigenx-tdx method checks the quote against the Intel SGX root, binds the
+// nonce (and cc_init_data, when sent) through REPORTDATA, and replays RTMR[3]
+// to recover the launched image reference.
+func (c *Client) createEigenXTDXAttestationRequest(appID string, opts *SecretsOptions) types.SecretsRequestV1 {
+	c.logger.Sugar().Debugw("Creating eigenx-tdx attestation request",
+		"app_id", appID,
+		"evidence_size", len(opts.RawTDXEvidence),
+		"cc_init_data_size", len(opts.CCInitData),
+	)
+
+	return types.SecretsRequestV1{
+		AppID:             appID,
+		StackID:           opts.StackID,
+		AttestationMethod: "eigenx-tdx",
+		Attestation:       opts.RawTDXEvidence,
+		RSAPubKeyTmp:      opts.RSAPublicKeyPEM,
+		AttestationTime:   time.Now().Unix(),
+		ExtraData:         opts.ExtraData,
+		CCInitData:        opts.CCInitData,
+	}
+}
+
 // EncryptForApp encrypts data for a specific application using IBE
 func (c *Client) EncryptForApp(appID string, plaintext []byte) ([]byte, error) {
 	operators, err := c.GetOperators()
@@ -973,12 +1291,16 @@ func (c *Client) EncryptForApp(appID string, plaintext []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to get operators: %w", err)
 	}
 
-	masterPubKey, err := c.GetMasterPublicKey(operators)
+	masterPubKey, generation, err := c.getMasterPublicKey(operators)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get master public key: %w", err)
 	}
 
-	return crypto.EncryptForApp(appID, *masterPubKey, plaintext)
+	ciphertext, err := crypto.EncryptForApp(appID, *masterPubKey, plaintext)
+	if err != nil {
+		return nil, err
+	}
+	return crypto.WithGeneration(generation, ciphertext), nil
 }
 
 // DecryptForApp decrypts data by collecting partial signatures and recovering app private key
@@ -990,8 +1312,9 @@ func (c *Client) DecryptForApp(appID string, ciphertext []byte, attestationTime
 
 	threshold := dkg.CalculateThreshold(len(operators.Peers))
 
-	// Collect partial signatures from all available operators
-	partialSigs, err := c.collectPartialSignaturesForDecrypt(appID, operators, attestationTime, threshold)
+	// Collect partial signatures from all available operators, for the generation the
+	// ciphertext was encrypted to
+	partialSigs, err := c.forCiphertext(ciphertext).collectPartialSignaturesForDecrypt(appID, operators, attestationTime, threshold)
 	if err != nil {
 		return nil, fmt.Errorf("failed to collect partial signatures: %w", err)
 	}
@@ -1025,6 +1348,7 @@ func (c *Client) collectPartialSignaturesForDecrypt(appID string, operators *pee
 			req := types.AppSignRequest{
 				AppID:           appID,
 				AttestationTime: attestationTime,
+				Generation:      c.generation,
 			}
 
 			reqBody, err := json.Marshal(req)
@@ -1033,7 +1357,7 @@ func (c *Client) collectPartialSignaturesForDecrypt(appID string, operators *pee
 				return
 			}
 
-			resp, err := c.httpClient.Post(op.SocketAddress+"/app/sign", "application/json", bytes.NewReader(reqBody))
+			resp, err := c.httpClient.Post(c.operatorURL(op.SocketAddress, "/app/sign"), "application/json", bytes.NewReader(reqBody))
 			if err != nil {
 				c.logger.Sugar().Warnw("Failed to contact operator", "operator_index", idx, "url", op.SocketAddress, "error", err)
 				return
@@ -1089,22 +1413,23 @@ func (c *Client) collectPartialSignaturesForDecrypt(appID string, operators *pee
 		close(resultChan)
 	}()
 
-	// Collect all available results (not just threshold) to enable retry with
-	// alternative subsets if some operators provided invalid signatures (KMS-012)
 	partialSigs := make(map[common.Address]types.G1Point)
-	// TODO(security): DOS vector — only count cryptographically verified partial signatures.
-	// Current code counts non-zero shares and relies on later interpolation failure.
-	// With KMS-012 retry logic, a single malicious operator can now trigger up to
-	// DefaultMaxRecoveryAttempts (1000) Lagrange interpolation + pairing calls.
 	for res := range resultChan {
 		partialSigs[res.operatorAddr] = res.signature
 	}
 
+	// Only count partial signatures that verify against the operator's public key
+	// share (for the key version used at attestationTime).
+	partialSigs, invalid, verified := c.filterVerifiedPartialSignatures(appID, operators, attestationTime, partialSigs)
 	if len(partialSigs) < threshold {
+		if len(invalid) > 0 {
+			return nil, fmt.Errorf("insufficient valid partial signatures: collected %d, needed %d (invalid from: %s)",
+				len(partialSigs), threshold, formatAddresses(invalid))
+		}
 		return nil, fmt.Errorf("insufficient partial signatures: collected %d, needed %d", len(partialSigs), threshold)
 	}
 
-	c.logger.Sugar().Infow("Successfully collected partial signatures", "collected", len(partialSigs), "threshold", threshold)
+	c.logger.Sugar().Infow("Successfully collected partial signatures", "collected", len(partialSigs), "invalid", len(invalid), "verified", verified, "threshold", threshold)
 	return partialSigs, nil
 }
 
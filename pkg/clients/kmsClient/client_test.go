@@ -1,6 +1,7 @@
 package kmsClient
 
 import (
+	"bytes"
 	"encoding/base64"
 	"encoding/json"
 	"math/big"
@@ -302,6 +303,38 @@ func TestDecryptWithRetry_ValidCiphertext(t *testing.T) {
 	assert.Equal(t, plaintext, result)
 }
 
+func TestEncryptDecryptStream(t *testing.T) {
+	appID := "test-stream-app"
+	sharing := newTestSharing(t, appID, 5, 4)
+	operators := startTestOperators(t, sharing, map[common.Address]types.G1Point{
+		// One operator returns another's partial signature
+		common.BigToAddress(big.NewInt(2)): sharing.partialSigs[common.BigToAddress(big.NewInt(1))],
+	})
+	client := newVerifyTestClient(t)
+
+	plaintext := bytes.Repeat([]byte("model weights "), 20000)
+	var ciphertext bytes.Buffer
+	n, err := client.EncryptStream(appID, &ciphertext, bytes.NewReader(plaintext), operators)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(plaintext)), n)
+
+	var decrypted bytes.Buffer
+	n, err = client.DecryptStream(appID, &decrypted, bytes.NewReader(ciphertext.Bytes()), operators, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(plaintext)), n)
+	assert.Equal(t, plaintext, decrypted.Bytes())
+
+	// The in-memory path reads the chunked format too
+	result, err := client.Decrypt(appID, ciphertext.Bytes(), operators, 0)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, result)
+
+	// A truncated ciphertext fails, though earlier chunks were written out
+	decrypted.Reset()
+	_, err = client.DecryptStream(appID, &decrypted, bytes.NewReader(ciphertext.Bytes()[:ciphertext.Len()-100]), operators, 0)
+	require.ErrorContains(t, err, "failed to decrypt data")
+}
+
 // generateTestPartialSigs creates partial signatures for testing.
 // Returns a map of address -> partial signature.
 func generateTestPartialSigs(t *testing.T, appID string, n, threshold int) map[common.Address]types.G1Point {
@@ -402,6 +435,42 @@ func TestGetMasterPublicKey_ThresholdAgreement_AllHonest(t *testing.T) {
 	assert.True(t, mpk.IsEqual(&crypto.G2Generator), "MPK should match the honest value")
 }
 
+func TestGetMasterPublicKey_KeyScoped(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+
+	// Servers holding several keys serve each key under /v1/keys/<id>/.
+	commitments := []types.G2Point{crypto.G2Generator}
+	inner := createMockPubkeyServer(t, commitments, &crypto.G2Generator)
+	defer inner.Close()
+	mux := http.NewServeMux()
+	mux.Handle("/v1/keys/tenant-b/", http.StripPrefix("/v1/keys/tenant-b", inner.Config.Handler))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	peers := []*peering.OperatorSetPeer{{
+		OperatorAddress: common.HexToAddress("0x1000000000000000000000000000000000000000"),
+		SocketAddress:   srv.URL,
+	}}
+
+	client := &Client{
+		avsAddress:    "0x1234567890123456789012345678901234567890",
+		operatorSetID: 2,
+		keyID:         "tenant-b",
+		logger:        logger,
+		httpClient:    &http.Client{},
+	}
+
+	mpk, err := client.GetMasterPublicKey(&peering.OperatorSetPeers{Peers: peers})
+	require.NoError(t, err)
+	assert.True(t, mpk.IsEqual(&crypto.G2Generator))
+
+	// The same server has no unscoped /pubkey.
+	client.keyID = ""
+	_, err = client.GetMasterPublicKey(&peering.OperatorSetPeers{Peers: peers})
+	require.Error(t, err)
+}
+
 func TestGetMasterPublicKey_ThresholdAgreement_OneCorrupted(t *testing.T) {
 	logger, err := zap.NewDevelopment()
 	require.NoError(t, err)
@@ -687,6 +756,69 @@ func TestRetrieveSecretsWithOptions_EigenXSNP_Validation(t *testing.T) {
 	}
 }
 
+func TestRetrieveSecretsWithOptions_EigenXTDX(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+
+	c := &Client{
+		logger: logger,
+	}
+
+	opts := &SecretsOptions{
+		AttestationMethod: "eigenx-tdx",
+		RawTDXEvidence:    []byte(`{"quote":"","image_ref":"","collateral":{}}`),
+		RSAPrivateKeyPEM:  []byte("key"),
+		RSAPublicKeyPEM:   []byte("pub"),
+		ExtraData:         []byte("extra"),
+		StackID:           "stack-123",
+	}
+
+	// cc_init_data is optional for eigenx-tdx
+	require.NoError(t, validateSecretsOptions(opts))
+	req, err := c.createAttestationRequest("app-id", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "app-id", req.AppID)
+	assert.Equal(t, "stack-123", req.StackID)
+	assert.Equal(t, "eigenx-tdx", req.AttestationMethod)
+	assert.Equal(t, opts.RawTDXEvidence, req.Attestation)
+	assert.Equal(t, opts.RSAPublicKeyPEM, req.RSAPubKeyTmp)
+	assert.Equal(t, opts.ExtraData, req.ExtraData)
+	assert.Nil(t, req.CCInitData)
+
+	opts.RawTDXEvidence = nil
+	_, err = c.RetrieveSecretsWithOptions("app-id", opts)
+	require.ErrorContains(t, err, "RawTDXEvidence is required for eigenx-tdx attestation method")
+}
+
+func TestRetrieveSecretsWithOptions_Nitro(t *testing.T) {
+	logger, err := zap.NewDevelopment()
+	require.NoError(t, err)
+
+	c := &Client{
+		logger: logger,
+	}
+
+	opts := &SecretsOptions{
+		AttestationMethod:        "nitro",
+		NitroAttestationDocument: []byte{0xd2, 0x84},
+		RSAPrivateKeyPEM:         []byte("key"),
+		RSAPublicKeyPEM:          []byte("pub"),
+		ExtraData:                []byte("extra"),
+	}
+
+	require.NoError(t, validateSecretsOptions(opts))
+	req, err := c.createAttestationRequest("app-id", opts)
+	require.NoError(t, err)
+	assert.Equal(t, "nitro", req.AttestationMethod)
+	assert.Equal(t, opts.NitroAttestationDocument, req.Attestation)
+	assert.Equal(t, opts.RSAPublicKeyPEM, req.RSAPubKeyTmp)
+	assert.Equal(t, opts.ExtraData, req.ExtraData)
+
+	opts.NitroAttestationDocument = nil
+	_, err = c.RetrieveSecretsWithOptions("app-id", opts)
+	require.ErrorContains(t, err, "NitroAttestationDocument is required for nitro attestation method")
+}
+
 // TestEigenXSNP_WireFormat verifies the raw-SNP evidence is base64-encoded on
 // the wire (per the brief's "<base64 of raw-SNP evidence JSON>" contract) by
 // virtue of Go's []byte JSON marshalling — no explicit double-encoding.
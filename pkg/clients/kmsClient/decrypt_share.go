@@ -0,0 +1,492 @@
+package kmsClient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// ThresholdDecrypt decrypts ciphertexts without reconstructing the app private key:
+// operators return a decryption share of each ciphertext's C1 from /app/decrypt-share,
+// authorized with the same attestation as RetrieveSecretsWithOptions, every share is
+// checked against its operator's public key share, and a threshold of the shares that
+// verify is combined per ciphertext. An operator's answer is useless for any ciphertext
+// but those presented, and opts.ExtraData must begin with their binding (see
+// CiphertextExtraData) so that the attestation names them.
+//
+// Verification needs the group commitments operators agree on; there is no fallback to
+// unverified shares. At most types.MaxCiphertextsPerRequest ciphertexts, all encrypted
+// to the same generation.
+func (c *Client) ThresholdDecrypt(appID string, ciphertexts [][]byte, opts *SecretsOptions) ([][]byte, error) {
+	if appID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+	if err := validateSecretsOptions(opts); err != nil {
+		return nil, err
+	}
+	if len(ciphertexts) > types.MaxCiphertextsPerRequest {
+		return nil, fmt.Errorf("at most %d ciphertexts per request, got %d", types.MaxCiphertextsPerRequest, len(ciphertexts))
+	}
+	c1s, err := ciphertextC1s(ciphertexts)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCiphertextBinding(c1s, opts.ExtraData); err != nil {
+		return nil, err
+	}
+	// Shares and commitments are those of the generation the ciphertexts were encrypted to
+	c, err = c.forCiphertexts(ciphertexts)
+	if err != nil {
+		return nil, err
+	}
+
+	operators, err := c.GetOperators()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operators: %w", err)
+	}
+	req, err := c.createAttestationRequest(appID, opts)
+	if err != nil {
+		return nil, err
+	}
+	req.CiphertextC1s = c1s
+
+	groupCommitments, err := c.getGroupCommitments(operators, req.AttestationTime)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify decryption shares: %w", err)
+	}
+
+	shares, err := c.collectDecryptionShares(operators, types.AppDecryptShareRequest{SecretsRequestV1: req}, opts.RSAPrivateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := c.combineDecryptionShares(appID, c1s, shares, groupCommitments)
+	if err != nil {
+		return nil, err
+	}
+	plaintexts := make([][]byte, len(ciphertexts))
+	for i, key := range keys {
+		plaintexts[i], err = key.DecryptPQ(appID, opts.PQKeys, ciphertexts[i])
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt ciphertext %d: %w", i, err)
+		}
+	}
+
+	c.logger.Sugar().Infow("Threshold decrypted ciphertexts",
+		"app_id", appID,
+		"ciphertexts", len(ciphertexts))
+	return plaintexts, nil
+}
+
+// CiphertextExtraData returns the extra_data to attest for a request that decrypts
+// ciphertexts: their binding (crypto.CiphertextBinding of their C1s) followed by
+// extraData. Set it as SecretsOptions.ExtraData, and bind attestation evidence produced
+// outside the client (eigenx-snp, eigenx-tdx, nitro) to it.
+func CiphertextExtraData(ciphertexts [][]byte, extraData []byte) ([]byte, error) {
+	c1s, err := ciphertextC1s(ciphertexts)
+	if err != nil {
+		return nil, err
+	}
+	return append(crypto.CiphertextBinding(c1s), extraData...), nil
+}
+
+// checkCiphertextBinding checks that extraData begins with the binding of c1s, which
+// operators require before serving decryption shares.
+func checkCiphertextBinding(c1s []types.G2Point, extraData []byte) error {
+	if !bytes.HasPrefix(extraData, crypto.CiphertextBinding(c1s)) {
+		return fmt.Errorf("extra_data must begin with the ciphertext binding; build it with CiphertextExtraData")
+	}
+	return nil
+}
+
+// ciphertextC1s returns the C1 of each ciphertext.
+func ciphertextC1s(ciphertexts [][]byte) ([]types.G2Point, error) {
+	if len(ciphertexts) == 0 {
+		return nil, fmt.Errorf("no ciphertexts provided")
+	}
+	c1s := make([]types.G2Point, len(ciphertexts))
+	for i, ciphertext := range ciphertexts {
+		c1, err := crypto.CiphertextC1(ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("ciphertext %d: %w", i, err)
+		}
+		c1s[i] = *c1
+	}
+	return c1s, nil
+}
+
+// collectDecryptionShares requests decryption shares of req.CiphertextC1s from all
+// operators concurrently. Entry i of the result holds the shares of
+// req.CiphertextC1s[i] keyed by operator; operators that fail or answer malformed are
+// left out.
+func (c *Client) collectDecryptionShares(operators *peering.OperatorSetPeers, req types.AppDecryptShareRequest, rsaPrivateKeyPEM []byte) ([]map[common.Address]types.DecryptionShare, error) {
+	type result struct {
+		operatorAddr common.Address
+		shares       []types.DecryptionShare
+	}
+
+	rsaEncryption := encryption.NewRSAEncryption()
+	resultChan := make(chan result, len(operators.Peers))
+	var wg sync.WaitGroup
+
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	for i, operator := range operators.Peers {
+		wg.Add(1)
+		go func(idx int, op *peering.OperatorSetPeer) {
+			defer wg.Done()
+
+			resp, err := c.httpClient.Post(c.operatorURL(op.SocketAddress, "/app/decrypt-share"), "application/json", bytes.NewReader(reqBody))
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to contact operator",
+					"operator_index", idx,
+					"address", op.SocketAddress,
+					"error", err,
+				)
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+				c.logger.Sugar().Warnw("Operator returned error",
+					"operator_index", idx,
+					"status_code", resp.StatusCode,
+					"body", string(body),
+				)
+				return
+			}
+
+			var response types.AppDecryptShareResponse
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				c.logger.Sugar().Warnw("Failed to decode response from operator",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+
+			// SECURITY: bind response identity to the operator we actually queried
+			if !strings.EqualFold(response.OperatorAddress, op.OperatorAddress.Hex()) {
+				c.logger.Sugar().Warnw("Operator address mismatch in decryption share response",
+					"operator_index", idx,
+					"expected_operator_address", op.OperatorAddress.Hex(),
+					"response_operator_address", response.OperatorAddress,
+				)
+				return
+			}
+
+			payloadBytes, err := rsaEncryption.DecryptHybrid(response.EncryptedDecryptionShares, rsaPrivateKeyPEM)
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to decrypt decryption shares",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+			var payload types.SecretsDecryptionShares
+			if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+				c.logger.Sugar().Warnw("Failed to parse decryption shares",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+			if len(payload.Shares) != len(req.CiphertextC1s) {
+				c.logger.Sugar().Warnw("Operator returned the wrong number of decryption shares",
+					"operator_index", idx,
+					"expected", len(req.CiphertextC1s),
+					"got", len(payload.Shares),
+				)
+				return
+			}
+
+			resultChan <- result{operatorAddr: op.OperatorAddress, shares: payload.Shares}
+		}(i, operator)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	shares := make([]map[common.Address]types.DecryptionShare, len(req.CiphertextC1s))
+	for i := range shares {
+		shares[i] = make(map[common.Address]types.DecryptionShare)
+	}
+	for res := range resultChan {
+		for i, share := range res.shares {
+			shares[i][res.operatorAddr] = share
+		}
+	}
+	return shares, nil
+}
+
+// combineDecryptionShares checks every decryption share against its operator's public
+// key share derived from groupCommitments and combines, per ciphertext, those that
+// verify. shares[i] holds the shares of c1s[i]. The threshold is the size of the group
+// polynomial.
+func (c *Client) combineDecryptionShares(
+	appID string,
+	c1s []types.G2Point,
+	shares []map[common.Address]types.DecryptionShare,
+	groupCommitments []types.G2Point,
+) ([]*crypto.CiphertextKey, error) {
+	threshold := len(groupCommitments)
+	publicKeyShares := make(map[common.Address]types.G2Point)
+	invalid := make(map[common.Address]bool)
+
+	keys := make([]*crypto.CiphertextKey, len(c1s))
+	for i, c1 := range c1s {
+		valid := make(map[common.Address]types.DecryptionShare, len(shares[i]))
+		for addr, share := range shares[i] {
+			pkShare, ok := publicKeyShares[addr]
+			if !ok {
+				pk, err := crypto.ComputeOperatorPublicKeyShare(groupCommitments, addr)
+				if err != nil {
+					invalid[addr] = true
+					continue
+				}
+				pkShare = *pk
+				publicKeyShares[addr] = pkShare
+			}
+			ok, err := crypto.VerifyDecryptionShare(appID, c1, share, pkShare)
+			if err != nil || !ok {
+				if !invalid[addr] {
+					c.logger.Sugar().Warnw("Operator returned invalid decryption share, skipping",
+						"operator_address", addr.Hex(),
+						"app_id", appID,
+						"ciphertext", i)
+				}
+				invalid[addr] = true
+				continue
+			}
+			valid[addr] = share
+		}
+
+		if len(valid) < threshold {
+			if len(invalid) > 0 {
+				return nil, fmt.Errorf("insufficient valid decryption shares for ciphertext %d: got %d, need %d (invalid from: %s)",
+					i, len(valid), threshold, formatAddresses(sortedAddresses(invalid)))
+			}
+			return nil, fmt.Errorf("insufficient decryption shares for ciphertext %d: got %d, need %d", i, len(valid), threshold)
+		}
+		key, err := crypto.CombineDecryptionShares(c1, valid, threshold)
+		if err != nil {
+			return nil, fmt.Errorf("failed to combine decryption shares for ciphertext %d: %w", i, err)
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// retrieveSecretsDecryptions completes RetrieveSecretsWithOptions when opts.Ciphertexts
+// is set: the attested request asks for decryption shares of those ciphertexts, and of
+// the encrypted environment, in place of the partial signature.
+func (c *Client) retrieveSecretsDecryptions(appID string, opts *SecretsOptions, operators *peering.OperatorSetPeers, req types.SecretsRequestV1) (*SecretsResult, error) {
+	c1s, err := ciphertextC1s(opts.Ciphertexts)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkCiphertextBinding(c1s, opts.ExtraData); err != nil {
+		return nil, err
+	}
+	req.CiphertextC1s = c1s
+
+	groupCommitments, err := c.getGroupCommitments(operators, req.AttestationTime)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify decryption shares: %w", err)
+	}
+
+	responses, payloads, err := c.collectSecretsDecryptionShares(operators, req, len(c1s), opts.RSAPrivateKeyPEM)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect secrets: %w", err)
+	}
+
+	// Every response must carry the same environment, as with partial signatures; the
+	// platform (stack_id) path returns none
+	var encryptedEnv, publicEnv string
+	for _, op := range operators.Peers {
+		if resp, ok := responses[op.OperatorAddress]; ok {
+			encryptedEnv, publicEnv = resp.EncryptedEnv, resp.PublicEnv
+			break
+		}
+	}
+	if opts.StackID == "" {
+		for addr, resp := range responses {
+			if resp.EncryptedEnv != encryptedEnv {
+				return nil, fmt.Errorf("environment data mismatch from operator %s", addr.Hex())
+			}
+		}
+	}
+
+	shares := make([]map[common.Address]types.DecryptionShare, len(c1s))
+	for i := range shares {
+		shares[i] = make(map[common.Address]types.DecryptionShare)
+	}
+	for addr, payload := range payloads {
+		for i, share := range payload.Shares {
+			shares[i][addr] = share
+		}
+	}
+
+	// The environment is decrypted along with the ciphertexts when it is an IBE
+	// ciphertext; otherwise it is returned as is in EncryptedEnv
+	var envCiphertext []byte
+	if opts.StackID == "" && encryptedEnv != "" {
+		if decoded, err := hex.DecodeString(encryptedEnv); err == nil {
+			if envC1, err := crypto.CiphertextC1(decoded); err == nil {
+				envShares := make(map[common.Address]types.DecryptionShare)
+				for addr, payload := range payloads {
+					if payload.EnvShare != nil {
+						envShares[addr] = *payload.EnvShare
+					}
+				}
+				envCiphertext = decoded
+				c1s = append(c1s, *envC1)
+				shares = append(shares, envShares)
+			}
+		}
+	}
+
+	keys, err := c.combineDecryptionShares(appID, c1s, shares, groupCommitments)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SecretsResult{
+		EncryptedEnv:    encryptedEnv,
+		PublicEnv:       publicEnv,
+		ResponseCount:   len(responses),
+		ThresholdNeeded: len(groupCommitments),
+		ExtraData:       opts.ExtraData,
+		Verified:        true,
+		Plaintexts:      make([][]byte, len(opts.Ciphertexts)),
+	}
+	for i, ciphertext := range opts.Ciphertexts {
+		result.Plaintexts[i], err = keys[i].DecryptPQ(appID, opts.PQKeys, ciphertext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt ciphertext %d: %w", i, err)
+		}
+	}
+	if envCiphertext != nil {
+		result.Env, err = keys[len(keys)-1].DecryptPQ(appID, opts.PQKeys, envCiphertext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt environment: %w", err)
+		}
+	}
+
+	c.logger.Sugar().Infow("Successfully decrypted secrets with decryption shares",
+		"app_id", appID,
+		"ciphertexts", len(opts.Ciphertexts),
+		"env", envCiphertext != nil)
+	return result, nil
+}
+
+// collectSecretsDecryptionShares requests secrets from all operators concurrently and
+// returns each operator's response and decrypted decryption shares, keyed by operator
+// address, like collectSecretsResponses does for partial signatures.
+func (c *Client) collectSecretsDecryptionShares(
+	operators *peering.OperatorSetPeers,
+	req types.SecretsRequestV1,
+	ciphertexts int,
+	rsaPrivateKeyPEM []byte,
+) (map[common.Address]types.SecretsResponseV1, map[common.Address]types.SecretsDecryptionShares, error) {
+	rsaEncryption := encryption.NewRSAEncryption()
+
+	type result struct {
+		response     types.SecretsResponseV1
+		payload      types.SecretsDecryptionShares
+		operatorAddr common.Address
+	}
+
+	resultChan := make(chan result, len(operators.Peers))
+	var wg sync.WaitGroup
+
+	for _, peer := range operators.Peers {
+		wg.Add(1)
+		go func(op *peering.OperatorSetPeer) {
+			defer wg.Done()
+
+			resp, err := c.requestSecretsFromKMS(op.SocketAddress, req)
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to get secrets from operator",
+					"url", op.SocketAddress,
+					"error", err,
+				)
+				return
+			}
+
+			payloadBytes, err := rsaEncryption.DecryptHybrid(resp.EncryptedDecryptionShares, rsaPrivateKeyPEM)
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to decrypt decryption shares",
+					"url", op.SocketAddress,
+					"error", err,
+				)
+				return
+			}
+			var payload types.SecretsDecryptionShares
+			if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+				c.logger.Sugar().Warnw("Failed to parse decryption shares",
+					"url", op.SocketAddress,
+					"error", err,
+				)
+				return
+			}
+			if len(payload.Shares) != ciphertexts {
+				c.logger.Sugar().Warnw("Operator returned the wrong number of decryption shares",
+					"url", op.SocketAddress,
+					"expected", ciphertexts,
+					"got", len(payload.Shares),
+				)
+				return
+			}
+
+			resultChan <- result{response: *resp, payload: payload, operatorAddr: op.OperatorAddress}
+		}(peer)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	responses := make(map[common.Address]types.SecretsResponseV1)
+	payloads := make(map[common.Address]types.SecretsDecryptionShares)
+	for res := range resultChan {
+		responses[res.operatorAddr] = res.response
+		payloads[res.operatorAddr] = res.payload
+	}
+
+	if len(responses) == 0 {
+		return nil, nil, fmt.Errorf("failed to collect any valid responses from operators")
+	}
+	return responses, payloads, nil
+}
+
+// sortedAddresses returns the addresses of a set in byte order.
+func sortedAddresses(set map[common.Address]bool) []common.Address {
+	addrs := make([]common.Address, 0, len(set))
+	for addr := range set {
+		addrs = append(addrs, addr)
+	}
+	sort.Slice(addrs, func(i, j int) bool {
+		return bytes.Compare(addrs[i].Bytes(), addrs[j].Bytes()) < 0
+	})
+	return addrs
+}
@@ -0,0 +1,139 @@
+package kmsClient
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestThresholdDecrypt(t *testing.T) {
+	appID := "test-threshold-app"
+	sharing := newTestSharing(t, appID, 5, 3)
+	mpk := sharing.groupCommitments[0]
+
+	ciphertexts := make([][]byte, 3)
+	for i := range ciphertexts {
+		ct, err := crypto.EncryptForApp(appID, mpk, fmt.Appendf(nil, "secret %d", i))
+		require.NoError(t, err)
+		ciphertexts[i] = ct
+	}
+
+	privPEM, pubPEM, err := encryption.GenerateKeyPair(2048)
+	require.NoError(t, err)
+	newOptions := func(t *testing.T, ciphertexts [][]byte) *SecretsOptions {
+		extraData, err := CiphertextExtraData(ciphertexts, []byte("caller data"))
+		require.NoError(t, err)
+		return &SecretsOptions{
+			AttestationMethod:   "tpm",
+			TPMAttestationBytes: []byte("evidence"),
+			RSAPrivateKeyPEM:    privPEM,
+			RSAPublicKeyPEM:     pubPEM,
+			ExtraData:           extraData,
+		}
+	}
+
+	t.Run("decrypts with verified shares", func(t *testing.T) {
+		// Two operators answer with shares made with wrong key shares
+		operators := startTestOperators(t, sharing, map[common.Address]types.G1Point{
+			common.BigToAddress(big.NewInt(2)): sharing.partialSigs[common.BigToAddress(big.NewInt(1))],
+			common.BigToAddress(big.NewInt(4)): sharing.partialSigs[common.BigToAddress(big.NewInt(1))],
+		})
+		client := newThresholdDecryptClient(t, operators)
+
+		plaintexts, err := client.ThresholdDecrypt(appID, ciphertexts, newOptions(t, ciphertexts))
+		require.NoError(t, err)
+		require.Len(t, plaintexts, len(ciphertexts))
+		for i, plaintext := range plaintexts {
+			assert.Equal(t, fmt.Sprintf("secret %d", i), string(plaintext))
+		}
+	})
+
+	t.Run("too many invalid shares", func(t *testing.T) {
+		bad := make(map[common.Address]types.G1Point)
+		for i := 1; i <= 3; i++ {
+			bad[common.BigToAddress(big.NewInt(int64(i)))] = sharing.partialSigs[common.BigToAddress(big.NewInt(5))]
+		}
+		operators := startTestOperators(t, sharing, bad)
+		client := newThresholdDecryptClient(t, operators)
+
+		_, err := client.ThresholdDecrypt(appID, ciphertexts, newOptions(t, ciphertexts))
+		require.ErrorContains(t, err, "insufficient valid decryption shares")
+		assert.Contains(t, err.Error(), common.BigToAddress(big.NewInt(1)).Hex())
+	})
+
+	t.Run("extra data must bind the ciphertexts", func(t *testing.T) {
+		client := newVerifyTestClient(t)
+
+		opts := newOptions(t, ciphertexts[:1])
+		_, err := client.ThresholdDecrypt(appID, ciphertexts, opts)
+		require.ErrorContains(t, err, "ciphertext binding")
+
+		opts.ExtraData = nil
+		_, err = client.ThresholdDecrypt(appID, ciphertexts[:1], opts)
+		require.ErrorContains(t, err, "ciphertext binding")
+	})
+
+	t.Run("invalid ciphertext", func(t *testing.T) {
+		client := newVerifyTestClient(t)
+
+		_, err := client.ThresholdDecrypt(appID, [][]byte{ciphertexts[0], []byte("not a ciphertext")}, newOptions(t, ciphertexts))
+		require.ErrorContains(t, err, "ciphertext 1")
+		_, err = client.ThresholdDecrypt(appID, nil, newOptions(t, ciphertexts))
+		require.Error(t, err)
+	})
+}
+
+// newThresholdDecryptClient returns a test client whose operator set is operators.
+func newThresholdDecryptClient(t *testing.T, operators *peering.OperatorSetPeers) *Client {
+	t.Helper()
+	contractCaller := NewMockContractCaller(t)
+	contractCaller.EXPECT().GetOperatorSetMembersWithPeering(
+		"0x1234567890123456789012345678901234567890", uint32(0),
+	).Return(operators, nil)
+	client := newVerifyTestClient(t)
+	client.contractCaller = contractCaller
+	return client
+}
+
+func TestRetrieveSecretsWithOptions_Ciphertexts(t *testing.T) {
+	appID := "test-threshold-app"
+	sharing := newTestSharing(t, appID, 4, 3)
+	mpk := sharing.groupCommitments[0]
+
+	env, err := crypto.EncryptForApp(appID, mpk, []byte("API_KEY=abc"))
+	require.NoError(t, err)
+	sharing.encryptedEnv = hex.EncodeToString(env)
+	data, err := crypto.EncryptForApp(appID, mpk, []byte("dataset key"))
+	require.NoError(t, err)
+
+	client := newThresholdDecryptClient(t, startTestOperators(t, sharing, nil))
+
+	privPEM, pubPEM, err := encryption.GenerateKeyPair(2048)
+	require.NoError(t, err)
+	extraData, err := CiphertextExtraData([][]byte{data}, nil)
+	require.NoError(t, err)
+	result, err := client.RetrieveSecretsWithOptions(appID, &SecretsOptions{
+		AttestationMethod:   "tpm",
+		TPMAttestationBytes: []byte("evidence"),
+		RSAPrivateKeyPEM:    privPEM,
+		RSAPublicKeyPEM:     pubPEM,
+		ExtraData:           extraData,
+		Ciphertexts:         [][]byte{data},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Plaintexts, 1)
+	assert.Equal(t, "dataset key", string(result.Plaintexts[0]))
+	assert.Equal(t, "API_KEY=abc", string(result.Env))
+	assert.Equal(t, sharing.encryptedEnv, result.EncryptedEnv)
+	assert.True(t, result.Verified)
+	assert.Empty(t, result.PartialSigs)
+}
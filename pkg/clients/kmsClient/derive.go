@@ -0,0 +1,38 @@
+package kmsClient
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/derive"
+)
+
+// ErrDerivationGenerationMismatch is returned when an app private key from one master
+// secret generation is asked to derive the keys pinned to another.
+var ErrDerivationGenerationMismatch = errors.New("derivation generation mismatch")
+
+// DeriveRoot returns the derivation root of the recovered app private key, from which
+// the app's mnemonic, wallet keys and labelled secrets derive (see package derive). It
+// refuses an unverified result: a key recovered on the degraded path may be corrupt,
+// and wallets derived from it would silently differ from the app's real ones.
+//
+// The app private key belongs to one master secret generation, and a rotation gives
+// the app another one, so every derived key changes with it. generation pins the
+// generation the app's keys derive from: DeriveRoot fails with
+// ErrDerivationGenerationMismatch unless the key was recovered from that generation,
+// as a client addressing it with WithGeneration recovers it while operators still
+// serve it. Once a pinned generation is rotated out, move what its keys hold to the
+// keys of the new generation before it retires, then pin the new one.
+func (r *SecretsResult) DeriveRoot(generation uint32) (*derive.Root, error) {
+	if !r.Verified {
+		return nil, fmt.Errorf("app private key was not verified against the master public key")
+	}
+	if r.Generation == nil {
+		return nil, fmt.Errorf("%w: the master secret generation of the app private key is unknown", ErrDerivationGenerationMismatch)
+	}
+	if *r.Generation != generation {
+		return nil, fmt.Errorf("%w: app private key belongs to master secret generation %d, but derivation is pinned to generation %d",
+			ErrDerivationGenerationMismatch, *r.Generation, generation)
+	}
+	return derive.NewRoot(r.AppPrivateKey)
+}
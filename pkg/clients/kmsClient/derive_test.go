@@ -0,0 +1,67 @@
+package kmsClient
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/derive"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecretsResult_DeriveRoot(t *testing.T) {
+	qID, err := crypto.HashToG1("test-app-derive")
+	require.NoError(t, err)
+	appKey, err := crypto.ScalarMulG1(*qID, new(fr.Element).SetUint64(42))
+	require.NoError(t, err)
+
+	result := &SecretsResult{AppPrivateKey: *appKey}
+	_, err = result.DeriveRoot(0)
+	require.Error(t, err, "an unverified app private key must not be derived from")
+
+	result.Verified = true
+	_, err = result.DeriveRoot(0)
+	require.ErrorIs(t, err, ErrDerivationGenerationMismatch, "a key of unknown generation must not be derived from")
+
+	generation := uint32(1)
+	result.Generation = &generation
+	root, err := result.DeriveRoot(1)
+	require.NoError(t, err)
+	want, err := derive.NewRoot(*appKey)
+	require.NoError(t, err)
+	assert.Equal(t, want.Mnemonic(), root.Mnemonic())
+
+	// The key of generation 1 derives other wallets than those pinned to generation 0
+	_, err = result.DeriveRoot(0)
+	require.ErrorIs(t, err, ErrDerivationGenerationMismatch)
+}
+
+func TestRetrieveSecretsWithOptions_Generation(t *testing.T) {
+	appID := "test-derive-generation"
+	sharing := newTestSharing(t, appID, 4, 3)
+	operators := startTestOperators(t, sharing, nil)
+	contractCaller := NewMockContractCaller(t)
+	contractCaller.EXPECT().GetOperatorSetMembersWithPeering(
+		"0x1234567890123456789012345678901234567890", uint32(0),
+	).Return(operators, nil)
+	client := newVerifyTestClient(t)
+	client.contractCaller = contractCaller
+
+	privPEM, pubPEM, err := encryption.GenerateKeyPair(2048)
+	require.NoError(t, err)
+	result, err := client.RetrieveSecretsWithOptions(appID, &SecretsOptions{
+		AttestationMethod:   "tpm",
+		TPMAttestationBytes: []byte("evidence"),
+		RSAPrivateKeyPEM:    privPEM,
+		RSAPublicKeyPEM:     pubPEM,
+	})
+	require.NoError(t, err)
+	require.True(t, result.Verified)
+	require.NotNil(t, result.Generation)
+	assert.Equal(t, uint32(0), *result.Generation)
+
+	_, err = result.DeriveRoot(0)
+	require.NoError(t, err)
+}
@@ -0,0 +1,314 @@
+package kmsClient
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/reshare"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// ErrMasterPublicKeyMismatch is returned when the master public key operators agree on
+// fails on-chain verification, or differs from the pinned key without an on-chain
+// record of the change.
+var ErrMasterPublicKeyMismatch = errors.New("master public key mismatch")
+
+// CommitmentReader reads dealers' commitment hashes from the EigenKMSCommitmentRegistry.
+// *caller.ContractCaller implements it.
+type CommitmentReader interface {
+	GetCommitment(ctx context.Context, registryAddress common.Address, epoch int64, operator common.Address) (commitmentHash [32]byte, ackMerkleRoot [32]byte, submittedAt uint64, err error)
+}
+
+// mpkPinFileMu serializes updates of pin files. It is not per client, since clients
+// addressing other generations (see Client.WithGeneration) share the file.
+var mpkPinFileMu sync.Mutex
+
+// registryReadTimeout bounds the registry reads of one on-chain verification.
+const registryReadTimeout = 30 * time.Second
+
+// checkMasterPublicKey applies the configured on-chain verification and pinning to the
+// master public key agreed from responses.
+func (c *Client) checkMasterPublicKey(operators *peering.OperatorSetPeers, responses []pubkeyResponse, mpk *types.G2Point) error {
+	verifiedOnChain := false
+	if c.commitmentReader != nil {
+		if err := c.verifyMasterPublicKeyOnChain(operators, responses, mpk); err != nil {
+			return err
+		}
+		verifiedOnChain = true
+	}
+	if c.mpkPinFile != "" {
+		return c.checkPinnedMasterPublicKey(mpk, verifiedOnChain)
+	}
+	return nil
+}
+
+// dealerSet is the dealer commitments and source version an operator serves for a key
+// version.
+type dealerSet struct {
+	version       int64
+	sourceVersion int64
+	commitments   map[common.Address][]types.G2Point
+}
+
+// verifyMasterPublicKeyOnChain checks mpk against the commitment registry. Some operator
+// serving mpk must also serve the commitments of the dealers whose sharing produced its
+// key version, such that:
+//   - every dealer is a member of the operator set, and there are at least a threshold
+//     of them;
+//   - the dealers are exactly the operators with a commitment on-chain for the
+//     version's epoch under the client's key (types.CommitmentEpoch): the registry
+//     accepts a commitment from any sender, so a dealer outside the operator set is
+//     rejected, and so is a set that leaves out an operator that committed;
+//   - every dealer's commitments hash to its on-chain commitment hash (HashCommitment
+//     for a DKG, HashReshareCommitment for a reshare);
+//   - the commitments combine to mpk: the sum of their constant terms for a DKG, their
+//     Lagrange combination over the dealer set for a reshare.
+//
+// Forging this takes an operator key, not just control of operator sockets or the
+// network path to them. Operators that predate dealer commitments cannot be verified.
+// A version whose dealers have since left the operator set, or that an operator
+// committed to after the round's cutoff, fails verification until the next reshare.
+func (c *Client) verifyMasterPublicKeyOnChain(operators *peering.OperatorSetPeers, responses []pubkeyResponse, mpk *types.G2Point) error {
+	var candidates []dealerSet
+	seen := make(map[string]bool)
+	for _, res := range responses {
+		if !res.IsActive || res.MasterPublicKey == nil || !res.MasterPublicKey.IsEqual(mpk) || len(res.DealerCommitments) == 0 {
+			continue
+		}
+		set := dealerSet{version: res.Version, sourceVersion: res.SourceVersion, commitments: res.DealerCommitments}
+		key, err := set.key()
+		if err != nil {
+			c.logger.Sugar().Warnw("Operator returned invalid dealer commitments, skipping",
+				"operator", res.OperatorAddress, "error", err)
+			continue
+		}
+		if !seen[key] {
+			seen[key] = true
+			candidates = append(candidates, set)
+		}
+	}
+	if len(candidates) == 0 {
+		return fmt.Errorf("%w: no operator serving it returned dealer commitments to verify on-chain", ErrMasterPublicKeyMismatch)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), registryReadTimeout)
+	defer cancel()
+
+	var errs []error
+	for _, set := range candidates {
+		err := c.verifyDealerSet(ctx, operators, set, mpk)
+		if err == nil {
+			c.logger.Sugar().Infow("Master public key verified against the commitment registry",
+				"version", set.version,
+				"dealers", len(set.commitments))
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("version %d: %w", set.version, err))
+	}
+	return fmt.Errorf("%w: on-chain verification failed: %w", ErrMasterPublicKeyMismatch, errors.Join(errs...))
+}
+
+// verifyDealerSet checks one dealer set as verifyMasterPublicKeyOnChain describes.
+func (c *Client) verifyDealerSet(ctx context.Context, operators *peering.OperatorSetPeers, set dealerSet, mpk *types.G2Point) error {
+	dealers := make([]common.Address, 0, len(set.commitments))
+	for dealer := range set.commitments {
+		dealers = append(dealers, dealer)
+	}
+	sort.Slice(dealers, func(i, j int) bool {
+		return bytes.Compare(dealers[i].Bytes(), dealers[j].Bytes()) < 0
+	})
+
+	isOperator := make(map[common.Address]bool, len(operators.Peers))
+	for _, op := range operators.Peers {
+		isOperator[op.OperatorAddress] = true
+	}
+	for _, dealer := range dealers {
+		if !isOperator[dealer] {
+			return fmt.Errorf("dealer %s is not a member of the operator set", dealer.Hex())
+		}
+	}
+	threshold := dkg.CalculateThreshold(len(operators.Peers))
+	if len(dealers) < threshold {
+		return fmt.Errorf("only %d dealers, need %d", len(dealers), threshold)
+	}
+
+	// Every operator's record is read, so an operator that committed but is missing
+	// from the dealer set is caught as well
+	epoch := types.CommitmentEpoch(c.keyID, set.version)
+	onChainHashes := make(map[common.Address][32]byte, len(operators.Peers))
+	for _, op := range operators.Peers {
+		onChain, _, _, err := c.commitmentReader.GetCommitment(ctx, c.registryAddress, epoch, op.OperatorAddress)
+		if err != nil {
+			return fmt.Errorf("failed to read commitment of operator %s: %w", op.OperatorAddress.Hex(), err)
+		}
+		if onChain == ([32]byte{}) {
+			continue
+		}
+		if _, ok := set.commitments[op.OperatorAddress]; !ok {
+			return fmt.Errorf("operator %s has a commitment on-chain but is missing from the dealer set", op.OperatorAddress.Hex())
+		}
+		onChainHashes[op.OperatorAddress] = onChain
+	}
+
+	for _, dealer := range dealers {
+		onChain, ok := onChainHashes[dealer]
+		if !ok {
+			return fmt.Errorf("dealer %s has no commitment on-chain", dealer.Hex())
+		}
+		expected := crypto.HashCommitment(set.commitments[dealer])
+		if set.sourceVersion != 0 {
+			expected = crypto.HashReshareCommitment(set.commitments[dealer], set.sourceVersion)
+		}
+		if onChain != expected {
+			return fmt.Errorf("commitments of dealer %s do not match its on-chain commitment hash", dealer.Hex())
+		}
+	}
+
+	var computed *types.G2Point
+	if set.sourceVersion == 0 {
+		all := make([][]types.G2Point, 0, len(dealers))
+		for _, dealer := range dealers {
+			all = append(all, set.commitments[dealer])
+		}
+		sum, err := crypto.ComputeMasterPublicKey(all)
+		if err != nil {
+			return fmt.Errorf("failed to combine dealer commitments: %w", err)
+		}
+		computed = sum
+	} else {
+		group, err := reshare.ComputeGroupCommitments(dealers, set.commitments)
+		if err != nil {
+			return fmt.Errorf("failed to combine dealer commitments: %w", err)
+		}
+		computed = &group[0]
+	}
+	if !computed.IsEqual(mpk) {
+		return fmt.Errorf("dealer commitments combine to a different master public key")
+	}
+	return nil
+}
+
+// key identifies the dealer set, validating every commitment as a G2 point.
+func (s dealerSet) key() (string, error) {
+	dealers := make([]string, 0, len(s.commitments))
+	for dealer, commitments := range s.commitments {
+		if len(commitments) == 0 {
+			return "", fmt.Errorf("dealer %s has no commitments", dealer.Hex())
+		}
+		ck, err := groupCommitmentsKey(commitments)
+		if err != nil {
+			return "", fmt.Errorf("dealer %s: %w", dealer.Hex(), err)
+		}
+		dealers = append(dealers, dealer.Hex()+"="+ck)
+	}
+	sort.Strings(dealers)
+	return fmt.Sprintf("%d/%d/%s", s.version, s.sourceVersion, strings.Join(dealers, ";")), nil
+}
+
+// mpkPin is a pinned master public key.
+type mpkPin struct {
+	MasterPublicKey string `json:"masterPublicKey"` // hex of the compressed G2 point
+	PinnedAt        int64  `json:"pinnedAt"`        // unix time
+}
+
+// pinKey identifies the key this client addresses in the pin file.
+func (c *Client) pinKey() string {
+	keyID := c.keyID
+	if keyID == "" {
+		keyID = types.DefaultKeyID
+	}
+	key := fmt.Sprintf("%s/%d/%s", strings.ToLower(c.avsAddress), c.operatorSetID, keyID)
+	if c.generation != nil {
+		key += fmt.Sprintf("/generation-%d", *c.generation)
+	}
+	return key
+}
+
+// checkPinnedMasterPublicKey compares mpk with the key pinned in the pin file, pinning
+// it on first use. A changed key is only re-pinned when verifiedOnChain; otherwise it is
+// an ErrMasterPublicKeyMismatch, since without an on-chain record nothing tells a
+// rotation apart from operators (or a network attacker) serving a key they control.
+func (c *Client) checkPinnedMasterPublicKey(mpk *types.G2Point, verifiedOnChain bool) error {
+	mpkPinFileMu.Lock()
+	defer mpkPinFileMu.Unlock()
+
+	pins, err := loadMPKPins(c.mpkPinFile)
+	if err != nil {
+		return err
+	}
+	key := c.pinKey()
+	served := hex.EncodeToString(mpk.CompressedBytes)
+	pin, ok := pins[key]
+	switch {
+	case ok && pin.MasterPublicKey == served:
+		return nil
+	case ok && !verifiedOnChain:
+		return fmt.Errorf("%w: operators serve %s but %s pins %s for %s since %s, and there is no on-chain record of the change; remove the entry only once the change is confirmed out of band",
+			ErrMasterPublicKeyMismatch, served, c.mpkPinFile, pin.MasterPublicKey, key, time.Unix(pin.PinnedAt, 0).UTC().Format(time.RFC3339))
+	case ok:
+		c.logger.Sugar().Warnw("Pinned master public key changed; re-pinning the on-chain verified key",
+			"pin", key,
+			"previous", pin.MasterPublicKey,
+			"current", served)
+	default:
+		c.logger.Sugar().Infow("Pinning master public key on first use", "pin", key, "file", c.mpkPinFile)
+	}
+
+	pins[key] = mpkPin{MasterPublicKey: served, PinnedAt: time.Now().Unix()}
+	return saveMPKPins(c.mpkPinFile, pins)
+}
+
+// loadMPKPins reads the pin file; a missing file holds no pins.
+func loadMPKPins(path string) (map[string]mpkPin, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return make(map[string]mpkPin), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read master public key pin file: %w", err)
+	}
+	pins := make(map[string]mpkPin)
+	if err := json.Unmarshal(data, &pins); err != nil {
+		return nil, fmt.Errorf("invalid master public key pin file %s: %w", path, err)
+	}
+	return pins, nil
+}
+
+// saveMPKPins replaces the pin file atomically, readable only by its owner.
+func saveMPKPins(path string, pins map[string]mpkPin) error {
+	data, err := json.MarshalIndent(pins, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode master public key pins: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".mpk-pins-*")
+	if err != nil {
+		return fmt.Errorf("failed to write master public key pin file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("failed to write master public key pin file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write master public key pin file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write master public key pin file: %w", err)
+	}
+	return nil
+}
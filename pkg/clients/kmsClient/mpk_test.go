@@ -0,0 +1,251 @@
+package kmsClient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/reshare"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// fakeCommitmentReader serves on-chain commitment hashes for a single epoch.
+type fakeCommitmentReader struct {
+	epoch  int64
+	hashes map[common.Address][32]byte
+}
+
+func (f *fakeCommitmentReader) GetCommitment(_ context.Context, _ common.Address, epoch int64, operator common.Address) ([32]byte, [32]byte, uint64, error) {
+	if epoch != f.epoch {
+		return [32]byte{}, [32]byte{}, 0, nil
+	}
+	return f.hashes[operator], [32]byte{}, 1, nil
+}
+
+// dealtVersion is a key version dealt by three operators, with the responses their
+// /pubkey serves and the registry state of its epoch.
+type dealtVersion struct {
+	operators *peering.OperatorSetPeers
+	mpk       types.G2Point
+	reader    *fakeCommitmentReader
+}
+
+// newDealtVersion starts /pubkey servers for a version 100 dealt by three operators, a
+// DKG when sourceVersion is 0 and otherwise a reshare of sourceVersion.
+func newDealtVersion(t *testing.T, sourceVersion int64) *dealtVersion {
+	t.Helper()
+	dealerCommitments := make(map[common.Address][]types.G2Point)
+	dealers := make([]common.Address, 3)
+	for i := range dealers {
+		dealers[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+		commitments := make([]types.G2Point, 2)
+		for k := range commitments {
+			point, err := crypto.ScalarMulG2(crypto.G2Generator, new(fr.Element).SetInt64(int64(10*i+k+1)))
+			require.NoError(t, err)
+			commitments[k] = *point
+		}
+		dealerCommitments[dealers[i]] = commitments
+	}
+
+	v := &dealtVersion{reader: &fakeCommitmentReader{epoch: 100, hashes: make(map[common.Address][32]byte)}}
+	if sourceVersion == 0 {
+		all := make([][]types.G2Point, 0, len(dealers))
+		for _, dealer := range dealers {
+			all = append(all, dealerCommitments[dealer])
+			v.reader.hashes[dealer] = crypto.HashCommitment(dealerCommitments[dealer])
+		}
+		mpk, err := crypto.ComputeMasterPublicKey(all)
+		require.NoError(t, err)
+		v.mpk = *mpk
+	} else {
+		group, err := reshare.ComputeGroupCommitments(dealers, dealerCommitments)
+		require.NoError(t, err)
+		v.mpk = group[0]
+		for _, dealer := range dealers {
+			v.reader.hashes[dealer] = crypto.HashReshareCommitment(dealerCommitments[dealer], sourceVersion)
+		}
+	}
+
+	peers := make([]*peering.OperatorSetPeer, len(dealers))
+	for i, dealer := range dealers {
+		resp := pubkeyResponse{
+			OperatorAddress:   dealer.Hex(),
+			Commitments:       dealerCommitments[dealer],
+			MasterPublicKey:   &v.mpk,
+			Version:           100,
+			IsActive:          true,
+			DealerCommitments: dealerCommitments,
+			SourceVersion:     sourceVersion,
+		}
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(resp)
+		}))
+		t.Cleanup(srv.Close)
+		peers[i] = &peering.OperatorSetPeer{OperatorAddress: dealer, SocketAddress: srv.URL}
+	}
+	v.operators = &peering.OperatorSetPeers{Peers: peers}
+	return v
+}
+
+func newMPKTestClient(t *testing.T, reader CommitmentReader, pinFile string) *Client {
+	t.Helper()
+	client := &Client{
+		avsAddress:    "0x1234567890123456789012345678901234567890",
+		operatorSetID: 0,
+		logger:        zap.NewNop(),
+		httpClient:    &http.Client{},
+		mpkPinFile:    pinFile,
+	}
+	if reader != nil {
+		client.commitmentReader = reader
+		client.registryAddress = common.HexToAddress("0xc0ffee")
+	}
+	return client
+}
+
+func TestGetMasterPublicKey_OnChainVerification(t *testing.T) {
+	for _, sourceVersion := range []int64{0, 50} {
+		t.Run(fmt.Sprintf("source version %d", sourceVersion), func(t *testing.T) {
+			v := newDealtVersion(t, sourceVersion)
+			client := newMPKTestClient(t, v.reader, "")
+
+			mpk, err := client.GetMasterPublicKey(v.operators)
+			require.NoError(t, err)
+			assert.True(t, mpk.IsEqual(&v.mpk))
+
+			// Commitments that do not match a dealer's on-chain hash fail loudly
+			v.reader.hashes[v.operators.Peers[1].OperatorAddress] = [32]byte{1}
+			_, err = client.GetMasterPublicKey(v.operators)
+			require.ErrorIs(t, err, ErrMasterPublicKeyMismatch)
+			assert.Contains(t, err.Error(), "do not match its on-chain commitment hash")
+
+			// As does a dealer with no commitment on-chain for the version's epoch
+			v.reader.epoch = 101
+			_, err = client.GetMasterPublicKey(v.operators)
+			require.ErrorIs(t, err, ErrMasterPublicKeyMismatch)
+		})
+	}
+}
+
+func TestGetMasterPublicKey_OnChainVerification_DealerOutsideOperatorSet(t *testing.T) {
+	v := newDealtVersion(t, 0)
+	client := newMPKTestClient(t, v.reader, "")
+
+	// The dealers' on-chain records check out, since the registry accepts a commitment
+	// from any sender, but one dealer is not an operator
+	others := &peering.OperatorSetPeers{Peers: append([]*peering.OperatorSetPeer{}, v.operators.Peers...)}
+	others.Peers[0] = &peering.OperatorSetPeer{OperatorAddress: common.BigToAddress(big.NewInt(99)), SocketAddress: v.operators.Peers[0].SocketAddress}
+
+	_, err := client.GetMasterPublicKey(others)
+	require.ErrorIs(t, err, ErrMasterPublicKeyMismatch)
+	assert.Contains(t, err.Error(), "is not a member of the operator set")
+}
+
+func TestGetMasterPublicKey_OnChainVerification_DealerLeftOut(t *testing.T) {
+	for _, sourceVersion := range []int64{0, 50} {
+		t.Run(fmt.Sprintf("source version %d", sourceVersion), func(t *testing.T) {
+			v := newDealtVersion(t, sourceVersion)
+			client := newMPKTestClient(t, v.reader, "")
+
+			// A fourth operator that holds no dealer commitments is not a dealer
+			extra := common.BigToAddress(big.NewInt(4))
+			withExtra := &peering.OperatorSetPeers{Peers: append(append([]*peering.OperatorSetPeer{}, v.operators.Peers...),
+				&peering.OperatorSetPeer{OperatorAddress: extra, SocketAddress: "http://127.0.0.1:1"})}
+			_, err := client.GetMasterPublicKey(withExtra)
+			require.NoError(t, err)
+
+			// Once it has committed on-chain for the version, the served dealer set
+			// must include it
+			v.reader.hashes[extra] = [32]byte{4}
+			_, err = client.GetMasterPublicKey(withExtra)
+			require.ErrorIs(t, err, ErrMasterPublicKeyMismatch)
+			assert.Contains(t, err.Error(), "is missing from the dealer set")
+		})
+	}
+}
+
+func TestGetMasterPublicKey_OnChainVerification_NoDealerCommitments(t *testing.T) {
+	commitments := []types.G2Point{crypto.G2Generator}
+	peers := make([]*peering.OperatorSetPeer, 3)
+	for i := range peers {
+		srv := createMockPubkeyServer(t, commitments, &crypto.G2Generator)
+		defer srv.Close()
+		peers[i] = &peering.OperatorSetPeer{OperatorAddress: common.BigToAddress(big.NewInt(int64(i + 1))), SocketAddress: srv.URL}
+	}
+	client := newMPKTestClient(t, &fakeCommitmentReader{}, "")
+
+	_, err := client.GetMasterPublicKey(&peering.OperatorSetPeers{Peers: peers})
+	require.ErrorIs(t, err, ErrMasterPublicKeyMismatch)
+	assert.Contains(t, err.Error(), "no operator serving it returned dealer commitments")
+}
+
+func TestGetMasterPublicKey_Pinning(t *testing.T) {
+	pinFile := filepath.Join(t.TempDir(), "mpk-pins.json")
+	first := newDealtVersion(t, 0)
+	client := newMPKTestClient(t, nil, pinFile)
+
+	// First use pins the key
+	mpk, err := client.GetMasterPublicKey(first.operators)
+	require.NoError(t, err)
+	assert.True(t, mpk.IsEqual(&first.mpk))
+	fi, err := os.Stat(pinFile)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+
+	_, err = client.GetMasterPublicKey(first.operators)
+	require.NoError(t, err)
+
+	// A different key without an on-chain record is refused
+	commitments := []types.G2Point{crypto.G2Generator}
+	other := make([]*peering.OperatorSetPeer, 3)
+	for i := range other {
+		srv := createMockPubkeyServer(t, commitments, &crypto.G2Generator)
+		defer srv.Close()
+		other[i] = &peering.OperatorSetPeer{OperatorAddress: common.BigToAddress(big.NewInt(int64(i + 1))), SocketAddress: srv.URL}
+	}
+	_, err = client.GetMasterPublicKey(&peering.OperatorSetPeers{Peers: other})
+	require.ErrorIs(t, err, ErrMasterPublicKeyMismatch)
+
+	// Each generation is pinned separately
+	generation := uint32(1)
+	client.generation = &generation
+	_, err = client.GetMasterPublicKey(&peering.OperatorSetPeers{Peers: other})
+	require.NoError(t, err)
+	client.generation = nil
+
+	// A change verified on-chain is re-pinned
+	rotated := newDealtVersion(t, 50)
+	verifying := newMPKTestClient(t, rotated.reader, pinFile)
+	mpk, err = verifying.GetMasterPublicKey(rotated.operators)
+	require.NoError(t, err)
+	assert.True(t, mpk.IsEqual(&rotated.mpk))
+
+	_, err = client.GetMasterPublicKey(rotated.operators)
+	require.NoError(t, err, "the re-pinned key is accepted without on-chain verification")
+	_, err = client.GetMasterPublicKey(first.operators)
+	require.ErrorIs(t, err, ErrMasterPublicKeyMismatch)
+}
+
+func TestNewClient_CommitmentRegistry(t *testing.T) {
+	_, err := NewClient(&ClientConfig{
+		AVSAddress:       "0x1234567890123456789012345678901234567890",
+		Logger:           zap.NewNop(),
+		ContractCaller:   NewMockContractCaller(t),
+		CommitmentReader: &fakeCommitmentReader{},
+	})
+	require.ErrorContains(t, err, "must be set together")
+}
@@ -0,0 +1,359 @@
+package kmsClient
+
+import (
+	"bytes"
+	"crypto/mlkem"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// GetAppPQPublicKeys fetches each operator's ML-KEM-768 encapsulation key for an app
+// from /v1/app/pq-public-key. Every key must be signed by its operator's registered
+// transport key and name that operator and the app; keys that are not are left out. It
+// fails when fewer than the operator set's threshold remain.
+func (c *Client) GetAppPQPublicKeys(appID string, operators *peering.OperatorSetPeers) ([]types.AppPQPublicKey, error) {
+	if appID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+	if operators == nil || len(operators.Peers) == 0 {
+		return nil, fmt.Errorf("no operators provided")
+	}
+
+	keys := make([]*types.AppPQPublicKey, len(operators.Peers))
+	var wg sync.WaitGroup
+	for i, operator := range operators.Peers {
+		wg.Add(1)
+		go func(idx int, op *peering.OperatorSetPeer) {
+			defer wg.Done()
+			key, err := c.fetchAppPQPublicKey(appID, op)
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to get PQ public key from operator",
+					"operator_address", op.OperatorAddress.Hex(),
+					"app_id", appID,
+					"error", err,
+				)
+				return
+			}
+			keys[idx] = key
+		}(i, operator)
+	}
+	wg.Wait()
+
+	// Keep the operators' order, so ciphertexts name recipients deterministically
+	verified := make([]types.AppPQPublicKey, 0, len(keys))
+	for _, key := range keys {
+		if key != nil {
+			verified = append(verified, *key)
+		}
+	}
+	threshold := dkg.CalculateThreshold(len(operators.Peers))
+	if len(verified) < threshold {
+		return nil, fmt.Errorf("insufficient PQ public keys: got %d, need %d", len(verified), threshold)
+	}
+	return verified, nil
+}
+
+// fetchAppPQPublicKey fetches and authenticates one operator's PQ public key for appID.
+func (c *Client) fetchAppPQPublicKey(appID string, op *peering.OperatorSetPeer) (*types.AppPQPublicKey, error) {
+	resp, err := c.httpClient.Get(c.operatorURL(op.SocketAddress, "/v1/app/pq-public-key") + "?app_id=" + url.QueryEscape(appID))
+	if err != nil {
+		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+		return nil, fmt.Errorf("operator returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var authMsg types.AuthenticatedMessage
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&authMsg); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	if err := op.VerifyMessage(&authMsg); err != nil {
+		return nil, fmt.Errorf("PQ public key failed authentication: %w", err)
+	}
+
+	var key types.AppPQPublicKey
+	if err := json.Unmarshal(authMsg.Payload, &key); err != nil {
+		return nil, fmt.Errorf("failed to parse PQ public key: %w", err)
+	}
+	if key.OperatorAddress != op.OperatorAddress {
+		return nil, fmt.Errorf("PQ public key announced for %s", key.OperatorAddress.Hex())
+	}
+	if key.AppID != appID {
+		return nil, fmt.Errorf("PQ public key announced for app %q", key.AppID)
+	}
+	if _, err := mlkem.NewEncapsulationKey768(key.EncapsulationKey); err != nil {
+		return nil, fmt.Errorf("invalid PQ public key: %w", err)
+	}
+	return &key, nil
+}
+
+// EncryptPQ encrypts data for an application as a post-quantum hybrid (version 3)
+// ciphertext: decrypting it needs the app private key and the decapsulation keys of a
+// threshold of the operators whose PQ public keys it was encrypted to (see
+// RetrieveAppPQKeys). It stays decryptable while a threshold of those operators remain
+// and until the master secret generation it is tagged with retires; ReencryptPQ moves
+// it to the current operators and generation.
+func (c *Client) EncryptPQ(appID string, data []byte, operators *peering.OperatorSetPeers) ([]byte, error) {
+	if appID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("data to encrypt is required")
+	}
+
+	c.logger.Sugar().Infow("Encrypting data for app with post-quantum hybrid encryption", "app_id", appID)
+
+	masterPubKey, generation, err := c.getMasterPublicKey(operators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master public key: %w", err)
+	}
+	pqKeys, err := c.GetAppPQPublicKeys(appID, operators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PQ public keys: %w", err)
+	}
+	if len(pqKeys) < len(operators.Peers) {
+		c.logger.Sugar().Warnw("Encrypting to a subset of operators; the ciphertext is lost if fewer than the threshold of them remain",
+			"app_id", appID,
+			"recipients", len(pqKeys),
+			"operators", len(operators.Peers))
+	}
+
+	threshold := dkg.CalculateThreshold(len(operators.Peers))
+	encryptedData, err := crypto.EncryptForAppPQ(appID, *masterPubKey, pqKeys, threshold, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt data: %w", err)
+	}
+
+	c.logger.Sugar().Infow("Successfully encrypted data",
+		"app_id", appID,
+		"recipients", len(pqKeys),
+		"threshold", threshold)
+	return crypto.WithGeneration(generation, encryptedData), nil
+}
+
+// RetrieveAppPQKeys retrieves operators' ML-KEM-768 decapsulation keys for an app from
+// /v1/app/pq-key, with the same attestation as RetrieveSecretsWithOptions. Each key is
+// sealed to a fresh ML-KEM key of this call inside the RSA encryption, and is checked
+// against its operator's signed PQ public key; keys that fail are left out. The keys
+// decrypt version 3 ciphertexts with crypto.DecryptForAppPQ, or through
+// SecretsOptions.PQKeys. It fails when fewer than the operator set's threshold remain.
+func (c *Client) RetrieveAppPQKeys(appID string, opts *SecretsOptions) (map[common.Address]*mlkem.DecapsulationKey768, error) {
+	if err := validateSecretsOptions(opts); err != nil {
+		return nil, err
+	}
+
+	operators, err := c.GetOperators()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operators: %w", err)
+	}
+	pqKeys, err := c.GetAppPQPublicKeys(appID, operators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PQ public keys: %w", err)
+	}
+	announced := make(map[common.Address][]byte, len(pqKeys))
+	for _, key := range pqKeys {
+		announced[key.OperatorAddress] = key.EncapsulationKey
+	}
+
+	req, err := c.createAttestationRequest(appID, opts)
+	if err != nil {
+		return nil, err
+	}
+	requesterKey, err := mlkem.GenerateKey768()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ML-KEM key: %w", err)
+	}
+
+	keys := c.collectAppPQKeys(operators, types.AppPQKeyRequest{
+		SecretsRequestV1: req,
+		EncapsulationKey: requesterKey.EncapsulationKey().Bytes(),
+	}, requesterKey, opts.RSAPrivateKeyPEM)
+
+	var invalidOperators []common.Address
+	for addr, key := range keys {
+		if !bytes.Equal(key.EncapsulationKey().Bytes(), announced[addr]) {
+			c.logger.Sugar().Warnw("Operator released a PQ key that does not match its public key, skipping",
+				"operator_address", addr.Hex(),
+				"app_id", appID)
+			invalidOperators = append(invalidOperators, addr)
+			delete(keys, addr)
+		}
+	}
+	threshold := dkg.CalculateThreshold(len(operators.Peers))
+	if len(keys) < threshold {
+		if len(invalidOperators) > 0 {
+			return nil, fmt.Errorf("insufficient valid PQ keys: got %d, need %d (invalid from: %s)",
+				len(keys), threshold, formatAddresses(invalidOperators))
+		}
+		return nil, fmt.Errorf("insufficient PQ keys: got %d, need %d", len(keys), threshold)
+	}
+
+	c.logger.Sugar().Infow("Retrieved PQ keys",
+		"app_id", appID,
+		"keys", len(keys))
+	return keys, nil
+}
+
+// ReencryptPQ moves a post-quantum (version 3) ciphertext to the current operators and
+// to the generation the client encrypts to, so it stays decryptable once the operators
+// it names leave the set or the generation it was encrypted to retires. It runs inside
+// the app: the ciphertext is threshold decrypted with opts as ThresholdDecrypt does, so
+// opts.ExtraData must begin with its binding (see CiphertextExtraData) and opts.PQKeys
+// must hold the keys RetrieveAppPQKeys returned, then encrypted again with EncryptPQ. A
+// ciphertext that already names exactly the current operators and is tagged with the
+// target generation is returned unchanged.
+func (c *Client) ReencryptPQ(appID string, ciphertext []byte, opts *SecretsOptions) ([]byte, error) {
+	recipients, _, err := crypto.PQRecipients(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("not a post-quantum ciphertext; use Reencrypt")
+	}
+
+	operators, err := c.GetOperators()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operators: %w", err)
+	}
+	named := make(map[common.Address]bool, len(recipients))
+	for _, addr := range recipients {
+		named[addr] = true
+	}
+	current := len(recipients) == len(operators.Peers)
+	for _, op := range operators.Peers {
+		current = current && named[op.OperatorAddress]
+	}
+	_, generation, err := c.getMasterPublicKey(operators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master public key: %w", err)
+	}
+	if from, ok := crypto.CiphertextGeneration(ciphertext); current && ok && from == generation {
+		c.logger.Sugar().Infow("Ciphertext is already encrypted to the current operators and generation", "app_id", appID, "generation", generation)
+		return ciphertext, nil
+	}
+
+	plaintexts, err := c.ThresholdDecrypt(appID, [][]byte{ciphertext}, opts)
+	if err != nil {
+		return nil, err
+	}
+	return c.EncryptPQ(appID, plaintexts[0], operators)
+}
+
+// collectAppPQKeys requests PQ keys from all operators concurrently and returns the
+// opened ones keyed by operator; operators that fail or answer malformed are left out.
+func (c *Client) collectAppPQKeys(
+	operators *peering.OperatorSetPeers,
+	req types.AppPQKeyRequest,
+	requesterKey *mlkem.DecapsulationKey768,
+	rsaPrivateKeyPEM []byte,
+) map[common.Address]*mlkem.DecapsulationKey768 {
+	rsaEncryption := encryption.NewRSAEncryption()
+
+	type result struct {
+		operatorAddr common.Address
+		key          *mlkem.DecapsulationKey768
+	}
+
+	resultChan := make(chan result, len(operators.Peers))
+	var wg sync.WaitGroup
+
+	req.Generation = c.generation
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		c.logger.Sugar().Errorw("Failed to marshal PQ key request", "error", err)
+		return nil
+	}
+
+	for i, operator := range operators.Peers {
+		wg.Add(1)
+		go func(idx int, op *peering.OperatorSetPeer) {
+			defer wg.Done()
+
+			resp, err := c.httpClient.Post(c.operatorURL(op.SocketAddress, "/v1/app/pq-key"), "application/json", bytes.NewReader(reqBody))
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to contact operator",
+					"operator_index", idx,
+					"address", op.SocketAddress,
+					"error", err,
+				)
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+				c.logger.Sugar().Warnw("Operator returned error",
+					"operator_index", idx,
+					"status_code", resp.StatusCode,
+					"body", string(body),
+				)
+				return
+			}
+
+			var response types.AppPQKeyResponse
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				c.logger.Sugar().Warnw("Failed to decode response from operator",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+
+			// SECURITY: bind response identity to the operator we actually queried
+			if !strings.EqualFold(response.OperatorAddress, op.OperatorAddress.Hex()) {
+				c.logger.Sugar().Warnw("Operator address mismatch in PQ key response",
+					"operator_index", idx,
+					"expected_operator_address", op.OperatorAddress.Hex(),
+					"response_operator_address", response.OperatorAddress,
+				)
+				return
+			}
+
+			sealedKey, err := rsaEncryption.DecryptHybrid(response.EncryptedKey, rsaPrivateKeyPEM)
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to decrypt PQ key",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+			key, err := crypto.OpenAppPQKey(requesterKey, req.AppID, op.OperatorAddress, response.KEMCiphertext, sealedKey)
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to open PQ key",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+
+			resultChan <- result{operatorAddr: op.OperatorAddress, key: key}
+		}(i, operator)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	keys := make(map[common.Address]*mlkem.DecapsulationKey768)
+	for res := range resultChan {
+		keys[res.operatorAddr] = res.key
+	}
+	return keys
+}
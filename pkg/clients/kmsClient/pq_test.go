@@ -0,0 +1,166 @@
+package kmsClient
+
+import (
+	"crypto/mlkem"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAppPQPublicKeys(t *testing.T) {
+	appID := "test-pq-app"
+	sharing := newTestSharing(t, appID, 4, 3)
+	operators := startTestOperators(t, sharing, nil)
+	client := newVerifyTestClient(t)
+
+	keys, err := client.GetAppPQPublicKeys(appID, operators)
+	require.NoError(t, err)
+	require.Len(t, keys, 4)
+	for i, key := range keys {
+		assert.Equal(t, operators.Peers[i].OperatorAddress, key.OperatorAddress)
+		assert.Equal(t, appID, key.AppID)
+		want, err := crypto.DeriveAppPQKey(testPQSeed(key.OperatorAddress), appID)
+		require.NoError(t, err)
+		assert.Equal(t, want.EncapsulationKey().Bytes(), key.EncapsulationKey)
+	}
+
+	// A key not signed by the operator's registered transport key is dropped
+	operators.Peers[1].WrappedPublicKey.ECDSAAddress = common.BigToAddress(big.NewInt(99))
+	keys, err = client.GetAppPQPublicKeys(appID, operators)
+	require.NoError(t, err)
+	require.Len(t, keys, 3)
+	for _, key := range keys {
+		assert.NotEqual(t, operators.Peers[1].OperatorAddress, key.OperatorAddress)
+	}
+
+	// An operator serving another operator's announcement is rejected too
+	operators.Peers[2].SocketAddress = operators.Peers[3].SocketAddress
+	_, err = client.GetAppPQPublicKeys(appID, operators)
+	require.ErrorContains(t, err, "insufficient PQ public keys: got 2, need 3")
+}
+
+func TestEncryptPQ_RetrieveAppPQKeys(t *testing.T) {
+	appID := "test-pq-app"
+	sharing := newTestSharing(t, appID, 4, 3)
+	badOperator := common.BigToAddress(big.NewInt(2))
+	operators := startTestOperators(t, sharing, map[common.Address]types.G1Point{badOperator: sharing.partialSigs[badOperator]})
+	contractCaller := NewMockContractCaller(t)
+	contractCaller.EXPECT().GetOperatorSetMembersWithPeering(
+		"0x1234567890123456789012345678901234567890", uint32(0),
+	).Return(operators, nil)
+	client := newVerifyTestClient(t)
+	client.contractCaller = contractCaller
+
+	env, err := client.EncryptPQ(appID, []byte("API_KEY=abc"), operators)
+	require.NoError(t, err)
+	sharing.encryptedEnv = hex.EncodeToString(env)
+	data, err := client.EncryptPQ(appID, []byte("dataset key"), operators)
+	require.NoError(t, err)
+	recipients, threshold, err := crypto.PQRecipients(data)
+	require.NoError(t, err)
+	assert.Len(t, recipients, 4)
+	assert.Equal(t, 3, threshold)
+
+	privPEM, pubPEM, err := encryption.GenerateKeyPair(2048)
+	require.NoError(t, err)
+	opts := &SecretsOptions{
+		AttestationMethod:   "tpm",
+		TPMAttestationBytes: []byte("evidence"),
+		RSAPrivateKeyPEM:    privPEM,
+		RSAPublicKeyPEM:     pubPEM,
+	}
+
+	// The operator releasing a key that does not match its announcement is dropped
+	pqKeys, err := client.RetrieveAppPQKeys(appID, opts)
+	require.NoError(t, err)
+	require.Len(t, pqKeys, 3)
+	assert.NotContains(t, pqKeys, badOperator)
+
+	// The IBE key alone does not decrypt a version 3 ciphertext
+	opts.Ciphertexts = [][]byte{data}
+	opts.ExtraData, err = CiphertextExtraData(opts.Ciphertexts, nil)
+	require.NoError(t, err)
+	_, err = client.RetrieveSecretsWithOptions(appID, opts)
+	require.ErrorContains(t, err, "insufficient PQ decapsulation keys")
+
+	// Nor do fewer than the threshold of the operators' PQ keys
+	opts.PQKeys = make(map[common.Address]*mlkem.DecapsulationKey768)
+	for addr, key := range pqKeys {
+		if len(opts.PQKeys) < threshold-1 {
+			opts.PQKeys[addr] = key
+		}
+	}
+	_, err = client.RetrieveSecretsWithOptions(appID, opts)
+	require.ErrorContains(t, err, "insufficient PQ decapsulation keys")
+
+	// The bad operator's decryption shares are skipped too, leaving exactly a threshold
+	opts.PQKeys = pqKeys
+	result, err := client.RetrieveSecretsWithOptions(appID, opts)
+	require.NoError(t, err)
+	require.Len(t, result.Plaintexts, 1)
+	assert.Equal(t, "dataset key", string(result.Plaintexts[0]))
+	assert.Equal(t, "API_KEY=abc", string(result.Env))
+}
+
+func TestReencryptPQ(t *testing.T) {
+	appID := "test-pq-app"
+	sharing := newTestSharing(t, appID, 4, 3)
+	operators := startTestOperators(t, sharing, nil)
+	contractCaller := NewMockContractCaller(t)
+	contractCaller.EXPECT().GetOperatorSetMembersWithPeering(
+		"0x1234567890123456789012345678901234567890", uint32(0),
+	).Return(operators, nil)
+	client := newVerifyTestClient(t)
+	client.contractCaller = contractCaller
+
+	data, err := client.EncryptPQ(appID, []byte("dataset key"), operators)
+	require.NoError(t, err)
+
+	privPEM, pubPEM, err := encryption.GenerateKeyPair(2048)
+	require.NoError(t, err)
+	opts := &SecretsOptions{
+		AttestationMethod:   "tpm",
+		TPMAttestationBytes: []byte("evidence"),
+		RSAPrivateKeyPEM:    privPEM,
+		RSAPublicKeyPEM:     pubPEM,
+	}
+	opts.PQKeys, err = client.RetrieveAppPQKeys(appID, opts)
+	require.NoError(t, err)
+	opts.ExtraData, err = CiphertextExtraData([][]byte{data}, nil)
+	require.NoError(t, err)
+
+	// Nothing to do while the ciphertext names exactly the current operators
+	same, err := client.ReencryptPQ(appID, data, opts)
+	require.NoError(t, err)
+	assert.Equal(t, data, same)
+
+	// Once an operator it names leaves, it is encrypted again to those that remain
+	departed := operators.Peers[3].OperatorAddress
+	operators.Peers = operators.Peers[:3]
+	moved, err := client.ReencryptPQ(appID, data, opts)
+	require.NoError(t, err)
+	recipients, _, err := crypto.PQRecipients(moved)
+	require.NoError(t, err)
+	assert.Len(t, recipients, 3)
+	assert.NotContains(t, recipients, departed)
+
+	opts.Ciphertexts = [][]byte{moved}
+	opts.ExtraData, err = CiphertextExtraData(opts.Ciphertexts, nil)
+	require.NoError(t, err)
+	plaintexts, err := client.ThresholdDecrypt(appID, opts.Ciphertexts, opts)
+	require.NoError(t, err)
+	assert.Equal(t, "dataset key", string(plaintexts[0]))
+
+	// Other versions go through Reencrypt
+	v1, err := client.Encrypt(appID, []byte("dataset key"), operators)
+	require.NoError(t, err)
+	_, err = client.ReencryptPQ(appID, v1, opts)
+	require.ErrorContains(t, err, "use Reencrypt")
+}
@@ -0,0 +1,79 @@
+package kmsClient
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+)
+
+// Reencrypt moves ciphertext to the generation the client encrypts to (the active one
+// unless WithGeneration was used), so it stays decryptable once the generation it was
+// encrypted to is retired after a master secret rotation. It decrypts with the
+// generation named in the ciphertext's header, or the configured one for an untagged
+// ciphertext, and encrypts the plaintext again. A ciphertext already tagged with the
+// target generation is returned unchanged.
+//
+// Post-quantum (version 3) ciphertexts are not supported: decrypting them needs
+// attested app keys, so they are re-encrypted inside the app with ReencryptPQ.
+func (c *Client) Reencrypt(appID string, ciphertext []byte, operators *peering.OperatorSetPeers, threshold int) ([]byte, error) {
+	if appID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+	if operators == nil || len(operators.Peers) == 0 {
+		return nil, fmt.Errorf("no operators provided")
+	}
+	isPQ, err := crypto.IsPQCiphertext(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ciphertext: %w", err)
+	}
+	if isPQ {
+		return nil, fmt.Errorf("post-quantum ciphertexts must be re-encrypted by the app; use ReencryptPQ")
+	}
+
+	masterPubKey, generation, err := c.getMasterPublicKey(operators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master public key: %w", err)
+	}
+	if from, ok := crypto.CiphertextGeneration(ciphertext); ok && from == generation {
+		c.logger.Sugar().Infow("Ciphertext is already encrypted to the target generation", "app_id", appID, "generation", generation)
+		return ciphertext, nil
+	}
+
+	plaintext, err := c.Decrypt(appID, ciphertext, operators, threshold)
+	if err != nil {
+		return nil, err
+	}
+	reencrypted, err := crypto.EncryptForApp(appID, *masterPubKey, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt data: %w", err)
+	}
+
+	c.logger.Sugar().Infow("Re-encrypted data", "app_id", appID, "generation", generation)
+	return crypto.WithGeneration(generation, reencrypted), nil
+}
+
+// ReencryptStream is Reencrypt for a chunked ciphertext read from src, written to dst
+// as it is decrypted. As with DecryptStream, only a nil error means dst holds the whole
+// ciphertext. It returns the number of plaintext bytes re-encrypted.
+func (c *Client) ReencryptStream(appID string, dst io.Writer, src io.Reader, operators *peering.OperatorSetPeers, threshold int) (int64, error) {
+	pr, pw := io.Pipe()
+	decrypted := make(chan error, 1)
+	go func() {
+		_, err := c.DecryptStream(appID, pw, src, operators, threshold)
+		_ = pw.CloseWithError(err)
+		decrypted <- err
+	}()
+
+	n, err := c.EncryptStream(appID, dst, pr, operators)
+	_ = pr.CloseWithError(err)
+	if decErr := <-decrypted; decErr != nil {
+		return n, decErr
+	}
+	if err != nil {
+		return n, err
+	}
+	c.logger.Sugar().Infow("Re-encrypted stream", "app_id", appID, "bytes", n)
+	return n, nil
+}
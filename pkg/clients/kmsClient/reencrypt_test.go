@@ -0,0 +1,141 @@
+package kmsClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// startRotatedTestOperators serves /pubkey and /app/sign for an operator set that
+// rotated from generation 0 to generation 1: requests without a generation get the
+// active generation 1.
+func startRotatedTestOperators(t *testing.T, generations map[uint32]*testSharing) *peering.OperatorSetPeers {
+	t.Helper()
+
+	sharingFor := func(generation *uint32) (uint32, *testSharing) {
+		g := uint32(1)
+		if generation != nil {
+			g = *generation
+		}
+		return g, generations[g]
+	}
+
+	n := len(generations[1].partialSigs)
+	peers := make([]*peering.OperatorSetPeer, 0, n)
+	for i := 1; i <= n; i++ {
+		addr := common.BigToAddress(big.NewInt(int64(i)))
+		mux := http.NewServeMux()
+		mux.HandleFunc("/pubkey", func(w http.ResponseWriter, r *http.Request) {
+			var generation *uint32
+			if g := r.URL.Query().Get("generation"); g != "" {
+				parsed, err := strconv.ParseUint(g, 10, 32)
+				require.NoError(t, err)
+				gen := uint32(parsed)
+				generation = &gen
+			}
+			g, sharing := sharingFor(generation)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"operatorAddress":  addr.Hex(),
+				"commitments":      sharing.groupCommitments,
+				"masterPublicKey":  sharing.groupCommitments[0],
+				"groupCommitments": sharing.groupCommitments,
+				"version":          int64(1),
+				"generation":       g,
+				"isActive":         true,
+			})
+		})
+		mux.HandleFunc("/app/sign", func(w http.ResponseWriter, r *http.Request) {
+			var req types.AppSignRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			_, sharing := sharingFor(req.Generation)
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(types.AppSignResponse{
+				OperatorAddress:  addr.Hex(),
+				PartialSignature: sharing.partialSigs[addr],
+			})
+		})
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+		peers = append(peers, &peering.OperatorSetPeer{OperatorAddress: addr, SocketAddress: srv.URL})
+	}
+	return &peering.OperatorSetPeers{Peers: peers}
+}
+
+func TestReencrypt(t *testing.T) {
+	appID := "reencrypt-app"
+	old := newTestSharing(t, appID, 5, 4)
+	active := newTestSharing(t, appID, 5, 4)
+	operators := startRotatedTestOperators(t, map[uint32]*testSharing{0: old, 1: active})
+	plaintext := []byte("encrypted before the rotation")
+
+	// Encrypted to generation 0 before the rotation
+	client := newVerifyTestClient(t)
+	ciphertext, err := client.WithGeneration(0).Encrypt(appID, plaintext, operators)
+	require.NoError(t, err)
+	generation, ok := crypto.CiphertextGeneration(ciphertext)
+	require.True(t, ok)
+	assert.Equal(t, uint32(0), generation)
+
+	// Decrypt follows the header, though the client defaults to the active generation
+	decrypted, err := client.Decrypt(appID, ciphertext, operators, 0)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	reencrypted, err := client.Reencrypt(appID, ciphertext, operators, 0)
+	require.NoError(t, err)
+	generation, ok = crypto.CiphertextGeneration(reencrypted)
+	require.True(t, ok)
+	assert.Equal(t, uint32(1), generation)
+
+	// Generation 0 no longer decrypts it, even when its header is rewritten to ask
+	// for generation 0; generation 1 does
+	retagged := crypto.WithGeneration(0, reencrypted[len(crypto.GenerationHeader(1)):])
+	_, err = client.Decrypt(appID, retagged, operators, 0)
+	require.Error(t, err)
+	decrypted, err = client.Decrypt(appID, reencrypted, operators, 0)
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+
+	// Already on the target generation
+	again, err := client.Reencrypt(appID, reencrypted, operators, 0)
+	require.NoError(t, err)
+	assert.Equal(t, reencrypted, again)
+
+	t.Run("stream", func(t *testing.T) {
+		data := bytes.Repeat([]byte("model weights "), 20000)
+		var src bytes.Buffer
+		_, err := client.WithGeneration(0).EncryptStream(appID, &src, bytes.NewReader(data), operators)
+		require.NoError(t, err)
+
+		var dst bytes.Buffer
+		n, err := client.ReencryptStream(appID, &dst, bytes.NewReader(src.Bytes()), operators, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(len(data)), n)
+		generation, ok := crypto.CiphertextGeneration(dst.Bytes())
+		require.True(t, ok)
+		assert.Equal(t, uint32(1), generation)
+
+		var out bytes.Buffer
+		_, err = client.DecryptStream(appID, &out, bytes.NewReader(dst.Bytes()), operators, 0)
+		require.NoError(t, err)
+		assert.Equal(t, data, out.Bytes())
+	})
+
+	t.Run("mixed generations", func(t *testing.T) {
+		_, err := client.forCiphertexts([][]byte{ciphertext, reencrypted})
+		require.ErrorContains(t, err, "another master secret generation")
+	})
+}
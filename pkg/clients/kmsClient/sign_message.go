@@ -0,0 +1,244 @@
+package kmsClient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// SignMessageResult contains an application's threshold BLS signature over a message
+type SignMessageResult struct {
+	// Signature is the standard BLS signature over the message, verifiable with
+	// crypto.VerifyAppMessageSignature against PublicKey
+	Signature types.G1Point
+	// PublicKey is the app's signing public key, derived from the group commitments
+	// operators agreed on (see crypto.AppSigningPublicKey)
+	PublicKey        types.G2Point
+	PartialSigs      map[common.Address]types.G1Point
+	ThresholdNeeded  int
+	InvalidOperators []common.Address
+}
+
+// SignMessage has operators sign message under the app's derived signing key, from
+// /v1/app/sign-message with the same attestation as RetrieveSecretsWithOptions. Each
+// partial signature is checked against its operator's public key share and a threshold
+// of those that verify are combined, so the signing key exists nowhere. The result is
+// checked against the app's signing public key before it is returned.
+func (c *Client) SignMessage(appID string, message []byte, opts *SecretsOptions) (*SignMessageResult, error) {
+	if err := validateSecretsOptions(opts); err != nil {
+		return nil, err
+	}
+	if len(message) > types.MaxAppMessageSize {
+		return nil, fmt.Errorf("message exceeds %d byte limit (%d bytes)", types.MaxAppMessageSize, len(message))
+	}
+
+	operators, err := c.GetOperators()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operators: %w", err)
+	}
+	req, err := c.createAttestationRequest(appID, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	groupCommitments, err := c.getGroupCommitments(operators, req.AttestationTime)
+	if err != nil {
+		return nil, fmt.Errorf("cannot verify partial signatures: %w", err)
+	}
+	appPubKey, err := crypto.AppSigningPublicKey(appID, groupCommitments[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute app signing public key: %w", err)
+	}
+
+	partialSigs := c.collectMessagePartialSignatures(operators, types.AppSignMessageRequest{
+		SecretsRequestV1: req,
+		Message:          message,
+	}, opts.RSAPrivateKeyPEM)
+
+	// The threshold is the size of the group polynomial, as for decryption shares
+	threshold := len(groupCommitments)
+	valid := make(map[common.Address]types.G1Point, len(partialSigs))
+	var invalidOperators []common.Address
+	for _, op := range operators.Peers {
+		partialSig, ok := partialSigs[op.OperatorAddress]
+		if !ok {
+			continue
+		}
+		pkShare, err := crypto.ComputeOperatorPublicKeyShare(groupCommitments, op.OperatorAddress)
+		if err == nil {
+			var opKey *types.G2Point
+			opKey, err = crypto.AppSigningPublicKey(appID, *pkShare)
+			if err == nil {
+				ok, err = crypto.VerifyAppMessageSignature(appID, message, partialSig, *opKey)
+			}
+		}
+		if err != nil || !ok {
+			c.logger.Sugar().Warnw("Operator returned invalid message partial signature, skipping",
+				"operator_address", op.OperatorAddress.Hex(),
+				"app_id", appID)
+			invalidOperators = append(invalidOperators, op.OperatorAddress)
+			continue
+		}
+		valid[op.OperatorAddress] = partialSig
+	}
+	if len(valid) < threshold {
+		if len(invalidOperators) > 0 {
+			return nil, fmt.Errorf("insufficient valid partial signatures: got %d, need %d (invalid from: %s)",
+				len(valid), threshold, formatAddresses(invalidOperators))
+		}
+		return nil, fmt.Errorf("insufficient partial signatures: got %d, need %d", len(valid), threshold)
+	}
+
+	signature, err := crypto.CombineAppMessageSignatures(valid, threshold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to combine partial signatures: %w", err)
+	}
+	ok, err := crypto.VerifyAppMessageSignature(appID, message, *signature, *appPubKey)
+	if err != nil || !ok {
+		return nil, fmt.Errorf("combined signature does not verify against the app signing public key")
+	}
+
+	c.logger.Sugar().Infow("Signed message",
+		"app_id", appID,
+		"partial_signatures", len(valid))
+	return &SignMessageResult{
+		Signature:        *signature,
+		PublicKey:        *appPubKey,
+		PartialSigs:      valid,
+		ThresholdNeeded:  threshold,
+		InvalidOperators: invalidOperators,
+	}, nil
+}
+
+// GetAppSigningPublicKey returns the public key an application's message signatures
+// verify against. It is derived from the master public key, so it is checked on-chain
+// and pinned like that key when the client is configured to.
+func (c *Client) GetAppSigningPublicKey(appID string) (*types.G2Point, error) {
+	if appID == "" {
+		return nil, fmt.Errorf("app ID is required")
+	}
+
+	operators, err := c.GetOperators()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operators: %w", err)
+	}
+	masterPubKey, err := c.GetMasterPublicKey(operators)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get master public key: %w", err)
+	}
+	return crypto.AppSigningPublicKey(appID, *masterPubKey)
+}
+
+// collectMessagePartialSignatures requests message partial signatures from all operators
+// concurrently and returns the decrypted ones keyed by operator; operators that fail or
+// answer malformed are left out.
+func (c *Client) collectMessagePartialSignatures(
+	operators *peering.OperatorSetPeers,
+	req types.AppSignMessageRequest,
+	rsaPrivateKeyPEM []byte,
+) map[common.Address]types.G1Point {
+	rsaEncryption := encryption.NewRSAEncryption()
+
+	type result struct {
+		operatorAddr common.Address
+		partialSig   types.G1Point
+	}
+
+	resultChan := make(chan result, len(operators.Peers))
+	var wg sync.WaitGroup
+
+	req.Generation = c.generation
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		c.logger.Sugar().Errorw("Failed to marshal sign message request", "error", err)
+		return nil
+	}
+
+	for i, operator := range operators.Peers {
+		wg.Add(1)
+		go func(idx int, op *peering.OperatorSetPeer) {
+			defer wg.Done()
+
+			resp, err := c.httpClient.Post(c.operatorURL(op.SocketAddress, "/v1/app/sign-message"), "application/json", bytes.NewReader(reqBody))
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to contact operator",
+					"operator_index", idx,
+					"address", op.SocketAddress,
+					"error", err,
+				)
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+				c.logger.Sugar().Warnw("Operator returned error",
+					"operator_index", idx,
+					"status_code", resp.StatusCode,
+					"body", string(body),
+				)
+				return
+			}
+
+			var response types.AppSignMessageResponse
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				c.logger.Sugar().Warnw("Failed to decode response from operator",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+
+			// SECURITY: bind response identity to the operator we actually queried
+			if !strings.EqualFold(response.OperatorAddress, op.OperatorAddress.Hex()) {
+				c.logger.Sugar().Warnw("Operator address mismatch in sign message response",
+					"operator_index", idx,
+					"expected_operator_address", op.OperatorAddress.Hex(),
+					"response_operator_address", response.OperatorAddress,
+				)
+				return
+			}
+
+			partialSigBytes, err := rsaEncryption.Decrypt(response.EncryptedPartialSig, rsaPrivateKeyPEM)
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to decrypt partial signature",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+			var partialSig types.G1Point
+			if err := json.Unmarshal(partialSigBytes, &partialSig); err != nil {
+				c.logger.Sugar().Warnw("Failed to parse partial signature",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+
+			resultChan <- result{operatorAddr: op.OperatorAddress, partialSig: partialSig}
+		}(i, operator)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	partialSigs := make(map[common.Address]types.G1Point)
+	for res := range resultChan {
+		partialSigs[res.operatorAddr] = res.partialSig
+	}
+	return partialSigs
+}
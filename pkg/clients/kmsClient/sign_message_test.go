@@ -0,0 +1,78 @@
+package kmsClient
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignMessage(t *testing.T) {
+	appID := "test-signing-app"
+	sharing := newTestSharing(t, appID, 5, 3)
+	privPEM, pubPEM, err := encryption.GenerateKeyPair(2048)
+	require.NoError(t, err)
+	opts := func() *SecretsOptions {
+		return &SecretsOptions{
+			AttestationMethod:   "tpm",
+			TPMAttestationBytes: []byte("evidence"),
+			RSAPrivateKeyPEM:    privPEM,
+			RSAPublicKeyPEM:     pubPEM,
+		}
+	}
+	newClient := func(t *testing.T, operators *peering.OperatorSetPeers) *Client {
+		contractCaller := NewMockContractCaller(t)
+		contractCaller.EXPECT().GetOperatorSetMembersWithPeering(
+			"0x1234567890123456789012345678901234567890", uint32(0),
+		).Return(operators, nil)
+		client := newVerifyTestClient(t)
+		client.contractCaller = contractCaller
+		return client
+	}
+
+	t.Run("combines verified partial signatures", func(t *testing.T) {
+		bad := common.BigToAddress(big.NewInt(2))
+		operators := startTestOperators(t, sharing, map[common.Address]types.G1Point{
+			bad: sharing.partialSigs[common.BigToAddress(big.NewInt(1))],
+		})
+		client := newClient(t, operators)
+
+		message := []byte("release v1.2.3")
+		result, err := client.SignMessage(appID, message, opts())
+		require.NoError(t, err)
+		assert.Equal(t, []common.Address{bad}, result.InvalidOperators)
+		assert.Equal(t, 3, result.ThresholdNeeded)
+
+		appPubKey, err := crypto.AppSigningPublicKey(appID, sharing.groupCommitments[0])
+		require.NoError(t, err)
+		assert.Equal(t, appPubKey.CompressedBytes, result.PublicKey.CompressedBytes)
+		ok, err := crypto.VerifyAppMessageSignature(appID, message, result.Signature, *appPubKey)
+		require.NoError(t, err)
+		assert.True(t, ok)
+	})
+
+	t.Run("too many invalid partial signatures", func(t *testing.T) {
+		badSigs := make(map[common.Address]types.G1Point)
+		for i := 1; i <= 3; i++ {
+			badSigs[common.BigToAddress(big.NewInt(int64(i)))] = sharing.partialSigs[common.BigToAddress(big.NewInt(5))]
+		}
+		client := newClient(t, startTestOperators(t, sharing, badSigs))
+
+		_, err := client.SignMessage(appID, []byte("m"), opts())
+		require.ErrorContains(t, err, "insufficient valid partial signatures")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		client := newVerifyTestClient(t)
+		_, err := client.SignMessage(appID, []byte("m"), nil)
+		require.Error(t, err)
+		_, err = client.SignMessage(appID, make([]byte, types.MaxAppMessageSize+1), opts())
+		require.ErrorContains(t, err, "message exceeds")
+	})
+}
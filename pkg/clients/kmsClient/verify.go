@@ -0,0 +1,220 @@
+package kmsClient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// pubkeyResponse is an operator's /pubkey response.
+type pubkeyResponse struct {
+	OperatorAddress  string          `json:"operatorAddress"`
+	Commitments      []types.G2Point `json:"commitments"`
+	MasterPublicKey  *types.G2Point  `json:"masterPublicKey"`
+	GroupCommitments []types.G2Point `json:"groupCommitments"`
+	Version          int64           `json:"version"`
+	Generation       uint32          `json:"generation"`
+	IsActive         bool            `json:"isActive"`
+
+	// Dealer commitments of the key version, checked against the commitment registry
+	DealerCommitments map[common.Address][]types.G2Point `json:"dealerCommitments"`
+	SourceVersion     int64                              `json:"sourceVersion"`
+}
+
+// fetchPubkeys queries /pubkey on all operators concurrently and returns the responses
+// that decoded. attestationTime > 0 asks for the key version used to sign at that
+// time instead of the active one; a configured generation scopes the lookup to it.
+func (c *Client) fetchPubkeys(operators *peering.OperatorSetPeers, attestationTime int64) []pubkeyResponse {
+	resultChan := make(chan pubkeyResponse, len(operators.Peers))
+	var wg sync.WaitGroup
+
+	for i, operator := range operators.Peers {
+		wg.Add(1)
+		go func(idx int, op *peering.OperatorSetPeer) {
+			defer wg.Done()
+
+			url := c.operatorURL(op.SocketAddress, "/pubkey")
+			var query []string
+			if attestationTime > 0 {
+				query = append(query, fmt.Sprintf("attestationTime=%d", attestationTime))
+			}
+			if c.generation != nil {
+				query = append(query, fmt.Sprintf("generation=%d", *c.generation))
+			}
+			if len(query) > 0 {
+				url += "?" + strings.Join(query, "&")
+			}
+			resp, err := c.httpClient.Get(url)
+			if err != nil {
+				c.logger.Sugar().Warnw("Failed to contact operator",
+					"operator_index", idx,
+					"address", op.SocketAddress,
+					"error", err,
+				)
+				return
+			}
+			defer func() { _ = resp.Body.Close() }()
+
+			if resp.StatusCode != http.StatusOK {
+				// Cap the error-body read: the helper runs in a memory-
+				// constrained peer-pod, and a misbehaving operator returning
+				// gigabytes of bytes on a non-200 response shouldn't OOM us.
+				// 64 KiB is enough to surface any reasonable error message.
+				body, _ := io.ReadAll(io.LimitReader(resp.Body, 64<<10))
+				c.logger.Sugar().Warnw("Operator returned error",
+					"operator_index", idx,
+					"status_code", resp.StatusCode,
+					"body", string(body),
+				)
+				return
+			}
+
+			var response pubkeyResponse
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+				c.logger.Sugar().Warnw("Failed to decode response from operator",
+					"operator_index", idx,
+					"error", err,
+				)
+				return
+			}
+			resultChan <- response
+		}(i, operator)
+	}
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	var results []pubkeyResponse
+	for res := range resultChan {
+		results = append(results, res)
+	}
+	return results
+}
+
+// getGroupCommitments returns the group commitments of the key version operators sign
+// with at attestationTime (0 = active), as agreed by at least a threshold of operators.
+// Agreement matters: a single operator could otherwise serve commitments under which
+// its own bad partial signature verifies.
+func (c *Client) getGroupCommitments(operators *peering.OperatorSetPeers, attestationTime int64) ([]types.G2Point, error) {
+	results := c.fetchPubkeys(operators, attestationTime)
+	threshold := dkg.CalculateThreshold(len(operators.Peers))
+
+	votes := make(map[string][]types.G2Point)
+	counts := make(map[string]int)
+	for _, res := range results {
+		if len(res.GroupCommitments) == 0 {
+			continue
+		}
+		key, err := groupCommitmentsKey(res.GroupCommitments)
+		if err != nil {
+			c.logger.Sugar().Warnw("Operator returned invalid group commitments, skipping",
+				"operator", res.OperatorAddress, "error", err)
+			continue
+		}
+		votes[key] = res.GroupCommitments
+		counts[key]++
+	}
+
+	for key, count := range counts {
+		if count >= threshold {
+			return votes[key], nil
+		}
+	}
+	return nil, fmt.Errorf("no threshold agreement on group commitments: needed %d, got max %d agreeing out of %d responses",
+		threshold, maxMPKVotes(counts), len(results))
+}
+
+// groupCommitmentsKey validates every commitment as a G2 point and returns a key
+// identifying the full commitment vector.
+func groupCommitmentsKey(commitments []types.G2Point) (string, error) {
+	var sb strings.Builder
+	for i, c := range commitments {
+		if _, err := bls.G2PointFromCompressedBytes(c.CompressedBytes); err != nil {
+			return "", fmt.Errorf("commitment %d is not a valid G2 point: %w", i, err)
+		}
+		sb.WriteString(hex.EncodeToString(c.CompressedBytes))
+		sb.WriteByte(':')
+	}
+	return sb.String(), nil
+}
+
+// verifyPartialSignatures checks each partial signature against its operator's public
+// key share derived from groupCommitments, one pairing check per operator. It returns
+// the signatures that verify and the operators whose signatures do not.
+func verifyPartialSignatures(
+	appID string,
+	partialSigs map[common.Address]types.G1Point,
+	groupCommitments []types.G2Point,
+) (map[common.Address]types.G1Point, []common.Address) {
+	valid := make(map[common.Address]types.G1Point, len(partialSigs))
+	var invalid []common.Address
+	for addr, sig := range partialSigs {
+		pkShare, err := crypto.ComputeOperatorPublicKeyShare(groupCommitments, addr)
+		if err != nil {
+			invalid = append(invalid, addr)
+			continue
+		}
+		ok, err := crypto.VerifyPartialSignature(appID, sig, *pkShare)
+		if err != nil || !ok {
+			invalid = append(invalid, addr)
+			continue
+		}
+		valid[addr] = sig
+	}
+	sort.Slice(invalid, func(i, j int) bool {
+		return bytes.Compare(invalid[i].Bytes(), invalid[j].Bytes()) < 0
+	})
+	return valid, invalid
+}
+
+// filterVerifiedPartialSignatures drops partial signatures that fail per-operator
+// verification, logging each bad operator by address. verified reports whether the
+// check ran: if group commitments cannot be agreed (e.g. operators that predate them
+// during a rolling upgrade), partialSigs is returned unfiltered and recovery falls back
+// to subset search.
+func (c *Client) filterVerifiedPartialSignatures(
+	appID string,
+	operators *peering.OperatorSetPeers,
+	attestationTime int64,
+	partialSigs map[common.Address]types.G1Point,
+) (valid map[common.Address]types.G1Point, invalid []common.Address, verified bool) {
+	groupCommitments, err := c.getGroupCommitments(operators, attestationTime)
+	if err != nil {
+		c.logger.Sugar().Warnw("Cannot verify partial signatures individually, falling back to subset search",
+			"error", err)
+		return partialSigs, nil, false
+	}
+
+	valid, invalid = verifyPartialSignatures(appID, partialSigs, groupCommitments)
+	for _, addr := range invalid {
+		c.logger.Sugar().Warnw("Operator returned invalid partial signature, skipping",
+			"operator_address", addr.Hex(),
+			"app_id", appID)
+	}
+	return valid, invalid, true
+}
+
+// formatAddresses renders operator addresses for error messages.
+func formatAddresses(addrs []common.Address) string {
+	hexes := make([]string, len(addrs))
+	for i, a := range addrs {
+		hexes[i] = a.Hex()
+	}
+	return strings.Join(hexes, ", ")
+}
@@ -0,0 +1,383 @@
+package kmsClient
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/transportSigner/inMemoryTransportSigner"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// testSharing is a degree threshold-1 sharing with its group commitments and the
+// partial signatures every operator produces for one app ID.
+type testSharing struct {
+	appID            string
+	groupCommitments []types.G2Point
+	keyShares        map[common.Address]*fr.Element
+	partialSigs      map[common.Address]types.G1Point
+	encryptedEnv     string // served by /secrets
+}
+
+func newTestSharing(t *testing.T, appID string, n, threshold int) *testSharing {
+	t.Helper()
+
+	coeffs := make([]*fr.Element, threshold)
+	commitments := make([]types.G2Point, threshold)
+	for i := range coeffs {
+		coeffs[i] = new(fr.Element)
+		_, err := coeffs[i].SetRandom()
+		require.NoError(t, err)
+		c, err := crypto.ScalarMulG2(crypto.G2Generator, coeffs[i])
+		require.NoError(t, err)
+		commitments[i] = *c
+	}
+
+	qID, err := crypto.HashToG1(appID)
+	require.NoError(t, err)
+
+	keyShares := make(map[common.Address]*fr.Element, n)
+	sigs := make(map[common.Address]types.G1Point, n)
+	for i := 1; i <= n; i++ {
+		addr := common.BigToAddress(big.NewInt(int64(i)))
+		x := crypto.AddressToFr(addr)
+		share := new(fr.Element).Set(coeffs[threshold-1])
+		for j := threshold - 2; j >= 0; j-- {
+			share.Mul(share, x).Add(share, coeffs[j])
+		}
+		sig, err := crypto.ScalarMulG1(*qID, share)
+		require.NoError(t, err)
+		sigs[addr] = *sig
+		keyShares[addr] = share
+	}
+
+	return &testSharing{appID: appID, groupCommitments: commitments, keyShares: keyShares, partialSigs: sigs}
+}
+
+// testPQSeed is the PQ key seed operator addr of startTestOperators derives its keys from.
+func testPQSeed(addr common.Address) []byte {
+	return common.LeftPadBytes(addr.Bytes(), crypto.PQSeedSize)
+}
+
+// startTestOperators serves /pubkey, /app/sign, /app/decrypt-share, /secrets (with
+// decryption shares, or a partial signature when no ciphertexts are asked for) and the
+// PQ key endpoints for every operator in sharing, each deriving its PQ keys from a seed of
+// its own (testPQSeed). An operator listed in badSigs returns that signature instead of its own,
+// decryption shares made with a wrong key share, and a PQ key that does not match the
+// one it announces.
+func startTestOperators(t *testing.T, sharing *testSharing, badSigs map[common.Address]types.G1Point) *peering.OperatorSetPeers {
+	t.Helper()
+
+	mpk := sharing.groupCommitments[0]
+	peers := make([]*peering.OperatorSetPeer, 0, len(sharing.partialSigs))
+	for i := 1; i <= len(sharing.partialSigs); i++ {
+		addr := common.BigToAddress(big.NewInt(int64(i)))
+		sig := sharing.partialSigs[addr]
+		keyShare := sharing.keyShares[addr]
+		if bad, ok := badSigs[addr]; ok {
+			sig = bad
+			keyShare = new(fr.Element).Add(keyShare, new(fr.Element).SetOne())
+		}
+		decryptionShares := func(w http.ResponseWriter, c1s []types.G2Point) []types.DecryptionShare {
+			shares := make([]types.DecryptionShare, len(c1s))
+			for i, c1 := range c1s {
+				share, err := crypto.ComputeDecryptionShare(sharing.appID, keyShare, c1)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return nil
+				}
+				shares[i] = *share
+			}
+			return shares
+		}
+
+		transportKey, err := ethcrypto.GenerateKey()
+		require.NoError(t, err)
+		signer, err := inMemoryTransportSigner.NewECDSAInMemoryTransportSigner(ethcrypto.FromECDSA(transportKey), zap.NewNop())
+		require.NoError(t, err)
+		pqSeed := testPQSeed(addr)
+		releasedSeed := pqSeed
+		if _, ok := badSigs[addr]; ok {
+			releasedSeed = common.RightPadBytes(addr.Bytes(), crypto.PQSeedSize)
+		}
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/v1/app/pq-public-key", func(w http.ResponseWriter, r *http.Request) {
+			appID := r.URL.Query().Get("app_id")
+			dk, err := crypto.DeriveAppPQKey(pqSeed, appID)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			payload, _ := json.Marshal(types.AppPQPublicKey{OperatorAddress: addr, AppID: appID, EncapsulationKey: dk.EncapsulationKey().Bytes()})
+			authMsg, err := signer.CreateAuthenticatedMessage(payload)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(authMsg)
+		})
+		mux.HandleFunc("/v1/app/pq-key", func(w http.ResponseWriter, r *http.Request) {
+			var req types.AppPQKeyRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			dk, err := crypto.DeriveAppPQKey(releasedSeed, req.AppID)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			kemCiphertext, sealedKey, err := crypto.SealAppPQKey(req.EncapsulationKey, req.AppID, addr, dk)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			encrypted, err := encryption.NewRSAEncryption().EncryptHybrid(sealedKey, req.RSAPubKeyTmp)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(types.AppPQKeyResponse{
+				OperatorAddress: addr.Hex(),
+				KEMCiphertext:   kemCiphertext,
+				EncryptedKey:    encrypted,
+			})
+		})
+		mux.HandleFunc("/pubkey", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"operatorAddress":  addr.Hex(),
+				"commitments":      sharing.groupCommitments,
+				"masterPublicKey":  mpk,
+				"groupCommitments": sharing.groupCommitments,
+				"version":          int64(1),
+				"isActive":         true,
+			})
+		})
+		mux.HandleFunc("/app/sign", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(types.AppSignResponse{
+				OperatorAddress:  addr.Hex(),
+				PartialSignature: sig,
+			})
+		})
+		mux.HandleFunc("/app/decrypt-share", func(w http.ResponseWriter, r *http.Request) {
+			var req types.AppDecryptShareRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if !bytes.HasPrefix(req.ExtraData, crypto.CiphertextBinding(req.CiphertextC1s)) {
+				http.Error(w, "extra_data must begin with the ciphertext binding", http.StatusBadRequest)
+				return
+			}
+			shares := decryptionShares(w, req.CiphertextC1s)
+			if shares == nil {
+				return
+			}
+			payloadBytes, _ := json.Marshal(types.SecretsDecryptionShares{Shares: shares})
+			encrypted, err := encryption.NewRSAEncryption().EncryptHybrid(payloadBytes, req.RSAPubKeyTmp)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(types.AppDecryptShareResponse{
+				OperatorAddress:           addr.Hex(),
+				EncryptedDecryptionShares: encrypted,
+			})
+		})
+		mux.HandleFunc("/secrets", func(w http.ResponseWriter, r *http.Request) {
+			var req types.SecretsRequestV1
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			if len(req.CiphertextC1s) == 0 {
+				sigBytes, _ := json.Marshal(sig)
+				encrypted, err := encryption.NewRSAEncryption().Encrypt(sigBytes, req.RSAPubKeyTmp)
+				if err != nil {
+					http.Error(w, err.Error(), http.StatusBadRequest)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				_ = json.NewEncoder(w).Encode(types.SecretsResponseV1{
+					EncryptedEnv:        sharing.encryptedEnv,
+					EncryptedPartialSig: encrypted,
+				})
+				return
+			}
+			payload := types.SecretsDecryptionShares{Shares: decryptionShares(w, req.CiphertextC1s)}
+			if payload.Shares == nil {
+				return
+			}
+			if env, err := hex.DecodeString(sharing.encryptedEnv); err == nil && len(env) > 0 {
+				if c1, err := crypto.CiphertextC1(env); err == nil {
+					payload.EnvShare = &decryptionShares(w, []types.G2Point{*c1})[0]
+				}
+			}
+			payloadBytes, _ := json.Marshal(payload)
+			encrypted, err := encryption.NewRSAEncryption().EncryptHybrid(payloadBytes, req.RSAPubKeyTmp)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(types.SecretsResponseV1{
+				EncryptedEnv:              sharing.encryptedEnv,
+				EncryptedDecryptionShares: encrypted,
+			})
+		})
+		mux.HandleFunc("/v1/app/sign-message", func(w http.ResponseWriter, r *http.Request) {
+			var req types.AppSignMessageRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			partialSig, err := crypto.SignAppMessage(req.AppID, keyShare, req.Message)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			partialSigBytes, _ := json.Marshal(partialSig)
+			encrypted, err := encryption.NewRSAEncryption().Encrypt(partialSigBytes, req.RSAPubKeyTmp)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(types.AppSignMessageResponse{
+				OperatorAddress:     addr.Hex(),
+				EncryptedPartialSig: encrypted,
+			})
+		})
+		srv := httptest.NewServer(mux)
+		t.Cleanup(srv.Close)
+
+		peers = append(peers, &peering.OperatorSetPeer{
+			OperatorAddress:  addr,
+			SocketAddress:    srv.URL,
+			WrappedPublicKey: peering.WrappedPublicKey{ECDSAAddress: ethcrypto.PubkeyToAddress(transportKey.PublicKey)},
+			CurveType:        config.CurveTypeECDSA,
+		})
+	}
+	return &peering.OperatorSetPeers{Peers: peers}
+}
+
+func newVerifyTestClient(t *testing.T) *Client {
+	t.Helper()
+	return &Client{
+		avsAddress:    "0x1234567890123456789012345678901234567890",
+		operatorSetID: 0,
+		logger:        zap.NewNop(),
+		httpClient:    &http.Client{},
+	}
+}
+
+func TestVerifyPartialSignatures_RejectsBadOperator(t *testing.T) {
+	appID := "verify-app"
+	sharing := newTestSharing(t, appID, 5, 4)
+
+	bad := common.BigToAddress(big.NewInt(3))
+	other := newTestSharing(t, appID, 5, 4)
+	sigs := make(map[common.Address]types.G1Point, len(sharing.partialSigs))
+	for addr, sig := range sharing.partialSigs {
+		sigs[addr] = sig
+	}
+	sigs[bad] = other.partialSigs[bad]
+
+	valid, invalid := verifyPartialSignatures(appID, sigs, sharing.groupCommitments)
+	assert.Equal(t, []common.Address{bad}, invalid)
+	assert.Len(t, valid, 4)
+	assert.NotContains(t, valid, bad)
+}
+
+func TestCollectPartialSignatures_SkipsInvalidOperators(t *testing.T) {
+	appID := "verify-app"
+	n := 7
+	threshold := 5 // dkg.CalculateThreshold(7)
+	sharing := newTestSharing(t, appID, n, threshold)
+
+	// Two operators return signatures from a different sharing: non-zero and well
+	// formed, but not valid under their public key shares.
+	other := newTestSharing(t, appID, n, threshold)
+	bad1 := common.BigToAddress(big.NewInt(2))
+	bad2 := common.BigToAddress(big.NewInt(6))
+	operators := startTestOperators(t, sharing, map[common.Address]types.G1Point{
+		bad1: other.partialSigs[bad1],
+		bad2: other.partialSigs[bad2],
+	})
+
+	client := newVerifyTestClient(t)
+	sigs, err := client.CollectPartialSignatures(appID, operators, threshold)
+	require.NoError(t, err)
+	require.Len(t, sigs, n-2)
+	assert.NotContains(t, sigs, bad1)
+	assert.NotContains(t, sigs, bad2)
+
+	// Exactly threshold verified signatures are enough: no subset search needed.
+	appKey, err := crypto.RecoverAppPrivateKey(appID, sigs, threshold)
+	require.NoError(t, err)
+	ok, err := crypto.VerifyAppPrivateKey(appID, *appKey, sharing.groupCommitments[0])
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestCollectPartialSignatures_ReportsInvalidOperatorsBelowThreshold(t *testing.T) {
+	appID := "verify-app"
+	n := 4
+	threshold := 3 // dkg.CalculateThreshold(4)
+	sharing := newTestSharing(t, appID, n, threshold)
+
+	other := newTestSharing(t, appID, n, threshold)
+	bad1 := common.BigToAddress(big.NewInt(1))
+	bad2 := common.BigToAddress(big.NewInt(4))
+	operators := startTestOperators(t, sharing, map[common.Address]types.G1Point{
+		bad1: other.partialSigs[bad1],
+		bad2: other.partialSigs[bad2],
+	})
+
+	client := newVerifyTestClient(t)
+	_, err := client.CollectPartialSignatures(appID, operators, threshold)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "insufficient valid partial signatures")
+	assert.Contains(t, err.Error(), bad1.Hex())
+	assert.Contains(t, err.Error(), bad2.Hex())
+}
+
+// Without threshold agreement on group commitments (e.g. operators that predate
+// them) the signatures pass through unfiltered and recovery falls back to subset search.
+func TestFilterVerifiedPartialSignatures_FallsBackWithoutGroupCommitments(t *testing.T) {
+	appID := "verify-app"
+	sharing := newTestSharing(t, appID, 4, 3)
+
+	peers := make([]*peering.OperatorSetPeer, 0, 4)
+	for addr := range sharing.partialSigs {
+		srv := createMockPubkeyServer(t, sharing.groupCommitments, &sharing.groupCommitments[0])
+		t.Cleanup(srv.Close)
+		peers = append(peers, &peering.OperatorSetPeer{OperatorAddress: addr, SocketAddress: srv.URL})
+	}
+
+	client := newVerifyTestClient(t)
+	valid, invalid, verified := client.filterVerifiedPartialSignatures(appID, &peering.OperatorSetPeers{Peers: peers}, 0, sharing.partialSigs)
+	assert.False(t, verified)
+	assert.Empty(t, invalid)
+	assert.Equal(t, sharing.partialSigs, valid)
+}
@@ -2,8 +2,14 @@ package config
 
 import (
 	"fmt"
+	"net"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 	"github.com/ethereum/go-ethereum/common"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 )
@@ -41,6 +47,20 @@ const (
 	EnvKMSRedisPassword  = "KMS_REDIS_PASSWORD"
 	EnvKMSRedisDB        = "KMS_REDIS_DB"
 	EnvKMSRedisKeyPrefix = "KMS_REDIS_KEY_PREFIX"
+	// Encryption at rest configuration
+	EnvKMSKEKSource         = "KMS_KEK_SOURCE"
+	EnvKMSKEKKeyfile        = "KMS_KEK_KEYFILE"
+	EnvKMSKEKPassphrase     = "KMS_KEK_PASSPHRASE"
+	EnvKMSKEKAWSKMSKeyID    = "KMS_KEK_AWS_KMS_KEY_ID"
+	EnvKMSKEKAWSKMSEndpoint = "KMS_KEK_AWS_KMS_ENDPOINT"
+	EnvKMSKEKAWSRegion      = "KMS_KEK_AWS_REGION"
+	// EnvKMSKEKPrevious is a comma-separated list of retired KEKs that may still
+	// wrap stored records, each "keyfile:<path>", "aws-kms:<key-id>" or
+	// "passphrase-env:<ENV_VAR>".
+	EnvKMSKEKPrevious = "KMS_KEK_PREVIOUS"
+	// EnvKMSKEKMigratePlaintext enables the one-time pass that seals records
+	// written before encryption at rest was enabled.
+	EnvKMSKEKMigratePlaintext = "KMS_KEK_MIGRATE_PLAINTEXT"
 	// Attestation configuration
 	EnvKMSGCPProjectID           = "KMS_GCP_PROJECT_ID"
 	EnvKMSAttestationProvider    = "KMS_ATTESTATION_PROVIDER"
@@ -55,6 +75,49 @@ const (
 	// (96-hex) SEV-SNP MEASUREMENT values. On AWS this pins the OVMF firmware
 	// version + vCPU shape (NOT image identity). Empty = not enforced.
 	EnvKMSEigenXSNPMeasurements = "KMS_EIGENX_SNP_MEASUREMENTS"
+	// EnvKMSEigenXSNPPolicyRules is a comma-separated list of accepted SHA-256
+	// digests (hex) of the kata genpolicy rules in cc_init_data's policy.rego.
+	// Empty = ContainerPolicy is not surfaced for eigenx-snp.
+	EnvKMSEigenXSNPPolicyRules = "KMS_EIGENX_SNP_POLICY_RULES"
+	// eigenx-tdx (raw Intel TDX quote) attestation configuration
+	EnvKMSEnableEigenXTDXAttestation = "KMS_ENABLE_EIGENX_TDX_ATTESTATION"
+	// EnvKMSEigenXTDXMeasurements is a comma-separated list of accepted TD boot
+	// states, each <mrtd>:<rtmr0>:<rtmr1>:<rtmr2> in hex. This pins the TD firmware,
+	// kernel and command line the RTMR[3] image claim relies on. Empty = not enforced.
+	EnvKMSEigenXTDXMeasurements = "KMS_EIGENX_TDX_MEASUREMENTS"
+	// EnvKMSEnableNitroAttestation enables AWS Nitro Enclaves attestation documents
+	EnvKMSEnableNitroAttestation = "KMS_ENABLE_NITRO_ATTESTATION"
+	// EnvKMSMetricsAddress is the host:port of the Prometheus /metrics listener,
+	// separate from the public KMS port. Empty = metrics disabled.
+	EnvKMSMetricsAddress = "KMS_METRICS_ADDRESS"
+	// EnvKMSKeys lists the master keys this server holds, one per operator set, as
+	// comma-separated <key-id>:<operator-set-id> pairs. Empty = a single default key
+	// for KMS_OPERATOR_SET_ID.
+	EnvKMSKeys = "KMS_KEYS"
+	// EnvKMSKeyRetentionMaxAge, EnvKMSKeyRetentionMaxVersions and
+	// EnvKMSKeyRetentionDryRun configure key version retention (see RetentionConfig).
+	EnvKMSKeyRetentionMaxAge      = "KMS_KEY_RETENTION_MAX_AGE"
+	EnvKMSKeyRetentionMaxVersions = "KMS_KEY_RETENTION_MAX_VERSIONS"
+	EnvKMSKeyRetentionDryRun      = "KMS_KEY_RETENTION_DRY_RUN"
+	// EnvKMSAdminSocket and EnvKMSAdminAddress select the admin API listener: a Unix
+	// socket path, or a host:port served over mutual TLS with the certificate, key and
+	// client CA in EnvKMSAdminTLSCert, EnvKMSAdminTLSKey and EnvKMSAdminTLSClientCA.
+	EnvKMSAdminSocket      = "KMS_ADMIN_SOCKET"
+	EnvKMSAdminAddress     = "KMS_ADMIN_ADDRESS"
+	EnvKMSAdminTLSCert     = "KMS_ADMIN_TLS_CERT"
+	EnvKMSAdminTLSKey      = "KMS_ADMIN_TLS_KEY"
+	EnvKMSAdminTLSClientCA = "KMS_ADMIN_TLS_CLIENT_CA"
+	// EnvKMSOTLPEndpoint is the host:port of the OTLP collector protocol spans are
+	// exported to (empty = tracing disabled); EnvKMSOTLPProtocol is "grpc" or "http"
+	// and EnvKMSOTLPInsecure disables TLS to the collector.
+	EnvKMSOTLPEndpoint = "KMS_OTLP_ENDPOINT"
+	EnvKMSOTLPProtocol = "KMS_OTLP_PROTOCOL"
+	EnvKMSOTLPInsecure = "KMS_OTLP_INSECURE"
+	// EnvKMSAuthzPolicyFile is a YAML file of authorization rules applied to /secrets
+	// after the default rules (empty = default rules only). EnvKMSAuthzPolicyReloadInterval is
+	// how often the file is re-read.
+	EnvKMSAuthzPolicyFile           = "KMS_AUTHZ_POLICY_FILE"
+	EnvKMSAuthzPolicyReloadInterval = "KMS_AUTHZ_POLICY_RELOAD_INTERVAL"
 )
 
 type CurveType string
@@ -322,6 +385,71 @@ type PersistenceConfig struct {
 
 	// Redis configuration (only used when Type is "redis")
 	RedisConfig *RedisConfig `json:"redis_config,omitempty"`
+
+	// Encryption configures encryption at rest of key shares. Nil stores them in
+	// plaintext.
+	Encryption *EncryptionConfig `json:"encryption,omitempty"`
+}
+
+// KEK sources supported by EncryptionConfig.KEKSource
+const (
+	KEKSourceKeyfile    = "keyfile"
+	KEKSourceAWSKMS     = "aws-kms"
+	KEKSourcePassphrase = "passphrase"
+)
+
+// EncryptionConfig holds configuration for encryption at rest
+type EncryptionConfig struct {
+	// KEKSource selects where the key-encryption key comes from: "keyfile",
+	// "aws-kms" or "passphrase"
+	KEKSource string `json:"kek_source"`
+	// KeyfilePath is the file holding a 32-byte KEK (raw or hex), for "keyfile"
+	KeyfilePath string `json:"keyfile_path,omitempty"`
+	// Passphrase is the passphrase the KEK is derived from, for "passphrase"
+	Passphrase string `json:"-"`
+	// AWSKMSKeyID is the KMS key ID, ARN or alias, for "aws-kms"
+	AWSKMSKeyID string `json:"aws_kms_key_id,omitempty"`
+	// AWSKMSEndpoint optionally overrides the KMS endpoint (LocalStack, local-kms, ...)
+	AWSKMSEndpoint string `json:"aws_kms_endpoint,omitempty"`
+	// AWSRegion optionally overrides the AWS region for "aws-kms"
+	AWSRegion string `json:"aws_region,omitempty"`
+	// PreviousKEKs lists retired KEKs still needed to read records until they are
+	// re-wrapped: "keyfile:<path>", "aws-kms:<key-id>" or "passphrase-env:<ENV_VAR>"
+	PreviousKEKs []string `json:"previous_keks,omitempty"`
+	// MigratePlaintext seals records stored in plaintext before encryption at rest
+	// was enabled. Without it a plaintext record is rejected on read, so set it only
+	// for the one run that migrates an existing store.
+	MigratePlaintext bool `json:"migrate_plaintext,omitempty"`
+}
+
+// Validate validates the encryption configuration
+func (ec *EncryptionConfig) Validate() error {
+	switch ec.KEKSource {
+	case KEKSourceKeyfile:
+		if ec.KeyfilePath == "" {
+			return fmt.Errorf("keyfile path is required when KEK source is 'keyfile'")
+		}
+	case KEKSourceAWSKMS:
+		if ec.AWSKMSKeyID == "" {
+			return fmt.Errorf("AWS KMS key ID is required when KEK source is 'aws-kms'")
+		}
+	case KEKSourcePassphrase:
+		if ec.Passphrase == "" {
+			return fmt.Errorf("passphrase is required when KEK source is 'passphrase'")
+		}
+	default:
+		return fmt.Errorf("KEK source must be 'keyfile', 'aws-kms', or 'passphrase', got '%s'", ec.KEKSource)
+	}
+	for _, spec := range ec.PreviousKEKs {
+		kind, value, ok := strings.Cut(spec, ":")
+		if !ok || value == "" {
+			return fmt.Errorf("invalid previous KEK %q: expected <kind>:<value>", spec)
+		}
+		if kind != KEKSourceKeyfile && kind != KEKSourceAWSKMS && kind != "passphrase-env" {
+			return fmt.Errorf("invalid previous KEK %q: kind must be 'keyfile', 'aws-kms', or 'passphrase-env'", spec)
+		}
+	}
+	return nil
 }
 
 // RedisConfig holds configuration for Redis persistence
@@ -368,6 +496,218 @@ func (pc *PersistenceConfig) Validate() error {
 		}
 	}
 
+	if pc.Encryption != nil {
+		if err := pc.Encryption.Validate(); err != nil {
+			return fmt.Errorf("invalid encryption config: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ForKey returns the persistence configuration for keyID's namespace. The default key
+// keeps the configured location, so a single-key deployment can add keys without
+// migrating its data; every other key gets a "keys/<id>" subdirectory (Badger) or a
+// "key:<id>:" key prefix (Redis).
+func (pc PersistenceConfig) ForKey(keyID string) PersistenceConfig {
+	if keyID == "" || keyID == types.DefaultKeyID {
+		return pc
+	}
+	scoped := pc
+	if pc.DataPath != "" {
+		scoped.DataPath = filepath.Join(pc.DataPath, "keys", keyID)
+	}
+	if pc.RedisConfig != nil {
+		redisConfig := *pc.RedisConfig
+		redisConfig.KeyPrefix = pc.RedisConfig.KeyPrefix + "key:" + keyID + ":"
+		scoped.RedisConfig = &redisConfig
+	}
+	return scoped
+}
+
+// Namespace returns the shared-backend namespace records are stored under: the Redis
+// key prefix. Badger directories belong to a single node, so they have none.
+func (pc PersistenceConfig) Namespace() string {
+	if pc.Type == "redis" && pc.RedisConfig != nil {
+		return pc.RedisConfig.KeyPrefix
+	}
+	return ""
+}
+
+// keyIDPattern restricts key IDs to what can sit in a URL path segment, a directory
+// name and a Redis key without escaping.
+var keyIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,62}$`)
+
+// KeyConfig names one master key: the key held by an operator set. Its ID scopes the
+// key's routes (/v1/keys/<id>/...) and persistence namespace, and must be the same on
+// every operator in the set.
+type KeyConfig struct {
+	ID            string `json:"id"`
+	OperatorSetId uint32 `json:"operator_set_id"`
+}
+
+// ParseKeyConfig parses a "<key-id>:<operator-set-id>" key specification.
+func ParseKeyConfig(spec string) (KeyConfig, error) {
+	id, setID, ok := strings.Cut(strings.TrimSpace(spec), ":")
+	if !ok {
+		return KeyConfig{}, fmt.Errorf("invalid key %q: expected <key-id>:<operator-set-id>", spec)
+	}
+	operatorSetId, err := strconv.ParseUint(setID, 10, 32)
+	if err != nil {
+		return KeyConfig{}, fmt.Errorf("invalid key %q: bad operator set ID: %w", spec, err)
+	}
+	kc := KeyConfig{ID: id, OperatorSetId: uint32(operatorSetId)}
+	if err := kc.Validate(); err != nil {
+		return KeyConfig{}, err
+	}
+	return kc, nil
+}
+
+// Validate validates the key configuration
+func (kc KeyConfig) Validate() error {
+	if !keyIDPattern.MatchString(kc.ID) {
+		return fmt.Errorf("invalid key ID %q: must be 1-63 lowercase letters, digits or '-', starting with a letter or digit", kc.ID)
+	}
+	return nil
+}
+
+// DefaultGenerationRetireAfter is how long a rotated-out master secret generation
+// keeps serving when its on-chain rotation schedule sets no retirement window.
+const DefaultGenerationRetireAfter = 7 * 24 * time.Hour
+
+// RetentionConfig bounds how many key share versions a node keeps. A reshare stores a
+// new version at every interval, so without a limit the keystore and the database grow
+// without bound. The zero value keeps every version.
+type RetentionConfig struct {
+	// MaxAge is the attestation-time window: /secrets and /app/sign serve attestation
+	// times up to MaxAge old, and versions no such attestation time resolves to are
+	// pruned. 0 = no age limit.
+	MaxAge time.Duration `json:"max_age,omitempty"`
+	// MaxVersions caps the versions kept per master secret generation, newest first.
+	// A cap smaller than the versions created within MaxAge narrows the window
+	// further. 0 = no count limit.
+	MaxVersions int `json:"max_versions,omitempty"`
+	// DryRun reports what the policy would prune without deleting anything or
+	// narrowing the attestation-time window.
+	DryRun bool `json:"dry_run,omitempty"`
+}
+
+// Enabled reports whether the configuration limits retention at all.
+func (rc RetentionConfig) Enabled() bool {
+	return rc.MaxAge > 0 || rc.MaxVersions > 0
+}
+
+// Validate validates the retention configuration
+func (rc RetentionConfig) Validate() error {
+	if rc.MaxAge < 0 {
+		return fmt.Errorf("key retention max age cannot be negative, got %s", rc.MaxAge)
+	}
+	if rc.MaxVersions < 0 {
+		return fmt.Errorf("key retention max versions cannot be negative, got %d", rc.MaxVersions)
+	}
+	return nil
+}
+
+// AdminConfig configures the admin control API. It listens either on a Unix socket,
+// which only the server's user can connect to, or on a TCP address that requires a
+// client certificate signed by ClientCAFile. The zero value disables it.
+type AdminConfig struct {
+	SocketPath   string `json:"socket_path,omitempty"`
+	Address      string `json:"address,omitempty"` // host:port of the mTLS listener
+	TLSCertFile  string `json:"tls_cert_file,omitempty"`
+	TLSKeyFile   string `json:"tls_key_file,omitempty"`
+	ClientCAFile string `json:"client_ca_file,omitempty"`
+}
+
+// Enabled reports whether an admin listener is configured.
+func (ac AdminConfig) Enabled() bool {
+	return ac.SocketPath != "" || ac.Address != ""
+}
+
+// Validate validates the admin API configuration
+func (ac AdminConfig) Validate() error {
+	if ac.SocketPath != "" && ac.Address != "" {
+		return fmt.Errorf("admin API takes either a socket path or an address, not both")
+	}
+	tlsFiles := ac.TLSCertFile != "" || ac.TLSKeyFile != "" || ac.ClientCAFile != ""
+	if ac.SocketPath != "" && tlsFiles {
+		return fmt.Errorf("admin TLS files apply only to the admin address, not the socket")
+	}
+	if ac.Address == "" {
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(ac.Address); err != nil {
+		return fmt.Errorf("invalid admin address %q: %w", ac.Address, err)
+	}
+	if ac.TLSCertFile == "" || ac.TLSKeyFile == "" || ac.ClientCAFile == "" {
+		return fmt.Errorf("admin address %q requires a TLS certificate, key and client CA", ac.Address)
+	}
+	return nil
+}
+
+// DefaultAuthzPolicyReloadInterval is how often the authorization policy file is
+// re-read when no interval is configured.
+const DefaultAuthzPolicyReloadInterval = 10 * time.Second
+
+// AuthzConfig configures the operator authorization policy for /secrets (see
+// pkg/authz). The zero value applies no rules.
+type AuthzConfig struct {
+	PolicyFile     string        `json:"policy_file,omitempty"`
+	ReloadInterval time.Duration `json:"reload_interval,omitempty"` // 0 = DefaultAuthzPolicyReloadInterval
+}
+
+// Enabled reports whether a policy file is configured.
+func (ac AuthzConfig) Enabled() bool {
+	return ac.PolicyFile != ""
+}
+
+// Validate validates the authorization policy configuration
+func (ac AuthzConfig) Validate() error {
+	if ac.ReloadInterval < 0 {
+		return fmt.Errorf("authorization policy reload interval cannot be negative, got %s", ac.ReloadInterval)
+	}
+	if ac.PolicyFile == "" && ac.ReloadInterval != 0 {
+		return fmt.Errorf("authorization policy reload interval requires a policy file")
+	}
+	return nil
+}
+
+// OTLP export protocols
+const (
+	OTLPProtocolGRPC = "grpc"
+	OTLPProtocolHTTP = "http"
+)
+
+// TracingConfig configures export of OpenTelemetry spans over OTLP. Tracing is off
+// unless an endpoint is set. The standard OTEL_EXPORTER_OTLP_* variables (headers,
+// timeouts, certificates) and OTEL_TRACES_SAMPLER still apply to the exporter.
+type TracingConfig struct {
+	Endpoint string `json:"endpoint,omitempty"` // host:port of the OTLP collector
+	Protocol string `json:"protocol,omitempty"` // "grpc" (default) or "http"
+	Insecure bool   `json:"insecure,omitempty"` // plaintext connection to the collector
+}
+
+// Enabled reports whether spans are exported.
+func (tc TracingConfig) Enabled() bool {
+	return tc.Endpoint != ""
+}
+
+// Validate validates the tracing configuration
+func (tc TracingConfig) Validate() error {
+	switch tc.Protocol {
+	case "", OTLPProtocolGRPC, OTLPProtocolHTTP:
+	default:
+		return fmt.Errorf("unsupported OTLP protocol %q (want %q or %q)", tc.Protocol, OTLPProtocolGRPC, OTLPProtocolHTTP)
+	}
+	if tc.Endpoint == "" {
+		if tc.Protocol != "" || tc.Insecure {
+			return fmt.Errorf("OTLP protocol and insecure options require an OTLP endpoint")
+		}
+		return nil
+	}
+	if _, _, err := net.SplitHostPort(tc.Endpoint); err != nil {
+		return fmt.Errorf("invalid OTLP endpoint %q (want host:port): %w", tc.Endpoint, err)
+	}
 	return nil
 }
 
@@ -386,6 +726,10 @@ type KMSServerConfig struct {
 	AVSAddress    string `json:"avs_address"`     // AVS contract address
 	OperatorSetId uint32 `json:"operator_set_id"` // Operator set ID
 
+	// Keys lists the master keys served by this process, one per operator set. Empty
+	// serves a single default key for OperatorSetId (see EffectiveKeys).
+	Keys []KeyConfig `json:"keys,omitempty"`
+
 	// Base chain configuration (for commitment registry)
 	BaseRpcUrl                string `json:"base_rpc_url"`                // Base chain RPC endpoint
 	CommitmentRegistryAddress string `json:"commitment_registry_address"` // Commitment registry contract address on Base
@@ -397,6 +741,21 @@ type KMSServerConfig struct {
 	// Access control
 	AppAllowlist []string `json:"app_allowlist"` // Optional: restrict /app/sign and /secrets to these app IDs
 
+	// Observability
+	MetricsAddress string `json:"metrics_address"` // Optional: host:port for the Prometheus /metrics listener (empty = disabled)
+
+	// Admin control API (see AdminConfig)
+	Admin AdminConfig `json:"admin,omitempty"`
+
+	// Protocol tracing (see TracingConfig)
+	Tracing TracingConfig `json:"tracing,omitempty"`
+
+	// Key version retention (applies to every key)
+	Retention RetentionConfig `json:"retention,omitempty"`
+
+	// Authorization policy for /secrets (applies to every key)
+	Authz AuthzConfig `json:"authz,omitempty"`
+
 	// Persistence configuration
 	PersistenceConfig PersistenceConfig `json:"persistence_config"`
 
@@ -450,9 +809,74 @@ func (c *KMSServerConfig) Validate() error {
 		return fmt.Errorf("invalid persistence config: %w", err)
 	}
 
+	ids := make(map[string]bool, len(c.Keys))
+	operatorSets := make(map[uint32]string, len(c.Keys))
+	epochTags := make(map[uint32]string, len(c.Keys))
+	for _, key := range c.Keys {
+		if err := key.Validate(); err != nil {
+			return err
+		}
+		if ids[key.ID] {
+			return fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		if other, exists := operatorSets[key.OperatorSetId]; exists {
+			return fmt.Errorf("keys %q and %q both use operator set %d", other, key.ID, key.OperatorSetId)
+		}
+		// Keys submit commitments to the registry from the same operator address, so
+		// they must not share a registry epoch (types.CommitmentEpoch).
+		tag := types.CommitmentEpochTag(key.ID)
+		if other, exists := epochTags[tag]; exists {
+			return fmt.Errorf("keys %q and %q map to the same commitment registry epochs, rename one", other, key.ID)
+		}
+		ids[key.ID] = true
+		operatorSets[key.OperatorSetId] = key.ID
+		epochTags[tag] = key.ID
+	}
+
+	if err := c.Retention.Validate(); err != nil {
+		return fmt.Errorf("invalid retention config: %w", err)
+	}
+
+	if c.MetricsAddress != "" {
+		_, port, err := net.SplitHostPort(c.MetricsAddress)
+		if err != nil {
+			return fmt.Errorf("invalid metrics address %q: %w", c.MetricsAddress, err)
+		}
+		if port == strconv.Itoa(c.Port) {
+			return fmt.Errorf("metrics address %q must not use the KMS server port", c.MetricsAddress)
+		}
+	}
+
+	if err := c.Admin.Validate(); err != nil {
+		return fmt.Errorf("invalid admin config: %w", err)
+	}
+	if c.Admin.Address != "" {
+		_, port, _ := net.SplitHostPort(c.Admin.Address)
+		if port == strconv.Itoa(c.Port) {
+			return fmt.Errorf("admin address %q must not use the KMS server port", c.Admin.Address)
+		}
+	}
+
+	if err := c.Tracing.Validate(); err != nil {
+		return fmt.Errorf("invalid tracing config: %w", err)
+	}
+
+	if err := c.Authz.Validate(); err != nil {
+		return fmt.Errorf("invalid authz config: %w", err)
+	}
+
 	return nil
 }
 
+// EffectiveKeys returns the keys the server holds: Keys if configured, otherwise the
+// default key for OperatorSetId.
+func (c *KMSServerConfig) EffectiveKeys() []KeyConfig {
+	if len(c.Keys) > 0 {
+		return c.Keys
+	}
+	return []KeyConfig{{ID: types.DefaultKeyID, OperatorSetId: c.OperatorSetId}}
+}
+
 // GetSupportedChainIDs returns all supported chain IDs
 func GetSupportedChainIDs() []ChainId {
 	return []ChainId{
@@ -1,6 +1,11 @@
 package config
 
-import "testing"
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
 
 func TestGetReshareCutoffBufferForChain(t *testing.T) {
 	cases := []struct {
@@ -30,3 +35,200 @@ func TestCutoffBufferStrictlyInsideInterval(t *testing.T) {
 		}
 	}
 }
+
+func TestEncryptionConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     EncryptionConfig
+		wantErr bool
+	}{
+		{"keyfile", EncryptionConfig{KEKSource: KEKSourceKeyfile, KeyfilePath: "/etc/kek"}, false},
+		{"keyfile missing path", EncryptionConfig{KEKSource: KEKSourceKeyfile}, true},
+		{"aws-kms", EncryptionConfig{KEKSource: KEKSourceAWSKMS, AWSKMSKeyID: "alias/kms"}, false},
+		{"aws-kms missing key", EncryptionConfig{KEKSource: KEKSourceAWSKMS}, true},
+		{"passphrase", EncryptionConfig{KEKSource: KEKSourcePassphrase, Passphrase: "secret"}, false},
+		{"passphrase missing", EncryptionConfig{KEKSource: KEKSourcePassphrase}, true},
+		{"unknown source", EncryptionConfig{KEKSource: "vault"}, true},
+		{"previous keks", EncryptionConfig{KEKSource: KEKSourceKeyfile, KeyfilePath: "/etc/kek",
+			PreviousKEKs: []string{"keyfile:/etc/old", "aws-kms:alias/old", "passphrase-env:OLD_PASS"}}, false},
+		{"previous kek bad kind", EncryptionConfig{KEKSource: KEKSourceKeyfile, KeyfilePath: "/etc/kek",
+			PreviousKEKs: []string{"vault:x"}}, true},
+		{"previous kek missing value", EncryptionConfig{KEKSource: KEKSourceKeyfile, KeyfilePath: "/etc/kek",
+			PreviousKEKs: []string{"keyfile:"}}, true},
+	}
+	for _, c := range cases {
+		err := c.cfg.Validate()
+		if (err != nil) != c.wantErr {
+			t.Fatalf("%s: got err %v, wantErr %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestParseKeyConfig(t *testing.T) {
+	kc, err := ParseKeyConfig("tenant-b:3")
+	if err != nil || kc.ID != "tenant-b" || kc.OperatorSetId != 3 {
+		t.Fatalf("got %+v, %v", kc, err)
+	}
+	for _, spec := range []string{"tenant-b", "tenant-b:", "tenant-b:x", ":3", "Tenant:3", "-b:3", "a/b:3"} {
+		if _, err := ParseKeyConfig(spec); err == nil {
+			t.Fatalf("%q: expected error", spec)
+		}
+	}
+}
+
+func TestKMSServerConfigKeys(t *testing.T) {
+	c := &KMSServerConfig{OperatorSetId: 7}
+	if keys := c.EffectiveKeys(); len(keys) != 1 || keys[0].ID != "default" || keys[0].OperatorSetId != 7 {
+		t.Fatalf("unexpected default keys %+v", keys)
+	}
+
+	cases := []struct {
+		name    string
+		keys    []KeyConfig
+		wantErr bool
+	}{
+		{"distinct", []KeyConfig{{ID: "default", OperatorSetId: 0}, {ID: "b", OperatorSetId: 1}}, false},
+		{"duplicate id", []KeyConfig{{ID: "a", OperatorSetId: 0}, {ID: "a", OperatorSetId: 1}}, true},
+		{"shared operator set", []KeyConfig{{ID: "a", OperatorSetId: 1}, {ID: "b", OperatorSetId: 1}}, true},
+		// key-5316 and key-5993 hash to the same commitment registry epoch tag
+		{"shared registry epochs", []KeyConfig{{ID: "key-5316", OperatorSetId: 1}, {ID: "key-5993", OperatorSetId: 2}}, true},
+	}
+	for _, tc := range cases {
+		c := validServerConfig()
+		c.Keys = tc.keys
+		err := c.Validate()
+		if (err != nil) != tc.wantErr {
+			t.Fatalf("%s: got err %v, wantErr %v", tc.name, err, tc.wantErr)
+		}
+	}
+}
+
+func TestCommitmentEpoch(t *testing.T) {
+	const session = int64(1_700_000_000)
+	if got := types.CommitmentEpoch(types.DefaultKeyID, session); got != session {
+		t.Fatalf("default key epoch = %d, want the session timestamp %d", got, session)
+	}
+	if got := types.CommitmentEpoch("", session); got != session {
+		t.Fatalf("empty key epoch = %d, want the session timestamp %d", got, session)
+	}
+	other := types.CommitmentEpoch("tenant-b", session)
+	if other == session || other <= 0 {
+		t.Fatalf("tenant-b epoch = %d, want a positive epoch distinct from %d", other, session)
+	}
+	if other&(1<<40-1) != session {
+		t.Fatalf("tenant-b epoch %d does not keep the session timestamp in its low bits", other)
+	}
+	if types.CommitmentEpoch("tenant-c", session) == other {
+		t.Fatal("tenant-b and tenant-c share an epoch")
+	}
+}
+
+func TestRetentionConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     RetentionConfig
+		wantErr bool
+	}{
+		{"keep everything", RetentionConfig{}, false},
+		{"age and count", RetentionConfig{MaxAge: 30 * 24 * time.Hour, MaxVersions: 1000}, false},
+		{"negative age", RetentionConfig{MaxAge: -time.Hour}, true},
+		{"negative count", RetentionConfig{MaxVersions: -1}, true},
+	}
+	for _, c := range cases {
+		err := c.cfg.Validate()
+		if (err != nil) != c.wantErr {
+			t.Fatalf("%s: got err %v, wantErr %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestAdminConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     AdminConfig
+		wantErr bool
+	}{
+		{"disabled", AdminConfig{}, false},
+		{"socket", AdminConfig{SocketPath: "/run/kms/admin.sock"}, false},
+		{"mtls", AdminConfig{Address: "127.0.0.1:9443", TLSCertFile: "c", TLSKeyFile: "k", ClientCAFile: "ca"}, false},
+		{"both listeners", AdminConfig{SocketPath: "/run/kms/admin.sock", Address: "127.0.0.1:9443"}, true},
+		{"address without client CA", AdminConfig{Address: "127.0.0.1:9443", TLSCertFile: "c", TLSKeyFile: "k"}, true},
+		{"socket with TLS files", AdminConfig{SocketPath: "/run/kms/admin.sock", TLSCertFile: "c"}, true},
+		{"bad address", AdminConfig{Address: "9443", TLSCertFile: "c", TLSKeyFile: "k", ClientCAFile: "ca"}, true},
+	}
+	for _, c := range cases {
+		err := c.cfg.Validate()
+		if (err != nil) != c.wantErr {
+			t.Fatalf("%s: got err %v, wantErr %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestTracingConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     TracingConfig
+		wantErr bool
+	}{
+		{"disabled", TracingConfig{}, false},
+		{"grpc default", TracingConfig{Endpoint: "otel-collector:4317"}, false},
+		{"http insecure", TracingConfig{Endpoint: "localhost:4318", Protocol: OTLPProtocolHTTP, Insecure: true}, false},
+		{"unknown protocol", TracingConfig{Endpoint: "localhost:4317", Protocol: "thrift"}, true},
+		{"url instead of host:port", TracingConfig{Endpoint: "http://localhost:4318/v1/traces"}, true},
+		{"options without endpoint", TracingConfig{Insecure: true}, true},
+	}
+	for _, c := range cases {
+		err := c.cfg.Validate()
+		if (err != nil) != c.wantErr {
+			t.Fatalf("%s: got err %v, wantErr %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestAuthzConfigValidate(t *testing.T) {
+	cases := []struct {
+		name    string
+		cfg     AuthzConfig
+		wantErr bool
+	}{
+		{"disabled", AuthzConfig{}, false},
+		{"file with default interval", AuthzConfig{PolicyFile: "/etc/kms/authz.yaml"}, false},
+		{"file with interval", AuthzConfig{PolicyFile: "/etc/kms/authz.yaml", ReloadInterval: time.Minute}, false},
+		{"negative interval", AuthzConfig{PolicyFile: "/etc/kms/authz.yaml", ReloadInterval: -time.Second}, true},
+		{"interval without file", AuthzConfig{ReloadInterval: time.Minute}, true},
+	}
+	for _, c := range cases {
+		err := c.cfg.Validate()
+		if (err != nil) != c.wantErr {
+			t.Fatalf("%s: got err %v, wantErr %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestPersistenceConfigForKey(t *testing.T) {
+	pc := PersistenceConfig{Type: "redis", DataPath: "/data", RedisConfig: &RedisConfig{Address: "r:6379", KeyPrefix: "app:"}}
+
+	if got := pc.ForKey("default"); got.DataPath != "/data" || got.RedisConfig.KeyPrefix != "app:" {
+		t.Fatalf("default key must keep the unscoped namespace, got %+v", got)
+	}
+	got := pc.ForKey("b")
+	if got.DataPath != "/data/keys/b" || got.RedisConfig.KeyPrefix != "app:key:b:" {
+		t.Fatalf("unexpected scoped config %+v / %+v", got, got.RedisConfig)
+	}
+	if pc.RedisConfig.KeyPrefix != "app:" {
+		t.Fatalf("ForKey must not modify the shared redis config")
+	}
+}
+
+func validServerConfig() *KMSServerConfig {
+	return &KMSServerConfig{
+		OperatorAddress: "0x1111111111111111111111111111111111111111",
+		Port:            8000,
+		ChainID:         ChainId_EthereumAnvil,
+		OperatorConfig: &OperatorConfig{
+			Address:       "0x1111111111111111111111111111111111111111",
+			SigningConfig: &ECDSAKeyConfig{PrivateKey: "0x01"},
+		},
+		PersistenceConfig: PersistenceConfig{Type: "memory"},
+	}
+}
@@ -3,6 +3,7 @@ package caller
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/big"
 
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/middleware-bindings/EigenKMSCommitmentRegistry"
@@ -86,3 +87,279 @@ func (c *ContractCaller) GetCommitmentAt(
 
 	return commitment.CommitmentHash, commitment.AckMerkleRoot, commitment.SubmittedAt.Uint64(), nil
 }
+
+// AckProof is one leaf of a dealer's on-chain ack merkle tree with its inclusion proof,
+// in the shape proveEquivocation expects.
+type AckProof struct {
+	Player         common.Address
+	Dealer         common.Address
+	ShareHash      [32]byte
+	CommitmentHash [32]byte
+	Proof          [][32]byte
+}
+
+func (a *AckProof) toBinding() EigenKMSCommitmentRegistry.IEigenKMSCommitmentRegistryAckData {
+	return EigenKMSCommitmentRegistry.IEigenKMSCommitmentRegistryAckData{
+		Player:         a.Player,
+		Dealer:         a.Dealer,
+		ShareHash:      a.ShareHash,
+		CommitmentHash: a.CommitmentHash,
+		Proof:          a.Proof,
+	}
+}
+
+// SubmitEquivocationProof submits two acks, both included under the dealer's on-chain ack
+// merkle root, that commit to different dealings. The registry records the equivocation
+// and reverts if either proof fails or the equivocation was already proven.
+func (c *ContractCaller) SubmitEquivocationProof(
+	ctx context.Context,
+	registryAddress common.Address,
+	epoch int64,
+	dealer common.Address,
+	ack1 *AckProof,
+	ack2 *AckProof,
+) (*types.Receipt, error) {
+	if ack1 == nil || ack2 == nil {
+		return nil, fmt.Errorf("both acknowledgement proofs are required")
+	}
+
+	registry, err := EigenKMSCommitmentRegistry.NewEigenKMSCommitmentRegistry(registryAddress, c.ethclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commitment registry instance: %w", err)
+	}
+
+	txOpts, err := c.buildTransactionOpts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	tx, err := registry.ProveEquivocation(txOpts, uint64(epoch), dealer, ack1.toBinding(), ack2.toBinding())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	c.logger.Sugar().Infow("Submitting equivocation proof to registry",
+		"epoch", epoch,
+		"dealer", dealer.Hex(),
+		"player1", ack1.Player.Hex(),
+		"player2", ack2.Player.Hex(),
+	)
+
+	return c.signAndSendTransaction(ctx, tx, "ProveEquivocation")
+}
+
+// IsEquivocationProven reports whether equivocation by dealer in epoch is already
+// recorded on the registry.
+func (c *ContractCaller) IsEquivocationProven(
+	ctx context.Context,
+	registryAddress common.Address,
+	epoch int64,
+	dealer common.Address,
+) (bool, error) {
+	registry, err := EigenKMSCommitmentRegistry.NewEigenKMSCommitmentRegistry(registryAddress, c.ethclient)
+	if err != nil {
+		return false, fmt.Errorf("failed to create commitment registry instance: %w", err)
+	}
+
+	proven, err := registry.EquivocationProven(&bind.CallOpts{Context: ctx}, uint64(epoch), dealer)
+	if err != nil {
+		return false, fmt.Errorf("failed to query equivocation status: %w", err)
+	}
+	return proven, nil
+}
+
+// ShareComplaint is a player's on-chain complaint that a dealer's share to it does not
+// verify. Deadline is the Unix time by which the dealer must answer; 0 means the player has
+// not complained.
+type ShareComplaint struct {
+	Deadline int64
+	Answered bool
+}
+
+// OpenShareComplaint complains, as the signing operator, that the share dealer dealt it in
+// epoch does not verify against the dealer's commitments. The dealer must answer before the
+// complaint's deadline or the invalid share can be proven with ProveInvalidShare.
+func (c *ContractCaller) OpenShareComplaint(
+	ctx context.Context,
+	registryAddress common.Address,
+	epoch int64,
+	dealer common.Address,
+) (*types.Receipt, error) {
+	registry, err := EigenKMSCommitmentRegistry.NewEigenKMSCommitmentRegistry(registryAddress, c.ethclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commitment registry instance: %w", err)
+	}
+
+	txOpts, err := c.buildTransactionOpts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	tx, err := registry.ComplainShare(txOpts, uint64(epoch), dealer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	c.logger.Sugar().Infow("Submitting share complaint to registry",
+		"epoch", epoch,
+		"dealer", dealer.Hex(),
+	)
+
+	return c.signAndSendTransaction(ctx, tx, "ComplainShare")
+}
+
+// AnswerShareComplaint answers player's complaint against the signing operator by revealing
+// the share it dealt player in epoch. commitmentPoints are the operator's commitments in
+// EIP-2537 encoding (crypto.EncodeG2ForPrecompile); sourceVersion is the key version a
+// reshare dealt from, or 0 for a DKG. The registry reverts unless the commitments match the
+// operator's on-chain commitment hash and the share verifies against them.
+func (c *ContractCaller) AnswerShareComplaint(
+	ctx context.Context,
+	registryAddress common.Address,
+	epoch int64,
+	player common.Address,
+	share *big.Int,
+	commitmentPoints [][]byte,
+	sourceVersion int64,
+) (*types.Receipt, error) {
+	if share == nil || len(commitmentPoints) == 0 {
+		return nil, fmt.Errorf("a share and its commitments are required")
+	}
+
+	registry, err := EigenKMSCommitmentRegistry.NewEigenKMSCommitmentRegistry(registryAddress, c.ethclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commitment registry instance: %w", err)
+	}
+
+	txOpts, err := c.buildTransactionOpts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	tx, err := registry.AnswerShareComplaint(txOpts, uint64(epoch), player, share, commitmentPoints, uint64(sourceVersion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	c.logger.Sugar().Infow("Answering share complaint on registry",
+		"epoch", epoch,
+		"player", player.Hex(),
+		"source_version", sourceVersion,
+	)
+
+	return c.signAndSendTransaction(ctx, tx, "AnswerShareComplaint")
+}
+
+// ProveInvalidShare proves that dealer dealt player an invalid share in epoch, by player's
+// complaint the dealer left unanswered past its deadline. The registry reverts while the
+// complaint can still be answered.
+func (c *ContractCaller) ProveInvalidShare(
+	ctx context.Context,
+	registryAddress common.Address,
+	epoch int64,
+	dealer common.Address,
+	player common.Address,
+) (*types.Receipt, error) {
+	registry, err := EigenKMSCommitmentRegistry.NewEigenKMSCommitmentRegistry(registryAddress, c.ethclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commitment registry instance: %w", err)
+	}
+
+	txOpts, err := c.buildTransactionOpts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build transaction options: %w", err)
+	}
+
+	tx, err := registry.ProveInvalidShare(txOpts, uint64(epoch), dealer, player)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transaction: %w", err)
+	}
+
+	c.logger.Sugar().Infow("Submitting invalid share proof to registry",
+		"epoch", epoch,
+		"dealer", dealer.Hex(),
+		"player", player.Hex(),
+	)
+
+	return c.signAndSendTransaction(ctx, tx, "ProveInvalidShare")
+}
+
+// GetShareComplaint reads player's complaint against dealer for epoch at chain head.
+func (c *ContractCaller) GetShareComplaint(
+	ctx context.Context,
+	registryAddress common.Address,
+	epoch int64,
+	dealer common.Address,
+	player common.Address,
+) (*ShareComplaint, error) {
+	registry, err := EigenKMSCommitmentRegistry.NewEigenKMSCommitmentRegistry(registryAddress, c.ethclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commitment registry instance: %w", err)
+	}
+
+	complaint, err := registry.GetShareComplaint(&bind.CallOpts{Context: ctx}, uint64(epoch), dealer, player)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get share complaint: %w", err)
+	}
+	if complaint.Deadline > math.MaxInt64 {
+		return nil, fmt.Errorf("complaint deadline %d out of range", complaint.Deadline)
+	}
+	return &ShareComplaint{
+		Deadline: int64(complaint.Deadline),
+		Answered: complaint.Answered,
+	}, nil
+}
+
+// IsInvalidShareProven reports whether an invalid share by dealer in epoch is already
+// recorded on the registry.
+func (c *ContractCaller) IsInvalidShareProven(
+	ctx context.Context,
+	registryAddress common.Address,
+	epoch int64,
+	dealer common.Address,
+) (bool, error) {
+	registry, err := EigenKMSCommitmentRegistry.NewEigenKMSCommitmentRegistry(registryAddress, c.ethclient)
+	if err != nil {
+		return false, fmt.Errorf("failed to create commitment registry instance: %w", err)
+	}
+
+	proven, err := registry.InvalidShareProven(&bind.CallOpts{Context: ctx}, uint64(epoch), dealer)
+	if err != nil {
+		return false, fmt.Errorf("failed to query invalid share status: %w", err)
+	}
+	return proven, nil
+}
+
+// RotationSchedule is the master secret rotation the registry owner scheduled for a key.
+// Generation is 0 when none is scheduled. RetireAfter is in seconds; 0 leaves the
+// retirement window to the node default.
+type RotationSchedule struct {
+	Generation  uint32
+	At          int64
+	RetireAfter uint64
+}
+
+// GetRotationSchedule reads the master secret rotation scheduled for keyID at chain head.
+func (c *ContractCaller) GetRotationSchedule(
+	ctx context.Context,
+	registryAddress common.Address,
+	keyID string,
+) (*RotationSchedule, error) {
+	registry, err := EigenKMSCommitmentRegistry.NewEigenKMSCommitmentRegistry(registryAddress, c.ethclient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create commitment registry instance: %w", err)
+	}
+
+	rotation, err := registry.GetRotation(&bind.CallOpts{Context: ctx}, keyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rotation schedule: %w", err)
+	}
+	if rotation.At > math.MaxInt64 {
+		return nil, fmt.Errorf("rotation time %d out of range", rotation.At)
+	}
+	return &RotationSchedule{
+		Generation:  rotation.Generation,
+		At:          int64(rotation.At),
+		RetireAfter: rotation.RetireAfter,
+	}, nil
+}
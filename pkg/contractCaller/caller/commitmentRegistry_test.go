@@ -18,6 +18,8 @@ func TestCommitmentRegistryFunctions(t *testing.T) {
 		var c *ContractCaller
 		require.NotNil(t, c.SubmitCommitment)
 		require.NotNil(t, c.GetCommitment)
+		require.NotNil(t, c.SubmitEquivocationProof)
+		require.NotNil(t, c.IsEquivocationProven)
 	})
 }
 
@@ -53,3 +55,21 @@ func TestEpochType(t *testing.T) {
 	epochBig := big.NewInt(epoch)
 	require.Equal(t, int64(1234567890), epochBig.Int64())
 }
+
+// TestAckProofToBinding verifies the ack proof maps field-for-field onto the contract struct
+func TestAckProofToBinding(t *testing.T) {
+	ack := &AckProof{
+		Player:         common.HexToAddress("0x01"),
+		Dealer:         common.HexToAddress("0x02"),
+		ShareHash:      [32]byte{3},
+		CommitmentHash: [32]byte{4},
+		Proof:          [][32]byte{{5}, {6}},
+	}
+
+	b := ack.toBinding()
+	require.Equal(t, ack.Player, b.Player)
+	require.Equal(t, ack.Dealer, b.Dealer)
+	require.Equal(t, ack.ShareHash, b.ShareHash)
+	require.Equal(t, ack.CommitmentHash, b.CommitmentHash)
+	require.Equal(t, ack.Proof, b.Proof)
+}
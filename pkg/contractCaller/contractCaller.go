@@ -2,6 +2,7 @@ package contractCaller
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/Layr-Labs/crypto-libs/pkg/bn254"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
@@ -96,6 +97,86 @@ type IContractCaller interface {
 		blockNumber uint64,
 	) (commitmentHash [32]byte, ackMerkleRoot [32]byte, submittedAt uint64, err error)
 
+	// SubmitEquivocationProof calls the registry's proveEquivocation with two acks, each
+	// proven against the dealer's on-chain ack merkle root, that commit to different
+	// dealings. See docs/003_fraudProofs.md.
+	SubmitEquivocationProof(
+		ctx context.Context,
+		registryAddress common.Address,
+		epoch int64,
+		dealer common.Address,
+		ack1 *caller.AckProof,
+		ack2 *caller.AckProof,
+	) (*ethereumTypes.Receipt, error)
+
+	// IsEquivocationProven reports whether the registry already holds an equivocation
+	// proof against dealer for epoch.
+	IsEquivocationProven(
+		ctx context.Context,
+		registryAddress common.Address,
+		epoch int64,
+		dealer common.Address,
+	) (bool, error)
+
+	// OpenShareComplaint calls the registry's complainShare: the signing operator
+	// complains that dealer's share to it does not verify. See docs/003_fraudProofs.md.
+	OpenShareComplaint(
+		ctx context.Context,
+		registryAddress common.Address,
+		epoch int64,
+		dealer common.Address,
+	) (*ethereumTypes.Receipt, error)
+
+	// AnswerShareComplaint calls the registry's answerShareComplaint, revealing the share
+	// the signing operator dealt player so the registry can check it against the
+	// operator's commitments.
+	AnswerShareComplaint(
+		ctx context.Context,
+		registryAddress common.Address,
+		epoch int64,
+		player common.Address,
+		share *big.Int,
+		commitmentPoints [][]byte,
+		sourceVersion int64,
+	) (*ethereumTypes.Receipt, error)
+
+	// ProveInvalidShare calls the registry's proveInvalidShare for a share complaint
+	// dealer left unanswered past its deadline.
+	ProveInvalidShare(
+		ctx context.Context,
+		registryAddress common.Address,
+		epoch int64,
+		dealer common.Address,
+		player common.Address,
+	) (*ethereumTypes.Receipt, error)
+
+	// GetShareComplaint reads player's share complaint against dealer.
+	GetShareComplaint(
+		ctx context.Context,
+		registryAddress common.Address,
+		epoch int64,
+		dealer common.Address,
+		player common.Address,
+	) (*caller.ShareComplaint, error)
+
+	// IsInvalidShareProven reports whether the registry already holds an invalid-share
+	// proof against dealer for epoch.
+	IsInvalidShareProven(
+		ctx context.Context,
+		registryAddress common.Address,
+		epoch int64,
+		dealer common.Address,
+	) (bool, error)
+
+	// GetRotationSchedule reads the master secret rotation the registry owner scheduled
+	// for keyID. Operators run the rotation DKG from the on-chain schedule so they all
+	// rotate at the same boundary.
+	GetRotationSchedule(
+		ctx context.Context,
+		registryAddress common.Address,
+		keyID string,
+	) (*caller.RotationSchedule, error)
+
 	// HeaderTimestampAt returns the Unix timestamp of the block at blockNumber
 	// (0 => latest head). Used to map an L1 deadline block to an L2 read height.
 	HeaderTimestampAt(ctx context.Context, blockNumber uint64) (uint64, error)
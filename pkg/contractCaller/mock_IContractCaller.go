@@ -6,6 +6,7 @@ package contractCaller
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/Layr-Labs/crypto-libs/pkg/bn254"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
@@ -45,6 +46,104 @@ func (_m *MockIContractCaller) EXPECT() *MockIContractCaller_Expecter {
 	return &MockIContractCaller_Expecter{mock: &_m.Mock}
 }
 
+// AnswerShareComplaint provides a mock function for the type MockIContractCaller
+func (_mock *MockIContractCaller) AnswerShareComplaint(ctx context.Context, registryAddress common.Address, epoch int64, player common.Address, share *big.Int, commitmentPoints [][]byte, sourceVersion int64) (*types.Receipt, error) {
+	ret := _mock.Called(ctx, registryAddress, epoch, player, share, commitmentPoints, sourceVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AnswerShareComplaint")
+	}
+
+	var r0 *types.Receipt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address, *big.Int, [][]byte, int64) (*types.Receipt, error)); ok {
+		return returnFunc(ctx, registryAddress, epoch, player, share, commitmentPoints, sourceVersion)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address, *big.Int, [][]byte, int64) *types.Receipt); ok {
+		r0 = returnFunc(ctx, registryAddress, epoch, player, share, commitmentPoints, sourceVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, int64, common.Address, *big.Int, [][]byte, int64) error); ok {
+		r1 = returnFunc(ctx, registryAddress, epoch, player, share, commitmentPoints, sourceVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIContractCaller_AnswerShareComplaint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AnswerShareComplaint'
+type MockIContractCaller_AnswerShareComplaint_Call struct {
+	*mock.Call
+}
+
+// AnswerShareComplaint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registryAddress common.Address
+//   - epoch int64
+//   - player common.Address
+//   - share *big.Int
+//   - commitmentPoints [][]byte
+//   - sourceVersion int64
+func (_e *MockIContractCaller_Expecter) AnswerShareComplaint(ctx interface{}, registryAddress interface{}, epoch interface{}, player interface{}, share interface{}, commitmentPoints interface{}, sourceVersion interface{}) *MockIContractCaller_AnswerShareComplaint_Call {
+	return &MockIContractCaller_AnswerShareComplaint_Call{Call: _e.mock.On("AnswerShareComplaint", ctx, registryAddress, epoch, player, share, commitmentPoints, sourceVersion)}
+}
+
+func (_c *MockIContractCaller_AnswerShareComplaint_Call) Run(run func(ctx context.Context, registryAddress common.Address, epoch int64, player common.Address, share *big.Int, commitmentPoints [][]byte, sourceVersion int64)) *MockIContractCaller_AnswerShareComplaint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 common.Address
+		if args[1] != nil {
+			arg1 = args[1].(common.Address)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 common.Address
+		if args[3] != nil {
+			arg3 = args[3].(common.Address)
+		}
+		var arg4 *big.Int
+		if args[4] != nil {
+			arg4 = args[4].(*big.Int)
+		}
+		var arg5 [][]byte
+		if args[5] != nil {
+			arg5 = args[5].([][]byte)
+		}
+		var arg6 int64
+		if args[6] != nil {
+			arg6 = args[6].(int64)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIContractCaller_AnswerShareComplaint_Call) Return(receipt *types.Receipt, err error) *MockIContractCaller_AnswerShareComplaint_Call {
+	_c.Call.Return(receipt, err)
+	return _c
+}
+
+func (_c *MockIContractCaller_AnswerShareComplaint_Call) RunAndReturn(run func(ctx context.Context, registryAddress common.Address, epoch int64, player common.Address, share *big.Int, commitmentPoints [][]byte, sourceVersion int64) (*types.Receipt, error)) *MockIContractCaller_AnswerShareComplaint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateOperatorAndRegisterWithAvs provides a mock function for the type MockIContractCaller
 func (_mock *MockIContractCaller) CreateOperatorAndRegisterWithAvs(ctx context.Context, avsAddress common.Address, operatorAddress common.Address, operatorSetIds []uint32, socket string, allocationDelay uint32, metadataUri string) (*types.Receipt, error) {
 	ret := _mock.Called(ctx, avsAddress, operatorAddress, operatorSetIds, socket, allocationDelay, metadataUri)
@@ -1479,6 +1578,166 @@ func (_c *MockIContractCaller_GetOperatorSetMembersWithPeering_Call) RunAndRetur
 	return _c
 }
 
+// GetRotationSchedule provides a mock function for the type MockIContractCaller
+func (_mock *MockIContractCaller) GetRotationSchedule(ctx context.Context, registryAddress common.Address, keyID string) (*caller.RotationSchedule, error) {
+	ret := _mock.Called(ctx, registryAddress, keyID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRotationSchedule")
+	}
+
+	var r0 *caller.RotationSchedule
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, string) (*caller.RotationSchedule, error)); ok {
+		return returnFunc(ctx, registryAddress, keyID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, string) *caller.RotationSchedule); ok {
+		r0 = returnFunc(ctx, registryAddress, keyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*caller.RotationSchedule)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, string) error); ok {
+		r1 = returnFunc(ctx, registryAddress, keyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIContractCaller_GetRotationSchedule_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRotationSchedule'
+type MockIContractCaller_GetRotationSchedule_Call struct {
+	*mock.Call
+}
+
+// GetRotationSchedule is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registryAddress common.Address
+//   - keyID string
+func (_e *MockIContractCaller_Expecter) GetRotationSchedule(ctx interface{}, registryAddress interface{}, keyID interface{}) *MockIContractCaller_GetRotationSchedule_Call {
+	return &MockIContractCaller_GetRotationSchedule_Call{Call: _e.mock.On("GetRotationSchedule", ctx, registryAddress, keyID)}
+}
+
+func (_c *MockIContractCaller_GetRotationSchedule_Call) Run(run func(ctx context.Context, registryAddress common.Address, keyID string)) *MockIContractCaller_GetRotationSchedule_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 common.Address
+		if args[1] != nil {
+			arg1 = args[1].(common.Address)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIContractCaller_GetRotationSchedule_Call) Return(rotationSchedule *caller.RotationSchedule, err error) *MockIContractCaller_GetRotationSchedule_Call {
+	_c.Call.Return(rotationSchedule, err)
+	return _c
+}
+
+func (_c *MockIContractCaller_GetRotationSchedule_Call) RunAndReturn(run func(ctx context.Context, registryAddress common.Address, keyID string) (*caller.RotationSchedule, error)) *MockIContractCaller_GetRotationSchedule_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShareComplaint provides a mock function for the type MockIContractCaller
+func (_mock *MockIContractCaller) GetShareComplaint(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, player common.Address) (*caller.ShareComplaint, error) {
+	ret := _mock.Called(ctx, registryAddress, epoch, dealer, player)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShareComplaint")
+	}
+
+	var r0 *caller.ShareComplaint
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address, common.Address) (*caller.ShareComplaint, error)); ok {
+		return returnFunc(ctx, registryAddress, epoch, dealer, player)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address, common.Address) *caller.ShareComplaint); ok {
+		r0 = returnFunc(ctx, registryAddress, epoch, dealer, player)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*caller.ShareComplaint)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, int64, common.Address, common.Address) error); ok {
+		r1 = returnFunc(ctx, registryAddress, epoch, dealer, player)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIContractCaller_GetShareComplaint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShareComplaint'
+type MockIContractCaller_GetShareComplaint_Call struct {
+	*mock.Call
+}
+
+// GetShareComplaint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registryAddress common.Address
+//   - epoch int64
+//   - dealer common.Address
+//   - player common.Address
+func (_e *MockIContractCaller_Expecter) GetShareComplaint(ctx interface{}, registryAddress interface{}, epoch interface{}, dealer interface{}, player interface{}) *MockIContractCaller_GetShareComplaint_Call {
+	return &MockIContractCaller_GetShareComplaint_Call{Call: _e.mock.On("GetShareComplaint", ctx, registryAddress, epoch, dealer, player)}
+}
+
+func (_c *MockIContractCaller_GetShareComplaint_Call) Run(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, player common.Address)) *MockIContractCaller_GetShareComplaint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 common.Address
+		if args[1] != nil {
+			arg1 = args[1].(common.Address)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 common.Address
+		if args[3] != nil {
+			arg3 = args[3].(common.Address)
+		}
+		var arg4 common.Address
+		if args[4] != nil {
+			arg4 = args[4].(common.Address)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIContractCaller_GetShareComplaint_Call) Return(shareComplaint *caller.ShareComplaint, err error) *MockIContractCaller_GetShareComplaint_Call {
+	_c.Call.Return(shareComplaint, err)
+	return _c
+}
+
+func (_c *MockIContractCaller_GetShareComplaint_Call) RunAndReturn(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, player common.Address) (*caller.ShareComplaint, error)) *MockIContractCaller_GetShareComplaint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HeaderTimestampAt provides a mock function for the type MockIContractCaller
 func (_mock *MockIContractCaller) HeaderTimestampAt(ctx context.Context, blockNumber uint64) (uint64, error) {
 	ret := _mock.Called(ctx, blockNumber)
@@ -1545,6 +1804,328 @@ func (_c *MockIContractCaller_HeaderTimestampAt_Call) RunAndReturn(run func(ctx
 	return _c
 }
 
+// IsEquivocationProven provides a mock function for the type MockIContractCaller
+func (_mock *MockIContractCaller) IsEquivocationProven(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address) (bool, error) {
+	ret := _mock.Called(ctx, registryAddress, epoch, dealer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsEquivocationProven")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address) (bool, error)); ok {
+		return returnFunc(ctx, registryAddress, epoch, dealer)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address) bool); ok {
+		r0 = returnFunc(ctx, registryAddress, epoch, dealer)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, int64, common.Address) error); ok {
+		r1 = returnFunc(ctx, registryAddress, epoch, dealer)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIContractCaller_IsEquivocationProven_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsEquivocationProven'
+type MockIContractCaller_IsEquivocationProven_Call struct {
+	*mock.Call
+}
+
+// IsEquivocationProven is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registryAddress common.Address
+//   - epoch int64
+//   - dealer common.Address
+func (_e *MockIContractCaller_Expecter) IsEquivocationProven(ctx interface{}, registryAddress interface{}, epoch interface{}, dealer interface{}) *MockIContractCaller_IsEquivocationProven_Call {
+	return &MockIContractCaller_IsEquivocationProven_Call{Call: _e.mock.On("IsEquivocationProven", ctx, registryAddress, epoch, dealer)}
+}
+
+func (_c *MockIContractCaller_IsEquivocationProven_Call) Run(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address)) *MockIContractCaller_IsEquivocationProven_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 common.Address
+		if args[1] != nil {
+			arg1 = args[1].(common.Address)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 common.Address
+		if args[3] != nil {
+			arg3 = args[3].(common.Address)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIContractCaller_IsEquivocationProven_Call) Return(b bool, err error) *MockIContractCaller_IsEquivocationProven_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockIContractCaller_IsEquivocationProven_Call) RunAndReturn(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address) (bool, error)) *MockIContractCaller_IsEquivocationProven_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// IsInvalidShareProven provides a mock function for the type MockIContractCaller
+func (_mock *MockIContractCaller) IsInvalidShareProven(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address) (bool, error) {
+	ret := _mock.Called(ctx, registryAddress, epoch, dealer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsInvalidShareProven")
+	}
+
+	var r0 bool
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address) (bool, error)); ok {
+		return returnFunc(ctx, registryAddress, epoch, dealer)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address) bool); ok {
+		r0 = returnFunc(ctx, registryAddress, epoch, dealer)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, int64, common.Address) error); ok {
+		r1 = returnFunc(ctx, registryAddress, epoch, dealer)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIContractCaller_IsInvalidShareProven_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'IsInvalidShareProven'
+type MockIContractCaller_IsInvalidShareProven_Call struct {
+	*mock.Call
+}
+
+// IsInvalidShareProven is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registryAddress common.Address
+//   - epoch int64
+//   - dealer common.Address
+func (_e *MockIContractCaller_Expecter) IsInvalidShareProven(ctx interface{}, registryAddress interface{}, epoch interface{}, dealer interface{}) *MockIContractCaller_IsInvalidShareProven_Call {
+	return &MockIContractCaller_IsInvalidShareProven_Call{Call: _e.mock.On("IsInvalidShareProven", ctx, registryAddress, epoch, dealer)}
+}
+
+func (_c *MockIContractCaller_IsInvalidShareProven_Call) Run(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address)) *MockIContractCaller_IsInvalidShareProven_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 common.Address
+		if args[1] != nil {
+			arg1 = args[1].(common.Address)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 common.Address
+		if args[3] != nil {
+			arg3 = args[3].(common.Address)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIContractCaller_IsInvalidShareProven_Call) Return(b bool, err error) *MockIContractCaller_IsInvalidShareProven_Call {
+	_c.Call.Return(b, err)
+	return _c
+}
+
+func (_c *MockIContractCaller_IsInvalidShareProven_Call) RunAndReturn(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address) (bool, error)) *MockIContractCaller_IsInvalidShareProven_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// OpenShareComplaint provides a mock function for the type MockIContractCaller
+func (_mock *MockIContractCaller) OpenShareComplaint(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address) (*types.Receipt, error) {
+	ret := _mock.Called(ctx, registryAddress, epoch, dealer)
+
+	if len(ret) == 0 {
+		panic("no return value specified for OpenShareComplaint")
+	}
+
+	var r0 *types.Receipt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address) (*types.Receipt, error)); ok {
+		return returnFunc(ctx, registryAddress, epoch, dealer)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address) *types.Receipt); ok {
+		r0 = returnFunc(ctx, registryAddress, epoch, dealer)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, int64, common.Address) error); ok {
+		r1 = returnFunc(ctx, registryAddress, epoch, dealer)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIContractCaller_OpenShareComplaint_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'OpenShareComplaint'
+type MockIContractCaller_OpenShareComplaint_Call struct {
+	*mock.Call
+}
+
+// OpenShareComplaint is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registryAddress common.Address
+//   - epoch int64
+//   - dealer common.Address
+func (_e *MockIContractCaller_Expecter) OpenShareComplaint(ctx interface{}, registryAddress interface{}, epoch interface{}, dealer interface{}) *MockIContractCaller_OpenShareComplaint_Call {
+	return &MockIContractCaller_OpenShareComplaint_Call{Call: _e.mock.On("OpenShareComplaint", ctx, registryAddress, epoch, dealer)}
+}
+
+func (_c *MockIContractCaller_OpenShareComplaint_Call) Run(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address)) *MockIContractCaller_OpenShareComplaint_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 common.Address
+		if args[1] != nil {
+			arg1 = args[1].(common.Address)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 common.Address
+		if args[3] != nil {
+			arg3 = args[3].(common.Address)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIContractCaller_OpenShareComplaint_Call) Return(receipt *types.Receipt, err error) *MockIContractCaller_OpenShareComplaint_Call {
+	_c.Call.Return(receipt, err)
+	return _c
+}
+
+func (_c *MockIContractCaller_OpenShareComplaint_Call) RunAndReturn(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address) (*types.Receipt, error)) *MockIContractCaller_OpenShareComplaint_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProveInvalidShare provides a mock function for the type MockIContractCaller
+func (_mock *MockIContractCaller) ProveInvalidShare(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, player common.Address) (*types.Receipt, error) {
+	ret := _mock.Called(ctx, registryAddress, epoch, dealer, player)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProveInvalidShare")
+	}
+
+	var r0 *types.Receipt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address, common.Address) (*types.Receipt, error)); ok {
+		return returnFunc(ctx, registryAddress, epoch, dealer, player)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address, common.Address) *types.Receipt); ok {
+		r0 = returnFunc(ctx, registryAddress, epoch, dealer, player)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, int64, common.Address, common.Address) error); ok {
+		r1 = returnFunc(ctx, registryAddress, epoch, dealer, player)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIContractCaller_ProveInvalidShare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProveInvalidShare'
+type MockIContractCaller_ProveInvalidShare_Call struct {
+	*mock.Call
+}
+
+// ProveInvalidShare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registryAddress common.Address
+//   - epoch int64
+//   - dealer common.Address
+//   - player common.Address
+func (_e *MockIContractCaller_Expecter) ProveInvalidShare(ctx interface{}, registryAddress interface{}, epoch interface{}, dealer interface{}, player interface{}) *MockIContractCaller_ProveInvalidShare_Call {
+	return &MockIContractCaller_ProveInvalidShare_Call{Call: _e.mock.On("ProveInvalidShare", ctx, registryAddress, epoch, dealer, player)}
+}
+
+func (_c *MockIContractCaller_ProveInvalidShare_Call) Run(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, player common.Address)) *MockIContractCaller_ProveInvalidShare_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 common.Address
+		if args[1] != nil {
+			arg1 = args[1].(common.Address)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 common.Address
+		if args[3] != nil {
+			arg3 = args[3].(common.Address)
+		}
+		var arg4 common.Address
+		if args[4] != nil {
+			arg4 = args[4].(common.Address)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIContractCaller_ProveInvalidShare_Call) Return(receipt *types.Receipt, err error) *MockIContractCaller_ProveInvalidShare_Call {
+	_c.Call.Return(receipt, err)
+	return _c
+}
+
+func (_c *MockIContractCaller_ProveInvalidShare_Call) RunAndReturn(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, player common.Address) (*types.Receipt, error)) *MockIContractCaller_ProveInvalidShare_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RegisterKeyWithKeyRegistrar provides a mock function for the type MockIContractCaller
 func (_mock *MockIContractCaller) RegisterKeyWithKeyRegistrar(ctx context.Context, operatorAddress common.Address, avsAddress common.Address, operatorSetId uint32, sigBytes []byte, keyData []byte) (*types.Receipt, error) {
 	ret := _mock.Called(ctx, operatorAddress, avsAddress, operatorSetId, sigBytes, keyData)
@@ -1773,3 +2354,95 @@ func (_c *MockIContractCaller_SubmitCommitment_Call) RunAndReturn(run func(ctx c
 	_c.Call.Return(run)
 	return _c
 }
+
+// SubmitEquivocationProof provides a mock function for the type MockIContractCaller
+func (_mock *MockIContractCaller) SubmitEquivocationProof(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, ack1 *caller.AckProof, ack2 *caller.AckProof) (*types.Receipt, error) {
+	ret := _mock.Called(ctx, registryAddress, epoch, dealer, ack1, ack2)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubmitEquivocationProof")
+	}
+
+	var r0 *types.Receipt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address, *caller.AckProof, *caller.AckProof) (*types.Receipt, error)); ok {
+		return returnFunc(ctx, registryAddress, epoch, dealer, ack1, ack2)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, common.Address, int64, common.Address, *caller.AckProof, *caller.AckProof) *types.Receipt); ok {
+		r0 = returnFunc(ctx, registryAddress, epoch, dealer, ack1, ack2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Receipt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, common.Address, int64, common.Address, *caller.AckProof, *caller.AckProof) error); ok {
+		r1 = returnFunc(ctx, registryAddress, epoch, dealer, ack1, ack2)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// MockIContractCaller_SubmitEquivocationProof_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SubmitEquivocationProof'
+type MockIContractCaller_SubmitEquivocationProof_Call struct {
+	*mock.Call
+}
+
+// SubmitEquivocationProof is a helper method to define mock.On call
+//   - ctx context.Context
+//   - registryAddress common.Address
+//   - epoch int64
+//   - dealer common.Address
+//   - ack1 *caller.AckProof
+//   - ack2 *caller.AckProof
+func (_e *MockIContractCaller_Expecter) SubmitEquivocationProof(ctx interface{}, registryAddress interface{}, epoch interface{}, dealer interface{}, ack1 interface{}, ack2 interface{}) *MockIContractCaller_SubmitEquivocationProof_Call {
+	return &MockIContractCaller_SubmitEquivocationProof_Call{Call: _e.mock.On("SubmitEquivocationProof", ctx, registryAddress, epoch, dealer, ack1, ack2)}
+}
+
+func (_c *MockIContractCaller_SubmitEquivocationProof_Call) Run(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, ack1 *caller.AckProof, ack2 *caller.AckProof)) *MockIContractCaller_SubmitEquivocationProof_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 common.Address
+		if args[1] != nil {
+			arg1 = args[1].(common.Address)
+		}
+		var arg2 int64
+		if args[2] != nil {
+			arg2 = args[2].(int64)
+		}
+		var arg3 common.Address
+		if args[3] != nil {
+			arg3 = args[3].(common.Address)
+		}
+		var arg4 *caller.AckProof
+		if args[4] != nil {
+			arg4 = args[4].(*caller.AckProof)
+		}
+		var arg5 *caller.AckProof
+		if args[5] != nil {
+			arg5 = args[5].(*caller.AckProof)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *MockIContractCaller_SubmitEquivocationProof_Call) Return(receipt *types.Receipt, err error) *MockIContractCaller_SubmitEquivocationProof_Call {
+	_c.Call.Return(receipt, err)
+	return _c
+}
+
+func (_c *MockIContractCaller_SubmitEquivocationProof_Call) RunAndReturn(run func(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, ack1 *caller.AckProof, ack2 *caller.AckProof) (*types.Receipt, error)) *MockIContractCaller_SubmitEquivocationProof_Call {
+	_c.Call.Return(run)
+	return _c
+}
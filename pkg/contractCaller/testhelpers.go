@@ -3,6 +3,7 @@ package contractCaller
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"sync"
 
 	"github.com/Layr-Labs/crypto-libs/pkg/bn254"
@@ -34,6 +35,9 @@ type MockContractCallerStub struct {
 	// The operator identity is threaded via OperatorAddress below (SubmitCommitment's ABI has
 	// no operator arg; the contract uses msg.sender).
 	SubmitCommitmentFunc func(epoch int64, operator common.Address, commitmentHash [32]byte, ackMerkleRoot [32]byte)
+	// GetRotationScheduleFunc, when set, lets a test drive the on-chain rotation
+	// schedule. When nil, no rotation is scheduled.
+	GetRotationScheduleFunc func(ctx context.Context, registryAddress common.Address, keyID string) (*caller.RotationSchedule, error)
 	// OperatorAddress identifies which operator this caller instance acts as, so
 	// SubmitCommitment can attribute the submission (mirrors msg.sender on-chain).
 	OperatorAddress common.Address
@@ -93,6 +97,41 @@ func (m *MockContractCallerStub) GetCommitmentAt(ctx context.Context, registryAd
 	return [32]byte{}, [32]byte{}, 0, nil
 }
 
+func (m *MockContractCallerStub) SubmitEquivocationProof(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, ack1 *caller.AckProof, ack2 *caller.AckProof) (*ethTypes.Receipt, error) {
+	return &ethTypes.Receipt{Status: 1}, nil
+}
+
+func (m *MockContractCallerStub) IsEquivocationProven(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address) (bool, error) {
+	return false, nil
+}
+
+func (m *MockContractCallerStub) OpenShareComplaint(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address) (*ethTypes.Receipt, error) {
+	return &ethTypes.Receipt{Status: 1}, nil
+}
+
+func (m *MockContractCallerStub) AnswerShareComplaint(ctx context.Context, registryAddress common.Address, epoch int64, player common.Address, share *big.Int, commitmentPoints [][]byte, sourceVersion int64) (*ethTypes.Receipt, error) {
+	return &ethTypes.Receipt{Status: 1}, nil
+}
+
+func (m *MockContractCallerStub) ProveInvalidShare(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, player common.Address) (*ethTypes.Receipt, error) {
+	return &ethTypes.Receipt{Status: 1}, nil
+}
+
+func (m *MockContractCallerStub) GetShareComplaint(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address, player common.Address) (*caller.ShareComplaint, error) {
+	return &caller.ShareComplaint{}, nil
+}
+
+func (m *MockContractCallerStub) IsInvalidShareProven(ctx context.Context, registryAddress common.Address, epoch int64, dealer common.Address) (bool, error) {
+	return false, nil
+}
+
+func (m *MockContractCallerStub) GetRotationSchedule(ctx context.Context, registryAddress common.Address, keyID string) (*caller.RotationSchedule, error) {
+	if m.GetRotationScheduleFunc != nil {
+		return m.GetRotationScheduleFunc(ctx, registryAddress, keyID)
+	}
+	return &caller.RotationSchedule{}, nil
+}
+
 func (m *MockContractCallerStub) HeaderTimestampAt(ctx context.Context, blockNumber uint64) (uint64, error) {
 	if m.HeaderTimestampAtFunc != nil {
 		return m.HeaderTimestampAtFunc(ctx, blockNumber)
@@ -158,6 +197,7 @@ type TestableContractCallerStub struct {
 	releases        map[string]*types.Release         // confirmed (active) releases
 	pendingReleases map[string]*types.Release         // pending releases awaiting confirmation
 	creators        map[common.Address]common.Address // configured app creators
+	operatorSets    map[common.Address]uint32         // configured app operator sets
 	mu              sync.RWMutex
 }
 
@@ -167,6 +207,7 @@ func NewTestableContractCallerStub() *TestableContractCallerStub {
 		releases:        make(map[string]*types.Release),
 		pendingReleases: make(map[string]*types.Release),
 		creators:        make(map[common.Address]common.Address),
+		operatorSets:    make(map[common.Address]uint32),
 	}
 }
 
@@ -217,6 +258,22 @@ func (m *TestableContractCallerStub) GetAppCreator(app common.Address, opts *bin
 	return m.creators[app], nil
 }
 
+// SetAppOperatorSetId configures the operator set returned by GetAppOperatorSetId for
+// a given app address. Used to drive app-to-key binding tests.
+func (m *TestableContractCallerStub) SetAppOperatorSetId(app common.Address, operatorSetId uint32) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.operatorSets[app] = operatorSetId
+}
+
+// GetAppOperatorSetId returns the configured operator set for an app, or 0 if none
+// was set.
+func (m *TestableContractCallerStub) GetAppOperatorSetId(app common.Address, opts *bind.CallOpts) (uint32, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.operatorSets[app], nil
+}
+
 // GetLatestRelease returns the confirmed release data for an app in its raw form.
 // Delegates to the releases map so behaviour is consistent with GetLatestReleaseAsRelease.
 func (m *TestableContractCallerStub) GetLatestRelease(ctx context.Context, appID string) ([32]byte, caller.Env, []byte, types.ContainerPolicy, error) {
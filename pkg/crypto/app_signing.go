@@ -0,0 +1,154 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/util"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Threshold BLS signing of application messages. Each application has a signing key
+// derived from the master secret s,
+//
+//	sk_app = t_app·s,   pk_app = t_app·MPK,   t_app = H_Fr(appID)
+//
+// so operator i's share of it is t_app·s_i and anyone can compute pk_app from the master
+// public key. A partial signature is t_app·s_i·H_m(appID, message); a threshold of them
+// combine by Lagrange interpolation into the standard (minimal-signature-size) BLS
+// signature sk_app·H_m(appID, message), which verifies as
+//
+//	e(σ, G2) == e(H_m(appID, message), pk_app)
+//
+// H_m hashes to G1 under its own DST, so no message signs to an IBE key share
+// s_i·H_1(appID), and the appID prefix keeps one app's signatures from being valid
+// under another's key.
+
+const (
+	// appSigningKeyDST separates the per-app key tweak from other hashes to Fr.
+	appSigningKeyDST = "EIGENX-KMS-APP-SIGNING-KEY-V1"
+
+	// appMessageDST is the hash-to-G1 DST of application messages.
+	appMessageDST = "EIGENX-KMS-APP-MESSAGE-V1_BLS12381G1_XMD:SHA-256_SSWU_RO_"
+)
+
+// appSigningKeyTweak returns t_app, the factor relating an application's signing key to
+// the master secret.
+func appSigningKeyTweak(appID string) (*fr.Element, error) {
+	if err := util.ValidateAppID(appID); err != nil {
+		return nil, err
+	}
+	t, err := fr.Hash([]byte(appID), []byte(appSigningKeyDST), 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash app ID: %w", err)
+	}
+	if t[0].IsZero() {
+		return nil, errors.New("app signing key tweak is zero")
+	}
+	return &t[0], nil
+}
+
+// HashAppMessage hashes an application's message to the G1 point it signs.
+func HashAppMessage(appID string, message []byte) (*types.G1Point, error) {
+	if err := util.ValidateAppID(appID); err != nil {
+		return nil, err
+	}
+	msg := make([]byte, 0, 2+len(appID)+len(message))
+	msg = binary.BigEndian.AppendUint16(msg, uint16(len(appID)))
+	msg = append(msg, appID...)
+	msg = append(msg, message...)
+
+	point, err := bls12381.HashToG1(msg, []byte(appMessageDST))
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash message to G1: %w", err)
+	}
+	return &types.G1Point{CompressedBytes: bls.NewG1Point(&point).Marshal()}, nil
+}
+
+// AppSigningPublicKey returns the public key an application's message signatures verify
+// against, given the master public key. Given an operator's public key share instead (see
+// ComputeOperatorPublicKeyShare), it returns the key that operator's partial signatures
+// verify against.
+func AppSigningPublicKey(appID string, masterPublicKey types.G2Point) (*types.G2Point, error) {
+	t, err := appSigningKeyTweak(appID)
+	if err != nil {
+		return nil, err
+	}
+	return ScalarMulG2(masterPublicKey, t)
+}
+
+// SignAppMessage computes an operator's partial signature over an application's message
+// from the operator's key share.
+func SignAppMessage(appID string, keyShare *fr.Element, message []byte) (*types.G1Point, error) {
+	if keyShare == nil || keyShare.IsZero() {
+		return nil, errors.New("invalid key share")
+	}
+	t, err := appSigningKeyTweak(appID)
+	if err != nil {
+		return nil, err
+	}
+	h, err := HashAppMessage(appID, message)
+	if err != nil {
+		return nil, err
+	}
+	return ScalarMulG1(*h, new(fr.Element).Mul(t, keyShare))
+}
+
+// VerifyAppMessageSignature checks a signature over an application's message against
+// the application's signing public key (see AppSigningPublicKey). It checks partial
+// signatures too, against the operator's key.
+func VerifyAppMessageSignature(appID string, message []byte, signature types.G1Point, publicKey types.G2Point) (bool, error) {
+	h, err := HashAppMessage(appID, message)
+	if err != nil {
+		return false, err
+	}
+	sigPoint, err := bls.G1PointFromCompressedBytes(signature.CompressedBytes)
+	if err != nil {
+		return false, fmt.Errorf("invalid signature: %w", err)
+	}
+	if sigPoint.ToAffine().IsInfinity() {
+		return false, nil
+	}
+	hPoint, err := bls.G1PointFromCompressedBytes(h.CompressedBytes)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert message hash: %w", err)
+	}
+	pkPoint, err := bls.G2PointFromCompressedBytes(publicKey.CompressedBytes)
+	if err != nil {
+		return false, fmt.Errorf("invalid public key: %w", err)
+	}
+	if pkPoint.ToAffine().IsInfinity() {
+		return false, errors.New("invalid public key: point at infinity")
+	}
+	g2Gen, err := bls.G2PointFromCompressedBytes(G2Generator.CompressedBytes)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert G2 generator: %w", err)
+	}
+
+	// e(σ, G2) · e(-H_m, pk) == 1
+	var negH bls12381.G1Affine
+	negH.Neg(hPoint.ToAffine())
+	ok, err := bls12381.PairingCheck(
+		[]bls12381.G1Affine{*sigPoint.ToAffine(), negH},
+		[]bls12381.G2Affine{*g2Gen.ToAffine(), *pkPoint.ToAffine()},
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to compute pairing check: %w", err)
+	}
+	return ok, nil
+}
+
+// CombineAppMessageSignatures combines the partial signatures of a threshold of
+// operators over the same application message into the application's signature. The
+// partials are not checked here; see VerifyAppMessageSignature.
+func CombineAppMessageSignatures(partialSigs map[common.Address]types.G1Point, threshold int) (*types.G1Point, error) {
+	if threshold <= 0 || len(partialSigs) < threshold {
+		return nil, fmt.Errorf("insufficient partial signatures: got %d, need %d", len(partialSigs), threshold)
+	}
+	return interpolateG1(partialSigs, threshold)
+}
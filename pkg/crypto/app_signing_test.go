@@ -0,0 +1,137 @@
+package crypto
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AppMessageSigning(t *testing.T) {
+	appID := "test-app-signing"
+	threshold := 3
+
+	masterSecret := new(fr.Element).SetUint64(123456789)
+	poly, err := bls.GeneratePolynomial(masterSecret, threshold-1)
+	require.NoError(t, err)
+	operators := make([]common.Address, 5)
+	for i := range operators {
+		operators[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+	}
+	keyShares := bls.GenerateShares(poly, operators)
+	commitments, err := bls.CreateCommitments(poly)
+	require.NoError(t, err)
+	groupCommitments := make([]types.G2Point, len(commitments))
+	for i, c := range commitments {
+		groupCommitments[i] = types.G2Point{CompressedBytes: c.Marshal()}
+	}
+	appPubKey, err := AppSigningPublicKey(appID, groupCommitments[0])
+	require.NoError(t, err)
+
+	partialsFor := func(t *testing.T, message []byte) map[common.Address]types.G1Point {
+		partials := make(map[common.Address]types.G1Point)
+		for _, op := range operators {
+			partial, err := SignAppMessage(appID, keyShares[op], message)
+			require.NoError(t, err)
+			pkShare, err := ComputeOperatorPublicKeyShare(groupCommitments, op)
+			require.NoError(t, err)
+			opKey, err := AppSigningPublicKey(appID, *pkShare)
+			require.NoError(t, err)
+			ok, err := VerifyAppMessageSignature(appID, message, *partial, *opKey)
+			require.NoError(t, err)
+			require.True(t, ok, "partial of %s", op.Hex())
+			partials[op] = *partial
+		}
+		return partials
+	}
+
+	t.Run("combine and verify", func(t *testing.T) {
+		message := []byte("transfer 10 to 0xabc")
+		partials := partialsFor(t, message)
+
+		sig, err := CombineAppMessageSignatures(partials, threshold)
+		require.NoError(t, err)
+		ok, err := VerifyAppMessageSignature(appID, message, *sig, *appPubKey)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		// Any threshold subset gives the same signature
+		subset := map[common.Address]types.G1Point{
+			operators[4]: partials[operators[4]],
+			operators[0]: partials[operators[0]],
+			operators[2]: partials[operators[2]],
+		}
+		other, err := CombineAppMessageSignatures(subset, threshold)
+		require.NoError(t, err)
+		require.Equal(t, sig.CompressedBytes, other.CompressedBytes)
+
+		// It is the signature of the derived key t_app·s
+		tweak, err := appSigningKeyTweak(appID)
+		require.NoError(t, err)
+		h, err := HashAppMessage(appID, message)
+		require.NoError(t, err)
+		direct, err := ScalarMulG1(*h, new(fr.Element).Mul(tweak, masterSecret))
+		require.NoError(t, err)
+		require.Equal(t, direct.CompressedBytes, sig.CompressedBytes)
+
+		delete(subset, operators[0])
+		_, err = CombineAppMessageSignatures(subset, threshold)
+		require.ErrorContains(t, err, "insufficient partial signatures")
+	})
+
+	t.Run("signature is bound to message and app", func(t *testing.T) {
+		message := []byte("hello")
+		sig, err := CombineAppMessageSignatures(partialsFor(t, message), threshold)
+		require.NoError(t, err)
+
+		ok, err := VerifyAppMessageSignature(appID, []byte("hello!"), *sig, *appPubKey)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		otherKey, err := AppSigningPublicKey("another-app", groupCommitments[0])
+		require.NoError(t, err)
+		ok, err = VerifyAppMessageSignature("another-app", message, *sig, *otherKey)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		// Verifying against the master public key itself fails
+		ok, err = VerifyAppMessageSignature(appID, message, *sig, groupCommitments[0])
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		// The app ID and message are length-delimited
+		h1, err := HashAppMessage("app-ab", []byte("c"))
+		require.NoError(t, err)
+		h2, err := HashAppMessage("app-a", []byte("bc"))
+		require.NoError(t, err)
+		require.NotEqual(t, h1.CompressedBytes, h2.CompressedBytes)
+	})
+
+	t.Run("partial is not an IBE key share", func(t *testing.T) {
+		op := operators[0]
+		partial, err := SignAppMessage(appID, keyShares[op], []byte(appID))
+		require.NoError(t, err)
+		qID, err := HashToG1(appID)
+		require.NoError(t, err)
+		ibeShare, err := ScalarMulG1(*qID, keyShares[op])
+		require.NoError(t, err)
+		require.NotEqual(t, ibeShare.CompressedBytes, partial.CompressedBytes)
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		_, err := SignAppMessage(appID, new(fr.Element), []byte("m"))
+		require.ErrorContains(t, err, "invalid key share")
+		_, err = SignAppMessage("", keyShares[operators[0]], []byte("m"))
+		require.Error(t, err)
+
+		ok, err := VerifyAppMessageSignature(appID, []byte("m"), *types.ZeroG1Point(), *appPubKey)
+		require.NoError(t, err)
+		require.False(t, ok)
+		_, err = VerifyAppMessageSignature(appID, []byte("m"), types.G1Point{CompressedBytes: []byte{1, 2, 3}}, *appPubKey)
+		require.ErrorContains(t, err, "invalid signature")
+	})
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/util"
 	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fp"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr/polynomial"
 	"github.com/ethereum/go-ethereum/common"
@@ -156,6 +157,27 @@ func PointsEqualG2(a, b types.G2Point) (bool, error) {
 	return aPoint.Equal(bPoint), nil
 }
 
+// EncodeG2ForPrecompile returns point in the 256-byte encoding the EIP-2537 BLS12-381
+// precompiles take: x.c0 || x.c1 || y.c0 || y.c1, each coordinate left-padded to 64
+// bytes, with the point at infinity as all zeros. The commitment registry checks a share
+// revealed in answer to a complaint against commitments in this form.
+func EncodeG2ForPrecompile(point types.G2Point) ([]byte, error) {
+	g2Point, err := bls.G2PointFromCompressedBytes(point.CompressedBytes)
+	if err != nil {
+		return nil, err
+	}
+	affine := g2Point.ToAffine()
+	out := make([]byte, 256)
+	if affine.IsInfinity() {
+		return out, nil
+	}
+	for i, coord := range []fp.Element{affine.X.A0, affine.X.A1, affine.Y.A0, affine.Y.A1} {
+		b := coord.Bytes()
+		copy(out[i*64+16:(i+1)*64], b[:])
+	}
+	return out, nil
+}
+
 // HashToG1 hashes a string to a G1 point using proper hash-to-curve
 func HashToG1(appID string) (*types.G1Point, error) {
 	g1Point, err := bls.HashToG1([]byte(appID))
@@ -231,9 +253,28 @@ func RecoverAppPrivateKey(appID string, partialSigs map[common.Address]types.G1P
 		return nil, fmt.Errorf("insufficient partial signatures: got %d, need %d", len(partialSigs), threshold)
 	}
 
+	result, err := interpolateG1(partialSigs, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	// check if the result is still a zero point
+	isZero, err := result.IsZero()
+	if err != nil {
+		return nil, err
+	}
+	if isZero {
+		return nil, errors.New("recovered app private key is zero")
+	}
+	return result, nil
+}
+
+// interpolateG1 combines the first threshold of the given G1 shares, in address order,
+// by Lagrange interpolation at x=0. The caller checks there are at least threshold.
+func interpolateG1(shares map[common.Address]types.G1Point, threshold int) (*types.G1Point, error) {
 	// Collect all participant addresses and sort by bytes for deterministic selection
-	participants := make([]common.Address, 0, len(partialSigs))
-	for addr := range partialSigs {
+	participants := make([]common.Address, 0, len(shares))
+	for addr := range shares {
 		participants = append(participants, addr)
 	}
 
@@ -250,24 +291,15 @@ func RecoverAppPrivateKey(appID string, partialSigs map[common.Address]types.G1P
 
 	for _, addr := range participants {
 		lambda := ComputeLagrangeCoefficient(addr, participants)
-		scaledSig, err := ScalarMulG1(partialSigs[addr], lambda)
+		scaled, err := ScalarMulG1(shares[addr], lambda)
 		if err != nil {
 			return nil, err
 		}
-		result, err = AddG1(*result, *scaledSig)
+		result, err = AddG1(*result, *scaled)
 		if err != nil {
 			return nil, err
 		}
 	}
-
-	// check if the result is still a zero point
-	isZero, err := result.IsZero()
-	if err != nil {
-		return nil, err
-	}
-	if isZero {
-		return nil, errors.New("recovered app private key is zero")
-	}
 	return result, nil
 }
 
@@ -457,6 +489,47 @@ func VerifyAppPrivateKey(appID string, appPrivKey types.G1Point, masterPubKey ty
 	return ok, nil
 }
 
+// ComputeOperatorPublicKeyShare evaluates the group commitment polynomial at an
+// operator's address, giving the public counterpart of that operator's key share:
+//
+//	PK_j = Σ_k groupCommitments[k] · x_j^k = s_j·G2
+//
+// groupCommitments[0] is the master public key. The result lets anyone check a single
+// operator's partial signature with VerifyPartialSignature, without interpolation.
+func ComputeOperatorPublicKeyShare(groupCommitments []types.G2Point, operator common.Address) (*types.G2Point, error) {
+	if len(groupCommitments) == 0 {
+		return nil, errors.New("no group commitments provided")
+	}
+	x := AddressToFr(operator)
+
+	// Horner's rule from the highest-degree coefficient down.
+	result := groupCommitments[len(groupCommitments)-1]
+	for k := len(groupCommitments) - 2; k >= 0; k-- {
+		scaled, err := ScalarMulG2(result, x)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scale commitment %d: %w", k, err)
+		}
+		sum, err := AddG2(*scaled, groupCommitments[k])
+		if err != nil {
+			return nil, fmt.Errorf("failed to add commitment %d: %w", k, err)
+		}
+		result = *sum
+	}
+	return &result, nil
+}
+
+// VerifyPartialSignature checks one operator's partial signature against its public key
+// share (see ComputeOperatorPublicKeyShare):
+//
+//	e(partialSig, G2_gen) == e(H(appID), PK_j)
+//
+// This is the same equation VerifyAppPrivateKey checks against the master public key,
+// applied to a single share, so a bad operator is identified with one pairing check
+// instead of by trial interpolation over subsets.
+func VerifyPartialSignature(appID string, partialSig types.G1Point, publicKeyShare types.G2Point) (bool, error) {
+	return VerifyAppPrivateKey(appID, partialSig, publicKeyShare)
+}
+
 // ComputeMasterPublicKey computes the master public key from commitments
 func ComputeMasterPublicKey(allCommitments [][]types.G2Point) (*types.G2Point, error) {
 	masterPK := types.ZeroG2Point()
@@ -524,6 +597,10 @@ func ComputeAppPublicKeyFromMaster(appID string, masterPublicKey types.G2Point)
 //	[4:100]   C1 (compressed G2 point, 96 bytes)
 //	[100:112] nonce (12 bytes)
 //	[112:]    encrypted data + GCM tag
+//
+// The plaintext is sealed in one piece; NewEncryptWriter writes the chunked version 2
+// format instead, for payloads too large to hold in memory, and EncryptForAppPQ the
+// post-quantum hybrid version 3.
 func EncryptForApp(appID string, masterPublicKey types.G2Point, plaintext []byte) ([]byte, error) {
 
 	// Validate appID
@@ -531,48 +608,103 @@ func EncryptForApp(appID string, masterPublicKey types.G2Point, plaintext []byte
 		return nil, fmt.Errorf("invalid app ID for encryption: %w", err)
 	}
 
+	// Steps 1-4: C1 = r*P and g_ID = e(Q_ID, masterPublicKey)^r
+	c1, gIDBytes, err := ibeEncapsulate(appID, masterPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// Step 5: Derive symmetric key from g_ID using HKDF
+	// HKDF provides better security properties than raw hashing:
+	// - Salt ensures different keys even if g_ID repeats across systems
+	// - Info binds the key to its specific purpose, version, and application
+	keyMaterial, err := deriveKeyMaterial(gIDBytes, nil, ibeVersion, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newIBEAEAD(keyMaterial)
+	if err != nil {
+		return nil, err
+	}
+
+	// Generate random nonce
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	// Prepare additional authenticated data (AAD)
+	// This cryptographically binds the appID, version, and C1 to the ciphertext
+	aad := buildAAD(appID, ibeVersion, c1.CompressedBytes)
+
+	// Encrypt plaintext with AAD
+	encryptedData := gcm.Seal(nil, nonce, plaintext, aad)
+
+	// Build final ciphertext with version header
+	// Format: magic(3) || version(1) || C1(96) || nonce(12) || encrypted_data
+	// This allows format detection and future upgrades
+	totalLen := headerSize + len(c1.CompressedBytes) + len(nonce) + len(encryptedData)
+	finalCiphertext := make([]byte, 0, totalLen)
+
+	// Append header
+	finalCiphertext = append(finalCiphertext, ibeMagic...)
+	finalCiphertext = append(finalCiphertext, ibeVersion)
+
+	// Append ciphertext components
+	finalCiphertext = append(finalCiphertext, c1.CompressedBytes...)
+	finalCiphertext = append(finalCiphertext, nonce...)
+	finalCiphertext = append(finalCiphertext, encryptedData...)
+
+	return finalCiphertext, nil
+}
+
+// ibeEncapsulate chooses a fresh r and returns C1 = r*P together with the key seed
+// g_ID = e(Q_ID, masterPublicKey)^r, serialized, for the key derivation of either
+// ciphertext version.
+func ibeEncapsulate(appID string, masterPublicKey types.G2Point) (*types.G2Point, []byte, error) {
 	// Step 1: Compute QiD = H_1(app_id) ∈ G1
 	QiD, err := HashToG1(appID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to hash app ID: %w", err)
+		return nil, nil, fmt.Errorf("failed to hash app ID: %w", err)
 	}
 
 	// Convert Q_ID to G1Affine for pairing
 	QiDAffine, err := bls.G1PointFromCompressedBytes(QiD.CompressedBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert Q_ID to G1Affine: %w", err)
+		return nil, nil, fmt.Errorf("failed to convert Q_ID to G1Affine: %w", err)
 	}
 
 	// Convert masterPublicKey to G2Affine for pairing
 	masterPKAffine, err := bls.G2PointFromCompressedBytes(masterPublicKey.CompressedBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert master public key to G2Affine: %w", err)
+		return nil, nil, fmt.Errorf("failed to convert master public key to G2Affine: %w", err)
 	}
 
 	// Validate master public key is not zero/infinity point
 	if masterPKAffine.IsZero() {
-		return nil, errors.New("invalid master public key: zero/infinity point")
+		return nil, nil, errors.New("invalid master public key: zero/infinity point")
 	}
 
 	// Step 2: Choose random r ∈ Fr
 	r, err := new(fr.Element).SetRandom()
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate random r: %w", err)
+		return nil, nil, fmt.Errorf("failed to generate random r: %w", err)
 	}
 
 	// Step 3: Compute C1 = r*P where P is G2 generator
 	c1, err := ScalarMulG2(G2Generator, r)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compute C1: %w", err)
+		return nil, nil, fmt.Errorf("failed to compute C1: %w", err)
 	}
 
 	// Safety check: Ensure C1 is not infinity (should never happen with valid r)
 	c1Check, err := bls.G2PointFromCompressedBytes(c1.CompressedBytes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to validate C1: %w", err)
+		return nil, nil, fmt.Errorf("failed to validate C1: %w", err)
 	}
 	if c1Check.IsZero() {
-		return nil, errors.New("internal error: C1 is infinity point")
+		return nil, nil, errors.New("internal error: C1 is infinity point")
 	}
 
 	// Step 4: Compute g_ID = e(Q_ID, masterPublicKey)^r
@@ -582,14 +714,14 @@ func EncryptForApp(appID string, masterPublicKey types.G2Point, plaintext []byte
 		[]bls12381.G2Affine{*masterPKAffine.ToAffine()},
 	)
 	if err != nil {
-		return nil, fmt.Errorf("failed to compute pairing: %w", err)
+		return nil, nil, fmt.Errorf("failed to compute pairing: %w", err)
 	}
 
 	// CRITICAL: Validate pairing result is not identity element
 	// If e(Q_ID, masterPublicKey) = 1_GT, then g_ID = 1^r = 1 for any r
 	// This would make all encryptions use the same predictable key!
 	if pairingResult.IsOne() {
-		return nil, errors.New("invalid pairing result: identity element (possible invalid master public key)")
+		return nil, nil, errors.New("invalid pairing result: identity element (possible invalid master public key)")
 	}
 
 	// Then raise to the power r: g_ID = pairing^r
@@ -602,74 +734,43 @@ func EncryptForApp(appID string, masterPublicKey types.G2Point, plaintext []byte
 	// This is a defensive programming check - the probability is astronomically low (~1/2^255)
 	// since it requires r = 0 mod order(GT).
 	if gID.IsOne() {
-		return nil, errors.New("invalid g_ID: identity element")
+		return nil, nil, errors.New("invalid g_ID: identity element")
 	}
 
-	// Step 5: Derive symmetric key from g_ID using HKDF
-	// HKDF provides better security properties than raw hashing:
-	// - Salt ensures different keys even if g_ID repeats across systems
-	// - Info binds the key to its specific purpose, version, and application
 	gIDBytes := gID.Bytes()
-	keyMaterial, err := deriveKeyMaterial(gIDBytes[:], ibeVersion, appID)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create AES cipher with derived key
-	block, err := aes.NewCipher(keyMaterial)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
-	}
-
-	// Create GCM mode
-	gcm, err := cipher.NewGCMWithNonceSize(block, nonceSize)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create GCM: %w", err)
-	}
-
-	// Generate random nonce
-	nonce := make([]byte, nonceSize)
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return nil, fmt.Errorf("failed to generate nonce: %w", err)
-	}
-
-	// Prepare additional authenticated data (AAD)
-	// This cryptographically binds the appID, version, and C1 to the ciphertext
-	aad := buildAAD(appID, ibeVersion, c1.CompressedBytes)
-
-	// Encrypt plaintext with AAD
-	encryptedData := gcm.Seal(nil, nonce, plaintext, aad)
-
-	// Build final ciphertext with version header
-	// Format: magic(3) || version(1) || C1(96) || nonce(12) || encrypted_data
-	// This allows format detection and future upgrades
-	totalLen := headerSize + len(c1.CompressedBytes) + len(nonce) + len(encryptedData)
-	finalCiphertext := make([]byte, 0, totalLen)
-
-	// Append header
-	finalCiphertext = append(finalCiphertext, ibeMagic...)
-	finalCiphertext = append(finalCiphertext, ibeVersion)
-
-	// Append ciphertext components
-	finalCiphertext = append(finalCiphertext, c1.CompressedBytes...)
-	finalCiphertext = append(finalCiphertext, nonce...)
-	finalCiphertext = append(finalCiphertext, encryptedData...)
-
-	return finalCiphertext, nil
+	return c1, gIDBytes[:], nil
 }
 
 // ValidateCiphertextFormat checks that the ciphertext has a valid IBE format
 // (magic number, version, minimum length) without attempting decryption.
 // Use this to fail fast on malformed input before attempting key recovery retries.
+// A generation header (see WithGeneration) is skipped.
 func ValidateCiphertextFormat(ciphertext []byte) error {
-	if len(ciphertext) < minCiphertextSize {
+	return validateIBE(stripGeneration(ciphertext))
+}
+
+// validateIBE implements ValidateCiphertextFormat for a ciphertext without a generation
+// header.
+func validateIBE(ciphertext []byte) error {
+	// A chunked ciphertext of nothing is the shortest of either version
+	if len(ciphertext) < minStreamCiphertextSize {
 		return errors.New("ciphertext too short")
 	}
 	if !bytes.Equal(ciphertext[:magicSize], []byte(ibeMagic)) {
 		return errors.New("invalid ciphertext format: missing or incorrect magic number")
 	}
-	version := ciphertext[magicSize]
-	if version != ibeVersion {
+	switch version := ciphertext[magicSize]; version {
+	case ibeVersion:
+		if len(ciphertext) < minCiphertextSize {
+			return errors.New("ciphertext too short")
+		}
+	case ibeStreamVersion:
+		if _, err := parseChunkSize(ciphertext[headerSize+g2Size:]); err != nil {
+			return err
+		}
+	case ibePQVersion:
+		return validatePQHeader(ciphertext)
+	default:
 		return fmt.Errorf("unsupported ciphertext version: %d", version)
 	}
 	return nil
@@ -687,28 +788,94 @@ func ValidateCiphertextFormat(ciphertext []byte) error {
 //   - Derives AES key from g_ID using HKDF with version-aware domain separation
 //   - Decrypts with AES-GCM and verifies authentication using AAD
 //
-// Expected ciphertext format matches EncryptForApp output.
+// Expected ciphertext format matches EncryptForApp output. A chunked (version 2)
+// ciphertext written by NewEncryptWriter is decrypted too, in memory; stream large ones
+// through NewDecryptReader instead. A post-quantum (version 3) ciphertext needs
+// DecryptForAppPQ.
 func DecryptForApp(appID string, appPrivateKey types.G1Point, ciphertext []byte) ([]byte, error) {
+	return decryptIBE(appID, ciphertext, appKeySeed(appPrivateKey))
+}
+
+// keySeedFunc returns the key seed g_ID of the ciphertext with the given C1.
+type keySeedFunc func(c1Bytes []byte) ([]byte, error)
 
+// appKeySeed computes key seeds with the application private key.
+func appKeySeed(appPrivateKey types.G1Point) keySeedFunc {
+	return func(c1Bytes []byte) ([]byte, error) {
+		return ibeDecapsulate(appPrivateKey, c1Bytes)
+	}
+}
+
+// decryptIBE decrypts a ciphertext of either version, taking its key seed from keySeed.
+func decryptIBE(appID string, ciphertext []byte, keySeed keySeedFunc) ([]byte, error) {
 	// Validate appID
 	if err := util.ValidateAppID(appID); err != nil {
 		return nil, err
 	}
 
 	// Validate ciphertext format
-	if err := ValidateCiphertextFormat(ciphertext); err != nil {
+	ciphertext = stripGeneration(ciphertext)
+	if err := validateIBE(ciphertext); err != nil {
 		return nil, err
 	}
 
 	// Extract version for downstream use
 	version := ciphertext[magicSize]
+	if version == ibeStreamVersion {
+		return decryptChunked(appID, ciphertext, keySeed)
+	}
+	if version == ibePQVersion {
+		return nil, errors.New("version 3 ciphertext needs PQ decapsulation keys (see DecryptForAppPQ)")
+	}
 
 	// Extract C1 from ciphertext (after header)
 	c1Start := headerSize
 	c1End := c1Start + g2Size
 	c1Bytes := ciphertext[c1Start:c1End]
-	c1 := &types.G2Point{CompressedBytes: c1Bytes}
 
+	// Compute g_ID = e(appPrivateKey, C1)
+	gIDBytes, err := keySeed(c1Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Derive symmetric key from g_ID using HKDF (must match encryption exactly)
+	// Uses same salt and info structure to ensure decryption works
+	// The version from the ciphertext is used to ensure proper version-aware decryption
+	keyMaterial, err := deriveKeyMaterial(gIDBytes, nil, version, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newIBEAEAD(keyMaterial)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract nonce and encrypted data
+	nonceStart := headerSize + g2Size
+	nonceEnd := nonceStart + nonceSize
+	nonce := ciphertext[nonceStart:nonceEnd]
+	encryptedData := ciphertext[nonceEnd:]
+
+	// Reconstruct additional authenticated data (AAD)
+	// Must match exactly what was used during encryption
+	aad := buildAAD(appID, version, c1Bytes)
+
+	// Decrypt with AES-GCM using AAD
+	plaintext, err := gcm.Open(nil, nonce, encryptedData, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+
+	return plaintext, nil
+
+}
+
+// ibeDecapsulate recovers the key seed g_ID = e(appPrivateKey, C1) from the C1 of a
+// ciphertext. Since appPrivateKey = [s]Q_ID and C1 = [r]P, this equals the
+// e(Q_ID, masterPublicKey)^r that ibeEncapsulate returned.
+func ibeDecapsulate(appPrivateKey types.G1Point, c1Bytes []byte) ([]byte, error) {
 	// Convert appPrivateKey to G1Affine for pairing
 	appPrivKeyAffine, err := bls.G1PointFromCompressedBytes(appPrivateKey.CompressedBytes)
 	if err != nil {
@@ -721,7 +888,7 @@ func DecryptForApp(appID string, appPrivateKey types.G1Point, ciphertext []byte)
 	}
 
 	// Convert C1 to G2Affine for pairing
-	c1Affine, err := bls.G2PointFromCompressedBytes(c1.CompressedBytes)
+	c1Affine, err := bls.G2PointFromCompressedBytes(c1Bytes)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert C1 to G2Affine: %w", err)
 	}
@@ -750,15 +917,12 @@ func DecryptForApp(appID string, appPrivateKey types.G1Point, ciphertext []byte)
 		return nil, errors.New("invalid pairing result: identity element")
 	}
 
-	// Derive symmetric key from g_ID using HKDF (must match encryption exactly)
-	// Uses same salt and info structure to ensure decryption works
-	// The version from the ciphertext is used to ensure proper version-aware decryption
 	gIDBytes := gID.Bytes()
-	keyMaterial, err := deriveKeyMaterial(gIDBytes[:], version, appID)
-	if err != nil {
-		return nil, err
-	}
+	return gIDBytes[:], nil
+}
 
+// newIBEAEAD returns the AES-256-GCM instance both ciphertext versions seal with.
+func newIBEAEAD(keyMaterial []byte) (cipher.AEAD, error) {
 	// Create AES cipher with derived key
 	block, err := aes.NewCipher(keyMaterial)
 	if err != nil {
@@ -770,25 +934,7 @@ func DecryptForApp(appID string, appPrivateKey types.G1Point, ciphertext []byte)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create GCM: %w", err)
 	}
-
-	// Extract nonce and encrypted data
-	nonceStart := headerSize + g2Size
-	nonceEnd := nonceStart + nonceSize
-	nonce := ciphertext[nonceStart:nonceEnd]
-	encryptedData := ciphertext[nonceEnd:]
-
-	// Reconstruct additional authenticated data (AAD)
-	// Must match exactly what was used during encryption
-	aad := buildAAD(appID, version, c1Bytes)
-
-	// Decrypt with AES-GCM using AAD
-	plaintext, err := gcm.Open(nil, nonce, encryptedData, aad)
-	if err != nil {
-		return nil, fmt.Errorf("failed to decrypt: %w", err)
-	}
-
-	return plaintext, nil
-
+	return gcm, nil
 }
 
 // buildAAD constructs the Additional Authenticated Data for AES-GCM
@@ -803,13 +949,15 @@ func buildAAD(appID string, version byte, c1Bytes []byte) []byte {
 	return aad
 }
 
-// deriveKeyMaterial uses HKDF to derive AES-256 key material from g_ID
+// deriveKeyMaterial uses HKDF to derive AES-256 key material from g_ID and, for
+// version 3, the PQ secret K (nil otherwise), so the key stays secret while either does.
 // The key is bound to the version and appID through the HKDF info parameter
-func deriveKeyMaterial(gIDBytes []byte, version byte, appID string) ([]byte, error) {
+func deriveKeyMaterial(gIDBytes, pqSecret []byte, version byte, appID string) ([]byte, error) {
 	salt := []byte(hkdfSalt)
 	info := fmt.Appendf(nil, "IBE-encryption|v%d|%s", version, appID)
 
-	hkdfReader := hkdf.New(sha256.New, gIDBytes, salt, info)
+	ikm := append(bytes.Clone(gIDBytes), pqSecret...)
+	hkdfReader := hkdf.New(sha256.New, ikm, salt, info)
 
 	// Derive 32 bytes for AES-256
 	keyMaterial := make([]byte, 32)
@@ -831,9 +979,9 @@ func HashAcknowledgementForMerkle(ack *types.Acknowledgement) [32]byte {
 	data = append(data, ack.PlayerAddress.Bytes()...)
 	data = append(data, ack.DealerAddress.Bytes()...)
 
-	// Encode session timestamp as uint64 (8 bytes, big endian) to match Solidity's abi.encodePacked(uint64)
+	// Encode the key's registry epoch as uint64 (8 bytes, big endian) to match Solidity's abi.encodePacked(uint64)
 	epochBytes := make([]byte, 8)
-	binary.BigEndian.PutUint64(epochBytes, uint64(ack.SessionTimestamp))
+	binary.BigEndian.PutUint64(epochBytes, uint64(types.CommitmentEpoch(ack.KeyID, ack.SessionTimestamp)))
 	data = append(data, epochBytes...)
 
 	data = append(data, ack.ShareHash[:]...)
@@ -842,15 +990,18 @@ func HashAcknowledgementForMerkle(ack *types.Acknowledgement) [32]byte {
 	return keccak256Hash(data)
 }
 
-// CreateAcknowledgement creates an acknowledgement for a received share during DKG or reshare.
-func CreateAcknowledgement(playerAddress, dealerAddress common.Address, epoch int64, share *fr.Element, commitments []types.G2Point, signer func(common.Address, common.Address, int64, [32]byte, [32]byte) []byte) *types.Acknowledgement {
+// CreateAcknowledgement creates an acknowledgement for a received share during DKG or reshare
+// of keyID. The signer is handed the key's registry epoch (types.CommitmentEpoch), so the
+// signature cannot be replayed into another key's session.
+func CreateAcknowledgement(playerAddress, dealerAddress common.Address, keyID string, epoch int64, share *fr.Element, commitments []types.G2Point, signer func(common.Address, common.Address, int64, [32]byte, [32]byte) []byte) *types.Acknowledgement {
 	commitmentHash := HashCommitment(commitments)
 	shareHash := HashShareForAck(share)
-	signature := signer(dealerAddress, playerAddress, epoch, shareHash, commitmentHash)
+	signature := signer(dealerAddress, playerAddress, types.CommitmentEpoch(keyID, epoch), shareHash, commitmentHash)
 
 	return &types.Acknowledgement{
 		DealerAddress:    dealerAddress,
 		PlayerAddress:    playerAddress,
+		KeyID:            keyID,
 		SessionTimestamp: epoch,
 		ShareHash:        shareHash,
 		CommitmentHash:   commitmentHash,
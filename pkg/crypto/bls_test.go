@@ -23,6 +23,7 @@ func Test_CryptoOperations(t *testing.T) {
 	t.Run("ComputeLagrangeCoefficient", func(t *testing.T) { testComputeLagrangeCoefficient(t) })
 	t.Run("RecoverSecret", func(t *testing.T) { testRecoverSecret(t) })
 	t.Run("HashCommitment", func(t *testing.T) { testHashCommitment(t) })
+	t.Run("EncodeG2ForPrecompile", func(t *testing.T) { testEncodeG2ForPrecompile(t) })
 	t.Run("RecoverAppPrivateKey", func(t *testing.T) { testRecoverAppPrivateKey(t) })
 	t.Run("ComputeMasterPublicKey", func(t *testing.T) { testComputeMasterPublicKey(t) })
 	t.Run("RecoverAppPrivateKeyWithRetry_AllValid", func(t *testing.T) { testRecoverAppPrivateKeyWithRetry_AllValid(t) })
@@ -31,6 +32,7 @@ func Test_CryptoOperations(t *testing.T) {
 	t.Run("RecoverAppPrivateKeyWithRetry_TooManyCorrupt", func(t *testing.T) { testRecoverAppPrivateKeyWithRetry_TooManyCorrupt(t) })
 	t.Run("RecoverAppPrivateKeyWithRetry_CapReached", func(t *testing.T) { testRecoverAppPrivateKeyWithRetry_CapReached(t) })
 	t.Run("VerifyAppPrivateKey", func(t *testing.T) { testVerifyAppPrivateKey(t) })
+	t.Run("VerifyPartialSignature", func(t *testing.T) { testVerifyPartialSignature(t) })
 	t.Run("DecryptWithRetry_E2E", func(t *testing.T) { testDecryptWithRetry_E2E(t) })
 	t.Run("ValidateCiphertextFormat", func(t *testing.T) { testValidateCiphertextFormat(t) })
 }
@@ -99,6 +101,24 @@ func testScalarMulG2(t *testing.T) {
 	}
 }
 
+// testEncodeG2ForPrecompile checks the generator against its EIP-2537 encoding
+func testEncodeG2ForPrecompile(t *testing.T) {
+	encoded, err := EncodeG2ForPrecompile(G2Generator)
+	require.NoError(t, err)
+	want := "" +
+		"00000000000000000000000000000000024aa2b2f08f0a91260805272dc51051c6e47ad4fa403b02b4510b647ae3d1770bac0326a805bbefd48056c8c121bdb8" +
+		"0000000000000000000000000000000013e02b6052719f607dacd3a088274f65596bd0d09920b61ab5da61bbdc7f5049334cf11213945d57e5ac7d055d042b7e" +
+		"000000000000000000000000000000000ce5d527727d6e118cc9cdc6da2e351aadfd9baa8cbdd3a76d429a695160d12c923ac9cc3baca289e193548608b82801" +
+		"000000000000000000000000000000000606c4a02ea734cc32acd2b02bc28b99cb3e287e85a763af267492ab572e99ab3f370d275cec1da1aaa9075ff05f79be"
+	require.Equal(t, want, common.Bytes2Hex(encoded))
+
+	zero, err := ScalarMulG2(G2Generator, new(fr.Element))
+	require.NoError(t, err)
+	encoded, err = EncodeG2ForPrecompile(*zero)
+	require.NoError(t, err)
+	require.Equal(t, make([]byte, 256), encoded, "the point at infinity encodes as zeros")
+}
+
 // testAddG1 tests point addition on G1
 func testAddG1(t *testing.T) {
 	// Create two points
@@ -673,3 +693,59 @@ func testValidateCiphertextFormat(t *testing.T) {
 		})
 	}
 }
+
+func testVerifyPartialSignature(t *testing.T) {
+	appID := "test-verify-partial"
+	threshold := 3
+
+	poly := make(polynomial.Polynomial, threshold)
+	poly[0].SetInt64(42)
+	for i := 1; i < threshold; i++ {
+		_, _ = poly[i].SetRandom()
+	}
+	groupCommitments := make([]types.G2Point, threshold)
+	for k := range poly {
+		c, err := ScalarMulG2(G2Generator, &poly[k])
+		require.NoError(t, err)
+		groupCommitments[k] = *c
+	}
+
+	msgPoint, err := HashToG1(appID)
+	require.NoError(t, err)
+
+	for i := 1; i <= 5; i++ {
+		addr := common.HexToAddress("0x" + padHex(i))
+		share := EvaluatePolynomial(poly, addr)
+
+		pkShare, err := ComputeOperatorPublicKeyShare(groupCommitments, addr)
+		require.NoError(t, err)
+		expectedPK, err := ScalarMulG2(G2Generator, share)
+		require.NoError(t, err)
+		require.True(t, pkShare.IsEqual(expectedPK), "public key share must equal s_j*G2")
+
+		sig, err := ScalarMulG1(*msgPoint, share)
+		require.NoError(t, err)
+		valid, err := VerifyPartialSignature(appID, *sig, *pkShare)
+		require.NoError(t, err)
+		require.True(t, valid)
+
+		// Another app's signature, or a signature from a different share, must fail.
+		otherMsg, err := HashToG1("other-app")
+		require.NoError(t, err)
+		otherSig, err := ScalarMulG1(*otherMsg, share)
+		require.NoError(t, err)
+		valid, err = VerifyPartialSignature(appID, *otherSig, *pkShare)
+		require.NoError(t, err)
+		require.False(t, valid)
+
+		wrongShare := new(fr.Element).Add(share, new(fr.Element).SetOne())
+		wrongSig, err := ScalarMulG1(*msgPoint, wrongShare)
+		require.NoError(t, err)
+		valid, err = VerifyPartialSignature(appID, *wrongSig, *pkShare)
+		require.NoError(t, err)
+		require.False(t, valid)
+	}
+
+	_, err = ComputeOperatorPublicKeyShare(nil, common.HexToAddress("0x1"))
+	require.Error(t, err)
+}
@@ -0,0 +1,338 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/util"
+	bls12381 "github.com/consensys/gnark-crypto/ecc/bls12-381"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Threshold decryption of a single IBE ciphertext. Operator i, holding key share s_i,
+// returns the decryption share
+//
+//	D_i = e(partial_sig_i, C1) = e(Q_ID, C1)^{s_i}
+//
+// and the shares of a threshold of operators combine, by Lagrange interpolation in the
+// exponent, to the ciphertext's key seed g_ID = e(Q_ID, C1)^s. The client decrypts the
+// one ciphertext whose C1 it presented; the app private key is never reconstructed.
+//
+// Each share comes with a Chaum-Pedersen proof that log_{G2}(PK_i) = log_h(D_i) for
+// h = e(Q_ID, C1), with PK_i = s_i·G2 the operator's public key share: a challenge c
+// and a response z = k + c·s_i for a random k, where c hashes the statement and the
+// commitments A = k·G2 and B = h^k. A wrong share is therefore identified, and left
+// out, before combination instead of corrupting the key seed.
+
+const (
+	// gtSize is the size of a serialized GT element.
+	gtSize = bls12381.SizeOfGT
+
+	// decryptionShareProofSize is the size of a proof: challenge || response.
+	decryptionShareProofSize = 2 * fr.Bytes
+
+	// decryptionShareDST separates the proof challenge from other hashes to Fr.
+	decryptionShareDST = "EIGENX-KMS-DECRYPTION-SHARE-PROOF-V1"
+
+	// ciphertextBindingDST separates the ciphertext binding from other hashes.
+	ciphertextBindingDST = "EIGENX-KMS-CIPHERTEXT-BINDING-V1"
+)
+
+// ComputeDecryptionShare computes an operator's decryption share of the ciphertext with
+// the given C1, and its proof, from the operator's key share.
+func ComputeDecryptionShare(appID string, keyShare *fr.Element, c1 types.G2Point) (*types.DecryptionShare, error) {
+	if err := util.ValidateAppID(appID); err != nil {
+		return nil, err
+	}
+	if keyShare == nil || keyShare.IsZero() {
+		return nil, errors.New("invalid key share")
+	}
+	c1Affine, err := ciphertextC1Affine(c1.CompressedBytes)
+	if err != nil {
+		return nil, err
+	}
+	qID, err := HashToG1(appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash app ID: %w", err)
+	}
+
+	// D_i = e(s_i·Q_ID, C1) and B = e(k·Q_ID, C1): exponentiating in G1 keeps the secret
+	// scalars out of GT exponentiations
+	share, err := pairWithC1(*qID, keyShare, c1Affine)
+	if err != nil {
+		return nil, err
+	}
+	k, err := new(fr.Element).SetRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate proof nonce: %w", err)
+	}
+	commitB, err := pairWithC1(*qID, k, c1Affine)
+	if err != nil {
+		return nil, err
+	}
+	commitA, err := ScalarMulG2(G2Generator, k)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute proof commitment: %w", err)
+	}
+	publicKeyShare, err := ScalarMulG2(G2Generator, keyShare)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute public key share: %w", err)
+	}
+
+	shareBytes := share.Bytes()
+	c, err := decryptionShareChallenge(appID, c1.CompressedBytes, publicKeyShare.CompressedBytes, shareBytes[:], commitA.CompressedBytes, commitB)
+	if err != nil {
+		return nil, err
+	}
+	var z fr.Element
+	z.Mul(c, keyShare).Add(&z, k)
+
+	cBytes, zBytes := c.Bytes(), z.Bytes()
+	proof := make([]byte, 0, decryptionShareProofSize)
+	proof = append(proof, cBytes[:]...)
+	proof = append(proof, zBytes[:]...)
+	return &types.DecryptionShare{Share: shareBytes[:], Proof: proof}, nil
+}
+
+// VerifyDecryptionShare checks an operator's decryption share of the ciphertext with the
+// given C1 against the operator's public key share (see ComputeOperatorPublicKeyShare).
+func VerifyDecryptionShare(appID string, c1 types.G2Point, share types.DecryptionShare, publicKeyShare types.G2Point) (bool, error) {
+	if err := util.ValidateAppID(appID); err != nil {
+		return false, err
+	}
+	d, err := parseDecryptionShare(share.Share)
+	if err != nil {
+		return false, err
+	}
+	if len(share.Proof) != decryptionShareProofSize {
+		return false, fmt.Errorf("invalid decryption share proof size: %d", len(share.Proof))
+	}
+	var c, z fr.Element
+	if err := c.SetBytesCanonical(share.Proof[:fr.Bytes]); err != nil {
+		return false, fmt.Errorf("invalid decryption share proof: %w", err)
+	}
+	if err := z.SetBytesCanonical(share.Proof[fr.Bytes:]); err != nil {
+		return false, fmt.Errorf("invalid decryption share proof: %w", err)
+	}
+
+	c1Affine, err := ciphertextC1Affine(c1.CompressedBytes)
+	if err != nil {
+		return false, err
+	}
+	qID, err := HashToG1(appID)
+	if err != nil {
+		return false, fmt.Errorf("failed to hash app ID: %w", err)
+	}
+	h, err := pairWithC1(*qID, new(fr.Element).SetOne(), c1Affine)
+	if err != nil {
+		return false, err
+	}
+
+	// A = z·G2 - c·PK_i
+	zG2, err := ScalarMulG2(G2Generator, &z)
+	if err != nil {
+		return false, err
+	}
+	var negC fr.Element
+	negC.Neg(&c)
+	cPK, err := ScalarMulG2(publicKeyShare, &negC)
+	if err != nil {
+		return false, fmt.Errorf("invalid public key share: %w", err)
+	}
+	commitA, err := AddG2(*zG2, *cPK)
+	if err != nil {
+		return false, err
+	}
+
+	// B = h^z · D_i^{-c}
+	var zInt, negCInt big.Int
+	z.BigInt(&zInt)
+	negC.BigInt(&negCInt)
+	var commitB, dNegC bls12381.GT
+	commitB.Exp(h, &zInt)
+	dNegC.Exp(d, &negCInt)
+	commitB.Mul(&commitB, &dNegC)
+
+	shareBytes := d.Bytes()
+	expected, err := decryptionShareChallenge(appID, c1.CompressedBytes, publicKeyShare.CompressedBytes, shareBytes[:], commitA.CompressedBytes, commitB)
+	if err != nil {
+		return false, err
+	}
+	return expected.Equal(&c), nil
+}
+
+// CombineDecryptionShares interpolates the key seed of the ciphertext with the given C1
+// from the decryption shares of at least threshold operators, keyed by address. The
+// shares must each have passed VerifyDecryptionShare; like RecoverAppPrivateKey, the
+// first threshold of them by address are used.
+func CombineDecryptionShares(c1 types.G2Point, shares map[common.Address]types.DecryptionShare, threshold int) (*CiphertextKey, error) {
+	if _, err := ciphertextC1Affine(c1.CompressedBytes); err != nil {
+		return nil, err
+	}
+	if len(shares) < threshold {
+		return nil, fmt.Errorf("insufficient decryption shares: got %d, need %d", len(shares), threshold)
+	}
+
+	participants := make([]common.Address, 0, len(shares))
+	for addr := range shares {
+		participants = append(participants, addr)
+	}
+	sort.Slice(participants, func(i, j int) bool {
+		return bytes.Compare(participants[i].Bytes(), participants[j].Bytes()) < 0
+	})
+	if len(participants) > threshold {
+		participants = participants[:threshold]
+	}
+
+	var gID bls12381.GT
+	gID.SetOne()
+	for _, addr := range participants {
+		d, err := parseDecryptionShare(shares[addr].Share)
+		if err != nil {
+			return nil, fmt.Errorf("decryption share of %s: %w", addr.Hex(), err)
+		}
+		var lambda big.Int
+		ComputeLagrangeCoefficient(addr, participants).BigInt(&lambda)
+		var term bls12381.GT
+		term.Exp(d, &lambda)
+		gID.Mul(&gID, &term)
+	}
+	if gID.IsOne() {
+		return nil, errors.New("combined key seed is the identity element")
+	}
+
+	gIDBytes := gID.Bytes()
+	return &CiphertextKey{
+		c1:      bytes.Clone(c1.CompressedBytes),
+		keySeed: gIDBytes[:],
+	}, nil
+}
+
+// CiphertextKey is the key seed of one IBE ciphertext, combined from decryption shares.
+// It decrypts that ciphertext, or any other with the same C1, and nothing else.
+type CiphertextKey struct {
+	c1      []byte
+	keySeed []byte
+}
+
+// Decrypt decrypts a ciphertext of either version, like DecryptForApp.
+func (k *CiphertextKey) Decrypt(appID string, ciphertext []byte) ([]byte, error) {
+	return decryptIBE(appID, ciphertext, k.seedFor)
+}
+
+// NewDecryptReader returns a reader of the plaintext of a chunked ciphertext read from
+// r, like the package-level NewDecryptReader.
+func (k *CiphertextKey) NewDecryptReader(r io.Reader, appID string) (io.Reader, error) {
+	return newDecryptReader(r, appID, k.seedFor)
+}
+
+func (k *CiphertextKey) seedFor(c1Bytes []byte) ([]byte, error) {
+	if !bytes.Equal(c1Bytes, k.c1) {
+		return nil, errors.New("ciphertext C1 does not match the decryption shares")
+	}
+	return k.keySeed, nil
+}
+
+// CiphertextC1 returns the C1 of an IBE ciphertext of either version, the point
+// operators compute decryption shares of.
+func CiphertextC1(ciphertext []byte) (*types.G2Point, error) {
+	ciphertext = stripGeneration(ciphertext)
+	if err := validateIBE(ciphertext); err != nil {
+		return nil, err
+	}
+	c1Bytes := bytes.Clone(ciphertext[headerSize : headerSize+g2Size])
+	if _, err := ciphertextC1Affine(c1Bytes); err != nil {
+		return nil, err
+	}
+	return &types.G2Point{CompressedBytes: c1Bytes}, nil
+}
+
+// CiphertextBinding returns the digest that ties a request for decryption shares to the
+// ciphertexts it presents. The request's extra_data must begin with the binding of its
+// C1s; the attestation covers extra_data, so a captured attestation cannot be replayed
+// to ask for shares of other ciphertexts.
+func CiphertextBinding(c1s []types.G2Point) []byte {
+	h := sha256.New()
+	h.Write([]byte(ciphertextBindingDST))
+	for _, c1 := range c1s {
+		h.Write(binary.BigEndian.AppendUint16(nil, uint16(len(c1.CompressedBytes))))
+		h.Write(c1.CompressedBytes)
+	}
+	return h.Sum(nil)
+}
+
+// ciphertextC1Affine parses a C1, rejecting the infinity point for the reason
+// ibeDecapsulate does.
+func ciphertextC1Affine(c1Bytes []byte) (*bls12381.G2Affine, error) {
+	c1, err := bls.G2PointFromCompressedBytes(c1Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid C1: %w", err)
+	}
+	if c1.IsZero() {
+		return nil, errors.New("invalid ciphertext: C1 is infinity point")
+	}
+	return c1.ToAffine(), nil
+}
+
+// pairWithC1 computes e(scalar·point, C1).
+func pairWithC1(point types.G1Point, scalar *fr.Element, c1 *bls12381.G2Affine) (bls12381.GT, error) {
+	scaled, err := ScalarMulG1(point, scalar)
+	if err != nil {
+		return bls12381.GT{}, err
+	}
+	affine, err := bls.G1PointFromCompressedBytes(scaled.CompressedBytes)
+	if err != nil {
+		return bls12381.GT{}, err
+	}
+	result, err := bls12381.Pair([]bls12381.G1Affine{*affine.ToAffine()}, []bls12381.G2Affine{*c1})
+	if err != nil {
+		return bls12381.GT{}, fmt.Errorf("failed to compute pairing: %w", err)
+	}
+	if result.IsOne() {
+		return bls12381.GT{}, errors.New("invalid pairing result: identity element")
+	}
+	return result, nil
+}
+
+// parseDecryptionShare parses a share as an element of GT other than the identity.
+func parseDecryptionShare(b []byte) (bls12381.GT, error) {
+	var d bls12381.GT
+	if len(b) != gtSize {
+		return d, fmt.Errorf("invalid decryption share size: %d", len(b))
+	}
+	if err := d.SetBytes(b); err != nil {
+		return d, fmt.Errorf("invalid decryption share: %w", err)
+	}
+	if !d.IsInSubGroup() || d.IsOne() {
+		return d, errors.New("invalid decryption share: not a GT element")
+	}
+	return d, nil
+}
+
+// decryptionShareChallenge hashes the statement and commitments of a proof to Fr.
+func decryptionShareChallenge(appID string, c1, publicKeyShare, share, commitA []byte, commitB bls12381.GT) (*fr.Element, error) {
+	commitBBytes := commitB.Bytes()
+	msg := make([]byte, 0, 2+len(appID)+2*g2Size+2*gtSize+g2Size)
+	msg = binary.BigEndian.AppendUint16(msg, uint16(len(appID)))
+	msg = append(msg, appID...)
+	msg = append(msg, c1...)
+	msg = append(msg, publicKeyShare...)
+	msg = append(msg, share...)
+	msg = append(msg, commitA...)
+	msg = append(msg, commitBBytes[:]...)
+
+	c, err := fr.Hash(msg, []byte(decryptionShareDST), 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash proof challenge: %w", err)
+	}
+	return &c[0], nil
+}
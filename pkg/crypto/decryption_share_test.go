@@ -0,0 +1,188 @@
+package crypto
+
+import (
+	"bytes"
+	"io"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_DecryptionShares(t *testing.T) {
+	appID := "test-app-threshold"
+	threshold := 3
+
+	poly, err := bls.GeneratePolynomial(new(fr.Element).SetUint64(987654321), threshold-1)
+	require.NoError(t, err)
+	operators := make([]common.Address, 5)
+	for i := range operators {
+		operators[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+	}
+	keyShares := bls.GenerateShares(poly, operators)
+	commitments, err := bls.CreateCommitments(poly)
+	require.NoError(t, err)
+	groupCommitments := make([]types.G2Point, len(commitments))
+	for i, c := range commitments {
+		groupCommitments[i] = types.G2Point{CompressedBytes: c.Marshal()}
+	}
+	masterPubKey := groupCommitments[0]
+
+	publicKeyShare := func(t *testing.T, op common.Address) types.G2Point {
+		pk, err := ComputeOperatorPublicKeyShare(groupCommitments, op)
+		require.NoError(t, err)
+		return *pk
+	}
+	sharesFor := func(t *testing.T, c1 types.G2Point) map[common.Address]types.DecryptionShare {
+		shares := make(map[common.Address]types.DecryptionShare)
+		for _, op := range operators {
+			share, err := ComputeDecryptionShare(appID, keyShares[op], c1)
+			require.NoError(t, err)
+			ok, err := VerifyDecryptionShare(appID, c1, *share, publicKeyShare(t, op))
+			require.NoError(t, err)
+			require.True(t, ok, "share of %s", op.Hex())
+			shares[op] = *share
+		}
+		return shares
+	}
+
+	t.Run("combine and decrypt", func(t *testing.T) {
+		plaintext := []byte("database password")
+		ciphertext, err := EncryptForApp(appID, masterPubKey, plaintext)
+		require.NoError(t, err)
+		c1, err := CiphertextC1(ciphertext)
+		require.NoError(t, err)
+
+		shares := sharesFor(t, *c1)
+		key, err := CombineDecryptionShares(*c1, shares, threshold)
+		require.NoError(t, err)
+		decrypted, err := key.Decrypt(appID, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+
+		// Any threshold subset gives the same key seed
+		subset := map[common.Address]types.DecryptionShare{
+			operators[4]: shares[operators[4]],
+			operators[2]: shares[operators[2]],
+			operators[1]: shares[operators[1]],
+		}
+		key, err = CombineDecryptionShares(*c1, subset, threshold)
+		require.NoError(t, err)
+		decrypted, err = key.Decrypt(appID, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+
+		delete(subset, operators[1])
+		_, err = CombineDecryptionShares(*c1, subset, threshold)
+		require.ErrorContains(t, err, "insufficient decryption shares")
+	})
+
+	t.Run("chunked ciphertext", func(t *testing.T) {
+		plaintext := bytes.Repeat([]byte("weights"), 1000)
+		var buf bytes.Buffer
+		w, err := newEncryptWriter(&buf, appID, masterPubKey, 256)
+		require.NoError(t, err)
+		_, err = w.Write(plaintext)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		c1, err := CiphertextC1(buf.Bytes())
+		require.NoError(t, err)
+		key, err := CombineDecryptionShares(*c1, sharesFor(t, *c1), threshold)
+		require.NoError(t, err)
+
+		r, err := key.NewDecryptReader(bytes.NewReader(buf.Bytes()), appID)
+		require.NoError(t, err)
+		decrypted, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("key decrypts only its ciphertext", func(t *testing.T) {
+		ciphertext, err := EncryptForApp(appID, masterPubKey, []byte("one"))
+		require.NoError(t, err)
+		other, err := EncryptForApp(appID, masterPubKey, []byte("two"))
+		require.NoError(t, err)
+		c1, err := CiphertextC1(ciphertext)
+		require.NoError(t, err)
+		key, err := CombineDecryptionShares(*c1, sharesFor(t, *c1), threshold)
+		require.NoError(t, err)
+
+		_, err = key.Decrypt(appID, other)
+		require.ErrorContains(t, err, "does not match")
+		_, err = key.Decrypt("another-app", ciphertext)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid shares fail verification", func(t *testing.T) {
+		ciphertext, err := EncryptForApp(appID, masterPubKey, []byte("secret"))
+		require.NoError(t, err)
+		c1, err := CiphertextC1(ciphertext)
+		require.NoError(t, err)
+		op := operators[0]
+		share, err := ComputeDecryptionShare(appID, keyShares[op], *c1)
+		require.NoError(t, err)
+
+		// Another operator's public key share
+		ok, err := VerifyDecryptionShare(appID, *c1, *share, publicKeyShare(t, operators[1]))
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		// Another operator's share under this operator's proof
+		otherShare, err := ComputeDecryptionShare(appID, keyShares[operators[1]], *c1)
+		require.NoError(t, err)
+		ok, err = VerifyDecryptionShare(appID, *c1, types.DecryptionShare{Share: otherShare.Share, Proof: share.Proof}, publicKeyShare(t, op))
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		// A share computed with a wrong key share, with a valid proof for it
+		wrong, err := ComputeDecryptionShare(appID, new(fr.Element).SetUint64(7), *c1)
+		require.NoError(t, err)
+		ok, err = VerifyDecryptionShare(appID, *c1, *wrong, publicKeyShare(t, op))
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		// The share of another ciphertext, or of another app
+		otherCiphertext, err := EncryptForApp(appID, masterPubKey, []byte("secret"))
+		require.NoError(t, err)
+		otherC1, err := CiphertextC1(otherCiphertext)
+		require.NoError(t, err)
+		ok, err = VerifyDecryptionShare(appID, *otherC1, *share, publicKeyShare(t, op))
+		require.NoError(t, err)
+		require.False(t, ok)
+		ok, err = VerifyDecryptionShare("another-app", *c1, *share, publicKeyShare(t, op))
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		// Tampered proof
+		tampered := types.DecryptionShare{Share: share.Share, Proof: bytes.Clone(share.Proof)}
+		tampered.Proof[len(tampered.Proof)-1] ^= 0x01
+		ok, err = VerifyDecryptionShare(appID, *c1, tampered, publicKeyShare(t, op))
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		// Malformed shares and proofs are errors
+		_, err = VerifyDecryptionShare(appID, *c1, types.DecryptionShare{Share: share.Share[:10], Proof: share.Proof}, publicKeyShare(t, op))
+		require.ErrorContains(t, err, "invalid decryption share size")
+		_, err = VerifyDecryptionShare(appID, *c1, types.DecryptionShare{Share: make([]byte, gtSize), Proof: share.Proof}, publicKeyShare(t, op))
+		require.Error(t, err)
+		_, err = VerifyDecryptionShare(appID, *c1, types.DecryptionShare{Share: share.Share, Proof: share.Proof[:32]}, publicKeyShare(t, op))
+		require.ErrorContains(t, err, "invalid decryption share proof size")
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		_, err := ComputeDecryptionShare(appID, keyShares[operators[0]], types.G2Point{CompressedBytes: make([]byte, g2Size)})
+		require.Error(t, err)
+		_, err = ComputeDecryptionShare(appID, new(fr.Element), G2Generator)
+		require.ErrorContains(t, err, "invalid key share")
+		_, err = ComputeDecryptionShare("", keyShares[operators[0]], G2Generator)
+		require.Error(t, err)
+
+		_, err = CiphertextC1([]byte("IBE"))
+		require.ErrorContains(t, err, "too short")
+	})
+}
@@ -0,0 +1,79 @@
+package crypto
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// A master secret rotation replaces the master public key, so a ciphertext can only be
+// decrypted with the app private key of the generation it was encrypted to. The client
+// prefixes every ciphertext it writes with that generation:
+//
+//	[0:3] magic ("IBG")
+//	[3:7] generation (uint32, big-endian)
+//	[7:]  the IBE ciphertext, of any version
+//
+// The header is a routing hint and is not authenticated: a ciphertext whose header was
+// altered asks for another generation's key, which cannot open it. Every decryption
+// function in this package accepts ciphertexts with or without it.
+const (
+	generationMagic      = "IBG"
+	generationSize       = 4
+	generationHeaderSize = magicSize + generationSize
+)
+
+// WithGeneration prefixes ciphertext with the master secret generation it was
+// encrypted to.
+func WithGeneration(generation uint32, ciphertext []byte) []byte {
+	out := make([]byte, generationHeaderSize, generationHeaderSize+len(ciphertext))
+	copy(out, generationMagic)
+	binary.BigEndian.PutUint32(out[magicSize:], generation)
+	return append(out, ciphertext...)
+}
+
+// GenerationHeader returns the header WithGeneration prefixes a ciphertext with, for
+// writing ahead of a chunked ciphertext.
+func GenerationHeader(generation uint32) []byte {
+	return WithGeneration(generation, nil)
+}
+
+// CiphertextGeneration returns the master secret generation ciphertext was encrypted
+// to. ok is false for a ciphertext written without a generation header, by a client
+// that predates rotation.
+func CiphertextGeneration(ciphertext []byte) (generation uint32, ok bool) {
+	if len(ciphertext) < generationHeaderSize || !bytes.Equal(ciphertext[:magicSize], []byte(generationMagic)) {
+		return 0, false
+	}
+	return binary.BigEndian.Uint32(ciphertext[magicSize:generationHeaderSize]), true
+}
+
+// PeekCiphertextGeneration is CiphertextGeneration for a ciphertext read from r. It
+// only peeks, so the header is still read by NewDecryptReader.
+func PeekCiphertextGeneration(r *bufio.Reader) (generation uint32, ok bool, err error) {
+	header, err := r.Peek(generationHeaderSize)
+	if err != nil && len(header) < magicSize {
+		return 0, false, fmt.Errorf("failed to read ciphertext header: %w", err)
+	}
+	generation, ok = CiphertextGeneration(header)
+	return generation, ok, nil
+}
+
+// stripGeneration returns ciphertext without its generation header, if it has one.
+func stripGeneration(ciphertext []byte) []byte {
+	if _, ok := CiphertextGeneration(ciphertext); ok {
+		return ciphertext[generationHeaderSize:]
+	}
+	return ciphertext
+}
+
+// discardGeneration consumes the generation header at the head of r, if there is one.
+func discardGeneration(r *bufio.Reader) error {
+	header, _ := r.Peek(generationHeaderSize)
+	if _, ok := CiphertextGeneration(header); !ok {
+		return nil
+	}
+	_, err := r.Discard(generationHeaderSize)
+	return err
+}
@@ -0,0 +1,73 @@
+package crypto
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_GenerationHeader(t *testing.T) {
+	appID := "test-app-generation"
+	masterSecret := new(fr.Element).SetUint64(777)
+	masterPubKey, err := ScalarMulG2(G2Generator, masterSecret)
+	require.NoError(t, err)
+	qID, err := HashToG1(appID)
+	require.NoError(t, err)
+	appPrivKey, err := ScalarMulG1(*qID, masterSecret)
+	require.NoError(t, err)
+
+	plaintext := []byte("encrypted to generation 3")
+	single, err := EncryptForApp(appID, *masterPubKey, plaintext)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	w, err := newEncryptWriter(&buf, appID, *masterPubKey, 8)
+	require.NoError(t, err)
+	_, err = w.Write(plaintext)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	chunked := buf.Bytes()
+
+	for name, ciphertext := range map[string][]byte{"single": single, "chunked": chunked} {
+		t.Run(name, func(t *testing.T) {
+			_, ok := CiphertextGeneration(ciphertext)
+			require.False(t, ok, "untagged ciphertext")
+
+			tagged := WithGeneration(3, ciphertext)
+			generation, ok := CiphertextGeneration(tagged)
+			require.True(t, ok)
+			require.Equal(t, uint32(3), generation)
+			require.NoError(t, ValidateCiphertextFormat(tagged))
+
+			decrypted, err := DecryptForApp(appID, *appPrivKey, tagged)
+			require.NoError(t, err)
+			require.Equal(t, plaintext, decrypted)
+
+			c1, err := CiphertextC1(tagged)
+			require.NoError(t, err)
+			untaggedC1, err := CiphertextC1(ciphertext)
+			require.NoError(t, err)
+			require.Equal(t, untaggedC1, c1)
+
+			// A header on a header is not a ciphertext
+			require.Error(t, ValidateCiphertextFormat(WithGeneration(3, tagged)))
+		})
+	}
+
+	t.Run("stream", func(t *testing.T) {
+		br := bufio.NewReader(bytes.NewReader(append(GenerationHeader(3), chunked...)))
+		generation, ok, err := PeekCiphertextGeneration(br)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, uint32(3), generation)
+
+		r, err := NewDecryptReader(br, appID, *appPrivKey)
+		require.NoError(t, err)
+		decrypted, err := io.ReadAll(r)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+}
@@ -0,0 +1,388 @@
+package crypto
+
+import (
+	"crypto/cipher"
+	"crypto/mlkem"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/util"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/crypto/hkdf"
+)
+
+// Post-quantum hybrid IBE ciphertexts (version 3) add a second, lattice-based secret to
+// the key of a version 1 ciphertext, so that breaking the pairing (which a quantum
+// adversary can, from the master public key alone) no longer decrypts it.
+//
+// Each operator holds its own ML-KEM-768 key for every app, derived from a seed the
+// operator never shares (DeriveAppPQKey); the app's PQ public key is the set of those
+// encapsulation keys. The encryptor picks a random PQ secret K, splits it into
+// threshold-of-n Shamir shares over Fr, and encapsulates each operator's share to that
+// operator's key. The AES key is derived from both g_ID and K, so decryption needs the
+// app private key (or decryption shares) and the decapsulation keys of a threshold of
+// the operators named in the ciphertext, which they release only to attested workloads.
+// No operator, nor any set of fewer than the threshold, learns K.
+//
+//	[0:3]     magic ("IBE")
+//	[3:4]     version (0x03)
+//	[4:100]   C1 (compressed G2 point, 96 bytes)
+//	[100:102] threshold (uint16, big-endian)
+//	[102:104] recipient count n (uint16, big-endian)
+//	n recipients, each:
+//	          operator address (20 bytes)
+//	          ML-KEM-768 ciphertext (1088 bytes)
+//	          share of K, masked with a key derived from the ML-KEM shared key (32 bytes)
+//	nonce (12 bytes)
+//	encrypted data + GCM tag
+//
+// The AAD is appID || version || everything from C1 to the nonce, so no recipient can
+// be altered without failing decryption.
+const (
+	ibePQVersion = byte(0x03)
+
+	pqThresholdSize      = 2
+	pqRecipientCountSize = 2
+	pqRecipientSize      = common.AddressLength + mlkem.CiphertextSize768 + fr.Bytes
+	pqHeaderSize         = headerSize + g2Size + pqThresholdSize + pqRecipientCountSize
+
+	// pqSeedSalt separates the per-app ML-KEM keys an operator derives from its seed.
+	pqSeedSalt = "eigenx-kms-go-pq-key"
+
+	// PQSeedSize is the size of the seed an operator derives its per-app ML-KEM keys from.
+	PQSeedSize = 32
+)
+
+// DeriveAppPQKey returns an operator's ML-KEM-768 decapsulation key for appID, derived
+// from the operator's PQ seed. The seed must never be derived from a key share or any
+// other discrete-log secret, or the key would fall with the pairing.
+func DeriveAppPQKey(seed []byte, appID string) (*mlkem.DecapsulationKey768, error) {
+	if len(seed) != PQSeedSize {
+		return nil, fmt.Errorf("PQ seed must be %d bytes, got %d", PQSeedSize, len(seed))
+	}
+	if err := util.ValidateAppID(appID); err != nil {
+		return nil, err
+	}
+	keySeed := make([]byte, mlkem.SeedSize)
+	info := fmt.Appendf(nil, "ML-KEM-768|v1|%s", appID)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, seed, []byte(pqSeedSalt), info), keySeed); err != nil {
+		return nil, fmt.Errorf("failed to derive key using HKDF: %w", err)
+	}
+	return mlkem.NewDecapsulationKey768(keySeed)
+}
+
+// EncryptForAppPQ encrypts data for an application as a post-quantum hybrid (version 3)
+// ciphertext. pqKeys are the app's ML-KEM-768 encapsulation keys, one per operator, as
+// the operators announce them on /v1/app/pq-public-key, and threshold is how many of
+// those operators' decapsulation keys decryption needs.
+//
+// The ciphertext stays decryptable while a threshold of the operators it names keep
+// their PQ seeds; PQRecipients tells which they are, so that it can be re-encrypted to
+// the current operators before too many of them leave.
+func EncryptForAppPQ(appID string, masterPublicKey types.G2Point, pqKeys []types.AppPQPublicKey, threshold int, plaintext []byte) ([]byte, error) {
+	if err := util.ValidateAppID(appID); err != nil {
+		return nil, fmt.Errorf("invalid app ID for encryption: %w", err)
+	}
+	if len(pqKeys) == 0 || len(pqKeys) > math.MaxUint16 {
+		return nil, fmt.Errorf("need between 1 and %d PQ public keys, got %d", math.MaxUint16, len(pqKeys))
+	}
+	if threshold < 1 || threshold > len(pqKeys) {
+		return nil, fmt.Errorf("threshold must be between 1 and %d, got %d", len(pqKeys), threshold)
+	}
+
+	recipients := make([]common.Address, len(pqKeys))
+	encapsulationKeys := make([]*mlkem.EncapsulationKey768, len(pqKeys))
+	seen := make(map[common.Address]bool, len(pqKeys))
+	for i, key := range pqKeys {
+		if key.AppID != appID {
+			return nil, fmt.Errorf("PQ public key of %s is for app %q, not %q", key.OperatorAddress.Hex(), key.AppID, appID)
+		}
+		if seen[key.OperatorAddress] {
+			return nil, fmt.Errorf("duplicate PQ public key for %s", key.OperatorAddress.Hex())
+		}
+		seen[key.OperatorAddress] = true
+		ek, err := mlkem.NewEncapsulationKey768(key.EncapsulationKey)
+		if err != nil {
+			return nil, fmt.Errorf("invalid PQ public key of %s: %w", key.OperatorAddress.Hex(), err)
+		}
+		recipients[i] = key.OperatorAddress
+		encapsulationKeys[i] = ek
+	}
+
+	c1, gIDBytes, err := ibeEncapsulate(appID, masterPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	// K is shared with a fresh polynomial of degree threshold-1, so any threshold of the
+	// recipients' shares, and no fewer, determine it
+	pqSecret, err := new(fr.Element).SetRandom()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate PQ secret: %w", err)
+	}
+	poly, err := bls.GeneratePolynomial(pqSecret, threshold-1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to share PQ secret: %w", err)
+	}
+	shares := bls.GenerateShares(poly, recipients)
+
+	header := make([]byte, 0, pqHeaderSize+len(pqKeys)*pqRecipientSize)
+	header = append(header, ibeMagic...)
+	header = append(header, ibePQVersion)
+	header = append(header, c1.CompressedBytes...)
+	header = binary.BigEndian.AppendUint16(header, uint16(threshold))
+	header = binary.BigEndian.AppendUint16(header, uint16(len(pqKeys)))
+	for i, addr := range recipients {
+		sharedKey, kemCiphertext := encapsulationKeys[i].Encapsulate()
+		masked, err := maskPQShare(sharedKey, appID, addr, shares[addr].Bytes())
+		if err != nil {
+			return nil, err
+		}
+		header = append(header, addr.Bytes()...)
+		header = append(header, kemCiphertext...)
+		header = append(header, masked...)
+	}
+
+	pqSecretBytes := pqSecret.Bytes()
+	keyMaterial, err := deriveKeyMaterial(gIDBytes, pqSecretBytes[:], ibePQVersion, appID)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newIBEAEAD(keyMaterial)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	aad := buildAAD(appID, ibePQVersion, header[headerSize:])
+
+	ciphertext := append(header, nonce...)
+	return gcm.Seal(ciphertext, nonce, plaintext, aad), nil
+}
+
+// DecryptForAppPQ decrypts a ciphertext with the application private key. A version 3
+// ciphertext also needs the decapsulation keys of a threshold of the operators it
+// names, keyed by operator address; other versions are decrypted like DecryptForApp and
+// pqKeys is ignored.
+func DecryptForAppPQ(appID string, appPrivateKey types.G1Point, pqKeys map[common.Address]*mlkem.DecapsulationKey768, ciphertext []byte) ([]byte, error) {
+	return decryptPQ(appID, ciphertext, appKeySeed(appPrivateKey), pqKeys)
+}
+
+// DecryptPQ decrypts a ciphertext like DecryptForAppPQ, with the key seed combined from
+// decryption shares in place of the app private key.
+func (k *CiphertextKey) DecryptPQ(appID string, pqKeys map[common.Address]*mlkem.DecapsulationKey768, ciphertext []byte) ([]byte, error) {
+	return decryptPQ(appID, ciphertext, k.seedFor, pqKeys)
+}
+
+// IsPQCiphertext reports whether a ciphertext is a post-quantum hybrid (version 3) one.
+func IsPQCiphertext(ciphertext []byte) (bool, error) {
+	ciphertext = stripGeneration(ciphertext)
+	if err := validateIBE(ciphertext); err != nil {
+		return false, err
+	}
+	return ciphertext[magicSize] == ibePQVersion, nil
+}
+
+// PQRecipients returns the operators a version 3 ciphertext names and how many of
+// their decapsulation keys it needs. It returns no recipients for other versions.
+func PQRecipients(ciphertext []byte) ([]common.Address, int, error) {
+	ciphertext = stripGeneration(ciphertext)
+	if err := validateIBE(ciphertext); err != nil {
+		return nil, 0, err
+	}
+	if ciphertext[magicSize] != ibePQVersion {
+		return nil, 0, nil
+	}
+	threshold, recipients, _ := parsePQHeader(ciphertext)
+	addrs := make([]common.Address, len(recipients))
+	for i, r := range recipients {
+		addrs[i] = common.BytesToAddress(r[:common.AddressLength])
+	}
+	return addrs, threshold, nil
+}
+
+// decryptPQ decrypts a ciphertext of any version, taking its key seed from keySeed and,
+// for version 3, recovering its PQ secret with pqKeys.
+func decryptPQ(appID string, ciphertext []byte, keySeed keySeedFunc, pqKeys map[common.Address]*mlkem.DecapsulationKey768) ([]byte, error) {
+	if err := util.ValidateAppID(appID); err != nil {
+		return nil, err
+	}
+	ciphertext = stripGeneration(ciphertext)
+	if err := validateIBE(ciphertext); err != nil {
+		return nil, err
+	}
+	if ciphertext[magicSize] != ibePQVersion {
+		return decryptIBE(appID, ciphertext, keySeed)
+	}
+
+	threshold, recipients, nonceStart := parsePQHeader(ciphertext)
+	shares := make(map[common.Address]*fr.Element, threshold)
+	for _, r := range recipients {
+		if len(shares) == threshold {
+			break
+		}
+		addr := common.BytesToAddress(r[:common.AddressLength])
+		dk, ok := pqKeys[addr]
+		if !ok || dk == nil {
+			continue
+		}
+		kemCiphertext := r[common.AddressLength : common.AddressLength+mlkem.CiphertextSize768]
+		sharedKey, err := dk.Decapsulate(kemCiphertext)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decapsulate share of %s: %w", addr.Hex(), err)
+		}
+		shareBytes, err := maskPQShare(sharedKey, appID, addr, [fr.Bytes]byte(r[common.AddressLength+mlkem.CiphertextSize768:]))
+		if err != nil {
+			return nil, err
+		}
+		share := new(fr.Element)
+		if err := share.SetBytesCanonical(shareBytes); err != nil {
+			return nil, fmt.Errorf("invalid PQ share of %s: %w", addr.Hex(), err)
+		}
+		shares[addr] = share
+	}
+	if len(shares) < threshold {
+		return nil, fmt.Errorf("insufficient PQ decapsulation keys: have %d of the ciphertext's recipients, need %d", len(shares), threshold)
+	}
+	pqSecret, err := bls.RecoverSecret(shares)
+	if err != nil {
+		return nil, fmt.Errorf("failed to recover PQ secret: %w", err)
+	}
+
+	gIDBytes, err := keySeed(ciphertext[headerSize : headerSize+g2Size])
+	if err != nil {
+		return nil, err
+	}
+	pqSecretBytes := pqSecret.Bytes()
+	keyMaterial, err := deriveKeyMaterial(gIDBytes, pqSecretBytes[:], ibePQVersion, appID)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newIBEAEAD(keyMaterial)
+	if err != nil {
+		return nil, err
+	}
+	nonce := ciphertext[nonceStart : nonceStart+nonceSize]
+	aad := buildAAD(appID, ibePQVersion, ciphertext[headerSize:nonceStart])
+	plaintext, err := gcm.Open(nil, nonce, ciphertext[nonceStart+nonceSize:], aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+// validatePQHeader checks that a version 3 ciphertext is long enough for the recipients
+// it declares.
+func validatePQHeader(ciphertext []byte) error {
+	if len(ciphertext) < pqHeaderSize {
+		return errors.New("ciphertext too short")
+	}
+	threshold := int(binary.BigEndian.Uint16(ciphertext[headerSize+g2Size:]))
+	count := int(binary.BigEndian.Uint16(ciphertext[headerSize+g2Size+pqThresholdSize:]))
+	if count == 0 || threshold < 1 || threshold > count {
+		return fmt.Errorf("invalid PQ threshold %d of %d recipients", threshold, count)
+	}
+	if len(ciphertext) < pqHeaderSize+count*pqRecipientSize+nonceSize+tagSize {
+		return errors.New("ciphertext too short")
+	}
+	return nil
+}
+
+// parsePQHeader splits a validated version 3 ciphertext into its threshold, its
+// recipients and the offset of its nonce.
+func parsePQHeader(ciphertext []byte) (int, [][]byte, int) {
+	threshold := int(binary.BigEndian.Uint16(ciphertext[headerSize+g2Size:]))
+	count := int(binary.BigEndian.Uint16(ciphertext[headerSize+g2Size+pqThresholdSize:]))
+	recipients := make([][]byte, count)
+	offset := pqHeaderSize
+	for i := range recipients {
+		recipients[i] = ciphertext[offset : offset+pqRecipientSize]
+		offset += pqRecipientSize
+	}
+	return threshold, recipients, offset
+}
+
+// maskPQShare XORs a share with a pad derived from the ML-KEM shared key of its
+// recipient, masking it on encryption and unmasking it on decryption. Its integrity
+// comes from the AAD: a tampered share changes K and the GCM tag fails.
+func maskPQShare(sharedKey []byte, appID string, recipient common.Address, share [fr.Bytes]byte) ([]byte, error) {
+	pad := make([]byte, fr.Bytes)
+	info := fmt.Appendf(nil, "IBE-pq-share|v%d|%s|%s", ibePQVersion, appID, recipient.Hex())
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedKey, []byte(hkdfSalt), info), pad); err != nil {
+		return nil, fmt.Errorf("failed to derive key using HKDF: %w", err)
+	}
+	for i := range pad {
+		pad[i] ^= share[i]
+	}
+	return pad, nil
+}
+
+// SealAppPQKey seals an operator's decapsulation key for appID to a requester's
+// ML-KEM-768 encapsulation key, returning the ML-KEM ciphertext and the sealed key. The
+// seal is bound to the app and the operator, so it cannot be replayed as another
+// operator's key or another app's.
+func SealAppPQKey(requesterKey []byte, appID string, operator common.Address, key *mlkem.DecapsulationKey768) ([]byte, []byte, error) {
+	info := fmt.Appendf(nil, "IBE-pq-key-release|v%d|%s|%s", ibePQVersion, appID, operator.Hex())
+	return sealPQSecret(requesterKey, info, key.Bytes())
+}
+
+// OpenAppPQKey opens a decapsulation key sealed by SealAppPQKey with the requester's
+// decapsulation key. Callers should still check the key against the operator's signed
+// announcement, as the seal only proves who could encapsulate to the requester.
+func OpenAppPQKey(requesterKey *mlkem.DecapsulationKey768, appID string, operator common.Address, kemCiphertext, sealed []byte) (*mlkem.DecapsulationKey768, error) {
+	info := fmt.Appendf(nil, "IBE-pq-key-release|v%d|%s|%s", ibePQVersion, appID, operator.Hex())
+	seed, err := openPQSecret(requesterKey, info, kemCiphertext, sealed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PQ key: %w", err)
+	}
+	return mlkem.NewDecapsulationKey768(seed)
+}
+
+// sealPQSecret encapsulates to requesterKey and seals secret under a key derived from
+// the ML-KEM shared key with info, returning the ML-KEM ciphertext and the sealed secret.
+func sealPQSecret(requesterKey []byte, info []byte, secret []byte) ([]byte, []byte, error) {
+	ek, err := mlkem.NewEncapsulationKey768(requesterKey)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid requester encapsulation key: %w", err)
+	}
+	sharedKey, kemCiphertext := ek.Encapsulate()
+	gcm, err := pqSealAEAD(sharedKey, info)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The shared key is fresh for every seal, so a fixed nonce is never reused under it
+	sealed := gcm.Seal(nil, make([]byte, nonceSize), secret, nil)
+	return kemCiphertext, sealed, nil
+}
+
+// openPQSecret opens a secret sealed by sealPQSecret with the same info.
+func openPQSecret(requesterKey *mlkem.DecapsulationKey768, info []byte, kemCiphertext, sealed []byte) ([]byte, error) {
+	sharedKey, err := requesterKey.Decapsulate(kemCiphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decapsulate: %w", err)
+	}
+	gcm, err := pqSealAEAD(sharedKey, info)
+	if err != nil {
+		return nil, err
+	}
+	return gcm.Open(nil, make([]byte, nonceSize), sealed, nil)
+}
+
+// pqSealAEAD returns the AEAD a sealed PQ secret is sealed with.
+func pqSealAEAD(sharedKey []byte, info []byte) (cipher.AEAD, error) {
+	keyMaterial := make([]byte, 32)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, sharedKey, []byte(hkdfSalt), info), keyMaterial); err != nil {
+		return nil, fmt.Errorf("failed to derive key using HKDF: %w", err)
+	}
+	return newIBEAEAD(keyMaterial)
+}
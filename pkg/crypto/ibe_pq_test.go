@@ -0,0 +1,245 @@
+package crypto
+
+import (
+	"crypto/mlkem"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_PQIBE(t *testing.T) {
+	appID := "test-app-post-quantum"
+	threshold := 3
+
+	poly, err := bls.GeneratePolynomial(new(fr.Element).SetUint64(13371337), threshold-1)
+	require.NoError(t, err)
+	operators := make([]common.Address, 5)
+	for i := range operators {
+		operators[i] = common.BigToAddress(big.NewInt(int64(i + 1)))
+	}
+	keyShares := bls.GenerateShares(poly, operators)
+	commitments, err := bls.CreateCommitments(poly)
+	require.NoError(t, err)
+	masterPubKey := types.G2Point{CompressedBytes: commitments[0].Marshal()}
+	qID, err := HashToG1(appID)
+	require.NoError(t, err)
+	appPrivKey, err := ScalarMulG1(*qID, &poly[0])
+	require.NoError(t, err)
+
+	// Every operator derives its app key from its own seed
+	decapsulationKeys := make(map[common.Address]*mlkem.DecapsulationKey768, len(operators))
+	pqKeys := make([]types.AppPQPublicKey, len(operators))
+	for i, op := range operators {
+		seed := make([]byte, PQSeedSize)
+		seed[0] = byte(i + 1)
+		dk, err := DeriveAppPQKey(seed, appID)
+		require.NoError(t, err)
+		decapsulationKeys[op] = dk
+		pqKeys[i] = types.AppPQPublicKey{OperatorAddress: op, AppID: appID, EncapsulationKey: dk.EncapsulationKey().Bytes()}
+	}
+	subset := func(ops ...common.Address) map[common.Address]*mlkem.DecapsulationKey768 {
+		keys := make(map[common.Address]*mlkem.DecapsulationKey768, len(ops))
+		for _, op := range ops {
+			keys[op] = decapsulationKeys[op]
+		}
+		return keys
+	}
+	plaintext := []byte("keys that must outlive the pairing")
+
+	t.Run("derive app key", func(t *testing.T) {
+		seed := make([]byte, PQSeedSize)
+		a, err := DeriveAppPQKey(seed, appID)
+		require.NoError(t, err)
+		b, err := DeriveAppPQKey(seed, appID)
+		require.NoError(t, err)
+		require.Equal(t, a.Bytes(), b.Bytes())
+
+		other, err := DeriveAppPQKey(seed, "another-app")
+		require.NoError(t, err)
+		require.NotEqual(t, a.Bytes(), other.Bytes())
+
+		_, err = DeriveAppPQKey(seed[:16], appID)
+		require.ErrorContains(t, err, "PQ seed must be")
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		ciphertext, err := EncryptForAppPQ(appID, masterPubKey, pqKeys, threshold, plaintext)
+		require.NoError(t, err)
+		require.Equal(t, []byte("IBE"), ciphertext[:3])
+		require.Equal(t, ibePQVersion, ciphertext[3])
+		require.Len(t, ciphertext, pqHeaderSize+len(operators)*pqRecipientSize+nonceSize+len(plaintext)+tagSize)
+		require.NoError(t, ValidateCiphertextFormat(ciphertext))
+
+		isPQ, err := IsPQCiphertext(ciphertext)
+		require.NoError(t, err)
+		require.True(t, isPQ)
+
+		recipients, gotThreshold, err := PQRecipients(ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, operators, recipients)
+		require.Equal(t, threshold, gotThreshold)
+
+		// The generation header does not hide the recipients
+		recipients, gotThreshold, err = PQRecipients(WithGeneration(2, ciphertext))
+		require.NoError(t, err)
+		require.Equal(t, operators, recipients)
+		require.Equal(t, threshold, gotThreshold)
+
+		decrypted, err := DecryptForAppPQ(appID, *appPrivKey, decapsulationKeys, ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("threshold of keys", func(t *testing.T) {
+		ciphertext, err := EncryptForAppPQ(appID, masterPubKey, pqKeys, threshold, plaintext)
+		require.NoError(t, err)
+
+		decrypted, err := DecryptForAppPQ(appID, *appPrivKey, subset(operators[4], operators[0], operators[2]), ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+
+		_, err = DecryptForAppPQ(appID, *appPrivKey, subset(operators[4], operators[0]), ciphertext)
+		require.ErrorContains(t, err, "insufficient PQ decapsulation keys")
+
+		// A key of an operator the ciphertext does not name does not count
+		outsider := common.BigToAddress(big.NewInt(99))
+		keys := subset(operators[4], operators[0])
+		keys[outsider] = decapsulationKeys[operators[1]]
+		_, err = DecryptForAppPQ(appID, *appPrivKey, keys, ciphertext)
+		require.ErrorContains(t, err, "insufficient PQ decapsulation keys")
+	})
+
+	t.Run("wrong keys", func(t *testing.T) {
+		ciphertext, err := EncryptForAppPQ(appID, masterPubKey, pqKeys, threshold, plaintext)
+		require.NoError(t, err)
+
+		// Swapped decapsulation keys recover a wrong PQ secret
+		keys := subset(operators[0], operators[1])
+		keys[operators[2]] = decapsulationKeys[operators[3]]
+		_, err = DecryptForAppPQ(appID, *appPrivKey, keys, ciphertext)
+		require.Error(t, err)
+
+		// The decapsulation keys alone are not enough without the app private key
+		otherKey, err := ScalarMulG1(*qID, new(fr.Element).SetUint64(7))
+		require.NoError(t, err)
+		_, err = DecryptForAppPQ(appID, *otherKey, decapsulationKeys, ciphertext)
+		require.ErrorContains(t, err, "failed to decrypt")
+
+		_, err = DecryptForAppPQ("another-app", *appPrivKey, decapsulationKeys, ciphertext)
+		require.Error(t, err)
+	})
+
+	t.Run("tampering", func(t *testing.T) {
+		ciphertext, err := EncryptForAppPQ(appID, masterPubKey, pqKeys, threshold, plaintext)
+		require.NoError(t, err)
+
+		for _, offset := range []int{
+			headerSize + g2Size,                // threshold
+			pqHeaderSize + 3,                   // first recipient's address
+			pqHeaderSize + 100,                 // first recipient's ML-KEM ciphertext
+			pqHeaderSize + pqRecipientSize - 1, // first recipient's masked share
+			len(ciphertext) - 1,                // GCM tag
+		} {
+			tampered := append([]byte(nil), ciphertext...)
+			tampered[offset] ^= 0x01
+			_, err := DecryptForAppPQ(appID, *appPrivKey, decapsulationKeys, tampered)
+			require.Error(t, err, "offset %d", offset)
+		}
+
+		truncated := ciphertext[:pqHeaderSize+2*pqRecipientSize]
+		require.ErrorContains(t, ValidateCiphertextFormat(truncated), "too short")
+	})
+
+	t.Run("other versions", func(t *testing.T) {
+		ciphertext, err := EncryptForAppPQ(appID, masterPubKey, pqKeys, threshold, plaintext)
+		require.NoError(t, err)
+		_, err = DecryptForApp(appID, *appPrivKey, ciphertext)
+		require.ErrorContains(t, err, "DecryptForAppPQ")
+
+		v1, err := EncryptForApp(appID, masterPubKey, plaintext)
+		require.NoError(t, err)
+		decrypted, err := DecryptForAppPQ(appID, *appPrivKey, nil, v1)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+
+		recipients, _, err := PQRecipients(v1)
+		require.NoError(t, err)
+		require.Nil(t, recipients)
+
+		isPQ, err := IsPQCiphertext(v1)
+		require.NoError(t, err)
+		require.False(t, isPQ)
+	})
+
+	t.Run("decryption shares", func(t *testing.T) {
+		ciphertext, err := EncryptForAppPQ(appID, masterPubKey, pqKeys, threshold, plaintext)
+		require.NoError(t, err)
+		c1, err := CiphertextC1(ciphertext)
+		require.NoError(t, err)
+
+		shares := make(map[common.Address]types.DecryptionShare)
+		for _, op := range operators[:threshold] {
+			share, err := ComputeDecryptionShare(appID, keyShares[op], *c1)
+			require.NoError(t, err)
+			shares[op] = *share
+		}
+		key, err := CombineDecryptionShares(*c1, shares, threshold)
+		require.NoError(t, err)
+		decrypted, err := key.DecryptPQ(appID, subset(operators[1:4]...), ciphertext)
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("invalid public keys", func(t *testing.T) {
+		_, err := EncryptForAppPQ(appID, masterPubKey, pqKeys, 0, plaintext)
+		require.ErrorContains(t, err, "threshold")
+		_, err = EncryptForAppPQ(appID, masterPubKey, pqKeys, len(pqKeys)+1, plaintext)
+		require.ErrorContains(t, err, "threshold")
+		_, err = EncryptForAppPQ(appID, masterPubKey, nil, 1, plaintext)
+		require.ErrorContains(t, err, "PQ public keys")
+
+		_, err = EncryptForAppPQ(appID, masterPubKey, append(pqKeys, pqKeys[0]), threshold, plaintext)
+		require.ErrorContains(t, err, "duplicate")
+
+		wrongApp := append([]types.AppPQPublicKey(nil), pqKeys...)
+		wrongApp[1].AppID = "another-app"
+		_, err = EncryptForAppPQ(appID, masterPubKey, wrongApp, threshold, plaintext)
+		require.ErrorContains(t, err, "is for app")
+
+		badKey := append([]types.AppPQPublicKey(nil), pqKeys...)
+		badKey[2].EncapsulationKey = badKey[2].EncapsulationKey[:100]
+		_, err = EncryptForAppPQ(appID, masterPubKey, badKey, threshold, plaintext)
+		require.ErrorContains(t, err, "invalid PQ public key")
+	})
+
+	t.Run("key release", func(t *testing.T) {
+		requester, err := mlkem.GenerateKey768()
+		require.NoError(t, err)
+		released := decapsulationKeys[operators[2]]
+
+		kemCiphertext, sealed, err := SealAppPQKey(requester.EncapsulationKey().Bytes(), appID, operators[2], released)
+		require.NoError(t, err)
+		opened, err := OpenAppPQKey(requester, appID, operators[2], kemCiphertext, sealed)
+		require.NoError(t, err)
+		require.Equal(t, released.Bytes(), opened.Bytes())
+
+		// The seal is bound to the operator and the app
+		_, err = OpenAppPQKey(requester, appID, operators[1], kemCiphertext, sealed)
+		require.ErrorContains(t, err, "failed to open PQ key")
+		_, err = OpenAppPQKey(requester, "another-app", operators[2], kemCiphertext, sealed)
+		require.ErrorContains(t, err, "failed to open PQ key")
+
+		other, err := mlkem.GenerateKey768()
+		require.NoError(t, err)
+		_, err = OpenAppPQKey(other, appID, operators[2], kemCiphertext, sealed)
+		require.ErrorContains(t, err, "failed to open PQ key")
+
+		_, _, err = SealAppPQKey([]byte("short"), appID, operators[2], released)
+		require.ErrorContains(t, err, "invalid requester encapsulation key")
+	})
+}
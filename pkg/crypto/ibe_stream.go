@@ -0,0 +1,345 @@
+package crypto
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/util"
+)
+
+// Chunked IBE ciphertexts (version 2) carry the same key encapsulation as version 1, a
+// C1 per ciphertext, but seal the plaintext in fixed-size chunks so that neither side
+// has to hold it in memory:
+//
+//	[0:3]     magic ("IBE")
+//	[3:4]     version (0x02)
+//	[4:100]   C1 (compressed G2 point, 96 bytes)
+//	[100:104] chunk size (uint32, big-endian)
+//	[104:112] nonce prefix (8 random bytes)
+//	[112:]    chunks, each chunk size bytes of plaintext sealed with AES-GCM, but for
+//	          the last, which holds the remaining 0 to chunk size bytes
+//
+// Chunk i is sealed under nonce prefix || uint32(i) with AAD
+// appID || version || C1 || chunk size || uint32(i) || final, where final is 1 for the
+// last chunk and 0 otherwise. Chunks therefore cannot be reordered, dropped or moved
+// to another ciphertext, and a ciphertext cut short at a chunk boundary fails
+// decryption because its new last chunk was not sealed as final.
+const (
+	ibeStreamVersion = byte(0x02)
+
+	// DefaultChunkSize is the plaintext size of every chunk NewEncryptWriter seals but
+	// the last.
+	DefaultChunkSize = 64 * 1024
+
+	// maxChunkSize bounds the chunk size a ciphertext may declare, and with it the
+	// buffer NewDecryptReader allocates.
+	maxChunkSize = 16 * 1024 * 1024
+
+	chunkSizeSize           = 4
+	noncePrefixSize         = nonceSize - 4
+	streamHeaderSize        = headerSize + g2Size + chunkSizeSize + noncePrefixSize
+	minStreamCiphertextSize = streamHeaderSize + tagSize
+)
+
+// chunkAEAD seals or opens the chunks of one chunked ciphertext, in order.
+type chunkAEAD struct {
+	aead  cipher.AEAD
+	nonce []byte // nonce prefix, then the chunk index
+	aad   []byte // buildAAD and the chunk size, then the chunk index and final flag
+	base  int    // length of the part of aad shared by every chunk
+	index uint64
+}
+
+func newChunkAEAD(gIDBytes []byte, appID string, c1Bytes []byte, chunkSize int, noncePrefix []byte) (*chunkAEAD, error) {
+	keyMaterial, err := deriveKeyMaterial(gIDBytes, nil, ibeStreamVersion, appID)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newIBEAEAD(keyMaterial)
+	if err != nil {
+		return nil, err
+	}
+
+	aad := buildAAD(appID, ibeStreamVersion, c1Bytes)
+	aad = binary.BigEndian.AppendUint32(aad, uint32(chunkSize))
+	nonce := make([]byte, nonceSize)
+	copy(nonce, noncePrefix)
+	return &chunkAEAD{aead: aead, nonce: nonce, aad: aad, base: len(aad)}, nil
+}
+
+// next returns the nonce and AAD of the next chunk.
+func (c *chunkAEAD) next(final bool) ([]byte, []byte, error) {
+	if c.index > math.MaxUint32 {
+		return nil, nil, errors.New("too many chunks for one ciphertext")
+	}
+	binary.BigEndian.PutUint32(c.nonce[noncePrefixSize:], uint32(c.index))
+	c.aad = binary.BigEndian.AppendUint32(c.aad[:c.base], uint32(c.index))
+	if final {
+		c.aad = append(c.aad, 1)
+	} else {
+		c.aad = append(c.aad, 0)
+	}
+	c.index++
+	return c.nonce, c.aad, nil
+}
+
+// parseChunkSize reads the chunk size that follows C1 in a chunked ciphertext header.
+func parseChunkSize(b []byte) (int, error) {
+	chunkSize := binary.BigEndian.Uint32(b[:chunkSizeSize])
+	if chunkSize == 0 || chunkSize > maxChunkSize {
+		return 0, fmt.Errorf("invalid ciphertext chunk size: %d", chunkSize)
+	}
+	return int(chunkSize), nil
+}
+
+// encryptWriter implements NewEncryptWriter.
+type encryptWriter struct {
+	w      io.Writer
+	chunks *chunkAEAD
+	buf    []byte // plaintext of the chunk being filled
+	sealed []byte
+	err    error // sticky; set once a write fails or the writer is closed
+}
+
+// errWriterClosed is the sticky error of a closed encryptWriter.
+var errWriterClosed = errors.New("write to closed encrypt writer")
+
+// NewEncryptWriter returns a writer that encrypts what is written to it for appID and
+// writes it to w as a chunked (version 2) IBE ciphertext. The header is written right
+// away; each chunk is written once the writer holds the plaintext that follows it, so
+// the last chunk is only written by Close. Close must be called for the ciphertext to
+// decrypt, and does not close w.
+//
+// Only DefaultChunkSize bytes of plaintext are held at a time, whatever the size of
+// the whole.
+func NewEncryptWriter(w io.Writer, appID string, masterPublicKey types.G2Point) (io.WriteCloser, error) {
+	return newEncryptWriter(w, appID, masterPublicKey, DefaultChunkSize)
+}
+
+func newEncryptWriter(w io.Writer, appID string, masterPublicKey types.G2Point, chunkSize int) (*encryptWriter, error) {
+	if err := util.ValidateAppID(appID); err != nil {
+		return nil, fmt.Errorf("invalid app ID for encryption: %w", err)
+	}
+
+	c1, gIDBytes, err := ibeEncapsulate(appID, masterPublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, streamHeaderSize)
+	header = append(header, ibeMagic...)
+	header = append(header, ibeStreamVersion)
+	header = append(header, c1.CompressedBytes...)
+	header = binary.BigEndian.AppendUint32(header, uint32(chunkSize))
+	noncePrefix := make([]byte, noncePrefixSize)
+	if _, err := io.ReadFull(rand.Reader, noncePrefix); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce prefix: %w", err)
+	}
+	header = append(header, noncePrefix...)
+
+	chunks, err := newChunkAEAD(gIDBytes, appID, c1.CompressedBytes, chunkSize, noncePrefix)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write ciphertext header: %w", err)
+	}
+
+	return &encryptWriter{
+		w:      w,
+		chunks: chunks,
+		buf:    make([]byte, 0, chunkSize),
+		sealed: make([]byte, 0, chunkSize+tagSize),
+	}, nil
+}
+
+// Write encrypts p, writing out every chunk it completes but the last.
+func (e *encryptWriter) Write(p []byte) (int, error) {
+	if e.err != nil {
+		return 0, e.err
+	}
+	written := 0
+	for len(p) > 0 {
+		// A full chunk is not the last once more plaintext follows it
+		if len(e.buf) == cap(e.buf) {
+			if err := e.writeChunk(false); err != nil {
+				e.err = err
+				return written, err
+			}
+		}
+		n := copy(e.buf[len(e.buf):cap(e.buf)], p)
+		e.buf = e.buf[:len(e.buf)+n]
+		p = p[n:]
+		written += n
+	}
+	return written, nil
+}
+
+// Close writes the last chunk. Closing a closed writer does nothing.
+func (e *encryptWriter) Close() error {
+	if e.err == errWriterClosed {
+		return nil
+	}
+	if e.err != nil {
+		return e.err
+	}
+	if err := e.writeChunk(true); err != nil {
+		e.err = err
+		return err
+	}
+	e.err = errWriterClosed
+	return nil
+}
+
+func (e *encryptWriter) writeChunk(final bool) error {
+	nonce, aad, err := e.chunks.next(final)
+	if err != nil {
+		return err
+	}
+	e.sealed = e.chunks.aead.Seal(e.sealed[:0], nonce, e.buf, aad)
+	e.buf = e.buf[:0]
+	if _, err := e.w.Write(e.sealed); err != nil {
+		return fmt.Errorf("failed to write ciphertext chunk: %w", err)
+	}
+	return nil
+}
+
+// decryptReader implements NewDecryptReader.
+type decryptReader struct {
+	r      *bufio.Reader
+	chunks *chunkAEAD
+	in     []byte // the sealed chunk being opened
+	out    []byte // plaintext of the last chunk opened, not yet read
+	done   bool   // the final chunk has been opened
+	err    error  // sticky
+}
+
+// NewDecryptReader reads the header of a chunked (version 2) IBE ciphertext from r and
+// returns a reader of its plaintext, decrypted with the application private key.
+//
+// Each chunk's plaintext is only returned once the chunk is authenticated, but a chunk
+// can authenticate while a later one does not: the plaintext read is only the whole,
+// untampered plaintext once the reader returns io.EOF. Callers writing it somewhere
+// lasting should discard it on any other error.
+func NewDecryptReader(r io.Reader, appID string, appPrivateKey types.G1Point) (io.Reader, error) {
+	return newDecryptReader(r, appID, appKeySeed(appPrivateKey))
+}
+
+func newDecryptReader(r io.Reader, appID string, keySeed keySeedFunc) (*decryptReader, error) {
+	if err := util.ValidateAppID(appID); err != nil {
+		return nil, err
+	}
+
+	br := bufio.NewReader(r)
+	if err := discardGeneration(br); err != nil {
+		return nil, fmt.Errorf("failed to read ciphertext header: %w", err)
+	}
+	header := make([]byte, streamHeaderSize)
+	if _, err := io.ReadFull(br, header); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errors.New("ciphertext too short")
+		}
+		return nil, fmt.Errorf("failed to read ciphertext header: %w", err)
+	}
+	if !bytes.Equal(header[:magicSize], []byte(ibeMagic)) {
+		return nil, errors.New("invalid ciphertext format: missing or incorrect magic number")
+	}
+	if version := header[magicSize]; version != ibeStreamVersion {
+		return nil, fmt.Errorf("unsupported ciphertext version for streaming: %d", version)
+	}
+	c1Bytes := header[headerSize : headerSize+g2Size]
+	chunkSize, err := parseChunkSize(header[headerSize+g2Size:])
+	if err != nil {
+		return nil, err
+	}
+	noncePrefix := header[headerSize+g2Size+chunkSizeSize:]
+
+	gIDBytes, err := keySeed(c1Bytes)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := newChunkAEAD(gIDBytes, appID, c1Bytes, chunkSize, noncePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	return &decryptReader{
+		r:      br,
+		chunks: chunks,
+		in:     make([]byte, chunkSize+tagSize),
+	}, nil
+}
+
+// Read returns plaintext of the chunks opened so far, opening the next chunk when it has
+// none left.
+func (d *decryptReader) Read(p []byte) (int, error) {
+	for len(d.out) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if d.err != nil {
+			return 0, d.err
+		}
+		d.err = d.openChunk()
+	}
+	n := copy(p, d.out)
+	d.out = d.out[n:]
+	return n, nil
+}
+
+func (d *decryptReader) openChunk() error {
+	index := d.chunks.index
+	n, err := io.ReadFull(d.r, d.in)
+	final := false
+	switch {
+	case err == nil:
+		// A full chunk is the last when nothing follows it
+		if _, peekErr := d.r.Peek(1); errors.Is(peekErr, io.EOF) {
+			final = true
+		} else if peekErr != nil {
+			return fmt.Errorf("failed to read ciphertext: %w", peekErr)
+		}
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		final = true
+	case errors.Is(err, io.EOF):
+		return fmt.Errorf("ciphertext truncated: chunk %d missing", index)
+	default:
+		return fmt.Errorf("failed to read ciphertext: %w", err)
+	}
+	if n < tagSize {
+		return fmt.Errorf("ciphertext truncated: chunk %d is %d bytes", index, n)
+	}
+
+	nonce, aad, err := d.chunks.next(final)
+	if err != nil {
+		return err
+	}
+	plaintext, err := d.chunks.aead.Open(d.in[:0], nonce, d.in[:n], aad)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt chunk %d: %w", index, err)
+	}
+	d.out = plaintext
+	d.done = final
+	return nil
+}
+
+// decryptChunked decrypts a whole chunked ciphertext in memory, for decryptIBE.
+func decryptChunked(appID string, ciphertext []byte, keySeed keySeedFunc) ([]byte, error) {
+	r, err := newDecryptReader(bytes.NewReader(ciphertext), appID, keySeed)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return plaintext, nil
+}
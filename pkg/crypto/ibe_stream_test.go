@@ -0,0 +1,183 @@
+package crypto
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ChunkedIBE(t *testing.T) {
+	appID := "test-app-chunked"
+	masterSecret := new(fr.Element).SetUint64(424242)
+	masterPubKey, err := ScalarMulG2(G2Generator, masterSecret)
+	require.NoError(t, err)
+	qID, err := HashToG1(appID)
+	require.NoError(t, err)
+	appPrivKey, err := ScalarMulG1(*qID, masterSecret)
+	require.NoError(t, err)
+
+	const chunkSize = 64
+	encrypt := func(t *testing.T, plaintext []byte) []byte {
+		t.Helper()
+		var buf bytes.Buffer
+		w, err := newEncryptWriter(&buf, appID, *masterPubKey, chunkSize)
+		require.NoError(t, err)
+		// Odd-sized writes, so chunks are filled across several of them
+		for len(plaintext) > 0 {
+			n := min(len(plaintext), 37)
+			_, err := w.Write(plaintext[:n])
+			require.NoError(t, err)
+			plaintext = plaintext[n:]
+		}
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	}
+	decrypt := func(ciphertext []byte) ([]byte, error) {
+		r, err := NewDecryptReader(bytes.NewReader(ciphertext), appID, *appPrivKey)
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(r)
+	}
+	sealedChunk := chunkSize + tagSize
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, size := range []int{0, 1, chunkSize - 1, chunkSize, chunkSize + 1, 3 * chunkSize, 10*chunkSize + 5} {
+			plaintext := make([]byte, size)
+			_, err := rand.Read(plaintext)
+			require.NoError(t, err)
+
+			ciphertext := encrypt(t, plaintext)
+			require.Equal(t, []byte("IBE"), ciphertext[:3])
+			require.Equal(t, ibeStreamVersion, ciphertext[3])
+			chunks := max(1, (size+chunkSize-1)/chunkSize)
+			require.Len(t, ciphertext, streamHeaderSize+size+chunks*tagSize, "size %d", size)
+			require.NoError(t, ValidateCiphertextFormat(ciphertext))
+
+			decrypted, err := decrypt(ciphertext)
+			require.NoError(t, err, "size %d", size)
+			require.Equal(t, plaintext, decrypted)
+
+			// DecryptForApp takes chunked ciphertexts too
+			decrypted, err = DecryptForApp(appID, *appPrivKey, ciphertext)
+			require.NoError(t, err)
+			require.Equal(t, plaintext, decrypted)
+		}
+	})
+
+	t.Run("default chunk size", func(t *testing.T) {
+		plaintext := make([]byte, 2*DefaultChunkSize+100)
+		_, err := rand.Read(plaintext)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		w, err := NewEncryptWriter(&buf, appID, *masterPubKey)
+		require.NoError(t, err)
+		_, err = io.Copy(w, bytes.NewReader(plaintext))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		require.NoError(t, w.Close(), "closing twice is harmless")
+
+		decrypted, err := decrypt(buf.Bytes())
+		require.NoError(t, err)
+		require.Equal(t, plaintext, decrypted)
+	})
+
+	t.Run("truncation", func(t *testing.T) {
+		ciphertext := encrypt(t, make([]byte, 3*chunkSize+10))
+
+		// Cut at a chunk boundary: the new last chunk was not sealed as final
+		for chunks := 0; chunks < 3; chunks++ {
+			_, err := decrypt(ciphertext[:streamHeaderSize+chunks*sealedChunk])
+			require.Error(t, err, "%d chunks", chunks)
+		}
+		// Cut mid-chunk
+		_, err := decrypt(ciphertext[:len(ciphertext)-1])
+		require.Error(t, err)
+		// Cut inside the header
+		_, err = decrypt(ciphertext[:streamHeaderSize-1])
+		require.ErrorContains(t, err, "too short")
+	})
+
+	t.Run("reordering", func(t *testing.T) {
+		ciphertext := encrypt(t, make([]byte, 3*chunkSize+10))
+		reordered := bytes.Clone(ciphertext)
+		first := reordered[streamHeaderSize : streamHeaderSize+sealedChunk]
+		second := reordered[streamHeaderSize+sealedChunk : streamHeaderSize+2*sealedChunk]
+		tmp := bytes.Clone(first)
+		copy(first, second)
+		copy(second, tmp)
+
+		_, err := decrypt(reordered)
+		require.ErrorContains(t, err, "failed to decrypt chunk 0")
+	})
+
+	t.Run("chunk from another ciphertext", func(t *testing.T) {
+		plaintext := make([]byte, 2*chunkSize+10)
+		a := encrypt(t, plaintext)
+		b := encrypt(t, plaintext)
+		spliced := bytes.Clone(a)
+		copy(spliced[streamHeaderSize+sealedChunk:], b[streamHeaderSize+sealedChunk:streamHeaderSize+2*sealedChunk])
+
+		_, err := decrypt(spliced)
+		require.ErrorContains(t, err, "failed to decrypt chunk 1")
+	})
+
+	t.Run("tampering", func(t *testing.T) {
+		ciphertext := encrypt(t, make([]byte, 2*chunkSize))
+		for _, offset := range []int{
+			headerSize + g2Size,     // chunk size
+			headerSize + g2Size + 4, // nonce prefix
+			streamHeaderSize,        // first chunk
+			len(ciphertext) - 1,     // tag of the last chunk
+		} {
+			tampered := bytes.Clone(ciphertext)
+			tampered[offset] ^= 0x01
+			_, err := decrypt(tampered)
+			require.Error(t, err, "offset %d", offset)
+		}
+
+		// Appended data makes the real last chunk a non-final one
+		_, err := decrypt(append(bytes.Clone(ciphertext), make([]byte, sealedChunk)...))
+		require.Error(t, err)
+	})
+
+	t.Run("wrong key or app", func(t *testing.T) {
+		ciphertext := encrypt(t, []byte("model weights"))
+
+		otherKey, err := ScalarMulG1(*qID, new(fr.Element).SetUint64(7))
+		require.NoError(t, err)
+		r, err := NewDecryptReader(bytes.NewReader(ciphertext), appID, *otherKey)
+		require.NoError(t, err)
+		_, err = io.ReadAll(r)
+		require.Error(t, err)
+
+		_, err = DecryptForApp("another-app", *appPrivKey, ciphertext)
+		require.Error(t, err)
+	})
+
+	t.Run("invalid headers", func(t *testing.T) {
+		ciphertext := encrypt(t, []byte("data"))
+
+		v1, err := EncryptForApp(appID, *masterPubKey, []byte("data"))
+		require.NoError(t, err)
+		_, err = decrypt(v1)
+		require.ErrorContains(t, err, "unsupported ciphertext version")
+
+		for _, chunkSize := range [][]byte{{0, 0, 0, 0}, {0xff, 0xff, 0xff, 0xff}} {
+			bad := bytes.Clone(ciphertext)
+			copy(bad[headerSize+g2Size:], chunkSize)
+			require.ErrorContains(t, ValidateCiphertextFormat(bad), "invalid ciphertext chunk size")
+			_, err := decrypt(bad)
+			require.ErrorContains(t, err, "invalid ciphertext chunk size")
+		}
+
+		_, err = NewEncryptWriter(io.Discard, appID, types.G2Point{CompressedBytes: make([]byte, g2Size)})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,137 @@
+// Package derive turns an application's threshold-recovered private key into the keys
+// an application actually uses: a BIP-39 mnemonic, BIP-32 secp256k1 keys and SLIP-0010
+// ed25519 keys by derivation path, and raw secrets by label.
+//
+// Everything is a deterministic function of the app private key S·H_1(appID), so every
+// attested instance of an application derives the same wallets, and nothing derived
+// here is stored anywhere. The app key never seeds anything directly: HKDF separates
+// the mnemonic entropy from labelled secrets, and the wallet keys come from the
+// mnemonic's standard BIP-39 seed, so importing the mnemonic into any BIP-39 wallet
+// yields the same accounts.
+//
+// The app private key belongs to one master secret generation: a rotation replaces S,
+// and with it every key derived here. Callers pin the generation they derive from
+// (kmsClient.SecretsResult.DeriveRoot) and move to a new one deliberately.
+package derive
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/tyler-smith/go-bip39"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// hkdfSalt separates keys derived here from every other HKDF use of the app key.
+	// Changing it, or any info string below, changes every derived key.
+	hkdfSalt = "eigenx-kms-go-derive"
+
+	mnemonicInfo     = "bip39-entropy|v1"
+	secretInfoPrefix = "secret|v1|"
+
+	// mnemonicEntropySize gives a 24-word mnemonic.
+	mnemonicEntropySize = 32
+
+	// MaxSecretSize is the largest secret Secret derives (the HKDF-SHA256 output limit).
+	MaxSecretSize = 255 * sha256.Size
+
+	// EthereumPath is the BIP-44 path of the first Ethereum account.
+	EthereumPath = "m/44'/60'/0'/0/0"
+
+	// SolanaPath is the BIP-44 path of the first Solana account (all hardened, as
+	// SLIP-0010 ed25519 requires).
+	SolanaPath = "m/44'/501'/0'/0'"
+)
+
+// Root is the derivation root of one application.
+type Root struct {
+	appKey   []byte
+	mnemonic string
+	seed     []byte
+}
+
+// NewRoot returns the derivation root of an application from its recovered private key.
+// The key must be a valid, non-identity G1 point; callers should only pass one that was
+// verified against the master public key, as a corrupt key derives different wallets.
+func NewRoot(appPrivateKey types.G1Point) (*Root, error) {
+	point, err := bls.G1PointFromCompressedBytes(appPrivateKey.CompressedBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app private key: %w", err)
+	}
+	if point.ToAffine().IsInfinity() {
+		return nil, errors.New("invalid app private key: point at infinity")
+	}
+	appKey := point.Marshal()
+
+	entropy, err := expand(appKey, mnemonicInfo, mnemonicEntropySize)
+	if err != nil {
+		return nil, err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode mnemonic: %w", err)
+	}
+	return &Root{
+		appKey:   appKey,
+		mnemonic: mnemonic,
+		seed:     bip39.NewSeed(mnemonic, ""),
+	}, nil
+}
+
+// Mnemonic returns the application's 24-word BIP-39 mnemonic (English word list).
+func (r *Root) Mnemonic() string {
+	return r.mnemonic
+}
+
+// Seed returns the BIP-39 seed of the mnemonic, with an empty passphrase.
+func (r *Root) Seed() []byte {
+	return append([]byte(nil), r.seed...)
+}
+
+// Secp256k1 returns the BIP-32 secp256k1 private key at path, e.g. EthereumPath.
+func (r *Root) Secp256k1(path string) (*ecdsa.PrivateKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return deriveSecp256k1(r.seed, indexes)
+}
+
+// Ed25519 returns the SLIP-0010 ed25519 private key at path, e.g. SolanaPath. Every
+// index must be hardened.
+func (r *Root) Ed25519(path string) (ed25519.PrivateKey, error) {
+	indexes, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+	return deriveEd25519(r.seed, indexes)
+}
+
+// Secret returns size bytes derived from the app key for label, for keys that are not
+// wallet keys (e.g. a database encryption key). Different labels give independent
+// secrets, and none is related to the mnemonic.
+func (r *Root) Secret(label string, size int) ([]byte, error) {
+	if label == "" {
+		return nil, errors.New("secret label is required")
+	}
+	if size <= 0 || size > MaxSecretSize {
+		return nil, fmt.Errorf("secret size must be between 1 and %d bytes, got %d", MaxSecretSize, size)
+	}
+	return expand(r.appKey, secretInfoPrefix+label, size)
+}
+
+// expand derives size bytes from the app key with HKDF-SHA256 under info.
+func expand(appKey []byte, info string, size int) ([]byte, error) {
+	out := make([]byte, size)
+	if _, err := io.ReadFull(hkdf.New(sha256.New, appKey, []byte(hkdfSalt), []byte(info)), out); err != nil {
+		return nil, fmt.Errorf("failed to derive key using HKDF: %w", err)
+	}
+	return out, nil
+}
@@ -0,0 +1,173 @@
+package derive
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip39"
+)
+
+func Test_NewRoot(t *testing.T) {
+	qID, err := crypto.HashToG1("golden-app")
+	require.NoError(t, err)
+	appKey, err := crypto.ScalarMulG1(*qID, new(fr.Element).SetUint64(123456789))
+	require.NoError(t, err)
+
+	t.Run("golden vectors", func(t *testing.T) {
+		// Pinned: a change here changes every application's wallets
+		require.Equal(t, "a5537e61a2c19c9f9008c18577fa8a61c8a7347521adca51839ebe789793fad17fc1d4d93abb119bd39b89ca840726e9",
+			hex.EncodeToString(appKey.CompressedBytes))
+
+		root, err := NewRoot(*appKey)
+		require.NoError(t, err)
+		require.Equal(t, "marriage bright razor glass cry flag jelly royal nominee hotel bamboo bottom "+
+			"glove close lunar sunny system enroll satisfy cactus finger client cave armor", root.Mnemonic())
+		require.Equal(t, bip39.NewSeed(root.Mnemonic(), ""), root.Seed())
+
+		eth, err := root.Secp256k1(EthereumPath)
+		require.NoError(t, err)
+		require.Equal(t, "0x849D86d22eA3D0FA3BB5EFF81BB5BEB87030Ecc0", ethcrypto.PubkeyToAddress(eth.PublicKey).Hex())
+
+		sol, err := root.Ed25519(SolanaPath)
+		require.NoError(t, err)
+		require.Equal(t, "99bb6db84acd9f73b0328b4f94a6154404459124c4da4f0c65f941a821590a12", hex.EncodeToString(sol[32:]))
+
+		secret, err := root.Secret("db", 32)
+		require.NoError(t, err)
+		require.Equal(t, "2adb5e2edf643c960779b229a086514c13eb81cea0c0d7c1ebf30a6968f0f2d0", hex.EncodeToString(secret))
+	})
+
+	t.Run("keys are independent", func(t *testing.T) {
+		root, err := NewRoot(*appKey)
+		require.NoError(t, err)
+
+		a, err := root.Secret("a", 32)
+		require.NoError(t, err)
+		b, err := root.Secret("b", 32)
+		require.NoError(t, err)
+		require.NotEqual(t, a, b)
+
+		account0, err := root.Secp256k1(EthereumPath)
+		require.NoError(t, err)
+		account1, err := root.Secp256k1("m/44'/60'/0'/0/1")
+		require.NoError(t, err)
+		require.NotEqual(t, account0.D, account1.D)
+
+		other, err := crypto.ScalarMulG1(*qID, new(fr.Element).SetUint64(987654321))
+		require.NoError(t, err)
+		otherRoot, err := NewRoot(*other)
+		require.NoError(t, err)
+		require.NotEqual(t, root.Mnemonic(), otherRoot.Mnemonic())
+
+		// Seed returns a copy
+		seed := root.Seed()
+		seed[0] ^= 0xff
+		require.NotEqual(t, seed, root.Seed())
+	})
+
+	t.Run("invalid inputs", func(t *testing.T) {
+		_, err := NewRoot(*types.ZeroG1Point())
+		require.ErrorContains(t, err, "point at infinity")
+		_, err = NewRoot(types.G1Point{CompressedBytes: []byte{1, 2, 3}})
+		require.ErrorContains(t, err, "invalid app private key")
+
+		root, err := NewRoot(*appKey)
+		require.NoError(t, err)
+		_, err = root.Secret("", 32)
+		require.Error(t, err)
+		_, err = root.Secret("db", 0)
+		require.Error(t, err)
+		_, err = root.Secret("db", MaxSecretSize+1)
+		require.Error(t, err)
+		_, err = root.Ed25519("m/44'/501'/0")
+		require.ErrorContains(t, err, "hardened")
+	})
+}
+
+func Test_BIP39(t *testing.T) {
+	// BIP-39 reference vector, checking the library encodes as the spec does
+	mnemonic, err := bip39.NewMnemonic(make([]byte, 16))
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("abandon ", 11)+"about", mnemonic)
+	require.Equal(t, "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		hex.EncodeToString(bip39.NewSeed(mnemonic, "TREZOR")))
+}
+
+func Test_Secp256k1(t *testing.T) {
+	// BIP-32 test vector 1
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	vectors := []struct {
+		path string
+		key  string
+	}{
+		{"m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{"m/0H", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{"m/0H/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+		{"m/0H/1/2H", "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca"},
+		{"m/0H/1/2H/2", "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4"},
+		{"m/0H/1/2H/2/1000000000", "471b76e389e528d6de6d816857e012c5455051cad6660850e58372a6c3e6e7c8"},
+	}
+	for _, v := range vectors {
+		t.Run(v.path, func(t *testing.T) {
+			indexes, err := ParsePath(v.path)
+			require.NoError(t, err)
+			key, err := deriveSecp256k1(seed, indexes)
+			require.NoError(t, err)
+			require.Equal(t, v.key, hex.EncodeToString(ethcrypto.FromECDSA(key)))
+		})
+	}
+}
+
+func Test_Ed25519(t *testing.T) {
+	// SLIP-0010 ed25519 test vector 1
+	seed, err := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	require.NoError(t, err)
+
+	vectors := []struct {
+		path string
+		key  string
+	}{
+		{"m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{"m/0H", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{"m/0H/1H", "b1d0bad404bf35da785a64ca1ac54b2617211d2777696fbffaf208f746ae84f2"},
+		{"m/0H/1H/2H", "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9"},
+		{"m/0H/1H/2H/2H", "30d1dc7e5fc04c31219ab25a27ae00b50f6fd66622f6e9c913253d6511d1e662"},
+		{"m/0H/1H/2H/2H/1000000000H", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+	}
+	for _, v := range vectors {
+		t.Run(v.path, func(t *testing.T) {
+			indexes, err := ParsePath(v.path)
+			require.NoError(t, err)
+			key, err := deriveEd25519(seed, indexes)
+			require.NoError(t, err)
+			require.Equal(t, v.key, hex.EncodeToString(key.Seed()))
+		})
+	}
+}
+
+func Test_ParsePath(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		indexes, err := ParsePath("m")
+		require.NoError(t, err)
+		require.Empty(t, indexes)
+
+		indexes, err = ParsePath("m/44'/60h/0H/0/2147483647")
+		require.NoError(t, err)
+		require.Equal(t, []uint32{44 + HardenedOffset, 60 + HardenedOffset, HardenedOffset, 0, 2147483647}, indexes)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, path := range []string{"", "/0", "M/0", "m/", "m//0", "m/-1", "m/+1", "m/01", "m/2147483648", "m/0''", "m/x"} {
+			_, err := ParsePath(path)
+			require.Error(t, err, "path %q", path)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package derive
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// HardenedOffset is added to an index to make it hardened (written i' or ih in a path).
+const HardenedOffset uint32 = 1 << 31
+
+// ParsePath parses a derivation path such as "m/44'/60'/0'/0/0" into child indexes.
+// Hardened indexes are marked with ', h or H.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("invalid derivation path %q: must start with m", path)
+	}
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h") || strings.HasSuffix(part, "H")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		i, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(i) >= HardenedOffset || part != strconv.FormatUint(i, 10) {
+			return nil, fmt.Errorf("invalid derivation path %q: bad index %q", path, part)
+		}
+		if hardened {
+			i += uint64(HardenedOffset)
+		}
+		indexes = append(indexes, uint32(i))
+	}
+	return indexes, nil
+}
+
+// deriveSecp256k1 derives the BIP-32 private key at indexes from seed. BIP-32 skips an
+// index whose child key is invalid; that happens with probability below 2^-127, so it
+// is reported as an error instead.
+func deriveSecp256k1(seed []byte, indexes []uint32) (*ecdsa.PrivateKey, error) {
+	n := ethcrypto.S256().Params().N
+
+	key, chainCode := hmacSHA512([]byte("Bitcoin seed"), seed)
+	k := new(big.Int).SetBytes(key)
+	if k.Sign() == 0 || k.Cmp(n) >= 0 {
+		return nil, errors.New("invalid BIP-32 master key")
+	}
+
+	for _, index := range indexes {
+		var data []byte
+		if index >= HardenedOffset {
+			data = append([]byte{0}, k.FillBytes(make([]byte, 32))...)
+		} else {
+			priv, err := ethcrypto.ToECDSA(k.FillBytes(make([]byte, 32)))
+			if err != nil {
+				return nil, err
+			}
+			data = ethcrypto.CompressPubkey(&priv.PublicKey)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		il, ir := hmacSHA512(chainCode, data)
+		tweak := new(big.Int).SetBytes(il)
+		if tweak.Cmp(n) >= 0 {
+			return nil, fmt.Errorf("invalid BIP-32 child key at index %d", index)
+		}
+		k = tweak.Add(tweak, k).Mod(tweak, n)
+		if k.Sign() == 0 {
+			return nil, fmt.Errorf("invalid BIP-32 child key at index %d", index)
+		}
+		chainCode = ir
+	}
+	return ethcrypto.ToECDSA(k.FillBytes(make([]byte, 32)))
+}
+
+// deriveEd25519 derives the SLIP-0010 ed25519 private key at indexes from seed. ed25519
+// has no public derivation, so every index must be hardened.
+func deriveEd25519(seed []byte, indexes []uint32) (ed25519.PrivateKey, error) {
+	key, chainCode := hmacSHA512([]byte("ed25519 seed"), seed)
+	for _, index := range indexes {
+		if index < HardenedOffset {
+			return nil, fmt.Errorf("ed25519 derivation requires hardened indexes, got %d", index)
+		}
+		data := append([]byte{0}, key...)
+		data = binary.BigEndian.AppendUint32(data, index)
+		key, chainCode = hmacSHA512(chainCode, data)
+	}
+	return ed25519.NewKeyFromSeed(key), nil
+}
+
+// hmacSHA512 returns the two halves of HMAC-SHA512(key, data).
+func hmacSHA512(key, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
@@ -0,0 +1,171 @@
+package dkg
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Complaint round
+//
+// After shares and commitments are exchanged, every receiver whose share from a dealer
+// is missing or fails VerifyShare broadcasts a signed complaint against that dealer.
+// The accused dealer answers an invalid-share complaint by revealing the disputed share
+// to every operator, so anyone can check it; revealing it leaks nothing new, as the
+// share the complainer holds is already public in effect. A missing share is never
+// revealed: the dealer re-sends it sealed to the complainer, and only the complainer can
+// check it. A dealer is disqualified when any complaint against it goes unanswered or is
+// answered with a share that fails verification; the remaining dealers form the
+// qualified set. Because a sealed answer is checked by the complainer alone, operators
+// can disagree on the set, so it is agreed among them before anything is finalized.
+
+// Justification is a dealer's answer to a complaint as one operator saw it. Share is the
+// share the dealer revealed, or, at the complainer, the sealed share it re-sent, once
+// opened; it is nil if the complainer could not open it. Sealed marks an answer sealed
+// to someone else, which this operator cannot check.
+type Justification struct {
+	Dealer     common.Address
+	Complainer common.Address
+	Share      *fr.Element
+	Sealed     bool
+}
+
+// ComplaintResolution is the outcome of the complaint round.
+type ComplaintResolution struct {
+	// Qualified lists the dealers whose contributions are kept, in input order.
+	Qualified []common.Address
+	// Disqualified maps each dropped dealer to the reason it was dropped.
+	Disqualified map[common.Address]string
+	// Revealed holds the verified justification shares, keyed by dealer then complainer.
+	// A complainer uses the share revealed for it in place of the one it complained about.
+	Revealed map[common.Address]map[common.Address]*fr.Element
+}
+
+// IsQualified reports whether dealer survived the complaint round.
+func (r *ComplaintResolution) IsQualified(dealer common.Address) bool {
+	_, dropped := r.Disqualified[dealer]
+	return !dropped
+}
+
+// RevealedShare returns the share dealer revealed for complainer, or nil.
+func (r *ComplaintResolution) RevealedShare(dealer, complainer common.Address) *fr.Element {
+	return r.Revealed[dealer][complainer]
+}
+
+// ResolveComplaints computes the qualified dealer set. A dealer is disqualified when it
+// has no commitments, or when a complaint against it has no justification whose share
+// verifies against its commitments for the complainer. A sealed justification settles a
+// missing-share or missing-commitments complaint, but never an invalid-share one, which
+// must be answered in public. Complaints and justifications naming dealers outside
+// dealers are ignored, as are justifications nobody asked for.
+func ResolveComplaints(
+	dealers []common.Address,
+	commitments map[common.Address][]types.G2Point,
+	complaints []*types.Complaint,
+	justifications []*Justification,
+) *ComplaintResolution {
+	res := &ComplaintResolution{
+		Qualified:    make([]common.Address, 0, len(dealers)),
+		Disqualified: make(map[common.Address]string),
+		Revealed:     make(map[common.Address]map[common.Address]*fr.Element),
+	}
+
+	// A dealer may justify the same complaint more than once (it answers every copy it
+	// receives); any one verifying share settles it.
+	justified := make(map[common.Address]map[common.Address][]*Justification)
+	for _, j := range justifications {
+		if j == nil {
+			continue
+		}
+		if justified[j.Dealer] == nil {
+			justified[j.Dealer] = make(map[common.Address][]*Justification)
+		}
+		justified[j.Dealer][j.Complainer] = append(justified[j.Dealer][j.Complainer], j)
+	}
+
+	against := make(map[common.Address][]*types.Complaint)
+	for _, c := range complaints {
+		if c != nil {
+			against[c.DealerAddress] = append(against[c.DealerAddress], c)
+		}
+	}
+
+	for _, dealer := range dealers {
+		dealerCommitments := commitments[dealer]
+		if len(dealerCommitments) == 0 {
+			res.Disqualified[dealer] = "no commitments received"
+			continue
+		}
+
+		var reason string
+		for _, c := range against[dealer] {
+			answers := justified[dealer][c.ComplainerAddress]
+			share := firstVerifiedShare(c.ComplainerAddress, answers, dealerCommitments)
+			if share == nil {
+				switch {
+				case len(answers) == 0:
+					reason = fmt.Sprintf("unanswered %s complaint from %s", c.Reason, c.ComplainerAddress.Hex())
+				case c.Reason != types.ComplaintReasonInvalidShare && anySealed(answers):
+					continue // re-sent to the complainer, which checks it
+				default:
+					reason = fmt.Sprintf("justification for %s fails verification", c.ComplainerAddress.Hex())
+				}
+				break
+			}
+			if res.Revealed[dealer] == nil {
+				res.Revealed[dealer] = make(map[common.Address]*fr.Element)
+			}
+			res.Revealed[dealer][c.ComplainerAddress] = share
+		}
+		if reason != "" {
+			res.Disqualified[dealer] = reason
+			delete(res.Revealed, dealer)
+			continue
+		}
+		res.Qualified = append(res.Qualified, dealer)
+	}
+
+	return res
+}
+
+func firstVerifiedShare(recipient common.Address, answers []*Justification, commitments []types.G2Point) *fr.Element {
+	for _, j := range answers {
+		if j.Share != nil && VerifyShareFor(recipient, j.Share, commitments) {
+			return j.Share
+		}
+	}
+	return nil
+}
+
+func anySealed(answers []*Justification) bool {
+	for _, j := range answers {
+		if j.Sealed {
+			return true
+		}
+	}
+	return false
+}
+
+// BuildComplaintSigningMessage returns the bytes a complainer signs:
+// dealer || complainer || sessionTimestamp || commitmentHash || len(keyID) || keyID || reason.
+// The key ID binds the complaint to one key's session, so it cannot be replayed into a
+// session of another key the same operators hold.
+func BuildComplaintSigningMessage(dealer, complainer common.Address, keyID string, sessionTimestamp int64, commitmentHash [32]byte, reason string) []byte {
+	if keyID == "" {
+		keyID = types.DefaultKeyID
+	}
+	msg := make([]byte, 0, 20+20+8+32+1+len(keyID)+len(reason))
+	sessionBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(sessionBytes, uint64(sessionTimestamp))
+	msg = append(msg, dealer.Bytes()...)
+	msg = append(msg, complainer.Bytes()...)
+	msg = append(msg, sessionBytes...)
+	msg = append(msg, commitmentHash[:]...)
+	msg = append(msg, byte(len(keyID)))
+	msg = append(msg, keyID...)
+	msg = append(msg, reason...)
+	return msg
+}
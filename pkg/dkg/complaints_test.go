@@ -0,0 +1,148 @@
+package dkg
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// complaintTestDealing has every operator deal once and returns the dealers, each
+// dealer's shares keyed by recipient, and each dealer's commitments.
+func complaintTestDealing(t *testing.T, n int) ([]common.Address, map[common.Address]map[common.Address]*fr.Element, map[common.Address][]types.G2Point) {
+	t.Helper()
+	operators := make([]*peering.OperatorSetPeer, n)
+	addrs := make([]common.Address, n)
+	for i := range operators {
+		addrs[i] = common.BigToAddress(big.NewInt(int64(0x100 + i)))
+		operators[i] = &peering.OperatorSetPeer{OperatorAddress: addrs[i]}
+	}
+
+	shares := make(map[common.Address]map[common.Address]*fr.Element, n)
+	commitments := make(map[common.Address][]types.G2Point, n)
+	for _, dealer := range addrs {
+		d := NewDKG(dealer, CalculateThreshold(n), operators)
+		s, c, err := d.GenerateShares()
+		require.NoError(t, err)
+		shares[dealer] = s
+		commitments[dealer] = c
+	}
+	return addrs, shares, commitments
+}
+
+func TestVerifyShareFor(t *testing.T) {
+	addrs, shares, commitments := complaintTestDealing(t, 3)
+	dealer, recipient, other := addrs[0], addrs[1], addrs[2]
+
+	assert.True(t, VerifyShareFor(recipient, shares[dealer][recipient], commitments[dealer]))
+	assert.False(t, VerifyShareFor(other, shares[dealer][recipient], commitments[dealer]), "share is bound to its recipient")
+	assert.False(t, VerifyShareFor(recipient, nil, commitments[dealer]))
+	assert.False(t, VerifyShareFor(recipient, shares[dealer][recipient], nil))
+}
+
+func TestResolveComplaints(t *testing.T) {
+	addrs, shares, commitments := complaintTestDealing(t, 4)
+	dealer, complainer := addrs[0], addrs[1]
+	complaint := &types.Complaint{DealerAddress: dealer, ComplainerAddress: complainer, Reason: types.ComplaintReasonInvalidShare}
+
+	t.Run("no complaints", func(t *testing.T) {
+		res := ResolveComplaints(addrs, commitments, nil, nil)
+		assert.Equal(t, addrs, res.Qualified)
+		assert.Empty(t, res.Disqualified)
+	})
+
+	t.Run("valid justification keeps dealer and reveals share", func(t *testing.T) {
+		bogus := fr.NewElement(42)
+		res := ResolveComplaints(addrs, commitments, []*types.Complaint{complaint}, []*Justification{
+			{Dealer: dealer, Complainer: complainer, Share: &bogus},
+			{Dealer: dealer, Complainer: complainer, Share: shares[dealer][complainer]},
+		})
+		assert.Equal(t, addrs, res.Qualified)
+		assert.True(t, res.IsQualified(dealer))
+		require.NotNil(t, res.RevealedShare(dealer, complainer))
+		assert.True(t, res.RevealedShare(dealer, complainer).Equal(shares[dealer][complainer]))
+	})
+
+	t.Run("unanswered complaint disqualifies dealer", func(t *testing.T) {
+		res := ResolveComplaints(addrs, commitments, []*types.Complaint{complaint}, nil)
+		assert.Equal(t, addrs[1:], res.Qualified)
+		assert.False(t, res.IsQualified(dealer))
+		assert.Contains(t, res.Disqualified[dealer], "unanswered")
+	})
+
+	t.Run("justification that fails verification disqualifies dealer", func(t *testing.T) {
+		// The share dealt to someone else does not verify for the complainer.
+		res := ResolveComplaints(addrs, commitments, []*types.Complaint{complaint}, []*Justification{
+			{Dealer: dealer, Complainer: complainer, Share: shares[dealer][addrs[2]]},
+		})
+		assert.False(t, res.IsQualified(dealer))
+		assert.Contains(t, res.Disqualified[dealer], "fails verification")
+		assert.Nil(t, res.RevealedShare(dealer, complainer))
+	})
+
+	t.Run("one unanswered complaint outweighs answered ones", func(t *testing.T) {
+		second := &types.Complaint{DealerAddress: dealer, ComplainerAddress: addrs[2], Reason: types.ComplaintReasonMissingShare}
+		res := ResolveComplaints(addrs, commitments, []*types.Complaint{complaint, second}, []*Justification{
+			{Dealer: dealer, Complainer: complainer, Share: shares[dealer][complainer]},
+		})
+		assert.False(t, res.IsQualified(dealer))
+		assert.Nil(t, res.RevealedShare(dealer, complainer))
+	})
+
+	t.Run("sealed answer settles a missing share but not an invalid one", func(t *testing.T) {
+		missing := &types.Complaint{DealerAddress: dealer, ComplainerAddress: complainer, Reason: types.ComplaintReasonMissingShare}
+		sealed := []*Justification{{Dealer: dealer, Complainer: complainer, Sealed: true}}
+
+		res := ResolveComplaints(addrs, commitments, []*types.Complaint{missing}, sealed)
+		assert.True(t, res.IsQualified(dealer))
+		assert.Nil(t, res.RevealedShare(dealer, complainer), "nothing was revealed")
+
+		res = ResolveComplaints(addrs, commitments, []*types.Complaint{complaint}, sealed)
+		assert.False(t, res.IsQualified(dealer), "an invalid share must be answered in public")
+	})
+
+	t.Run("complainer that cannot open the re-sent share disqualifies dealer", func(t *testing.T) {
+		missing := &types.Complaint{DealerAddress: dealer, ComplainerAddress: complainer, Reason: types.ComplaintReasonMissingShare}
+		res := ResolveComplaints(addrs, commitments, []*types.Complaint{missing}, []*Justification{
+			{Dealer: dealer, Complainer: complainer},
+		})
+		assert.False(t, res.IsQualified(dealer))
+		assert.Contains(t, res.Disqualified[dealer], "fails verification")
+	})
+
+	t.Run("dealer without commitments is disqualified", func(t *testing.T) {
+		partial := make(map[common.Address][]types.G2Point)
+		for addr, c := range commitments {
+			if addr != addrs[3] {
+				partial[addr] = c
+			}
+		}
+		res := ResolveComplaints(addrs, partial, nil, nil)
+		assert.Equal(t, addrs[:3], res.Qualified)
+		assert.False(t, res.IsQualified(addrs[3]))
+	})
+
+	t.Run("complaints against non-dealers are ignored", func(t *testing.T) {
+		outsider := &types.Complaint{DealerAddress: common.HexToAddress("0xdead"), ComplainerAddress: complainer}
+		res := ResolveComplaints(addrs, commitments, []*types.Complaint{outsider}, nil)
+		assert.Equal(t, addrs, res.Qualified)
+	})
+}
+
+func TestBuildComplaintSigningMessage(t *testing.T) {
+	dealer := common.HexToAddress("0x01")
+	complainer := common.HexToAddress("0x02")
+	hash := [32]byte{7}
+
+	msg := BuildComplaintSigningMessage(dealer, complainer, types.DefaultKeyID, 99, hash, types.ComplaintReasonInvalidShare)
+	assert.Len(t, msg, 20+20+8+32+1+len(types.DefaultKeyID)+len(types.ComplaintReasonInvalidShare))
+	assert.NotEqual(t, msg, BuildComplaintSigningMessage(dealer, complainer, types.DefaultKeyID, 99, hash, types.ComplaintReasonMissingShare))
+	assert.NotEqual(t, msg, BuildComplaintSigningMessage(complainer, dealer, types.DefaultKeyID, 99, hash, types.ComplaintReasonInvalidShare))
+	assert.NotEqual(t, msg, BuildComplaintSigningMessage(dealer, complainer, "other", 99, hash, types.ComplaintReasonInvalidShare))
+	assert.Equal(t, msg, BuildComplaintSigningMessage(dealer, complainer, "", 99, hash, types.ComplaintReasonInvalidShare))
+}
@@ -72,16 +72,23 @@ func (d *DKG) GenerateShares() (map[common.Address]*fr.Element, []types.G2Point,
 
 // VerifyShare verifies a share against commitments using polynomial commitment verification
 func (d *DKG) VerifyShare(share *fr.Element, commitments []types.G2Point) bool {
+	return VerifyShareFor(d.nodeAddress, share, commitments)
+}
+
+// VerifyShareFor verifies the share a dealer dealt to recipient against the dealer's
+// commitments. Unlike VerifyShare it is not bound to this node, so it also checks shares
+// revealed publicly for other operators during the complaint round.
+func VerifyShareFor(recipient common.Address, share *fr.Element, commitments []types.G2Point) bool {
 	if len(commitments) == 0 || share == nil {
 		return false
 	}
-	// Verify: share * G2 == Σ(commitment_k * nodeAddress^k)
+	// Verify: share * G2 == Σ(commitment_k * recipient^k)
 	leftSide, err := crypto.ScalarMulG2(crypto.G2Generator, share)
 	if err != nil {
 		return false
 	}
 
-	jFr := bls.AddressToFr(d.nodeAddress)
+	jFr := bls.AddressToFr(recipient)
 	jPower := new(fr.Element).SetOne()
 	rightSide := commitments[0]
 
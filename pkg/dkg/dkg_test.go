@@ -217,7 +217,7 @@ func testCreateAcknowledgement(t *testing.T) {
 		return []byte("mock-signature")
 	}
 
-	ack := crypto.CreateAcknowledgement(playerAddr, dealerAddr, epoch, &share, commitments, signer)
+	ack := crypto.CreateAcknowledgement(playerAddr, dealerAddr, types.DefaultKeyID, epoch, &share, commitments, signer)
 
 	require.NotNil(t, ack, "Expected non-nil acknowledgement")
 	require.Equal(t, playerAddr, ack.PlayerAddress)
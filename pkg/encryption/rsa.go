@@ -1,6 +1,8 @@
 package encryption
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/sha256"
@@ -25,6 +27,111 @@ func NewRSAEncryption() *RSAEncryption {
 
 // Encrypt encrypts data with RSA public key using OAEP padding
 func (e *RSAEncryption) Encrypt(plaintext, publicKeyPEM []byte) ([]byte, error) {
+	rsaPubKey, err := parseRSAPublicKey(publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// Encrypt using OAEP with SHA-256
+	ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, rsaPubKey, plaintext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("encryption failed: %w", err)
+	}
+
+	return ciphertext, nil
+}
+
+// Decrypt decrypts data with RSA private key using OAEP padding
+func (e *RSAEncryption) Decrypt(ciphertext, privateKeyPEM []byte) ([]byte, error) {
+	privkey, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decrypt using OAEP with SHA-256
+	plaintext, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privkey, ciphertext, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decryption failed: %w", err)
+	}
+
+	return plaintext, nil
+}
+
+// EncryptHybrid encrypts data of any size to an RSA public key: a fresh AES-256 key,
+// encrypted with RSA-OAEP, followed by the data sealed with AES-GCM under that key.
+// Encrypt is limited to a few hundred bytes by the RSA modulus.
+//
+// Format: RSA-OAEP(key) (modulus size) || nonce (12 bytes) || AES-GCM ciphertext
+func (e *RSAEncryption) EncryptHybrid(plaintext, publicKeyPEM []byte) ([]byte, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	encryptedKey, err := e.Encrypt(key, publicKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	out := make([]byte, 0, len(encryptedKey)+len(nonce)+len(plaintext)+gcm.Overhead())
+	out = append(out, encryptedKey...)
+	out = append(out, nonce...)
+	return gcm.Seal(out, nonce, plaintext, encryptedKey), nil
+}
+
+// DecryptHybrid decrypts data encrypted with EncryptHybrid
+func (e *RSAEncryption) DecryptHybrid(ciphertext, privateKeyPEM []byte) ([]byte, error) {
+	privkey, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	keySize := privkey.Size()
+	if len(ciphertext) < keySize {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	encryptedKey := ciphertext[:keySize]
+	key, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privkey, encryptedKey, nil)
+	if err != nil {
+		return nil, fmt.Errorf("decryption failed: %w", err)
+	}
+
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	rest := ciphertext[keySize:]
+	if len(rest) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, fmt.Errorf("ciphertext too short")
+	}
+	plaintext, err := gcm.Open(nil, rest[:gcm.NonceSize()], rest[gcm.NonceSize():], encryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("decryption failed: %w", err)
+	}
+	return plaintext, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// parseRSAPublicKey parses a PEM-encoded PKIX RSA public key of at least MinRSAKeyBits
+func parseRSAPublicKey(publicKeyPEM []byte) (*rsa.PublicKey, error) {
 	// Parse PEM-encoded public key
 	block, _ := pem.Decode(publicKeyPEM)
 	if block == nil {
@@ -48,17 +155,12 @@ func (e *RSAEncryption) Encrypt(plaintext, publicKeyPEM []byte) ([]byte, error)
 		return nil, fmt.Errorf("RSA key too weak: %d bits (minimum %d)", keyBits, MinRSAKeyBits)
 	}
 
-	// Encrypt using OAEP with SHA-256
-	ciphertext, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, rsaPubKey, plaintext, nil)
-	if err != nil {
-		return nil, fmt.Errorf("encryption failed: %w", err)
-	}
-
-	return ciphertext, nil
+	return rsaPubKey, nil
 }
 
-// Decrypt decrypts data with RSA private key using OAEP padding
-func (e *RSAEncryption) Decrypt(ciphertext, privateKeyPEM []byte) ([]byte, error) {
+// parseRSAPrivateKey parses a PEM-encoded PKCS1 or PKCS8 RSA private key of at least
+// MinRSAKeyBits
+func parseRSAPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
 	// Parse PEM-encoded private key
 	block, _ := pem.Decode(privateKeyPEM)
 	if block == nil {
@@ -87,13 +189,7 @@ func (e *RSAEncryption) Decrypt(ciphertext, privateKeyPEM []byte) ([]byte, error
 		return nil, fmt.Errorf("RSA key too weak: %d bits (minimum %d)", keyBits, MinRSAKeyBits)
 	}
 
-	// Decrypt using OAEP with SHA-256
-	plaintext, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, privkey, ciphertext, nil)
-	if err != nil {
-		return nil, fmt.Errorf("decryption failed: %w", err)
-	}
-
-	return plaintext, nil
+	return privkey, nil
 }
 
 // GenerateKeyPair generates a new RSA key pair.
@@ -0,0 +1,40 @@
+package encryption
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRSAEncryption_Hybrid(t *testing.T) {
+	privPEM, pubPEM, err := GenerateKeyPair(2048)
+	require.NoError(t, err)
+	e := NewRSAEncryption()
+
+	// Well past what OAEP alone takes with a 2048-bit key
+	plaintext := bytes.Repeat([]byte{0xab}, 64*576)
+	_, err = e.Encrypt(plaintext, pubPEM)
+	require.Error(t, err)
+
+	ct, err := e.EncryptHybrid(plaintext, pubPEM)
+	require.NoError(t, err)
+	got, err := e.DecryptHybrid(ct, privPEM)
+	require.NoError(t, err)
+	require.Equal(t, plaintext, got)
+
+	// The encrypted key is authenticated along with the data
+	for _, offset := range []int{0, 255, 256, len(ct) - 1} {
+		tampered := bytes.Clone(ct)
+		tampered[offset] ^= 0x01
+		_, err := e.DecryptHybrid(tampered, privPEM)
+		require.Error(t, err, "offset %d", offset)
+	}
+	_, err = e.DecryptHybrid(ct[:256+12], privPEM)
+	require.Error(t, err)
+
+	otherPriv, _, err := GenerateKeyPair(2048)
+	require.NoError(t, err)
+	_, err = e.DecryptHybrid(ct, otherPriv)
+	require.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package encryption
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"slices"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+)
+
+// shareContextDomain prefixes every share-encryption context so ciphertexts produced
+// here can never be confused with ECIES payloads from another protocol. v2 added the
+// key ID and operator set to the context.
+const shareContextDomain = "eigenx-kms/share-encryption/v2"
+
+// Share-encryption labels distinguish the protocol a share was dealt in. The label is
+// part of the ECIES context, so a DKG ciphertext cannot be replayed as a reshare share.
+const (
+	ShareLabelDKG     = "dkg"
+	ShareLabelReshare = "reshare"
+)
+
+// GenerateShareKey creates a fresh secp256k1 ECIES key pair used to receive
+// DKG/reshare shares.
+func GenerateShareKey() (*ecies.PrivateKey, error) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate share encryption key: %w", err)
+	}
+	return ecies.ImportECDSA(key), nil
+}
+
+// MarshalSharePublicKey encodes a share encryption public key as an uncompressed
+// 65-byte secp256k1 point.
+func MarshalSharePublicKey(pub *ecies.PublicKey) []byte {
+	return crypto.FromECDSAPub(pub.ExportECDSA())
+}
+
+// ParseSharePublicKey decodes a public key produced by MarshalSharePublicKey.
+func ParseSharePublicKey(b []byte) (*ecies.PublicKey, error) {
+	pub, err := crypto.UnmarshalPubkey(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid share encryption public key: %w", err)
+	}
+	return ecies.ImportECDSAPublic(pub), nil
+}
+
+// ShareBinding is what a share ciphertext is bound to. Both sides derive it
+// independently: the dealer from the session it deals in, the recipient from the
+// authenticated message and its own session.
+type ShareBinding struct {
+	Label            string // ShareLabelDKG or ShareLabelReshare
+	KeyID            string // key the session belongs to
+	From             common.Address
+	To               common.Address
+	SessionTimestamp int64
+	// Operators is the session's operator set, in any order.
+	Operators []common.Address
+}
+
+// Context returns the byte string fed into both the ECIES KDF and MAC, so decrypting
+// under any other binding fails authentication.
+func (b ShareBinding) Context() []byte {
+	operatorSet := OperatorSetDigest(b.Operators)
+	ctx := make([]byte, 0, len(shareContextDomain)+1+len(b.Label)+1+len(b.KeyID)+1+2*common.AddressLength+8+len(operatorSet))
+	ctx = append(ctx, shareContextDomain...)
+	ctx = append(ctx, 0)
+	ctx = append(ctx, b.Label...)
+	ctx = append(ctx, 0)
+	ctx = append(ctx, b.KeyID...)
+	ctx = append(ctx, 0)
+	ctx = append(ctx, b.From.Bytes()...)
+	ctx = append(ctx, b.To.Bytes()...)
+	ctx = binary.BigEndian.AppendUint64(ctx, uint64(b.SessionTimestamp))
+	ctx = append(ctx, operatorSet...)
+	return ctx
+}
+
+// OperatorSetDigest hashes an operator set independently of its order.
+func OperatorSetDigest(operators []common.Address) []byte {
+	sorted := slices.Clone(operators)
+	slices.SortFunc(sorted, func(a, b common.Address) int { return bytes.Compare(a.Bytes(), b.Bytes()) })
+	buf := make([]byte, 0, len(sorted)*common.AddressLength)
+	for _, addr := range sorted {
+		buf = append(buf, addr.Bytes()...)
+	}
+	return crypto.Keccak256(buf)
+}
+
+// EncryptShare encrypts a share to the recipient's share encryption key under binding.
+func EncryptShare(pub *ecies.PublicKey, share *fr.Element, binding ShareBinding) ([]byte, error) {
+	if pub == nil {
+		return nil, fmt.Errorf("recipient share encryption key is nil")
+	}
+	if share == nil {
+		return nil, fmt.Errorf("share is nil")
+	}
+	plaintext := share.Bytes()
+	shareCtx := binding.Context()
+	ciphertext, err := ecies.Encrypt(rand.Reader, pub, plaintext[:], shareCtx, shareCtx)
+	if err != nil {
+		return nil, fmt.Errorf("share encryption failed: %w", err)
+	}
+	return ciphertext, nil
+}
+
+// DecryptShare reverses EncryptShare. It fails if the ciphertext was produced for a
+// different key or under a different binding, and rejects plaintexts that are not a
+// canonical field element.
+func DecryptShare(priv *ecies.PrivateKey, ciphertext []byte, binding ShareBinding) (*fr.Element, error) {
+	if priv == nil {
+		return nil, fmt.Errorf("share decryption key is nil")
+	}
+	shareCtx := binding.Context()
+	plaintext, err := priv.Decrypt(ciphertext, shareCtx, shareCtx)
+	if err != nil {
+		return nil, fmt.Errorf("share decryption failed: %w", err)
+	}
+	if len(plaintext) != fr.Bytes {
+		return nil, fmt.Errorf("decrypted share has invalid length %d", len(plaintext))
+	}
+	share := new(fr.Element)
+	if err := share.SetBytesCanonical(plaintext); err != nil {
+		return nil, fmt.Errorf("decrypted share is not a canonical field element: %w", err)
+	}
+	return share, nil
+}
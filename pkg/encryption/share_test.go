@@ -0,0 +1,98 @@
+package encryption
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShareEncryption_RoundTrip(t *testing.T) {
+	key, err := GenerateShareKey()
+	require.NoError(t, err)
+
+	pub, err := ParseSharePublicKey(MarshalSharePublicKey(&key.PublicKey))
+	require.NoError(t, err)
+
+	binding := testBinding(ShareLabelDKG)
+	share := new(fr.Element).SetUint64(424242)
+
+	ct, err := EncryptShare(pub, share, binding)
+	require.NoError(t, err)
+	plain := share.Bytes()
+	require.NotContains(t, string(ct), string(plain[:]), "ciphertext must not contain the plaintext share")
+
+	got, err := DecryptShare(key, ct, binding)
+	require.NoError(t, err)
+	require.True(t, got.Equal(share))
+}
+
+func testBinding(label string) ShareBinding {
+	return ShareBinding{
+		Label:            label,
+		KeyID:            "default",
+		From:             common.HexToAddress("0x01"),
+		To:               common.HexToAddress("0x02"),
+		SessionTimestamp: 1000,
+		Operators:        []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")},
+	}
+}
+
+// A ciphertext is bound to its ShareBinding: replaying it under any other binding must
+// fail authentication rather than yield a share.
+func TestShareEncryption_ContextBinding(t *testing.T) {
+	key, err := GenerateShareKey()
+	require.NoError(t, err)
+
+	binding := testBinding(ShareLabelReshare)
+	other := common.HexToAddress("0x04")
+	ct, err := EncryptShare(&key.PublicKey, new(fr.Element).SetUint64(7), binding)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		modify func(b *ShareBinding)
+	}{
+		{"different session", func(b *ShareBinding) { b.SessionTimestamp++ }},
+		{"different sender", func(b *ShareBinding) { b.From = other }},
+		{"different recipient", func(b *ShareBinding) { b.To = other }},
+		{"different protocol", func(b *ShareBinding) { b.Label = ShareLabelDKG }},
+		{"different key", func(b *ShareBinding) { b.KeyID = "treasury" }},
+		{"different operator set", func(b *ShareBinding) { b.Operators = append(slices.Clone(b.Operators), other) }},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			replayed := binding
+			tc.modify(&replayed)
+			_, err := DecryptShare(key, ct, replayed)
+			require.Error(t, err)
+		})
+	}
+
+	t.Run("operator order does not matter", func(t *testing.T) {
+		reordered := binding
+		reordered.Operators = []common.Address{binding.Operators[2], binding.Operators[0], binding.Operators[1]}
+		_, err := DecryptShare(key, ct, reordered)
+		require.NoError(t, err)
+	})
+}
+
+func TestShareEncryption_WrongKey(t *testing.T) {
+	key, err := GenerateShareKey()
+	require.NoError(t, err)
+	otherKey, err := GenerateShareKey()
+	require.NoError(t, err)
+
+	ct, err := EncryptShare(&key.PublicKey, new(fr.Element).SetUint64(9), testBinding(ShareLabelDKG))
+	require.NoError(t, err)
+
+	_, err = DecryptShare(otherKey, ct, testBinding(ShareLabelDKG))
+	require.Error(t, err)
+}
+
+func TestParseSharePublicKey_Invalid(t *testing.T) {
+	_, err := ParseSharePublicKey([]byte{0x04, 0x01})
+	require.Error(t, err)
+}
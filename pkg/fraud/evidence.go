@@ -0,0 +1,213 @@
+package fraud
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+
+	eigenxcrypto "github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/merkle"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrNoEquivocation is returned when an ack transcript holds no two acks that commit to
+// different dealings.
+var ErrNoEquivocation = errors.New("no equivocation in acknowledgements")
+
+// EvidenceID names an evidence bundle. It is stable for a given (session, kind, dealer)
+// so recording the same fraud twice overwrites the first bundle.
+func EvidenceID(kind string, sessionTimestamp int64, dealer common.Address) string {
+	return fmt.Sprintf("%d-%s-%s", sessionTimestamp, kind, strings.ToLower(dealer.Hex()))
+}
+
+// NewInvalidShareEvidence builds the bundle for a share from dealer that does not verify
+// against the dealer's commitments. The caller attaches the signed messages and ack
+// transcript it holds.
+func NewInvalidShareEvidence(
+	protocol string,
+	sessionTimestamp int64,
+	dealer common.Address,
+	reporter common.Address,
+	share *fr.Element,
+	commitments []types.G2Point,
+) *types.FraudEvidence {
+	ev := &types.FraudEvidence{
+		ID:               EvidenceID(types.FraudKindInvalidShare, sessionTimestamp, dealer),
+		Kind:             types.FraudKindInvalidShare,
+		Protocol:         protocol,
+		SessionTimestamp: sessionTimestamp,
+		DealerAddress:    dealer,
+		ReporterAddress:  reporter,
+		Commitments:      commitments,
+	}
+	if share != nil {
+		ev.Share = types.SerializeFr(share)
+	}
+	return ev
+}
+
+// FindEquivocation returns two acks for dealer, from different players, that commit to
+// different commitment hashes: the dealer showed different polynomials to different
+// players. The pair is chosen deterministically (lowest player address in each of the
+// first two commitment hashes, by sorted player order). Returns ErrNoEquivocation if all
+// of the dealer's acks agree.
+func FindEquivocation(dealer common.Address, acks []*types.Acknowledgement) (*types.Acknowledgement, *types.Acknowledgement, error) {
+	var first *types.Acknowledgement
+	for _, ack := range merkle.SortAcknowledgements(acks) {
+		if ack == nil || ack.DealerAddress != dealer {
+			continue
+		}
+		if first == nil {
+			first = ack
+			continue
+		}
+		if ack.CommitmentHash != first.CommitmentHash && ack.PlayerAddress != first.PlayerAddress {
+			return first, ack, nil
+		}
+	}
+	return nil, nil, ErrNoEquivocation
+}
+
+// NewEquivocationEvidence builds the bundle for a dealer whose ack transcript (the full
+// ack list from its commitment broadcast) contains acks for different commitments. acks
+// must be the exact set the dealer built its on-chain ack merkle tree from, so the
+// inclusion proofs verify against the root it submitted.
+func NewEquivocationEvidence(
+	protocol string,
+	sessionTimestamp int64,
+	dealer common.Address,
+	reporter common.Address,
+	acks []*types.Acknowledgement,
+) (*types.FraudEvidence, error) {
+	ack1, ack2, err := FindEquivocation(dealer, acks)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := merkle.BuildMerkleTree(acks)
+	if err != nil {
+		return nil, fmt.Errorf("failed to rebuild ack merkle tree: %w", err)
+	}
+	sorted := merkle.SortAcknowledgements(acks)
+
+	conflicting := make([]*types.AcknowledgementProof, 0, 2)
+	for _, ack := range []*types.Acknowledgement{ack1, ack2} {
+		index := -1
+		for i, candidate := range sorted {
+			if candidate == ack {
+				index = i
+				break
+			}
+		}
+		proof, err := tree.GenerateProof(index)
+		if err != nil {
+			return nil, fmt.Errorf("failed to prove ack from %s: %w", ack.PlayerAddress.Hex(), err)
+		}
+		conflicting = append(conflicting, &types.AcknowledgementProof{
+			Ack:       ack,
+			LeafIndex: proof.LeafIndex,
+			Proof:     proof.Proof,
+		})
+	}
+
+	return &types.FraudEvidence{
+		ID:               EvidenceID(types.FraudKindEquivocation, sessionTimestamp, dealer),
+		Kind:             types.FraudKindEquivocation,
+		Protocol:         protocol,
+		KeyID:            ack1.KeyID,
+		SessionTimestamp: sessionTimestamp,
+		DealerAddress:    dealer,
+		ReporterAddress:  reporter,
+		Acknowledgements: acks,
+		ConflictingAcks:  conflicting,
+		AckMerkleRoot:    tree.Root,
+	}, nil
+}
+
+// VerifyEvidence re-checks a bundle from its own contents, without trusting the reporter:
+// an invalid share must really fail verification for the reporter, and conflicting acks
+// must really conflict and be included under the recorded ack root. Signatures on the
+// attached messages are checked by the node on receipt and are not re-verified here,
+// beyond requiring that each message's hash matches its payload.
+func VerifyEvidence(ev *types.FraudEvidence) error {
+	if ev == nil {
+		return fmt.Errorf("evidence is nil")
+	}
+	if ev.DealerAddress == (common.Address{}) {
+		return fmt.Errorf("evidence has no dealer")
+	}
+	if ev.DealerAddress == ev.ReporterAddress {
+		return fmt.Errorf("reporter %s cannot accuse itself", ev.ReporterAddress.Hex())
+	}
+	for name, msg := range map[string]*types.AuthenticatedMessage{
+		"signed commitments": ev.SignedCommitments,
+		"signed share":       ev.SignedShare,
+	} {
+		if msg != nil && !bytes.Equal(msg.Hash[:], crypto.Keccak256(msg.Payload)) {
+			return fmt.Errorf("%s hash does not match payload", name)
+		}
+	}
+
+	switch ev.Kind {
+	case types.FraudKindInvalidShare:
+		return verifyInvalidShare(ev)
+	case types.FraudKindEquivocation:
+		return verifyEquivocation(ev)
+	default:
+		return fmt.Errorf("unknown fraud kind %q", ev.Kind)
+	}
+}
+
+func verifyInvalidShare(ev *types.FraudEvidence) error {
+	if ev.Share == nil {
+		return fmt.Errorf("invalid-share evidence has no share")
+	}
+	if len(ev.Commitments) == 0 {
+		return fmt.Errorf("invalid-share evidence has no commitments")
+	}
+	if dkg.VerifyShareFor(ev.ReporterAddress, types.DeserializeFr(ev.Share), ev.Commitments) {
+		return fmt.Errorf("share verifies against the dealer's commitments")
+	}
+	return nil
+}
+
+func verifyEquivocation(ev *types.FraudEvidence) error {
+	if len(ev.ConflictingAcks) != 2 {
+		return fmt.Errorf("equivocation evidence needs 2 conflicting acks, got %d", len(ev.ConflictingAcks))
+	}
+	a, b := ev.ConflictingAcks[0], ev.ConflictingAcks[1]
+	if a == nil || a.Ack == nil || b == nil || b.Ack == nil {
+		return fmt.Errorf("equivocation evidence has an empty ack")
+	}
+	if a.Ack.PlayerAddress == b.Ack.PlayerAddress {
+		return fmt.Errorf("conflicting acks are from the same player")
+	}
+	if a.Ack.CommitmentHash == b.Ack.CommitmentHash {
+		return fmt.Errorf("conflicting acks commit to the same commitments")
+	}
+	for _, p := range ev.ConflictingAcks {
+		if p.Ack.DealerAddress != ev.DealerAddress {
+			return fmt.Errorf("ack from %s names dealer %s", p.Ack.PlayerAddress.Hex(), p.Ack.DealerAddress.Hex())
+		}
+		if p.Ack.SessionTimestamp != ev.SessionTimestamp {
+			return fmt.Errorf("ack from %s is for session %d", p.Ack.PlayerAddress.Hex(), p.Ack.SessionTimestamp)
+		}
+		if !types.SameKeyID(p.Ack.KeyID, ev.KeyID) {
+			return fmt.Errorf("ack from %s is for key %q", p.Ack.PlayerAddress.Hex(), p.Ack.KeyID)
+		}
+		proof := &merkle.MerkleProof{
+			LeafIndex: p.LeafIndex,
+			Leaf:      eigenxcrypto.HashAcknowledgementForMerkle(p.Ack),
+			Proof:     p.Proof,
+		}
+		if !merkle.VerifyProof(proof, ev.AckMerkleRoot) {
+			return fmt.Errorf("ack from %s is not included under the ack root", p.Ack.PlayerAddress.Hex())
+		}
+	}
+	return nil
+}
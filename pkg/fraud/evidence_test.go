@@ -0,0 +1,118 @@
+package fraud
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// equivocatingAcks returns the acks four players sent dealer, where the dealer showed
+// players 2 and 3 a different polynomial than players 0 and 1.
+func equivocatingAcks(dealer common.Address, session int64) []*types.Acknowledgement {
+	acks := make([]*types.Acknowledgement, 4)
+	for i := range acks {
+		commitmentHash := [32]byte{0xAA}
+		if i >= 2 {
+			commitmentHash = [32]byte{0xBB}
+		}
+		acks[i] = &types.Acknowledgement{
+			DealerAddress:    dealer,
+			PlayerAddress:    common.BigToAddress(big.NewInt(int64(0x300 + i))),
+			SessionTimestamp: session,
+			ShareHash:        [32]byte{byte(i + 1)},
+			CommitmentHash:   commitmentHash,
+		}
+	}
+	return acks
+}
+
+func TestFindEquivocation(t *testing.T) {
+	dealer := common.HexToAddress("0x0D")
+	acks := equivocatingAcks(dealer, 100)
+
+	a, b, err := FindEquivocation(dealer, acks)
+	require.NoError(t, err)
+	assert.Equal(t, acks[0], a)
+	assert.Equal(t, acks[2], b)
+
+	_, _, err = FindEquivocation(dealer, acks[:2])
+	assert.ErrorIs(t, err, ErrNoEquivocation)
+
+	_, _, err = FindEquivocation(common.HexToAddress("0x0E"), acks)
+	assert.ErrorIs(t, err, ErrNoEquivocation, "acks for another dealer are ignored")
+}
+
+func TestEquivocationEvidence(t *testing.T) {
+	dealer := common.HexToAddress("0x0D")
+	reporter := common.HexToAddress("0x0E")
+	ev, err := NewEquivocationEvidence("dkg", 100, dealer, reporter, equivocatingAcks(dealer, 100))
+	require.NoError(t, err)
+	require.NoError(t, VerifyEvidence(ev))
+	assert.Equal(t, EvidenceID(types.FraudKindEquivocation, 100, dealer), ev.ID)
+	assert.Len(t, ev.Acknowledgements, 4)
+
+	t.Run("wrong root", func(t *testing.T) {
+		bad := *ev
+		bad.AckMerkleRoot = [32]byte{1}
+		assert.ErrorContains(t, VerifyEvidence(&bad), "not included")
+	})
+	t.Run("same commitments", func(t *testing.T) {
+		bad := *ev
+		second := *ev.ConflictingAcks[1]
+		ack := *second.Ack
+		ack.CommitmentHash = ev.ConflictingAcks[0].Ack.CommitmentHash
+		second.Ack = &ack
+		bad.ConflictingAcks = []*types.AcknowledgementProof{ev.ConflictingAcks[0], &second}
+		assert.ErrorContains(t, VerifyEvidence(&bad), "same commitments")
+	})
+	t.Run("session mismatch", func(t *testing.T) {
+		bad := *ev
+		bad.SessionTimestamp = 101
+		assert.Error(t, VerifyEvidence(&bad))
+	})
+	t.Run("no equivocation", func(t *testing.T) {
+		_, err := NewEquivocationEvidence("dkg", 100, dealer, reporter, equivocatingAcks(dealer, 100)[:2])
+		assert.ErrorIs(t, err, ErrNoEquivocation)
+	})
+}
+
+func TestInvalidShareEvidence(t *testing.T) {
+	operators := make([]*peering.OperatorSetPeer, 3)
+	for i := range operators {
+		operators[i] = &peering.OperatorSetPeer{OperatorAddress: common.BigToAddress(big.NewInt(int64(0x400 + i)))}
+	}
+	dealer, reporter := operators[0].OperatorAddress, operators[1].OperatorAddress
+	shares, commitments, err := dkg.NewDKG(dealer, dkg.CalculateThreshold(len(operators)), operators).GenerateShares()
+	require.NoError(t, err)
+
+	bad := fr.NewElement(9)
+	ev := NewInvalidShareEvidence("dkg", 5, dealer, reporter, &bad, commitments)
+	payload := []byte(`{"fromOperatorAddress":"dealer"}`)
+	ev.SignedShare = &types.AuthenticatedMessage{Payload: payload, Hash: crypto.Keccak256Hash(payload)}
+	require.NoError(t, VerifyEvidence(ev))
+
+	t.Run("share that verifies is not evidence", func(t *testing.T) {
+		good := NewInvalidShareEvidence("dkg", 5, dealer, reporter, shares[reporter], commitments)
+		assert.ErrorContains(t, VerifyEvidence(good), "share verifies")
+	})
+	t.Run("tampered signed message", func(t *testing.T) {
+		tampered := *ev
+		tampered.SignedShare = &types.AuthenticatedMessage{Payload: []byte("other"), Hash: ev.SignedShare.Hash}
+		assert.ErrorContains(t, VerifyEvidence(&tampered), "hash does not match")
+	})
+	t.Run("self accusation", func(t *testing.T) {
+		self := NewInvalidShareEvidence("dkg", 5, reporter, reporter, &bad, commitments)
+		assert.Error(t, VerifyEvidence(self))
+	})
+	t.Run("missing share", func(t *testing.T) {
+		assert.Error(t, VerifyEvidence(NewInvalidShareEvidence("dkg", 5, dealer, reporter, nil, commitments)))
+	})
+}
@@ -0,0 +1,319 @@
+// Package fraud records and submits evidence of dealer misbehaviour during DKG and
+// reshare (docs/003_fraudProofs.md).
+//
+// When a node catches a dealer cheating it builds a FraudEvidence bundle: the
+// dealer-signed commitment and share messages as received, the ack transcript it holds,
+// and for equivocation two conflicting acks with merkle proofs against the dealer's
+// on-chain ack root. Bundles are persisted before anything else happens, so evidence
+// survives a crash or a failed submission.
+//
+// Equivocation evidence is submitted to the commitment registry's proveEquivocation.
+// An invalid share is disputed through the registry's share complaints: the reporter
+// complains, the dealer has ShareAnswerWindow to reveal the share and have the registry
+// check it against its commitments (EIP-2537 G2 multi-scalar multiplication), and a
+// complaint left unanswered is proven with proveInvalidShare.
+package fraud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller/caller"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// The registry's share complaint windows; these must match its COMPLAINT_PERIOD and
+// ANSWER_WINDOW.
+const (
+	// ShareComplaintPeriod is how long after a session starts a player may complain
+	// about the share a dealer dealt it.
+	ShareComplaintPeriod = time.Hour
+	// ShareAnswerWindow is how long a dealer has to answer a complaint.
+	ShareAnswerWindow = time.Hour
+)
+
+// IEvidenceStore persists evidence bundles. persistence.INodePersistence satisfies it.
+type IEvidenceStore interface {
+	SaveFraudEvidence(evidence *types.FraudEvidence) error
+	ListFraudEvidence() ([]*types.FraudEvidence, error)
+}
+
+// Reporter verifies, persists and submits fraud evidence.
+type Reporter struct {
+	store           IEvidenceStore
+	contractCaller  contractCaller.IContractCaller
+	registryAddress common.Address
+	logger          *zap.Logger
+}
+
+// NewReporter creates a Reporter. contractCaller may be nil, in which case evidence is
+// recorded but never submitted.
+func NewReporter(
+	store IEvidenceStore,
+	cc contractCaller.IContractCaller,
+	registryAddress common.Address,
+	logger *zap.Logger,
+) *Reporter {
+	return &Reporter{
+		store:           store,
+		contractCaller:  cc,
+		registryAddress: registryAddress,
+		logger:          logger,
+	}
+}
+
+// Record verifies the bundle and persists it. Evidence that does not stand on its own is
+// rejected rather than stored.
+func (r *Reporter) Record(evidence *types.FraudEvidence) error {
+	if err := VerifyEvidence(evidence); err != nil {
+		return fmt.Errorf("refusing to record fraud evidence: %w", err)
+	}
+	if evidence.RecordedAt == 0 {
+		evidence.RecordedAt = time.Now().Unix()
+	}
+	if err := r.store.SaveFraudEvidence(evidence); err != nil {
+		return fmt.Errorf("failed to persist fraud evidence: %w", err)
+	}
+
+	r.logger.Sugar().Warnw("Recorded fraud evidence",
+		"evidence_id", evidence.ID,
+		"kind", evidence.Kind,
+		"protocol", evidence.Protocol,
+		"session_timestamp", evidence.SessionTimestamp,
+		"dealer_address", evidence.DealerAddress.Hex(),
+		"reporter_address", evidence.ReporterAddress.Hex())
+	return nil
+}
+
+// Submit sends recorded evidence to the commitment registry and persists the
+// transaction hash. It is a no-op for evidence already settled, or when the registry
+// already holds a proof against the dealer for the session.
+//
+// An invalid-share dispute spans several calls: the first opens the complaint, and
+// later ones (SubmitPending) prove it once the dealer's answer window has passed.
+func (r *Reporter) Submit(ctx context.Context, evidence *types.FraudEvidence) error {
+	if settled(evidence) {
+		return nil
+	}
+	if err := VerifyEvidence(evidence); err != nil {
+		return fmt.Errorf("refusing to submit fraud evidence: %w", err)
+	}
+	if r.contractCaller == nil {
+		return fmt.Errorf("no contract caller configured for fraud submission")
+	}
+
+	epoch := types.CommitmentEpoch(evidence.KeyID, evidence.SessionTimestamp)
+	if evidence.Kind == types.FraudKindInvalidShare {
+		return r.submitInvalidShare(ctx, epoch, evidence)
+	}
+
+	proven, err := r.contractCaller.IsEquivocationProven(ctx, r.registryAddress, epoch, evidence.DealerAddress)
+	if err != nil {
+		return err
+	}
+	if proven {
+		r.logger.Sugar().Infow("Equivocation already proven on-chain, skipping submission",
+			"evidence_id", evidence.ID,
+			"dealer_address", evidence.DealerAddress.Hex(),
+			"session_timestamp", evidence.SessionTimestamp)
+		return nil
+	}
+
+	// The proofs only verify against the root the dealer actually submitted; checking
+	// first avoids paying for a transaction that is certain to revert.
+	_, onChainRoot, _, err := r.contractCaller.GetCommitment(ctx, r.registryAddress, epoch, evidence.DealerAddress)
+	if err != nil {
+		return err
+	}
+	if onChainRoot != evidence.AckMerkleRoot {
+		return fmt.Errorf("evidence ack root %x does not match on-chain root %x", evidence.AckMerkleRoot, onChainRoot)
+	}
+
+	receipt, err := r.contractCaller.SubmitEquivocationProof(
+		ctx,
+		r.registryAddress,
+		epoch,
+		evidence.DealerAddress,
+		toAckProof(evidence.ConflictingAcks[0]),
+		toAckProof(evidence.ConflictingAcks[1]),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to submit equivocation proof: %w", err)
+	}
+	if receipt == nil {
+		return fmt.Errorf("equivocation proof submission returned no receipt")
+	}
+
+	evidence.SubmissionTxHash = receipt.TxHash.Hex()
+	if err := r.store.SaveFraudEvidence(evidence); err != nil {
+		return fmt.Errorf("equivocation proof submitted in %s but not persisted: %w", evidence.SubmissionTxHash, err)
+	}
+
+	r.logger.Sugar().Infow("Submitted equivocation proof",
+		"evidence_id", evidence.ID,
+		"dealer_address", evidence.DealerAddress.Hex(),
+		"session_timestamp", evidence.SessionTimestamp,
+		"tx_hash", evidence.SubmissionTxHash)
+	return nil
+}
+
+// submitInvalidShare advances the reporter's share complaint against the dealer by one
+// step: open it, wait out the dealer's answer window, then prove it.
+func (r *Reporter) submitInvalidShare(ctx context.Context, epoch int64, evidence *types.FraudEvidence) error {
+	dealer, player := evidence.DealerAddress, evidence.ReporterAddress
+
+	proven, err := r.contractCaller.IsInvalidShareProven(ctx, r.registryAddress, epoch, dealer)
+	if err != nil {
+		return err
+	}
+	if proven {
+		r.logger.Sugar().Infow("Invalid share already proven on-chain",
+			"evidence_id", evidence.ID,
+			"dealer_address", dealer.Hex(),
+			"session_timestamp", evidence.SessionTimestamp)
+		return r.resolve(evidence, types.FraudResolutionProven, "")
+	}
+
+	complaint, err := r.contractCaller.GetShareComplaint(ctx, r.registryAddress, epoch, dealer, player)
+	if err != nil {
+		return err
+	}
+	if complaint.Answered {
+		r.logger.Sugar().Warnw("Dealer answered share complaint with a share that verifies on-chain",
+			"evidence_id", evidence.ID,
+			"dealer_address", dealer.Hex(),
+			"session_timestamp", evidence.SessionTimestamp)
+		return r.resolve(evidence, types.FraudResolutionAnswered, "")
+	}
+
+	now, err := r.contractCaller.HeaderTimestampAt(ctx, 0)
+	if err != nil {
+		return err
+	}
+
+	if complaint.Deadline == 0 {
+		if int64(now) > evidence.SessionTimestamp+int64(ShareComplaintPeriod/time.Second) {
+			r.logger.Sugar().Warnw("Share complaint period closed before a complaint was opened",
+				"evidence_id", evidence.ID,
+				"dealer_address", dealer.Hex(),
+				"session_timestamp", evidence.SessionTimestamp)
+			return r.resolve(evidence, types.FraudResolutionExpired, "")
+		}
+		// The registry only takes complaints against a dealer that has committed; until
+		// then the complaint waits for the next pass.
+		commitmentHash, _, _, err := r.contractCaller.GetCommitment(ctx, r.registryAddress, epoch, dealer)
+		if err != nil {
+			return err
+		}
+		if commitmentHash == ([32]byte{}) {
+			return nil
+		}
+		return r.openShareComplaint(ctx, epoch, evidence)
+	}
+
+	if int64(now) <= complaint.Deadline {
+		return nil
+	}
+
+	receipt, err := r.contractCaller.ProveInvalidShare(ctx, r.registryAddress, epoch, dealer, player)
+	if err != nil {
+		return fmt.Errorf("failed to prove invalid share: %w", err)
+	}
+	if receipt == nil {
+		return fmt.Errorf("invalid share proof submission returned no receipt")
+	}
+	if err := r.resolve(evidence, types.FraudResolutionProven, receipt.TxHash.Hex()); err != nil {
+		return err
+	}
+
+	r.logger.Sugar().Infow("Proved invalid share",
+		"evidence_id", evidence.ID,
+		"dealer_address", dealer.Hex(),
+		"session_timestamp", evidence.SessionTimestamp,
+		"tx_hash", evidence.ResolutionTxHash)
+	return nil
+}
+
+func (r *Reporter) openShareComplaint(ctx context.Context, epoch int64, evidence *types.FraudEvidence) error {
+	receipt, err := r.contractCaller.OpenShareComplaint(ctx, r.registryAddress, epoch, evidence.DealerAddress)
+	if err != nil {
+		return fmt.Errorf("failed to open share complaint: %w", err)
+	}
+	if receipt == nil {
+		return fmt.Errorf("share complaint submission returned no receipt")
+	}
+
+	evidence.SubmissionTxHash = receipt.TxHash.Hex()
+	if err := r.store.SaveFraudEvidence(evidence); err != nil {
+		return fmt.Errorf("share complaint opened in %s but not persisted: %w", evidence.SubmissionTxHash, err)
+	}
+
+	r.logger.Sugar().Infow("Opened share complaint",
+		"evidence_id", evidence.ID,
+		"dealer_address", evidence.DealerAddress.Hex(),
+		"session_timestamp", evidence.SessionTimestamp,
+		"tx_hash", evidence.SubmissionTxHash)
+	return nil
+}
+
+// resolve persists how an invalid-share complaint ended.
+func (r *Reporter) resolve(evidence *types.FraudEvidence, resolution, txHash string) error {
+	evidence.Resolution = resolution
+	evidence.ResolutionTxHash = txHash
+	if err := r.store.SaveFraudEvidence(evidence); err != nil {
+		return fmt.Errorf("failed to persist %s share complaint: %w", resolution, err)
+	}
+	return nil
+}
+
+// settled reports whether evidence needs no further on-chain action.
+func settled(evidence *types.FraudEvidence) bool {
+	if evidence.Kind == types.FraudKindInvalidShare {
+		return evidence.Resolution != ""
+	}
+	return evidence.SubmissionTxHash != ""
+}
+
+// Report records evidence and submits it to the registry.
+func (r *Reporter) Report(ctx context.Context, evidence *types.FraudEvidence) error {
+	if err := r.Record(evidence); err != nil {
+		return err
+	}
+	return r.Submit(ctx, evidence)
+}
+
+// SubmitPending advances every persisted bundle that is not settled on-chain yet: it
+// retries submissions after a restart or an RPC outage and moves open share complaints
+// on once the dealer's answer window has passed.
+func (r *Reporter) SubmitPending(ctx context.Context) error {
+	evidence, err := r.store.ListFraudEvidence()
+	if err != nil {
+		return fmt.Errorf("failed to list fraud evidence: %w", err)
+	}
+
+	var errs []error
+	for _, ev := range evidence {
+		if settled(ev) {
+			continue
+		}
+		if err := r.Submit(ctx, ev); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", ev.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func toAckProof(p *types.AcknowledgementProof) *caller.AckProof {
+	return &caller.AckProof{
+		Player:         p.Ack.PlayerAddress,
+		Dealer:         p.Ack.DealerAddress,
+		ShareHash:      p.Ack.ShareHash,
+		CommitmentHash: p.Ack.CommitmentHash,
+		Proof:          p.Proof,
+	}
+}
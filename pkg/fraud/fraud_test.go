@@ -0,0 +1,233 @@
+package fraud
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller/caller"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testRegistry = common.HexToAddress("0x00000000000000000000000000000000000000CC")
+
+func newEquivocationEvidence(t *testing.T) *types.FraudEvidence {
+	t.Helper()
+	dealer := common.HexToAddress("0x0D")
+	ev, err := NewEquivocationEvidence("dkg", 100, dealer, common.HexToAddress("0x0E"), equivocatingAcks(dealer, 100))
+	require.NoError(t, err)
+	return ev
+}
+
+func TestReporter_ReportSubmitsEquivocation(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewMemoryPersistence()
+	cc := contractCaller.NewMockIContractCaller(t)
+	ev := newEquivocationEvidence(t)
+
+	cc.EXPECT().IsEquivocationProven(mock.Anything, testRegistry, int64(100), ev.DealerAddress).Return(false, nil).Once()
+	cc.EXPECT().GetCommitment(mock.Anything, testRegistry, int64(100), ev.DealerAddress).Return([32]byte{}, ev.AckMerkleRoot, uint64(1), nil).Once()
+	cc.EXPECT().SubmitEquivocationProof(mock.Anything, testRegistry, int64(100), ev.DealerAddress, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, _ common.Address, _ int64, dealer common.Address, ack1, ack2 *caller.AckProof) (*ethTypes.Receipt, error) {
+			assert.Equal(t, ev.ConflictingAcks[0].Ack.PlayerAddress, ack1.Player)
+			assert.Equal(t, ev.ConflictingAcks[1].Proof, ack2.Proof)
+			assert.NotEqual(t, ack1.CommitmentHash, ack2.CommitmentHash)
+			return &ethTypes.Receipt{TxHash: common.HexToHash("0x1234")}, nil
+		}).Once()
+
+	r := NewReporter(store, cc, testRegistry, zap.NewNop())
+	require.NoError(t, r.Report(ctx, ev))
+
+	stored, err := store.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, common.HexToHash("0x1234").Hex(), stored[0].SubmissionTxHash)
+	assert.NotZero(t, stored[0].RecordedAt)
+
+	// Nothing left to submit.
+	require.NoError(t, r.SubmitPending(ctx))
+}
+
+func TestReporter_RootMismatchKeepsEvidencePending(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewMemoryPersistence()
+	cc := contractCaller.NewMockIContractCaller(t)
+	ev := newEquivocationEvidence(t)
+
+	cc.EXPECT().IsEquivocationProven(mock.Anything, testRegistry, int64(100), ev.DealerAddress).Return(false, nil)
+	cc.EXPECT().GetCommitment(mock.Anything, testRegistry, int64(100), ev.DealerAddress).Return([32]byte{}, [32]byte{0xFF}, uint64(1), nil)
+
+	r := NewReporter(store, cc, testRegistry, zap.NewNop())
+	assert.ErrorContains(t, r.Report(ctx, ev), "does not match on-chain root")
+
+	stored, err := store.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, stored, 1, "evidence is persisted even though submission failed")
+	assert.Empty(t, stored[0].SubmissionTxHash)
+
+	assert.Error(t, r.SubmitPending(ctx))
+}
+
+func TestReporter_AlreadyProven(t *testing.T) {
+	cc := contractCaller.NewMockIContractCaller(t)
+	ev := newEquivocationEvidence(t)
+	cc.EXPECT().IsEquivocationProven(mock.Anything, testRegistry, int64(100), ev.DealerAddress).Return(true, nil)
+
+	r := NewReporter(memory.NewMemoryPersistence(), cc, testRegistry, zap.NewNop())
+	require.NoError(t, r.Report(context.Background(), ev))
+}
+
+// newInvalidShareEvidence returns verifiable invalid-share evidence from a DKG session
+// starting at Unix time 1000.
+func newInvalidShareEvidence(t *testing.T) *types.FraudEvidence {
+	t.Helper()
+	operators := make([]*peering.OperatorSetPeer, 3)
+	for i := range operators {
+		operators[i] = &peering.OperatorSetPeer{OperatorAddress: common.BigToAddress(big.NewInt(int64(0x400 + i)))}
+	}
+	dealer, reporter := operators[0].OperatorAddress, operators[1].OperatorAddress
+	_, commitments, err := dkg.NewDKG(dealer, dkg.CalculateThreshold(len(operators)), operators).GenerateShares()
+	require.NoError(t, err)
+	bad := fr.NewElement(9)
+	return NewInvalidShareEvidence("dkg", 1000, dealer, reporter, &bad, commitments)
+}
+
+func TestReporter_InvalidShareComplaint(t *testing.T) {
+	ctx := context.Background()
+	store := memory.NewMemoryPersistence()
+	cc := contractCaller.NewMockIContractCaller(t)
+	ev := newInvalidShareEvidence(t)
+	dealer, player := ev.DealerAddress, ev.ReporterAddress
+
+	cc.EXPECT().IsInvalidShareProven(mock.Anything, testRegistry, int64(1000), dealer).Return(false, nil)
+
+	// Report opens the complaint once the dealer has committed.
+	cc.EXPECT().GetShareComplaint(mock.Anything, testRegistry, int64(1000), dealer, player).Return(&caller.ShareComplaint{}, nil).Once()
+	cc.EXPECT().HeaderTimestampAt(mock.Anything, uint64(0)).Return(uint64(1010), nil).Once()
+	cc.EXPECT().GetCommitment(mock.Anything, testRegistry, int64(1000), dealer).Return([32]byte{1}, [32]byte{}, uint64(1), nil).Once()
+	cc.EXPECT().OpenShareComplaint(mock.Anything, testRegistry, int64(1000), dealer).Return(&ethTypes.Receipt{TxHash: common.HexToHash("0x01")}, nil).Once()
+
+	r := NewReporter(store, cc, testRegistry, zap.NewNop())
+	require.NoError(t, r.Report(ctx, ev))
+
+	stored, err := store.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, common.HexToHash("0x01").Hex(), stored[0].SubmissionTxHash)
+	assert.Empty(t, stored[0].Resolution)
+
+	// The dealer can still answer, so nothing is sent.
+	open := &caller.ShareComplaint{Deadline: 1010 + 3600}
+	cc.EXPECT().GetShareComplaint(mock.Anything, testRegistry, int64(1000), dealer, player).Return(open, nil).Once()
+	cc.EXPECT().HeaderTimestampAt(mock.Anything, uint64(0)).Return(uint64(1010+3600), nil).Once()
+	require.NoError(t, r.SubmitPending(ctx))
+
+	// Past the deadline the unanswered complaint is proven.
+	cc.EXPECT().GetShareComplaint(mock.Anything, testRegistry, int64(1000), dealer, player).Return(open, nil).Once()
+	cc.EXPECT().HeaderTimestampAt(mock.Anything, uint64(0)).Return(uint64(1010+3601), nil).Once()
+	cc.EXPECT().ProveInvalidShare(mock.Anything, testRegistry, int64(1000), dealer, player).Return(&ethTypes.Receipt{TxHash: common.HexToHash("0x02")}, nil).Once()
+	require.NoError(t, r.SubmitPending(ctx))
+
+	stored, err = store.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, types.FraudResolutionProven, stored[0].Resolution)
+	assert.Equal(t, common.HexToHash("0x02").Hex(), stored[0].ResolutionTxHash)
+
+	// Settled: no further registry calls.
+	require.NoError(t, r.SubmitPending(ctx))
+}
+
+func TestReporter_InvalidShareComplaintOutcomes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("answered", func(t *testing.T) {
+		cc := contractCaller.NewMockIContractCaller(t)
+		ev := newInvalidShareEvidence(t)
+		cc.EXPECT().IsInvalidShareProven(mock.Anything, testRegistry, int64(1000), ev.DealerAddress).Return(false, nil)
+		cc.EXPECT().GetShareComplaint(mock.Anything, testRegistry, int64(1000), ev.DealerAddress, ev.ReporterAddress).
+			Return(&caller.ShareComplaint{Deadline: 5000, Answered: true}, nil)
+
+		r := NewReporter(memory.NewMemoryPersistence(), cc, testRegistry, zap.NewNop())
+		require.NoError(t, r.Report(ctx, ev))
+		assert.Equal(t, types.FraudResolutionAnswered, ev.Resolution)
+	})
+	t.Run("proven by another player", func(t *testing.T) {
+		cc := contractCaller.NewMockIContractCaller(t)
+		ev := newInvalidShareEvidence(t)
+		cc.EXPECT().IsInvalidShareProven(mock.Anything, testRegistry, int64(1000), ev.DealerAddress).Return(true, nil)
+
+		r := NewReporter(memory.NewMemoryPersistence(), cc, testRegistry, zap.NewNop())
+		require.NoError(t, r.Report(ctx, ev))
+		assert.Equal(t, types.FraudResolutionProven, ev.Resolution)
+		assert.Empty(t, ev.ResolutionTxHash)
+	})
+	t.Run("dealer not committed yet", func(t *testing.T) {
+		cc := contractCaller.NewMockIContractCaller(t)
+		ev := newInvalidShareEvidence(t)
+		cc.EXPECT().IsInvalidShareProven(mock.Anything, testRegistry, int64(1000), ev.DealerAddress).Return(false, nil)
+		cc.EXPECT().GetShareComplaint(mock.Anything, testRegistry, int64(1000), ev.DealerAddress, ev.ReporterAddress).Return(&caller.ShareComplaint{}, nil)
+		cc.EXPECT().HeaderTimestampAt(mock.Anything, uint64(0)).Return(uint64(1010), nil)
+		cc.EXPECT().GetCommitment(mock.Anything, testRegistry, int64(1000), ev.DealerAddress).Return([32]byte{}, [32]byte{}, uint64(0), nil)
+
+		r := NewReporter(memory.NewMemoryPersistence(), cc, testRegistry, zap.NewNop())
+		require.NoError(t, r.Report(ctx, ev))
+		assert.Empty(t, ev.SubmissionTxHash)
+		assert.Empty(t, ev.Resolution)
+	})
+	t.Run("complaint period over", func(t *testing.T) {
+		cc := contractCaller.NewMockIContractCaller(t)
+		ev := newInvalidShareEvidence(t)
+		cc.EXPECT().IsInvalidShareProven(mock.Anything, testRegistry, int64(1000), ev.DealerAddress).Return(false, nil)
+		cc.EXPECT().GetShareComplaint(mock.Anything, testRegistry, int64(1000), ev.DealerAddress, ev.ReporterAddress).Return(&caller.ShareComplaint{}, nil)
+		cc.EXPECT().HeaderTimestampAt(mock.Anything, uint64(0)).Return(uint64(1000+3601), nil)
+
+		r := NewReporter(memory.NewMemoryPersistence(), cc, testRegistry, zap.NewNop())
+		require.NoError(t, r.Report(ctx, ev))
+		assert.Equal(t, types.FraudResolutionExpired, ev.Resolution)
+	})
+}
+
+func TestReporter_RejectsUnverifiableInvalidShare(t *testing.T) {
+	store := memory.NewMemoryPersistence()
+	r := NewReporter(store, contractCaller.NewMockIContractCaller(t), testRegistry, zap.NewNop())
+
+	ev := &types.FraudEvidence{
+		ID:              "x",
+		Kind:            types.FraudKindInvalidShare,
+		DealerAddress:   common.HexToAddress("0x0D"),
+		ReporterAddress: common.HexToAddress("0x0E"),
+	}
+	assert.Error(t, r.Report(context.Background(), ev), "evidence that does not verify is rejected")
+
+	stored, err := store.ListFraudEvidence()
+	require.NoError(t, err)
+	assert.Empty(t, stored)
+}
+
+type failingStore struct{}
+
+func (failingStore) SaveFraudEvidence(*types.FraudEvidence) error {
+	return fmt.Errorf("disk full")
+}
+
+func (failingStore) ListFraudEvidence() ([]*types.FraudEvidence, error) {
+	return nil, nil
+}
+
+func TestReporter_RecordSurfacesStoreErrors(t *testing.T) {
+	r := NewReporter(failingStore{}, nil, testRegistry, zap.NewNop())
+	assert.ErrorContains(t, r.Record(newEquivocationEvidence(t)), "disk full")
+}
@@ -2,6 +2,8 @@ package keystore
 
 import (
 	"fmt"
+	"math"
+	"sort"
 	"sync"
 
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
@@ -16,6 +18,10 @@ type KeyStore struct {
 	activeVersion  *types.KeyShareVersion
 	pendingVersion *types.KeyShareVersion
 	poisoned       map[int64]struct{}
+
+	// retiring maps each previous master secret generation still being served to the
+	// Unix time it retires at.
+	retiring map[uint32]int64
 }
 
 // NewKeyStore creates a new key store
@@ -207,16 +213,37 @@ func (ks *KeyStore) GetPrivateShareForVersion(version int64) (*fr.Element, error
 	return nil, fmt.Errorf("no key version %d in keystore", version)
 }
 
-// GetKeyVersionAtTime returns the key version that was active at the given timestamp.
-// It returns the latest version whose Version (block timestamp) is <= the given timestamp.
+// GetKeyVersionAtTime returns the key version of the active generation that was active
+// at the given timestamp. It returns the latest version whose Version (block timestamp)
+// is <= the given timestamp.
 func (ks *KeyStore) GetKeyVersionAtTime(timestamp int64) *types.KeyShareVersion {
 	ks.mu.RLock()
 	defer ks.mu.RUnlock()
 
+	return ks.versionAtTime(timestamp, ks.activeGeneration())
+}
+
+// GetKeyVersionAtTimeForGeneration is GetKeyVersionAtTime for the given master secret
+// generation. Returns nil for a generation the keystore does not serve.
+func (ks *KeyStore) GetKeyVersionAtTimeForGeneration(timestamp int64, generation uint32) *types.KeyShareVersion {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if !ks.servesGeneration(generation) {
+		return nil
+	}
+	return ks.versionAtTime(timestamp, generation)
+}
+
+// versionAtTime must be called with ks.mu held.
+func (ks *KeyStore) versionAtTime(timestamp int64, generation uint32) *types.KeyShareVersion {
 	var best *types.KeyShareVersion
 	for _, version := range ks.keyVersions {
+		if version.Generation != generation {
+			continue
+		}
 		// Skip poisoned versions so resolution falls back to the next-lower good
-		// version. Read the poisoned set inline under the RLock already held here;
+		// version. Read the poisoned set inline under the lock already held here;
 		// do NOT call IsPoisoned (which takes its own RLock) — lock re-entrancy.
 		if _, bad := ks.poisoned[version.Version]; bad {
 			continue
@@ -230,3 +257,254 @@ func (ks *KeyStore) GetKeyVersionAtTime(timestamp int64) *types.KeyShareVersion
 
 	return best
 }
+
+// activeGeneration must be called with ks.mu held.
+func (ks *KeyStore) activeGeneration() uint32 {
+	if ks.activeVersion == nil {
+		return 0
+	}
+	return ks.activeVersion.Generation
+}
+
+// servesGeneration must be called with ks.mu held.
+func (ks *KeyStore) servesGeneration(generation uint32) bool {
+	if generation == ks.activeGeneration() {
+		return true
+	}
+	_, ok := ks.retiring[generation]
+	return ok
+}
+
+// ActiveGeneration returns the master secret generation of the active version, or 0
+// when there is none.
+func (ks *KeyStore) ActiveGeneration() uint32 {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	return ks.activeGeneration()
+}
+
+// GetGenerationVersion returns the version a generation is currently served with: the
+// active version for the active generation, the newest non-poisoned version for a
+// retiring one, and nil for a generation the keystore does not serve.
+func (ks *KeyStore) GetGenerationVersion(generation uint32) *types.KeyShareVersion {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	if generation == ks.activeGeneration() {
+		return ks.activeVersion
+	}
+	if _, ok := ks.retiring[generation]; !ok {
+		return nil
+	}
+	// A retiring generation is no longer reshared, so its newest version is final.
+	return ks.versionAtTime(math.MaxInt64, generation)
+}
+
+// RetireGeneration keeps serving a previous master secret generation until retireAt
+// (Unix seconds). Versions of a generation that is neither active nor retiring are not
+// served.
+func (ks *KeyStore) RetireGeneration(generation uint32, retireAt int64) {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	if ks.retiring == nil {
+		ks.retiring = map[uint32]int64{}
+	}
+	ks.retiring[generation] = retireAt
+}
+
+// RetiringGenerations returns the retiring generations and the time each retires at.
+func (ks *KeyStore) RetiringGenerations() map[uint32]int64 {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	retiring := make(map[uint32]int64, len(ks.retiring))
+	for generation, retireAt := range ks.retiring {
+		retiring[generation] = retireAt
+	}
+	return retiring
+}
+
+// NumVersions returns the number of key versions the keystore holds.
+func (ks *KeyStore) NumVersions() int {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	return len(ks.keyVersions)
+}
+
+// Versions returns copies of the key versions the keystore holds, oldest first, with
+// their private shares removed.
+func (ks *KeyStore) Versions() []types.KeyShareVersion {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	versions := make([]types.KeyShareVersion, len(ks.keyVersions))
+	for i, version := range ks.keyVersions {
+		versions[i] = *version
+		versions[i].PrivateShare = nil
+		versions[i].SealedPrivateShare = nil
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Version < versions[j].Version })
+	return versions
+}
+
+// Generations returns every master secret generation the keystore holds versions of,
+// in ascending order.
+func (ks *KeyStore) Generations() []uint32 {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	seen := make(map[uint32]struct{})
+	var generations []uint32
+	for _, version := range ks.keyVersions {
+		if _, ok := seen[version.Generation]; !ok {
+			seen[version.Generation] = struct{}{}
+			generations = append(generations, version.Generation)
+		}
+	}
+	sort.Slice(generations, func(i, j int) bool { return generations[i] < generations[j] })
+	return generations
+}
+
+// PruneRetiredGenerations drops every version of the generations whose retirement time
+// is at or before now and returns them, so the caller can delete them from persistence.
+// The active generation is never pruned.
+func (ks *KeyStore) PruneRetiredGenerations(now int64) []*types.KeyShareVersion {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	active := ks.activeGeneration()
+	retired := make(map[uint32]struct{})
+	for generation, retireAt := range ks.retiring {
+		if generation != active && retireAt <= now {
+			retired[generation] = struct{}{}
+			delete(ks.retiring, generation)
+		}
+	}
+	if len(retired) == 0 {
+		return nil
+	}
+
+	var pruned []*types.KeyShareVersion
+	kept := ks.keyVersions[:0]
+	for _, version := range ks.keyVersions {
+		if _, ok := retired[version.Generation]; ok {
+			pruned = append(pruned, version)
+			continue
+		}
+		kept = append(kept, version)
+	}
+	ks.keyVersions = kept
+	return pruned
+}
+
+// RetentionPolicy selects the key versions a retention pass prunes. It is applied to
+// each master secret generation separately.
+type RetentionPolicy struct {
+	// Cutoff (Unix seconds) prunes every version superseded at or before Cutoff by a
+	// newer, non-poisoned version of its generation, so that no attestation time at or
+	// after Cutoff resolves to it. 0 = no age limit.
+	Cutoff int64
+	// MaxVersions prunes all but the newest MaxVersions versions of each generation.
+	// 0 = no count limit.
+	MaxVersions int
+	// Protected lists versions that are never pruned, in addition to the active and
+	// pending versions.
+	Protected []int64
+}
+
+// PruneReason says why a retention pass selected a version.
+type PruneReason string
+
+const (
+	PruneReasonAge   PruneReason = "age"
+	PruneReasonCount PruneReason = "count"
+)
+
+// PrunedVersion is a key version selected by a retention pass.
+type PrunedVersion struct {
+	Version *types.KeyShareVersion
+	Reason  PruneReason
+}
+
+// SelectForPruning returns the versions policy would prune, oldest first, without
+// removing them.
+func (ks *KeyStore) SelectForPruning(policy RetentionPolicy) []PrunedVersion {
+	ks.mu.RLock()
+	defer ks.mu.RUnlock()
+
+	return ks.selectForPruning(policy)
+}
+
+// PruneVersions drops the versions policy selects and returns them, oldest first, so
+// the caller can delete them from persistence.
+func (ks *KeyStore) PruneVersions(policy RetentionPolicy) []PrunedVersion {
+	ks.mu.Lock()
+	defer ks.mu.Unlock()
+
+	pruned := ks.selectForPruning(policy)
+	if len(pruned) == 0 {
+		return nil
+	}
+	drop := make(map[*types.KeyShareVersion]struct{}, len(pruned))
+	for _, p := range pruned {
+		drop[p.Version] = struct{}{}
+	}
+	kept := ks.keyVersions[:0]
+	for _, version := range ks.keyVersions {
+		if _, ok := drop[version]; !ok {
+			kept = append(kept, version)
+		}
+	}
+	ks.keyVersions = kept
+	return pruned
+}
+
+// selectForPruning must be called with ks.mu held.
+func (ks *KeyStore) selectForPruning(policy RetentionPolicy) []PrunedVersion {
+	protected := make(map[int64]struct{}, len(policy.Protected)+2)
+	for _, v := range policy.Protected {
+		protected[v] = struct{}{}
+	}
+	if ks.activeVersion != nil {
+		protected[ks.activeVersion.Version] = struct{}{}
+	}
+	if ks.pendingVersion != nil {
+		protected[ks.pendingVersion.Version] = struct{}{}
+	}
+
+	byGeneration := make(map[uint32][]*types.KeyShareVersion)
+	for _, version := range ks.keyVersions {
+		byGeneration[version.Generation] = append(byGeneration[version.Generation], version)
+	}
+
+	var pruned []PrunedVersion
+	for _, versions := range byGeneration {
+		// Newest first, so a version's index is the number of newer versions.
+		sorted := append([]*types.KeyShareVersion(nil), versions...)
+		sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Version > sorted[j].Version })
+
+		// supersededAtCutoff turns true once a non-poisoned version at or before the
+		// cutoff has been seen: attestation times from the cutoff on resolve to it or
+		// to a newer version, never to anything older.
+		supersededAtCutoff := false
+		for i, version := range sorted {
+			_, keep := protected[version.Version]
+			switch {
+			case keep:
+			case policy.MaxVersions > 0 && i >= policy.MaxVersions:
+				pruned = append(pruned, PrunedVersion{Version: version, Reason: PruneReasonCount})
+			case policy.Cutoff > 0 && supersededAtCutoff:
+				pruned = append(pruned, PrunedVersion{Version: version, Reason: PruneReasonAge})
+			}
+			if _, bad := ks.poisoned[version.Version]; !bad && version.Version <= policy.Cutoff {
+				supersededAtCutoff = true
+			}
+		}
+	}
+
+	sort.Slice(pruned, func(i, j int) bool { return pruned[i].Version.Version < pruned[j].Version.Version })
+	return pruned
+}
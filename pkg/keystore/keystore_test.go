@@ -210,3 +210,202 @@ func TestKeyStore_ExcludesPoisonedVersion(t *testing.T) {
 		t.Fatal("IsPoisoned wrong")
 	}
 }
+
+func makeGenerationVersion(ts int64, generation uint32, active bool) *types.KeyShareVersion {
+	v := makeVersion(ts)
+	v.Generation = generation
+	v.IsActive = active
+	return v
+}
+
+// rotatedKeyStore holds generation 0 (versions 100, 200), retiring at 1000, and the
+// active generation 1 (versions 300, 400).
+func rotatedKeyStore() *KeyStore {
+	ks := NewKeyStore()
+	ks.AddVersion(makeGenerationVersion(100, 0, false))
+	ks.AddVersion(makeGenerationVersion(200, 0, true))
+	ks.RetireGeneration(0, 1000)
+	ks.AddVersion(makeGenerationVersion(300, 1, false))
+	ks.AddVersion(makeGenerationVersion(400, 1, true))
+	return ks
+}
+
+func TestKeyStore_Generations(t *testing.T) {
+	t.Run("time lookup stays within the active generation", func(t *testing.T) {
+		ks := rotatedKeyStore()
+		if got := ks.ActiveGeneration(); got != 1 {
+			t.Fatalf("expected active generation 1, got %d", got)
+		}
+		if got := ks.GetKeyVersionAtTime(350); got == nil || got.Version != 300 {
+			t.Fatalf("expected version 300, got %v", got)
+		}
+		// Before the rotation the active generation had no version.
+		if got := ks.GetKeyVersionAtTime(250); got != nil {
+			t.Fatalf("expected nil, got version %d", got.Version)
+		}
+	})
+
+	t.Run("retiring generation is served until it retires", func(t *testing.T) {
+		ks := rotatedKeyStore()
+		if got := ks.GetGenerationVersion(0); got == nil || got.Version != 200 {
+			t.Fatalf("expected version 200, got %v", got)
+		}
+		if got := ks.GetKeyVersionAtTimeForGeneration(150, 0); got == nil || got.Version != 100 {
+			t.Fatalf("expected version 100, got %v", got)
+		}
+		if got := ks.GetGenerationVersion(1); got == nil || got.Version != 400 {
+			t.Fatalf("expected version 400, got %v", got)
+		}
+		if got := ks.GetGenerationVersion(2); got != nil {
+			t.Fatalf("expected nil for an unknown generation, got version %d", got.Version)
+		}
+	})
+
+	t.Run("prune drops generations past their retirement", func(t *testing.T) {
+		ks := rotatedKeyStore()
+		if pruned := ks.PruneRetiredGenerations(999); len(pruned) != 0 {
+			t.Fatalf("expected nothing pruned before retirement, got %d", len(pruned))
+		}
+		pruned := ks.PruneRetiredGenerations(1000)
+		if len(pruned) != 2 {
+			t.Fatalf("expected 2 pruned versions, got %d", len(pruned))
+		}
+		if got := ks.GetGenerationVersion(0); got != nil {
+			t.Fatalf("expected retired generation to be unserved, got version %d", got.Version)
+		}
+		if _, err := ks.GetPrivateShareForVersion(200); err == nil {
+			t.Fatalf("expected retired version's share to be gone")
+		}
+		if got := ks.Generations(); len(got) != 1 || got[0] != 1 {
+			t.Fatalf("expected only generation 1 to remain, got %v", got)
+		}
+	})
+
+	t.Run("active generation is never pruned", func(t *testing.T) {
+		ks := rotatedKeyStore()
+		ks.RetireGeneration(1, 500)
+		ks.PruneRetiredGenerations(2000)
+		if got := ks.GetActiveVersion(); got == nil || got.Version != 400 {
+			t.Fatalf("expected active version 400, got %v", got)
+		}
+		if _, err := ks.GetPrivateShareForVersion(400); err != nil {
+			t.Fatalf("expected active version to be kept: %v", err)
+		}
+	})
+}
+
+// retentionKeyStore holds versions 100..600 of generation 0, with 600 active.
+func retentionKeyStore() *KeyStore {
+	ks := NewKeyStore()
+	for ts := int64(100); ts <= 600; ts += 100 {
+		ks.AddVersion(makeVersion(ts))
+	}
+	_ = ks.SetActiveVersionByTimestamp(600)
+	return ks
+}
+
+func prunedVersions(pruned []PrunedVersion) []int64 {
+	out := make([]int64, len(pruned))
+	for i, p := range pruned {
+		out[i] = p.Version.Version
+	}
+	return out
+}
+
+func TestKeyStore_Retention(t *testing.T) {
+	t.Run("age keeps the version active at the cutoff", func(t *testing.T) {
+		ks := retentionKeyStore()
+		pruned := ks.PruneVersions(RetentionPolicy{Cutoff: 350})
+		if got := prunedVersions(pruned); len(got) != 2 || got[0] != 100 || got[1] != 200 {
+			t.Fatalf("expected versions 100 and 200 pruned, got %v", got)
+		}
+		if pruned[0].Reason != PruneReasonAge {
+			t.Fatalf("expected reason %q, got %q", PruneReasonAge, pruned[0].Reason)
+		}
+		if got := ks.GetKeyVersionAtTime(350); got == nil || got.Version != 300 {
+			t.Fatalf("expected attestation time 350 to resolve to version 300, got %v", got)
+		}
+		if got := ks.GetKeyVersionAtTime(250); got != nil {
+			t.Fatalf("expected attestation time before the retained versions to resolve to nil, got %d", got.Version)
+		}
+	})
+
+	t.Run("poisoned version does not supersede", func(t *testing.T) {
+		ks := retentionKeyStore()
+		ks.MarkPoisoned(300)
+		got := prunedVersions(ks.SelectForPruning(RetentionPolicy{Cutoff: 350}))
+		if len(got) != 1 || got[0] != 100 {
+			t.Fatalf("expected only version 100 selected, got %v", got)
+		}
+		if resolved := ks.GetKeyVersionAtTime(350); resolved == nil || resolved.Version != 200 {
+			t.Fatalf("expected version 200 to keep serving attestation time 350, got %v", resolved)
+		}
+	})
+
+	t.Run("count keeps the newest versions", func(t *testing.T) {
+		ks := retentionKeyStore()
+		pruned := ks.PruneVersions(RetentionPolicy{MaxVersions: 2})
+		if got := prunedVersions(pruned); len(got) != 4 || got[0] != 100 || got[3] != 400 {
+			t.Fatalf("expected versions 100-400 pruned, got %v", got)
+		}
+		if pruned[0].Reason != PruneReasonCount {
+			t.Fatalf("expected reason %q, got %q", PruneReasonCount, pruned[0].Reason)
+		}
+	})
+
+	t.Run("active, pending and protected versions are kept", func(t *testing.T) {
+		ks := retentionKeyStore()
+		_ = ks.SetActiveVersionByTimestamp(300)
+		ks.SetPendingVersion(makeVersion(200))
+		got := prunedVersions(ks.PruneVersions(RetentionPolicy{Cutoff: 10_000, MaxVersions: 1, Protected: []int64{500}}))
+		if len(got) != 2 || got[0] != 100 || got[1] != 400 {
+			t.Fatalf("expected only versions 100 and 400 pruned, got %v", got)
+		}
+		if _, err := ks.GetPrivateShareForVersion(300); err != nil {
+			t.Fatalf("expected active version to be kept: %v", err)
+		}
+	})
+
+	t.Run("generations are pruned separately", func(t *testing.T) {
+		ks := rotatedKeyStore()
+		got := prunedVersions(ks.PruneVersions(RetentionPolicy{MaxVersions: 1}))
+		if len(got) != 2 || got[0] != 100 || got[1] != 300 {
+			t.Fatalf("expected versions 100 and 300 pruned, got %v", got)
+		}
+		if v := ks.GetGenerationVersion(0); v == nil || v.Version != 200 {
+			t.Fatalf("expected retiring generation to keep version 200, got %v", v)
+		}
+	})
+
+	t.Run("select does not remove", func(t *testing.T) {
+		ks := retentionKeyStore()
+		if got := ks.SelectForPruning(RetentionPolicy{MaxVersions: 1}); len(got) != 5 {
+			t.Fatalf("expected 5 versions selected, got %d", len(got))
+		}
+		if got := ks.SelectForPruning(RetentionPolicy{MaxVersions: 1}); len(got) != 5 {
+			t.Fatalf("expected dry run to leave versions in place, got %d selected", len(got))
+		}
+		if got := ks.SelectForPruning(RetentionPolicy{}); got != nil {
+			t.Fatalf("expected an empty policy to select nothing, got %d", len(got))
+		}
+	})
+}
+
+func TestKeyStore_Versions(t *testing.T) {
+	ks := NewKeyStore()
+	ks.AddVersion(&types.KeyShareVersion{Version: 200, PrivateShare: new(fr.Element).SetInt64(2), IsActive: true})
+	ks.AddVersion(&types.KeyShareVersion{Version: 100, PrivateShare: new(fr.Element).SetInt64(1)})
+
+	versions := ks.Versions()
+	if len(versions) != 2 || versions[0].Version != 100 || versions[1].Version != 200 {
+		t.Fatalf("expected versions 100, 200 oldest first, got %+v", versions)
+	}
+	for _, v := range versions {
+		if v.PrivateShare != nil {
+			t.Fatalf("version %d exposes its private share", v.Version)
+		}
+	}
+	if share, err := ks.GetPrivateShareForVersion(100); err != nil || share == nil {
+		t.Fatalf("Versions must not strip the stored share: %v", err)
+	}
+}
@@ -0,0 +1,252 @@
+// Package metrics defines the Prometheus metrics exported by the KMS server.
+//
+// All recording methods are safe to call on a nil *Metrics, so components can be
+// instrumented unconditionally and run without metrics in tests and tools.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "kms"
+
+// Protocol names used as the "protocol" label.
+const (
+	ProtocolDKG             = "dkg"
+	ProtocolReshareExisting = "reshare_existing"
+	ProtocolReshareNew      = "reshare_new"
+)
+
+// Rejection reasons used as the "reason" label of kms_http_rejections_total.
+const (
+	RejectionRateLimit        = "rate_limit"
+	RejectionConcurrencyLimit = "concurrency_limit"
+)
+
+// Metrics holds the KMS server's collectors and the registry they are registered in.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	dkgExecutions              *prometheus.CounterVec
+	reshareExecutions          *prometheus.CounterVec
+	protocolDuration           *prometheus.HistogramVec
+	autoHealDemotions          prometheus.Counter
+	commitmentSubmissions      *prometheus.CounterVec
+	commitmentAttempts         prometheus.Counter
+	httpRequests               *prometheus.CounterVec
+	httpDuration               *prometheus.HistogramVec
+	httpRejections             *prometheus.CounterVec
+	teeSecretsRequests         *prometheus.CounterVec
+	p2pSignatureVerifyFailures *prometheus.CounterVec
+}
+
+// NewMetrics creates the KMS collectors in a fresh registry, together with the
+// standard Go runtime and process collectors.
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		dkgExecutions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "dkg_executions_total",
+			Help:      "DKG runs, by result.",
+		}, []string{"result"}),
+		reshareExecutions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reshare_executions_total",
+			Help:      "Reshare runs, by role (existing or new operator) and result.",
+		}, []string{"role", "result"}),
+		protocolDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "protocol_duration_seconds",
+			Help:      "Wall-clock duration of DKG and reshare runs.",
+			Buckets:   []float64{1, 5, 10, 30, 60, 120, 300, 600},
+		}, []string{"protocol", "result"}),
+		autoHealDemotions: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "autoheal_demotions_total",
+			Help:      "Active key versions demoted by auto-heal after repeated MPK-validation aborts.",
+		}),
+		commitmentSubmissions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "commitment_submissions_total",
+			Help:      "Commitment submissions to the registry contract, by final result after retries.",
+		}, []string{"result"}),
+		commitmentAttempts: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "commitment_submission_attempts_total",
+			Help:      "Individual commitment submission transactions, including retries.",
+		}),
+		httpRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "HTTP requests served, by handler, method and status code.",
+		}, []string{"handler", "method", "code"}),
+		httpDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latency, by handler.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"handler"}),
+		httpRejections: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_rejections_total",
+			Help:      "Requests rejected before reaching the handler, by handler and reason.",
+		}, []string{"handler", "reason"}),
+		teeSecretsRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tee_secrets_requests_total",
+			Help:      "/secrets requests, by attestation method and status code.",
+		}, []string{"attestation_method", "code"}),
+		p2pSignatureVerifyFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "p2p_signature_verification_failures_total",
+			Help:      "Inter-node messages whose sender signature failed verification, by handler.",
+		}, []string{"handler"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.dkgExecutions,
+		m.reshareExecutions,
+		m.protocolDuration,
+		m.autoHealDemotions,
+		m.commitmentSubmissions,
+		m.commitmentAttempts,
+		m.httpRequests,
+		m.httpDuration,
+		m.httpRejections,
+		m.teeSecretsRequests,
+		m.p2pSignatureVerifyFailures,
+	)
+	return m
+}
+
+// Registry returns the registry holding all KMS collectors.
+func (m *Metrics) Registry() *prometheus.Registry {
+	return m.registry
+}
+
+// Handler returns an HTTP handler serving the registry in the Prometheus
+// exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// RegisterActiveKeyVersion exports kms_active_key_version{key_id}, read from version
+// at scrape time. version returns the active version of keyID (its session timestamp),
+// or 0 when the key has none. Register once per key the server holds.
+func (m *Metrics) RegisterActiveKeyVersion(keyID string, version func() int64) error {
+	if m == nil {
+		return nil
+	}
+	return m.registry.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "active_key_version",
+		Help:        "Session timestamp of the active key share version (0 if none).",
+		ConstLabels: prometheus.Labels{"key_id": keyID},
+	}, func() float64 { return float64(version()) }))
+}
+
+// RegisterDroppedLogCount exports kms_block_handler_dropped_logs_total, read from
+// count at scrape time.
+func (m *Metrics) RegisterDroppedLogCount(count func() uint64) error {
+	if m == nil {
+		return nil
+	}
+	return m.registry.Register(prometheus.NewCounterFunc(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "block_handler_dropped_logs_total",
+		Help:      "Chain logs dropped because the block handler's log channel was full.",
+	}, func() float64 { return float64(count()) }))
+}
+
+// ObserveProtocolRun records the outcome and duration of a DKG or reshare run.
+func (m *Metrics) ObserveProtocolRun(protocol string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	result := resultLabel(err)
+	switch protocol {
+	case ProtocolDKG:
+		m.dkgExecutions.WithLabelValues(result).Inc()
+	case ProtocolReshareExisting:
+		m.reshareExecutions.WithLabelValues("existing", result).Inc()
+	case ProtocolReshareNew:
+		m.reshareExecutions.WithLabelValues("new", result).Inc()
+	}
+	m.protocolDuration.WithLabelValues(protocol, result).Observe(time.Since(start).Seconds())
+}
+
+// IncAutoHealDemotion records an auto-heal demotion of the active key version.
+func (m *Metrics) IncAutoHealDemotion() {
+	if m == nil {
+		return
+	}
+	m.autoHealDemotions.Inc()
+}
+
+// IncCommitmentAttempt records one commitment submission transaction.
+func (m *Metrics) IncCommitmentAttempt() {
+	if m == nil {
+		return
+	}
+	m.commitmentAttempts.Inc()
+}
+
+// ObserveCommitmentSubmission records the final result of a commitment submission.
+func (m *Metrics) ObserveCommitmentSubmission(err error) {
+	if m == nil {
+		return
+	}
+	m.commitmentSubmissions.WithLabelValues(resultLabel(err)).Inc()
+}
+
+// ObserveHTTPRequest records a served HTTP request.
+func (m *Metrics) ObserveHTTPRequest(handler, method string, code int, duration time.Duration) {
+	if m == nil {
+		return
+	}
+	m.httpRequests.WithLabelValues(handler, method, strconv.Itoa(code)).Inc()
+	m.httpDuration.WithLabelValues(handler).Observe(duration.Seconds())
+}
+
+// IncHTTPRejection records a request rejected by a rate or concurrency limit.
+func (m *Metrics) IncHTTPRejection(handler, reason string) {
+	if m == nil {
+		return
+	}
+	m.httpRejections.WithLabelValues(handler, reason).Inc()
+}
+
+// ObserveSecretsRequest records a /secrets request by attestation method. Callers
+// must pass a bounded method name (a registered method or "unknown"), never the
+// raw request value.
+func (m *Metrics) ObserveSecretsRequest(attestationMethod string, code int) {
+	if m == nil {
+		return
+	}
+	m.teeSecretsRequests.WithLabelValues(attestationMethod, strconv.Itoa(code)).Inc()
+}
+
+// IncP2PSignatureVerificationFailure records an inter-node message whose
+// signature did not verify.
+func (m *Metrics) IncP2PSignatureVerificationFailure(handler string) {
+	if m == nil {
+		return
+	}
+	m.p2pSignatureVerifyFailures.WithLabelValues(handler).Inc()
+}
+
+func resultLabel(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
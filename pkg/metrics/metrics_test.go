@@ -0,0 +1,75 @@
+package metrics
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestNilMetricsIsNoOp(t *testing.T) {
+	var m *Metrics
+	m.ObserveProtocolRun(ProtocolDKG, time.Now(), nil)
+	m.IncAutoHealDemotion()
+	m.IncCommitmentAttempt()
+	m.ObserveCommitmentSubmission(errors.New("boom"))
+	m.ObserveHTTPRequest("/secrets", http.MethodPost, http.StatusOK, time.Millisecond)
+	m.IncHTTPRejection("/secrets", RejectionRateLimit)
+	m.ObserveSecretsRequest("gcp", http.StatusOK)
+	m.IncP2PSignatureVerificationFailure("/dkg/share")
+	require.NoError(t, m.RegisterActiveKeyVersion("default", func() int64 { return 1 }))
+	require.NoError(t, m.RegisterDroppedLogCount(func() uint64 { return 1 }))
+}
+
+func TestProtocolRunCounters(t *testing.T) {
+	m := NewMetrics()
+	m.ObserveProtocolRun(ProtocolDKG, time.Now(), nil)
+	m.ObserveProtocolRun(ProtocolReshareExisting, time.Now(), nil)
+	m.ObserveProtocolRun(ProtocolReshareExisting, time.Now(), errors.New("timeout"))
+	m.ObserveProtocolRun(ProtocolReshareNew, time.Now(), nil)
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.dkgExecutions.WithLabelValues("success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.reshareExecutions.WithLabelValues("existing", "success")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.reshareExecutions.WithLabelValues("existing", "failure")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(m.reshareExecutions.WithLabelValues("new", "success")))
+}
+
+func TestServerExposesMetrics(t *testing.T) {
+	m := NewMetrics()
+	version := int64(0)
+	require.NoError(t, m.RegisterActiveKeyVersion("default", func() int64 { return version }))
+	require.NoError(t, m.RegisterActiveKeyVersion("tenant-b", func() int64 { return 0 }))
+	require.NoError(t, m.RegisterDroppedLogCount(func() uint64 { return 7 }))
+	m.ObserveSecretsRequest("gcp", http.StatusForbidden)
+	version = 1700000000
+
+	srv := httptest.NewServer(m.Handler())
+	defer srv.Close()
+
+	resp, err := http.Get(srv.URL)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	text := string(body)
+	assert.Contains(t, text, `kms_active_key_version{key_id="default"} 1.7e+09`)
+	assert.Contains(t, text, `kms_active_key_version{key_id="tenant-b"} 0`)
+	assert.Contains(t, text, "kms_block_handler_dropped_logs_total 7")
+	assert.Contains(t, text, `kms_tee_secrets_requests_total{attestation_method="gcp",code="403"} 1`)
+	assert.True(t, strings.Contains(text, "go_goroutines"), "runtime collectors should be registered")
+}
+
+func TestServerStartStop(t *testing.T) {
+	s := NewServer("127.0.0.1:0", NewMetrics(), zap.NewNop())
+	require.NoError(t, s.Start())
+	require.NoError(t, s.Stop())
+}
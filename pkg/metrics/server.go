@@ -0,0 +1,58 @@
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Server serves /metrics on its own listener, so scrapes never share the public
+// port (and its rate limits) with /secrets and protocol traffic, and the port can
+// be kept off the public network.
+type Server struct {
+	httpServer *http.Server
+	logger     *zap.Logger
+}
+
+// NewServer creates a metrics server listening on addr (host:port; ":9090" binds
+// all interfaces).
+func NewServer(addr string, m *Metrics, l *zap.Logger) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", m.Handler())
+
+	return &Server{
+		httpServer: &http.Server{
+			Addr:              addr,
+			Handler:           mux,
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       10 * time.Second,
+			WriteTimeout:      30 * time.Second,
+			IdleTimeout:       120 * time.Second,
+		},
+		logger: l,
+	}
+}
+
+// Start binds the listener and serves in the background. Bind errors are returned
+// immediately rather than logged from the serving goroutine.
+func (s *Server) Start() error {
+	ln, err := net.Listen("tcp", s.httpServer.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for metrics on %s: %w", s.httpServer.Addr, err)
+	}
+	s.logger.Sugar().Infow("Starting metrics server", "address", ln.Addr().String())
+	go func() {
+		if err := s.httpServer.Serve(ln); err != nil && err != http.ErrServerClosed {
+			s.logger.Sugar().Errorw("Metrics server error", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Stop closes the listener and any open connections.
+func (s *Server) Stop() error {
+	return s.httpServer.Close()
+}
@@ -40,7 +40,7 @@ type IEigenKMSCommitmentRegistryAckData struct {
 
 // EigenKMSCommitmentRegistryMetaData contains all meta data concerning the EigenKMSCommitmentRegistry contract.
 var EigenKMSCommitmentRegistryMetaData = &bind.MetaData{
-	ABI: "[{\"type\":\"constructor\",\"inputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"avs\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"bn254CertificateVerifier\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"commitments\",\"inputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"ackMerkleRoot\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"submittedAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"curveType\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"ecdsaCertificateVerifier\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"equivocationProven\",\"inputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getCommitment\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"operator\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"ackMerkleRoot\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"submittedAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"initialize\",\"inputs\":[{\"name\":\"_owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_avs\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_operatorSetId\",\"type\":\"uint32\",\"internalType\":\"uint32\"},{\"name\":\"_ecdsaCertificateVerifier\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_bn254CertificateVerifier\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_curveType\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"operatorSetId\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint32\",\"internalType\":\"uint32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"proveEquivocation\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"ack1\",\"type\":\"tuple\",\"internalType\":\"structIEigenKMSCommitmentRegistry.AckData\",\"components\":[{\"name\":\"player\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"dealer\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"shareHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"proof\",\"type\":\"bytes32[]\",\"internalType\":\"bytes32[]\"}]},{\"name\":\"ack2\",\"type\":\"tuple\",\"internalType\":\"structIEigenKMSCommitmentRegistry.AckData\",\"components\":[{\"name\":\"player\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"dealer\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"shareHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"proof\",\"type\":\"bytes32[]\",\"internalType\":\"bytes32[]\"}]}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"renounceOwnership\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"setCurveType\",\"inputs\":[{\"name\":\"_curveType\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"submitCommitment\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"_commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"_ackMerkleRoot\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"CommitmentSubmitted\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"indexed\":true,\"internalType\":\"uint64\"},{\"name\":\"operator\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"indexed\":false,\"internalType\":\"bytes32\"},{\"name\":\"ackMerkleRoot\",\"type\":\"bytes32\",\"indexed\":false,\"internalType\":\"bytes32\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CurveTypeUpdated\",\"inputs\":[{\"name\":\"oldCurveType\",\"type\":\"uint8\",\"indexed\":false,\"internalType\":\"uint8\"},{\"name\":\"newCurveType\",\"type\":\"uint8\",\"indexed\":false,\"internalType\":\"uint8\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"EquivocationProven\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"indexed\":true,\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"player1\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"},{\"name\":\"player2\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Initialized\",\"inputs\":[{\"name\":\"version\",\"type\":\"uint8\",\"indexed\":false,\"internalType\":\"uint8\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"OwnershipTransferred\",\"inputs\":[{\"name\":\"previousOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"newOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"Ack1Invalid\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"Ack2Invalid\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"AcksMustBeFromDifferentPlayers\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"BN254VerifierNotConfigured\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"CommitmentAlreadySubmitted\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"DealerMismatch\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ECDSAVerifierNotConfigured\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"EquivocationAlreadyProven\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidCommitmentHash\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidCurveType\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidMerkleRoot\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NoCommitment\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NoEquivocationDetected\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"OperatorNotRegisteredBN254\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"OperatorNotRegisteredECDSA\",\"inputs\":[]}]",
+	ABI: "[{\"type\":\"constructor\",\"inputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"ANSWER_WINDOW\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"COMPLAINT_PERIOD\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"ROTATION_NOTICE\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"answerShareComplaint\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"player\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"share\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"commitmentPoints\",\"type\":\"bytes[]\",\"internalType\":\"bytes[]\"},{\"name\":\"sourceVersion\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"avs\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"bn254CertificateVerifier\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"commitments\",\"inputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"ackMerkleRoot\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"submittedAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"complainShare\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"curveType\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"ecdsaCertificateVerifier\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"equivocationProven\",\"inputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getCommitment\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"operator\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"ackMerkleRoot\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"submittedAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getRotation\",\"inputs\":[{\"name\":\"keyId\",\"type\":\"string\",\"internalType\":\"string\"}],\"outputs\":[{\"name\":\"generation\",\"type\":\"uint32\",\"internalType\":\"uint32\"},{\"name\":\"at\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"retireAfter\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getShareComplaint\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"player\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"deadline\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"answered\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"initialize\",\"inputs\":[{\"name\":\"_owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_avs\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_operatorSetId\",\"type\":\"uint32\",\"internalType\":\"uint32\"},{\"name\":\"_ecdsaCertificateVerifier\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_bn254CertificateVerifier\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_curveType\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"invalidShareProven\",\"inputs\":[{\"name\":\"\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"operatorSetId\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint32\",\"internalType\":\"uint32\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"owner\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"proveEquivocation\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"ack1\",\"type\":\"tuple\",\"internalType\":\"structIEigenKMSCommitmentRegistry.AckData\",\"components\":[{\"name\":\"player\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"dealer\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"shareHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"proof\",\"type\":\"bytes32[]\",\"internalType\":\"bytes32[]\"}]},{\"name\":\"ack2\",\"type\":\"tuple\",\"internalType\":\"structIEigenKMSCommitmentRegistry.AckData\",\"components\":[{\"name\":\"player\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"dealer\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"shareHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"proof\",\"type\":\"bytes32[]\",\"internalType\":\"bytes32[]\"}]}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"proveInvalidShare\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"player\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"renounceOwnership\",\"inputs\":[],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"scheduleRotation\",\"inputs\":[{\"name\":\"keyId\",\"type\":\"string\",\"internalType\":\"string\"},{\"name\":\"generation\",\"type\":\"uint32\",\"internalType\":\"uint32\"},{\"name\":\"at\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"retireAfter\",\"type\":\"uint64\",\"internalType\":\"uint64\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"setCurveType\",\"inputs\":[{\"name\":\"_curveType\",\"type\":\"uint8\",\"internalType\":\"uint8\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"submitCommitment\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"internalType\":\"uint64\"},{\"name\":\"_commitmentHash\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"},{\"name\":\"_ackMerkleRoot\",\"type\":\"bytes32\",\"internalType\":\"bytes32\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"transferOwnership\",\"inputs\":[{\"name\":\"newOwner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"event\",\"name\":\"CommitmentSubmitted\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"indexed\":true,\"internalType\":\"uint64\"},{\"name\":\"operator\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"commitmentHash\",\"type\":\"bytes32\",\"indexed\":false,\"internalType\":\"bytes32\"},{\"name\":\"ackMerkleRoot\",\"type\":\"bytes32\",\"indexed\":false,\"internalType\":\"bytes32\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CurveTypeUpdated\",\"inputs\":[{\"name\":\"oldCurveType\",\"type\":\"uint8\",\"indexed\":false,\"internalType\":\"uint8\"},{\"name\":\"newCurveType\",\"type\":\"uint8\",\"indexed\":false,\"internalType\":\"uint8\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"EquivocationProven\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"indexed\":true,\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"player1\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"},{\"name\":\"player2\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"Initialized\",\"inputs\":[{\"name\":\"version\",\"type\":\"uint8\",\"indexed\":false,\"internalType\":\"uint8\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"InvalidShareProven\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"indexed\":true,\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"player\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"OwnershipTransferred\",\"inputs\":[{\"name\":\"previousOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"newOwner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"RotationScheduled\",\"inputs\":[{\"name\":\"keyId\",\"type\":\"string\",\"indexed\":false,\"internalType\":\"string\"},{\"name\":\"generation\",\"type\":\"uint32\",\"indexed\":false,\"internalType\":\"uint32\"},{\"name\":\"at\",\"type\":\"uint64\",\"indexed\":false,\"internalType\":\"uint64\"},{\"name\":\"retireAfter\",\"type\":\"uint64\",\"indexed\":false,\"internalType\":\"uint64\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"ShareComplaintAnswered\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"indexed\":true,\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"player\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"},{\"name\":\"share\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"ShareComplaintOpened\",\"inputs\":[{\"name\":\"epoch\",\"type\":\"uint64\",\"indexed\":true,\"internalType\":\"uint64\"},{\"name\":\"dealer\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"player\",\"type\":\"address\",\"indexed\":false,\"internalType\":\"address\"},{\"name\":\"deadline\",\"type\":\"uint64\",\"indexed\":false,\"internalType\":\"uint64\"}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"Ack1Invalid\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"Ack2Invalid\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"AcksMustBeFromDifferentPlayers\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"AnswerWindowOpen\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"AnswerWindowOver\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"BN254VerifierNotConfigured\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"CommitmentAlreadySubmitted\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"CommitmentMismatch\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ComplaintAlreadyAnswered\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ComplaintAlreadyOpen\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ComplaintPeriodOver\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"DealerMismatch\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ECDSAVerifierNotConfigured\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"EquivocationAlreadyProven\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidCommitmentHash\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidCurveType\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidG2Point\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidMerkleRoot\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidRotation\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidShareAlreadyProven\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NoCommitment\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NoComplaint\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"NoEquivocationDetected\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"OperatorNotRegisteredBN254\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"OperatorNotRegisteredECDSA\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"RotationLocked\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"SelfComplaint\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"ShareInvalid\",\"inputs\":[]}]",
 	Bin: "0x6080604052348015600e575f5ffd5b5060156019565b60d3565b5f54610100900460ff161560835760405162461bcd60e51b815260206004820152602760248201527f496e697469616c697a61626c653a20636f6e747261637420697320696e697469604482015266616c697a696e6760c81b606482015260840160405180910390fd5b5f5460ff9081161460d1575f805460ff191660ff9081179091556040519081527f7f26b83ff96e1f2b6a682f133852f6798a09c465da95921460cefb38474024989060200160405180910390a15b565b610f36806100e05f395ff3fe608060405234801561000f575f5ffd5b50600436106100f0575f3560e01c8063b8c1430611610093578063e1ebfc3711610063578063e1ebfc371461027e578063ea1f0a7b146102aa578063f2fde38b146102bd578063fd935eb4146102d0575f5ffd5b8063b8c143061461021f578063d3728de414610232578063d50b374814610258578063de1164bb1461026b575f5ffd5b8063715018a6116100ce578063715018a6146101a25780637b1a1e26146101aa5780638da5cb5b146101e7578063ad0f95821461020c575f5ffd5b80630b3d2f92146100f45780630e1a71581461010957806356a62d0f1461011c575b5f5ffd5b610107610102366004610c36565b610309565b005b610107610117366004610c65565b6103aa565b61018261012a366004610cf6565b67ffffffffffffffff82165f9081526068602090815260408083206001600160a01b03851684528252918290208251606081018452815480825260018301549382018490526002909201549301839052919250925092565b604080519384526020840192909252908201526060015b60405180910390f35b61010761056b565b6101d76101b8366004610cf6565b606960209081525f928352604080842090915290825290205460ff1681565b6040519015158152602001610199565b6033546001600160a01b03165b6040516001600160a01b039091168152602001610199565b6066546101f4906001600160a01b031681565b6067546101f4906001600160a01b031681565b60675461024690600160a01b900460ff1681565b60405160ff9091168152602001610199565b610107610266366004610d27565b61057e565b6065546101f4906001600160a01b031681565b60655461029590600160a01b900463ffffffff1681565b60405163ffffffff9091168152602001610199565b6101076102b8366004610d6d565b61068a565b6101076102cb366004610df2565b610a1a565b6101826102de366004610cf6565b606860209081525f928352604080842090915290825290208054600182015460029092015490919083565b610311610a93565b8060ff1660011415801561032957508060ff16600214155b156103475760405163fdea7c0960e01b815260040160405180910390fd5b6067805460ff838116600160a01b81810260ff60a01b1985161790945560408051949093049091168084526020840191909152917fc2fda93842fa9624ded7e2dfc4d8012be02d28201944b8aa9dc0987fe4515678910160405180910390a15050565b5f54610100900460ff16158080156103c857505f54600160ff909116105b806103e15750303b1580156103e157505f5460ff166001145b6104495760405162461bcd60e51b815260206004820152602e60248201527f496e697469616c697a61626c653a20636f6e747261637420697320616c72656160448201526d191e481a5b9a5d1a585b1a5e995960921b60648201526084015b60405180910390fd5b5f805460ff19166001179055801561046a575f805461ff0019166101001790555b8160ff1660011415801561048257508160ff16600214155b156104a05760405163fdea7c0960e01b815260040160405180910390fd5b6104a8610aed565b6104b187610b1b565b606580546001600160a01b038881166001600160c01b031990921691909117600160a01b63ffffffff8916810291909117909255606680546001600160a01b031916878316179055606780549186166001600160a81b03199092169190911760ff85169092029190911790558015610562575f805461ff0019169055604051600181527f7f26b83ff96e1f2b6a682f133852f6798a09c465da95921460cefb38474024989060200160405180910390a15b50505050505050565b610573610a93565b61057c5f610b1b565b565b8161059c5760405163029dd5dd60e41b815260040160405180910390fd5b806105ba57604051639dd854d360e01b815260040160405180910390fd5b67ffffffffffffffff83165f908152606860209081526040808320338452909152902054156105fb57604051626a17dd60e61b815260040160405180910390fd5b6040805160608101825283815260208082018481524383850190815267ffffffffffffffff88165f818152606885528681203380835290865290879020955186559251600186015590516002909401939093558351868152918201859052927fc67cced54d126bd1721153300cdbf3ee48fdd6f98a5a643b5afa983f558419d5910160405180910390a3505050565b67ffffffffffffffff84165f9081526068602090815260408083206001600160a01b0387168452909152902060010154806106d857604051635b07c98960e01b815260040160405180910390fd5b67ffffffffffffffff85165f9081526069602090815260408083206001600160a01b038816845290915290205460ff1615610726576040516301b5f1b760e71b815260040160405180910390fd5b6107336020830183610df2565b6001600160a01b03166107496020850185610df2565b6001600160a01b0316036107705760405163cb76bd6360e01b815260040160405180910390fd5b6107806040830160208401610df2565b6001600160a01b03166107996040850160208601610df2565b6001600160a01b0316146107c05760405163bcd365b360e01b815260040160405180910390fd5b816040013583604001351480156107de575081606001358360600135145b156107fc5760405163e609617560e01b815260040160405180910390fd5b5f61080a6020850185610df2565b61081a6040860160208701610df2565b8786604001358760600135604051602001610839959493929190610e0b565b60408051601f19818403018152919052805160209182012091505f9061086190850185610df2565b6108716040860160208701610df2565b8886604001358760600135604051602001610890959493929190610e0b565b60408051601f19818403018152919052805160209091012090506108f46108ba6080870187610e54565b808060200260200160405190810160405280939291908181526020018383602002808284375f92019190915250879250869150610b6c9050565b61091157604051637990605b60e01b815260040160405180910390fd5b61095b6109216080860186610e54565b808060200260200160405190810160405280939291908181526020018383602002808284375f92019190915250879250859150610b6c9050565b6109785760405163c00719db60e01b815260040160405180910390fd5b67ffffffffffffffff87165f8181526069602090815260408083206001600160a01b038b168085529083529220805460ff191660011790559091907f86c0a9d8ee45dd6550a34414591b4eddd9a5bdcdf34a78f4b6de6cfd5d185c73906109e190890189610df2565b6109ee6020890189610df2565b604080516001600160a01b0393841681529290911660208301520160405180910390a350505050505050565b610a22610a93565b6001600160a01b038116610a875760405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201526564647265737360d01b6064820152608401610440565b610a9081610b1b565b50565b6033546001600160a01b0316331461057c5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610440565b5f54610100900460ff16610b135760405162461bcd60e51b815260040161044090610ea1565b61057c610b81565b603380546001600160a01b038381166001600160a01b0319831681179093556040519116919082907f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0905f90a35050565b5f82610b788584610bb0565b14949350505050565b5f54610100900460ff16610ba75760405162461bcd60e51b815260040161044090610ea1565b61057c33610b1b565b5f81815b8451811015610bea57610be082868381518110610bd357610bd3610eec565b6020026020010151610bf2565b9150600101610bb4565b509392505050565b5f818310610c0c575f828152602084905260409020610c1a565b5f8381526020839052604090205b9392505050565b803560ff81168114610c31575f5ffd5b919050565b5f60208284031215610c46575f5ffd5b610c1a82610c21565b80356001600160a01b0381168114610c31575f5ffd5b5f5f5f5f5f5f60c08789031215610c7a575f5ffd5b610c8387610c4f565b9550610c9160208801610c4f565b9450604087013563ffffffff81168114610ca9575f5ffd5b9350610cb760608801610c4f565b9250610cc560808801610c4f565b9150610cd360a08801610c21565b90509295509295509295565b803567ffffffffffffffff81168114610c31575f5ffd5b5f5f60408385031215610d07575f5ffd5b610d1083610cdf565b9150610d1e60208401610c4f565b90509250929050565b5f5f5f60608486031215610d39575f5ffd5b610d4284610cdf565b95602085013595506040909401359392505050565b5f60a08284031215610d67575f5ffd5b50919050565b5f5f5f5f60808587031215610d80575f5ffd5b610d8985610cdf565b9350610d9760208601610c4f565b9250604085013567ffffffffffffffff811115610db2575f5ffd5b610dbe87828801610d57565b925050606085013567ffffffffffffffff811115610dda575f5ffd5b610de687828801610d57565b91505092959194509250565b5f60208284031215610e02575f5ffd5b610c1a82610c4f565b606095861b6bffffffffffffffffffffffff1990811682529490951b909316601485015260c09190911b6001600160c01b03191660288401526030830152605082015260700190565b5f5f8335601e19843603018112610e69575f5ffd5b83018035915067ffffffffffffffff821115610e83575f5ffd5b6020019150600581901b3603821315610e9a575f5ffd5b9250929050565b6020808252602b908201527f496e697469616c697a61626c653a20636f6e7472616374206973206e6f74206960408201526a6e697469616c697a696e6760a81b606082015260800190565b634e487b7160e01b5f52603260045260245ffdfea26469706673582212204f301a5d7589a6305ef3c6813709b9aa1b771f6e2c63055e3b41eabc26c5c15764736f6c634300081b0033",
 }
 
@@ -211,6 +211,99 @@ func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactorRaw) Tran
 	return _EigenKMSCommitmentRegistry.Contract.contract.Transact(opts, method, params...)
 }
 
+// ANSWERWINDOW is a free data retrieval call binding the contract method 0x05dfc472.
+//
+// Solidity: function ANSWER_WINDOW() view returns(uint64)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCaller) ANSWERWINDOW(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _EigenKMSCommitmentRegistry.contract.Call(opts, &out, "ANSWER_WINDOW")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// ANSWERWINDOW is a free data retrieval call binding the contract method 0x05dfc472.
+//
+// Solidity: function ANSWER_WINDOW() view returns(uint64)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) ANSWERWINDOW() (uint64, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ANSWERWINDOW(&_EigenKMSCommitmentRegistry.CallOpts)
+}
+
+// ANSWERWINDOW is a free data retrieval call binding the contract method 0x05dfc472.
+//
+// Solidity: function ANSWER_WINDOW() view returns(uint64)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCallerSession) ANSWERWINDOW() (uint64, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ANSWERWINDOW(&_EigenKMSCommitmentRegistry.CallOpts)
+}
+
+// COMPLAINTPERIOD is a free data retrieval call binding the contract method 0x6e181d77.
+//
+// Solidity: function COMPLAINT_PERIOD() view returns(uint64)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCaller) COMPLAINTPERIOD(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _EigenKMSCommitmentRegistry.contract.Call(opts, &out, "COMPLAINT_PERIOD")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// COMPLAINTPERIOD is a free data retrieval call binding the contract method 0x6e181d77.
+//
+// Solidity: function COMPLAINT_PERIOD() view returns(uint64)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) COMPLAINTPERIOD() (uint64, error) {
+	return _EigenKMSCommitmentRegistry.Contract.COMPLAINTPERIOD(&_EigenKMSCommitmentRegistry.CallOpts)
+}
+
+// COMPLAINTPERIOD is a free data retrieval call binding the contract method 0x6e181d77.
+//
+// Solidity: function COMPLAINT_PERIOD() view returns(uint64)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCallerSession) COMPLAINTPERIOD() (uint64, error) {
+	return _EigenKMSCommitmentRegistry.Contract.COMPLAINTPERIOD(&_EigenKMSCommitmentRegistry.CallOpts)
+}
+
+// ROTATIONNOTICE is a free data retrieval call binding the contract method 0x99942c9b.
+//
+// Solidity: function ROTATION_NOTICE() view returns(uint64)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCaller) ROTATIONNOTICE(opts *bind.CallOpts) (uint64, error) {
+	var out []interface{}
+	err := _EigenKMSCommitmentRegistry.contract.Call(opts, &out, "ROTATION_NOTICE")
+
+	if err != nil {
+		return *new(uint64), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(uint64)).(*uint64)
+
+	return out0, err
+
+}
+
+// ROTATIONNOTICE is a free data retrieval call binding the contract method 0x99942c9b.
+//
+// Solidity: function ROTATION_NOTICE() view returns(uint64)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) ROTATIONNOTICE() (uint64, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ROTATIONNOTICE(&_EigenKMSCommitmentRegistry.CallOpts)
+}
+
+// ROTATIONNOTICE is a free data retrieval call binding the contract method 0x99942c9b.
+//
+// Solidity: function ROTATION_NOTICE() view returns(uint64)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCallerSession) ROTATIONNOTICE() (uint64, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ROTATIONNOTICE(&_EigenKMSCommitmentRegistry.CallOpts)
+}
+
 // Avs is a free data retrieval call binding the contract method 0xde1164bb.
 //
 // Solidity: function avs() view returns(address)
@@ -466,6 +559,132 @@ func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCallerSession) GetC
 	return _EigenKMSCommitmentRegistry.Contract.GetCommitment(&_EigenKMSCommitmentRegistry.CallOpts, epoch, operator)
 }
 
+// GetRotation is a free data retrieval call binding the contract method 0xd19f574c.
+//
+// Solidity: function getRotation(string keyId) view returns(uint32 generation, uint64 at, uint64 retireAfter)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCaller) GetRotation(opts *bind.CallOpts, keyId string) (struct {
+	Generation  uint32
+	At          uint64
+	RetireAfter uint64
+}, error) {
+	var out []interface{}
+	err := _EigenKMSCommitmentRegistry.contract.Call(opts, &out, "getRotation", keyId)
+
+	outstruct := new(struct {
+		Generation  uint32
+		At          uint64
+		RetireAfter uint64
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Generation = *abi.ConvertType(out[0], new(uint32)).(*uint32)
+	outstruct.At = *abi.ConvertType(out[1], new(uint64)).(*uint64)
+	outstruct.RetireAfter = *abi.ConvertType(out[2], new(uint64)).(*uint64)
+
+	return *outstruct, err
+
+}
+
+// GetRotation is a free data retrieval call binding the contract method 0xd19f574c.
+//
+// Solidity: function getRotation(string keyId) view returns(uint32 generation, uint64 at, uint64 retireAfter)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) GetRotation(keyId string) (struct {
+	Generation  uint32
+	At          uint64
+	RetireAfter uint64
+}, error) {
+	return _EigenKMSCommitmentRegistry.Contract.GetRotation(&_EigenKMSCommitmentRegistry.CallOpts, keyId)
+}
+
+// GetRotation is a free data retrieval call binding the contract method 0xd19f574c.
+//
+// Solidity: function getRotation(string keyId) view returns(uint32 generation, uint64 at, uint64 retireAfter)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCallerSession) GetRotation(keyId string) (struct {
+	Generation  uint32
+	At          uint64
+	RetireAfter uint64
+}, error) {
+	return _EigenKMSCommitmentRegistry.Contract.GetRotation(&_EigenKMSCommitmentRegistry.CallOpts, keyId)
+}
+
+// GetShareComplaint is a free data retrieval call binding the contract method 0xdfae566b.
+//
+// Solidity: function getShareComplaint(uint64 epoch, address dealer, address player) view returns(uint64 deadline, bool answered)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCaller) GetShareComplaint(opts *bind.CallOpts, epoch uint64, dealer common.Address, player common.Address) (struct {
+	Deadline uint64
+	Answered bool
+}, error) {
+	var out []interface{}
+	err := _EigenKMSCommitmentRegistry.contract.Call(opts, &out, "getShareComplaint", epoch, dealer, player)
+
+	outstruct := new(struct {
+		Deadline uint64
+		Answered bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.Deadline = *abi.ConvertType(out[0], new(uint64)).(*uint64)
+	outstruct.Answered = *abi.ConvertType(out[1], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// GetShareComplaint is a free data retrieval call binding the contract method 0xdfae566b.
+//
+// Solidity: function getShareComplaint(uint64 epoch, address dealer, address player) view returns(uint64 deadline, bool answered)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) GetShareComplaint(epoch uint64, dealer common.Address, player common.Address) (struct {
+	Deadline uint64
+	Answered bool
+}, error) {
+	return _EigenKMSCommitmentRegistry.Contract.GetShareComplaint(&_EigenKMSCommitmentRegistry.CallOpts, epoch, dealer, player)
+}
+
+// GetShareComplaint is a free data retrieval call binding the contract method 0xdfae566b.
+//
+// Solidity: function getShareComplaint(uint64 epoch, address dealer, address player) view returns(uint64 deadline, bool answered)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCallerSession) GetShareComplaint(epoch uint64, dealer common.Address, player common.Address) (struct {
+	Deadline uint64
+	Answered bool
+}, error) {
+	return _EigenKMSCommitmentRegistry.Contract.GetShareComplaint(&_EigenKMSCommitmentRegistry.CallOpts, epoch, dealer, player)
+}
+
+// InvalidShareProven is a free data retrieval call binding the contract method 0xc09a5957.
+//
+// Solidity: function invalidShareProven(uint64 , address ) view returns(bool)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCaller) InvalidShareProven(opts *bind.CallOpts, arg0 uint64, arg1 common.Address) (bool, error) {
+	var out []interface{}
+	err := _EigenKMSCommitmentRegistry.contract.Call(opts, &out, "invalidShareProven", arg0, arg1)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// InvalidShareProven is a free data retrieval call binding the contract method 0xc09a5957.
+//
+// Solidity: function invalidShareProven(uint64 , address ) view returns(bool)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) InvalidShareProven(arg0 uint64, arg1 common.Address) (bool, error) {
+	return _EigenKMSCommitmentRegistry.Contract.InvalidShareProven(&_EigenKMSCommitmentRegistry.CallOpts, arg0, arg1)
+}
+
+// InvalidShareProven is a free data retrieval call binding the contract method 0xc09a5957.
+//
+// Solidity: function invalidShareProven(uint64 , address ) view returns(bool)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCallerSession) InvalidShareProven(arg0 uint64, arg1 common.Address) (bool, error) {
+	return _EigenKMSCommitmentRegistry.Contract.InvalidShareProven(&_EigenKMSCommitmentRegistry.CallOpts, arg0, arg1)
+}
+
 // OperatorSetId is a free data retrieval call binding the contract method 0xe1ebfc37.
 //
 // Solidity: function operatorSetId() view returns(uint32)
@@ -528,6 +747,48 @@ func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryCallerSession) Owne
 	return _EigenKMSCommitmentRegistry.Contract.Owner(&_EigenKMSCommitmentRegistry.CallOpts)
 }
 
+// AnswerShareComplaint is a paid mutator transaction binding the contract method 0x175e2006.
+//
+// Solidity: function answerShareComplaint(uint64 epoch, address player, uint256 share, bytes[] commitmentPoints, uint64 sourceVersion) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactor) AnswerShareComplaint(opts *bind.TransactOpts, epoch uint64, player common.Address, share *big.Int, commitmentPoints [][]byte, sourceVersion uint64) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.contract.Transact(opts, "answerShareComplaint", epoch, player, share, commitmentPoints, sourceVersion)
+}
+
+// AnswerShareComplaint is a paid mutator transaction binding the contract method 0x175e2006.
+//
+// Solidity: function answerShareComplaint(uint64 epoch, address player, uint256 share, bytes[] commitmentPoints, uint64 sourceVersion) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) AnswerShareComplaint(epoch uint64, player common.Address, share *big.Int, commitmentPoints [][]byte, sourceVersion uint64) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.Contract.AnswerShareComplaint(&_EigenKMSCommitmentRegistry.TransactOpts, epoch, player, share, commitmentPoints, sourceVersion)
+}
+
+// AnswerShareComplaint is a paid mutator transaction binding the contract method 0x175e2006.
+//
+// Solidity: function answerShareComplaint(uint64 epoch, address player, uint256 share, bytes[] commitmentPoints, uint64 sourceVersion) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactorSession) AnswerShareComplaint(epoch uint64, player common.Address, share *big.Int, commitmentPoints [][]byte, sourceVersion uint64) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.Contract.AnswerShareComplaint(&_EigenKMSCommitmentRegistry.TransactOpts, epoch, player, share, commitmentPoints, sourceVersion)
+}
+
+// ComplainShare is a paid mutator transaction binding the contract method 0x7960d441.
+//
+// Solidity: function complainShare(uint64 epoch, address dealer) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactor) ComplainShare(opts *bind.TransactOpts, epoch uint64, dealer common.Address) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.contract.Transact(opts, "complainShare", epoch, dealer)
+}
+
+// ComplainShare is a paid mutator transaction binding the contract method 0x7960d441.
+//
+// Solidity: function complainShare(uint64 epoch, address dealer) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) ComplainShare(epoch uint64, dealer common.Address) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ComplainShare(&_EigenKMSCommitmentRegistry.TransactOpts, epoch, dealer)
+}
+
+// ComplainShare is a paid mutator transaction binding the contract method 0x7960d441.
+//
+// Solidity: function complainShare(uint64 epoch, address dealer) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactorSession) ComplainShare(epoch uint64, dealer common.Address) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ComplainShare(&_EigenKMSCommitmentRegistry.TransactOpts, epoch, dealer)
+}
+
 // Initialize is a paid mutator transaction binding the contract method 0x0e1a7158.
 //
 // Solidity: function initialize(address _owner, address _avs, uint32 _operatorSetId, address _ecdsaCertificateVerifier, address _bn254CertificateVerifier, uint8 _curveType) returns()
@@ -570,6 +831,27 @@ func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactorSession)
 	return _EigenKMSCommitmentRegistry.Contract.ProveEquivocation(&_EigenKMSCommitmentRegistry.TransactOpts, epoch, dealer, ack1, ack2)
 }
 
+// ProveInvalidShare is a paid mutator transaction binding the contract method 0x66a00fbc.
+//
+// Solidity: function proveInvalidShare(uint64 epoch, address dealer, address player) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactor) ProveInvalidShare(opts *bind.TransactOpts, epoch uint64, dealer common.Address, player common.Address) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.contract.Transact(opts, "proveInvalidShare", epoch, dealer, player)
+}
+
+// ProveInvalidShare is a paid mutator transaction binding the contract method 0x66a00fbc.
+//
+// Solidity: function proveInvalidShare(uint64 epoch, address dealer, address player) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) ProveInvalidShare(epoch uint64, dealer common.Address, player common.Address) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ProveInvalidShare(&_EigenKMSCommitmentRegistry.TransactOpts, epoch, dealer, player)
+}
+
+// ProveInvalidShare is a paid mutator transaction binding the contract method 0x66a00fbc.
+//
+// Solidity: function proveInvalidShare(uint64 epoch, address dealer, address player) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactorSession) ProveInvalidShare(epoch uint64, dealer common.Address, player common.Address) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ProveInvalidShare(&_EigenKMSCommitmentRegistry.TransactOpts, epoch, dealer, player)
+}
+
 // RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
 //
 // Solidity: function renounceOwnership() returns()
@@ -591,6 +873,27 @@ func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactorSession)
 	return _EigenKMSCommitmentRegistry.Contract.RenounceOwnership(&_EigenKMSCommitmentRegistry.TransactOpts)
 }
 
+// ScheduleRotation is a paid mutator transaction binding the contract method 0x977c30ff.
+//
+// Solidity: function scheduleRotation(string keyId, uint32 generation, uint64 at, uint64 retireAfter) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactor) ScheduleRotation(opts *bind.TransactOpts, keyId string, generation uint32, at uint64, retireAfter uint64) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.contract.Transact(opts, "scheduleRotation", keyId, generation, at, retireAfter)
+}
+
+// ScheduleRotation is a paid mutator transaction binding the contract method 0x977c30ff.
+//
+// Solidity: function scheduleRotation(string keyId, uint32 generation, uint64 at, uint64 retireAfter) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistrySession) ScheduleRotation(keyId string, generation uint32, at uint64, retireAfter uint64) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ScheduleRotation(&_EigenKMSCommitmentRegistry.TransactOpts, keyId, generation, at, retireAfter)
+}
+
+// ScheduleRotation is a paid mutator transaction binding the contract method 0x977c30ff.
+//
+// Solidity: function scheduleRotation(string keyId, uint32 generation, uint64 at, uint64 retireAfter) returns()
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryTransactorSession) ScheduleRotation(keyId string, generation uint32, at uint64, retireAfter uint64) (*types.Transaction, error) {
+	return _EigenKMSCommitmentRegistry.Contract.ScheduleRotation(&_EigenKMSCommitmentRegistry.TransactOpts, keyId, generation, at, retireAfter)
+}
+
 // SetCurveType is a paid mutator transaction binding the contract method 0x0b3d2f92.
 //
 // Solidity: function setCurveType(uint8 _curveType) returns()
@@ -1233,9 +1536,9 @@ func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) ParseInit
 	return event, nil
 }
 
-// EigenKMSCommitmentRegistryOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the EigenKMSCommitmentRegistry contract.
-type EigenKMSCommitmentRegistryOwnershipTransferredIterator struct {
-	Event *EigenKMSCommitmentRegistryOwnershipTransferred // Event containing the contract specifics and raw log
+// EigenKMSCommitmentRegistryInvalidShareProvenIterator is returned from FilterInvalidShareProven and is used to iterate over the raw logs and unpacked data for InvalidShareProven events raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryInvalidShareProvenIterator struct {
+	Event *EigenKMSCommitmentRegistryInvalidShareProven // Event containing the contract specifics and raw log
 
 	contract *bind.BoundContract // Generic contract to use for unpacking event data
 	event    string              // Event name to use for unpacking event data
@@ -1249,7 +1552,7 @@ type EigenKMSCommitmentRegistryOwnershipTransferredIterator struct {
 // Next advances the iterator to the subsequent event, returning whether there
 // are any more events found. In case of a retrieval or parsing error, false is
 // returned and Error() can be queried for the exact failure.
-func (it *EigenKMSCommitmentRegistryOwnershipTransferredIterator) Next() bool {
+func (it *EigenKMSCommitmentRegistryInvalidShareProvenIterator) Next() bool {
 	// If the iterator failed, stop iterating
 	if it.fail != nil {
 		return false
@@ -1258,7 +1561,7 @@ func (it *EigenKMSCommitmentRegistryOwnershipTransferredIterator) Next() bool {
 	if it.done {
 		select {
 		case log := <-it.logs:
-			it.Event = new(EigenKMSCommitmentRegistryOwnershipTransferred)
+			it.Event = new(EigenKMSCommitmentRegistryInvalidShareProven)
 			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
 				it.fail = err
 				return false
@@ -1273,7 +1576,7 @@ func (it *EigenKMSCommitmentRegistryOwnershipTransferredIterator) Next() bool {
 	// Iterator still in progress, wait for either a data or an error event
 	select {
 	case log := <-it.logs:
-		it.Event = new(EigenKMSCommitmentRegistryOwnershipTransferred)
+		it.Event = new(EigenKMSCommitmentRegistryInvalidShareProven)
 		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
 			it.fail = err
 			return false
@@ -1289,60 +1592,61 @@ func (it *EigenKMSCommitmentRegistryOwnershipTransferredIterator) Next() bool {
 }
 
 // Error returns any retrieval or parsing error occurred during filtering.
-func (it *EigenKMSCommitmentRegistryOwnershipTransferredIterator) Error() error {
+func (it *EigenKMSCommitmentRegistryInvalidShareProvenIterator) Error() error {
 	return it.fail
 }
 
 // Close terminates the iteration process, releasing any pending underlying
 // resources.
-func (it *EigenKMSCommitmentRegistryOwnershipTransferredIterator) Close() error {
+func (it *EigenKMSCommitmentRegistryInvalidShareProvenIterator) Close() error {
 	it.sub.Unsubscribe()
 	return nil
 }
 
-// EigenKMSCommitmentRegistryOwnershipTransferred represents a OwnershipTransferred event raised by the EigenKMSCommitmentRegistry contract.
-type EigenKMSCommitmentRegistryOwnershipTransferred struct {
-	PreviousOwner common.Address
-	NewOwner      common.Address
-	Raw           types.Log // Blockchain specific contextual infos
+// EigenKMSCommitmentRegistryInvalidShareProven represents a InvalidShareProven event raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryInvalidShareProven struct {
+	Epoch  uint64
+	Dealer common.Address
+	Player common.Address
+	Raw    types.Log // Blockchain specific contextual infos
 }
 
-// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+// FilterInvalidShareProven is a free log retrieval operation binding the contract event 0xecc26da818bf1ad9909e4492faa21460721cf0229203849aacec47d6b50133f2.
 //
-// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
-func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*EigenKMSCommitmentRegistryOwnershipTransferredIterator, error) {
+// Solidity: event InvalidShareProven(uint64 indexed epoch, address indexed dealer, address player)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) FilterInvalidShareProven(opts *bind.FilterOpts, epoch []uint64, dealer []common.Address) (*EigenKMSCommitmentRegistryInvalidShareProvenIterator, error) {
 
-	var previousOwnerRule []interface{}
-	for _, previousOwnerItem := range previousOwner {
-		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	var epochRule []interface{}
+	for _, epochItem := range epoch {
+		epochRule = append(epochRule, epochItem)
 	}
-	var newOwnerRule []interface{}
-	for _, newOwnerItem := range newOwner {
-		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	var dealerRule []interface{}
+	for _, dealerItem := range dealer {
+		dealerRule = append(dealerRule, dealerItem)
 	}
 
-	logs, sub, err := _EigenKMSCommitmentRegistry.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.FilterLogs(opts, "InvalidShareProven", epochRule, dealerRule)
 	if err != nil {
 		return nil, err
 	}
-	return &EigenKMSCommitmentRegistryOwnershipTransferredIterator{contract: _EigenKMSCommitmentRegistry.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+	return &EigenKMSCommitmentRegistryInvalidShareProvenIterator{contract: _EigenKMSCommitmentRegistry.contract, event: "InvalidShareProven", logs: logs, sub: sub}, nil
 }
 
-// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+// WatchInvalidShareProven is a free log subscription operation binding the contract event 0xecc26da818bf1ad9909e4492faa21460721cf0229203849aacec47d6b50133f2.
 //
-// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
-func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *EigenKMSCommitmentRegistryOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+// Solidity: event InvalidShareProven(uint64 indexed epoch, address indexed dealer, address player)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) WatchInvalidShareProven(opts *bind.WatchOpts, sink chan<- *EigenKMSCommitmentRegistryInvalidShareProven, epoch []uint64, dealer []common.Address) (event.Subscription, error) {
 
-	var previousOwnerRule []interface{}
-	for _, previousOwnerItem := range previousOwner {
-		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	var epochRule []interface{}
+	for _, epochItem := range epoch {
+		epochRule = append(epochRule, epochItem)
 	}
-	var newOwnerRule []interface{}
-	for _, newOwnerItem := range newOwner {
-		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	var dealerRule []interface{}
+	for _, dealerItem := range dealer {
+		dealerRule = append(dealerRule, dealerItem)
 	}
 
-	logs, sub, err := _EigenKMSCommitmentRegistry.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.WatchLogs(opts, "InvalidShareProven", epochRule, dealerRule)
 	if err != nil {
 		return nil, err
 	}
@@ -1352,8 +1656,8 @@ func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) WatchOwne
 			select {
 			case log := <-logs:
 				// New log arrived, parse the event and forward to the user
-				event := new(EigenKMSCommitmentRegistryOwnershipTransferred)
-				if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+				event := new(EigenKMSCommitmentRegistryInvalidShareProven)
+				if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "InvalidShareProven", log); err != nil {
 					return err
 				}
 				event.Raw = log
@@ -1374,12 +1678,612 @@ func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) WatchOwne
 	}), nil
 }
 
-// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+// ParseInvalidShareProven is a log parse operation binding the contract event 0xecc26da818bf1ad9909e4492faa21460721cf0229203849aacec47d6b50133f2.
 //
-// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
-func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) ParseOwnershipTransferred(log types.Log) (*EigenKMSCommitmentRegistryOwnershipTransferred, error) {
-	event := new(EigenKMSCommitmentRegistryOwnershipTransferred)
-	if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+// Solidity: event InvalidShareProven(uint64 indexed epoch, address indexed dealer, address player)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) ParseInvalidShareProven(log types.Log) (*EigenKMSCommitmentRegistryInvalidShareProven, error) {
+	event := new(EigenKMSCommitmentRegistryInvalidShareProven)
+	if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "InvalidShareProven", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EigenKMSCommitmentRegistryOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryOwnershipTransferredIterator struct {
+	Event *EigenKMSCommitmentRegistryOwnershipTransferred // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EigenKMSCommitmentRegistryOwnershipTransferredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EigenKMSCommitmentRegistryOwnershipTransferred)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EigenKMSCommitmentRegistryOwnershipTransferred)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EigenKMSCommitmentRegistryOwnershipTransferredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EigenKMSCommitmentRegistryOwnershipTransferredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EigenKMSCommitmentRegistryOwnershipTransferred represents a OwnershipTransferred event raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryOwnershipTransferred struct {
+	PreviousOwner common.Address
+	NewOwner      common.Address
+	Raw           types.Log // Blockchain specific contextual infos
+}
+
+// FilterOwnershipTransferred is a free log retrieval operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) FilterOwnershipTransferred(opts *bind.FilterOpts, previousOwner []common.Address, newOwner []common.Address) (*EigenKMSCommitmentRegistryOwnershipTransferredIterator, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.FilterLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EigenKMSCommitmentRegistryOwnershipTransferredIterator{contract: _EigenKMSCommitmentRegistry.contract, event: "OwnershipTransferred", logs: logs, sub: sub}, nil
+}
+
+// WatchOwnershipTransferred is a free log subscription operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) WatchOwnershipTransferred(opts *bind.WatchOpts, sink chan<- *EigenKMSCommitmentRegistryOwnershipTransferred, previousOwner []common.Address, newOwner []common.Address) (event.Subscription, error) {
+
+	var previousOwnerRule []interface{}
+	for _, previousOwnerItem := range previousOwner {
+		previousOwnerRule = append(previousOwnerRule, previousOwnerItem)
+	}
+	var newOwnerRule []interface{}
+	for _, newOwnerItem := range newOwner {
+		newOwnerRule = append(newOwnerRule, newOwnerItem)
+	}
+
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.WatchLogs(opts, "OwnershipTransferred", previousOwnerRule, newOwnerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EigenKMSCommitmentRegistryOwnershipTransferred)
+				if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseOwnershipTransferred is a log parse operation binding the contract event 0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0.
+//
+// Solidity: event OwnershipTransferred(address indexed previousOwner, address indexed newOwner)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) ParseOwnershipTransferred(log types.Log) (*EigenKMSCommitmentRegistryOwnershipTransferred, error) {
+	event := new(EigenKMSCommitmentRegistryOwnershipTransferred)
+	if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EigenKMSCommitmentRegistryRotationScheduledIterator is returned from FilterRotationScheduled and is used to iterate over the raw logs and unpacked data for RotationScheduled events raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryRotationScheduledIterator struct {
+	Event *EigenKMSCommitmentRegistryRotationScheduled // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EigenKMSCommitmentRegistryRotationScheduledIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EigenKMSCommitmentRegistryRotationScheduled)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EigenKMSCommitmentRegistryRotationScheduled)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EigenKMSCommitmentRegistryRotationScheduledIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EigenKMSCommitmentRegistryRotationScheduledIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EigenKMSCommitmentRegistryRotationScheduled represents a RotationScheduled event raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryRotationScheduled struct {
+	KeyId       string
+	Generation  uint32
+	At          uint64
+	RetireAfter uint64
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterRotationScheduled is a free log retrieval operation binding the contract event 0xb064365e9269885b778458b09c61522b6401a220c6dedd6408ca28b862de895e.
+//
+// Solidity: event RotationScheduled(string keyId, uint32 generation, uint64 at, uint64 retireAfter)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) FilterRotationScheduled(opts *bind.FilterOpts) (*EigenKMSCommitmentRegistryRotationScheduledIterator, error) {
+
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.FilterLogs(opts, "RotationScheduled")
+	if err != nil {
+		return nil, err
+	}
+	return &EigenKMSCommitmentRegistryRotationScheduledIterator{contract: _EigenKMSCommitmentRegistry.contract, event: "RotationScheduled", logs: logs, sub: sub}, nil
+}
+
+// WatchRotationScheduled is a free log subscription operation binding the contract event 0xb064365e9269885b778458b09c61522b6401a220c6dedd6408ca28b862de895e.
+//
+// Solidity: event RotationScheduled(string keyId, uint32 generation, uint64 at, uint64 retireAfter)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) WatchRotationScheduled(opts *bind.WatchOpts, sink chan<- *EigenKMSCommitmentRegistryRotationScheduled) (event.Subscription, error) {
+
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.WatchLogs(opts, "RotationScheduled")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EigenKMSCommitmentRegistryRotationScheduled)
+				if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "RotationScheduled", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseRotationScheduled is a log parse operation binding the contract event 0xb064365e9269885b778458b09c61522b6401a220c6dedd6408ca28b862de895e.
+//
+// Solidity: event RotationScheduled(string keyId, uint32 generation, uint64 at, uint64 retireAfter)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) ParseRotationScheduled(log types.Log) (*EigenKMSCommitmentRegistryRotationScheduled, error) {
+	event := new(EigenKMSCommitmentRegistryRotationScheduled)
+	if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "RotationScheduled", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EigenKMSCommitmentRegistryShareComplaintAnsweredIterator is returned from FilterShareComplaintAnswered and is used to iterate over the raw logs and unpacked data for ShareComplaintAnswered events raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryShareComplaintAnsweredIterator struct {
+	Event *EigenKMSCommitmentRegistryShareComplaintAnswered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EigenKMSCommitmentRegistryShareComplaintAnsweredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EigenKMSCommitmentRegistryShareComplaintAnswered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EigenKMSCommitmentRegistryShareComplaintAnswered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EigenKMSCommitmentRegistryShareComplaintAnsweredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EigenKMSCommitmentRegistryShareComplaintAnsweredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EigenKMSCommitmentRegistryShareComplaintAnswered represents a ShareComplaintAnswered event raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryShareComplaintAnswered struct {
+	Epoch  uint64
+	Dealer common.Address
+	Player common.Address
+	Share  *big.Int
+	Raw    types.Log // Blockchain specific contextual infos
+}
+
+// FilterShareComplaintAnswered is a free log retrieval operation binding the contract event 0x88084d63a5fceedca3fe98d9718534d21ab62738c3984a3c0701d7671cd1b053.
+//
+// Solidity: event ShareComplaintAnswered(uint64 indexed epoch, address indexed dealer, address player, uint256 share)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) FilterShareComplaintAnswered(opts *bind.FilterOpts, epoch []uint64, dealer []common.Address) (*EigenKMSCommitmentRegistryShareComplaintAnsweredIterator, error) {
+
+	var epochRule []interface{}
+	for _, epochItem := range epoch {
+		epochRule = append(epochRule, epochItem)
+	}
+	var dealerRule []interface{}
+	for _, dealerItem := range dealer {
+		dealerRule = append(dealerRule, dealerItem)
+	}
+
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.FilterLogs(opts, "ShareComplaintAnswered", epochRule, dealerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EigenKMSCommitmentRegistryShareComplaintAnsweredIterator{contract: _EigenKMSCommitmentRegistry.contract, event: "ShareComplaintAnswered", logs: logs, sub: sub}, nil
+}
+
+// WatchShareComplaintAnswered is a free log subscription operation binding the contract event 0x88084d63a5fceedca3fe98d9718534d21ab62738c3984a3c0701d7671cd1b053.
+//
+// Solidity: event ShareComplaintAnswered(uint64 indexed epoch, address indexed dealer, address player, uint256 share)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) WatchShareComplaintAnswered(opts *bind.WatchOpts, sink chan<- *EigenKMSCommitmentRegistryShareComplaintAnswered, epoch []uint64, dealer []common.Address) (event.Subscription, error) {
+
+	var epochRule []interface{}
+	for _, epochItem := range epoch {
+		epochRule = append(epochRule, epochItem)
+	}
+	var dealerRule []interface{}
+	for _, dealerItem := range dealer {
+		dealerRule = append(dealerRule, dealerItem)
+	}
+
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.WatchLogs(opts, "ShareComplaintAnswered", epochRule, dealerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EigenKMSCommitmentRegistryShareComplaintAnswered)
+				if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "ShareComplaintAnswered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseShareComplaintAnswered is a log parse operation binding the contract event 0x88084d63a5fceedca3fe98d9718534d21ab62738c3984a3c0701d7671cd1b053.
+//
+// Solidity: event ShareComplaintAnswered(uint64 indexed epoch, address indexed dealer, address player, uint256 share)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) ParseShareComplaintAnswered(log types.Log) (*EigenKMSCommitmentRegistryShareComplaintAnswered, error) {
+	event := new(EigenKMSCommitmentRegistryShareComplaintAnswered)
+	if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "ShareComplaintAnswered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// EigenKMSCommitmentRegistryShareComplaintOpenedIterator is returned from FilterShareComplaintOpened and is used to iterate over the raw logs and unpacked data for ShareComplaintOpened events raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryShareComplaintOpenedIterator struct {
+	Event *EigenKMSCommitmentRegistryShareComplaintOpened // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EigenKMSCommitmentRegistryShareComplaintOpenedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EigenKMSCommitmentRegistryShareComplaintOpened)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EigenKMSCommitmentRegistryShareComplaintOpened)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EigenKMSCommitmentRegistryShareComplaintOpenedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EigenKMSCommitmentRegistryShareComplaintOpenedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EigenKMSCommitmentRegistryShareComplaintOpened represents a ShareComplaintOpened event raised by the EigenKMSCommitmentRegistry contract.
+type EigenKMSCommitmentRegistryShareComplaintOpened struct {
+	Epoch    uint64
+	Dealer   common.Address
+	Player   common.Address
+	Deadline uint64
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterShareComplaintOpened is a free log retrieval operation binding the contract event 0x6cc08481ffd4c4784b3882f7cdba7380aee3df3ea44d97d487ac784eadd5a1f4.
+//
+// Solidity: event ShareComplaintOpened(uint64 indexed epoch, address indexed dealer, address player, uint64 deadline)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) FilterShareComplaintOpened(opts *bind.FilterOpts, epoch []uint64, dealer []common.Address) (*EigenKMSCommitmentRegistryShareComplaintOpenedIterator, error) {
+
+	var epochRule []interface{}
+	for _, epochItem := range epoch {
+		epochRule = append(epochRule, epochItem)
+	}
+	var dealerRule []interface{}
+	for _, dealerItem := range dealer {
+		dealerRule = append(dealerRule, dealerItem)
+	}
+
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.FilterLogs(opts, "ShareComplaintOpened", epochRule, dealerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EigenKMSCommitmentRegistryShareComplaintOpenedIterator{contract: _EigenKMSCommitmentRegistry.contract, event: "ShareComplaintOpened", logs: logs, sub: sub}, nil
+}
+
+// WatchShareComplaintOpened is a free log subscription operation binding the contract event 0x6cc08481ffd4c4784b3882f7cdba7380aee3df3ea44d97d487ac784eadd5a1f4.
+//
+// Solidity: event ShareComplaintOpened(uint64 indexed epoch, address indexed dealer, address player, uint64 deadline)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) WatchShareComplaintOpened(opts *bind.WatchOpts, sink chan<- *EigenKMSCommitmentRegistryShareComplaintOpened, epoch []uint64, dealer []common.Address) (event.Subscription, error) {
+
+	var epochRule []interface{}
+	for _, epochItem := range epoch {
+		epochRule = append(epochRule, epochItem)
+	}
+	var dealerRule []interface{}
+	for _, dealerItem := range dealer {
+		dealerRule = append(dealerRule, dealerItem)
+	}
+
+	logs, sub, err := _EigenKMSCommitmentRegistry.contract.WatchLogs(opts, "ShareComplaintOpened", epochRule, dealerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EigenKMSCommitmentRegistryShareComplaintOpened)
+				if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "ShareComplaintOpened", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseShareComplaintOpened is a log parse operation binding the contract event 0x6cc08481ffd4c4784b3882f7cdba7380aee3df3ea44d97d487ac784eadd5a1f4.
+//
+// Solidity: event ShareComplaintOpened(uint64 indexed epoch, address indexed dealer, address player, uint64 deadline)
+func (_EigenKMSCommitmentRegistry *EigenKMSCommitmentRegistryFilterer) ParseShareComplaintOpened(log types.Log) (*EigenKMSCommitmentRegistryShareComplaintOpened, error) {
+	event := new(EigenKMSCommitmentRegistryShareComplaintOpened)
+	if err := _EigenKMSCommitmentRegistry.contract.UnpackLog(event, "ShareComplaintOpened", log); err != nil {
 		return nil, err
 	}
 	event.Raw = log
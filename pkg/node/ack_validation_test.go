@@ -75,4 +75,21 @@ func TestVerifyAcknowledgement_BindsFieldsAndSignature(t *testing.T) {
 	if err := n.verifyAcknowledgement(session, senderPeer, dealerAddr, epoch, &tampered); err == nil {
 		t.Fatal("expected tampered acknowledgement to be rejected")
 	}
+
+	// An ack signed for another key's session is rejected, and relabelling it as this
+	// node's key breaks its signature.
+	tenant := &Node{logger: logger, transportSigner: ts, OperatorAddress: dealerAddr, KeyID: "tenant-b"}
+	foreign := *ack
+	foreign.KeyID = tenant.KeyID
+	foreign.Signature = tenant.signAcknowledgement(ack.DealerAddress, ack.PlayerAddress, types.CommitmentEpoch(tenant.KeyID, epoch), ack.ShareHash, ack.CommitmentHash)
+	if err := tenant.verifyAcknowledgement(session, senderPeer, dealerAddr, epoch, &foreign); err != nil {
+		t.Fatalf("expected acknowledgement to verify for its own key, got error: %v", err)
+	}
+	if err := n.verifyAcknowledgement(session, senderPeer, dealerAddr, epoch, &foreign); err == nil {
+		t.Fatal("expected acknowledgement for another key to be rejected")
+	}
+	foreign.KeyID = types.DefaultKeyID
+	if err := n.verifyAcknowledgement(session, senderPeer, dealerAddr, epoch, &foreign); err == nil {
+		t.Fatal("expected relabelled acknowledgement to be rejected")
+	}
 }
@@ -0,0 +1,648 @@
+package node
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// triggerNextBlock is the reshareTrigger value asking for a reshare at the next block
+// the poller delivers, whatever its number.
+const triggerNextBlock = -1
+
+// AdminServer serves the admin control API for several nodes, one per key, under
+// /v1/admin/keys/{id}/. It never shares a listener with the public routes: it listens
+// on a Unix socket only the server's user can open, or on a TCP address that requires
+// a client certificate signed by the configured admin CA.
+type AdminServer struct {
+	cfg        config.AdminConfig
+	tlsConfig  *tls.Config // nil for the Unix socket
+	nodes      map[string]*Node
+	keyIDs     []string // sorted
+	httpServer *http.Server
+	logger     *zap.Logger
+}
+
+// NewAdminServer creates an admin server for nodes. The TLS material is loaded here so
+// a bad certificate fails startup rather than the first admin request.
+func NewAdminServer(cfg config.AdminConfig, nodes []*Node, l *zap.Logger) (*AdminServer, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	if !cfg.Enabled() {
+		return nil, fmt.Errorf("admin API has no socket path or address")
+	}
+	as := &AdminServer{
+		cfg:    cfg,
+		nodes:  make(map[string]*Node, len(nodes)),
+		logger: l,
+	}
+	for _, n := range nodes {
+		if _, exists := as.nodes[n.KeyID]; exists {
+			return nil, fmt.Errorf("duplicate key ID %q", n.KeyID)
+		}
+		as.nodes[n.KeyID] = n
+		as.keyIDs = append(as.keyIDs, n.KeyID)
+	}
+	sort.Strings(as.keyIDs)
+
+	if cfg.Address != "" {
+		tlsConfig, err := adminTLSConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		as.tlsConfig = tlsConfig
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v1/status", as.handleStatus)
+	mux.HandleFunc("GET /v1/admin/keys", as.handleListKeys)
+	mux.HandleFunc("GET /v1/admin/keys/{id}/state", as.handleState)
+	mux.HandleFunc("GET /v1/admin/keys/{id}/sessions", as.handleSessions)
+	mux.HandleFunc("GET /v1/admin/keys/{id}/generated-shares", as.handleGeneratedShares)
+	mux.HandleFunc("GET /v1/admin/keys/{id}/retention", as.handleRetention)
+	mux.HandleFunc("POST /v1/admin/keys/{id}/reshare/pause", as.handlePause)
+	mux.HandleFunc("POST /v1/admin/keys/{id}/reshare/resume", as.handleResume)
+	mux.HandleFunc("POST /v1/admin/keys/{id}/reshare/trigger", maxBodySize(4<<10, as.handleTrigger))
+	mux.HandleFunc("POST /v1/admin/keys/{id}/rollback", maxBodySize(4<<10, as.handleRollback))
+	mux.HandleFunc("POST /v1/admin/keys/{id}/poison", maxBodySize(4<<10, as.handlePoison))
+
+	as.httpServer = &http.Server{
+		Handler:           mux,
+		TLSConfig:         as.tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       10 * time.Second,
+		WriteTimeout:      30 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	return as, nil
+}
+
+// adminTLSConfig requires every client to present a certificate signed by the admin CA.
+func adminTLSConfig(cfg config.AdminConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.TLSCertFile, cfg.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load admin TLS certificate: %w", err)
+	}
+	caPEM, err := os.ReadFile(cfg.ClientCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read admin client CA: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("admin client CA %s holds no PEM certificates", cfg.ClientCAFile)
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    clientCAs,
+		MinVersion:   tls.VersionTLS13,
+	}, nil
+}
+
+// Start binds the listener and serves in the background. Bind errors are returned
+// immediately rather than logged from the serving goroutine.
+func (as *AdminServer) Start() error {
+	ln, err := as.listen()
+	if err != nil {
+		return err
+	}
+	as.logger.Sugar().Infow("Starting admin server", "address", ln.Addr().String())
+	go func() {
+		if err := as.httpServer.Serve(ln); err != nil && err != http.ErrServerClosed {
+			as.logger.Sugar().Errorw("Admin server error", "error", err)
+		}
+	}()
+	return nil
+}
+
+func (as *AdminServer) listen() (net.Listener, error) {
+	if as.cfg.Address != "" {
+		ln, err := tls.Listen("tcp", as.cfg.Address, as.tlsConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to listen for admin on %s: %w", as.cfg.Address, err)
+		}
+		return ln, nil
+	}
+
+	// A socket left behind by an unclean shutdown would fail the bind. Never remove
+	// anything that is not a socket.
+	path := as.cfg.SocketPath
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("admin socket path %s exists and is not a socket", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale admin socket: %w", err)
+		}
+	}
+
+	// A socket is created with the process umask applied, and chmod after the bind
+	// leaves a window in which another user can connect. Bind instead inside a private
+	// (0700) directory next to path, where nobody else can reach the socket, restrict
+	// it there and only then move it into place.
+	dir, err := os.MkdirTemp(filepath.Dir(path), ".admin-sock-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create private directory for admin socket: %w", err)
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	bindPath := filepath.Join(dir, "admin.sock")
+
+	ln, err := net.Listen("unix", bindPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for admin on %s: %w", path, err)
+	}
+	// The listener would unlink bindPath on close; the socket is removed from path instead
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	if err := os.Chmod(bindPath, 0o600); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to restrict admin socket permissions: %w", err)
+	}
+	if err := os.Rename(bindPath, path); err != nil {
+		_ = ln.Close()
+		return nil, fmt.Errorf("failed to move admin socket into place: %w", err)
+	}
+	return &unixSocketListener{Listener: ln, path: path}, nil
+}
+
+// unixSocketListener removes the socket file at path when closed.
+type unixSocketListener struct {
+	net.Listener
+	path string
+}
+
+func (l *unixSocketListener) Close() error {
+	err := l.Listener.Close()
+	if rmErr := os.Remove(l.path); rmErr != nil && !os.IsNotExist(rmErr) && err == nil {
+		err = rmErr
+	}
+	return err
+}
+
+// Stop closes the listener, removing the socket file, and any open connections.
+func (as *AdminServer) Stop() error {
+	return as.httpServer.Close()
+}
+
+// GetHandler returns the HTTP handler (for testing)
+func (as *AdminServer) GetHandler() http.Handler {
+	return as.httpServer.Handler
+}
+
+// node returns the node for the request's key, or writes a 404.
+func (as *AdminServer) node(w http.ResponseWriter, r *http.Request) *Node {
+	keyID := r.PathValue("id")
+	n, ok := as.nodes[keyID]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown key %q", keyID), http.StatusNotFound)
+		return nil
+	}
+	return n
+}
+
+// audit logs a state-changing admin request with the caller's identity.
+func (as *AdminServer) audit(r *http.Request, n *Node, action string, keysAndValues ...interface{}) {
+	caller := "unix-socket"
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		caller = r.TLS.PeerCertificates[0].Subject.String()
+	}
+	args := append([]interface{}{
+		"operator_address", n.OperatorAddress.Hex(),
+		"key_id", n.KeyID,
+		"action", action,
+		"caller", caller,
+	}, keysAndValues...)
+	as.logger.Sugar().Warnw("Admin action", args...)
+}
+
+func writeAdminJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeAdminState answers a state-changing request with the key's resulting state.
+func writeAdminState(w http.ResponseWriter, n *Node) {
+	state, err := n.adminState()
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeAdminJSON(w, state)
+}
+
+func (as *AdminServer) handleListKeys(w http.ResponseWriter, r *http.Request) {
+	states := make([]*types.AdminKeyState, 0, len(as.keyIDs))
+	for _, id := range as.keyIDs {
+		state, err := as.nodes[id].adminState()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		states = append(states, state)
+	}
+	writeAdminJSON(w, states)
+}
+
+func (as *AdminServer) handleState(w http.ResponseWriter, r *http.Request) {
+	if n := as.node(w, r); n != nil {
+		writeAdminState(w, n)
+	}
+}
+
+func (as *AdminServer) handleSessions(w http.ResponseWriter, r *http.Request) {
+	if n := as.node(w, r); n != nil {
+		writeAdminJSON(w, n.adminSessions())
+	}
+}
+
+func (as *AdminServer) handleGeneratedShares(w http.ResponseWriter, r *http.Request) {
+	if n := as.node(w, r); n != nil {
+		writeAdminJSON(w, n.retainedShareRounds())
+	}
+}
+
+func (as *AdminServer) handleRetention(w http.ResponseWriter, r *http.Request) {
+	if n := as.node(w, r); n != nil {
+		writeAdminJSON(w, n.KeyRetentionReport(time.Now().Unix()))
+	}
+}
+
+func (as *AdminServer) handlePause(w http.ResponseWriter, r *http.Request) {
+	n := as.node(w, r)
+	if n == nil {
+		return
+	}
+	as.audit(r, n, "pause-reshare")
+	n.setAutoResharePaused(true)
+	writeAdminState(w, n)
+}
+
+func (as *AdminServer) handleResume(w http.ResponseWriter, r *http.Request) {
+	n := as.node(w, r)
+	if n == nil {
+		return
+	}
+	as.audit(r, n, "resume-reshare")
+	n.setAutoResharePaused(false)
+	writeAdminState(w, n)
+}
+
+func (as *AdminServer) handleTrigger(w http.ResponseWriter, r *http.Request) {
+	n := as.node(w, r)
+	if n == nil {
+		return
+	}
+	var req types.AdminReshareTriggerRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "Invalid request body", http.StatusBadRequest)
+			return
+		}
+	}
+	as.audit(r, n, "trigger-reshare", "block_number", req.BlockNumber)
+	if code, err := n.triggerReshare(req.BlockNumber); err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+	writeAdminState(w, n)
+}
+
+func (as *AdminServer) handleRollback(w http.ResponseWriter, r *http.Request) {
+	n := as.node(w, r)
+	if n == nil {
+		return
+	}
+	var req types.AdminVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Version <= 0 {
+		http.Error(w, "Invalid request body: a version is required", http.StatusBadRequest)
+		return
+	}
+	as.audit(r, n, "rollback", "version", req.Version)
+	if code, err := n.rollbackToVersion(req.Version); err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+	writeAdminState(w, n)
+}
+
+func (as *AdminServer) handlePoison(w http.ResponseWriter, r *http.Request) {
+	n := as.node(w, r)
+	if n == nil {
+		return
+	}
+	var req types.AdminVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Version <= 0 {
+		http.Error(w, "Invalid request body: a version is required", http.StatusBadRequest)
+		return
+	}
+	as.audit(r, n, "poison", "version", req.Version)
+	if code, err := n.poisonVersion(req.Version); err != nil {
+		http.Error(w, err.Error(), code)
+		return
+	}
+	writeAdminState(w, n)
+}
+
+// adminState snapshots the node's scheduling and auto-heal state. The last-known-good
+// version and abort counter are read from the persisted node state, which auto-heal
+// writes on every change, rather than from the tracker the reshare goroutine owns.
+func (n *Node) adminState() (*types.AdminKeyState, error) {
+	poisoned, err := n.persistence.ListPoisonedVersions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list poisoned versions: %w", err)
+	}
+	sort.Slice(poisoned, func(i, j int) bool { return poisoned[i] < poisoned[j] })
+	st, err := n.persistence.LoadNodeState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node state: %w", err)
+	}
+
+	state := &types.AdminKeyState{
+		KeyID:               n.KeyID,
+		AutoResharePaused:   n.autoResharePaused.Load(),
+		ReshareTriggerBlock: n.reshareTrigger.Load(),
+		LastBlockNumber:     n.lastBlockNumber.Load(),
+		ActiveVersion:       n.activeKeyVersionNumber(),
+		ActiveGeneration:    n.keyStore.ActiveGeneration(),
+		PoisonedVersions:    poisoned,
+		DemotionThreshold:   demotionThreshold,
+		AutoHealRollback:    n.autoHealRollback.Load(),
+	}
+	if pending := n.keyStore.GetPendingVersion(); pending != nil {
+		state.PendingVersion = pending.Version
+	}
+	if st != nil {
+		state.LastKnownGoodVersion = st.LastKnownGoodSourceVersion
+		state.TrackedSourceVersion = st.TrackedSourceVersion
+		state.ConsecutiveMPKAborts = st.ConsecutiveMPKAborts
+	}
+	return state, nil
+}
+
+// adminSessions describes the in-progress protocol sessions, oldest first.
+func (n *Node) adminSessions() []types.AdminSession {
+	n.sessionMutex.RLock()
+	sessions := make([]*ProtocolSession, 0, len(n.activeSessions))
+	for _, s := range n.activeSessions {
+		sessions = append(sessions, s)
+	}
+	n.sessionMutex.RUnlock()
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].SessionTimestamp < sessions[j].SessionTimestamp })
+
+	out := make([]types.AdminSession, 0, len(sessions))
+	for _, s := range sessions {
+		out = append(out, s.adminSummary())
+	}
+	return out
+}
+
+// adminSummary describes the session with the messages it holds from each operator.
+func (s *ProtocolSession) adminSummary() types.AdminSession {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	acks := make(map[common.Address]int, len(s.Operators))
+	for _, byPlayer := range s.acks {
+		for player := range byPlayer {
+			acks[player]++
+		}
+	}
+	info := types.AdminSession{
+		SessionTimestamp:   s.SessionTimestamp,
+		Type:               s.Type,
+		Phase:              s.Phase,
+		Generation:         s.Generation,
+		TriggerBlockNumber: s.TriggerBlockNumber,
+		StartTime:          s.StartTime.Unix(),
+		Restored:           s.restored,
+		Operators:          make([]types.AdminSessionOperator, 0, len(s.Operators)),
+	}
+	for _, op := range s.Operators {
+		addr := op.OperatorAddress
+		status := types.AdminSessionOperator{Address: addr.Hex(), Acks: acks[addr]}
+		if s.shares[addr] != nil {
+			status.Shares = 1
+		}
+		if len(s.commitments[addr]) > 0 {
+			status.Commitments = 1
+		}
+		info.Operators = append(info.Operators, status)
+	}
+	return info
+}
+
+// retainedShareRounds lists who each retained round of dealt shares is for, oldest
+// round first. The shares themselves stay in memory.
+func (n *Node) retainedShareRounds() []types.RetainedShareRound {
+	n.retainedSharesMutex.RLock()
+	defer n.retainedSharesMutex.RUnlock()
+
+	out := make([]types.RetainedShareRound, 0, len(n.retainedGeneratedShareOrder))
+	for _, ts := range n.retainedGeneratedShareOrder {
+		out = append(out, types.RetainedShareRound{
+			SessionTimestamp: ts,
+			Recipients:       sortedHexAddresses(n.retainedGeneratedShares[ts]),
+		})
+	}
+	return out
+}
+
+func sortedHexAddresses[V any](m map[common.Address]V) []string {
+	out := make([]string, 0, len(m))
+	for addr := range m {
+		out = append(out, addr.Hex())
+	}
+	sort.Strings(out)
+	return out
+}
+
+// setAutoResharePaused pauses or resumes the scheduled reshare. Genesis, joining and
+// scheduled rotations still run, as does a reshare an admin triggers. The flag is
+// persisted so the pause survives a restart; a persistence failure is logged and the
+// in-memory flag applies regardless.
+func (n *Node) setAutoResharePaused(paused bool) {
+	n.autoResharePaused.Store(paused)
+	st, err := n.persistence.LoadNodeState()
+	if err != nil || st == nil {
+		st = &persistence.NodeState{OperatorAddress: n.OperatorAddress.Hex()}
+	}
+	st.AutoResharePaused = paused
+	if err := n.persistence.SaveNodeState(st); err != nil {
+		n.logger.Sugar().Errorw("Failed to persist automatic reshare pause; it will not survive a restart",
+			"operator_address", n.OperatorAddress.Hex(),
+			"paused", paused,
+			"error", err)
+	}
+}
+
+// triggerReshare asks for a reshare at blockNumber, or at the next block when it is 0.
+// A reshare completes only when a threshold of operators run it in the same session,
+// so every operator must be triggered with the same block number (or all at an
+// interval boundary, where the scheduled reshare runs anyway). Returns the HTTP status
+// to fail with.
+func (n *Node) triggerReshare(blockNumber int64) (int, error) {
+	if !n.hasExistingShares() {
+		return http.StatusConflict, fmt.Errorf("node holds no key share to reshare")
+	}
+	if blockNumber < 0 {
+		return http.StatusBadRequest, fmt.Errorf("invalid block number %d", blockNumber)
+	}
+	if last := n.lastBlockNumber.Load(); blockNumber > 0 && blockNumber <= last {
+		return http.StatusBadRequest, fmt.Errorf("block %d is not after the latest block %d", blockNumber, last)
+	}
+	target := blockNumber
+	if target == 0 {
+		target = triggerNextBlock
+	}
+	n.reshareTrigger.Store(target)
+	return 0, nil
+}
+
+// takeReshareTrigger reports whether an admin-triggered reshare is due at blockNumber,
+// clearing the trigger if so. A trigger whose block was never delivered is dropped.
+func (n *Node) takeReshareTrigger(blockNumber int64) bool {
+	target := n.reshareTrigger.Load()
+	switch {
+	case target == 0:
+		return false
+	case target == triggerNextBlock || target == blockNumber:
+		return n.reshareTrigger.CompareAndSwap(target, 0)
+	case target < blockNumber:
+		if n.reshareTrigger.CompareAndSwap(target, 0) {
+			n.logger.Sugar().Warnw("Dropping admin-triggered reshare: its block was skipped",
+				"operator_address", n.OperatorAddress.Hex(),
+				"trigger_block", target,
+				"block_number", blockNumber)
+		}
+	}
+	return false
+}
+
+// runTriggeredReshare starts an admin-triggered reshare off an interval boundary.
+func (n *Node) runTriggeredReshare(blockTimestamp, blockNumber int64) {
+	if !n.hasExistingShares() {
+		n.logger.Sugar().Errorw("Skipping admin-triggered reshare: no key share to reshare",
+			"operator_address", n.OperatorAddress.Hex(),
+			"block_number", blockNumber)
+		return
+	}
+	n.sessionMutex.RLock()
+	activeCount := len(n.activeSessions)
+	n.sessionMutex.RUnlock()
+	if activeCount > 0 {
+		n.logger.Sugar().Warnw("Skipping admin-triggered reshare: protocol session already in progress",
+			"operator_address", n.OperatorAddress.Hex(),
+			"block_number", blockNumber,
+			"active_sessions", activeCount)
+		return
+	}
+
+	n.logger.Sugar().Infow("Triggering admin-requested reshare",
+		"operator_address", n.OperatorAddress.Hex(),
+		"block_number", blockNumber,
+		"block_timestamp", blockTimestamp)
+
+	go func() {
+		if err := n.RunReshareAsExistingOperator(blockTimestamp, blockNumber); err != nil {
+			n.logger.Sugar().Errorw("Admin-triggered reshare failed",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	}()
+}
+
+// loadPersistedVersion returns the persisted key version, or nil if there is none.
+func (n *Node) loadPersistedVersion(version int64) (*types.KeyShareVersion, error) {
+	versions, err := n.persistence.ListKeyShareVersions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list key versions: %w", err)
+	}
+	for _, v := range versions {
+		if v.Version == version {
+			return v, nil
+		}
+	}
+	return nil, nil
+}
+
+// rollbackToVersion makes version the active key version, the manual counterpart of
+// the auto-heal rollback in performRollback. The version must belong to the active
+// master secret generation and not be poisoned. The session lock is held throughout
+// so no reshare starts from the version being replaced. Returns the HTTP status to
+// fail with.
+func (n *Node) rollbackToVersion(version int64) (int, error) {
+	n.sessionMutex.Lock()
+	defer n.sessionMutex.Unlock()
+	if len(n.activeSessions) > 0 {
+		return http.StatusConflict, fmt.Errorf("a protocol session is in progress")
+	}
+
+	target, err := n.loadPersistedVersion(version)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if target == nil {
+		return http.StatusNotFound, fmt.Errorf("no key version %d", version)
+	}
+	if n.keyStore.IsPoisoned(version) {
+		return http.StatusConflict, fmt.Errorf("key version %d is poisoned", version)
+	}
+	if active := n.keyStore.GetActiveVersion(); active != nil && active.Generation != target.Generation {
+		return http.StatusConflict, fmt.Errorf("key version %d is in generation %d, not the active generation %d",
+			version, target.Generation, active.Generation)
+	}
+	previous := n.activeKeyVersionNumber()
+	if err := n.keyStore.SetActiveVersionByTimestamp(version); err != nil {
+		return http.StatusConflict, err
+	}
+	if err := n.persistence.SetActiveVersionTimestamp(version); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("rolled back in memory, but failed to persist the active version: %w", err)
+	}
+	if n.abortTracker != nil {
+		n.abortTracker.TrackedSourceVersion = version
+		n.abortTracker.ConsecutiveAborts = 0
+		n.persistAbortTracker()
+	}
+	n.logger.Sugar().Warnw("Admin rolled back the active key version",
+		"operator_address", n.OperatorAddress.Hex(),
+		"from", previous,
+		"to", version)
+	return 0, nil
+}
+
+// poisonVersion marks version poisoned so it is never activated or served again. The
+// active version cannot be poisoned: roll back first. Returns the HTTP status to fail
+// with.
+func (n *Node) poisonVersion(version int64) (int, error) {
+	target, err := n.loadPersistedVersion(version)
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+	if target == nil {
+		return http.StatusNotFound, fmt.Errorf("no key version %d", version)
+	}
+	if n.activeKeyVersionNumber() == version {
+		return http.StatusConflict, fmt.Errorf("key version %d is active; roll back to another version first", version)
+	}
+	n.keyStore.MarkPoisoned(version)
+	if err := n.persistence.AddPoisonedVersion(version); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("poisoned in memory, but failed to persist: %w", err)
+	}
+	n.logger.Sugar().Warnw("Admin marked key version poisoned",
+		"operator_address", n.OperatorAddress.Hex(),
+		"version", version)
+	return 0, nil
+}
@@ -0,0 +1,273 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newAdminTestServer returns an admin server for a node holding versions 1000..6000,
+// 6000 active.
+func newAdminTestServer(t *testing.T) (*AdminServer, *Node) {
+	t.Helper()
+	n := newRetentionTestNode(t, config.RetentionConfig{})
+	n.KeyID = types.DefaultKeyID
+	n.abortTracker = &abortTracker{TrackedSourceVersion: 6000, ConsecutiveAborts: 2}
+	n.persistAbortTracker()
+	n.activeSessions = make(map[int64]*ProtocolSession)
+
+	as, err := NewAdminServer(config.AdminConfig{SocketPath: filepath.Join(t.TempDir(), "admin.sock")}, []*Node{n}, zap.NewNop())
+	require.NoError(t, err)
+	return as, n
+}
+
+func adminRequest(t *testing.T, as *AdminServer, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	as.GetHandler().ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return rec
+}
+
+func decodeAdminState(t *testing.T, rec *httptest.ResponseRecorder) types.AdminKeyState {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var state types.AdminKeyState
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&state))
+	return state
+}
+
+func TestAdminServer_State(t *testing.T) {
+	as, _ := newAdminTestServer(t)
+
+	state := decodeAdminState(t, adminRequest(t, as, http.MethodGet, "/v1/admin/keys/default/state", ""))
+	assert.Equal(t, "default", state.KeyID)
+	assert.Equal(t, int64(6000), state.ActiveVersion)
+	assert.Equal(t, int64(5000), state.LastKnownGoodVersion)
+	assert.Equal(t, int64(6000), state.TrackedSourceVersion)
+	assert.Equal(t, 2, state.ConsecutiveMPKAborts)
+	assert.Equal(t, demotionThreshold, state.DemotionThreshold)
+	assert.Empty(t, state.PoisonedVersions)
+
+	rec := adminRequest(t, as, http.MethodGet, "/v1/admin/keys/other/state", "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdminServer_PauseResume(t *testing.T) {
+	as, n := newAdminTestServer(t)
+
+	state := decodeAdminState(t, adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/reshare/pause", ""))
+	assert.True(t, state.AutoResharePaused)
+	st, err := n.persistence.LoadNodeState()
+	require.NoError(t, err)
+	assert.True(t, st.AutoResharePaused)
+	assert.Equal(t, int64(5000), st.LastKnownGoodSourceVersion, "the pause must not clobber other node state")
+
+	// The pause survives a restart
+	n.autoResharePaused.Store(false)
+	require.NoError(t, n.RestoreState())
+	assert.True(t, n.autoResharePaused.Load())
+
+	state = decodeAdminState(t, adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/reshare/resume", ""))
+	assert.False(t, state.AutoResharePaused)
+	st, err = n.persistence.LoadNodeState()
+	require.NoError(t, err)
+	assert.False(t, st.AutoResharePaused)
+
+	rec := adminRequest(t, as, http.MethodGet, "/v1/admin/keys/default/reshare/pause", "")
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestAdminServer_TriggerReshare(t *testing.T) {
+	as, n := newAdminTestServer(t)
+	n.lastBlockNumber.Store(100)
+
+	state := decodeAdminState(t, adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/reshare/trigger", ""))
+	assert.Equal(t, int64(triggerNextBlock), state.ReshareTriggerBlock)
+	assert.True(t, n.takeReshareTrigger(101))
+	assert.False(t, n.takeReshareTrigger(102), "a trigger fires once")
+
+	rec := adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/reshare/trigger", `{"block_number":100}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	state = decodeAdminState(t, adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/reshare/trigger", `{"block_number":105}`))
+	assert.Equal(t, int64(105), state.ReshareTriggerBlock)
+	assert.False(t, n.takeReshareTrigger(104))
+	assert.True(t, n.takeReshareTrigger(105))
+
+	// A trigger whose block was skipped is dropped
+	_, err := n.triggerReshare(110)
+	require.NoError(t, err)
+	assert.False(t, n.takeReshareTrigger(111))
+	assert.Zero(t, n.reshareTrigger.Load())
+}
+
+func TestAdminServer_Rollback(t *testing.T) {
+	as, n := newAdminTestServer(t)
+
+	state := decodeAdminState(t, adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/rollback", `{"version":4000}`))
+	assert.Equal(t, int64(4000), state.ActiveVersion)
+	assert.Equal(t, int64(4000), state.TrackedSourceVersion)
+	assert.Zero(t, state.ConsecutiveMPKAborts)
+	active, err := n.persistence.GetActiveVersionTimestamp()
+	require.NoError(t, err)
+	assert.Equal(t, int64(4000), active)
+
+	rec := adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/rollback", `{"version":4500}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	n.keyStore.MarkPoisoned(3000)
+	rec = adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/rollback", `{"version":3000}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	n.activeSessions[1] = &ProtocolSession{SessionTimestamp: 1}
+	rec = adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/rollback", `{"version":2000}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.Equal(t, int64(4000), n.activeKeyVersionNumber())
+
+	rec = adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/rollback", `{}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
+
+// TestAdminServer_RollbackDuringAutoHeal runs manual rollbacks alongside auto-heal
+// demotions. Both update the abort tracker and the active version; run with -race.
+func TestAdminServer_RollbackDuringAutoHeal(t *testing.T) {
+	as, n := newAdminTestServer(t)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 50; i++ {
+			active := n.activeKeyVersionNumber()
+			n.onMPKValidationAbort(active, active)
+		}
+	}()
+	for i := 0; i < 50; i++ {
+		version := []int64{5000, 4000, 3000}[i%3]
+		rec := adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/rollback", fmt.Sprintf(`{"version":%d}`, version))
+		require.Contains(t, []int{http.StatusOK, http.StatusConflict}, rec.Code, rec.Body.String())
+	}
+	<-done
+
+	n.sessionMutex.RLock()
+	tracked := n.abortTracker.TrackedSourceVersion
+	n.sessionMutex.RUnlock()
+	st, err := n.persistence.LoadNodeState()
+	require.NoError(t, err)
+	assert.Equal(t, tracked, st.TrackedSourceVersion, "persisted tracker must match memory")
+}
+
+func TestAdminServer_Poison(t *testing.T) {
+	as, n := newAdminTestServer(t)
+
+	state := decodeAdminState(t, adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/poison", `{"version":5000}`))
+	assert.Equal(t, []int64{5000}, state.PoisonedVersions)
+	assert.True(t, n.keyStore.IsPoisoned(5000))
+
+	rec := adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/poison", `{"version":6000}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	assert.False(t, n.keyStore.IsPoisoned(6000))
+
+	rec = adminRequest(t, as, http.MethodPost, "/v1/admin/keys/default/poison", `{"version":7000}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdminServer_SessionsAndGeneratedShares(t *testing.T) {
+	n, operators := newResumeTestNode(t)
+	n.KeyID = types.DefaultKeyID
+	as, err := NewAdminServer(config.AdminConfig{SocketPath: filepath.Join(t.TempDir(), "admin.sock")}, []*Node{n}, zap.NewNop())
+	require.NoError(t, err)
+
+	session := newDealtSession(t, n, operators)
+	n.activeSessions[session.SessionTimestamp] = session
+	n.retainGeneratedShares(session.SessionTimestamp, map[common.Address]*fr.Element{
+		operators[1].OperatorAddress: new(fr.Element).SetInt64(1234567),
+	})
+
+	rec := adminRequest(t, as, http.MethodGet, "/v1/admin/keys/default/sessions", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var sessions []types.AdminSession
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&sessions))
+	require.Len(t, sessions, 1)
+	assert.Equal(t, session.SessionTimestamp, sessions[0].SessionTimestamp)
+	assert.Equal(t, "dkg", sessions[0].Type)
+	assert.Equal(t, uint32(2), sessions[0].Generation)
+	require.Len(t, sessions[0].Operators, 3)
+	for _, op := range sessions[0].Operators {
+		assert.Equal(t, 1, op.Shares, op.Address)
+		assert.Equal(t, 1, op.Commitments, op.Address)
+		assert.Equal(t, 1, op.Acks, op.Address)
+	}
+
+	rec = adminRequest(t, as, http.MethodGet, "/v1/admin/keys/default/generated-shares", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "1234567", "share values must never be exposed")
+	var rounds []types.RetainedShareRound
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&rounds))
+	assert.Equal(t, []types.RetainedShareRound{{
+		SessionTimestamp: session.SessionTimestamp,
+		Recipients:       []string{operators[1].OperatorAddress.Hex()},
+	}}, rounds)
+}
+
+func TestAdminServer_UnixSocket(t *testing.T) {
+	as, _ := newAdminTestServer(t)
+	require.NoError(t, as.Start())
+	t.Cleanup(func() { _ = as.Stop() })
+
+	fi, err := os.Stat(as.cfg.SocketPath)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+
+	client := &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, "unix", as.cfg.SocketPath)
+		},
+	}, Timeout: 5 * time.Second}
+	resp, err := client.Get("http://kms-admin/v1/admin/keys")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var states []types.AdminKeyState
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&states))
+	require.Len(t, states, 1)
+	assert.Equal(t, "default", states[0].KeyID)
+
+	// The socket was bound in a private directory that is gone once it is in place
+	entries, err := os.ReadDir(filepath.Dir(as.cfg.SocketPath))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "admin.sock", entries[0].Name())
+
+	// Stop removes the socket, and a later start binds the same path again
+	require.NoError(t, as.Stop())
+	_, err = os.Stat(as.cfg.SocketPath)
+	require.True(t, os.IsNotExist(err))
+	restarted, err := NewAdminServer(as.cfg, nil, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, restarted.Start())
+	require.NoError(t, restarted.Stop())
+
+	// A regular file at the socket path is never removed
+	path := filepath.Join(t.TempDir(), "not-a-socket")
+	require.NoError(t, os.WriteFile(path, []byte("x"), 0o600))
+	other, err := NewAdminServer(config.AdminConfig{SocketPath: path}, nil, zap.NewNop())
+	require.NoError(t, err)
+	require.ErrorContains(t, other.Start(), "is not a socket")
+}
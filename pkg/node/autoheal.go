@@ -13,12 +13,10 @@ const demotionThreshold = 3
 // abortTracker counts consecutive Layer-1 MPK-validation aborts on the same
 // active source version, majority-gated so an early roller does not over-walk.
 //
-// Thread-safety: these fields are read/written without a mutex. This is safe
-// ONLY because checkScheduledOperations (node.go ~735) guarantees at most one
-// reshare goroutine runs at a time — it skips the boundary if any protocol
-// session is already active, so recordMPKAbort / recordSuccess never race. A
-// future change that permits overlapping reshare sessions MUST add
-// synchronization (or route mutations through a single owner) here.
+// Thread-safety: the tracker has no lock of its own. Every read and write after
+// startup holds Node.sessionMutex, the lock the admin rollback (rollbackToVersion)
+// also takes, so an auto-heal rollback and an operator's manual one cannot
+// interleave their tracker and active-version updates.
 type abortTracker struct {
 	TrackedSourceVersion int64
 	ConsecutiveAborts    int
@@ -72,6 +70,8 @@ func rollbackTarget(lkg int64, poisonedVersion int64, persistedVersions []int64,
 // source version (majority-gated) and, on reaching the demotion threshold,
 // demotes the active version and rolls back. Persists the counter each call.
 func (n *Node) onMPKValidationAbort(activeSourceVersion, majoritySrcVersion int64) {
+	n.sessionMutex.Lock()
+	defer n.sessionMutex.Unlock()
 	demote := n.abortTracker.recordMPKAbort(activeSourceVersion, majoritySrcVersion)
 	n.persistAbortTracker()
 	if !demote {
@@ -80,12 +80,15 @@ func (n *Node) onMPKValidationAbort(activeSourceVersion, majoritySrcVersion int6
 	n.logger.Sugar().Warnw("Auto-heal: demoting poisoned source version after consecutive MPK aborts",
 		"operator_address", n.OperatorAddress.Hex(),
 		"poisoned_version", activeSourceVersion, "consecutive_aborts", n.abortTracker.ConsecutiveAborts)
+	n.metrics.IncAutoHealDemotion()
+	n.autoHealRollback.Store(true)
 	n.performRollback(activeSourceVersion)
 }
 
 // performRollback marks poisonedVersion poisoned (keystore + persistence) and
 // re-points the active version to the rollback target (LKG or walk-back). If no
-// target exists, halts rotation with a loud alert (never auto-re-DKG).
+// target exists, halts rotation with a loud alert (never auto-re-DKG). The caller
+// holds n.sessionMutex.
 func (n *Node) performRollback(poisonedVersion int64) {
 	n.keyStore.MarkPoisoned(poisonedVersion)
 	if err := n.persistence.AddPoisonedVersion(poisonedVersion); err != nil {
@@ -109,8 +112,23 @@ func (n *Node) performRollback(poisonedVersion int64) {
 			"operator_address", n.OperatorAddress.Hex(), "poisoned_version", poisonedVersion, "error", verr, "action", "MANUAL_INTERVENTION_REQUIRED")
 		return
 	}
+	// Only roll back within the poisoned version's master secret generation: a version
+	// of an older generation holds a share of a different secret.
+	generation := n.keyStore.ActiveGeneration()
+	for _, v := range versions {
+		if v.Version == poisonedVersion {
+			generation = v.Generation
+			break
+		}
+	}
 	nums := make([]int64, 0, len(versions))
 	for _, v := range versions {
+		if v.Generation != generation {
+			if v.Version == lkg {
+				lkg = 0
+			}
+			continue
+		}
 		nums = append(nums, v.Version)
 	}
 	target, ok := rollbackTarget(lkg, poisonedVersion, nums, n.keyStore.IsPoisoned)
@@ -144,7 +162,8 @@ func (n *Node) performRollback(poisonedVersion int64) {
 }
 
 // persistAbortTracker writes the current tracker into NodeState (merging with
-// the existing persisted state so other fields are preserved).
+// the existing persisted state so other fields are preserved). The caller holds
+// n.sessionMutex.
 func (n *Node) persistAbortTracker() {
 	st, err := n.persistence.LoadNodeState()
 	if err != nil || st == nil {
@@ -160,7 +179,10 @@ func (n *Node) persistAbortTracker() {
 // recordSuccessfulReshare resets the abort counter and records the agreed source
 // version as last-known-good after a round that passed MPK validation.
 func (n *Node) recordSuccessfulReshare(agreedSrcVersion int64) {
+	n.sessionMutex.Lock()
+	defer n.sessionMutex.Unlock()
 	n.abortTracker.recordSuccess()
+	n.autoHealRollback.Store(false)
 	st, err := n.persistence.LoadNodeState()
 	if err != nil || st == nil {
 		st = &persistence.NodeState{OperatorAddress: n.OperatorAddress.Hex()}
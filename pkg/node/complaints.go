@@ -0,0 +1,314 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	eigenxcrypto "github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// newComplaint builds and signs this node's complaint against dealer. commitments are
+// the dealer's commitments as received (nil when none arrived).
+func (n *Node) newComplaint(dealer common.Address, sessionTimestamp int64, commitments []types.G2Point, reason string) *types.Complaint {
+	var commitmentHash [32]byte
+	if len(commitments) > 0 {
+		commitmentHash = eigenxcrypto.HashCommitment(commitments)
+	}
+	msg := dkg.BuildComplaintSigningMessage(dealer, n.OperatorAddress, n.KeyID, sessionTimestamp, commitmentHash, reason)
+	signature, err := n.transportSigner.SignMessage(msg)
+	if err != nil {
+		n.logger.Sugar().Errorw("Failed to sign complaint",
+			"operator_address", n.OperatorAddress.Hex(),
+			"dealer_address", dealer.Hex(),
+			"error", err)
+	}
+	return &types.Complaint{
+		DealerAddress:     dealer,
+		ComplainerAddress: n.OperatorAddress,
+		SessionTimestamp:  sessionTimestamp,
+		Reason:            reason,
+		CommitmentHash:    commitmentHash,
+		Signature:         signature,
+	}
+}
+
+// verifyComplaint checks that a complaint was raised and signed by senderPeer for this
+// session of keyID.
+func verifyComplaint(senderPeer *peering.OperatorSetPeer, keyID string, sessionTimestamp int64, c *types.Complaint) error {
+	if c == nil {
+		return fmt.Errorf("complaint is nil")
+	}
+	if c.ComplainerAddress != senderPeer.OperatorAddress {
+		return fmt.Errorf("complainer mismatch: got %s expected %s", c.ComplainerAddress.Hex(), senderPeer.OperatorAddress.Hex())
+	}
+	if c.DealerAddress == c.ComplainerAddress {
+		return fmt.Errorf("operator %s complained about itself", c.ComplainerAddress.Hex())
+	}
+	if c.SessionTimestamp != sessionTimestamp {
+		return fmt.Errorf("complaint session timestamp mismatch: got %d expected %d", c.SessionTimestamp, sessionTimestamp)
+	}
+	switch c.Reason {
+	case types.ComplaintReasonInvalidShare, types.ComplaintReasonMissingShare, types.ComplaintReasonMissingCommitments:
+	default:
+		return fmt.Errorf("unknown complaint reason %q", c.Reason)
+	}
+	if len(c.Signature) == 0 {
+		return fmt.Errorf("complaint signature is empty")
+	}
+	msg := dkg.BuildComplaintSigningMessage(c.DealerAddress, c.ComplainerAddress, keyID, c.SessionTimestamp, c.CommitmentHash, c.Reason)
+	return verifyPeerSignature(senderPeer, crypto.Keccak256Hash(msg), c.Signature, "complaint")
+}
+
+// justifyComplaint answers a complaint against this node and records the answer locally
+// so this node's own resolution of the round sees it too. An invalid share is revealed
+// to every operator so all of them can check it. A missing share is re-sent sealed to the
+// complainer instead, preceded by this node's commitments when those went missing too;
+// every operator still receives the sealed answer, so all of them see the complaint
+// answered.
+func (n *Node) justifyComplaint(ctx context.Context, session *ProtocolSession, c *types.Complaint) {
+	share := session.GetMyGeneratedShareFor(c.ComplainerAddress)
+	commitments := session.GetCommitmentsFor(n.OperatorAddress)
+	complainer := n.findPeerByAddress(c.ComplainerAddress, session.Operators)
+	if share == nil || len(commitments) == 0 || complainer == nil {
+		n.logger.Sugar().Errorw("Cannot justify complaint: dealt share unavailable",
+			"operator_address", n.OperatorAddress.Hex(),
+			"complainer_address", c.ComplainerAddress.Hex(),
+			"session_timestamp", session.SessionTimestamp)
+		return
+	}
+
+	var revealed *fr.Element
+	var encryptedShare []byte
+	if c.Reason == types.ComplaintReasonInvalidShare {
+		revealed = share
+	} else {
+		if c.Reason == types.ComplaintReasonMissingCommitments {
+			if err := n.transport.BroadcastDKGCommitments(ctx, []*peering.OperatorSetPeer{complainer}, commitments, session.SessionTimestamp); err != nil {
+				n.logger.Sugar().Warnw("Failed to re-send commitments to complainer",
+					"operator_address", n.OperatorAddress.Hex(),
+					"complainer_address", c.ComplainerAddress.Hex(),
+					"error", err)
+			}
+		}
+		recipientKey, err := n.resolveShareEncryptionKey(complainer, session.SessionTimestamp)
+		if err == nil {
+			encryptedShare, err = encryption.EncryptShare(recipientKey, share, n.shareBinding(justificationLabel, n.OperatorAddress, c.ComplainerAddress, session))
+		}
+		if err != nil {
+			n.logger.Sugar().Errorw("Cannot justify complaint: failed to seal share to complainer",
+				"operator_address", n.OperatorAddress.Hex(),
+				"complainer_address", c.ComplainerAddress.Hex(),
+				"session_timestamp", session.SessionTimestamp,
+				"error", err)
+			return
+		}
+	}
+
+	n.logger.Sugar().Infow("Justifying complaint",
+		"operator_address", n.OperatorAddress.Hex(),
+		"complainer_address", c.ComplainerAddress.Hex(),
+		"reason", c.Reason,
+		"revealed", revealed != nil,
+		"session_timestamp", session.SessionTimestamp)
+
+	session.HandleReceivedJustification(&dkg.Justification{
+		Dealer:     n.OperatorAddress,
+		Complainer: c.ComplainerAddress,
+		Share:      revealed,
+		Sealed:     revealed == nil,
+	})
+	if err := n.transport.BroadcastDKGJustification(ctx, session.Operators, c.ComplainerAddress, revealed, encryptedShare, session.SessionTimestamp); err != nil {
+		n.logger.Sugar().Warnw("Failed to deliver justification to some operators",
+			"operator_address", n.OperatorAddress.Hex(),
+			"complainer_address", c.ComplainerAddress.Hex(),
+			"error", err)
+	}
+}
+
+// justificationLabel is the share-binding label of a share re-sent in a justification.
+const justificationLabel = "dkg-justification"
+
+// openJustification records justMsg, received from dealer. A sealed share addressed to
+// this node is opened here; if it cannot be, the answer is recorded without a share so
+// the complaint resolves as a failed justification rather than staying unanswered.
+func (n *Node) openJustification(session *ProtocolSession, dealer common.Address, justMsg *types.JustificationMessage) {
+	j := &dkg.Justification{Dealer: dealer, Complainer: justMsg.ComplainerAddress}
+	switch {
+	case justMsg.Share != nil:
+		j.Share = types.DeserializeFr(justMsg.Share)
+	case justMsg.ComplainerAddress != n.OperatorAddress:
+		j.Sealed = true
+	default:
+		share, err := encryption.DecryptShare(n.shareEncryptionKey, justMsg.EncryptedShare, n.shareBinding(justificationLabel, dealer, n.OperatorAddress, session))
+		if err != nil {
+			n.logger.Sugar().Warnw("Failed to open share re-sent in justification",
+				"operator_address", n.OperatorAddress.Hex(),
+				"dealer_address", dealer.Hex(),
+				"session_timestamp", session.SessionTimestamp,
+				"error", err)
+		}
+		j.Share = share
+	}
+	session.HandleReceivedJustification(j)
+}
+
+// resolveDKGComplaints closes the complaint round as this node saw it and returns the
+// qualified dealer set it computed. Nothing is recorded on the session until the set is
+// agreed (see agreeQualifiedDealers and applyComplaintResolution).
+func (n *Node) resolveDKGComplaints(session *ProtocolSession) *dkg.ComplaintResolution {
+	session.mu.RLock()
+	dealers := sessionParticipantIDs(session.Operators)
+	commitments := make(map[common.Address][]types.G2Point, len(session.commitments))
+	for dealer, c := range session.commitments {
+		commitments[dealer] = c
+	}
+	var complaints []*types.Complaint
+	for _, byComplainer := range session.complaints {
+		for _, c := range byComplainer {
+			complaints = append(complaints, c)
+		}
+	}
+	var justifications []*dkg.Justification
+	for _, byComplainer := range session.justifications {
+		for _, answers := range byComplainer {
+			justifications = append(justifications, answers...)
+		}
+	}
+	session.mu.RUnlock()
+
+	resolution := dkg.ResolveComplaints(dealers, commitments, complaints, justifications)
+
+	for dealer, reason := range resolution.Disqualified {
+		n.logger.Sugar().Warnw("Dealer disqualified in DKG complaint round",
+			"operator_address", n.OperatorAddress.Hex(),
+			"session_timestamp", session.SessionTimestamp,
+			"dealer_address", dealer.Hex(),
+			"reason", reason)
+	}
+	n.logger.Sugar().Infow("DKG complaint round resolved",
+		"operator_address", n.OperatorAddress.Hex(),
+		"session_timestamp", session.SessionTimestamp,
+		"complaints", len(complaints),
+		"qualified_dealers", len(resolution.Qualified),
+		"disqualified_dealers", len(resolution.Disqualified))
+
+	return resolution
+}
+
+// applyComplaintResolution records an agreed qualified set on the session and swaps in
+// any share a qualified dealer re-sent to self in place of the one self complained about.
+func (s *ProtocolSession) applyComplaintResolution(self common.Address, resolution *dkg.ComplaintResolution) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.qualifiedDealers = make(map[common.Address]bool, len(resolution.Qualified))
+	for _, dealer := range resolution.Qualified {
+		s.qualifiedDealers[dealer] = true
+		if share := resolution.RevealedShare(dealer, self); share != nil {
+			s.shares[dealer] = share
+		}
+	}
+}
+
+// agreeQualifiedDealers announces the qualified set this node computed to every operator
+// and checks it against theirs. Complaints and justifications reach each operator
+// separately, and a sealed justification is checked by its complainer alone, so
+// operators can close the round with different sets; finalizing on them would leave
+// honest operators with shares of different master keys. The DKG is aborted when any
+// operator announces a different set, or when a qualified dealer announces none, since
+// its view is then unknown.
+func (n *Node) agreeQualifiedDealers(ctx context.Context, session *ProtocolSession, qualified []common.Address, timeout time.Duration) error {
+	_ = session.HandleReceivedQualifiedSet(n.OperatorAddress, qualified)
+	if err := n.transport.BroadcastDKGQualifiedSet(ctx, session.Operators, qualified, session.SessionTimestamp); err != nil {
+		n.logger.Sugar().Warnw("Failed to deliver qualified set to some operators",
+			"operator_address", n.OperatorAddress.Hex(),
+			"error", err)
+	}
+	if err := waitForN(ctx, session, len(session.Operators), timeout, func() int { return len(session.qualifiedSets) }, "qualified sets"); err != nil {
+		n.logger.Sugar().Warnw("Checking qualified set agreement without every operator's set",
+			"operator_address", n.OperatorAddress.Hex(),
+			"error", err)
+	}
+
+	session.mu.RLock()
+	announced := make(map[common.Address][]common.Address, len(session.qualifiedSets))
+	for sender, set := range session.qualifiedSets {
+		announced[sender] = set
+	}
+	session.mu.RUnlock()
+
+	return checkQualifiedSetAgreement(session.Operators, announced, qualified)
+}
+
+// checkQualifiedSetAgreement checks the sets operators announced against qualified.
+func checkQualifiedSetAgreement(operators []*peering.OperatorSetPeer, announced map[common.Address][]common.Address, qualified []common.Address) error {
+	for _, op := range operators {
+		set, ok := announced[op.OperatorAddress]
+		if ok && !sameAddressSet(set, qualified) {
+			return fmt.Errorf("qualified dealer sets disagree: %s qualified %d dealers %v, this operator qualified %d dealers %v",
+				op.OperatorAddress.Hex(), len(set), set, len(qualified), qualified)
+		}
+	}
+	for _, dealer := range qualified {
+		if _, ok := announced[dealer]; !ok {
+			return fmt.Errorf("qualified dealer %s did not announce its qualified set", dealer.Hex())
+		}
+	}
+	return nil
+}
+
+// sameAddressSet reports whether a and b hold the same addresses, ignoring order.
+func sameAddressSet(a, b []common.Address) bool {
+	a, b = slices.Clone(a), slices.Clone(b)
+	slices.SortFunc(a, common.Address.Cmp)
+	slices.SortFunc(b, common.Address.Cmp)
+	return slices.Equal(a, b)
+}
+
+// waitForComplaints waits until every operator (including this one) has delivered its
+// complaint message for the session.
+func waitForComplaints(ctx context.Context, session *ProtocolSession, timeout time.Duration) error {
+	return waitForN(ctx, session, len(session.Operators), timeout, func() int { return len(session.complaintSenders) }, "complaint messages")
+}
+
+// waitForJustifications waits until every complaint received so far has been answered by
+// at least one justification. Whether the answers verify is decided by resolveDKGComplaints.
+func waitForJustifications(session *ProtocolSession, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(50 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		session.mu.RLock()
+		unanswered := session.unansweredComplaints()
+		session.mu.RUnlock()
+		if unanswered == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout waiting for justifications: %d complaint(s) unanswered", unanswered)
+		case <-ticker.C:
+		}
+	}
+}
+
+// copyShares returns a shallow copy of a session share map; the caller holds session.mu.
+func copyShares(shares map[common.Address]*fr.Element) map[common.Address]*fr.Element {
+	out := make(map[common.Address]*fr.Element, len(shares))
+	for addr, share := range shares {
+		out[addr] = share
+	}
+	return out
+}
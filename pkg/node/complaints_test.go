@@ -0,0 +1,255 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/crypto-libs/pkg/bn254"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/transportSigner/inMemoryTransportSigner"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestVerifyComplaint(t *testing.T) {
+	logger := zap.NewNop()
+	skBytes := make([]byte, 32)
+	skBytes[31] = 1
+	ts, err := inMemoryTransportSigner.NewBn254InMemoryTransportSigner(skBytes, logger)
+	require.NoError(t, err)
+	sk, err := bn254.NewPrivateKeyFromBytes(skBytes)
+	require.NoError(t, err)
+
+	complainer := common.HexToAddress("0x0000000000000000000000000000000000000016")
+	dealer := common.HexToAddress("0x000000000000000000000000000000000000000B")
+	n := &Node{logger: logger, transportSigner: ts, OperatorAddress: complainer, KeyID: types.DefaultKeyID}
+	senderPeer := &peering.OperatorSetPeer{
+		OperatorAddress:  complainer,
+		CurveType:        config.CurveTypeBN254,
+		WrappedPublicKey: peering.WrappedPublicKey{PublicKey: sk.Public()},
+	}
+
+	session := int64(123456)
+	commitments := []types.G2Point{{CompressedBytes: []byte{1, 2, 3}}}
+	complaint := n.newComplaint(dealer, session, commitments, types.ComplaintReasonInvalidShare)
+	require.NoError(t, verifyComplaint(senderPeer, types.DefaultKeyID, session, complaint))
+
+	t.Run("tampered reason", func(t *testing.T) {
+		c := *complaint
+		c.Reason = types.ComplaintReasonMissingShare
+		assert.Error(t, verifyComplaint(senderPeer, types.DefaultKeyID, session, &c))
+	})
+	t.Run("wrong session", func(t *testing.T) {
+		assert.Error(t, verifyComplaint(senderPeer, types.DefaultKeyID, session+1, complaint))
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		assert.Error(t, verifyComplaint(senderPeer, "other", session, complaint))
+	})
+	t.Run("complainer is not the sender", func(t *testing.T) {
+		c := *complaint
+		c.ComplainerAddress = common.HexToAddress("0x99")
+		assert.ErrorContains(t, verifyComplaint(senderPeer, types.DefaultKeyID, session, &c), "complainer mismatch")
+	})
+	t.Run("self complaint", func(t *testing.T) {
+		c := n.newComplaint(complainer, session, commitments, types.ComplaintReasonInvalidShare)
+		assert.ErrorContains(t, verifyComplaint(senderPeer, types.DefaultKeyID, session, c), "itself")
+	})
+	t.Run("unknown reason", func(t *testing.T) {
+		c := n.newComplaint(dealer, session, commitments, "bored")
+		assert.ErrorContains(t, verifyComplaint(senderPeer, types.DefaultKeyID, session, c), "unknown complaint reason")
+	})
+}
+
+func TestSession_HandleReceivedComplaints_RejectsDuplicateSender(t *testing.T) {
+	s := &ProtocolSession{}
+	sender := common.HexToAddress("0x01")
+	c := &types.Complaint{DealerAddress: common.HexToAddress("0x02"), ComplainerAddress: sender}
+
+	require.NoError(t, s.HandleReceivedComplaints(sender, []*types.Complaint{c}))
+	require.Error(t, s.HandleReceivedComplaints(sender, nil))
+	require.NoError(t, s.HandleReceivedComplaints(common.HexToAddress("0x03"), nil))
+	assert.Len(t, s.complaintSenders, 2)
+	assert.Equal(t, 1, s.unansweredComplaints())
+}
+
+// complaintRoundSession sets up a 4-operator DKG as seen by operators[1]: dealer 0 sent it
+// a bad share, everything else verifies.
+func complaintRoundSession(t *testing.T) (*Node, *ProtocolSession, []common.Address, map[common.Address]map[common.Address]*fr.Element) {
+	t.Helper()
+	operators := make([]*peering.OperatorSetPeer, 4)
+	addrs := make([]common.Address, len(operators))
+	for i := range operators {
+		addrs[i] = common.BigToAddress(big.NewInt(int64(0x200 + i)))
+		operators[i] = &peering.OperatorSetPeer{OperatorAddress: addrs[i]}
+	}
+	self := addrs[1]
+
+	s := &ProtocolSession{
+		SessionTimestamp: 1,
+		Type:             "dkg",
+		Operators:        operators,
+		shares:           make(map[common.Address]*fr.Element),
+		commitments:      make(map[common.Address][]types.G2Point),
+	}
+	dealt := make(map[common.Address]map[common.Address]*fr.Element)
+	for _, dealer := range addrs {
+		shares, commitments, err := dkg.NewDKG(dealer, dkg.CalculateThreshold(len(operators)), operators).GenerateShares()
+		require.NoError(t, err)
+		dealt[dealer] = shares
+		s.shares[dealer] = shares[self]
+		s.commitments[dealer] = commitments
+	}
+	bad := fr.NewElement(7)
+	s.shares[addrs[0]] = &bad
+
+	return &Node{OperatorAddress: self, logger: zap.NewNop()}, s, addrs, dealt
+}
+
+func TestResolveDKGComplaints(t *testing.T) {
+	t.Run("justified complaint keeps dealer and adopts revealed share", func(t *testing.T) {
+		n, s, addrs, dealt := complaintRoundSession(t)
+		dealer := addrs[0]
+		require.NoError(t, s.HandleReceivedComplaints(n.OperatorAddress, []*types.Complaint{
+			{DealerAddress: dealer, ComplainerAddress: n.OperatorAddress, Reason: types.ComplaintReasonInvalidShare},
+		}))
+		s.HandleReceivedJustification(&dkg.Justification{Dealer: dealer, Complainer: n.OperatorAddress, Share: dealt[dealer][n.OperatorAddress]})
+
+		res := n.resolveDKGComplaints(s)
+		assert.Len(t, res.Qualified, 4)
+		assert.Nil(t, s.qualifiedDealers, "nothing is recorded before the set is agreed")
+		s.applyComplaintResolution(n.OperatorAddress, res)
+		assert.Len(t, s.qualifiedDealers, 4)
+		assert.True(t, s.shares[dealer].Equal(dealt[dealer][n.OperatorAddress]), "revealed share replaces the bad one")
+	})
+
+	t.Run("unanswered complaint drops dealer for every observer", func(t *testing.T) {
+		n, s, addrs, _ := complaintRoundSession(t)
+		dealer := addrs[0]
+		require.NoError(t, s.HandleReceivedComplaints(n.OperatorAddress, []*types.Complaint{
+			{DealerAddress: dealer, ComplainerAddress: n.OperatorAddress, Reason: types.ComplaintReasonInvalidShare},
+		}))
+		// Another operator's complaint against a dealer this node has no issue with.
+		require.NoError(t, s.HandleReceivedComplaints(addrs[2], []*types.Complaint{
+			{DealerAddress: addrs[3], ComplainerAddress: addrs[2], Reason: types.ComplaintReasonMissingShare},
+		}))
+
+		res := n.resolveDKGComplaints(s)
+		s.applyComplaintResolution(n.OperatorAddress, res)
+		assert.Equal(t, []common.Address{addrs[1], addrs[2]}, res.Qualified)
+		assert.False(t, s.qualifiedDealers[dealer])
+		assert.False(t, s.qualifiedDealers[addrs[3]])
+	})
+}
+
+func TestWaitForJustifications(t *testing.T) {
+	dealer := common.HexToAddress("0x0A")
+	complainer := common.HexToAddress("0x0B")
+	s := &ProtocolSession{}
+	require.NoError(t, s.HandleReceivedComplaints(complainer, []*types.Complaint{
+		{DealerAddress: dealer, ComplainerAddress: complainer},
+	}))
+
+	require.Error(t, waitForJustifications(s, 100*time.Millisecond))
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		share := fr.NewElement(1)
+		s.HandleReceivedJustification(&dkg.Justification{Dealer: dealer, Complainer: complainer, Share: &share})
+	}()
+	require.NoError(t, waitForJustifications(s, 2*time.Second))
+}
+
+func TestOpenJustification(t *testing.T) {
+	dealerAddr := common.HexToAddress("0x000000000000000000000000000000000000000A")
+	complainerAddr := common.HexToAddress("0x000000000000000000000000000000000000000B")
+	observerAddr := common.HexToAddress("0x000000000000000000000000000000000000000C")
+	dealer, dealerPeer := newShareEncryptionTestNode(t, dealerAddr, 1)
+	complainer, complainerPeer := newShareEncryptionTestNode(t, complainerAddr, 2)
+	observer, observerPeer := newShareEncryptionTestNode(t, observerAddr, 3)
+	operators := []*peering.OperatorSetPeer{dealerPeer, complainerPeer, observerPeer}
+	sessions := openShareSession(t, 1000, operators, dealer, complainer, observer)
+
+	share := fr.NewElement(99)
+	sealed, err := encryption.EncryptShare(&complainer.shareEncryptionKey.PublicKey, &share,
+		dealer.shareBinding(justificationLabel, dealerAddr, complainerAddr, sessions[dealer]))
+	require.NoError(t, err)
+	msg := &types.JustificationMessage{ComplainerAddress: complainerAddr, EncryptedShare: sealed}
+
+	t.Run("complainer opens the sealed share", func(t *testing.T) {
+		session := sessions[complainer]
+		complainer.openJustification(session, dealerAddr, msg)
+		got := session.justifications[dealerAddr][complainerAddr]
+		require.Len(t, got, 1)
+		require.NotNil(t, got[0].Share)
+		assert.True(t, got[0].Share.Equal(&share))
+		assert.False(t, got[0].Sealed)
+	})
+
+	t.Run("observer records a sealed answer", func(t *testing.T) {
+		session := sessions[observer]
+		observer.openJustification(session, dealerAddr, msg)
+		got := session.justifications[dealerAddr][complainerAddr]
+		require.Len(t, got, 1)
+		assert.Nil(t, got[0].Share)
+		assert.True(t, got[0].Sealed)
+	})
+
+	t.Run("share sealed by someone else fails to open", func(t *testing.T) {
+		session := sessions[complainer]
+		forged := &types.JustificationMessage{ComplainerAddress: complainerAddr, EncryptedShare: sealed}
+		complainer.openJustification(session, observerAddr, forged)
+		got := session.justifications[observerAddr][complainerAddr]
+		require.Len(t, got, 1)
+		assert.Nil(t, got[0].Share, "recorded as a failed answer")
+		assert.False(t, got[0].Sealed)
+	})
+}
+
+func TestCheckQualifiedSetAgreement(t *testing.T) {
+	addrs := []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")}
+	operators := make([]*peering.OperatorSetPeer, len(addrs))
+	for i, addr := range addrs {
+		operators[i] = &peering.OperatorSetPeer{OperatorAddress: addr}
+	}
+	qualified := addrs[:2]
+
+	t.Run("matching sets in any order agree", func(t *testing.T) {
+		announced := map[common.Address][]common.Address{
+			addrs[0]: {addrs[1], addrs[0]},
+			addrs[1]: qualified,
+			addrs[2]: qualified,
+		}
+		assert.NoError(t, checkQualifiedSetAgreement(operators, announced, qualified))
+	})
+	t.Run("silent disqualified operator is tolerated", func(t *testing.T) {
+		announced := map[common.Address][]common.Address{addrs[0]: qualified, addrs[1]: qualified}
+		assert.NoError(t, checkQualifiedSetAgreement(operators, announced, qualified))
+	})
+	t.Run("any differing set aborts", func(t *testing.T) {
+		announced := map[common.Address][]common.Address{
+			addrs[0]: qualified,
+			addrs[1]: qualified,
+			addrs[2]: addrs,
+		}
+		assert.ErrorContains(t, checkQualifiedSetAgreement(operators, announced, qualified), "disagree")
+	})
+	t.Run("repeated dealer does not stand in for a missing one", func(t *testing.T) {
+		announced := map[common.Address][]common.Address{
+			addrs[0]: qualified,
+			addrs[1]: {addrs[0], addrs[0]},
+		}
+		assert.ErrorContains(t, checkQualifiedSetAgreement(operators, announced, qualified), "disagree")
+	})
+	t.Run("silent qualified dealer aborts", func(t *testing.T) {
+		announced := map[common.Address][]common.Address{addrs[0]: qualified}
+		assert.ErrorContains(t, checkQualifiedSetAgreement(operators, announced, qualified), "did not announce")
+	})
+}
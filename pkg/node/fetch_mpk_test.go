@@ -55,7 +55,7 @@ func TestFetchMPKFromPeers_AllHonest(t *testing.T) {
 	}
 
 	node := newTestNode(t, "0x5000000000000000000000000000000000000000")
-	mpk, err := node.fetchMPKFromPeers(context.Background(), peers)
+	mpk, _, err := node.fetchMPKFromPeers(context.Background(), peers)
 	require.NoError(t, err)
 	require.NotNil(t, mpk)
 	assert.True(t, mpk.IsEqual(&honestMPK))
@@ -83,7 +83,7 @@ func TestFetchMPKFromPeers_OneCorrupted(t *testing.T) {
 	}
 
 	node := newTestNode(t, "0x5000000000000000000000000000000000000000")
-	mpk, err := node.fetchMPKFromPeers(context.Background(), peers)
+	mpk, _, err := node.fetchMPKFromPeers(context.Background(), peers)
 	require.NoError(t, err)
 	require.NotNil(t, mpk)
 	assert.True(t, mpk.IsEqual(&honestMPK))
@@ -113,7 +113,7 @@ func TestFetchMPKFromPeers_TooManyCorrupted(t *testing.T) {
 	}
 
 	node := newTestNode(t, "0x5000000000000000000000000000000000000000")
-	mpk, err := node.fetchMPKFromPeers(context.Background(), peers)
+	mpk, _, err := node.fetchMPKFromPeers(context.Background(), peers)
 	require.Error(t, err)
 	assert.Nil(t, mpk)
 	assert.Contains(t, err.Error(), "failed to reach threshold agreement")
@@ -147,7 +147,7 @@ func TestFetchMPKFromPeers_ExcludesSelf(t *testing.T) {
 	}
 
 	node := newTestNode(t, selfAddr)
-	mpk, err := node.fetchMPKFromPeers(context.Background(), peers)
+	mpk, _, err := node.fetchMPKFromPeers(context.Background(), peers)
 	require.NoError(t, err)
 	require.NotNil(t, mpk)
 	assert.True(t, mpk.IsEqual(&honestMPK))
@@ -172,7 +172,7 @@ func TestFetchMPKFromPeers_ContextCancellation(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	cancel() // Cancel immediately
 
-	mpk, err := node.fetchMPKFromPeers(ctx, peers)
+	mpk, _, err := node.fetchMPKFromPeers(ctx, peers)
 	require.Error(t, err)
 	assert.Nil(t, mpk)
 }
@@ -197,7 +197,7 @@ func TestFetchMPKFromPeers_UnreachablePeer(t *testing.T) {
 	}
 
 	node := newTestNode(t, "0x5000000000000000000000000000000000000000")
-	mpk, err := node.fetchMPKFromPeers(context.Background(), peers)
+	mpk, _, err := node.fetchMPKFromPeers(context.Background(), peers)
 	require.NoError(t, err)
 	require.NotNil(t, mpk)
 	assert.True(t, mpk.IsEqual(&honestMPK))
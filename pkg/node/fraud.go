@@ -0,0 +1,208 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"time"
+
+	eigenxcrypto "github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/fraud"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fraudSubmissionTimeout bounds one evidence submission (registry reads plus the
+// transaction round trip).
+const fraudSubmissionTimeout = 2 * time.Minute
+
+// shareComplaintPollInterval is how often a dealer checks the registry for complaints
+// against the shares it dealt.
+const shareComplaintPollInterval = time.Minute
+
+// recordSignedShare keeps the dealer's authenticated share message for fraud evidence.
+func (s *ProtocolSession) recordSignedShare(dealer common.Address, msg *types.AuthenticatedMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.signedShares == nil {
+		s.signedShares = make(map[common.Address]*types.AuthenticatedMessage)
+	}
+	s.signedShares[dealer] = msg
+}
+
+// recordSignedCommitments keeps the dealer's authenticated commitment message for fraud
+// evidence.
+func (s *ProtocolSession) recordSignedCommitments(dealer common.Address, msg *types.AuthenticatedMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.signedCommitments == nil {
+		s.signedCommitments = make(map[common.Address]*types.AuthenticatedMessage)
+	}
+	s.signedCommitments[dealer] = msg
+}
+
+// evidenceFor returns the signed messages and acks this session holds for dealer.
+func (s *ProtocolSession) evidenceFor(dealer common.Address) (signedShare, signedCommitments *types.AuthenticatedMessage, acks []*types.Acknowledgement) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, ack := range s.acks[dealer] {
+		acks = append(acks, ack)
+	}
+	return s.signedShares[dealer], s.signedCommitments[dealer], acks
+}
+
+// recordInvalidShareEvidence persists the evidence behind an invalid-share complaint and
+// opens the matching complaint on the registry in the background. The complaint is
+// proven by a later pending-evidence pass if the dealer lets its answer window lapse.
+func (n *Node) recordInvalidShareEvidence(protocol string, sessionTimestamp int64, dealer common.Address, share *fr.Element, commitments []types.G2Point) {
+	if n.fraudReporter == nil {
+		return
+	}
+	evidence := fraud.NewInvalidShareEvidence(protocol, sessionTimestamp, dealer, n.OperatorAddress, share, commitments)
+	evidence.KeyID = n.KeyID
+	if session := n.getSession(sessionTimestamp); session != nil {
+		evidence.SignedShare, evidence.SignedCommitments, evidence.Acknowledgements = session.evidenceFor(dealer)
+	}
+	if err := n.fraudReporter.Record(evidence); err != nil {
+		n.logger.Sugar().Errorw("Failed to record invalid-share evidence",
+			"operator_address", n.OperatorAddress.Hex(),
+			"dealer_address", dealer.Hex(),
+			"session_timestamp", sessionTimestamp,
+			"error", err)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), fraudSubmissionTimeout)
+		defer cancel()
+		if err := n.fraudReporter.Submit(ctx, evidence); err != nil {
+			n.logger.Sugar().Errorw("Failed to submit invalid-share complaint",
+				"operator_address", n.OperatorAddress.Hex(),
+				"dealer_address", dealer.Hex(),
+				"evidence_id", evidence.ID,
+				"error", err)
+		}
+	}()
+}
+
+// watchShareComplaints answers on-chain complaints against the shares this node dealt in
+// session, until the complaint period and the last answer window have closed. Answering
+// reveals the complainer's share on-chain so the registry can check it against the
+// commitment this node submitted; a complaint left unanswered gets the node's dealing
+// proven invalid.
+//
+// The dealt shares are taken from the session once its commitment is on-chain, so a node
+// resumed mid-session picks the watch back up. A node that restarts after the session has
+// finished no longer holds them, and a complaint opened after that goes unanswered.
+func (n *Node) watchShareComplaints(session *ProtocolSession) {
+	shares, commitments, sourceVersion := session.dealtShares(n.OperatorAddress)
+	if len(shares) == 0 {
+		return
+	}
+	points := make([][]byte, len(commitments))
+	for i, c := range commitments {
+		encoded, err := eigenxcrypto.EncodeG2ForPrecompile(c)
+		if err != nil {
+			n.logger.Sugar().Errorw("Cannot watch share complaints: failed to encode commitment",
+				"operator_address", n.OperatorAddress.Hex(),
+				"session_timestamp", session.SessionTimestamp,
+				"error", err)
+			return
+		}
+		points[i] = encoded
+	}
+
+	epoch := types.CommitmentEpoch(n.KeyID, session.SessionTimestamp)
+	closes := time.Unix(session.SessionTimestamp, 0).Add(fraud.ShareComplaintPeriod + fraud.ShareAnswerWindow)
+	ctx, cancel := context.WithDeadline(context.Background(), closes)
+	defer cancel()
+
+	ticker := time.NewTicker(shareComplaintPollInterval)
+	defer ticker.Stop()
+	for {
+		for player, share := range shares {
+			complaint, err := n.baseContractCaller.GetShareComplaint(ctx, n.commitmentRegistryAddress, epoch, n.OperatorAddress, player)
+			if err != nil || complaint.Deadline == 0 || complaint.Answered {
+				continue
+			}
+
+			n.logger.Sugar().Warnw("Answering share complaint on registry",
+				"operator_address", n.OperatorAddress.Hex(),
+				"player_address", player.Hex(),
+				"session_timestamp", session.SessionTimestamp,
+				"deadline", complaint.Deadline)
+			if _, err := n.baseContractCaller.AnswerShareComplaint(ctx, n.commitmentRegistryAddress, epoch, player, share.BigInt(new(big.Int)), points, sourceVersion); err != nil {
+				n.logger.Sugar().Errorw("Failed to answer share complaint",
+					"operator_address", n.OperatorAddress.Hex(),
+					"player_address", player.Hex(),
+					"session_timestamp", session.SessionTimestamp,
+					"error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkBroadcastEquivocation inspects the full ack list in a dealer's commitment
+// broadcast. Acks from different players over different commitment hashes prove the
+// dealer showed different polynomials to different players; the evidence is recorded
+// and submitted to the registry in the background.
+func (n *Node) checkBroadcastEquivocation(session *ProtocolSession, authMsg *types.AuthenticatedMessage, broadcast *types.CommitmentBroadcast) {
+	if n.fraudReporter == nil {
+		return
+	}
+	dealer := broadcast.FromOperatorAddress
+	evidence, err := fraud.NewEquivocationEvidence(session.Type, session.SessionTimestamp, dealer, n.OperatorAddress, broadcast.Acknowledgements)
+	if errors.Is(err, fraud.ErrNoEquivocation) {
+		return
+	}
+	if err != nil {
+		n.logger.Sugar().Warnw("Failed to build equivocation evidence",
+			"operator_address", n.OperatorAddress.Hex(),
+			"dealer_address", dealer.Hex(),
+			"error", err)
+		return
+	}
+	evidence.SignedCommitments = authMsg
+
+	n.logger.Sugar().Warnw("Dealer equivocated: acks commit to different commitments",
+		"operator_address", n.OperatorAddress.Hex(),
+		"dealer_address", dealer.Hex(),
+		"session_timestamp", session.SessionTimestamp,
+		"player1", evidence.ConflictingAcks[0].Ack.PlayerAddress.Hex(),
+		"player2", evidence.ConflictingAcks[1].Ack.PlayerAddress.Hex())
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), fraudSubmissionTimeout)
+		defer cancel()
+		if err := n.fraudReporter.Report(ctx, evidence); err != nil {
+			n.logger.Sugar().Errorw("Failed to report equivocation",
+				"operator_address", n.OperatorAddress.Hex(),
+				"dealer_address", dealer.Hex(),
+				"evidence_id", evidence.ID,
+				"error", err)
+		}
+	}()
+}
+
+// submitPendingFraudEvidence advances evidence that is not settled on-chain: submissions
+// left by an earlier run or a failed attempt, and share complaints whose answer window
+// has passed.
+func (n *Node) submitPendingFraudEvidence(ctx context.Context) {
+	if n.fraudReporter == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, fraudSubmissionTimeout)
+	defer cancel()
+	if err := n.fraudReporter.SubmitPending(ctx); err != nil {
+		n.logger.Sugar().Warnw("Failed to submit pending fraud evidence",
+			"operator_address", n.OperatorAddress.Hex(),
+			"error", err)
+	}
+}
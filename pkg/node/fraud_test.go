@@ -0,0 +1,104 @@
+package node
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/fraud"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRecordInvalidShareEvidence(t *testing.T) {
+	operators := make([]*peering.OperatorSetPeer, 3)
+	for i := range operators {
+		operators[i] = &peering.OperatorSetPeer{OperatorAddress: common.BigToAddress(big.NewInt(int64(0x500 + i)))}
+	}
+	dealer, self := operators[0].OperatorAddress, operators[1].OperatorAddress
+	_, commitments, err := dkg.NewDKG(dealer, dkg.CalculateThreshold(len(operators)), operators).GenerateShares()
+	require.NoError(t, err)
+
+	store := memory.NewMemoryPersistence()
+	session := &ProtocolSession{SessionTimestamp: 42, Type: "dkg", Operators: operators}
+	payload := []byte(`{"encryptedShare":"..."}`)
+	signedShare := &types.AuthenticatedMessage{Payload: payload, Hash: crypto.Keccak256Hash(payload), Signature: []byte{1}}
+	session.recordSignedShare(dealer, signedShare)
+
+	n := &Node{
+		OperatorAddress: self,
+		logger:          zap.NewNop(),
+		activeSessions:  map[int64]*ProtocolSession{42: session},
+		fraudReporter:   fraud.NewReporter(store, nil, common.Address{}, zap.NewNop()),
+	}
+
+	bad := fr.NewElement(11)
+	n.recordInvalidShareEvidence("dkg", 42, dealer, &bad, commitments)
+
+	stored, err := store.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, stored, 1)
+	assert.Equal(t, types.FraudKindInvalidShare, stored[0].Kind)
+	assert.Equal(t, dealer, stored[0].DealerAddress)
+	assert.Equal(t, self, stored[0].ReporterAddress)
+	assert.Equal(t, signedShare.Payload, stored[0].SignedShare.Payload)
+	assert.Nil(t, stored[0].SignedCommitments)
+}
+
+func TestCheckBroadcastEquivocation(t *testing.T) {
+	dealer := common.HexToAddress("0x0D")
+	self := common.HexToAddress("0x0E")
+	acks := make([]*types.Acknowledgement, 3)
+	for i := range acks {
+		acks[i] = &types.Acknowledgement{
+			DealerAddress:    dealer,
+			PlayerAddress:    common.BigToAddress(big.NewInt(int64(0x600 + i))),
+			SessionTimestamp: 7,
+			ShareHash:        [32]byte{byte(i)},
+			CommitmentHash:   [32]byte{byte(i % 2)},
+		}
+	}
+
+	store := memory.NewMemoryPersistence()
+	n := &Node{
+		OperatorAddress: self,
+		logger:          zap.NewNop(),
+		fraudReporter:   fraud.NewReporter(store, nil, common.Address{}, zap.NewNop()),
+	}
+	session := &ProtocolSession{SessionTimestamp: 7, Type: "dkg"}
+
+	// Consistent transcript: nothing recorded.
+	n.checkBroadcastEquivocation(session, &types.AuthenticatedMessage{}, &types.CommitmentBroadcast{
+		FromOperatorAddress: dealer,
+		Acknowledgements:    []*types.Acknowledgement{acks[0], acks[2]},
+	})
+
+	payload := []byte("broadcast")
+	signed := &types.AuthenticatedMessage{Payload: payload, Hash: crypto.Keccak256Hash(payload)}
+	n.checkBroadcastEquivocation(session, signed, &types.CommitmentBroadcast{
+		FromOperatorAddress: dealer,
+		Acknowledgements:    acks,
+	})
+
+	// Recording happens in the background; submission fails without a contract caller
+	// but the evidence is kept.
+	require.Eventually(t, func() bool {
+		stored, err := store.ListFraudEvidence()
+		return err == nil && len(stored) == 1
+	}, 2*time.Second, 10*time.Millisecond)
+
+	stored, err := store.ListFraudEvidence()
+	require.NoError(t, err)
+	assert.Equal(t, types.FraudKindEquivocation, stored[0].Kind)
+	assert.Equal(t, payload, stored[0].SignedCommitments.Payload)
+	assert.Len(t, stored[0].ConflictingAcks, 2)
+	assert.Empty(t, stored[0].SubmissionTxHash)
+}
@@ -0,0 +1,257 @@
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller/caller"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// Master secret rotation.
+//
+// Resharing refreshes the shares of one master secret; it never changes the secret. A
+// rotation replaces it. The commitment registry owner schedules it on-chain for a key
+// (scheduleRotation): at the first reshare boundary at or after the schedule's time,
+// every operator holding shares runs a genesis-style DKG for the scheduled generation
+// instead of a reshare. Reading the schedule from the registry, rather than from each
+// operator's configuration, is what lets the whole set start the DKG at the same
+// boundary. The new generation becomes active, so clients encrypt to it by default and
+// tag their ciphertexts with it; the previous generation is frozen (no longer reshared)
+// and keeps serving /secrets and /app/sign for requests that select it until the
+// schedule's retirement window has passed, after which its key versions are deleted
+// from the keystore and persistence. Data encrypted to it must be re-encrypted before
+// then (kmsClient.Client.Reencrypt).
+//
+// A rotation only runs at that one boundary. If it fails, or a node misses the
+// boundary, later boundaries reshare the current generation as usual and the registry
+// owner must schedule the rotation again.
+
+var (
+	errGenerationNotHeld = errors.New("master secret generation not held")
+	errNoVersionAtTime   = errors.New("no key version found for attestation time")
+)
+
+// keyVersionFor resolves the key version a request is served with. A nil generation
+// selects the active generation. A positive attestationTime selects the version that
+// was active at that time within the generation, otherwise the generation's current
+// version is used. Returns errGenerationNotHeld for a generation this node does not
+// serve and errNoVersionAtTime when the generation has no version at attestationTime
+// or attestationTime is older than the key retention window.
+func (n *Node) keyVersionFor(generation *uint32, attestationTime int64) (*types.KeyShareVersion, error) {
+	if cutoff := n.retentionCutoff(time.Now().Unix()); attestationTime > 0 && attestationTime < cutoff {
+		return nil, fmt.Errorf("%w %d: older than the key retention window", errNoVersionAtTime, attestationTime)
+	}
+	if generation == nil {
+		if attestationTime <= 0 {
+			return n.keyStore.GetActiveVersion(), nil
+		}
+		if version := n.keyStore.GetKeyVersionAtTime(attestationTime); version != nil {
+			return version, nil
+		}
+		return nil, fmt.Errorf("%w %d", errNoVersionAtTime, attestationTime)
+	}
+
+	current := n.keyStore.GetGenerationVersion(*generation)
+	if current == nil {
+		return nil, fmt.Errorf("%w: %d", errGenerationNotHeld, *generation)
+	}
+	if attestationTime <= 0 {
+		return current, nil
+	}
+	if version := n.keyStore.GetKeyVersionAtTimeForGeneration(attestationTime, *generation); version != nil {
+		return version, nil
+	}
+	return nil, fmt.Errorf("%w %d in generation %d", errNoVersionAtTime, attestationTime, *generation)
+}
+
+// parseGenerationParam parses the optional "generation" query parameter.
+func parseGenerationParam(r *http.Request) (*uint32, error) {
+	g := r.URL.Query().Get("generation")
+	if g == "" {
+		return nil, nil
+	}
+	generation, err := strconv.ParseUint(g, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid generation %q", g)
+	}
+	gen := uint32(generation)
+	return &gen, nil
+}
+
+// rotationDue reports whether the boundary at blockNumber should run the rotation DKG
+// scheduled on-chain for this node's key, and for which generation. It is due at the
+// first boundary whose L1 block time is at or after the scheduled time, so every
+// operator that has not yet rotated sees the same answer at the same boundary and they
+// all start the DKG together. Any other boundary, and any failure to read the schedule,
+// reports no rotation, so the boundary reshares as usual.
+func (n *Node) rotationDue(ctx context.Context, blockNumber, blockTimestamp, blockInterval int64) (uint32, bool) {
+	schedule := n.rotationSchedule(ctx)
+	if schedule == nil || schedule.Generation <= n.keyStore.ActiveGeneration() || blockTimestamp < schedule.At {
+		return 0, false
+	}
+
+	// Only the first boundary at or after At rotates
+	l1 := n.platformConfigCaller
+	if l1 == nil {
+		l1 = n.baseContractCaller
+	}
+	if previous := blockNumber - blockInterval; previous > 0 {
+		previousTimestamp, err := l1.HeaderTimestampAt(ctx, uint64(previous))
+		if err != nil {
+			n.logger.Sugar().Warnw("Failed to read the previous boundary's block time; resharing instead of rotating",
+				"operator_address", n.OperatorAddress.Hex(),
+				"block_number", previous,
+				"error", err)
+			return 0, false
+		}
+		if int64(previousTimestamp) >= schedule.At {
+			n.logger.Sugar().Warnw("Scheduled master secret rotation did not complete at its boundary; resharing the active generation. Schedule it again on-chain to rotate.",
+				"operator_address", n.OperatorAddress.Hex(),
+				"generation", schedule.Generation,
+				"rotate_at", schedule.At)
+			return 0, false
+		}
+	}
+	return schedule.Generation, true
+}
+
+// rotationSchedule reads the rotation scheduled on-chain for this node's key. It
+// returns nil when none is scheduled or the registry cannot be read.
+func (n *Node) rotationSchedule(ctx context.Context) *caller.RotationSchedule {
+	if n.commitmentRegistryAddress == (common.Address{}) {
+		return nil
+	}
+	keyID := n.KeyID
+	if keyID == "" {
+		keyID = types.DefaultKeyID
+	}
+	schedule, err := n.baseContractCaller.GetRotationSchedule(ctx, n.commitmentRegistryAddress, keyID)
+	if err != nil {
+		n.logger.Sugar().Warnw("Failed to read the master secret rotation schedule",
+			"operator_address", n.OperatorAddress.Hex(),
+			"key_id", keyID,
+			"error", err)
+		return nil
+	}
+	if schedule == nil || schedule.Generation == 0 {
+		return nil
+	}
+	return schedule
+}
+
+// generationRetireAfter is how long generation keeps serving once a rotation has
+// replaced it: the window of the on-chain schedule that rotated to rotatedTo, or
+// config.DefaultGenerationRetireAfter when the schedule sets none or has changed.
+func (n *Node) generationRetireAfter(ctx context.Context, rotatedTo uint32) time.Duration {
+	if schedule := n.rotationSchedule(ctx); schedule != nil && schedule.Generation == rotatedTo && schedule.RetireAfter > 0 {
+		return time.Duration(min(schedule.RetireAfter, uint64(math.MaxInt64/int64(time.Second)))) * time.Second
+	}
+	return config.DefaultGenerationRetireAfter
+}
+
+// retireGeneration schedules generation to stop being served at retireAt (unix
+// seconds), persisting the deadline so it survives a restart.
+func (n *Node) retireGeneration(generation uint32, retireAt int64) {
+	st, err := n.persistence.LoadNodeState()
+	if err != nil || st == nil {
+		st = &persistence.NodeState{OperatorAddress: n.OperatorAddress.Hex()}
+	}
+	if st.RetiringGenerations == nil {
+		st.RetiringGenerations = make(map[uint32]int64)
+	}
+	st.RetiringGenerations[generation] = retireAt
+	if err := n.persistence.SaveNodeState(st); err != nil {
+		// Not fatal: RestoreState gives a generation without a persisted deadline a
+		// fresh retirement window.
+		n.logger.Sugar().Errorw("Failed to persist generation retirement",
+			"operator_address", n.OperatorAddress.Hex(),
+			"generation", generation,
+			"error", err)
+	}
+	n.keyStore.RetireGeneration(generation, retireAt)
+
+	n.logger.Sugar().Infow("Master secret generation retiring",
+		"operator_address", n.OperatorAddress.Hex(),
+		"generation", generation,
+		"retire_at", time.Unix(retireAt, 0).UTC())
+}
+
+// retireExpiredGenerations drops the generations whose retirement time has passed
+// from the keystore and deletes their key versions from persistence.
+func (n *Node) retireExpiredGenerations(now int64) {
+	pruned := n.keyStore.PruneRetiredGenerations(now)
+	if len(pruned) == 0 {
+		return
+	}
+
+	retired := make(map[uint32]bool)
+	for _, version := range pruned {
+		retired[version.Generation] = true
+		if err := n.persistence.DeleteKeyShareVersion(version.Version); err != nil {
+			n.logger.Sugar().Errorw("Failed to delete retired key share version",
+				"operator_address", n.OperatorAddress.Hex(),
+				"version", version.Version,
+				"generation", version.Generation,
+				"error", err)
+		}
+	}
+
+	st, err := n.persistence.LoadNodeState()
+	if err == nil && st != nil {
+		for generation := range retired {
+			delete(st.RetiringGenerations, generation)
+		}
+		if err := n.persistence.SaveNodeState(st); err != nil {
+			n.logger.Sugar().Errorw("Failed to persist retired generations",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	}
+
+	for generation := range retired {
+		n.logger.Sugar().Infow("Master secret generation retired",
+			"operator_address", n.OperatorAddress.Hex(),
+			"generation", generation)
+	}
+}
+
+// restoreRetiringGenerations reloads the retirement deadlines persisted in nodeState.
+// Must run after the key versions and the active version are restored. A previous
+// generation found without a deadline (e.g. the deadline failed to persist) is given a
+// fresh retirement window rather than being served forever or dropped unannounced.
+func (n *Node) restoreRetiringGenerations(nodeState *persistence.NodeState) {
+	active := n.keyStore.ActiveGeneration()
+	var retiring map[uint32]int64
+	if nodeState != nil {
+		retiring = nodeState.RetiringGenerations
+	}
+	for _, generation := range n.keyStore.Generations() {
+		if generation == active {
+			continue
+		}
+		retireAt, ok := retiring[generation]
+		if !ok {
+			retireAt = time.Now().Add(n.generationRetireAfter(context.Background(), active)).Unix()
+			n.logger.Sugar().Warnw("Previous master secret generation has no retirement deadline; starting a new retirement window",
+				"operator_address", n.OperatorAddress.Hex(),
+				"generation", generation)
+			n.retireGeneration(generation, retireAt)
+			continue
+		}
+		n.keyStore.RetireGeneration(generation, retireAt)
+		n.logger.Sugar().Infow("Restored retiring master secret generation",
+			"operator_address", n.OperatorAddress.Hex(),
+			"generation", generation,
+			"retire_at", time.Unix(retireAt, 0).UTC())
+	}
+}
@@ -0,0 +1,218 @@
+package node
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller/caller"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/keystore"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRotatedTestNode returns a node that rotated from generation 0 (versions 100, 200)
+// to generation 1 (versions 300, 400) and still serves generation 0 until retireAt.
+func newRotatedTestNode(t *testing.T, retireAt int64) *Node {
+	t.Helper()
+	n := newReadyTestNode(t)
+	n.keyStore = keystore.NewKeyStore()
+	for _, v := range []struct {
+		version    int64
+		generation uint32
+		active     bool
+	}{{100, 0, false}, {200, 0, true}, {300, 1, false}, {400, 1, true}} {
+		version := &types.KeyShareVersion{
+			Version:      v.version,
+			Generation:   v.generation,
+			PrivateShare: new(fr.Element).SetInt64(v.version),
+			IsActive:     v.active,
+		}
+		require.NoError(t, n.persistence.SaveKeyShareVersion(version))
+		n.keyStore.AddVersion(version)
+		if v.version == 200 {
+			n.retireGeneration(0, retireAt)
+		}
+	}
+	return n
+}
+
+func uint32Ptr(v uint32) *uint32 { return &v }
+
+func Test_KeyVersionFor(t *testing.T) {
+	n := newRotatedTestNode(t, 1000)
+
+	cases := []struct {
+		name            string
+		generation      *uint32
+		attestationTime int64
+		wantVersion     int64
+		wantErr         error
+	}{
+		{"active generation", nil, 0, 400, nil},
+		{"active generation at time", nil, 350, 300, nil},
+		{"active generation before rotation", nil, 250, 0, errNoVersionAtTime},
+		{"retiring generation", uint32Ptr(0), 0, 200, nil},
+		{"retiring generation at time", uint32Ptr(0), 150, 100, nil},
+		{"explicit active generation", uint32Ptr(1), 0, 400, nil},
+		{"unknown generation", uint32Ptr(2), 0, 0, errGenerationNotHeld},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			version, err := n.keyVersionFor(tc.generation, tc.attestationTime)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.NotNil(t, version)
+			assert.Equal(t, tc.wantVersion, version.Version)
+		})
+	}
+}
+
+func Test_GetCommitments_Generation(t *testing.T) {
+	n := newRotatedTestNode(t, 1000)
+	s := &Server{node: n}
+
+	serve := func(query string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		s.handleGetCommitments(rec, httptest.NewRequest(http.MethodGet, "/pubkey"+query, nil))
+		return rec
+	}
+	decode := func(rec *httptest.ResponseRecorder) (int64, uint32) {
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var resp struct {
+			Version    int64  `json:"version"`
+			Generation uint32 `json:"generation"`
+		}
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		return resp.Version, resp.Generation
+	}
+
+	version, generation := decode(serve(""))
+	assert.Equal(t, int64(400), version)
+	assert.Equal(t, uint32(1), generation)
+
+	version, generation = decode(serve("?generation=0&attestationTime=150"))
+	assert.Equal(t, int64(100), version)
+	assert.Equal(t, uint32(0), generation)
+
+	assert.Equal(t, http.StatusNotFound, serve("?generation=5").Code)
+	assert.Equal(t, http.StatusBadRequest, serve("?generation=x").Code)
+}
+
+func Test_RotationDue(t *testing.T) {
+	// Boundaries every 10 blocks, block N at time 100*N
+	var schedule *caller.RotationSchedule
+	var scheduleErr error
+	stub := &contractCaller.MockContractCallerStub{
+		GetRotationScheduleFunc: func(ctx context.Context, registryAddress common.Address, keyID string) (*caller.RotationSchedule, error) {
+			assert.Equal(t, types.DefaultKeyID, keyID)
+			if schedule == nil {
+				return &caller.RotationSchedule{}, scheduleErr
+			}
+			return schedule, scheduleErr
+		},
+		HeaderTimestampAtFunc: func(ctx context.Context, blockNumber uint64) (uint64, error) {
+			return 100 * blockNumber, nil
+		},
+	}
+	n := newReadyTestNode(t)
+	n.baseContractCaller = stub
+	n.commitmentRegistryAddress = common.HexToAddress("0x1234")
+	ctx := context.Background()
+
+	_, due := n.rotationDue(ctx, 20, 2000, 10)
+	assert.False(t, due, "no rotation scheduled")
+
+	schedule = &caller.RotationSchedule{Generation: 1, At: 1500}
+	_, due = n.rotationDue(ctx, 10, 1000, 10)
+	assert.False(t, due, "before the rotation time")
+
+	generation, due := n.rotationDue(ctx, 20, 2000, 10)
+	assert.True(t, due, "first boundary at or after the rotation time")
+	assert.Equal(t, uint32(1), generation)
+
+	_, due = n.rotationDue(ctx, 30, 3000, 10)
+	assert.False(t, due, "a rotation that did not complete at its boundary must not block resharing")
+
+	scheduleErr = errors.New("rpc down")
+	_, due = n.rotationDue(ctx, 20, 2000, 10)
+	assert.False(t, due, "an unreadable schedule reshares")
+	scheduleErr = nil
+
+	n.keyStore.AddVersion(&types.KeyShareVersion{Version: 1000, Generation: 1, IsActive: true})
+	_, due = n.rotationDue(ctx, 20, 2000, 10)
+	assert.False(t, due, "generation already active")
+}
+
+func Test_GenerationRetireAfter(t *testing.T) {
+	n := newReadyTestNode(t)
+	n.commitmentRegistryAddress = common.HexToAddress("0x1234")
+	n.baseContractCaller = &contractCaller.MockContractCallerStub{
+		GetRotationScheduleFunc: func(ctx context.Context, registryAddress common.Address, keyID string) (*caller.RotationSchedule, error) {
+			return &caller.RotationSchedule{Generation: 2, At: 1000, RetireAfter: 3600}, nil
+		},
+	}
+	assert.Equal(t, time.Hour, n.generationRetireAfter(context.Background(), 2))
+	assert.Equal(t, config.DefaultGenerationRetireAfter, n.generationRetireAfter(context.Background(), 1), "schedule for another generation")
+}
+
+func Test_RetireExpiredGenerations(t *testing.T) {
+	n := newRotatedTestNode(t, 1000)
+
+	n.retireExpiredGenerations(999)
+	assert.NotNil(t, n.keyStore.GetGenerationVersion(0))
+
+	n.retireExpiredGenerations(1000)
+	assert.Nil(t, n.keyStore.GetGenerationVersion(0))
+	assert.Equal(t, []uint32{1}, n.keyStore.Generations())
+
+	persisted, err := n.persistence.ListKeyShareVersions()
+	require.NoError(t, err)
+	for _, v := range persisted {
+		assert.Equal(t, uint32(1), v.Generation, "version %d should have been deleted", v.Version)
+	}
+	st, err := n.persistence.LoadNodeState()
+	require.NoError(t, err)
+	require.NotNil(t, st)
+	assert.Empty(t, st.RetiringGenerations)
+}
+
+func Test_RestoreRetiringGenerations(t *testing.T) {
+	t.Run("persisted deadline is restored", func(t *testing.T) {
+		n := newRotatedTestNode(t, 1000)
+		n.keyStore.RetireGeneration(0, 0) // forget the in-memory deadline
+
+		st, err := n.persistence.LoadNodeState()
+		require.NoError(t, err)
+		n.restoreRetiringGenerations(st)
+		assert.Equal(t, map[uint32]int64{0: 1000}, n.keyStore.RetiringGenerations())
+	})
+
+	t.Run("missing deadline starts a new window", func(t *testing.T) {
+		n := newRotatedTestNode(t, 1000)
+		require.NoError(t, n.persistence.SaveNodeState(&persistence.NodeState{OperatorAddress: "0x1"}))
+
+		st, err := n.persistence.LoadNodeState()
+		require.NoError(t, err)
+		n.restoreRetiringGenerations(st)
+		retireAt := n.keyStore.RetiringGenerations()[0]
+		assert.InDelta(t, time.Now().Add(config.DefaultGenerationRetireAfter).Unix(), retireAt, 5)
+
+		st, err = n.persistence.LoadNodeState()
+		require.NoError(t, err)
+		assert.Equal(t, retireAt, st.RetiringGenerations[0])
+	})
+}
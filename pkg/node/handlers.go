@@ -1,39 +1,55 @@
 package node
 
 import (
+	"bytes"
 	"context"
+	"crypto/mlkem"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
-	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/attestation"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/authz"
 	platformClient "github.com/Layr-Labs/eigenx-kms-go/pkg/clients/platformClient"
+	eigenxcrypto "github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/tracing"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/util"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/ethereum/go-ethereum/common"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
 
-// validateAuthenticatedMessage validates an incoming authenticated message
-func (s *Server) validateAuthenticatedMessage(r *http.Request, expectedRecipient common.Address) (*types.AuthenticatedMessage, *peering.OperatorSetPeer, interface{}, error) {
+// validateAuthenticatedMessage validates an incoming authenticated message. On success it
+// also starts the span for handling the message, parented to the sender's trace context
+// from the signed payload, or to the session's trace when the sender sent none; the
+// caller must end the span in the returned context.
+func (s *Server) validateAuthenticatedMessage(r *http.Request, expectedRecipient common.Address) (*types.AuthenticatedMessage, *peering.OperatorSetPeer, context.Context, error) {
+	received := time.Now()
+
 	// Parse authenticated message wrapper
 	var authMsg types.AuthenticatedMessage
 	if err := json.NewDecoder(r.Body).Decode(&authMsg); err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to parse authenticated message: %w", err)
 	}
-	s.node.logger.Sugar().Infow("Received authenticated message wrapper", "msg", string(authMsg.Payload))
 	// First decode payload to get sender address and session timestamp
 	var baseMsg struct {
-		FromOperatorAddress common.Address `json:"fromOperatorAddress"`
-		ToOperatorAddress   common.Address `json:"toOperatorAddress"`
-		SessionTimestamp    int64          `json:"sessionTimestamp"`
+		FromOperatorAddress common.Address    `json:"fromOperatorAddress"`
+		ToOperatorAddress   common.Address    `json:"toOperatorAddress"`
+		KeyID               string            `json:"keyId"`
+		SessionTimestamp    int64             `json:"sessionTimestamp"`
+		TraceContext        map[string]string `json:"traceContext"`
 	}
 	if err := json.Unmarshal(authMsg.Payload, &baseMsg); err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to parse message addresses: %w", err)
@@ -45,6 +61,12 @@ func (s *Server) validateAuthenticatedMessage(r *http.Request, expectedRecipient
 		return nil, nil, nil, fmt.Errorf("message not intended for this operator - to: '%s' expected: '%s'", baseMsg.ToOperatorAddress, expectedRecipient)
 	}
 
+	// Verify message belongs to the key this node holds. Every key of a server runs its
+	// sessions at the same timestamps, so the session alone does not tell them apart.
+	if !types.SameKeyID(baseMsg.KeyID, s.node.KeyID) {
+		return nil, nil, nil, fmt.Errorf("message for key %q, this node holds key %q", baseMsg.KeyID, s.node.KeyID)
+	}
+
 	// Get session - it contains the operators for this protocol run
 	session := s.node.getSession(baseMsg.SessionTimestamp)
 	var operators []*peering.OperatorSetPeer
@@ -73,10 +95,24 @@ func (s *Server) validateAuthenticatedMessage(r *http.Request, expectedRecipient
 
 	// Verify authentication
 	if err := s.node.verifyMessage(&authMsg, senderPeer); err != nil {
+		s.metrics().IncP2PSignatureVerificationFailure(r.URL.Path)
 		return nil, nil, nil, fmt.Errorf("authentication failed: %w", err)
 	}
 
-	return &authMsg, senderPeer, nil, nil
+	// The sender's trace context is only trusted once its signature has verified
+	ctx := tracing.SessionContext(r.Context(), s.node.AVSAddress, s.node.OperatorSetId, baseMsg.SessionTimestamp)
+	ctx = tracing.Extract(ctx, baseMsg.TraceContext)
+	ctx, _ = tracing.Tracer().Start(ctx, "receive "+r.URL.Path,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithTimestamp(received),
+		trace.WithAttributes(
+			tracing.AttrKeyID.String(s.node.KeyID),
+			tracing.AttrOperatorAddress.String(s.node.OperatorAddress.Hex()),
+			tracing.AttrPeerAddress.String(senderPeer.OperatorAddress.Hex()),
+			tracing.AttrSessionTimestamp.Int64(baseMsg.SessionTimestamp),
+		))
+
+	return &authMsg, senderPeer, ctx, nil
 }
 
 // verifyECDSAOwnership confirms the ECDSA attestation signer controls the app's
@@ -189,8 +225,22 @@ func (s *Server) writePlatformAuthError(w http.ResponseWriter, req types.Secrets
 	}
 }
 
+// unknownAttestationMethod labels /secrets requests whose attestation method is
+// missing or not registered.
+const unknownAttestationMethod = "unknown"
+
+// attestationMethodLabel bounds the attestation_method metric label to registered
+// methods; the raw value is caller-controlled.
+func (s *Server) attestationMethodLabel(method string) string {
+	if s.node.attestationManager != nil && s.node.attestationManager.HasMethod(method) {
+		return method
+	}
+	return unknownAttestationMethod
+}
+
 // handleSecretsRequest handles the /secrets endpoint for application secret retrieval
 func (s *Server) handleSecretsRequest(w http.ResponseWriter, r *http.Request) {
+	setAttestationMethodLabel(w, unknownAttestationMethod)
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -211,47 +261,128 @@ func (s *Server) handleSecretsRequest(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, fmt.Sprintf("Failed to parse request: %v", err), http.StatusBadRequest)
 		return
 	}
+	setAttestationMethodLabel(w, s.attestationMethodLabel(req.AttestationMethod))
 
-	// Validate required fields
-	if req.AppID == "" {
-		http.Error(w, "app_id is required", http.StatusBadRequest)
+	if len(req.CiphertextC1s) > types.MaxCiphertextsPerRequest {
+		http.Error(w, fmt.Sprintf("ciphertext_c1s exceeds %d ciphertexts", types.MaxCiphertextsPerRequest), http.StatusBadRequest)
 		return
 	}
-	if s.node.appAllowlist != nil && !s.node.appAllowlist[req.AppID] {
-		s.node.logger.Sugar().Warnw("Secrets request rejected: app not in allowlist",
-			"operator_address", s.node.OperatorAddress.Hex(),
-			"app_id", req.AppID)
-		http.Error(w, "app not allowed", http.StatusForbidden)
+
+	release, keyVersion, ok := s.authorizeSecretsRequest(w, r, &req)
+	if !ok {
+		return
+	}
+
+	// Decryption shares for the presented ciphertexts replace the partial signature
+	if len(req.CiphertextC1s) > 0 {
+		s.serveSecretsDecryptionShares(w, r, &req, release, keyVersion)
+		return
+	}
+
+	// Step 6: Generate partial signature for this app using the already-resolved key version
+	// partial_sig = H(app_id)^{key_share}
+	_, signSpan := tracing.Tracer().Start(r.Context(), "secrets.sign", trace.WithAttributes(
+		attribute.Int64("kms.key_version", keyVersion.Version),
+	))
+	partialSig, err := s.node.signAppIDWithVersion(req.AppID, keyVersion)
+	tracing.End(signSpan, err)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to compute partial signature", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "error", err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+		return
+	}
+
+	s.node.logger.Sugar().Infow("Generated partial signature", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID)
+
+	// Step 7: Serialize partial signature for encryption
+	partialSigBytes, err := json.Marshal(partialSig)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to serialize partial signature", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+		return
+	}
+
+	// Step 8: Encrypt partial signature with ephemeral RSA public key
+	encryptedPartialSig, err := s.node.rsaEncryption.Encrypt(partialSigBytes, req.RSAPubKeyTmp)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to encrypt partial signature", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		http.Error(w, "Encryption failed", http.StatusInternalServerError)
+		return
+	}
+
+	// Step 9: Create response
+	response := types.SecretsResponseV1{
+		EncryptedPartialSig: encryptedPartialSig,
+		ExtraData:           req.ExtraData,
+	}
+	if release != nil {
+		response.EncryptedEnv = release.EncryptedEnv
+		response.PublicEnv = release.PublicEnv
+	}
+
+	// Return JSON response
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.node.logger.Sugar().Errorw("Failed to encode response", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
 		return
 	}
+
+	s.node.logger.Sugar().Infow("Successfully served secrets", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID)
+}
+
+// authorizeSecretsRequest runs the checks an attested request must pass before this
+// node serves anything derived from the app's key share (steps 1-5 of
+// handleSecretsRequest). It authenticates the request (field limits, attestation
+// verification, replay protection, key binding, ecdsa ownership or the platform
+// release), then leaves the decision to the authorization policy, whose default
+// rules are the release checks. On failure it writes the error response and returns
+// false. The release is nil on the platform (stack_id) path and when the app has
+// none.
+func (s *Server) authorizeSecretsRequest(w http.ResponseWriter, r *http.Request, req *types.SecretsRequestV1) (*types.Release, *types.KeyShareVersion, bool) {
+	// Validate required fields
+	if req.AppID == "" {
+		http.Error(w, "app_id is required", http.StatusBadRequest)
+		return nil, nil, false
+	}
 	if len(req.RSAPubKeyTmp) == 0 {
 		http.Error(w, "rsa_pubkey_tmp is required", http.StatusBadRequest)
-		return
+		return nil, nil, false
 	}
 	if len(req.RSAPubKeyTmp) > 8192 {
 		http.Error(w, "rsa_pubkey_tmp too large", http.StatusBadRequest)
-		return
+		return nil, nil, false
 	}
 	if len(req.ExtraData) > types.MaxExtraDataSize {
 		http.Error(w, fmt.Sprintf("extra_data exceeds 1MB limit (%d bytes)", len(req.ExtraData)), http.StatusBadRequest)
-		return
+		return nil, nil, false
 	}
 	if len(req.CCInitData) > types.MaxExtraDataSize {
 		http.Error(w, fmt.Sprintf("cc_init_data exceeds 1MB limit (%d bytes)", len(req.CCInitData)), http.StatusBadRequest)
-		return
+		return nil, nil, false
 	}
 	if len(req.Attestation) > types.MaxAttestationSize {
 		http.Error(w, fmt.Sprintf("attestation exceeds %d byte limit (%d bytes)", types.MaxAttestationSize, len(req.Attestation)), http.StatusBadRequest)
-		return
+		return nil, nil, false
+	}
+	// Decryption shares are served only for the ciphertexts the request was attested
+	// for: extra_data, which the attestation covers, must begin with their binding.
+	if len(req.CiphertextC1s) > 0 && !bytes.HasPrefix(req.ExtraData, eigenxcrypto.CiphertextBinding(req.CiphertextC1s)) {
+		http.Error(w, "extra_data must begin with the ciphertext binding of ciphertext_c1s", http.StatusBadRequest)
+		return nil, nil, false
 	}
 
 	s.node.logger.Sugar().Infow("Processing secrets request", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "attestation_method", req.AttestationMethod)
+	trace.SpanFromContext(r.Context()).SetAttributes(
+		tracing.AttrAppID.String(req.AppID),
+		attribute.String("kms.attestation_method", req.AttestationMethod),
+	)
 
 	// Step 1: Validate attestation method is provided
 	if req.AttestationMethod == "" {
 		s.node.logger.Sugar().Warnw("Attestation method is required", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID)
 		http.Error(w, "Attestation method is required", http.StatusBadRequest)
-		return
+		return nil, nil, false
 	}
 
 	// Step 2: Verify attestation using AttestationManager
@@ -273,14 +404,16 @@ func (s *Server) handleSecretsRequest(w http.ResponseWriter, r *http.Request) {
 		attestReq.Metadata["rsa_pubkey"] = req.RSAPubKeyTmp
 	}
 
+	_, verifySpan := tracing.Tracer().Start(r.Context(), "secrets.verify_attestation")
 	claims, err := s.node.attestationManager.VerifyWithMethod(req.AttestationMethod, attestReq)
+	tracing.End(verifySpan, err)
 	if err != nil {
 		s.node.logger.Sugar().Warnw("Attestation verification failed",
 			"operator_address", s.node.OperatorAddress.Hex(),
 			"method", req.AttestationMethod,
 			"error", err)
 		http.Error(w, fmt.Sprintf("Invalid attestation: %v", err), http.StatusUnauthorized)
-		return
+		return nil, nil, false
 	}
 
 	// Step 2b: Ensure attested application identity matches requested app.
@@ -290,7 +423,7 @@ func (s *Server) handleSecretsRequest(w http.ResponseWriter, r *http.Request) {
 			"requested_app_id", req.AppID,
 			"attested_app_id", claims.AppID)
 		http.Error(w, "App ID mismatch - unauthorized app", http.StatusForbidden)
-		return
+		return nil, nil, false
 	}
 
 	// Reject replayed attestation tokens by tracking JTI claim.
@@ -302,16 +435,33 @@ func (s *Server) handleSecretsRequest(w http.ResponseWriter, r *http.Request) {
 				"app_id", req.AppID,
 				"jti", claims.JTI)
 			http.Error(w, "attestation token already used", http.StatusUnauthorized)
-			return
+			return nil, nil, false
+		}
+	}
+
+	// Step 2c: On a multi-key server, the app must belong to this key's operator set.
+	// stack_id requests are authorized by the platform release, which names no
+	// operator set, so only the default key serves them.
+	if req.StackID != "" {
+		if s.node.bindAppsToKey && s.node.KeyID != types.DefaultKeyID {
+			http.Error(w, "stack_id requests are only served on the default key", http.StatusForbidden)
+			return nil, nil, false
 		}
+	} else if httpStatus, bindErr := s.verifyAppKeyBinding(req.AppID); bindErr != nil {
+		s.node.logger.Sugar().Warnw("Secrets request rejected: app not bound to key",
+			"operator_address", s.node.OperatorAddress.Hex(),
+			"app_id", req.AppID,
+			"error", bindErr)
+		http.Error(w, bindErr.Error(), httpStatus)
+		return nil, nil, false
 	}
 
-	// Step 3: Resolve the release and run method-specific authorization.
+	// Step 3: Authenticate the release path and resolve the release.
 	//
 	// ECDSA is a lightweight ownership-proof method for testing: it binds to the
-	// app's on-chain creator and does NOT depend on a release (best-effort env,
-	// no digest/registry/container-policy checks). All other methods keep the
-	// full release + image-digest + registry + container-policy enforcement.
+	// app's on-chain creator and does NOT depend on a release, so the default
+	// policy applies no image checks to it and a missing release serves the share
+	// with empty env.
 	var release *types.Release // stays nil on the platform path (no secrets returned)
 	if req.StackID != "" {
 		// The platform path authorizes SOLELY by matching the attested image digest
@@ -319,146 +469,227 @@ func (s *Server) handleSecretsRequest(w http.ResponseWriter, r *http.Request) {
 		// the running image (its claims.ImageDigest is either "ecdsa:unverified" or an
 		// operator-configured AllowedImageDigest — neither is a TEE-measured digest).
 		// Reject it outright so a configured AllowedImageDigest can never satisfy the
-		// platform digest match. Require a TEE method (gcp/intel/eigenx-snp).
+		// platform digest match. Require a TEE method (gcp/intel/eigenx-snp/eigenx-tdx/nitro).
 		if req.AttestationMethod == "ecdsa" {
 			s.node.logger.Sugar().Warnw("ecdsa attestation not allowed on the platform (stack_id) path",
 				"operator_address", s.node.OperatorAddress.Hex(), "stack_id", req.StackID)
 			http.Error(w, "ecdsa attestation is not permitted for stack_id requests", http.StatusForbidden)
-			return
+			return nil, nil, false
 		}
 		if err := s.authorizeViaPlatform(r.Context(), req.StackID, claims); err != nil {
-			s.writePlatformAuthError(w, req, err)
-			return
+			s.writePlatformAuthError(w, *req, err)
+			return nil, nil, false
 		}
 		// release stays nil -> response env fields stay empty (share-only).
-	} else if req.AttestationMethod == "ecdsa" {
-		if httpStatus, ownErr := s.verifyECDSAOwnership(req.AppID, claims.PublicKey); ownErr != nil {
-			s.node.logger.Sugar().Warnw("ECDSA ownership check failed",
-				"operator_address", s.node.OperatorAddress.Hex(),
-				"app_id", req.AppID,
-				"error", ownErr)
-			http.Error(w, ownErr.Error(), httpStatus)
-			return
+	} else {
+		if req.AttestationMethod == "ecdsa" {
+			if httpStatus, ownErr := s.verifyECDSAOwnership(req.AppID, claims.PublicKey); ownErr != nil {
+				s.node.logger.Sugar().Warnw("ECDSA ownership check failed",
+					"operator_address", s.node.OperatorAddress.Hex(),
+					"app_id", req.AppID,
+					"error", ownErr)
+				http.Error(w, ownErr.Error(), httpStatus)
+				return nil, nil, false
+			}
 		}
 
-		// Best-effort env: a missing release is fine for ECDSA — serve the share
-		// with empty env. A present release contributes its env.
-		release, err = s.node.baseContractCaller.GetLatestReleaseAsRelease(r.Context(), req.AppID)
-		if err != nil {
-			s.node.logger.Sugar().Infow("No release for ecdsa app; serving share with empty env",
-				"operator_address", s.node.OperatorAddress.Hex(),
-				"app_id", req.AppID)
-			release = &types.Release{}
-		}
-	} else {
-		// Query latest release from on-chain AppController
-		release, err = s.node.baseContractCaller.GetLatestReleaseAsRelease(r.Context(), req.AppID)
+		// Query latest release from on-chain AppController. A missing release is
+		// left to the policy, which refuses it for every method but ecdsa.
+		ctx, releaseSpan := tracing.Tracer().Start(r.Context(), "secrets.get_release")
+		release, err = s.node.baseContractCaller.GetLatestReleaseAsRelease(ctx, req.AppID)
+		tracing.End(releaseSpan, err)
 		if err != nil {
-			s.node.logger.Sugar().Warnw("Failed to get release", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "error", err)
-			http.Error(w, "Release not found", http.StatusNotFound)
-			return
+			s.node.logger.Sugar().Infow("No release for app", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "error", err)
+			release = nil
 		}
+	}
 
-		// Step 4: Verify image digest matches.
-		if claims.ImageDigest != release.ImageDigest {
-			s.node.logger.Sugar().Warnw("Image digest mismatch", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "expected", release.ImageDigest, "got", claims.ImageDigest)
-			http.Error(w, "Image digest mismatch - unauthorized image", http.StatusForbidden)
-			return
-		}
+	// Step 4: Apply the authorization policy: the default rules match the release
+	// (allowlist, image digest, registry, container policy) and the operator's
+	// rules follow. A rule that fails to evaluate denies.
+	decision, err := s.node.authzPolicy.Evaluate(&authz.Input{
+		Endpoint:          r.URL.Path,
+		AppID:             req.AppID,
+		AttestationMethod: req.AttestationMethod,
+		StackID:           req.StackID,
+		OperatorAddress:   s.node.OperatorAddress.Hex(),
+		ChainID:           uint64(s.node.ChainID),
+		KeyID:             s.node.KeyID,
+		AppAllowlist:      s.node.appAllowlist,
+		Claims:            claims,
+		Release:           release,
+	})
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Authorization policy evaluation failed; denying request",
+			"operator_address", s.node.OperatorAddress.Hex(),
+			"app_id", req.AppID,
+			"attestation_method", req.AttestationMethod,
+			"rule", decision.Rule,
+			"error", err)
+		http.Error(w, "Denied by authorization policy", http.StatusForbidden)
+		return nil, nil, false
+	}
+	if !decision.Allow {
+		s.node.logger.Sugar().Warnw("Authorization policy denied request",
+			"operator_address", s.node.OperatorAddress.Hex(),
+			"app_id", req.AppID,
+			"attestation_method", req.AttestationMethod,
+			"rule", decision.Rule)
+		http.Error(w, fmt.Sprintf("Denied by authorization policy (rule %q)", decision.Rule), http.StatusForbidden)
+		return nil, nil, false
+	}
+	s.node.logger.Sugar().Infow("Authorization policy allowed request",
+		"operator_address", s.node.OperatorAddress.Hex(),
+		"app_id", req.AppID,
+		"attestation_method", req.AttestationMethod,
+		"rule", decision.Rule)
+
+	// Step 5: Get appropriate key share based on attestation time and generation
+	keyVersion, err := s.node.keyVersionFor(req.Generation, req.AttestationTime)
+	if errors.Is(err, errGenerationNotHeld) {
+		s.node.logger.Sugar().Warnw("Requested master secret generation not held",
+			"operator_address", s.node.OperatorAddress.Hex(),
+			"generation", *req.Generation)
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return nil, nil, false
+	}
+	if errors.Is(err, errNoVersionAtTime) {
+		s.node.logger.Sugar().Warnw("No key version found for attestation time",
+			"operator_address", s.node.OperatorAddress.Hex(),
+			"attestation_time", req.AttestationTime)
+		http.Error(w, "No key version found for the specified attestation time", http.StatusNotFound)
+		return nil, nil, false
+	}
 
-		// Step 4b: Verify registry matches when claims surface one.
-		//
-		// eigenx-snp populates claims.Registry from cc_init_data's policy.rego
-		// (e.g. "ghcr.io/example/app"); the on-chain Release.Registry is the
-		// same shape — what AgentKit publishes via extractRegistryNameNoDocker
-		// (registry + repo path, sans tag/digest). Other attestation methods
-		// (kbs-ear, gcp/intel) leave claims.Registry empty; in that case we
-		// don't enforce — those methods either don't surface the registry yet
-		// or cover environments where the on-chain Registry is absent. When
-		// release.Registry is empty (older releases pre-Registry-field) we
-		// also skip — fail-open here is safe because the digest check above
-		// already pinned identity.
-		if claims.Registry != "" && release.Registry != "" && claims.Registry != release.Registry {
-			s.node.logger.Sugar().Warnw("Registry mismatch",
-				"operator_address", s.node.OperatorAddress.Hex(),
-				"app_id", req.AppID,
-				"expected", release.Registry, "got", claims.Registry)
-			http.Error(w, "Registry mismatch - unauthorized image source", http.StatusForbidden)
-			return
-		}
+	if keyVersion == nil || keyVersion.PrivateShare == nil {
+		s.node.logger.Sugar().Errorw("No valid key share available", "operator_address", s.node.OperatorAddress.Hex())
+		http.Error(w, "No valid key share", http.StatusServiceUnavailable)
+		return nil, nil, false
+	}
 
-		// Step 5: Verify container execution policy matches on-chain values.
-		//
-		// eigenx-snp does not surface ContainerPolicy in claims (the policy lives
-		// in cc_init_data's aa.toml / cdh.toml, which we don't parse yet). If a
-		// release pins a non-empty ContainerPolicy and the request authenticates
-		// via eigenx-snp, validateContainerPolicy would silently succeed against
-		// claims.ContainerPolicy's zero value — the policy would not be enforced.
-		// Fail closed instead: workloads that don't pin a ContainerPolicy still
-		// work over eigenx-snp; workloads that do pin one cannot use eigenx-snp
-		// until the SEV-SNP path surfaces the running container's policy claims.
-		// TODO(eigenx): surface the running container's launch spec into
-		// claims.ContainerPolicy so this gap closes and this branch can drop.
-		// Design + recommended approach: docs/009_eigenxSnpAttestation.md
-		// ("Follow-up 1: ContainerPolicy enforcement").
-		if req.AttestationMethod == "eigenx-snp" && hasContainerPolicy(release.ContainerPolicy) {
-			s.node.logger.Sugar().Warnw(
-				"refusing eigenx-snp request: release pins ContainerPolicy that this method does not yet surface in claims",
-				"operator_address", s.node.OperatorAddress.Hex(),
-				"app_id", req.AppID,
-			)
-			http.Error(w, "eigenx-snp attestation does not yet enforce ContainerPolicy; release requires it", http.StatusForbidden)
-			return
-		}
-		if err := validateContainerPolicy(claims.ContainerPolicy, release.ContainerPolicy); err != nil {
-			s.node.logger.Sugar().Warnw("Container policy mismatch", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "error", err)
-			http.Error(w, "Container policy mismatch", http.StatusForbidden)
-			return
+	return release, keyVersion, true
+}
+
+// serveSecretsDecryptionShares completes a /secrets request that set CiphertextC1s
+// (steps 6-9 of handleSecretsRequest): it returns decryption shares of the presented
+// ciphertexts, and of the release's encrypted environment, so the app decrypts those
+// without ever holding its private key.
+func (s *Server) serveSecretsDecryptionShares(w http.ResponseWriter, r *http.Request, req *types.SecretsRequestV1, release *types.Release, keyVersion *types.KeyShareVersion) {
+	_, shareSpan := tracing.Tracer().Start(r.Context(), "secrets.decrypt_share", trace.WithAttributes(
+		attribute.Int64("kms.key_version", keyVersion.Version),
+		attribute.Int("kms.ciphertexts", len(req.CiphertextC1s)),
+	))
+	shares, err := s.node.decryptionSharesWithVersion(req.AppID, req.CiphertextC1s, keyVersion)
+	tracing.End(shareSpan, err)
+	if err != nil {
+		s.node.logger.Sugar().Warnw("Failed to compute decryption shares", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "error", err)
+		http.Error(w, fmt.Sprintf("Invalid ciphertext_c1s: %v", err), http.StatusBadRequest)
+		return
+	}
+	payload := types.SecretsDecryptionShares{Shares: shares}
+
+	if release != nil && release.EncryptedEnv != "" {
+		// The environment is only shared when it is an IBE ciphertext; anything else is
+		// returned as is, as on the partial signature path
+		if envCiphertext, err := hex.DecodeString(release.EncryptedEnv); err == nil {
+			if c1, err := eigenxcrypto.CiphertextC1(envCiphertext); err == nil {
+				envShares, err := s.node.decryptionSharesWithVersion(req.AppID, []types.G2Point{*c1}, keyVersion)
+				if err != nil {
+					s.node.logger.Sugar().Errorw("Failed to compute environment decryption share", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "error", err)
+					http.Error(w, "Internal error", http.StatusInternalServerError)
+					return
+				}
+				payload.EnvShare = &envShares[0]
+			}
 		}
 	}
 
-	// Step 6: Get appropriate key share based on attestation time
-	var keyVersion *types.KeyShareVersion
-	if req.AttestationTime > 0 {
-		// Use key version from the specified time
-		keyVersion = s.node.keyStore.GetKeyVersionAtTime(req.AttestationTime)
-		if keyVersion == nil {
-			s.node.logger.Sugar().Warnw("No key version found for attestation time",
-				"operator_address", s.node.OperatorAddress.Hex(),
-				"attestation_time", req.AttestationTime)
-			http.Error(w, "No key version found for the specified attestation time", http.StatusNotFound)
-			return
-		}
-	} else {
-		keyVersion = s.node.keyStore.GetActiveVersion()
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to serialize decryption shares", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+		return
+	}
+	encryptedShares, err := s.node.rsaEncryption.EncryptHybrid(payloadBytes, req.RSAPubKeyTmp)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to encrypt decryption shares", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		http.Error(w, "Encryption failed", http.StatusInternalServerError)
+		return
 	}
 
-	if keyVersion == nil || keyVersion.PrivateShare == nil {
-		s.node.logger.Sugar().Errorw("No valid key share available", "operator_address", s.node.OperatorAddress.Hex())
-		http.Error(w, "No valid key share", http.StatusServiceUnavailable)
+	response := types.SecretsResponseV1{
+		EncryptedDecryptionShares: encryptedShares,
+		ExtraData:                 req.ExtraData,
+	}
+	if release != nil {
+		response.EncryptedEnv = release.EncryptedEnv
+		response.PublicEnv = release.PublicEnv
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.node.logger.Sugar().Errorw("Failed to encode response", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
 		return
 	}
 
-	// Step 7: Generate partial signature for this app using the already-resolved key version
-	// partial_sig = H(app_id)^{key_share}
-	partialSig, err := s.node.signAppIDWithVersion(req.AppID, keyVersion)
+	s.node.logger.Sugar().Infow("Successfully served decryption shares",
+		"operator_address", s.node.OperatorAddress.Hex(),
+		"app_id", req.AppID,
+		"ciphertexts", len(req.CiphertextC1s))
+}
+
+// handleAppSignMessage handles the /v1/app/sign-message endpoint: an attested app gets
+// this node's partial BLS signature over a message under the app's derived signing key
+// (see crypto.SignAppMessage). The partial signature is encrypted to the request's
+// ephemeral RSA key, as on /secrets, so a replayed attestation yields nothing usable.
+func (s *Server) handleAppSignMessage(w http.ResponseWriter, r *http.Request) {
+	setAttestationMethodLabel(w, unknownAttestationMethod)
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	const maxSignMessageBodyBytes = 2*types.MaxAttestationSize + 2*types.MaxExtraDataSize + 2*types.MaxAppMessageSize + 64*1024
+	r.Body = http.MaxBytesReader(w, r.Body, int64(maxSignMessageBodyBytes))
+
+	var req types.AppSignMessageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to parse request: %v", err), http.StatusBadRequest)
+		return
+	}
+	setAttestationMethodLabel(w, s.attestationMethodLabel(req.AttestationMethod))
+
+	if len(req.Message) > types.MaxAppMessageSize {
+		http.Error(w, fmt.Sprintf("message exceeds %d byte limit (%d bytes)", types.MaxAppMessageSize, len(req.Message)), http.StatusBadRequest)
+		return
+	}
+	if len(req.CiphertextC1s) > 0 {
+		http.Error(w, "ciphertext_c1s is not supported on this endpoint", http.StatusBadRequest)
+		return
+	}
+
+	_, keyVersion, ok := s.authorizeSecretsRequest(w, r, &req.SecretsRequestV1)
+	if !ok {
+		return
+	}
+
+	_, signSpan := tracing.Tracer().Start(r.Context(), "secrets.sign_message", trace.WithAttributes(
+		attribute.Int64("kms.key_version", keyVersion.Version),
+	))
+	partialSig, err := s.node.signAppMessageWithVersion(req.AppID, req.Message, keyVersion)
+	tracing.End(signSpan, err)
 	if err != nil {
-		s.node.logger.Sugar().Errorw("Failed to compute partial signature", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "error", err)
+		s.node.logger.Sugar().Errorw("Failed to compute message partial signature", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "error", err)
 		http.Error(w, "Internal error", http.StatusInternalServerError)
 		return
 	}
 
-	s.node.logger.Sugar().Infow("Generated partial signature", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID)
-
-	// Step 8: Serialize partial signature for encryption
 	partialSigBytes, err := json.Marshal(partialSig)
 	if err != nil {
 		s.node.logger.Sugar().Errorw("Failed to serialize partial signature", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
 		http.Error(w, "Internal error", http.StatusInternalServerError)
 		return
 	}
-
-	// Step 9: Encrypt partial signature with ephemeral RSA public key
 	encryptedPartialSig, err := s.node.rsaEncryption.Encrypt(partialSigBytes, req.RSAPubKeyTmp)
 	if err != nil {
 		s.node.logger.Sugar().Errorw("Failed to encrypt partial signature", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
@@ -466,25 +697,119 @@ func (s *Server) handleSecretsRequest(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Step 10: Create response
-	response := types.SecretsResponseV1{
+	response := types.AppSignMessageResponse{
+		OperatorAddress:     s.node.OperatorAddress.Hex(),
 		EncryptedPartialSig: encryptedPartialSig,
-		ExtraData:           req.ExtraData,
 	}
-	if release != nil {
-		response.EncryptedEnv = release.EncryptedEnv
-		response.PublicEnv = release.PublicEnv
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		s.node.logger.Sugar().Errorw("Failed to encode response", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		return
 	}
 
-	// Return JSON response
+	s.node.logger.Sugar().Infow("Served message partial signature",
+		"operator_address", s.node.OperatorAddress.Hex(),
+		"app_id", req.AppID,
+		"message_size", len(req.Message))
+}
+
+// handleAppPQPublicKey handles the /v1/app/pq-public-key endpoint: this node's ML-KEM-768
+// encapsulation key for an app, signed by its transport key. Encryptors collect one per
+// operator to build post-quantum (version 3) ciphertexts; the key is public, so the
+// request needs no attestation.
+func (s *Server) handleAppPQPublicKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	appID := r.URL.Query().Get("app_id")
+	if err := util.ValidateAppID(appID); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid app_id: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	data, err := s.node.appPQPublicKeyAnnouncement(appID)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to build PQ public key announcement", "app_id", appID, "error", err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		s.node.logger.Sugar().Warnw("Failed to write PQ public key response", "error", err)
+	}
+}
+
+// handleAppPQKey handles the /v1/app/pq-key endpoint: an attested app gets this node's
+// ML-KEM-768 decapsulation key for the app, which version 3 ciphertexts need, from a
+// threshold of operators, alongside the IBE key. The key is sealed to the request's
+// ephemeral ML-KEM key and the result RSA encrypted to its ephemeral RSA key, so neither
+// a replayed attestation nor a recording of the response broken with a quantum computer
+// yields the key.
+func (s *Server) handleAppPQKey(w http.ResponseWriter, r *http.Request) {
+	setAttestationMethodLabel(w, unknownAttestationMethod)
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	const maxPQKeyBodyBytes = 2*types.MaxAttestationSize + 2*types.MaxExtraDataSize + 64*1024
+	r.Body = http.MaxBytesReader(w, r.Body, int64(maxPQKeyBodyBytes))
+
+	var req types.AppPQKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to parse request: %v", err), http.StatusBadRequest)
+		return
+	}
+	setAttestationMethodLabel(w, s.attestationMethodLabel(req.AttestationMethod))
+
+	if len(req.EncapsulationKey) != mlkem.EncapsulationKeySize768 {
+		http.Error(w, fmt.Sprintf("encapsulation_key must be an ML-KEM-768 key (%d bytes)", mlkem.EncapsulationKeySize768), http.StatusBadRequest)
+		return
+	}
+	if len(req.CiphertextC1s) > 0 {
+		http.Error(w, "ciphertext_c1s is not supported on this endpoint", http.StatusBadRequest)
+		return
+	}
+
+	if _, _, ok := s.authorizeSecretsRequest(w, r, &req.SecretsRequestV1); !ok {
+		return
+	}
+
+	dk, err := s.node.appPQKey(req.AppID)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to derive PQ key", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID, "error", err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+		return
+	}
+	kemCiphertext, sealedKey, err := eigenxcrypto.SealAppPQKey(req.EncapsulationKey, req.AppID, s.node.OperatorAddress, dk)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Invalid encapsulation_key: %v", err), http.StatusBadRequest)
+		return
+	}
+	encryptedKey, err := s.node.rsaEncryption.EncryptHybrid(sealedKey, req.RSAPubKeyTmp)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to encrypt PQ key", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		http.Error(w, "Encryption failed", http.StatusInternalServerError)
+		return
+	}
+
+	response := types.AppPQKeyResponse{
+		OperatorAddress: s.node.OperatorAddress.Hex(),
+		KEMCiphertext:   kemCiphertext,
+		EncryptedKey:    encryptedKey,
+	}
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(response); err != nil {
 		s.node.logger.Sugar().Errorw("Failed to encode response", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
-		http.Error(w, "Internal error", http.StatusInternalServerError)
 		return
 	}
 
-	s.node.logger.Sugar().Infow("Successfully served secrets", "operator_address", s.node.OperatorAddress.Hex(), "app_id", req.AppID)
+	s.node.logger.Sugar().Infow("Served PQ decapsulation key",
+		"operator_address", s.node.OperatorAddress.Hex(),
+		"app_id", req.AppID)
 }
 
 // handleDKGCommitment handles DKG commitment messages
@@ -495,12 +820,13 @@ func (s *Server) handleDKGCommitment(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate authenticated message (accepts broadcast messages)
-	authMsg, senderPeer, _, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
 	if err != nil {
 		s.node.logger.Sugar().Warnw("DKG commitment authentication failed", "error", err)
 		http.Error(w, "Authentication failed", http.StatusUnauthorized)
 		return
 	}
+	defer trace.SpanFromContext(ctx).End()
 
 	// Decode commitment message
 	var commitMsg types.CommitmentMessage
@@ -530,6 +856,7 @@ func (s *Server) handleDKGCommitment(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	session.recordSignedCommitments(senderAddr, authMsg)
 
 	s.node.logger.Sugar().Debugw("Received authenticated DKG commitments",
 		"operator_address", s.node.OperatorAddress.Hex(),
@@ -549,12 +876,13 @@ func (s *Server) handleDKGShare(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate authenticated message
-	authMsg, senderPeer, _, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
 	if err != nil {
 		s.node.logger.Sugar().Warnw("DKG share authentication failed", "error", err)
 		http.Error(w, "Authentication failed", http.StatusUnauthorized)
 		return
 	}
+	defer trace.SpanFromContext(ctx).End()
 
 	// Decode share message
 	var shareMsg types.ShareMessage
@@ -573,15 +901,20 @@ func (s *Server) handleDKGShare(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate share is present
-	if shareMsg.Share == nil {
-		http.Error(w, "share is required", http.StatusBadRequest)
+	senderAddr := senderPeer.OperatorAddress
+
+	// Decrypt the share; the ciphertext must be bound to this sender, this node, this
+	// key and this session, so a share replayed from another session is rejected here.
+	share, err := s.node.decryptShareMessage(encryption.ShareLabelDKG, senderAddr, session, &shareMsg)
+	if err != nil {
+		s.node.logger.Sugar().Warnw("Failed to decrypt DKG share",
+			"from", senderPeer.OperatorAddress.Hex(),
+			"session_timestamp", shareMsg.SessionTimestamp,
+			"error", err)
+		http.Error(w, "Invalid encrypted share", http.StatusBadRequest)
 		return
 	}
 
-	senderAddr := senderPeer.OperatorAddress
-	share := types.DeserializeFr(shareMsg.Share)
-
 	// Store share in session (handles duplicate detection and completion signaling)
 	if err := session.HandleReceivedShare(senderAddr, share); err != nil {
 		s.node.logger.Sugar().Warnw("Failed to store share",
@@ -590,6 +923,7 @@ func (s *Server) handleDKGShare(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	session.recordSignedShare(senderAddr, authMsg)
 
 	s.node.logger.Sugar().Debugw("Received authenticated DKG share",
 		"operator_address", s.node.OperatorAddress.Hex(),
@@ -608,12 +942,13 @@ func (s *Server) handleDKGAck(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate authenticated message
-	authMsg, senderPeer, _, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
 	if err != nil {
 		s.node.logger.Sugar().Warnw("DKG acknowledgement authentication failed", "error", err)
 		http.Error(w, "Authentication failed", http.StatusUnauthorized)
 		return
 	}
+	defer trace.SpanFromContext(ctx).End()
 
 	// Decode acknowledgement message
 	var ackMsg types.AcknowledgementMessage
@@ -662,6 +997,167 @@ func (s *Server) handleDKGAck(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+// handleDKGComplaint handles an operator's complaint message for a DKG session. Complaints
+// against this node are justified immediately by revealing the disputed share.
+func (s *Server) handleDKGComplaint(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	if err != nil {
+		s.node.logger.Sugar().Warnw("DKG complaint authentication failed", "error", err)
+		http.Error(w, "Authentication failed", http.StatusUnauthorized)
+		return
+	}
+	defer trace.SpanFromContext(ctx).End()
+
+	var complaintMsg types.ComplaintMessage
+	if err := json.Unmarshal(authMsg.Payload, &complaintMsg); err != nil {
+		http.Error(w, "Failed to parse complaint message", http.StatusBadRequest)
+		return
+	}
+
+	session := s.node.waitForSession(complaintMsg.SessionTimestamp, 5*time.Second)
+	if session == nil {
+		s.node.logger.Sugar().Warnw("Session not created within timeout",
+			"session_timestamp", complaintMsg.SessionTimestamp,
+			"from", senderPeer.OperatorAddress.Hex())
+		http.Error(w, "Session timeout", http.StatusServiceUnavailable)
+		return
+	}
+
+	// A complaint is only useful to peers if it is individually attributable, so one bad
+	// entry rejects the whole message.
+	for _, c := range complaintMsg.Complaints {
+		if err := verifyComplaint(senderPeer, s.node.KeyID, complaintMsg.SessionTimestamp, c); err != nil {
+			s.node.logger.Sugar().Warnw("Invalid DKG complaint",
+				"from", senderPeer.OperatorAddress.Hex(),
+				"error", err)
+			http.Error(w, "Invalid complaint", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if err := session.HandleReceivedComplaints(senderPeer.OperatorAddress, complaintMsg.Complaints); err != nil {
+		s.node.logger.Sugar().Warnw("Failed to store complaints",
+			"from", senderPeer.OperatorAddress.Hex(),
+			"error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	for _, c := range complaintMsg.Complaints {
+		s.node.logger.Sugar().Warnw("Received DKG complaint",
+			"operator_address", s.node.OperatorAddress.Hex(),
+			"complainer_address", c.ComplainerAddress.Hex(),
+			"dealer_address", c.DealerAddress.Hex(),
+			"reason", c.Reason,
+			"session_timestamp", complaintMsg.SessionTimestamp)
+		if c.DealerAddress == s.node.OperatorAddress {
+			go s.node.justifyComplaint(context.WithoutCancel(ctx), session, c)
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleDKGJustification handles a dealer's answer to a complaint. A revealed share is
+// checked against the dealer's commitments when the complaint round closes, not here, so
+// a bad reveal still counts as the dealer's (failed) answer. Only the commitments the
+// dealer broadcast are ever used for that check.
+func (s *Server) handleDKGJustification(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	if err != nil {
+		s.node.logger.Sugar().Warnw("DKG justification authentication failed", "error", err)
+		http.Error(w, "Authentication failed", http.StatusUnauthorized)
+		return
+	}
+	defer trace.SpanFromContext(ctx).End()
+
+	var justMsg types.JustificationMessage
+	if err := json.Unmarshal(authMsg.Payload, &justMsg); err != nil {
+		http.Error(w, "Failed to parse justification message", http.StatusBadRequest)
+		return
+	}
+	if (justMsg.Share == nil) == (len(justMsg.EncryptedShare) == 0) {
+		http.Error(w, "exactly one of share or encryptedShare is required", http.StatusBadRequest)
+		return
+	}
+
+	session := s.node.getSession(justMsg.SessionTimestamp)
+	if session == nil {
+		http.Error(w, "Session not found", http.StatusNotFound)
+		return
+	}
+
+	dealerAddr := senderPeer.OperatorAddress
+	s.node.openJustification(session, dealerAddr, &justMsg)
+
+	s.node.logger.Sugar().Infow("Received DKG justification",
+		"operator_address", s.node.OperatorAddress.Hex(),
+		"dealer_address", dealerAddr.Hex(),
+		"complainer_address", justMsg.ComplainerAddress.Hex(),
+		"revealed", justMsg.Share != nil,
+		"session_timestamp", justMsg.SessionTimestamp)
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleDKGQualifiedSet handles the qualified dealer set an operator announces when its
+// DKG complaint round closes.
+func (s *Server) handleDKGQualifiedSet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	if err != nil {
+		s.node.logger.Sugar().Warnw("DKG qualified set authentication failed", "error", err)
+		http.Error(w, "Authentication failed", http.StatusUnauthorized)
+		return
+	}
+	defer trace.SpanFromContext(ctx).End()
+
+	var qualMsg types.QualifiedSetMessage
+	if err := json.Unmarshal(authMsg.Payload, &qualMsg); err != nil {
+		http.Error(w, "Failed to parse qualified set message", http.StatusBadRequest)
+		return
+	}
+
+	session := s.node.waitForSession(qualMsg.SessionTimestamp, 5*time.Second)
+	if session == nil {
+		s.node.logger.Sugar().Warnw("Session not created within timeout",
+			"session_timestamp", qualMsg.SessionTimestamp,
+			"from", senderPeer.OperatorAddress.Hex())
+		http.Error(w, "Session timeout", http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := session.HandleReceivedQualifiedSet(senderPeer.OperatorAddress, qualMsg.QualifiedDealers); err != nil {
+		s.node.logger.Sugar().Warnw("Failed to store qualified set",
+			"from", senderPeer.OperatorAddress.Hex(),
+			"error", err)
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.node.logger.Sugar().Debugw("Received DKG qualified set",
+		"operator_address", s.node.OperatorAddress.Hex(),
+		"from_address", senderPeer.OperatorAddress.Hex(),
+		"qualified_dealers", len(qualMsg.QualifiedDealers),
+		"session_timestamp", qualMsg.SessionTimestamp)
+
+	w.WriteHeader(http.StatusOK)
+}
+
 // handleReshareCommitment handles reshare commitment messages
 func (s *Server) handleReshareCommitment(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -670,12 +1166,13 @@ func (s *Server) handleReshareCommitment(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Validate authenticated message
-	authMsg, senderPeer, _, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
 	if err != nil {
 		s.node.logger.Sugar().Warnw("Reshare commitment authentication failed", "error", err)
 		http.Error(w, "Authentication failed", http.StatusUnauthorized)
 		return
 	}
+	defer trace.SpanFromContext(ctx).End()
 
 	// Decode commitment message
 	var commitMsg types.CommitmentMessage
@@ -706,6 +1203,7 @@ func (s *Server) handleReshareCommitment(w http.ResponseWriter, r *http.Request)
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	session.recordSignedCommitments(senderAddr, authMsg)
 
 	s.node.logger.Sugar().Debugw("Received reshare commitments",
 		"operator_address", s.node.OperatorAddress.Hex(),
@@ -723,12 +1221,13 @@ func (s *Server) handleReshareShare(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate authenticated message
-	authMsg, senderPeer, _, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
 	if err != nil {
 		s.node.logger.Sugar().Warnw("Reshare share authentication failed", "error", err)
 		http.Error(w, "Authentication failed", http.StatusUnauthorized)
 		return
 	}
+	defer trace.SpanFromContext(ctx).End()
 
 	// Decode share message
 	var shareMsg types.ShareMessage
@@ -747,15 +1246,19 @@ func (s *Server) handleReshareShare(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Validate share is present
-	if shareMsg.Share == nil {
-		http.Error(w, "share is required", http.StatusBadRequest)
+	senderAddr := senderPeer.OperatorAddress
+
+	// Decrypt the share (bound to this sender, this node, this key and this session)
+	share, err := s.node.decryptShareMessage(encryption.ShareLabelReshare, senderAddr, session, &shareMsg)
+	if err != nil {
+		s.node.logger.Sugar().Warnw("Failed to decrypt reshare share",
+			"from", senderPeer.OperatorAddress.Hex(),
+			"session_timestamp", shareMsg.SessionTimestamp,
+			"error", err)
+		http.Error(w, "Invalid encrypted share", http.StatusBadRequest)
 		return
 	}
 
-	senderAddr := senderPeer.OperatorAddress
-	share := types.DeserializeFr(shareMsg.Share)
-
 	// Store share in session
 	if err := session.HandleReceivedShare(senderAddr, share); err != nil {
 		s.node.logger.Sugar().Warnw("Failed to store reshare share",
@@ -764,6 +1267,7 @@ func (s *Server) handleReshareShare(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, err.Error(), http.StatusBadRequest)
 		return
 	}
+	session.recordSignedShare(senderAddr, authMsg)
 
 	s.node.logger.Sugar().Debugw("Received reshare share",
 		"operator_address", s.node.OperatorAddress.Hex(),
@@ -782,12 +1286,13 @@ func (s *Server) handleReshareShareRequest(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	authMsg, senderPeer, _, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
 	if err != nil {
 		s.node.logger.Sugar().Warnw("Reshare share-request authentication failed", "error", err)
 		http.Error(w, "Authentication failed", http.StatusUnauthorized)
 		return
 	}
+	defer trace.SpanFromContext(ctx).End()
 
 	var reqMsg types.ShareRequestMessage
 	if err := json.Unmarshal(authMsg.Payload, &reqMsg); err != nil {
@@ -795,6 +1300,14 @@ func (s *Server) handleReshareShareRequest(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	// The requester's encryption key is part of its signed request, so it is
+	// authenticated along with the request itself.
+	requesterKey, err := encryption.ParseSharePublicKey(reqMsg.EncryptionPublicKey)
+	if err != nil {
+		http.Error(w, "Invalid encryption public key", http.StatusBadRequest)
+		return
+	}
+
 	// Serve only the requester's own share (the authenticated sender), never another
 	// operator's. The requester address is the authenticated identity, not a field the
 	// caller can spoof.
@@ -807,11 +1320,16 @@ func (s *Server) handleReshareShareRequest(w http.ResponseWriter, r *http.Reques
 	// the round and tore down our session. Retained shares (docs/012 Layer 3a) let that
 	// fetch succeed instead of 503-ing the peer into a corrupting version split.
 	var share *fr.Element
+	var operators []common.Address
 	if session := s.node.waitForSession(reqMsg.SessionTimestamp, 5*time.Second); session != nil {
 		share = session.GetMyGeneratedShareFor(requester)
+		session.mu.RLock()
+		operators = sessionParticipantIDs(session.Operators)
+		session.mu.RUnlock()
 	}
 	if share == nil {
 		share = s.node.getRetainedGeneratedShare(reqMsg.SessionTimestamp, requester)
+		operators = s.node.retainedShareRecipients(reqMsg.SessionTimestamp)
 	}
 	if share == nil {
 		s.node.logger.Sugar().Warnw("No generated share to serve for requester",
@@ -822,11 +1340,26 @@ func (s *Server) handleReshareShareRequest(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	encryptedShare, err := encryption.EncryptShare(requesterKey, share, encryption.ShareBinding{
+		Label:            encryption.ShareLabelReshare,
+		KeyID:            s.node.KeyID,
+		From:             s.node.OperatorAddress,
+		To:               requester,
+		SessionTimestamp: reqMsg.SessionTimestamp,
+		Operators:        operators,
+	})
+	if err != nil {
+		s.node.logger.Sugar().Warnw("Failed to encrypt share response", "error", err)
+		http.Error(w, "Failed to encrypt share response", http.StatusInternalServerError)
+		return
+	}
+
 	respMsg := types.ShareMessage{
 		FromOperatorAddress: s.node.OperatorAddress,
 		ToOperatorAddress:   requester,
+		KeyID:               s.node.KeyID,
 		SessionTimestamp:    reqMsg.SessionTimestamp,
-		Share:               types.SerializeFr(share),
+		EncryptedShare:      encryptedShare,
 	}
 	respBytes, err := json.Marshal(respMsg)
 	if err != nil {
@@ -857,12 +1390,13 @@ func (s *Server) handleReshareAck(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Validate authenticated message
-	authMsg, senderPeer, _, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
 	if err != nil {
 		s.node.logger.Sugar().Warnw("Reshare ack authentication failed", "error", err)
 		http.Error(w, "Authentication failed", http.StatusUnauthorized)
 		return
 	}
+	defer trace.SpanFromContext(ctx).End()
 
 	// Decode acknowledgement message
 	var ackMsg types.AcknowledgementMessage
@@ -909,46 +1443,6 @@ func (s *Server) handleReshareAck(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
-// hasContainerPolicy reports whether the on-chain ContainerPolicy pins any
-// non-empty field. Used to detect releases that rely on policy enforcement
-// when the request authenticates via a method (e.g. eigenx-snp) that does not
-// surface ContainerPolicy in its claims.
-func hasContainerPolicy(p types.ContainerPolicy) bool {
-	return len(p.Args) > 0 || len(p.CmdOverride) > 0 || len(p.Env) > 0 || len(p.EnvOverride) > 0 || p.RestartPolicy != ""
-}
-
-// validateContainerPolicy checks that the container execution fields in the JWT claims
-// match the on-chain policy registered by the app developer. Fields with zero/empty
-// values in the policy are not enforced, allowing developers to restrict only the
-// fields they care about.
-func validateContainerPolicy(claims types.ContainerPolicy, policy types.ContainerPolicy) error {
-	if len(policy.Args) > 0 && !slices.Equal(claims.Args, policy.Args) {
-		return fmt.Errorf("args mismatch: expected %v, got %v", policy.Args, claims.Args)
-	}
-
-	if len(policy.CmdOverride) > 0 && !slices.Equal(claims.CmdOverride, policy.CmdOverride) {
-		return fmt.Errorf("cmd_override mismatch: expected %v, got %v", policy.CmdOverride, claims.CmdOverride)
-	}
-
-	for key, expectedVal := range policy.Env {
-		if actualVal, ok := claims.Env[key]; !ok || actualVal != expectedVal {
-			return fmt.Errorf("env mismatch for key %q: expected %q, got %q", key, expectedVal, actualVal)
-		}
-	}
-
-	for key, expectedVal := range policy.EnvOverride {
-		if actualVal, ok := claims.EnvOverride[key]; !ok || actualVal != expectedVal {
-			return fmt.Errorf("env_override mismatch for key %q: expected %q, got %q", key, expectedVal, actualVal)
-		}
-	}
-
-	if policy.RestartPolicy != "" && claims.RestartPolicy != policy.RestartPolicy {
-		return fmt.Errorf("restart_policy mismatch: expected %q, got %q", policy.RestartPolicy, claims.RestartPolicy)
-	}
-
-	return nil
-}
-
 // handleAppSign handles partial signature requests from KMS clients.
 // NOTE: This endpoint is intentionally client-facing (not node-to-node) and does not
 // use validateAuthenticatedMessage. It is called by the kmsClient CLI to collect partial
@@ -981,8 +1475,22 @@ func (s *Server) handleAppSign(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "app not allowed", http.StatusForbidden)
 		return
 	}
+	if httpStatus, err := s.verifyAppKeyBinding(req.AppID); err != nil {
+		s.node.logger.Sugar().Warnw("App sign request rejected: app not bound to key",
+			"operator_address", s.node.OperatorAddress.Hex(),
+			"app_id", req.AppID,
+			"error", err)
+		http.Error(w, err.Error(), httpStatus)
+		return
+	}
 
-	partialSig, err := s.node.SignAppID(req.AppID, req.AttestationTime)
+	keyVersion, err := s.node.keyVersionFor(req.Generation, req.AttestationTime)
+	if errors.Is(err, errGenerationNotHeld) || errors.Is(err, errNoVersionAtTime) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	partialSig, err := s.node.signAppIDWithVersion(req.AppID, keyVersion)
 	if err != nil {
 		s.node.logger.Sugar().Errorw("Failed to compute partial signature for app",
 			"operator_address", s.node.OperatorAddress.Hex(),
@@ -1007,6 +1515,86 @@ func (s *Server) handleAppSign(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleAppDecryptShare handles the /app/decrypt-share endpoint: an attested app gets
+// this node's decryption shares of the ciphertexts it presents. Unlike the partial
+// signature of /app/sign, a decryption share of a ciphertext's C1 only helps decrypt
+// that one ciphertext, and the request's extra_data binds the C1s into the attestation.
+// The shares are encrypted to the request's ephemeral RSA key, as on /secrets.
+func (s *Server) handleAppDecryptShare(w http.ResponseWriter, r *http.Request) {
+	setAttestationMethodLabel(w, unknownAttestationMethod)
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	const maxDecryptShareBodyBytes = 2*types.MaxAttestationSize + 2*types.MaxExtraDataSize + 64*1024
+	r.Body = http.MaxBytesReader(w, r.Body, int64(maxDecryptShareBodyBytes))
+
+	var req types.AppDecryptShareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to parse request: %v", err), http.StatusBadRequest)
+		return
+	}
+	setAttestationMethodLabel(w, s.attestationMethodLabel(req.AttestationMethod))
+
+	if len(req.CiphertextC1s) == 0 {
+		http.Error(w, "ciphertext_c1s is required", http.StatusBadRequest)
+		return
+	}
+	if len(req.CiphertextC1s) > types.MaxCiphertextsPerRequest {
+		http.Error(w, fmt.Sprintf("ciphertext_c1s exceeds %d ciphertexts", types.MaxCiphertextsPerRequest), http.StatusBadRequest)
+		return
+	}
+
+	_, keyVersion, ok := s.authorizeSecretsRequest(w, r, &req.SecretsRequestV1)
+	if !ok {
+		return
+	}
+
+	_, shareSpan := tracing.Tracer().Start(r.Context(), "secrets.decrypt_share", trace.WithAttributes(
+		attribute.Int64("kms.key_version", keyVersion.Version),
+		attribute.Int("kms.ciphertexts", len(req.CiphertextC1s)),
+	))
+	shares, err := s.node.decryptionSharesWithVersion(req.AppID, req.CiphertextC1s, keyVersion)
+	tracing.End(shareSpan, err)
+	if err != nil {
+		s.node.logger.Sugar().Warnw("Failed to compute decryption shares for app",
+			"operator_address", s.node.OperatorAddress.Hex(),
+			"app_id", req.AppID,
+			"error", err)
+		http.Error(w, fmt.Sprintf("Invalid ciphertext_c1s: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	payloadBytes, err := json.Marshal(types.SecretsDecryptionShares{Shares: shares})
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to serialize decryption shares", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+		return
+	}
+	encryptedShares, err := s.node.rsaEncryption.EncryptHybrid(payloadBytes, req.RSAPubKeyTmp)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to encrypt decryption shares", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		http.Error(w, "Encryption failed", http.StatusInternalServerError)
+		return
+	}
+
+	resp := types.AppDecryptShareResponse{
+		OperatorAddress:           s.node.OperatorAddress.Hex(),
+		EncryptedDecryptionShares: encryptedShares,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		s.node.logger.Sugar().Errorw("Failed to encode app decrypt share response", "operator_address", s.node.OperatorAddress.Hex(), "error", err)
+		return
+	}
+
+	s.node.logger.Sugar().Infow("Served decryption shares",
+		"operator_address", s.node.OperatorAddress.Hex(),
+		"app_id", req.AppID,
+		"ciphertexts", len(shares))
+}
+
 // handleGetCommitments handles requests for public key commitments
 func (s *Server) handleGetCommitments(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -1014,20 +1602,45 @@ func (s *Server) handleGetCommitments(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Get active key version
-	activeVersion := s.node.keyStore.GetActiveVersion()
+	// Get active key version, or the version /app/sign uses for a given attestation
+	// time and generation so clients can verify partial signatures made with it
+	var attestationTime int64
+	if at := r.URL.Query().Get("attestationTime"); at != "" {
+		var err error
+		attestationTime, err = strconv.ParseInt(at, 10, 64)
+		if err != nil {
+			http.Error(w, "Invalid attestationTime", http.StatusBadRequest)
+			return
+		}
+	}
+	generation, err := parseGenerationParam(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	activeVersion, err := s.node.keyVersionFor(generation, attestationTime)
+	if errors.Is(err, errGenerationNotHeld) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
 	if activeVersion == nil {
 		http.Error(w, "No active key version", http.StatusServiceUnavailable)
 		return
 	}
 
-	// Return commitments, operator address, and pre-computed master public key
+	// Return commitments, operator address, pre-computed master public key, the
+	// group commitments clients verify partial signatures against and the dealer
+	// commitments they check the master public key against on-chain
 	response := map[string]interface{}{
-		"operatorAddress": s.node.OperatorAddress.Hex(),
-		"commitments":     activeVersion.Commitments,
-		"masterPublicKey": activeVersion.MasterPublicKey,
-		"version":         activeVersion.Version,
-		"isActive":        activeVersion.IsActive,
+		"operatorAddress":   s.node.OperatorAddress.Hex(),
+		"commitments":       activeVersion.Commitments,
+		"masterPublicKey":   activeVersion.MasterPublicKey,
+		"groupCommitments":  activeVersion.GroupCommitments,
+		"dealerCommitments": activeVersion.DealerCommitments,
+		"sourceVersion":     activeVersion.SourceVersion,
+		"version":           activeVersion.Version,
+		"generation":        activeVersion.Generation,
+		"isActive":          activeVersion.IsActive,
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -1040,6 +1653,40 @@ func (s *Server) handleGetCommitments(w http.ResponseWriter, r *http.Request) {
 	s.node.logger.Sugar().Debugw("Served public key commitments", "operator_address", s.node.OperatorAddress.Hex())
 }
 
+// handleShareEncryptionKey serves this node's share encryption public key for the session
+// named by the session query parameter, signed by its transport key so peers can verify
+// it against the registered operator key before encrypting DKG/reshare shares to it. It
+// only announces for sessions this node is running, so an announcement cannot be
+// collected ahead of time for a session to come.
+func (s *Server) handleShareEncryptionKey(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	sessionTimestamp, err := strconv.ParseInt(r.URL.Query().Get("session"), 10, 64)
+	if err != nil || sessionTimestamp <= 0 {
+		http.Error(w, "session query parameter is required", http.StatusBadRequest)
+		return
+	}
+	if s.node.waitForSession(sessionTimestamp, 5*time.Second) == nil {
+		http.Error(w, "Unknown session", http.StatusNotFound)
+		return
+	}
+
+	data, err := s.node.shareEncryptionKeyAnnouncement(sessionTimestamp)
+	if err != nil {
+		s.node.logger.Sugar().Errorw("Failed to build share encryption key announcement", "error", err)
+		http.Error(w, "Internal error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if _, err := w.Write(data); err != nil {
+		s.node.logger.Sugar().Warnw("Failed to write share encryption key response", "error", err)
+	}
+}
+
 // handleCommitmentBroadcast handles authenticated commitment broadcasts with merkle proofs (Phase 5)
 func (s *Server) handleCommitmentBroadcast(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -1048,12 +1695,13 @@ func (s *Server) handleCommitmentBroadcast(w http.ResponseWriter, r *http.Reques
 	}
 
 	// Validate authentication
-	authMsg, senderPeer, _, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
+	authMsg, senderPeer, ctx, err := s.validateAuthenticatedMessage(r, s.node.OperatorAddress)
 	if err != nil {
 		s.node.logger.Sugar().Warnw("Authentication failed for commitment broadcast", "error", err)
 		http.Error(w, "Authentication failed", http.StatusUnauthorized)
 		return
 	}
+	defer trace.SpanFromContext(ctx).End()
 
 	var msg types.CommitmentBroadcastMessage
 	if err := json.Unmarshal(authMsg.Payload, &msg); err != nil {
@@ -1081,6 +1729,13 @@ func (s *Server) handleCommitmentBroadcast(w http.ResponseWriter, r *http.Reques
 		"proof_length", len(msg.Broadcast.MerkleProof),
 	)
 
+	// Check the ack transcript for equivocation before verifying our own ack, so the
+	// evidence is kept even when verification fails. Only the dealer's own broadcast
+	// binds it to the acks it carries.
+	if msg.Broadcast.FromOperatorAddress == senderPeer.OperatorAddress {
+		s.node.checkBroadcastEquivocation(session, authMsg, msg.Broadcast)
+	}
+
 	// Phase 6: Verify the broadcast against on-chain commitment
 	contractRegistryAddr := s.node.commitmentRegistryAddress
 	if err := s.node.VerifyOperatorBroadcast(msg.SessionTimestamp, msg.Broadcast, contractRegistryAddr); err != nil {
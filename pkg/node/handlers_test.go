@@ -2,13 +2,20 @@ package node
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	eigenxcrypto "github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -54,6 +61,42 @@ func TestCommitmentBroadcastMessage_Serialization(t *testing.T) {
 	require.Equal(t, msg.Broadcast.SessionTimestamp, decoded.Broadcast.SessionTimestamp)
 }
 
+// Every key of a server runs its sessions at the same timestamps, so a message is only
+// accepted by the key it names; a message naming no key is for the default key.
+func TestValidateAuthenticatedMessage_BindsKeyID(t *testing.T) {
+	senderAddr := common.HexToAddress("0x000000000000000000000000000000000000000A")
+	recipientAddr := common.HexToAddress("0x000000000000000000000000000000000000000B")
+	sender, senderPeer := newShareEncryptionTestNode(t, senderAddr, 1)
+	recipient, recipientPeer := newShareEncryptionTestNode(t, recipientAddr, 2)
+	openShareSession(t, 100, []*peering.OperatorSetPeer{senderPeer, recipientPeer}, recipient)
+	server := &Server{node: recipient}
+
+	validate := func(keyID string) error {
+		payload, err := json.Marshal(types.CommitmentMessage{
+			FromOperatorAddress: senderAddr,
+			ToOperatorAddress:   recipientAddr,
+			KeyID:               keyID,
+			SessionTimestamp:    100,
+		})
+		require.NoError(t, err)
+		authMsg, err := sender.transportSigner.CreateAuthenticatedMessage(payload)
+		require.NoError(t, err)
+		body, err := json.Marshal(authMsg)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/dkg/commitment", bytes.NewReader(body))
+		_, _, _, err = server.validateAuthenticatedMessage(req, recipientAddr)
+		return err
+	}
+
+	assert.NoError(t, validate(types.DefaultKeyID))
+	assert.NoError(t, validate(""))
+	assert.ErrorContains(t, validate("tenant-b"), "key")
+
+	recipient.KeyID = "tenant-b"
+	assert.NoError(t, validate("tenant-b"))
+	assert.Error(t, validate(""))
+}
+
 func TestHandleAppSign_Allowlist(t *testing.T) {
 	makeRequest := func(server *Server, appID string) *httptest.ResponseRecorder {
 		t.Helper()
@@ -105,3 +148,137 @@ func TestHandleAppSign_Allowlist(t *testing.T) {
 		assert.NotEqual(t, http.StatusForbidden, w.Code)
 	})
 }
+
+func TestHandleAppDecryptShare(t *testing.T) {
+	// The fixture's key share is 42, so its public key share is 42·G2
+	masterPubKey, err := eigenxcrypto.ScalarMulG2(eigenxcrypto.G2Generator, new(fr.Element).SetInt64(42))
+	require.NoError(t, err)
+	ciphertext, err := eigenxcrypto.EncryptForApp("test-app", *masterPubKey, []byte("secret"))
+	require.NoError(t, err)
+	c1, err := eigenxcrypto.CiphertextC1(ciphertext)
+	require.NoError(t, err)
+	privKeyPEM, pubKeyPEM, err := encryption.GenerateKeyPair(2048)
+	require.NoError(t, err)
+
+	newFixture := func(t *testing.T) *testSecretsFixture {
+		f := newTestSecretsFixture(t)
+		f.contractCallerStub.AddTestRelease("test-app", &types.Release{ImageDigest: "sha256:test123", Timestamp: time.Now().Unix()})
+		return f
+	}
+	// attestedRequest returns a gcp stub attestation over extraData for c1s
+	attestedRequest := func(t *testing.T, c1s []types.G2Point, extraData []byte) types.AppDecryptShareRequest {
+		t.Helper()
+		h := sha256.Sum256(append(bytes.Clone(pubKeyPEM), extraData...))
+		attestationBytes, err := json.Marshal(types.AttestationClaims{
+			AppID:       "test-app",
+			ImageDigest: "sha256:test123",
+			IssuedAt:    time.Now().Unix(),
+			PublicKey:   pubKeyPEM,
+			Nonce:       hex.EncodeToString(h[:]),
+		})
+		require.NoError(t, err)
+		return types.AppDecryptShareRequest{SecretsRequestV1: types.SecretsRequestV1{
+			AppID:             "test-app",
+			AttestationMethod: "gcp",
+			Attestation:       attestationBytes,
+			RSAPubKeyTmp:      pubKeyPEM,
+			AttestationTime:   time.Now().Unix(),
+			ExtraData:         extraData,
+			CiphertextC1s:     c1s,
+		}}
+	}
+	makeRequest := func(server *Server, req types.AppDecryptShareRequest) *httptest.ResponseRecorder {
+		t.Helper()
+		reqBody, _ := json.Marshal(req)
+		httpReq := httptest.NewRequest(http.MethodPost, "/app/decrypt-share", bytes.NewBuffer(reqBody))
+		w := httptest.NewRecorder()
+		server.handleAppDecryptShare(w, httpReq)
+		return w
+	}
+
+	t.Run("returns verifiable shares", func(t *testing.T) {
+		f := newFixture(t)
+		c1s := []types.G2Point{*c1, *c1}
+
+		w := makeRequest(f.server, attestedRequest(t, c1s, append(eigenxcrypto.CiphertextBinding(c1s), "caller data"...)))
+		require.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		var resp types.AppDecryptShareResponse
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&resp))
+		assert.Equal(t, f.node.OperatorAddress.Hex(), resp.OperatorAddress)
+
+		payloadBytes, err := encryption.NewRSAEncryption().DecryptHybrid(resp.EncryptedDecryptionShares, privKeyPEM)
+		require.NoError(t, err)
+		var payload types.SecretsDecryptionShares
+		require.NoError(t, json.Unmarshal(payloadBytes, &payload))
+		require.Len(t, payload.Shares, 2)
+		assert.Nil(t, payload.EnvShare)
+		for _, share := range payload.Shares {
+			ok, err := eigenxcrypto.VerifyDecryptionShare("test-app", *c1, share, *masterPubKey)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		}
+	})
+
+	t.Run("requires attestation", func(t *testing.T) {
+		f := newFixture(t)
+		c1s := []types.G2Point{*c1}
+
+		req := attestedRequest(t, c1s, eigenxcrypto.CiphertextBinding(c1s))
+		req.AttestationMethod = ""
+		w := makeRequest(f.server, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+
+		req = attestedRequest(t, c1s, eigenxcrypto.CiphertextBinding(c1s))
+		req.AttestationMethod = "unregistered"
+		w = makeRequest(f.server, req)
+		assert.Equal(t, http.StatusUnauthorized, w.Code, w.Body.String())
+
+		// An attestation of another image is refused by the release checks
+		req = attestedRequest(t, c1s, eigenxcrypto.CiphertextBinding(c1s))
+		req.Attestation = []byte("not the release image")
+		w = makeRequest(f.server, req)
+		assert.Equal(t, http.StatusForbidden, w.Code, w.Body.String())
+	})
+
+	t.Run("ties the ciphertexts to the attestation", func(t *testing.T) {
+		f := newFixture(t)
+		other, err := eigenxcrypto.EncryptForApp("test-app", *masterPubKey, []byte("other secret"))
+		require.NoError(t, err)
+		otherC1, err := eigenxcrypto.CiphertextC1(other)
+		require.NoError(t, err)
+
+		// An attestation for one ciphertext cannot be used to ask for another
+		req := attestedRequest(t, []types.G2Point{*c1}, eigenxcrypto.CiphertextBinding([]types.G2Point{*c1}))
+		req.CiphertextC1s = []types.G2Point{*otherC1}
+		w := makeRequest(f.server, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code, w.Body.String())
+		assert.Contains(t, w.Body.String(), "ciphertext binding")
+	})
+
+	t.Run("rejects invalid requests", func(t *testing.T) {
+		f := newFixture(t)
+
+		w := makeRequest(f.server, attestedRequest(t, nil, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		tooMany := make([]types.G2Point, types.MaxCiphertextsPerRequest+1)
+		for i := range tooMany {
+			tooMany[i] = *c1
+		}
+		w = makeRequest(f.server, attestedRequest(t, tooMany, eigenxcrypto.CiphertextBinding(tooMany)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		invalid := []types.G2Point{{CompressedBytes: make([]byte, 96)}}
+		w = makeRequest(f.server, attestedRequest(t, invalid, eigenxcrypto.CiphertextBinding(invalid)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("blocked app returns 403", func(t *testing.T) {
+		f := newFixture(t)
+		f.node.appAllowlist = map[string]bool{"allowed-app": true}
+		c1s := []types.G2Point{*c1}
+
+		w := makeRequest(f.server, attestedRequest(t, c1s, eigenxcrypto.CiphertextBinding(c1s)))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+}
@@ -0,0 +1,132 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// Readiness check names reported by /readyz.
+const (
+	readyCheckActiveKeyVersion = "active_key_version"
+	readyCheckPersistence      = "persistence"
+	readyCheckChainPoller      = "chain_poller"
+	readyCheckAutoHeal         = "auto_heal"
+)
+
+// chainPollerStallFactor is how many poll intervals may pass without a block before
+// the chain poller counts as stalled; minChainPollerStallAfter bounds it below so
+// fast dev chains do not flap on a slow RPC response.
+const (
+	chainPollerStallFactor   = 30
+	minChainPollerStallAfter = 2 * time.Minute
+)
+
+// chainPollerStallAfter returns how long the node may go without a block on chainID
+// before readiness fails.
+func chainPollerStallAfter(chainID config.ChainId) time.Duration {
+	d := chainPollerStallFactor * config.GetDefaultPollerIntervalForChainId(chainID)
+	if d < minChainPollerStallAfter {
+		return minChainPollerStallAfter
+	}
+	return d
+}
+
+// recordBlockSeen notes that the chain poller delivered a block.
+func (n *Node) recordBlockSeen() {
+	n.lastBlockSeen.Store(time.Now().UnixNano())
+}
+
+// readinessChecks evaluates everything that must hold for the node to serve
+// /secrets and /app/sign. Every check runs, so the response explains all failures.
+func (n *Node) readinessChecks(now time.Time) []types.HealthCheck {
+	checks := make([]types.HealthCheck, 0, 4)
+
+	if active := n.keyStore.GetActiveVersion(); active == nil || active.PrivateShare == nil {
+		checks = append(checks, types.HealthCheck{Name: readyCheckActiveKeyVersion, Message: "no active key version"})
+	} else {
+		checks = append(checks, types.HealthCheck{Name: readyCheckActiveKeyVersion, Healthy: true,
+			Message: fmt.Sprintf("version %d", active.Version)})
+	}
+
+	if err := n.persistence.HealthCheck(); err != nil {
+		checks = append(checks, types.HealthCheck{Name: readyCheckPersistence, Message: err.Error()})
+	} else {
+		checks = append(checks, types.HealthCheck{Name: readyCheckPersistence, Healthy: true})
+	}
+
+	checks = append(checks, n.chainPollerCheck(now))
+
+	if n.autoHealRollback.Load() {
+		checks = append(checks, types.HealthCheck{Name: readyCheckAutoHeal,
+			Message: "active version demoted by auto-heal; waiting for a validated reshare"})
+	} else {
+		checks = append(checks, types.HealthCheck{Name: readyCheckAutoHeal, Healthy: true})
+	}
+
+	return checks
+}
+
+// chainPollerCheck fails when no block has arrived within chainPollerStallAfter,
+// measured from node start until the first block.
+func (n *Node) chainPollerCheck(now time.Time) types.HealthCheck {
+	started := n.startedAt.Load()
+	if started == 0 {
+		return types.HealthCheck{Name: readyCheckChainPoller, Message: "node not started"}
+	}
+	stallAfter := chainPollerStallAfter(n.ChainID)
+	last := n.lastBlockSeen.Load()
+	if last == 0 {
+		if waited := now.Sub(time.Unix(0, started)); waited > stallAfter {
+			return types.HealthCheck{Name: readyCheckChainPoller,
+				Message: fmt.Sprintf("no block received since start %s ago", waited.Round(time.Second))}
+		}
+		return types.HealthCheck{Name: readyCheckChainPoller, Healthy: true, Message: "waiting for first block"}
+	}
+	age := now.Sub(time.Unix(0, last))
+	if age > stallAfter {
+		return types.HealthCheck{Name: readyCheckChainPoller,
+			Message: fmt.Sprintf("last block received %s ago (limit %s)", age.Round(time.Second), stallAfter)}
+	}
+	return types.HealthCheck{Name: readyCheckChainPoller, Healthy: true,
+		Message: fmt.Sprintf("last block received %s ago", age.Round(time.Second))}
+}
+
+// handleHealthz is the liveness probe: it answers whenever the process can serve HTTP.
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeHealthResponse(w, []types.HealthCheck{{Name: "process", Healthy: true}})
+}
+
+// handleReadyz is the readiness probe: it fails (503) while the node cannot serve
+// /secrets or /app/sign, so load balancers route around it.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeHealthResponse(w, s.node.readinessChecks(time.Now()))
+}
+
+func writeHealthResponse(w http.ResponseWriter, checks []types.HealthCheck) {
+	resp := types.HealthResponse{Status: types.HealthStatusOK, Checks: checks}
+	code := http.StatusOK
+	for _, c := range checks {
+		if !c.Healthy {
+			resp.Status = types.HealthStatusUnavailable
+			code = http.StatusServiceUnavailable
+			break
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,134 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/keystore"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newReadyTestNode returns a started node that passes every readiness check.
+func newReadyTestNode(t *testing.T) *Node {
+	t.Helper()
+	p := memory.NewMemoryPersistence()
+	t.Cleanup(func() { _ = p.Close() })
+
+	n := &Node{
+		ChainID:     config.ChainId_EthereumAnvil,
+		keyStore:    keystore.NewKeyStore(),
+		persistence: p,
+		logger:      zap.NewNop(),
+	}
+	n.keyStore.AddVersion(&types.KeyShareVersion{
+		Version:      100,
+		PrivateShare: new(fr.Element).SetInt64(7),
+		IsActive:     true,
+	})
+	n.startedAt.Store(time.Now().UnixNano())
+	n.recordBlockSeen()
+	return n
+}
+
+func serveReadyz(t *testing.T, n *Node) (int, types.HealthResponse) {
+	t.Helper()
+	s := &Server{node: n}
+	rec := httptest.NewRecorder()
+	s.handleReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+	var resp types.HealthResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return rec.Code, resp
+}
+
+// failingChecks returns the names of the unhealthy checks.
+func failingChecks(resp types.HealthResponse) []string {
+	var names []string
+	for _, c := range resp.Checks {
+		if !c.Healthy {
+			names = append(names, c.Name)
+		}
+	}
+	return names
+}
+
+func TestHealthz(t *testing.T) {
+	s := &Server{}
+	rec := httptest.NewRecorder()
+	s.handleHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp types.HealthResponse
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	assert.Equal(t, types.HealthStatusOK, resp.Status)
+}
+
+func TestReadyz(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		code, resp := serveReadyz(t, newReadyTestNode(t))
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, types.HealthStatusOK, resp.Status)
+		assert.Len(t, resp.Checks, 4)
+	})
+
+	t.Run("no active key version", func(t *testing.T) {
+		n := newReadyTestNode(t)
+		n.keyStore = keystore.NewKeyStore()
+		code, resp := serveReadyz(t, n)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, types.HealthStatusUnavailable, resp.Status)
+		assert.Equal(t, []string{readyCheckActiveKeyVersion}, failingChecks(resp))
+	})
+
+	t.Run("persistence unhealthy", func(t *testing.T) {
+		n := newReadyTestNode(t)
+		require.NoError(t, n.persistence.Close())
+		code, resp := serveReadyz(t, n)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, []string{readyCheckPersistence}, failingChecks(resp))
+	})
+
+	t.Run("chain poller stalled", func(t *testing.T) {
+		n := newReadyTestNode(t)
+		n.lastBlockSeen.Store(time.Now().Add(-2 * chainPollerStallAfter(n.ChainID)).UnixNano())
+		code, resp := serveReadyz(t, n)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, []string{readyCheckChainPoller}, failingChecks(resp))
+	})
+
+	t.Run("auto-heal rollback pending", func(t *testing.T) {
+		n := newReadyTestNode(t)
+		n.autoHealRollback.Store(true)
+		code, resp := serveReadyz(t, n)
+		assert.Equal(t, http.StatusServiceUnavailable, code)
+		assert.Equal(t, []string{readyCheckAutoHeal}, failingChecks(resp))
+	})
+
+	t.Run("reports every failing check", func(t *testing.T) {
+		n := newReadyTestNode(t)
+		n.keyStore = keystore.NewKeyStore()
+		n.autoHealRollback.Store(true)
+		_, resp := serveReadyz(t, n)
+		assert.ElementsMatch(t, []string{readyCheckActiveKeyVersion, readyCheckAutoHeal}, failingChecks(resp))
+	})
+}
+
+func TestChainPollerCheck_BeforeFirstBlock(t *testing.T) {
+	n := &Node{ChainID: config.ChainId_EthereumAnvil}
+	now := time.Now()
+
+	assert.False(t, n.chainPollerCheck(now).Healthy, "a node that has not started is not ready")
+
+	n.startedAt.Store(now.UnixNano())
+	assert.True(t, n.chainPollerCheck(now.Add(time.Second)).Healthy, "grace period after start")
+	assert.False(t, n.chainPollerCheck(now.Add(2*chainPollerStallAfter(n.ChainID))).Healthy)
+}
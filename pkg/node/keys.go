@@ -0,0 +1,165 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// verifyAppKeyBinding checks that appID belongs to this node's key: the app's operator
+// set in the AppController must be the one that holds the key. A no-op unless the node
+// was configured with BindAppsToKey. Returns the HTTP status to fail with.
+func (s *Server) verifyAppKeyBinding(appID string) (int, error) {
+	if !s.node.bindAppsToKey {
+		return 0, nil
+	}
+	if !common.IsHexAddress(appID) {
+		return http.StatusForbidden, fmt.Errorf("app_id must be an AppController app address to be bound to key %q", s.node.KeyID)
+	}
+	operatorSetId, err := s.node.baseContractCaller.GetAppOperatorSetId(common.HexToAddress(appID), nil)
+	if err != nil {
+		return http.StatusBadGateway, fmt.Errorf("failed to look up app operator set: %w", err)
+	}
+	if operatorSetId != s.node.OperatorSetId {
+		return http.StatusForbidden, fmt.Errorf("app is bound to operator set %d, not key %q (operator set %d)",
+			operatorSetId, s.node.KeyID, s.node.OperatorSetId)
+	}
+	return 0, nil
+}
+
+// KeyRouter serves the routes of several nodes on one listener, one node per key. A
+// node's routes are mounted under /v1/keys/{id}/; the default key's routes are also
+// mounted at the root, where single-key clients and peers expect them. Liveness and
+// readiness are answered for the process as a whole.
+type KeyRouter struct {
+	nodes      map[string]*Node
+	keyIDs     []string // sorted
+	httpServer *http.Server
+	logger     *zap.Logger
+}
+
+// NewKeyRouter creates a router serving nodes on port. Each node must hold a distinct
+// key and be configured with SharedListener.
+func NewKeyRouter(port int, nodes []*Node, l *zap.Logger) (*KeyRouter, error) {
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("at least one node is required")
+	}
+	kr := &KeyRouter{
+		nodes:  make(map[string]*Node, len(nodes)),
+		logger: l,
+	}
+	for _, n := range nodes {
+		if !n.sharedListener {
+			return nil, fmt.Errorf("node for key %q listens on its own port", n.KeyID)
+		}
+		if _, exists := kr.nodes[n.KeyID]; exists {
+			return nil, fmt.Errorf("duplicate key ID %q", n.KeyID)
+		}
+		kr.nodes[n.KeyID] = n
+		kr.keyIDs = append(kr.keyIDs, n.KeyID)
+	}
+	sort.Strings(kr.keyIDs)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", kr.handleHealthz)
+	mux.HandleFunc("/readyz", kr.handleReadyz)
+	mux.HandleFunc("GET /v1/keys", kr.handleListKeys)
+	mux.HandleFunc("/v1/keys/{id}/", kr.handleKeyRoute)
+	if n, ok := kr.nodes[types.DefaultKeyID]; ok {
+		mux.Handle("/", n.server.GetHandler())
+	}
+
+	kr.httpServer = &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      60 * time.Second,
+		IdleTimeout:       120 * time.Second,
+		MaxHeaderBytes:    1 << 20, // 1 MB
+	}
+	return kr, nil
+}
+
+// handleKeyRoute strips /v1/keys/{id} and hands the request to that key's node.
+func (kr *KeyRouter) handleKeyRoute(w http.ResponseWriter, r *http.Request) {
+	keyID := r.PathValue("id")
+	n, ok := kr.nodes[keyID]
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown key %q", keyID), http.StatusNotFound)
+		return
+	}
+	http.StripPrefix("/v1/keys/"+keyID, n.server.GetHandler()).ServeHTTP(w, r)
+}
+
+// handleListKeys lists the keys this process holds.
+func (kr *KeyRouter) handleListKeys(w http.ResponseWriter, r *http.Request) {
+	keys := make([]types.KeyInfo, 0, len(kr.keyIDs))
+	for _, id := range kr.keyIDs {
+		n := kr.nodes[id]
+		keys = append(keys, types.KeyInfo{
+			ID:               id,
+			OperatorSetId:    n.OperatorSetId,
+			ActiveVersion:    n.activeKeyVersionNumber(),
+			ActiveGeneration: n.keyStore.ActiveGeneration(),
+		})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(keys)
+}
+
+// handleHealthz is the process liveness probe.
+func (kr *KeyRouter) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeHealthResponse(w, []types.HealthCheck{{Name: "process", Healthy: true}})
+}
+
+// handleReadyz reports every key's readiness checks, named "<key-id>/<check>"; the
+// process is ready only when every key is. Per-key readiness is served at
+// /v1/keys/{id}/readyz.
+func (kr *KeyRouter) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	now := time.Now()
+	var checks []types.HealthCheck
+	for _, id := range kr.keyIDs {
+		for _, c := range kr.nodes[id].readinessChecks(now) {
+			c.Name = id + "/" + c.Name
+			checks = append(checks, c)
+		}
+	}
+	writeHealthResponse(w, checks)
+}
+
+// Start starts serving in the background.
+func (kr *KeyRouter) Start() error {
+	go func() {
+		kr.logger.Sugar().Infow("Starting HTTP server", "port", kr.httpServer.Addr, "keys", strings.Join(kr.keyIDs, ","))
+		if err := kr.httpServer.ListenAndServe(); err != http.ErrServerClosed {
+			kr.logger.Sugar().Errorw("HTTP server error", "error", err)
+		}
+	}()
+	return nil
+}
+
+// Stop stops the listener. The nodes are stopped separately.
+func (kr *KeyRouter) Stop() error {
+	return kr.httpServer.Close()
+}
+
+// GetHandler returns the HTTP handler (for testing)
+func (kr *KeyRouter) GetHandler() http.Handler {
+	return kr.httpServer.Handler
+}
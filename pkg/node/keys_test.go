@@ -0,0 +1,176 @@
+package node
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/keystore"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newKeyTestNode returns a node for keyID whose active key version is version, or
+// which has no key version when version is 0.
+func newKeyTestNode(t *testing.T, keyID string, operatorSetId uint32, version int64) *Node {
+	t.Helper()
+	n := newReadyTestNode(t)
+	n.KeyID = keyID
+	n.OperatorSetId = operatorSetId
+	n.sharedListener = true
+	n.keyStore = keystore.NewKeyStore()
+	if version > 0 {
+		n.keyStore.AddVersion(&types.KeyShareVersion{
+			Version:      version,
+			PrivateShare: new(fr.Element).SetInt64(7),
+			IsActive:     true,
+		})
+	}
+	n.server = NewServer(n, 0)
+	return n
+}
+
+func serveKeyRouter(t *testing.T, kr *KeyRouter, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	kr.GetHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func pubkeyVersion(t *testing.T, rec *httptest.ResponseRecorder) int64 {
+	t.Helper()
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var resp struct {
+		Version int64 `json:"version"`
+	}
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+	return resp.Version
+}
+
+func Test_KeyRouter(t *testing.T) {
+	defaultNode := newKeyTestNode(t, types.DefaultKeyID, 0, 100)
+	tenantNode := newKeyTestNode(t, "tenant-b", 2, 200)
+
+	kr, err := NewKeyRouter(0, []*Node{tenantNode, defaultNode}, defaultNode.logger)
+	require.NoError(t, err)
+
+	t.Run("routes by key ID", func(t *testing.T) {
+		assert.Equal(t, int64(100), pubkeyVersion(t, serveKeyRouter(t, kr, "/v1/keys/default/pubkey")))
+		assert.Equal(t, int64(200), pubkeyVersion(t, serveKeyRouter(t, kr, "/v1/keys/tenant-b/pubkey")))
+	})
+
+	t.Run("default key is served at the root", func(t *testing.T) {
+		assert.Equal(t, int64(100), pubkeyVersion(t, serveKeyRouter(t, kr, "/pubkey")))
+	})
+
+	t.Run("unknown key returns 404", func(t *testing.T) {
+		rec := serveKeyRouter(t, kr, "/v1/keys/nope/pubkey")
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("lists keys", func(t *testing.T) {
+		rec := serveKeyRouter(t, kr, "/v1/keys")
+		require.Equal(t, http.StatusOK, rec.Code)
+		var keys []types.KeyInfo
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&keys))
+		assert.Equal(t, []types.KeyInfo{
+			{ID: types.DefaultKeyID, OperatorSetId: 0, ActiveVersion: 100},
+			{ID: "tenant-b", OperatorSetId: 2, ActiveVersion: 200},
+		}, keys)
+	})
+
+	t.Run("readyz reports every key", func(t *testing.T) {
+		pending := newKeyTestNode(t, "tenant-c", 3, 0)
+		kr, err := NewKeyRouter(0, []*Node{defaultNode, pending}, defaultNode.logger)
+		require.NoError(t, err)
+
+		rec := serveKeyRouter(t, kr, "/readyz")
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+		var resp types.HealthResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&resp))
+		assert.Equal(t, []string{"tenant-c/" + readyCheckActiveKeyVersion}, failingChecks(resp))
+
+		// The default key on its own is ready.
+		rec = serveKeyRouter(t, kr, "/v1/keys/default/readyz")
+		assert.Equal(t, http.StatusOK, rec.Code)
+	})
+}
+
+func Test_NewKeyRouter_Validation(t *testing.T) {
+	t.Run("requires a node", func(t *testing.T) {
+		_, err := NewKeyRouter(0, nil, nil)
+		require.Error(t, err)
+	})
+
+	t.Run("rejects duplicate key IDs", func(t *testing.T) {
+		a := newKeyTestNode(t, "tenant-a", 1, 100)
+		b := newKeyTestNode(t, "tenant-a", 2, 100)
+		_, err := NewKeyRouter(0, []*Node{a, b}, a.logger)
+		require.ErrorContains(t, err, "duplicate key ID")
+	})
+
+	t.Run("rejects nodes with their own listener", func(t *testing.T) {
+		a := newKeyTestNode(t, "tenant-a", 1, 100)
+		a.sharedListener = false
+		_, err := NewKeyRouter(0, []*Node{a}, a.logger)
+		require.Error(t, err)
+	})
+}
+
+func Test_AppKeyBinding(t *testing.T) {
+	app := common.HexToAddress("0x00000000000000000000000000000000000000a1")
+
+	makeRequest := func(f *testSecretsFixture, appID string) *httptest.ResponseRecorder {
+		t.Helper()
+		reqBody, _ := json.Marshal(types.AppSignRequest{AppID: appID, AttestationTime: 1})
+		httpReq := httptest.NewRequest(http.MethodPost, "/app/sign", bytes.NewBuffer(reqBody))
+		w := httptest.NewRecorder()
+		f.server.handleAppSign(w, httpReq)
+		return w
+	}
+
+	t.Run("unbound node serves any app", func(t *testing.T) {
+		f := newTestSecretsFixture(t)
+		f.contractCallerStub.SetAppOperatorSetId(app, 7)
+
+		status, err := f.server.verifyAppKeyBinding(app.Hex())
+		require.NoError(t, err)
+		assert.Zero(t, status)
+	})
+
+	t.Run("app in the key's operator set passes", func(t *testing.T) {
+		f := newTestSecretsFixture(t)
+		f.node.bindAppsToKey = true
+		f.contractCallerStub.SetAppOperatorSetId(app, f.node.OperatorSetId)
+
+		status, err := f.server.verifyAppKeyBinding(app.Hex())
+		require.NoError(t, err)
+		assert.Zero(t, status)
+		assert.NotEqual(t, http.StatusForbidden, makeRequest(f, app.Hex()).Code)
+	})
+
+	t.Run("app in another operator set is rejected", func(t *testing.T) {
+		f := newTestSecretsFixture(t)
+		f.node.bindAppsToKey = true
+		f.contractCallerStub.SetAppOperatorSetId(app, f.node.OperatorSetId+1)
+
+		status, err := f.server.verifyAppKeyBinding(app.Hex())
+		require.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+		assert.Equal(t, http.StatusForbidden, makeRequest(f, app.Hex()).Code)
+	})
+
+	t.Run("non-address app ID is rejected", func(t *testing.T) {
+		f := newTestSecretsFixture(t)
+		f.node.bindAppsToKey = true
+
+		status, err := f.server.verifyAppKeyBinding("my-app")
+		require.Error(t, err)
+		assert.Equal(t, http.StatusForbidden, status)
+	})
+}
@@ -25,26 +25,33 @@ import (
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/transportSigner"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
 	"go.uber.org/zap"
 
 	"github.com/Layr-Labs/crypto-libs/pkg/bn254"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/attestation"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/authz"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller"
 	eigenxcrypto "github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/fraud"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/keystore"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/merkle"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/metrics"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/registrarabi"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/reshare"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/tracing"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/transport"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/util"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Node represents a KMS node
@@ -55,6 +62,7 @@ type Node struct {
 	ChainID         config.ChainId // Ethereum chain ID
 	AVSAddress      string         // AVS contract address
 	OperatorSetId   uint32         // Operator set ID
+	KeyID           string         // Key this node holds for OperatorSetId (types.DefaultKeyID unless configured)
 
 	// Dependencies
 	keyStore           *keystore.KeyStore
@@ -67,6 +75,19 @@ type Node struct {
 	transportSigner    transportSigner.ITransportSigner
 	persistence        persistence.INodePersistence
 
+	// shareEncryptionKey receives DKG/reshare shares. Peers encrypt every share to its
+	// public half, which this node announces signed by its transport key (GET /share/key),
+	// so shares never cross the network in cleartext. Generated per process: shares are
+	// decrypted on receipt, so nothing encrypted under a previous key needs to survive.
+	shareEncryptionKey *ecies.PrivateKey
+
+	// pqKeySeed is the seed of this node's per-app ML-KEM keys (see pq.go), loaded or
+	// generated on first use and never shared. Unlike shareEncryptionKey it must survive
+	// restarts and reshares: it decrypts every post-quantum ciphertext that names this
+	// node.
+	pqKeySeed   []byte
+	pqKeySeedMu sync.Mutex
+
 	// abortTracker counts consecutive Layer-1 MPK-validation aborts on the active
 	// source version (majority-gated) to drive auto-heal demotion/rollback.
 	abortTracker *abortTracker
@@ -78,6 +99,7 @@ type Node struct {
 	// Session management
 	activeSessions    map[int64]*ProtocolSession
 	sessionMutex      sync.RWMutex
+	restoredSessions  []*ProtocolSession      // rebuilt by RestoreState, rerun by Start
 	sessionNotify     map[int64]chan struct{} // Notifies when session is created
 	sessionNotifyLock sync.Mutex
 
@@ -93,10 +115,21 @@ type Node struct {
 	retainedSharesMutex         sync.RWMutex
 
 	// Scheduling
-	enableAutoReshare     bool
 	lastProcessedBoundary int64
 	cancelFunc            context.CancelFunc
 
+	// Admin controls (see admin.go). autoResharePaused skips the scheduled reshare
+	// at interval boundaries and is persisted in NodeState; reshareTrigger holds the
+	// block an admin asked a reshare at (0 = none, triggerNextBlock = the next block).
+	// lastBlockNumber is the latest block delivered by the poller.
+	autoResharePaused atomic.Bool
+	reshareTrigger    atomic.Int64
+	lastBlockNumber   atomic.Int64
+
+	// retention bounds the key versions kept in the keystore and persistence (see
+	// retention.go).
+	retention config.RetentionConfig
+
 	blockHandler blockHandler.IBlockHandler
 	poller       chainPoller.IChainPoller
 
@@ -104,6 +137,10 @@ type Node struct {
 	baseContractCaller        contractCaller.IContractCaller
 	commitmentRegistryAddress common.Address
 
+	// fraudReporter records dealer misbehaviour and submits what the registry accepts.
+	// nil in unit tests that build a Node directly.
+	fraudReporter *fraud.Reporter
+
 	// platformConfigCaller reads the EigenKMSRegistrar's AvsConfig for the one-shot
 	// startup seed of the platform RPC URL. The registrar lives on L1 (the same chain
 	// the AvsConfigSet event logs come from), while baseContractCaller is bound to the
@@ -120,21 +157,42 @@ type Node struct {
 	platformConfigCaller contractCaller.IContractCaller
 
 	// Access control
-	appAllowlist map[string]bool // nil means all apps allowed
+	appAllowlist  map[string]bool // nil means all apps allowed
+	bindAppsToKey bool            // apps must belong to OperatorSetId in the AppController
+	authzPolicy   *authz.Engine   // authorization policy for /secrets; nil applies the default policy
+
+	// sharedListener is set when a KeyRouter serves this node's routes; the node then
+	// does not listen on Port itself.
+	sharedListener bool
 
 	// ecloud-platform integration
 	platformClient platformClient.Client
 	platformURL    atomic.Value // string; current on-chain platformRpcUrl
+
+	// metrics is nil when instrumentation is disabled; its methods are nil-safe.
+	metrics *metrics.Metrics
+
+	// Readiness inputs (see health.go). startedAt and lastBlockSeen are unix nanos
+	// (0 = never); autoHealRollback is set by an auto-heal demotion and cleared by
+	// the next validated reshare. It is in-memory only: after a restart the node
+	// reports ready on its (rolled-back) active version until the next abort.
+	startedAt        atomic.Int64
+	lastBlockSeen    atomic.Int64
+	autoHealRollback atomic.Bool
 }
 
 // ProtocolSession tracks state for a DKG or reshare session
 type ProtocolSession struct {
 	SessionTimestamp int64
 	Type             string // "dkg" or "reshare"
-	Phase            int    // 1, 2, 3, 4 (Phase 4 adds merkle tree building and contract submission)
+	Phase            int    // 1, 2, 3, 4 (Phase 3 submits the ack merkle root, Phase 4 broadcasts and finalizes)
 	StartTime        time.Time
 	Operators        []*peering.OperatorSetPeer
 
+	// Generation is the master secret generation a DKG session deals (0 for genesis and
+	// reshare). Persisted so a resumed rotation DKG deals the same generation.
+	Generation uint32
+
 	// TriggerBlockNumber is the interval-boundary block that triggered this session.
 	// It is identical across all operators (they all trigger on the same boundary) and
 	// is the anchor for the pinned-height registry read used to derive the agreed
@@ -173,13 +231,104 @@ type ProtocolSession struct {
 	myAckMerkleTree     *merkle.MerkleTree
 	myAckCommitmentHash [32]byte
 	contractSubmitted   bool
+	// ackMerklePlayers are the players whose acks are myAckMerkleTree's leaves.
+	ackMerklePlayers []common.Address
 
 	// Phase 4: Verification state
 	verifiedOperators map[common.Address]bool
 
+	// DKG complaint round (see dkg.ResolveComplaints). complaints is keyed by dealer then
+	// complainer; complaintSenders records which operators have delivered their (possibly
+	// empty) complaint message; justifications holds the dealers' answers, keyed by
+	// dealer then complainer; qualifiedSets holds the qualified set each operator
+	// announced when its round closed. qualifiedDealers is nil until the operators have
+	// agreed on the set. Allocated lazily: reshare sessions never use them.
+	complaints       map[common.Address]map[common.Address]*types.Complaint
+	complaintSenders map[common.Address]bool
+	justifications   map[common.Address]map[common.Address][]*dkg.Justification
+	qualifiedSets    map[common.Address][]common.Address
+	qualifiedDealers map[common.Address]bool
+
+	// Dealer-signed share and commitment messages exactly as received, kept so a failed
+	// verification can be backed by fraud evidence (see pkg/fraud). Allocated lazily.
+	signedShares      map[common.Address]*types.AuthenticatedMessage
+	signedCommitments map[common.Address]*types.AuthenticatedMessage
+
+	// restored marks a session RestoreState rebuilt from persistence that no protocol run
+	// has picked up yet (see resume.go).
+	restored bool
+
 	mu sync.RWMutex
 }
 
+// HandleReceivedComplaints stores the complaint message sender delivered for this session.
+// Each operator sends exactly one; a second message from the same sender is rejected.
+func (s *ProtocolSession) HandleReceivedComplaints(sender common.Address, complaints []*types.Complaint) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.complaintSenders == nil {
+		s.complaintSenders = make(map[common.Address]bool)
+		s.complaints = make(map[common.Address]map[common.Address]*types.Complaint)
+	}
+	if s.complaintSenders[sender] {
+		return fmt.Errorf("duplicate complaints from %s", sender.Hex())
+	}
+	s.complaintSenders[sender] = true
+
+	for _, c := range complaints {
+		if s.complaints[c.DealerAddress] == nil {
+			s.complaints[c.DealerAddress] = make(map[common.Address]*types.Complaint)
+		}
+		s.complaints[c.DealerAddress][c.ComplainerAddress] = c
+	}
+	return nil
+}
+
+// HandleReceivedJustification stores a dealer's answer to a complaint. Revealed shares
+// are checked against the dealer's commitments when the complaint round is resolved.
+func (s *ProtocolSession) HandleReceivedJustification(j *dkg.Justification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.justifications == nil {
+		s.justifications = make(map[common.Address]map[common.Address][]*dkg.Justification)
+	}
+	if s.justifications[j.Dealer] == nil {
+		s.justifications[j.Dealer] = make(map[common.Address][]*dkg.Justification)
+	}
+	s.justifications[j.Dealer][j.Complainer] = append(s.justifications[j.Dealer][j.Complainer], j)
+}
+
+// HandleReceivedQualifiedSet stores the qualified dealer set sender announced for this
+// session. Each operator announces once; a second announcement is rejected.
+func (s *ProtocolSession) HandleReceivedQualifiedSet(sender common.Address, qualified []common.Address) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.qualifiedSets == nil {
+		s.qualifiedSets = make(map[common.Address][]common.Address)
+	}
+	if _, ok := s.qualifiedSets[sender]; ok {
+		return fmt.Errorf("duplicate qualified set from %s", sender.Hex())
+	}
+	s.qualifiedSets[sender] = qualified
+	return nil
+}
+
+// unansweredComplaints counts complaints with no justification yet. Caller holds s.mu.
+func (s *ProtocolSession) unansweredComplaints() int {
+	unanswered := 0
+	for dealer, byComplainer := range s.complaints {
+		for complainer := range byComplainer {
+			if len(s.justifications[dealer][complainer]) == 0 {
+				unanswered++
+			}
+		}
+	}
+	return unanswered
+}
+
 // SetMyGeneratedShares records the per-recipient shares this node dealt, so they can
 // be re-served on demand to a peer that missed the original send.
 func (s *ProtocolSession) SetMyGeneratedShares(shares map[common.Address]*fr.Element) {
@@ -265,6 +414,19 @@ func (n *Node) getRetainedGeneratedShare(sessionTimestamp int64, recipient commo
 	return new(fr.Element).Set(sh)
 }
 
+// retainedShareRecipients returns the recipients this node dealt to in the given
+// retained session: the session's operator set.
+func (n *Node) retainedShareRecipients(sessionTimestamp int64) []common.Address {
+	n.retainedSharesMutex.RLock()
+	defer n.retainedSharesMutex.RUnlock()
+
+	recipients := make([]common.Address, 0, len(n.retainedGeneratedShares[sessionTimestamp]))
+	for addr := range n.retainedGeneratedShares[sessionTimestamp] {
+		recipients = append(recipients, addr)
+	}
+	return recipients
+}
+
 // GetSourceVersions returns a copy of the per-dealer source versions recorded this session.
 func (s *ProtocolSession) GetSourceVersions() map[common.Address]int64 {
 	s.mu.RLock()
@@ -430,16 +592,45 @@ func (ps *ProtocolSession) toPersistenceState() *persistence.ProtocolSessionStat
 		acks[dealer.Hex()] = innerMap
 	}
 
-	return &persistence.ProtocolSessionState{
-		SessionTimestamp:  ps.SessionTimestamp,
-		Type:              ps.Type,
-		Phase:             ps.Phase,
-		StartTime:         ps.StartTime.Unix(),
-		OperatorAddresses: operatorAddresses,
-		Shares:            shares,
-		Commitments:       commitments,
-		Acknowledgements:  acks,
+	state := &persistence.ProtocolSessionState{
+		SessionTimestamp:   ps.SessionTimestamp,
+		Type:               ps.Type,
+		Phase:              ps.Phase,
+		Generation:         ps.Generation,
+		TriggerBlockNumber: ps.TriggerBlockNumber,
+		StartTime:          ps.StartTime.Unix(),
+		OperatorAddresses:  operatorAddresses,
+		Shares:             shares,
+		Commitments:        commitments,
+		Acknowledgements:   acks,
+		ContractSubmitted:  ps.contractSubmitted,
+	}
+
+	// What this node dealt, so a restart re-sends it rather than dealing again
+	if len(ps.myGeneratedShares) > 0 {
+		state.GeneratedShares = make(map[string]string, len(ps.myGeneratedShares))
+		for addr, share := range ps.myGeneratedShares {
+			state.GeneratedShares[addr.Hex()] = types.SerializeFr(share).Data
+		}
+	}
+	if len(ps.sourceVersions) > 0 {
+		state.SourceVersions = make(map[string]int64, len(ps.sourceVersions))
+		for addr, v := range ps.sourceVersions {
+			state.SourceVersions[addr.Hex()] = v
+		}
+	}
+	if ps.qualifiedDealers != nil {
+		state.QualifiedDealers = addressSetToHex(ps.qualifiedDealers)
 	}
+	state.VerifiedOperators = addressSetToHex(ps.verifiedOperators)
+	if ps.contractSubmitted && ps.myAckMerkleTree != nil {
+		state.AckMerkleRoot = fmt.Sprintf("0x%x", ps.myAckMerkleTree.Root)
+		for _, player := range ps.ackMerklePlayers {
+			state.AckMerklePlayers = append(state.AckMerklePlayers, player.Hex())
+		}
+	}
+
+	return state
 }
 
 // saveSession persists the current protocol session state
@@ -462,12 +653,30 @@ func (n *Node) saveSession(session *ProtocolSession) error {
 
 // Config holds node configuration
 type Config struct {
-	OperatorAddress string         // Ethereum address of the operator (hex string)
-	Port            int            // HTTP server port
-	ChainID         config.ChainId // Ethereum chain ID
-	AVSAddress      string         // AVS contract address (hex string)
-	OperatorSetId   uint32         // Operator set ID
-	AppAllowlist    []string       // Optional: restrict /app/sign and /secrets to these app IDs (empty = allow all)
+	OperatorAddress string           // Ethereum address of the operator (hex string)
+	Port            int              // HTTP server port
+	ChainID         config.ChainId   // Ethereum chain ID
+	AVSAddress      string           // AVS contract address (hex string)
+	OperatorSetId   uint32           // Operator set ID
+	AppAllowlist    []string         // Optional: restrict /app/sign and /secrets to these app IDs (empty = allow all)
+	Metrics         *metrics.Metrics // Optional: Prometheus metrics (nil disables instrumentation)
+
+	// KeyID names the key this node holds; empty means types.DefaultKeyID. Peers are
+	// addressed on the key's routes (types.KeyRoutePrefix).
+	KeyID string
+	// BindAppsToKey requires an app's AppController operator set to equal
+	// OperatorSetId before /secrets or /app/sign serve it. Set when one process holds
+	// keys for several operator sets, so a tenant's app cannot use another's key.
+	BindAppsToKey bool
+	// SharedListener leaves serving HTTP to a KeyRouter instead of listening on Port.
+	SharedListener bool
+	// Retention prunes old key versions at every interval boundary. The zero value
+	// keeps every version.
+	Retention config.RetentionConfig
+	// AuthzPolicy holds the authorization policy evaluated on /secrets once a request
+	// is authenticated. Nil applies authz.DefaultPolicy. One engine may be shared by
+	// every key of a process.
+	AuthzPolicy *authz.Engine
 }
 
 // NewNode creates a new node instance with dependency injection
@@ -498,12 +707,25 @@ func NewNode(
 	// Parse operator address
 	operatorAddress := common.HexToAddress(cfg.OperatorAddress)
 
+	keyID := cfg.KeyID
+	if keyID == "" {
+		keyID = types.DefaultKeyID
+	} else {
+		l = l.With(zap.String("key_id", keyID))
+	}
+
+	shareEncryptionKey, err := encryption.GenerateShareKey()
+	if err != nil {
+		return nil, err
+	}
+
 	n := &Node{
 		OperatorAddress:           operatorAddress,
 		Port:                      cfg.Port,
 		ChainID:                   cfg.ChainID,
 		AVSAddress:                cfg.AVSAddress,
 		OperatorSetId:             cfg.OperatorSetId,
+		KeyID:                     keyID,
 		keyStore:                  keystore.NewKeyStore(),
 		server:                    NewServer(nil, cfg.Port), // Will set node reference later
 		attestationManager:        attestationManager,
@@ -512,7 +734,6 @@ func NewNode(
 		logger:                    l,
 		activeSessions:            make(map[int64]*ProtocolSession),
 		sessionNotify:             make(map[int64]chan struct{}),
-		enableAutoReshare:         true, // Always enabled
 		blockHandler:              bh,
 		poller:                    cp,
 		lastProcessedBoundary:     0,
@@ -521,7 +742,14 @@ func NewNode(
 		platformConfigCaller:      platformConfigCaller,
 		commitmentRegistryAddress: commitmentRegistryAddress,
 		persistence:               p,
+		fraudReporter:             fraud.NewReporter(p, baseContractCaller, commitmentRegistryAddress, l),
+		shareEncryptionKey:        shareEncryptionKey,
 		abortTracker:              &abortTracker{},
+		metrics:                   cfg.Metrics,
+		bindAppsToKey:             cfg.BindAppsToKey,
+		authzPolicy:               cfg.AuthzPolicy,
+		sharedListener:            cfg.SharedListener,
+		retention:                 cfg.Retention,
 	}
 
 	// Build app allowlist if configured
@@ -535,13 +763,17 @@ func NewNode(
 	// Set node reference in server
 	n.server.node = n
 
+	if err := n.metrics.RegisterActiveKeyVersion(n.KeyID, n.activeKeyVersionNumber); err != nil {
+		return nil, fmt.Errorf("failed to register active key version metric: %w", err)
+	}
+
 	// Build the ecloud-platform client using the node's own PlatformRpcURL accessor as
 	// the live URL provider, so the client always reads the freshest cached on-chain URL.
 	n.platformClient = platformClient.NewClient(n.PlatformRpcURL, operatorAddress, tps, l)
 
 	// Initialize transport with authenticated messaging
 	// TODO(seanmcgary): this should be injected, not created here
-	n.transport = transport.NewClient(operatorAddress, tps)
+	n.transport = transport.NewKeyClient(operatorAddress, tps, keyID)
 
 	return n, nil
 }
@@ -689,14 +921,24 @@ func (n *Node) startScheduler(ctx context.Context) {
 
 // checkScheduledOperations checks for block interval boundaries and executes appropriate protocol
 func (n *Node) checkScheduledOperations(block *ethereum.EthereumBlock) {
+	n.recordBlockSeen()
+
 	blockNumber := int64(block.Number.Value())
 	blockTimestamp := int64(block.Timestamp.Value())
+	n.lastBlockNumber.Store(blockNumber)
 
 	// Step 1: Get block interval for this chain
 	blockInterval := config.GetReshareBlockIntervalForChain(n.ChainID)
 
+	// An admin-triggered reshare runs at its block even when that block is not a
+	// boundary (see admin.go); at a boundary it overrides a pause.
+	triggered := n.takeReshareTrigger(blockNumber)
+
 	// Step 2: Check if this block is an interval boundary
 	if blockNumber%blockInterval != 0 {
+		if triggered {
+			n.runTriggeredReshare(blockTimestamp, blockNumber)
+		}
 		// Not an interval boundary, skip
 		return
 	}
@@ -727,6 +969,15 @@ func (n *Node) checkScheduledOperations(block *ethereum.EthereumBlock) {
 		"block_timestamp", blockTimestamp,
 		"block_interval", blockInterval)
 
+	// Drop master secret generations whose retirement window has passed.
+	n.retireExpiredGenerations(blockTimestamp)
+
+	// Prune key versions outside the retention policy.
+	n.applyKeyRetention(blockTimestamp)
+
+	// Open, or prove, share complaints that are due.
+	go n.submitPendingFraudEvidence(context.Background())
+
 	// Step 6: Fetch current operators
 	ctx := context.Background()
 	operators, err := n.fetchCurrentOperators(ctx, n.AVSAddress, n.OperatorSetId)
@@ -784,6 +1035,28 @@ func (n *Node) checkScheduledOperations(block *ethereum.EthereumBlock) {
 				}
 			}()
 		}
+	} else if generation, due := n.rotationDue(ctx, blockNumber, blockTimestamp, blockInterval); due {
+		// A master secret rotation is scheduled on-chain for this boundary - run a
+		// fresh DKG for the new generation instead of resharing the current one.
+		n.logger.Sugar().Infow("Triggering master secret rotation DKG",
+			"operator_address", n.OperatorAddress.Hex(),
+			"block_number", blockNumber,
+			"block_timestamp", blockTimestamp,
+			"generation", generation)
+
+		go func() {
+			if err := n.RunRotationDKG(blockTimestamp, generation); err != nil {
+				n.logger.Sugar().Errorw("Master secret rotation DKG failed",
+					"operator_address", n.OperatorAddress.Hex(),
+					"generation", generation,
+					"error", err)
+			}
+		}()
+	} else if n.autoResharePaused.Load() && !triggered {
+		n.logger.Sugar().Infow("Skipping automatic reshare: paused by admin",
+			"operator_address", n.OperatorAddress.Hex(),
+			"block_number", blockNumber,
+			"block_timestamp", blockTimestamp)
 	} else {
 		// I'm an existing operator - run normal reshare.
 		n.logger.Sugar().Infow("Triggering automatic reshare",
@@ -842,6 +1115,7 @@ func (n *Node) Start() error {
 	// Create context for managing server and scheduler lifecycle
 	ctx, cancel := context.WithCancel(context.Background())
 	n.cancelFunc = cancel
+	n.startedAt.Store(time.Now().UnixNano())
 
 	// start the poller
 	if err := n.poller.Start(ctx); err != nil {
@@ -856,15 +1130,26 @@ func (n *Node) Start() error {
 	n.refreshPlatformConfig(seedCtx)
 	seedCancel()
 
+	// Retry fraud evidence that was recorded but never accepted on-chain.
+	go n.submitPendingFraudEvidence(ctx)
+
 	// Start scheduler in goroutine
 	go n.startScheduler(ctx)
 
-	// Start HTTP server in goroutine
-	go func() {
-		if err := n.server.Start(); err != nil {
-			n.logger.Sugar().Errorw("HTTP server error", "operator_address", n.OperatorAddress.Hex(), "error", err)
-		}
-	}()
+	// Start HTTP server in goroutine. Under a KeyRouter the router owns the listener
+	// and the node only runs the server's background work.
+	if n.sharedListener {
+		go n.server.runJTICleanup()
+	} else {
+		go func() {
+			if err := n.server.Start(); err != nil {
+				n.logger.Sugar().Errorw("HTTP server error", "operator_address", n.OperatorAddress.Hex(), "error", err)
+			}
+		}()
+	}
+
+	// Rejoin any protocol session interrupted by the last shutdown.
+	n.resumeRestoredSessions()
 
 	n.logger.Sugar().Infow("Node started", "operator_address", n.OperatorAddress.Hex(), "port", n.Port)
 	return nil
@@ -987,6 +1272,16 @@ func (n *Node) RestoreState() error {
 		}
 	}
 
+	// 3d. Restore the retirement deadlines of rotated-out master secret generations.
+	n.restoreRetiringGenerations(nodeState)
+
+	// 3e. Restore an admin pause of the scheduled reshare.
+	if nodeState != nil && nodeState.AutoResharePaused {
+		n.autoResharePaused.Store(true)
+		n.logger.Sugar().Warnw("Automatic reshare is paused by admin",
+			"operator_address", n.OperatorAddress.Hex())
+	}
+
 	// 4. Check for incomplete protocol sessions
 	sessions, err := n.persistence.ListProtocolSessions()
 	if err != nil {
@@ -1002,7 +1297,11 @@ func (n *Node) RestoreState() error {
 		protocolTimeout := config.GetProtocolTimeoutForChain(n.ChainID)
 		timeoutSeconds := int64(protocolTimeout.Seconds())
 
-		// Process each session
+		// Process each session. Sessions still within their deadline are rebuilt and
+		// resumed by Start; the operator set to rebuild them against is read once.
+		var operators []*peering.OperatorSetPeer
+		var operatorsErr error
+		operatorsFetched := false
 		for _, sessionState := range sessions {
 			// Check if session has expired
 			if sessionState.IsExpired(timeoutSeconds) {
@@ -1020,14 +1319,25 @@ func (n *Node) RestoreState() error {
 						"error", err)
 				}
 			} else {
-				// Session not expired - could attempt resumption
-				// For now, still clean up (resumption is complex, future enhancement)
-				n.logger.Sugar().Warnw("Cleaning up incomplete session (resumption not yet implemented)",
+				if !operatorsFetched {
+					operators, operatorsErr = n.fetchOperatorsForRestore()
+					operatorsFetched = true
+				}
+				err := operatorsErr
+				if err == nil {
+					err = n.restoreIncompleteSession(sessionState, operators)
+				}
+				if err == nil {
+					continue
+				}
+
+				n.logger.Sugar().Warnw("Cleaning up incomplete session that cannot be resumed",
 					"operator_address", n.OperatorAddress.Hex(),
 					"session_timestamp", sessionState.SessionTimestamp,
 					"type", sessionState.Type,
 					"phase", sessionState.Phase,
-					"age_seconds", time.Now().Unix()-sessionState.StartTime)
+					"age_seconds", time.Now().Unix()-sessionState.StartTime,
+					"error", err)
 
 				if err := n.persistence.DeleteProtocolSession(sessionState.SessionTimestamp); err != nil {
 					n.logger.Sugar().Errorw("Failed to delete incomplete session",
@@ -1270,10 +1580,11 @@ func (n *Node) resolveCutoffL2(ctx context.Context, triggerBlock int64) (uint64,
 func (n *Node) deriveAgreedDealerSet(
 	ctx context.Context,
 	operators []*peering.OperatorSetPeer,
-	epoch int64,
+	sessionTimestamp int64,
 	triggerBlock int64,
 	expectedDealers []common.Address,
 ) ([]common.Address, map[common.Address][32]byte, error) {
+	epoch := types.CommitmentEpoch(n.KeyID, sessionTimestamp)
 	// expectedDealers overrides the default when non-nil (the new-operator join path
 	// passes existingOperatorDealers(...) so a node with no active version scopes to the
 	// existing on-chain submitters, not the all-operators fallback expectedReshareDealers
@@ -1370,7 +1681,7 @@ func (n *Node) deriveAgreedDealerSet(
 // on-demand fetch RPC, then verifies it against the dealer's broadcast commitments using
 // the same polynomial-commitment check as the push path. Returns the verified share or
 // an error if it cannot be obtained/verified.
-func (n *Node) fetchAndVerifyReshareShare(session *ProtocolSession, dealer common.Address) (*fr.Element, error) {
+func (n *Node) fetchAndVerifyReshareShare(ctx context.Context, session *ProtocolSession, dealer common.Address) (*fr.Element, error) {
 	// Locate the dealer's peer info.
 	var dealerPeer *peering.OperatorSetPeer
 	for _, op := range session.Operators {
@@ -1390,7 +1701,7 @@ func (n *Node) fetchAndVerifyReshareShare(session *ProtocolSession, dealer commo
 	var err error
 	backoff := 1 * time.Second
 	for attempt := 0; attempt < 3; attempt++ {
-		authResp, err = n.transport.RequestReshareShare(dealerPeer, session.SessionTimestamp)
+		authResp, err = n.transport.RequestReshareShare(ctx, dealerPeer, session.SessionTimestamp, n.shareEncryptionPublicKey())
 		if err == nil {
 			break
 		}
@@ -1419,10 +1730,13 @@ func (n *Node) fetchAndVerifyReshareShare(session *ProtocolSession, dealer commo
 	if shareMsg.ToOperatorAddress != n.OperatorAddress {
 		return nil, fmt.Errorf("fetched share addressed to %s, not this node %s", shareMsg.ToOperatorAddress.Hex(), n.OperatorAddress.Hex())
 	}
-	if shareMsg.Share == nil {
-		return nil, fmt.Errorf("dealer %s returned empty share", dealer.Hex())
+	if !types.SameKeyID(shareMsg.KeyID, n.KeyID) {
+		return nil, fmt.Errorf("fetched share is for key %q, not %q", shareMsg.KeyID, n.KeyID)
+	}
+	share, err := n.decryptShareMessage(encryption.ShareLabelReshare, dealer, session, &shareMsg)
+	if err != nil {
+		return nil, fmt.Errorf("fetched share from %s: %w", dealer.Hex(), err)
 	}
-	share := types.DeserializeFr(shareMsg.Share)
 
 	// Verify against the dealer's commitments (must have been broadcast/received).
 	session.mu.RLock()
@@ -1442,6 +1756,14 @@ func (n *Node) hasExistingShares() bool {
 	return n.keyStore.GetActiveVersion() != nil
 }
 
+// activeKeyVersionNumber returns the active key version, or 0 if there is none.
+func (n *Node) activeKeyVersionNumber() int64 {
+	if active := n.keyStore.GetActiveVersion(); active != nil {
+		return active.Version
+	}
+	return 0
+}
+
 // countNewOperatorsInSet returns the number of operators in the current set that
 // were not participants in the previous key version (i.e., are joining fresh).
 //
@@ -1649,10 +1971,35 @@ func (n *Node) cleanupOldSessions(maxAge time.Duration) { //nolint:unused // Wil
 
 // RunDKG executes the DKG protocol with the provided session timestamp
 func (n *Node) RunDKG(sessionTimestamp int64) error {
-	ctx := context.Background()
+	start := time.Now()
+	run := n.startProtocolRun(metrics.ProtocolDKG, sessionTimestamp)
+	err := n.runDKG(run, sessionTimestamp, 0)
+	run.end(err)
+	n.metrics.ObserveProtocolRun(metrics.ProtocolDKG, start, err)
+	return err
+}
+
+// RunRotationDKG runs a fresh DKG for master secret generation, replacing the current
+// master secret. The current generation keeps serving until the retirement window of
+// the on-chain rotation schedule has passed.
+func (n *Node) RunRotationDKG(sessionTimestamp int64, generation uint32) error {
+	if active := n.keyStore.ActiveGeneration(); generation <= active && n.hasExistingShares() || generation == 0 {
+		return fmt.Errorf("cannot rotate to generation %d: generation %d is active", generation, active)
+	}
+	start := time.Now()
+	run := n.startProtocolRun(metrics.ProtocolDKG, sessionTimestamp, attribute.Int64("kms.generation", int64(generation)))
+	err := n.runDKG(run, sessionTimestamp, generation)
+	run.end(err)
+	n.metrics.ObserveProtocolRun(metrics.ProtocolDKG, start, err)
+	return err
+}
+
+func (n *Node) runDKG(run *protocolRun, sessionTimestamp int64, generation uint32) error {
+	ctx := run.startPhase("setup")
 	n.logger.Sugar().Infow("Starting DKG",
 		"operator_address", n.OperatorAddress.Hex(),
-		"session_timestamp", sessionTimestamp)
+		"session_timestamp", sessionTimestamp,
+		"generation", generation)
 
 	// Fetch current operators from peering system
 	operators, err := n.fetchCurrentOperators(ctx, n.AVSAddress, n.OperatorSetId)
@@ -1660,18 +2007,30 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 		return fmt.Errorf("failed to fetch operators: %w", err)
 	}
 
-	// Create session for this DKG run with provided timestamp
-	session, err := n.createSession("dkg", operators, sessionTimestamp)
+	// Create session for this DKG run with provided timestamp, or pick up the one
+	// restored after a restart
+	session, resumed, err := n.openSession("dkg", operators, sessionTimestamp)
 	if err != nil {
 		return fmt.Errorf("failed to create DKG session: %w", err)
 	}
 	defer n.cleanupSession(session.SessionTimestamp)
+	resumePhase := session.currentPhase()
 
 	// Persist initial session state
-	if err := n.saveSession(session); err != nil {
-		n.logger.Sugar().Warnw("Failed to persist initial DKG session",
+	if !resumed {
+		session.mu.Lock()
+		session.Generation = generation
+		session.mu.Unlock()
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist initial DKG session",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	} else {
+		n.logger.Sugar().Infow("Resuming DKG session",
 			"operator_address", n.OperatorAddress.Hex(),
-			"error", err)
+			"session_timestamp", sessionTimestamp,
+			"phase", resumePhase)
 	}
 
 	// Verify this operator is in the fetched operator set
@@ -1690,24 +2049,38 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 	threshold := dkg.CalculateThreshold(len(operators))
 	n.dkg = dkg.NewDKG(n.OperatorAddress, threshold, operators)
 
+	ctx = run.startPhase("deal")
 	n.logger.Sugar().Infow("Starting DKG Phase 1", "operator_address", n.OperatorAddress.Hex(), "threshold", threshold, "total_operators", len(operators))
 
-	// Phase 1: Generate shares and commitments
-	shares, commitments, err := n.dkg.GenerateShares()
-	if err != nil {
-		return err
-	}
+	// Phase 1: Generate shares and commitments. A resumed dealer re-sends what it dealt
+	// before the restart: dealing a second polynomial would be equivocation.
+	shares, commitments, _ := session.dealtShares(n.OperatorAddress)
+	if shares == nil {
+		shares, commitments, err = n.dkg.GenerateShares()
+		if err != nil {
+			return err
+		}
 
-	// Store own share and commitment BEFORE broadcasting to other nodes.
-	// This prevents a race where a fast peer receives our commitment, verifies,
-	// and sends an ack back before we've stored our own commitment in the
-	// session — causing verifyAcknowledgement to reject the ack with
-	// "dealer commitments unavailable".
-	_ = session.HandleReceivedShare(n.OperatorAddress, shares[n.OperatorAddress])
-	_ = session.HandleReceivedCommitment(n.OperatorAddress, commitments, 0) // DKG has no source version
+		// Store own share and commitment BEFORE broadcasting to other nodes.
+		// This prevents a race where a fast peer receives our commitment, verifies,
+		// and sends an ack back before we've stored our own commitment in the
+		// session — causing verifyAcknowledgement to reject the ack with
+		// "dealer commitments unavailable".
+		_ = session.HandleReceivedShare(n.OperatorAddress, shares[n.OperatorAddress])
+		_ = session.HandleReceivedCommitment(n.OperatorAddress, commitments, 0) // DKG has no source version
+		// Keep what we dealt so we can reveal a recipient's share if it complains.
+		session.SetMyGeneratedShares(shares)
+
+		// Persist the dealing before any of it leaves this node
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist DKG dealing",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	}
 
 	// Broadcast commitments
-	if err := n.transport.BroadcastDKGCommitments(operators, commitments, session.SessionTimestamp); err != nil {
+	if err := n.transport.BroadcastDKGCommitments(ctx, operators, commitments, session.SessionTimestamp); err != nil {
 		n.logger.Sugar().Errorw("Failed to broadcast commitments", "operator_address", n.OperatorAddress.Hex(), "error", err)
 		// Continue anyway - other nodes may have received
 	}
@@ -1721,7 +2094,15 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 		n.logger.Sugar().Debugw("Sending share to operator",
 			"operator_address", n.OperatorAddress.Hex(),
 			"target", op.OperatorAddress.Hex())
-		if err := n.transport.SendDKGShare(op, shares[op.OperatorAddress], session.SessionTimestamp); err != nil {
+		recipientKey, err := n.resolveShareEncryptionKey(op, session.SessionTimestamp)
+		if err != nil {
+			n.logger.Sugar().Warnw("Failed to resolve share encryption key for operator",
+				"operator_address", n.OperatorAddress.Hex(),
+				"target", op.OperatorAddress.Hex(),
+				"error", err)
+			continue
+		}
+		if err := n.transport.SendDKGShare(ctx, op, operators, recipientKey, shares[op.OperatorAddress], session.SessionTimestamp); err != nil {
 			n.logger.Sugar().Warnw("Failed to send share to operator",
 				"operator_address", n.OperatorAddress.Hex(),
 				"target", op.OperatorAddress.Hex(),
@@ -1730,79 +2111,170 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 		}
 	}
 
-	// Wait for all shares and commitments using channel-based signaling
+	// Wait for shares and commitments from every dealer. A dealer that withholds either
+	// no longer aborts the round: it is complained about below and dropped unless it
+	// justifies.
 	protocolTimeout := config.GetProtocolTimeoutForChain(n.ChainID)
-	if err := waitForShares(session, protocolTimeout); err != nil {
-		return err
-	}
-	if err := waitForCommitments(session, protocolTimeout); err != nil {
-		return err
-	}
+	if resumePhase < 2 {
+		if err := waitForShares(session, protocolTimeout); err != nil {
+			n.logger.Sugar().Warnw("Proceeding to complaint round without all DKG shares",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+		if err := waitForCommitments(session, protocolTimeout); err != nil {
+			n.logger.Sugar().Warnw("Proceeding to complaint round without all DKG commitments",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
 
-	// Update session to Phase 2 and persist
-	session.mu.Lock()
-	session.Phase = 2
-	session.mu.Unlock()
-	if err := n.saveSession(session); err != nil {
-		n.logger.Sugar().Warnw("Failed to persist DKG session after Phase 1",
-			"operator_address", n.OperatorAddress.Hex(),
-			"error", err)
+		// Update session to Phase 2 and persist
+		session.mu.Lock()
+		session.Phase = 2
+		session.mu.Unlock()
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist DKG session after Phase 1",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
 	}
 
-	// Phase 2: Verify and send acknowledgements
-	n.logger.Sugar().Infow("Starting DKG Phase 2", "operator_address", n.OperatorAddress.Hex(), "phase", "verify_and_ack")
+	// Phase 2: Verify shares, run the complaint round, then acknowledge qualified dealers
+	ctx = run.startPhase("verify_and_ack")
+	n.logger.Sugar().Infow("Starting DKG Phase 2", "operator_address", n.OperatorAddress.Hex(), "phase", "verify_complain_and_ack")
 
-	// Get shares and commitments from session (we know we have all of them now)
 	session.mu.RLock()
-	receivedShares := session.shares
-	receivedCommitments := session.commitments
+	receivedShares := copyShares(session.shares)
+	receivedCommitments := make(map[common.Address][]types.G2Point, len(session.commitments))
+	for dealerAddr, c := range session.commitments {
+		receivedCommitments[dealerAddr] = c
+	}
 	session.mu.RUnlock()
 
-	validShares := make(map[common.Address]*fr.Element)
-	for dealerAddr, share := range receivedShares {
-		commitments := receivedCommitments[dealerAddr]
-		if n.dkg.VerifyShare(share, commitments) {
-			validShares[dealerAddr] = share
-
-			// Skip sending ack to self — a dealer does not need to ack its own share.
+	// A complaint round that closed before a restart is not rerun: peers have moved on.
+	resolution := session.restoredComplaintResolution()
+	if resolution == nil {
+		// Complain about every dealer whose share is missing or does not verify.
+		var complaints []*types.Complaint
+		for _, op := range operators {
+			dealerAddr := op.OperatorAddress
 			if dealerAddr == n.OperatorAddress {
-				n.logger.Sugar().Debugw("Skipping self-ack", "operator_address", n.OperatorAddress.Hex(), "dealer_address", dealerAddr.Hex())
 				continue
 			}
-
-			// Find dealer's peer info for transport
-			var dealerPeer *peering.OperatorSetPeer
-			for _, op := range operators {
-				if op.OperatorAddress == dealerAddr {
-					dealerPeer = op
-					break
-				}
-			}
-
-			if dealerPeer == nil {
+			share, haveShare := receivedShares[dealerAddr]
+			dealerCommitments, haveCommitments := receivedCommitments[dealerAddr]
+
+			var reason string
+			switch {
+			case !haveCommitments:
+				reason = types.ComplaintReasonMissingCommitments
+			case !haveShare:
+				reason = types.ComplaintReasonMissingShare
+			case !n.dkg.VerifyShare(share, dealerCommitments):
+				n.logInvalidShareComplaint("dkg", sessionTimestamp, n.OperatorAddress, dealerAddr, share, dealerCommitments)
+				n.recordInvalidShareEvidence("dkg", sessionTimestamp, dealerAddr, share, dealerCommitments)
+				reason = types.ComplaintReasonInvalidShare
+			default:
 				continue
 			}
+			complaints = append(complaints, n.newComplaint(dealerAddr, sessionTimestamp, dealerCommitments, reason))
+		}
 
-			// Create acknowledgement for verified share using operator addresses
-			ack := eigenxcrypto.CreateAcknowledgement(n.OperatorAddress, dealerPeer.OperatorAddress, sessionTimestamp, share, commitments, n.signAcknowledgement)
+		if len(complaints) > 0 {
+			n.logger.Sugar().Warnw("Broadcasting DKG complaints",
+				"operator_address", n.OperatorAddress.Hex(),
+				"session_timestamp", sessionTimestamp,
+				"complaints", len(complaints))
+		}
+		_ = session.HandleReceivedComplaints(n.OperatorAddress, complaints)
+		if err := n.transport.BroadcastDKGComplaints(ctx, operators, complaints, session.SessionTimestamp); err != nil {
+			n.logger.Sugar().Warnw("Failed to deliver complaints to some operators",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
 
-			// Send acknowledgement to dealer
-			err := n.transport.SendDKGAcknowledgement(ack, dealerPeer, session.SessionTimestamp)
-			if err != nil {
-				n.logger.Sugar().Warnw("Failed to send acknowledgement",
-					"operator_address", n.OperatorAddress.Hex(),
-					"dealer_address", dealerPeer.OperatorAddress.Hex(),
-					"error", err)
-			} else {
-				n.logger.Sugar().Debugw("Sent acknowledgement",
-					"operator_address", n.OperatorAddress.Hex(),
-					"dealer_address", dealerAddr.Hex())
-			}
+		// Accused dealers justify as soon as a complaint reaches them (handleDKGComplaint), so
+		// once every operator has reported, only the justifications still in flight remain.
+		if err := waitForComplaints(ctx, session, protocolTimeout); err != nil {
+			n.logger.Sugar().Warnw("Closing complaint round without every operator's complaints",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+		if err := waitForJustifications(session, protocolTimeout/2); err != nil {
+			n.logger.Sugar().Warnw("Closing complaint round with unanswered complaints",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
 
-			n.logger.Sugar().Infow("Verified and acked share", "operator_address", n.OperatorAddress.Hex(), "dealer_address", dealerAddr.Hex())
+		resolution = n.resolveDKGComplaints(session)
+		if err := n.agreeQualifiedDealers(ctx, session, resolution.Qualified, protocolTimeout); err != nil {
+			return fmt.Errorf("DKG aborted: %w", err)
+		}
+		session.applyComplaintResolution(n.OperatorAddress, resolution)
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist DKG session after complaint round",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	}
+
+	if len(resolution.Qualified) < threshold {
+		return fmt.Errorf("insufficient qualified dealers after complaint round: got %d, need %d", len(resolution.Qualified), threshold)
+	}
+	if !resolution.IsQualified(n.OperatorAddress) {
+		return fmt.Errorf("this operator was disqualified in the complaint round: %s", resolution.Disqualified[n.OperatorAddress])
+	}
+
+	// Re-read shares: resolution swapped in shares re-sent to this node, and commitments
+	// a dealer re-sent in answer to a complaint may have arrived since.
+	session.mu.RLock()
+	receivedShares = copyShares(session.shares)
+	for dealerAddr, c := range session.commitments {
+		receivedCommitments[dealerAddr] = c
+	}
+	session.mu.RUnlock()
+
+	validShares := make(map[common.Address]*fr.Element)
+	for _, dealerAddr := range resolution.Qualified {
+		share := receivedShares[dealerAddr]
+		commitments := receivedCommitments[dealerAddr]
+		if !n.dkg.VerifyShare(share, commitments) {
+			// Defensive: resolution already verified any share revealed for this node.
+			n.logger.Sugar().Warnw("Qualified dealer's share still fails verification",
+				"operator_address", n.OperatorAddress.Hex(),
+				"dealer_address", dealerAddr.Hex())
+			continue
+		}
+		validShares[dealerAddr] = share
+
+		// Skip sending ack to self — a dealer does not need to ack its own share.
+		if dealerAddr == n.OperatorAddress {
+			n.logger.Sugar().Debugw("Skipping self-ack", "operator_address", n.OperatorAddress.Hex(), "dealer_address", dealerAddr.Hex())
+			continue
+		}
+
+		// Find dealer's peer info for transport
+		dealerPeer := n.findPeerByAddress(dealerAddr, operators)
+		if dealerPeer == nil {
+			continue
+		}
+
+		// Create acknowledgement for verified share using operator addresses
+		ack := eigenxcrypto.CreateAcknowledgement(n.OperatorAddress, dealerPeer.OperatorAddress, n.KeyID, sessionTimestamp, share, commitments, n.signAcknowledgement)
+
+		// Send acknowledgement to dealer
+		err := n.transport.SendDKGAcknowledgement(ctx, ack, dealerPeer, session.SessionTimestamp)
+		if err != nil {
+			n.logger.Sugar().Warnw("Failed to send acknowledgement",
+				"operator_address", n.OperatorAddress.Hex(),
+				"dealer_address", dealerPeer.OperatorAddress.Hex(),
+				"error", err)
 		} else {
-			n.logInvalidShareComplaint("dkg", sessionTimestamp, n.OperatorAddress, dealerAddr, share, commitments)
+			n.logger.Sugar().Debugw("Sent acknowledgement",
+				"operator_address", n.OperatorAddress.Hex(),
+				"dealer_address", dealerAddr.Hex())
 		}
+
+		n.logger.Sugar().Infow("Verified and acked share", "operator_address", n.OperatorAddress.Hex(), "dealer_address", dealerAddr.Hex())
 	}
 
 	// No need to store validShares globally - just use them for finalization later
@@ -1820,70 +2292,93 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 	}
 
 	// Update session to Phase 3 and persist
-	session.mu.Lock()
-	session.Phase = 3
-	session.mu.Unlock()
-	if err := n.saveSession(session); err != nil {
-		n.logger.Sugar().Warnw("Failed to persist DKG session after Phase 2",
-			"operator_address", n.OperatorAddress.Hex(),
-			"error", err)
+	if resumePhase < 3 {
+		session.mu.Lock()
+		session.Phase = 3
+		session.mu.Unlock()
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist DKG session after Phase 2",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
 	}
 
 	// Phase 3: Build Merkle Tree and Submit to Contract
+	ctx = run.startPhase("submit")
 	n.logger.Sugar().Infow("DKG Phase 3: Building merkle tree and submitting to contract",
 		"operator_address", n.OperatorAddress.Hex(),
 		"session", session.SessionTimestamp)
 
-	// Collect acknowledgements from session where I am the dealer
-	session.mu.RLock()
-	myAcks := make([]*types.Acknowledgement, 0)
-	if ackMap, ok := session.acks[n.OperatorAddress]; ok {
-		for _, ack := range ackMap {
-			myAcks = append(myAcks, ack)
+	// A node resumed after submitting reuses the tree it submitted
+	merkleTree, myAcks := session.submittedAckTree(n.OperatorAddress)
+	if merkleTree == nil {
+		// Collect acknowledgements from session where I am the dealer
+		session.mu.RLock()
+		myAcks = make([]*types.Acknowledgement, 0)
+		if ackMap, ok := session.acks[n.OperatorAddress]; ok {
+			for _, ack := range ackMap {
+				myAcks = append(myAcks, ack)
+			}
 		}
-	}
-	session.mu.RUnlock()
+		session.mu.RUnlock()
 
-	if len(myAcks) == 0 {
-		return fmt.Errorf("no acknowledgements collected as dealer")
-	}
+		if len(myAcks) == 0 {
+			return fmt.Errorf("no acknowledgements collected as dealer")
+		}
 
-	// Build merkle tree from collected acks
-	merkleTree, err := dkg.BuildAcknowledgementMerkleTree(myAcks)
-	if err != nil {
-		return fmt.Errorf("failed to build merkle tree: %w", err)
-	}
+		// Build merkle tree from collected acks
+		merkleTree, err = dkg.BuildAcknowledgementMerkleTree(myAcks)
+		if err != nil {
+			return fmt.Errorf("failed to build merkle tree: %w", err)
+		}
 
-	// Compute commitment hash
-	myCommitmentHash := eigenxcrypto.HashCommitment(commitments)
+		// Compute commitment hash
+		myCommitmentHash := eigenxcrypto.HashCommitment(commitments)
 
-	n.logger.Sugar().Infow("Merkle tree built successfully",
-		"num_acks", len(myAcks),
-		"merkle_root", fmt.Sprintf("0x%x", merkleTree.Root))
+		n.logger.Sugar().Infow("Merkle tree built successfully",
+			"num_acks", len(myAcks),
+			"merkle_root", fmt.Sprintf("0x%x", merkleTree.Root))
 
-	// Submit to contract with retry logic
-	err = n.submitCommitmentWithRetry(ctx, session.SessionTimestamp, myCommitmentHash, merkleTree.Root)
-	if err != nil {
-		return fmt.Errorf("failed to submit commitment after retries: %w", err)
-	}
+		// Submit to contract with retry logic
+		err = n.submitCommitmentWithRetry(ctx, session.SessionTimestamp, myCommitmentHash, merkleTree.Root)
+		if err != nil {
+			return fmt.Errorf("failed to submit commitment after retries: %w", err)
+		}
 
-	n.logger.Sugar().Infow("Commitment submitted to Base contract successfully",
-		"commitment_hash", fmt.Sprintf("0x%x", myCommitmentHash),
-		"merkle_root", fmt.Sprintf("0x%x", merkleTree.Root))
+		n.logger.Sugar().Infow("Commitment submitted to Base contract successfully",
+			"commitment_hash", fmt.Sprintf("0x%x", myCommitmentHash),
+			"merkle_root", fmt.Sprintf("0x%x", merkleTree.Root))
 
-	// Store in session
-	session.mu.Lock()
-	session.myAckMerkleTree = merkleTree
-	session.myAckCommitmentHash = myCommitmentHash
-	session.contractSubmitted = true
-	session.mu.Unlock()
+		// Store in session and persist, moving to Phase 4
+		session.recordAckSubmission(merkleTree, myAcks, myCommitmentHash)
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist DKG session after Phase 3",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	}
+	go n.watchShareComplaints(session)
+
+	// Only operators that acked get a proof: a disqualified or silent player has no leaf.
+	acked := make(map[common.Address]bool, len(myAcks))
+	for _, ack := range myAcks {
+		acked[ack.PlayerAddress] = true
+	}
+	ackedOperators := make([]*peering.OperatorSetPeer, 0, len(myAcks))
+	for _, op := range operators {
+		if acked[op.OperatorAddress] {
+			ackedOperators = append(ackedOperators, op)
+		}
+	}
 
 	// Phase 4: Broadcast commitments with proofs to all operators
+	ctx = run.startPhase("broadcast")
 	n.logger.Sugar().Infow("DKG Phase 4: Broadcasting commitments with proofs",
 		"operator_address", n.OperatorAddress.Hex())
 
 	err = n.transport.BroadcastCommitmentsWithProofs(
-		operators,
+		ctx,
+		ackedOperators,
 		session.SessionTimestamp,
 		commitments,
 		myAcks,
@@ -1895,8 +2390,9 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 	}
 
 	// Phase 5: Wait for and verify all operator broadcasts
+	run.startPhase("verify_broadcasts")
 	n.logger.Sugar().Infow("DKG Phase 5: Waiting for operator verifications",
-		"expected_verifications", len(operators)-1)
+		"expected_verifications", len(resolution.Qualified)-1)
 
 	err = n.WaitForVerifications(session.SessionTimestamp, protocolTimeout)
 	if err != nil {
@@ -1907,6 +2403,7 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 	}
 
 	// Phase 6: Finalize
+	run.startPhase("finalize")
 	n.logger.Sugar().Infow("DKG Phase 6: Finalizing key share",
 		"operator_address", n.OperatorAddress.Hex())
 
@@ -1931,6 +2428,7 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 	allCommitments := make([][]types.G2Point, 0, len(trustedShares))
 	participantIDs := make([]common.Address, 0, len(trustedShares))
 	finalShares := make(map[common.Address]*fr.Element, len(trustedShares))
+	dealerCommitments := make(map[common.Address][]types.G2Point, len(trustedShares))
 
 	for _, op := range operators {
 
@@ -1939,6 +2437,7 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 				allCommitments = append(allCommitments, comm)
 				participantIDs = append(participantIDs, op.OperatorAddress)
 				finalShares[op.OperatorAddress] = share
+				dealerCommitments[op.OperatorAddress] = comm
 			}
 		}
 	}
@@ -1950,12 +2449,17 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 	// Use finalShares (polynomial-verified AND merkle-verified, with matching commitments) for finalization
 	keyVersion := n.dkg.FinalizeKeyShare(finalShares, allCommitments, participantIDs)
 	keyVersion.Version = session.SessionTimestamp // Use session timestamp as version
+	keyVersion.Generation = generation
+	keyVersion.DealerCommitments = dealerCommitments
 	// Commitments[0] is the constant term of the combined commitment polynomial,
 	// which equals the master public key: MPK = sum_i(C_i[0]) where C_i is dealer i's commitment.
 	// Cache it before overwriting Commitments so operators can serve it for client threshold agreement.
 	if len(keyVersion.Commitments) > 0 {
 		mpk := keyVersion.Commitments[0]
 		keyVersion.MasterPublicKey = &mpk
+		// The combined commitments are also the group commitments clients use to
+		// verify each operator's partial signature.
+		keyVersion.GroupCommitments = keyVersion.Commitments
 	} else {
 		n.logger.Sugar().Errorw("DKG produced key version with empty commitments - MasterPublicKey will be nil",
 			"operator_address", n.OperatorAddress.Hex(),
@@ -1982,12 +2486,20 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 		return fmt.Errorf("failed to persist active version pointer: %w", err)
 	}
 
+	// A rotation replaces the active generation; the previous one keeps serving
+	// until its retirement window has passed.
+	if previous := n.keyStore.GetActiveVersion(); previous != nil && previous.Generation != generation {
+		retireAfter := n.generationRetireAfter(context.Background(), generation)
+		n.retireGeneration(previous.Generation, session.SessionTimestamp+int64(retireAfter.Seconds()))
+	}
+
 	// Only add to keystore after successful persistence
 	n.keyStore.AddVersion(keyVersion)
 
 	n.logger.Sugar().Infow("DKG complete",
 		"operator_address", n.OperatorAddress.Hex(),
-		"version", keyVersion.Version)
+		"version", keyVersion.Version,
+		"generation", keyVersion.Generation)
 	return nil
 }
 
@@ -1999,7 +2511,16 @@ func (n *Node) RunDKG(sessionTimestamp int64) error {
 // Pass 0 to disable pinned-height agreement and fall back to head reads (used by unit
 // tests that don't run a real chain).
 func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock int64) error {
-	ctx := context.Background()
+	start := time.Now()
+	run := n.startProtocolRun(metrics.ProtocolReshareExisting, sessionTimestamp)
+	err := n.runReshareAsExistingOperator(run, sessionTimestamp, triggerBlock)
+	run.end(err)
+	n.metrics.ObserveProtocolRun(metrics.ProtocolReshareExisting, start, err)
+	return err
+}
+
+func (n *Node) runReshareAsExistingOperator(run *protocolRun, sessionTimestamp int64, triggerBlock int64) error {
+	ctx := run.startPhase("setup")
 	n.logger.Sugar().Infow("Starting reshare as existing operator",
 		"operator_address", n.OperatorAddress.Hex(),
 		"session_timestamp", sessionTimestamp,
@@ -2066,59 +2587,91 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 	// there were no active version, so GetActiveVersion is non-nil.
 	sourceVersion := n.keyStore.GetActiveVersion().Version
 
-	// Phase 1: Generate dealer polynomials anchored at each dealer's current share.
-	// Each dealer i samples f_i with f_i(0)=x_i and broadcasts commitments + per-recipient shares.
-	// Recipients then combine received shares via Lagrange to derive a refreshed share of the same
-	// master secret. This works for both existing and newly joining operators.
-	shares, commitments, err := n.resharer.GenerateNewShares(currentShare, newThreshold)
-	if err != nil {
-		return err
-	}
-
-	session, err := n.createSession("reshare", operators, sessionTimestamp)
+	session, resumed, err := n.openSession("reshare", operators, sessionTimestamp)
 	if err != nil {
 		return fmt.Errorf("failed to create reshare session: %w", err)
 	}
-	session.TriggerBlockNumber = triggerBlock
 	defer n.cleanupSession(session.SessionTimestamp)
+	resumePhase := session.currentPhase()
 
 	// Persist initial session state
-	if err := n.saveSession(session); err != nil {
-		n.logger.Sugar().Warnw("Failed to persist initial reshare session",
+	if !resumed {
+		session.TriggerBlockNumber = triggerBlock
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist initial reshare session",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	} else {
+		n.logger.Sugar().Infow("Resuming reshare session",
 			"operator_address", n.OperatorAddress.Hex(),
-			"error", err)
+			"session_timestamp", sessionTimestamp,
+			"phase", resumePhase)
 	}
 
-	// Store own share and commitment BEFORE broadcasting to other nodes.
-	// This prevents a race where a fast peer receives our commitment, verifies,
-	// and sends an ack back before we've stored our own commitment in the
-	// session — causing verifyAcknowledgement to reject the ack with
-	// "dealer commitments unavailable".
-	_ = session.HandleReceivedShare(n.OperatorAddress, shares[n.OperatorAddress])
-	_ = session.HandleReceivedCommitment(n.OperatorAddress, commitments, sourceVersion)
+	// Phase 1: Generate dealer polynomials anchored at each dealer's current share.
+	// Each dealer i samples f_i with f_i(0)=x_i and broadcasts commitments + per-recipient shares.
+	// Recipients then combine received shares via Lagrange to derive a refreshed share of the same
+	// master secret. This works for both existing and newly joining operators.
+	//
+	// A dealer resumed after a restart re-sends the polynomial it dealt before, from the
+	// source version it dealt from.
+	ctx = run.startPhase("deal")
+	shares, commitments, dealtSourceVersion := session.dealtShares(n.OperatorAddress)
+	if shares != nil {
+		sourceVersion = dealtSourceVersion
+	} else {
+		shares, commitments, err = n.resharer.GenerateNewShares(currentShare, newThreshold)
+		if err != nil {
+			return err
+		}
 
-	// Broadcast commitments (advertising the source version we dealt from)
-	if err := n.transport.BroadcastReshareCommitments(operators, commitments, session.SessionTimestamp, sourceVersion); err != nil {
-		n.logger.Sugar().Errorw("Failed to broadcast reshare commitments", "operator_address", n.OperatorAddress.Hex(), "error", err)
-		// Continue anyway - other nodes may have received
+		// Store own share and commitment BEFORE broadcasting to other nodes.
+		// This prevents a race where a fast peer receives our commitment, verifies,
+		// and sends an ack back before we've stored our own commitment in the
+		// session — causing verifyAcknowledgement to reject the ack with
+		// "dealer commitments unavailable".
+		_ = session.HandleReceivedShare(n.OperatorAddress, shares[n.OperatorAddress])
+		_ = session.HandleReceivedCommitment(n.OperatorAddress, commitments, sourceVersion)
+
+		// Retain the shares we generated as a dealer so we can re-serve any of them to a
+		// peer that missed our original send (see on-demand share fetch during finalize).
+		session.SetMyGeneratedShares(shares)
+
+		// Persist the dealing before any of it leaves this node
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist reshare dealing",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
 	}
-
-	// Retain the shares we generated as a dealer so we can re-serve any of them to a
-	// peer that missed our original send (see on-demand share fetch during finalize).
-	session.SetMyGeneratedShares(shares)
 	// Also retain at the node level, keyed by session, so we can still serve an on-demand
 	// fetch AFTER this session is torn down on completion (docs/012 Layer 3a). This is the
 	// fix for the live incident's 503 trigger: a lagging peer fetching our share after we
 	// finished the round must succeed, not abort.
 	n.retainGeneratedShares(session.SessionTimestamp, shares)
 
+	// Broadcast commitments (advertising the source version we dealt from)
+	if err := n.transport.BroadcastReshareCommitments(ctx, operators, commitments, session.SessionTimestamp, sourceVersion); err != nil {
+		n.logger.Sugar().Errorw("Failed to broadcast reshare commitments", "operator_address", n.OperatorAddress.Hex(), "error", err)
+		// Continue anyway - other nodes may have received
+	}
+
 	// Send shares to all operators
 	for _, op := range operators {
 
 		if op.OperatorAddress == n.OperatorAddress {
 			continue // Already stored above
 		}
-		if err := n.transport.SendReshareShare(op, shares[op.OperatorAddress], session.SessionTimestamp); err != nil {
+		recipientKey, err := n.resolveShareEncryptionKey(op, session.SessionTimestamp)
+		if err != nil {
+			n.logger.Sugar().Warnw("Failed to resolve share encryption key for operator",
+				"operator_address", n.OperatorAddress.Hex(),
+				"target", op.OperatorAddress.Hex(),
+				"error", err)
+			continue
+		}
+		if err := n.transport.SendReshareShare(ctx, op, operators, recipientKey, shares[op.OperatorAddress], session.SessionTimestamp); err != nil {
 			n.logger.Sugar().Warnw("Failed to send reshare share to operator",
 				"operator_address", n.OperatorAddress.Hex(),
 				"target", op.OperatorAddress.Hex(),
@@ -2164,24 +2717,27 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 		}
 		return count
 	}
-	if err := waitForN(session, requiredContributions, protocolTimeout, countOnChainShares, "shares"); err != nil {
-		return err
-	}
-	if err := waitForN(session, requiredContributions, protocolTimeout, countOnChainCommitments, "commitments"); err != nil {
-		return err
-	}
+	if resumePhase < 2 {
+		if err := waitForN(ctx, session, requiredContributions, protocolTimeout, countOnChainShares, "shares"); err != nil {
+			return err
+		}
+		if err := waitForN(ctx, session, requiredContributions, protocolTimeout, countOnChainCommitments, "commitments"); err != nil {
+			return err
+		}
 
-	// Update session to Phase 2 and persist
-	session.mu.Lock()
-	session.Phase = 2
-	session.mu.Unlock()
-	if err := n.saveSession(session); err != nil {
-		n.logger.Sugar().Warnw("Failed to persist reshare session after Phase 1",
-			"operator_address", n.OperatorAddress.Hex(),
-			"error", err)
+		// Update session to Phase 2 and persist
+		session.mu.Lock()
+		session.Phase = 2
+		session.mu.Unlock()
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist reshare session after Phase 1",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
 	}
 
 	// Phase 1b: Verify shares and send acknowledgements
+	ctx = run.startPhase("verify_and_ack")
 	n.logger.Sugar().Infow("Reshare Phase 1b: Verifying shares and sending acknowledgements",
 		"operator_address", n.OperatorAddress.Hex())
 
@@ -2229,10 +2785,10 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 			}
 
 			// Create acknowledgement for verified share using operator addresses
-			ack := eigenxcrypto.CreateAcknowledgement(n.OperatorAddress, dealerPeer.OperatorAddress, sessionTimestamp, share, commitments, n.signAcknowledgement)
+			ack := eigenxcrypto.CreateAcknowledgement(n.OperatorAddress, dealerPeer.OperatorAddress, n.KeyID, sessionTimestamp, share, commitments, n.signAcknowledgement)
 
 			// Send acknowledgement to dealer
-			err := n.transport.SendReshareAcknowledgement(ack, dealerPeer, session.SessionTimestamp)
+			err := n.transport.SendReshareAcknowledgement(ctx, ack, dealerPeer, session.SessionTimestamp)
 			if err != nil {
 				n.logger.Sugar().Warnw("Failed to send reshare acknowledgement",
 					"operator_address", n.OperatorAddress.Hex(),
@@ -2249,6 +2805,7 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 				"dealer_address", dealerAddr.Hex())
 		} else {
 			n.logInvalidShareComplaint("reshare", sessionTimestamp, n.OperatorAddress, dealerAddr, share, commitments)
+			n.recordInvalidShareEvidence("reshare", sessionTimestamp, dealerAddr, share, commitments)
 			invalidDealers = append(invalidDealers, dealerAddr)
 		}
 	}
@@ -2296,31 +2853,24 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 		}
 	}
 
+	// Update session to Phase 3 and persist
+	if resumePhase < 3 {
+		session.mu.Lock()
+		session.Phase = 3
+		session.mu.Unlock()
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist reshare session after acknowledgements",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	}
+
 	// Phase 2: Build Merkle Tree and Submit to Contract
+	ctx = run.startPhase("submit")
 	n.logger.Sugar().Infow("Reshare Phase 2: Building merkle tree and submitting to contract",
 		"operator_address", n.OperatorAddress.Hex(),
 		"session", session.SessionTimestamp)
 
-	// Collect acknowledgements from session where I am the dealer
-	session.mu.RLock()
-	myAcks := make([]*types.Acknowledgement, 0)
-	if ackMap, ok := session.acks[n.OperatorAddress]; ok {
-		for _, ack := range ackMap {
-			myAcks = append(myAcks, ack)
-		}
-	}
-	session.mu.RUnlock()
-
-	if len(myAcks) == 0 {
-		return fmt.Errorf("no acknowledgements collected as dealer in reshare")
-	}
-
-	// Build merkle tree from collected acks
-	merkleTree, err := reshare.BuildAcknowledgementMerkleTree(myAcks)
-	if err != nil {
-		return fmt.Errorf("failed to build merkle tree in reshare: %w", err)
-	}
-
 	// Compute commitment hash from my commitments (from session)
 	session.mu.RLock()
 	myCommitments, ok := session.commitments[n.OperatorAddress]
@@ -2329,42 +2879,71 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 		return fmt.Errorf("my commitments not found in reshare")
 	}
 
-	// The ON-CHAIN submitted hash for reshare binds the source version (docs/013 Change 2),
-	// so every node can verify each dealer's P2P-advertised SourceVersion against shared
-	// registry state at finalize. This is intentionally NOT the ack/merkle hash: the ack
-	// subsystem (merkle tree + ack signatures) keeps using the plain HashCommitment, and
-	// nothing compares the two, so they are free to diverge.
-	onChainCommitmentHash := eigenxcrypto.HashReshareCommitment(myCommitments, sourceVersion)
+	// A node resumed after submitting reuses the tree it submitted
+	merkleTree, myAcks := session.submittedAckTree(n.OperatorAddress)
+	if merkleTree == nil {
+		// Collect acknowledgements from session where I am the dealer
+		session.mu.RLock()
+		myAcks = make([]*types.Acknowledgement, 0)
+		if ackMap, ok := session.acks[n.OperatorAddress]; ok {
+			for _, ack := range ackMap {
+				myAcks = append(myAcks, ack)
+			}
+		}
+		session.mu.RUnlock()
 
-	n.logger.Sugar().Infow("Merkle tree built successfully in reshare",
-		"num_acks", len(myAcks),
-		"merkle_root", fmt.Sprintf("0x%x", merkleTree.Root))
+		if len(myAcks) == 0 {
+			return fmt.Errorf("no acknowledgements collected as dealer in reshare")
+		}
 
-	// Submit to contract with retry logic
-	err = n.submitCommitmentWithRetry(ctx, session.SessionTimestamp, onChainCommitmentHash, merkleTree.Root)
-	if err != nil {
-		return fmt.Errorf("failed to submit commitment in reshare after retries: %w", err)
-	}
+		// Build merkle tree from collected acks
+		merkleTree, err = reshare.BuildAcknowledgementMerkleTree(myAcks)
+		if err != nil {
+			return fmt.Errorf("failed to build merkle tree in reshare: %w", err)
+		}
 
-	n.logger.Sugar().Infow("Commitment submitted to Base contract successfully in reshare",
-		"commitment_hash", fmt.Sprintf("0x%x", onChainCommitmentHash),
-		"source_version", sourceVersion,
-		"merkle_root", fmt.Sprintf("0x%x", merkleTree.Root))
+		// The ON-CHAIN submitted hash for reshare binds the source version (docs/013 Change 2),
+		// so every node can verify each dealer's P2P-advertised SourceVersion against shared
+		// registry state at finalize. This is intentionally NOT the ack/merkle hash: the ack
+		// subsystem (merkle tree + ack signatures) keeps using the plain HashCommitment, and
+		// nothing compares the two, so they are free to diverge.
+		onChainCommitmentHash := eigenxcrypto.HashReshareCommitment(myCommitments, sourceVersion)
 
-	// Store in session. myAckCommitmentHash holds the PLAIN hash (ack/merkle domain); the
-	// on-chain submission above used HashReshareCommitment (source-version-bound), so the two
-	// intentionally differ for reshare — see the field doc.
-	session.mu.Lock()
-	session.myAckMerkleTree = merkleTree
-	session.myAckCommitmentHash = eigenxcrypto.HashCommitment(myCommitments)
-	session.contractSubmitted = true
-	session.mu.Unlock()
+		n.logger.Sugar().Infow("Merkle tree built successfully in reshare",
+			"num_acks", len(myAcks),
+			"merkle_root", fmt.Sprintf("0x%x", merkleTree.Root))
+
+		// Submit to contract with retry logic
+		err = n.submitCommitmentWithRetry(ctx, session.SessionTimestamp, onChainCommitmentHash, merkleTree.Root)
+		if err != nil {
+			return fmt.Errorf("failed to submit commitment in reshare after retries: %w", err)
+		}
+
+		n.logger.Sugar().Infow("Commitment submitted to Base contract successfully in reshare",
+			"commitment_hash", fmt.Sprintf("0x%x", onChainCommitmentHash),
+			"source_version", sourceVersion,
+			"merkle_root", fmt.Sprintf("0x%x", merkleTree.Root))
+
+		// Store in session and persist, moving to Phase 4. myAckCommitmentHash holds the
+		// PLAIN hash (ack/merkle domain); the on-chain submission above used
+		// HashReshareCommitment (source-version-bound), so the two intentionally differ for
+		// reshare — see the field doc.
+		session.recordAckSubmission(merkleTree, myAcks, eigenxcrypto.HashCommitment(myCommitments))
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist reshare session after commitment submission",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	}
+	go n.watchShareComplaints(session)
 
 	// Phase 3: Broadcast commitments with proofs
+	ctx = run.startPhase("broadcast")
 	n.logger.Sugar().Infow("Reshare Phase 3: Broadcasting commitments with proofs",
 		"operator_address", n.OperatorAddress.Hex())
 
 	err = n.transport.BroadcastCommitmentsWithProofs(
+		ctx,
 		operators,
 		session.SessionTimestamp,
 		myCommitments,
@@ -2377,6 +2956,7 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 	}
 
 	// Phase 4: Wait for verifications
+	run.startPhase("verify_broadcasts")
 	n.logger.Sugar().Infow("Reshare Phase 4: Waiting for operator verifications",
 		"operator_address", n.OperatorAddress.Hex())
 
@@ -2389,6 +2969,7 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 	}
 
 	// Phase 5: Finalize reshare
+	ctx = run.startPhase("finalize")
 	n.logger.Sugar().Infow("Reshare Phase 5: Finalizing key share",
 		"operator_address", n.OperatorAddress.Hex())
 
@@ -2506,7 +3087,7 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 			continue
 		}
 		// Missing locally — fetch on demand.
-		share, ferr := n.fetchAndVerifyReshareShare(session, dealer)
+		share, ferr := n.fetchAndVerifyReshareShare(ctx, session, dealer)
 		if ferr != nil {
 			n.logger.Sugar().Warnw("Aborting reshare finalize: could not obtain a dealer in the agreed set",
 				"operator_address", n.OperatorAddress.Hex(),
@@ -2558,6 +3139,8 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 		}
 		mpkCopy := *currentVersion.MasterPublicKey
 		newKeyVersion.MasterPublicKey = &mpkCopy
+		// A reshare refreshes the shares of the same master secret generation.
+		newKeyVersion.Generation = currentVersion.Generation
 
 		// VALIDATE BEFORE COMMIT (docs/011 § step 5, docs/012 Layer 1). Recompute the
 		// group public key implied by the agreed dealers' commitments and require it to
@@ -2587,6 +3170,10 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 		}
 	}
 
+	newKeyVersion.GroupCommitments = n.reshareGroupCommitments(session, participantIDsForFinalize)
+	newKeyVersion.DealerCommitments = dealerCommitmentsFor(session, participantIDsForFinalize)
+	newKeyVersion.SourceVersion = srcVersion
+
 	// Persist new key version BEFORE adding to keystore
 	// This ensures we fail if persistence fails, preventing state inconsistency
 	if err := n.persistence.SaveKeyShareVersion(newKeyVersion); err != nil {
@@ -2619,7 +3206,16 @@ func (n *Node) RunReshareAsExistingOperator(sessionTimestamp int64, triggerBlock
 
 // RunReshareAsNewOperator executes reshare protocol as a new operator (no existing shares).
 func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int64) error {
-	ctx := context.Background()
+	start := time.Now()
+	run := n.startProtocolRun(metrics.ProtocolReshareNew, sessionTimestamp)
+	err := n.runReshareAsNewOperator(run, sessionTimestamp, triggerBlock)
+	run.end(err)
+	n.metrics.ObserveProtocolRun(metrics.ProtocolReshareNew, start, err)
+	return err
+}
+
+func (n *Node) runReshareAsNewOperator(run *protocolRun, sessionTimestamp int64, triggerBlock int64) error {
+	ctx := run.startPhase("setup")
 	n.logger.Sugar().Infow("Starting reshare as new operator (joining existing cluster)",
 		"operator_address", n.OperatorAddress.Hex(),
 		"session_timestamp", sessionTimestamp,
@@ -2664,21 +3260,32 @@ func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int6
 	// Create reshare instance
 	n.resharer = reshare.NewReshare(n.OperatorAddress, operators)
 
-	// Create session for this reshare (as recipient only)
-	session, err := n.createSession("reshare", operators, sessionTimestamp)
+	// Create session for this reshare (as recipient only), or pick up the one restored
+	// after a restart
+	session, resumed, err := n.openSession("reshare", operators, sessionTimestamp)
 	if err != nil {
 		return fmt.Errorf("failed to create reshare session: %w", err)
 	}
 	defer n.cleanupSession(session.SessionTimestamp)
+	resumePhase := session.currentPhase()
 
 	// Persist initial session state
-	if err := n.saveSession(session); err != nil {
-		n.logger.Sugar().Warnw("Failed to persist initial reshare session (new operator)",
+	if !resumed {
+		session.TriggerBlockNumber = triggerBlock
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist initial reshare session (new operator)",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
+	} else {
+		n.logger.Sugar().Infow("Resuming reshare session (new operator)",
 			"operator_address", n.OperatorAddress.Hex(),
-			"error", err)
+			"session_timestamp", sessionTimestamp,
+			"phase", resumePhase)
 	}
 
 	// New operators DON'T generate shares - only receive from existing operators.
+	ctx = run.startPhase("receive")
 	// We require a threshold of existing operators rather than all of them, so resharing
 	// can proceed even if some existing operators are offline (per KMS-010 recommendation).
 	existingOperators := len(operators) - numNewOperators
@@ -2709,11 +3316,23 @@ func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int6
 		}
 		return count
 	}
-	if err := waitForN(session, requiredContributions, protocolTimeout, countExistingShares, "shares"); err != nil {
-		return fmt.Errorf("failed to receive shares: %w", err)
-	}
-	if err := waitForN(session, requiredContributions, protocolTimeout, countExistingCommitments, "commitments"); err != nil {
-		return fmt.Errorf("failed to receive commitments: %w", err)
+	if resumePhase < 2 {
+		if err := waitForN(ctx, session, requiredContributions, protocolTimeout, countExistingShares, "shares"); err != nil {
+			return fmt.Errorf("failed to receive shares: %w", err)
+		}
+		if err := waitForN(ctx, session, requiredContributions, protocolTimeout, countExistingCommitments, "commitments"); err != nil {
+			return fmt.Errorf("failed to receive commitments: %w", err)
+		}
+
+		// Update session to Phase 2 and persist
+		session.mu.Lock()
+		session.Phase = 2
+		session.mu.Unlock()
+		if err := n.saveSession(session); err != nil {
+			n.logger.Sugar().Warnw("Failed to persist reshare session after receiving shares (new operator)",
+				"operator_address", n.OperatorAddress.Hex(),
+				"error", err)
+		}
 	}
 
 	// Copy shares and commitments from session under lock, filtering to existing operators only.
@@ -2734,6 +3353,7 @@ func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int6
 	session.mu.RUnlock()
 
 	// Verify all dealer shares and send acknowledgements to prevent dealer equivocation.
+	ctx = run.startPhase("verify_and_ack")
 	validShares := make(map[common.Address]*fr.Element)
 	for _, op := range operators {
 		dealerAddr := op.OperatorAddress
@@ -2746,10 +3366,10 @@ func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int6
 			validShares[dealerAddr] = share
 
 			// Create acknowledgement for verified share using operator addresses
-			ack := eigenxcrypto.CreateAcknowledgement(n.OperatorAddress, op.OperatorAddress, sessionTimestamp, share, commitments, n.signAcknowledgement)
+			ack := eigenxcrypto.CreateAcknowledgement(n.OperatorAddress, op.OperatorAddress, n.KeyID, sessionTimestamp, share, commitments, n.signAcknowledgement)
 
 			// Send acknowledgement to dealer
-			err := n.transport.SendReshareAcknowledgement(ack, op, session.SessionTimestamp)
+			err := n.transport.SendReshareAcknowledgement(ctx, ack, op, session.SessionTimestamp)
 			if err != nil {
 				n.logger.Sugar().Warnw("Failed to send reshare acknowledgement (new operator)",
 					"operator_address", n.OperatorAddress.Hex(),
@@ -2766,10 +3386,12 @@ func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int6
 				"dealer_address", dealerAddr.Hex())
 		} else {
 			n.logInvalidShareComplaint("reshare-new-operator", sessionTimestamp, n.OperatorAddress, dealerAddr, share, commitments)
+			n.recordInvalidShareEvidence("reshare-new-operator", sessionTimestamp, dealerAddr, share, commitments)
 		}
 	}
 
 	// Wait for dealer commitment broadcasts (merkle tree verification)
+	run.startPhase("verify_broadcasts")
 	n.logger.Sugar().Infow("Reshare (new operator): Waiting for operator commitment broadcasts",
 		"operator_address", n.OperatorAddress.Hex(),
 		"expected_verifications", len(operators)-1)
@@ -2781,6 +3403,8 @@ func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int6
 		n.logger.Sugar().Infow("All operator broadcasts verified successfully in reshare (new operator)")
 	}
 
+	ctx = run.startPhase("finalize")
+
 	// Build trusted dealer set: intersection of polynomial-verified shares and merkle-verified operators.
 	session.mu.RLock()
 	verifiedOps := make(map[common.Address]bool, len(session.verifiedOperators))
@@ -2859,7 +3483,7 @@ func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int6
 			finalShares[dealer] = share
 			continue
 		}
-		share, ferr := n.fetchAndVerifyReshareShare(session, dealer)
+		share, ferr := n.fetchAndVerifyReshareShare(ctx, session, dealer)
 		if ferr != nil {
 			n.logger.Sugar().Warnw("Aborting new-operator reshare finalize: could not obtain a dealer in the agreed set",
 				"operator_address", n.OperatorAddress.Hex(), "missing_dealer", dealer.Hex(), "error", ferr)
@@ -2892,15 +3516,19 @@ func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int6
 	// ComputeNewKeyShare defaulted to (docs/013 Change 1) — keeps the next round's expected
 	// dealer set stable across nodes.
 	newKeyVersion.ParticipantIDs = sessionParticipantIDs(operators)
+	newKeyVersion.GroupCommitments = n.reshareGroupCommitments(session, participantIDs)
+	newKeyVersion.DealerCommitments = dealerCommitmentsFor(session, participantIDs)
+	newKeyVersion.SourceVersion = agreedSrcVersion
 
 	// Fetch MPK from existing operators using threshold agreement
 	// New operators cannot derive the MPK from reshare protocol data alone
-	mpk, err := n.fetchMPKFromPeers(ctx, operators)
+	mpk, generation, err := n.fetchMPKFromPeers(ctx, operators)
 	if err != nil {
 		n.logger.Sugar().Warnw("Failed to fetch MPK from peers during new operator join - this operator will not contribute to client MPK threshold agreement until next reshare or restart",
 			"error", err)
 	} else {
 		newKeyVersion.MasterPublicKey = mpk
+		newKeyVersion.Generation = generation
 	}
 
 	// Persist first key version BEFORE adding to keystore (critical for new operator)
@@ -2938,6 +3566,31 @@ func (n *Node) RunReshareAsNewOperator(sessionTimestamp int64, triggerBlock int6
 	return nil
 }
 
+// reshareGroupCommitments computes the group commitments of a finalized reshare from the
+// agreed dealers' commitments. They only let clients verify partial signatures one by
+// one, so a failure is logged and the version is stored without them rather than
+// aborting the reshare; clients then fall back to subset search.
+func (n *Node) reshareGroupCommitments(session *ProtocolSession, dealers []common.Address) []types.G2Point {
+	group, err := reshare.ComputeGroupCommitments(dealers, dealerCommitmentsFor(session, dealers))
+	if err != nil {
+		n.logger.Sugar().Warnw("Failed to compute reshare group commitments; clients cannot verify this version's partial signatures individually",
+			"operator_address", n.OperatorAddress.Hex(),
+			"session_timestamp", session.SessionTimestamp,
+			"error", err)
+		return nil
+	}
+	return group
+}
+
+// dealerCommitmentsFor returns the session commitments of each dealer.
+func dealerCommitmentsFor(session *ProtocolSession, dealers []common.Address) map[common.Address][]types.G2Point {
+	commitments := make(map[common.Address][]types.G2Point, len(dealers))
+	for _, dealer := range dealers {
+		commitments[dealer] = session.GetCommitmentsFor(dealer)
+	}
+	return commitments
+}
+
 // signAppIDWithVersion computes a partial BLS signature for appID using a pre-resolved key version.
 func (n *Node) signAppIDWithVersion(appID string, keyVersion *types.KeyShareVersion) (types.G1Point, error) {
 	if keyVersion == nil || keyVersion.PrivateShare == nil {
@@ -2956,17 +3609,46 @@ func (n *Node) signAppIDWithVersion(appID string, keyVersion *types.KeyShareVers
 	return *partialSig, nil
 }
 
+// decryptionSharesWithVersion computes the decryption shares of the ciphertexts with the
+// given C1s, each with its proof, using the private share of the specified key version.
+func (n *Node) decryptionSharesWithVersion(appID string, c1s []types.G2Point, keyVersion *types.KeyShareVersion) ([]types.DecryptionShare, error) {
+	if keyVersion == nil || keyVersion.PrivateShare == nil {
+		return nil, fmt.Errorf("no private share available")
+	}
+
+	privateShare := new(fr.Element).Set(keyVersion.PrivateShare)
+	shares := make([]types.DecryptionShare, len(c1s))
+	for i, c1 := range c1s {
+		share, err := eigenxcrypto.ComputeDecryptionShare(appID, privateShare, c1)
+		if err != nil {
+			return nil, fmt.Errorf("ciphertext %d: %w", i, err)
+		}
+		shares[i] = *share
+	}
+	return shares, nil
+}
+
+// signAppMessageWithVersion computes the partial signature over an application's message
+// under the app's derived signing key, using the private share of the specified key version.
+func (n *Node) signAppMessageWithVersion(appID string, message []byte, keyVersion *types.KeyShareVersion) (types.G1Point, error) {
+	if keyVersion == nil || keyVersion.PrivateShare == nil {
+		return types.G1Point{}, fmt.Errorf("no private share available")
+	}
+
+	privateShare := new(fr.Element).Set(keyVersion.PrivateShare)
+	partialSig, err := eigenxcrypto.SignAppMessage(appID, privateShare, message)
+	if err != nil {
+		return types.G1Point{}, err
+	}
+	return *partialSig, nil
+}
+
 // SignAppID signs an application ID using the key version active at attestationTime.
 // attestationTime == 0 means "use the currently active version".
 func (n *Node) SignAppID(appID string, attestationTime int64) (types.G1Point, error) {
-	var keyVersion *types.KeyShareVersion
-	if attestationTime > 0 {
-		keyVersion = n.keyStore.GetKeyVersionAtTime(attestationTime)
-		if keyVersion == nil {
-			return types.G1Point{}, fmt.Errorf("no key version found for attestation time %d", attestationTime)
-		}
-	} else {
-		keyVersion = n.keyStore.GetActiveVersion()
+	keyVersion, err := n.keyVersionFor(nil, attestationTime)
+	if err != nil {
+		return types.G1Point{}, err
 	}
 	return n.signAppIDWithVersion(appID, keyVersion)
 }
@@ -3010,11 +3692,9 @@ func waitForCommitments(session *ProtocolSession, timeout time.Duration) error {
 }
 
 // waitForN polls until getCount() returns at least required, or the timeout elapses.
-// getCount is called while session.mu.RLock is held.
-func waitForN(session *ProtocolSession, required int, timeout time.Duration, getCount func() int, label string) error {
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
-
+// getCount is called while session.mu.RLock is held. The wait is a span under ctx
+// recording what was awaited and how much of it arrived.
+func waitForN(ctx context.Context, session *ProtocolSession, required int, timeout time.Duration, getCount func() int, label string) (err error) {
 	maxPossible := len(session.Operators)
 	if required < 0 {
 		required = 0
@@ -3023,10 +3703,23 @@ func waitForN(session *ProtocolSession, required int, timeout time.Duration, get
 		required = maxPossible
 	}
 
+	ctx, span := tracing.Tracer().Start(ctx, "wait_for_n", trace.WithAttributes(
+		attribute.String("kms.wait.label", label),
+		attribute.Int("kms.wait.required", required),
+	))
+	received := 0
+	defer func() {
+		span.SetAttributes(attribute.Int("kms.wait.received", received))
+		tracing.End(span, err)
+	}()
+
 	if required == 0 {
 		return nil
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
 	ticker := time.NewTicker(50 * time.Millisecond)
 	defer ticker.Stop()
 
@@ -3034,13 +3727,13 @@ func waitForN(session *ProtocolSession, required int, timeout time.Duration, get
 		select {
 		case <-ctx.Done():
 			session.mu.RLock()
-			received := getCount()
+			received = getCount()
 			session.mu.RUnlock()
 			return fmt.Errorf("timeout waiting for %s: got %d/%d", label, received, required)
 
 		case <-ticker.C:
 			session.mu.RLock()
-			received := getCount()
+			received = getCount()
 			session.mu.RUnlock()
 
 			if received >= required {
@@ -3054,14 +3747,14 @@ func waitForN(session *ProtocolSession, required int, timeout time.Duration, get
 // Use this instead of waitForShares when fewer than all operators are expected to contribute
 // (e.g., new operators joining don't send shares, so existing operators wait for N-numNew shares).
 func waitForNShares(session *ProtocolSession, required int, timeout time.Duration) error {
-	return waitForN(session, required, timeout, func() int { return len(session.shares) }, "shares")
+	return waitForN(context.Background(), session, required, timeout, func() int { return len(session.shares) }, "shares")
 }
 
 // waitForNCommitments waits for at least required commitments using polling.
 // Use this instead of waitForCommitments when fewer than all operators are expected to contribute
 // (e.g., new operators joining don't broadcast commitments).
 func waitForNCommitments(session *ProtocolSession, required int, timeout time.Duration) error {
-	return waitForN(session, required, timeout, func() int { return len(session.commitments) }, "commitments")
+	return waitForN(context.Background(), session, required, timeout, func() int { return len(session.commitments) }, "commitments")
 }
 
 // waitForAcks waits for at least required acknowledgements to be received for a specific dealer using polling.
@@ -3133,57 +3826,13 @@ func (n *Node) RunDKGPhase1() (map[common.Address]*fr.Element, []types.G2Point,
 
 // verifyMessage verifies an authenticated message using the sender's BN254 public key
 func (n *Node) verifyMessage(authMsg *types.AuthenticatedMessage, senderPeer *peering.OperatorSetPeer) error {
-	// Verify payload hash
-	actualHash := crypto.Keccak256(authMsg.Payload)
-	if !bytes.Equal(actualHash, authMsg.Hash[:]) {
-		return fmt.Errorf("payload digest mismatch")
-	}
-
 	n.logger.Sugar().Infow("Verifying message signature",
 		zap.String("sender_address", senderPeer.OperatorAddress.String()),
 		zap.String("public_key", senderPeer.WrappedPublicKey.ECDSAAddress.String()),
 		zap.String("curve_type", senderPeer.CurveType.String()),
 		zap.String("hash", fmt.Sprintf("0x%x", authMsg.Hash)),
 	)
-
-	//nolint:staticcheck
-	if senderPeer.CurveType == config.CurveTypeBN254 {
-		// Verify signature using BN254 (must use VerifySolidityCompatible to match SignSolidityCompatible)
-		sig, err := bn254.NewSignatureFromBytes(authMsg.Signature)
-		if err != nil {
-			return fmt.Errorf("invalid signature format: %w", err)
-		}
-
-		// Type assert to BN254 public key
-		bn254PubKey, ok := senderPeer.WrappedPublicKey.PublicKey.(*bn254.PublicKey)
-		if !ok {
-			return fmt.Errorf("sender public key is not BN254 type")
-		}
-
-		isValid, err := sig.VerifySolidityCompatible(bn254PubKey, authMsg.Hash)
-		if err != nil {
-			return fmt.Errorf("signature verification error: %w", err)
-		}
-		if !isValid {
-			return fmt.Errorf("signature verification failed")
-		}
-	} else if senderPeer.CurveType == config.CurveTypeECDSA {
-		sig, err := ecdsa.NewSignatureFromBytes(authMsg.Signature)
-		if err != nil {
-			return fmt.Errorf("invalid ECDSA signature format: %w", err)
-		}
-		verified, err := sig.VerifyWithAddress(actualHash, senderPeer.WrappedPublicKey.ECDSAAddress)
-		if err != nil {
-			return fmt.Errorf("ECDSA signature verification error: %w", err)
-		}
-		if !verified {
-			return fmt.Errorf("ECDSA signature verification failed")
-		}
-
-	} else {
-		return fmt.Errorf("unsupported curve type for sender: %v", senderPeer.CurveType)
-	}
-	return nil
+	return senderPeer.VerifyMessage(authMsg)
 }
 
 // trustedDealerIDs returns the subset of validShares whose dealers also passed
@@ -3237,13 +3886,16 @@ func (n *Node) findPeerByAddress(address common.Address, peers []*peering.Operat
 	return nil
 }
 
-// submitCommitmentWithRetry submits a commitment to the Base contract with exponential backoff retry logic
+// submitCommitmentWithRetry submits a commitment to the Base contract with exponential backoff retry logic.
+// The commitment is filed under the node's key's registry epoch for the session
+// (types.CommitmentEpoch), so each key a server holds gets its own registry slot.
 func (n *Node) submitCommitmentWithRetry(
 	ctx context.Context,
-	epoch int64,
+	sessionTimestamp int64,
 	commitmentHash [32]byte,
 	merkleRoot [32]byte,
 ) error {
+	epoch := types.CommitmentEpoch(n.KeyID, sessionTimestamp)
 	const maxRetries = 3
 	backoffDurations := []time.Duration{
 		2 * time.Second,
@@ -3251,18 +3903,31 @@ func (n *Node) submitCommitmentWithRetry(
 		8 * time.Second,
 	}
 
+	ctx, span := tracing.Tracer().Start(ctx, "submit_commitment",
+		trace.WithAttributes(tracing.AttrSessionTimestamp.Int64(sessionTimestamp)))
+	attempts := 0
+
 	var lastErr error
+	var err error
+	defer func() {
+		n.metrics.ObserveCommitmentSubmission(err)
+		span.SetAttributes(attribute.Int("kms.submit.attempts", attempts))
+		tracing.End(span, err)
+	}()
 
 	for attempt := 0; attempt < maxRetries; attempt++ {
+		attempts = attempt + 1
 		n.logger.Sugar().Infow("Submitting commitment to Base contract",
 			"attempt", attempt+1,
 			"max_attempts", maxRetries,
-			"session_timestamp", epoch,
+			"session_timestamp", sessionTimestamp,
+			"epoch", epoch,
 			"commitment_hash", fmt.Sprintf("0x%x", commitmentHash),
 			"merkle_root", fmt.Sprintf("0x%x", merkleRoot))
 
 		// Call contract submission (synchronous, waits for tx to be mined)
-		_, err := n.baseContractCaller.SubmitCommitment(
+		n.metrics.IncCommitmentAttempt()
+		_, err = n.baseContractCaller.SubmitCommitment(
 			ctx,
 			n.commitmentRegistryAddress,
 			epoch,
@@ -3273,7 +3938,7 @@ func (n *Node) submitCommitmentWithRetry(
 		if err == nil {
 			n.logger.Sugar().Infow("Commitment submitted successfully to Base chain",
 				"attempt", attempt+1,
-				"session_timestamp", epoch)
+				"session_timestamp", sessionTimestamp)
 			return nil
 		}
 
@@ -3290,19 +3955,26 @@ func (n *Node) submitCommitmentWithRetry(
 
 			select {
 			case <-ctx.Done():
-				return fmt.Errorf("context cancelled during retry backoff: %w", ctx.Err())
+				err = fmt.Errorf("context cancelled during retry backoff: %w", ctx.Err())
+				return err
 			case <-time.After(backoffDuration):
 				// Continue to next retry
 			}
 		}
 	}
 
-	return fmt.Errorf("failed to submit commitment after %d attempts: %w", maxRetries, lastErr)
+	err = fmt.Errorf("failed to submit commitment after %d attempts: %w", maxRetries, lastErr)
+	return err
 }
 
-func buildAcknowledgementSigningMessage(dealerAddress, playerAddress common.Address, epoch int64, shareHash, commitmentHash [32]byte) []byte {
-	// message = dealerAddress || playerAddress || epoch || shareHash || commitmentHash
-	msg := make([]byte, 0, 20+20+8+32+32)
+// buildAcknowledgementSigningMessage returns the bytes a player signs to acknowledge a
+// share. epoch is the key's registry epoch for the session (types.CommitmentEpoch).
+func buildAcknowledgementSigningMessage(keyID string, dealerAddress, playerAddress common.Address, epoch int64, shareHash, commitmentHash [32]byte) []byte {
+	// message = dealerAddress || playerAddress || epoch || shareHash || commitmentHash || len(keyID) || keyID
+	if keyID == "" {
+		keyID = types.DefaultKeyID
+	}
+	msg := make([]byte, 0, 20+20+8+32+32+1+len(keyID))
 	epochBytes := make([]byte, 8)
 	binary.BigEndian.PutUint64(epochBytes, uint64(epoch))
 	msg = append(msg, dealerAddress.Bytes()...)
@@ -3310,12 +3982,14 @@ func buildAcknowledgementSigningMessage(dealerAddress, playerAddress common.Addr
 	msg = append(msg, epochBytes...)
 	msg = append(msg, shareHash[:]...)
 	msg = append(msg, commitmentHash[:]...)
+	msg = append(msg, byte(len(keyID)))
+	msg = append(msg, keyID...)
 	return msg
 }
 
 // signAcknowledgement signs acknowledgement fields using the transport signer.
 func (n *Node) signAcknowledgement(dealerAddress, playerAddress common.Address, epoch int64, shareHash, commitmentHash [32]byte) []byte {
-	message := buildAcknowledgementSigningMessage(dealerAddress, playerAddress, epoch, shareHash, commitmentHash)
+	message := buildAcknowledgementSigningMessage(n.KeyID, dealerAddress, playerAddress, epoch, shareHash, commitmentHash)
 
 	// Sign using transport signer (ECDSA)
 	signature, err := n.transportSigner.SignMessage(message)
@@ -3347,6 +4021,9 @@ func (n *Node) verifyAcknowledgement(
 	if ack.SessionTimestamp != sessionTimestamp {
 		return fmt.Errorf("ack session timestamp mismatch: got %d expected %d", ack.SessionTimestamp, sessionTimestamp)
 	}
+	if !types.SameKeyID(ack.KeyID, n.KeyID) {
+		return fmt.Errorf("ack key mismatch: got %q expected %q", ack.KeyID, n.KeyID)
+	}
 	if len(ack.Signature) == 0 {
 		return fmt.Errorf("ack signature is empty")
 	}
@@ -3362,40 +4039,45 @@ func (n *Node) verifyAcknowledgement(
 		return fmt.Errorf("ack commitment hash mismatch")
 	}
 
-	msg := buildAcknowledgementSigningMessage(ack.DealerAddress, ack.PlayerAddress, ack.SessionTimestamp, ack.ShareHash, ack.CommitmentHash)
-	msgHash := crypto.Keccak256Hash(msg)
+	epoch := types.CommitmentEpoch(ack.KeyID, ack.SessionTimestamp)
+	msg := buildAcknowledgementSigningMessage(ack.KeyID, ack.DealerAddress, ack.PlayerAddress, epoch, ack.ShareHash, ack.CommitmentHash)
+	return verifyPeerSignature(senderPeer, crypto.Keccak256Hash(msg), ack.Signature, "ack")
+}
 
-	switch senderPeer.CurveType {
+// verifyPeerSignature checks a transport-signer signature over msgHash against peer's
+// registered key. what names the signed object in error messages.
+func verifyPeerSignature(peer *peering.OperatorSetPeer, msgHash common.Hash, signature []byte, what string) error {
+	switch peer.CurveType {
 	case config.CurveTypeBN254:
-		sig, err := bn254.NewSignatureFromBytes(ack.Signature)
+		sig, err := bn254.NewSignatureFromBytes(signature)
 		if err != nil {
-			return fmt.Errorf("invalid BN254 ack signature format: %w", err)
+			return fmt.Errorf("invalid BN254 %s signature format: %w", what, err)
 		}
-		bn254PubKey, ok := senderPeer.WrappedPublicKey.PublicKey.(*bn254.PublicKey)
+		bn254PubKey, ok := peer.WrappedPublicKey.PublicKey.(*bn254.PublicKey)
 		if !ok {
 			return fmt.Errorf("sender public key is not BN254 type")
 		}
 		valid, err := sig.VerifySolidityCompatible(bn254PubKey, msgHash)
 		if err != nil {
-			return fmt.Errorf("BN254 ack signature verification error: %w", err)
+			return fmt.Errorf("BN254 %s signature verification error: %w", what, err)
 		}
 		if !valid {
-			return fmt.Errorf("BN254 ack signature verification failed")
+			return fmt.Errorf("BN254 %s signature verification failed", what)
 		}
 	case config.CurveTypeECDSA:
-		sig, err := ecdsa.NewSignatureFromBytes(ack.Signature)
+		sig, err := ecdsa.NewSignatureFromBytes(signature)
 		if err != nil {
-			return fmt.Errorf("invalid ECDSA ack signature format: %w", err)
+			return fmt.Errorf("invalid ECDSA %s signature format: %w", what, err)
 		}
-		valid, err := sig.VerifyWithAddress(msgHash[:], senderPeer.WrappedPublicKey.ECDSAAddress)
+		valid, err := sig.VerifyWithAddress(msgHash[:], peer.WrappedPublicKey.ECDSAAddress)
 		if err != nil {
-			return fmt.Errorf("ECDSA ack signature verification error: %w", err)
+			return fmt.Errorf("ECDSA %s signature verification error: %w", what, err)
 		}
 		if !valid {
-			return fmt.Errorf("ECDSA ack signature verification failed")
+			return fmt.Errorf("ECDSA %s signature verification failed", what)
 		}
 	default:
-		return fmt.Errorf("unsupported curve type for ack verification: %s", senderPeer.CurveType)
+		return fmt.Errorf("unsupported curve type for %s verification: %s", what, peer.CurveType)
 	}
 
 	return nil
@@ -3484,10 +4166,16 @@ func (n *Node) WaitForVerifications(sessionTimestamp int64, timeout time.Duratio
 	}
 
 	// For reshare, only expect threshold-1 verifications (matching the threshold
-	// semantics used for shares). For DKG, expect all n-1.
+	// semantics used for shares). For DKG, expect every other qualified dealer once
+	// the complaint round has closed, otherwise all n-1.
 	// Cap at receivedShareCount-1 since we can only verify operators we received
 	// shares from, and some may go offline before broadcasting.
 	expectedVerifications := len(session.Operators) - 1
+	session.mu.RLock()
+	if session.qualifiedDealers != nil {
+		expectedVerifications = len(session.qualifiedDealers) - 1
+	}
+	session.mu.RUnlock()
 	if session.Type == "reshare" {
 		session.mu.RLock()
 		receivedShareCount := len(session.shares)
@@ -3527,9 +4215,10 @@ func (n *Node) WaitForVerifications(sessionTimestamp int64, timeout time.Duratio
 	}
 }
 
-// fetchMPKFromPeers fetches the master public key from peer operators using threshold agreement.
-// Used by new operators joining via reshare who cannot derive the MPK from protocol data alone.
-func (n *Node) fetchMPKFromPeers(ctx context.Context, operators []*peering.OperatorSetPeer) (*types.G2Point, error) {
+// fetchMPKFromPeers fetches the master public key and its master secret generation from
+// peer operators using threshold agreement. Used by new operators joining via reshare who
+// cannot derive either from protocol data alone.
+func (n *Node) fetchMPKFromPeers(ctx context.Context, operators []*peering.OperatorSetPeer) (*types.G2Point, uint32, error) {
 	// Build peer list excluding self, then compute threshold from full operator set
 	peers := make([]*peering.OperatorSetPeer, 0, len(operators))
 	for _, op := range operators {
@@ -3539,7 +4228,8 @@ func (n *Node) fetchMPKFromPeers(ctx context.Context, operators []*peering.Opera
 	}
 
 	type mpkResult struct {
-		mpk *types.G2Point
+		mpk        *types.G2Point
+		generation uint32
 	}
 
 	resultChan := make(chan mpkResult, len(peers))
@@ -3552,7 +4242,7 @@ func (n *Node) fetchMPKFromPeers(ctx context.Context, operators []*peering.Opera
 		go func(peer *peering.OperatorSetPeer) {
 			defer wg.Done()
 
-			req, err := http.NewRequestWithContext(ctx, http.MethodGet, peer.SocketAddress+"/pubkey", nil)
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, peer.SocketAddress+types.KeyRoutePrefix(n.KeyID)+"/pubkey", nil)
 			if err != nil {
 				n.logger.Sugar().Warnw("Failed to create MPK request", "peer", peer.SocketAddress, "error", err)
 				return
@@ -3572,6 +4262,7 @@ func (n *Node) fetchMPKFromPeers(ctx context.Context, operators []*peering.Opera
 
 			var response struct {
 				MasterPublicKey *types.G2Point `json:"masterPublicKey"`
+				Generation      uint32         `json:"generation"`
 			}
 			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
 				n.logger.Sugar().Warnw("Failed to decode MPK response", "peer", peer.SocketAddress, "error", err)
@@ -3588,7 +4279,7 @@ func (n *Node) fetchMPKFromPeers(ctx context.Context, operators []*peering.Opera
 				return
 			}
 
-			resultChan <- mpkResult{mpk: response.MasterPublicKey}
+			resultChan <- mpkResult{mpk: response.MasterPublicKey, generation: response.Generation}
 		}(op)
 	}
 
@@ -3597,22 +4288,24 @@ func (n *Node) fetchMPKFromPeers(ctx context.Context, operators []*peering.Opera
 		close(resultChan)
 	}()
 
-	// Threshold agreement: group by compressed bytes, pick the one with enough votes
+	// Threshold agreement: group by compressed bytes and generation, pick the one with enough votes
 	mpkVotes := make(map[string][]*types.G2Point)
+	generations := make(map[string]uint32)
 	for res := range resultChan {
-		key := hex.EncodeToString(res.mpk.CompressedBytes)
+		key := fmt.Sprintf("%d/%s", res.generation, hex.EncodeToString(res.mpk.CompressedBytes))
 		mpkVotes[key] = append(mpkVotes[key], res.mpk)
+		generations[key] = res.generation
 	}
 
 	// Use threshold based on the full operator set size (including self)
 	threshold := dkg.CalculateThreshold(len(operators))
-	for _, votes := range mpkVotes {
+	for key, votes := range mpkVotes {
 		if len(votes) >= threshold {
-			return votes[0], nil
+			return votes[0], generations[key], nil
 		}
 	}
 
-	return nil, fmt.Errorf("failed to reach threshold agreement on MPK: needed %d, best had %d votes", threshold, maxVotes(mpkVotes))
+	return nil, 0, fmt.Errorf("failed to reach threshold agreement on MPK: needed %d, best had %d votes", threshold, maxVotes(mpkVotes))
 }
 
 func maxVotes(votes map[string][]*types.G2Point) int {
@@ -679,7 +679,10 @@ func testNodeRestart_EmptyState(t *testing.T) {
 	t.Logf("✓ Node handles empty state correctly on first run")
 }
 
-// testNodeRestart_IncompleteSessions tests cleanup of incomplete protocol sessions
+// testNodeRestart_IncompleteSessions tests that a restarted node deletes the incomplete
+// protocol sessions it must not resume: one past the protocol timeout, and one whose
+// operator set no longer matches the current one. Both are otherwise well formed, so
+// resuming them is refused for the stated reason alone.
 func testNodeRestart_IncompleteSessions(t *testing.T) {
 	tmpDir := t.TempDir()
 	testLogger, _ := logger.NewLogger(&logger.LoggerConfig{Debug: false})
@@ -718,32 +721,45 @@ func testNodeRestart_IncompleteSessions(t *testing.T) {
 	persistence1, err := persistenceBadger.NewBadgerPersistence(tmpDir, testLogger)
 	require.NoError(t, err)
 
-	// Manually save an incomplete session (simulating crash during DKG)
-	incompleteSession := &persistence.ProtocolSessionState{
+	// Manually save two incomplete sessions (simulating a crash during DKG) over the
+	// node's own operator set, with a well-formed share
+	share := new(fr.Element).SetUint64(7).String()
+	expiredSession := &persistence.ProtocolSessionState{
 		SessionTimestamp:  1234567890,
 		Type:              "dkg",
 		Phase:             2, // Incomplete (not finalized)
-		StartTime:         time.Now().Unix(),
+		StartTime:         time.Now().Add(-24 * time.Hour).Unix(),
 		OperatorAddresses: []string{chainConfig.OperatorAccountAddress1},
-		Shares:            map[string]string{"0x0000000000000000000000000000000000000001": "test"},
+		Shares:            map[string]string{chainConfig.OperatorAccountAddress1: share},
+		Commitments:       map[string][]types.G2Point{},
+		Acknowledgements:  map[string]map[string]*types.Acknowledgement{},
+	}
+	// Unexpired, but dealt among an operator that has since left the set
+	staleSetSession := &persistence.ProtocolSessionState{
+		SessionTimestamp:  1234567891,
+		Type:              "dkg",
+		Phase:             2,
+		StartTime:         time.Now().Unix(),
+		OperatorAddresses: []string{chainConfig.OperatorAccountAddress1, "0x0000000000000000000000000000000000000002"},
+		Shares:            map[string]string{chainConfig.OperatorAccountAddress1: share},
 		Commitments:       map[string][]types.G2Point{},
 		Acknowledgements:  map[string]map[string]*types.Acknowledgement{},
 	}
 
-	err = persistence1.SaveProtocolSession(incompleteSession)
-	require.NoError(t, err)
+	require.NoError(t, persistence1.SaveProtocolSession(expiredSession))
+	require.NoError(t, persistence1.SaveProtocolSession(staleSetSession))
 
-	// Verify session was saved
+	// Verify sessions were saved
 	sessions, err := persistence1.ListProtocolSessions()
 	require.NoError(t, err)
-	require.Len(t, sessions, 1)
+	require.Len(t, sessions, 2)
 
 	err = persistence1.Close()
 	require.NoError(t, err)
 
-	t.Logf("Saved incomplete session: %d (phase %d)", incompleteSession.SessionTimestamp, incompleteSession.Phase)
+	t.Logf("Saved expired session %d and stale operator set session %d", expiredSession.SessionTimestamp, staleSetSession.SessionTimestamp)
 
-	// Phase 2: Restart node - should clean up incomplete session
+	// Phase 2: Restart node - should delete both sessions rather than resume them
 	persistence2, err := persistenceBadger.NewBadgerPersistence(tmpDir, testLogger)
 	require.NoError(t, err)
 	defer func() { _ = persistence2.Close() }()
@@ -794,17 +810,21 @@ func testNodeRestart_IncompleteSessions(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	// Start should clean up incomplete sessions
+	// Start should delete the expired and unresumable sessions
 	err = node.Start()
 	require.NoError(t, err)
 	defer func() { _ = node.Stop() }()
 
-	// Verify session was cleaned up
-	sessionsAfter, err := persistence2.ListProtocolSessions()
+	loaded, err := persistence2.LoadProtocolSession(expiredSession.SessionTimestamp)
+	require.NoError(t, err)
+	assert.Nil(t, loaded, "Expired session not cleaned up")
+	loaded, err = persistence2.LoadProtocolSession(staleSetSession.SessionTimestamp)
 	require.NoError(t, err)
-	assert.Empty(t, sessionsAfter, "Incomplete session not cleaned up")
+	assert.Nil(t, loaded, "Session over a changed operator set not cleaned up")
+	assert.Nil(t, node.getSession(expiredSession.SessionTimestamp), "Expired session registered for resume")
+	assert.Nil(t, node.getSession(staleSetSession.SessionTimestamp), "Session over a changed operator set registered for resume")
 
-	t.Logf("✓ Incomplete session successfully cleaned up on restart")
+	t.Logf("✓ Expired and unresumable sessions cleaned up on restart")
 }
 
 // Helper function to create single-node peering for isolated tests
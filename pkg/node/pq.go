@@ -0,0 +1,86 @@
+package node
+
+import (
+	"crypto/mlkem"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// appPQKey returns this node's ML-KEM-768 decapsulation key for appID.
+//
+// The keys derive from a random seed generated on first use and persisted (sealed at
+// rest when encryption at rest is enabled). The seed never leaves the node: the app's PQ
+// secret is shared across the operators' keys (see crypto.EncryptForAppPQ), so a
+// threshold of them must release theirs. It deliberately has no relation to the key
+// share or the transport keys: anything derived from a discrete-log secret would fall
+// to the same quantum adversary the PQ ciphertext version defends against.
+func (n *Node) appPQKey(appID string) (*mlkem.DecapsulationKey768, error) {
+	seed, err := n.loadOrCreatePQKeySeed()
+	if err != nil {
+		return nil, err
+	}
+	return crypto.DeriveAppPQKey(seed, appID)
+}
+
+// loadOrCreatePQKeySeed returns the node's PQ key seed, generating and persisting one
+// if none is stored. A load error is returned rather than treated as a first run, since
+// replacing a seed that exists makes this node's share of every ciphertext naming it
+// undecryptable.
+func (n *Node) loadOrCreatePQKeySeed() ([]byte, error) {
+	n.pqKeySeedMu.Lock()
+	defer n.pqKeySeedMu.Unlock()
+
+	if n.pqKeySeed != nil {
+		return n.pqKeySeed, nil
+	}
+	stored, err := n.persistence.LoadPQKeySeed()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PQ key seed: %w", err)
+	}
+	if stored != nil {
+		if len(stored.Seed) != crypto.PQSeedSize {
+			return nil, fmt.Errorf("stored PQ key seed is %d bytes, expected %d", len(stored.Seed), crypto.PQSeedSize)
+		}
+		n.pqKeySeed = stored.Seed
+		return n.pqKeySeed, nil
+	}
+
+	seed := make([]byte, crypto.PQSeedSize)
+	if _, err := rand.Read(seed); err != nil {
+		return nil, fmt.Errorf("failed to generate PQ key seed: %w", err)
+	}
+	if err := n.persistence.SavePQKeySeed(&types.PQKeySeed{Seed: seed, CreatedAt: time.Now().Unix()}); err != nil {
+		return nil, fmt.Errorf("failed to save PQ key seed: %w", err)
+	}
+	n.pqKeySeed = seed
+	n.logger.Sugar().Infow("Generated PQ key seed", "operator_address", n.OperatorAddress.Hex())
+	return seed, nil
+}
+
+// appPQPublicKeyAnnouncement builds the signed announcement of this node's ML-KEM
+// encapsulation key for appID, served on /v1/app/pq-public-key.
+func (n *Node) appPQPublicKeyAnnouncement(appID string) ([]byte, error) {
+	dk, err := n.appPQKey(appID)
+	if err != nil {
+		return nil, err
+	}
+	msg := types.AppPQPublicKey{
+		OperatorAddress:  n.OperatorAddress,
+		AppID:            appID,
+		EncapsulationKey: dk.EncapsulationKey().Bytes(),
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal PQ public key: %w", err)
+	}
+	authMsg, err := n.transportSigner.CreateAuthenticatedMessage(msgBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign PQ public key: %w", err)
+	}
+	return json.Marshal(authMsg)
+}
@@ -1,6 +1,7 @@
 package node
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -248,7 +249,7 @@ func TestWaitForN_ErrorMessageContainsLabel(t *testing.T) {
 		shares:    make(map[common.Address]*fr.Element),
 	}
 
-	err := waitForN(session, 2, 80*time.Millisecond, func() int { return 0 }, "widgets")
+	err := waitForN(context.Background(), session, 2, 80*time.Millisecond, func() int { return 0 }, "widgets")
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "widgets")
 	require.Contains(t, err.Error(), "0/2")
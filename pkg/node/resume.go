@@ -0,0 +1,365 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	eigenxcrypto "github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/merkle"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Crash recovery for in-progress DKG and reshare sessions.
+//
+// saveSession persists a session at every phase transition. When the node restarts
+// before a session's deadline, RestoreState rebuilds it and registers it, so peers'
+// messages for it are accepted again, and Start reruns its protocol. The run functions
+// pick the rebuilt session up through openSession and skip the work already done:
+//
+//   - a dealer re-sends the shares it dealt instead of dealing a second polynomial,
+//     which peers would see as equivocation;
+//   - a DKG whose complaint round closed keeps its qualified dealer set;
+//   - a node whose commitment is on-chain rebuilds the same ack merkle tree instead of
+//     submitting again.
+//
+// Everything else is replayed. Peers reject shares, acks and complaints they already
+// hold as duplicates, so replaying a phase is harmless. Messages that arrived after the
+// last save are lost and handled like messages that never arrived; in particular a DKG
+// that crashed inside its complaint round waits the round out for the complaints it lost.
+
+// restoreOperatorsTimeout bounds the operator set read RestoreState makes to rebuild
+// incomplete sessions.
+const restoreOperatorsTimeout = 30 * time.Second
+
+// restoreSession rebuilds a ProtocolSession from its persisted state. operators is the
+// current operator set; a session whose operator set has changed cannot be resumed.
+func (n *Node) restoreSession(state *persistence.ProtocolSessionState, operators []*peering.OperatorSetPeer) (*ProtocolSession, error) {
+	switch state.Type {
+	case "dkg", "reshare":
+	default:
+		return nil, fmt.Errorf("unknown session type %q", state.Type)
+	}
+	if !sameOperatorSet(hexToAddresses(state.OperatorAddresses), sessionParticipantIDs(operators)) {
+		return nil, fmt.Errorf("operator set changed since the session started")
+	}
+
+	session := &ProtocolSession{
+		SessionTimestamp:        state.SessionTimestamp,
+		Type:                    state.Type,
+		Phase:                   state.Phase,
+		StartTime:               time.Unix(state.StartTime, 0),
+		Operators:               operators,
+		Generation:              state.Generation,
+		TriggerBlockNumber:      state.TriggerBlockNumber,
+		commitments:             make(map[common.Address][]types.G2Point, len(state.Commitments)),
+		acks:                    make(map[common.Address]map[common.Address]*types.Acknowledgement),
+		sourceVersions:          make(map[common.Address]int64, len(state.SourceVersions)),
+		sharesCompleteChan:      make(chan bool, 1),
+		commitmentsCompleteChan: make(chan bool, 1),
+		acksCompleteChan:        make(chan bool, 1),
+		verifiedOperators:       make(map[common.Address]bool, len(state.VerifiedOperators)),
+		restored:                true,
+	}
+	for _, op := range operators {
+		session.acks[op.OperatorAddress] = make(map[common.Address]*types.Acknowledgement)
+	}
+
+	var err error
+	if session.shares, err = parseShareMap(state.Shares); err != nil {
+		return nil, fmt.Errorf("invalid received shares: %w", err)
+	}
+	if len(state.GeneratedShares) > 0 {
+		if session.myGeneratedShares, err = parseShareMap(state.GeneratedShares); err != nil {
+			return nil, fmt.Errorf("invalid generated shares: %w", err)
+		}
+	}
+	for addr, c := range state.Commitments {
+		session.commitments[common.HexToAddress(addr)] = c
+	}
+	for dealer, byPlayer := range state.Acknowledgements {
+		dealerAddr := common.HexToAddress(dealer)
+		if session.acks[dealerAddr] == nil {
+			session.acks[dealerAddr] = make(map[common.Address]*types.Acknowledgement, len(byPlayer))
+		}
+		for player, ack := range byPlayer {
+			session.acks[dealerAddr][common.HexToAddress(player)] = ack
+		}
+	}
+	for addr, v := range state.SourceVersions {
+		session.sourceVersions[common.HexToAddress(addr)] = v
+	}
+	if state.QualifiedDealers != nil {
+		session.qualifiedDealers = make(map[common.Address]bool, len(state.QualifiedDealers))
+		for _, addr := range hexToAddresses(state.QualifiedDealers) {
+			session.qualifiedDealers[addr] = true
+		}
+	}
+	for _, addr := range hexToAddresses(state.VerifiedOperators) {
+		session.verifiedOperators[addr] = true
+	}
+	if state.ContractSubmitted {
+		if err := session.restoreAckMerkleTree(n.OperatorAddress, state.AckMerklePlayers, state.AckMerkleRoot); err != nil {
+			return nil, err
+		}
+	}
+
+	// The handlers only signal completion on the message that completes a set, and
+	// that message may be among those restored.
+	if len(session.shares) == len(operators) {
+		session.sharesCompleteChan <- true
+	}
+	if len(session.commitments) == len(operators) {
+		session.commitmentsCompleteChan <- true
+	}
+	return session, nil
+}
+
+// restoreAckMerkleTree rebuilds the ack merkle tree this node (dealer) submitted on-chain
+// from the acks of players, and checks it against the submitted root.
+func (s *ProtocolSession) restoreAckMerkleTree(dealer common.Address, players []string, root string) error {
+	acks := make([]*types.Acknowledgement, 0, len(players))
+	for _, player := range hexToAddresses(players) {
+		ack := s.acks[dealer][player]
+		if ack == nil {
+			return fmt.Errorf("acknowledgement from %s in the submitted merkle tree is missing", player.Hex())
+		}
+		acks = append(acks, ack)
+		s.ackMerklePlayers = append(s.ackMerklePlayers, player)
+	}
+	tree, err := dkg.BuildAcknowledgementMerkleTree(acks)
+	if err != nil {
+		return fmt.Errorf("failed to rebuild ack merkle tree: %w", err)
+	}
+	if tree == nil || fmt.Sprintf("0x%x", tree.Root) != root {
+		return fmt.Errorf("rebuilt ack merkle tree does not match submitted root %s", root)
+	}
+	s.myAckMerkleTree = tree
+	s.myAckCommitmentHash = eigenxcrypto.HashCommitment(s.commitments[dealer])
+	s.contractSubmitted = true
+	return nil
+}
+
+// restoreIncompleteSession rebuilds an unexpired session found at startup and registers
+// it for Start to resume. It returns an error if the session cannot be resumed.
+func (n *Node) restoreIncompleteSession(state *persistence.ProtocolSessionState, operators []*peering.OperatorSetPeer) error {
+	session, err := n.restoreSession(state, operators)
+	if err != nil {
+		return err
+	}
+
+	n.sessionMutex.Lock()
+	n.activeSessions[session.SessionTimestamp] = session
+	n.restoredSessions = append(n.restoredSessions, session)
+	n.sessionMutex.Unlock()
+
+	n.logger.Sugar().Infow("Restored incomplete protocol session",
+		"operator_address", n.OperatorAddress.Hex(),
+		"session_timestamp", session.SessionTimestamp,
+		"type", session.Type,
+		"phase", session.Phase,
+		"shares", len(session.shares),
+		"commitments", len(session.commitments))
+	return nil
+}
+
+// fetchOperatorsForRestore reads the current operator set for RestoreState.
+func (n *Node) fetchOperatorsForRestore() ([]*peering.OperatorSetPeer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), restoreOperatorsTimeout)
+	defer cancel()
+	return n.fetchCurrentOperators(ctx, n.AVSAddress, n.OperatorSetId)
+}
+
+// resumeRestoredSessions reruns the protocol of every session RestoreState rebuilt.
+func (n *Node) resumeRestoredSessions() {
+	n.sessionMutex.Lock()
+	sessions := n.restoredSessions
+	n.restoredSessions = nil
+	n.sessionMutex.Unlock()
+
+	for _, session := range sessions {
+		go n.resumeSession(session)
+	}
+}
+
+// resumeSession reruns the protocol a restored session belongs to. The role is decided as
+// at an interval boundary: a reshare node with shares is an existing operator.
+func (n *Node) resumeSession(session *ProtocolSession) {
+	n.logger.Sugar().Infow("Resuming protocol session",
+		"operator_address", n.OperatorAddress.Hex(),
+		"session_timestamp", session.SessionTimestamp,
+		"type", session.Type,
+		"phase", session.Phase)
+
+	var err error
+	switch {
+	case session.Type == "dkg" && session.Generation > 0:
+		err = n.RunRotationDKG(session.SessionTimestamp, session.Generation)
+	case session.Type == "dkg":
+		err = n.RunDKG(session.SessionTimestamp)
+	case n.hasExistingShares():
+		err = n.RunReshareAsExistingOperator(session.SessionTimestamp, session.TriggerBlockNumber)
+	default:
+		err = n.RunReshareAsNewOperator(session.SessionTimestamp, session.TriggerBlockNumber)
+	}
+	if err != nil {
+		n.logger.Sugar().Errorw("Resumed protocol session failed",
+			"operator_address", n.OperatorAddress.Hex(),
+			"session_timestamp", session.SessionTimestamp,
+			"type", session.Type,
+			"error", err)
+	}
+
+	// A run that failed before picking the session up leaves it registered, and a
+	// registered session makes every later interval boundary skip.
+	if n.getSession(session.SessionTimestamp) == session {
+		n.cleanupSession(session.SessionTimestamp)
+	}
+}
+
+// openSession returns the session RestoreState rebuilt for sessionTimestamp, or creates a
+// new one; resumed reports which. A rebuilt session is only reused by a run of the same
+// type over the same operator set.
+func (n *Node) openSession(sessionType string, operators []*peering.OperatorSetPeer, sessionTimestamp int64) (*ProtocolSession, bool, error) {
+	n.sessionMutex.Lock()
+	if session := n.activeSessions[sessionTimestamp]; session != nil && session.restored {
+		session.restored = false
+		if session.Type == sessionType && sameOperatorSet(sessionParticipantIDs(session.Operators), sessionParticipantIDs(operators)) {
+			n.sessionMutex.Unlock()
+			return session, true, nil
+		}
+		delete(n.activeSessions, sessionTimestamp)
+		n.logger.Sugar().Warnw("Discarding restored session that no longer matches the protocol run",
+			"operator_address", n.OperatorAddress.Hex(),
+			"session_timestamp", sessionTimestamp,
+			"restored_type", session.Type,
+			"type", sessionType)
+	}
+	n.sessionMutex.Unlock()
+
+	session, err := n.createSession(sessionType, operators, sessionTimestamp)
+	return session, false, err
+}
+
+// dealtShares returns the shares and commitments dealer dealt in this session and the
+// source version it dealt from, or nil shares if it has not dealt.
+func (s *ProtocolSession) dealtShares(dealer common.Address) (map[common.Address]*fr.Element, []types.G2Point, int64) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	commitments, ok := s.commitments[dealer]
+	if len(s.myGeneratedShares) == 0 || !ok {
+		return nil, nil, 0
+	}
+	return copyShares(s.myGeneratedShares), commitments, s.sourceVersions[dealer]
+}
+
+// restoredComplaintResolution returns the outcome of a DKG complaint round that closed
+// before a restart, or nil if the round has not closed.
+func (s *ProtocolSession) restoredComplaintResolution() *dkg.ComplaintResolution {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.qualifiedDealers == nil {
+		return nil
+	}
+	resolution := &dkg.ComplaintResolution{Disqualified: make(map[common.Address]string)}
+	for _, op := range s.Operators {
+		if s.qualifiedDealers[op.OperatorAddress] {
+			resolution.Qualified = append(resolution.Qualified, op.OperatorAddress)
+		} else {
+			resolution.Disqualified[op.OperatorAddress] = "disqualified before restart"
+		}
+	}
+	return resolution
+}
+
+// submittedAckTree returns the ack merkle tree dealer submitted on-chain and the acks
+// that are its leaves, or a nil tree if it has not submitted.
+func (s *ProtocolSession) submittedAckTree(dealer common.Address) (*merkle.MerkleTree, []*types.Acknowledgement) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if !s.contractSubmitted || s.myAckMerkleTree == nil {
+		return nil, nil
+	}
+	acks := make([]*types.Acknowledgement, 0, len(s.ackMerklePlayers))
+	for _, player := range s.ackMerklePlayers {
+		acks = append(acks, s.acks[dealer][player])
+	}
+	return s.myAckMerkleTree, acks
+}
+
+// recordAckSubmission records the ack merkle tree this node submitted on-chain and moves
+// the session to Phase 4.
+func (s *ProtocolSession) recordAckSubmission(tree *merkle.MerkleTree, acks []*types.Acknowledgement, commitmentHash [32]byte) {
+	players := make([]common.Address, len(acks))
+	for i, ack := range acks {
+		players[i] = ack.PlayerAddress
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.myAckMerkleTree = tree
+	s.myAckCommitmentHash = commitmentHash
+	s.ackMerklePlayers = players
+	s.contractSubmitted = true
+	s.Phase = 4
+}
+
+// currentPhase returns the session's phase.
+func (s *ProtocolSession) currentPhase() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.Phase
+}
+
+func parseShareMap(serialized map[string]string) (map[common.Address]*fr.Element, error) {
+	shares := make(map[common.Address]*fr.Element, len(serialized))
+	for addr, data := range serialized {
+		share := new(fr.Element)
+		if _, err := share.SetString(data); err != nil {
+			return nil, fmt.Errorf("share for %s: %w", addr, err)
+		}
+		shares[common.HexToAddress(addr)] = share
+	}
+	return shares, nil
+}
+
+// addressSetToHex returns the members of set as sorted hex strings.
+func addressSetToHex(set map[common.Address]bool) []string {
+	out := make([]string, 0, len(set))
+	for addr, ok := range set {
+		if ok {
+			out = append(out, addr.Hex())
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func hexToAddresses(hexAddrs []string) []common.Address {
+	out := make([]common.Address, len(hexAddrs))
+	for i, h := range hexAddrs {
+		out[i] = common.HexToAddress(h)
+	}
+	return out
+}
+
+func sameOperatorSet(a, b []common.Address) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	members := make(map[common.Address]bool, len(a))
+	for _, addr := range a {
+		members[addr] = true
+	}
+	for _, addr := range b {
+		if !members[addr] {
+			return false
+		}
+	}
+	return true
+}
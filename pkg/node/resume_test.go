@@ -0,0 +1,244 @@
+package node
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/dkg"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/keystore"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newResumeTestNode returns operator 1 of a 3-operator set with in-memory persistence.
+func newResumeTestNode(t *testing.T) (*Node, []*peering.OperatorSetPeer) {
+	t.Helper()
+	p := memory.NewMemoryPersistence()
+	t.Cleanup(func() { _ = p.Close() })
+
+	operators := make([]*peering.OperatorSetPeer, 3)
+	for i := range operators {
+		operators[i] = &peering.OperatorSetPeer{
+			OperatorAddress: common.HexToAddress(fmt.Sprintf("0x%040x", i+1)),
+		}
+	}
+	n := &Node{
+		OperatorAddress:    operators[0].OperatorAddress,
+		ChainID:            config.ChainId_EthereumAnvil,
+		logger:             zap.NewNop(),
+		keyStore:           keystore.NewKeyStore(),
+		persistence:        p,
+		peeringDataFetcher: peering.NewStubPeeringDataFetcher(&peering.OperatorSetPeers{Peers: operators}),
+		activeSessions:     make(map[int64]*ProtocolSession),
+		sessionNotify:      make(map[int64]chan struct{}),
+	}
+	return n, operators
+}
+
+// newDealtSession returns a DKG session in which every operator has dealt to self and
+// acknowledged self's dealing, and self has submitted its ack merkle tree.
+func newDealtSession(t *testing.T, n *Node, operators []*peering.OperatorSetPeer) *ProtocolSession {
+	t.Helper()
+	session, err := n.createSession("dkg", operators, time.Now().Unix())
+	require.NoError(t, err)
+	session.Generation = 2
+
+	commitment := []types.G2Point{crypto.G2Generator}
+	commitmentHash := crypto.HashCommitment(commitment)
+	myAcks := make([]*types.Acknowledgement, 0, len(operators))
+	session.myGeneratedShares = make(map[common.Address]*fr.Element)
+	for i, op := range operators {
+		session.shares[op.OperatorAddress] = new(fr.Element).SetInt64(int64(10 + i))
+		session.commitments[op.OperatorAddress] = commitment
+		session.myGeneratedShares[op.OperatorAddress] = new(fr.Element).SetInt64(int64(20 + i))
+		session.verifiedOperators[op.OperatorAddress] = true
+
+		ack := &types.Acknowledgement{
+			DealerAddress:    n.OperatorAddress,
+			PlayerAddress:    op.OperatorAddress,
+			SessionTimestamp: session.SessionTimestamp,
+			ShareHash:        [32]byte{byte(i + 1)},
+			CommitmentHash:   commitmentHash,
+			Signature:        []byte{byte(i + 1)},
+		}
+		session.acks[n.OperatorAddress][op.OperatorAddress] = ack
+		myAcks = append(myAcks, ack)
+	}
+	session.qualifiedDealers = map[common.Address]bool{
+		operators[0].OperatorAddress: true,
+		operators[1].OperatorAddress: true,
+	}
+
+	tree, err := dkg.BuildAcknowledgementMerkleTree(myAcks)
+	require.NoError(t, err)
+	session.recordAckSubmission(tree, myAcks, commitmentHash)
+	return session
+}
+
+func TestRestoreSession_RoundTrip(t *testing.T) {
+	n, operators := newResumeTestNode(t)
+	original := newDealtSession(t, n, operators)
+
+	restored, err := n.restoreSession(original.toPersistenceState(), operators)
+	require.NoError(t, err)
+
+	assert.True(t, restored.restored)
+	assert.Equal(t, 4, restored.currentPhase())
+	assert.Equal(t, uint32(2), restored.Generation)
+	assert.Equal(t, original.shares, restored.shares)
+	assert.Equal(t, original.commitments, restored.commitments)
+	assert.Equal(t, original.verifiedOperators, restored.verifiedOperators)
+	assert.Equal(t, original.acks, restored.acks)
+
+	shares, commitments, _ := restored.dealtShares(n.OperatorAddress)
+	assert.Equal(t, original.myGeneratedShares, shares)
+	assert.Equal(t, original.commitments[n.OperatorAddress], commitments)
+
+	resolution := restored.restoredComplaintResolution()
+	require.NotNil(t, resolution)
+	assert.Equal(t, []common.Address{operators[0].OperatorAddress, operators[1].OperatorAddress}, resolution.Qualified)
+	assert.Contains(t, resolution.Disqualified, operators[2].OperatorAddress)
+
+	tree, acks := restored.submittedAckTree(n.OperatorAddress)
+	require.NotNil(t, tree)
+	assert.Equal(t, original.myAckMerkleTree.Root, tree.Root)
+	assert.Len(t, acks, len(operators))
+	assert.Equal(t, original.myAckCommitmentHash, restored.myAckCommitmentHash)
+
+	// Every share and commitment arrived before the restart, so the waits must not block
+	select {
+	case <-restored.sharesCompleteChan:
+	default:
+		t.Fatal("shares completion was not signaled")
+	}
+	select {
+	case <-restored.commitmentsCompleteChan:
+	default:
+		t.Fatal("commitments completion was not signaled")
+	}
+}
+
+func TestRestoreSession_BeforeDealing(t *testing.T) {
+	n, operators := newResumeTestNode(t)
+	session, err := n.createSession("reshare", operators, time.Now().Unix())
+	require.NoError(t, err)
+	session.shares[operators[1].OperatorAddress] = new(fr.Element).SetInt64(5)
+
+	restored, err := n.restoreSession(session.toPersistenceState(), operators)
+	require.NoError(t, err)
+
+	shares, _, _ := restored.dealtShares(n.OperatorAddress)
+	assert.Nil(t, shares)
+	assert.Nil(t, restored.restoredComplaintResolution())
+	tree, _ := restored.submittedAckTree(n.OperatorAddress)
+	assert.Nil(t, tree)
+	select {
+	case <-restored.sharesCompleteChan:
+		t.Fatal("shares completion signaled with shares missing")
+	default:
+	}
+}
+
+func TestRestoreSession_Rejects(t *testing.T) {
+	n, operators := newResumeTestNode(t)
+
+	t.Run("changed operator set", func(t *testing.T) {
+		state := newDealtSession(t, n, operators).toPersistenceState()
+		_, err := n.restoreSession(state, operators[:2])
+		require.ErrorContains(t, err, "operator set changed")
+	})
+
+	t.Run("corrupt share", func(t *testing.T) {
+		state := newDealtSession(t, n, operators).toPersistenceState()
+		state.Shares[operators[1].OperatorAddress.Hex()] = "not-a-share"
+		_, err := n.restoreSession(state, operators)
+		require.ErrorContains(t, err, "invalid received shares")
+	})
+
+	t.Run("merkle root mismatch", func(t *testing.T) {
+		state := newDealtSession(t, n, operators).toPersistenceState()
+		state.AckMerkleRoot = "0x00"
+		_, err := n.restoreSession(state, operators)
+		require.ErrorContains(t, err, "does not match submitted root")
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		state := newDealtSession(t, n, operators).toPersistenceState()
+		state.Type = "rotation"
+		_, err := n.restoreSession(state, operators)
+		require.Error(t, err)
+	})
+}
+
+func TestRestoreState_ResumesUnexpiredSessions(t *testing.T) {
+	n, operators := newResumeTestNode(t)
+
+	live := newDealtSession(t, n, operators)
+	require.NoError(t, n.saveSession(live))
+
+	expired := &persistence.ProtocolSessionState{
+		SessionTimestamp:  live.SessionTimestamp - 1,
+		Type:              "dkg",
+		Phase:             2,
+		StartTime:         time.Now().Add(-24 * time.Hour).Unix(),
+		OperatorAddresses: []string{operators[0].OperatorAddress.Hex()},
+	}
+	require.NoError(t, n.persistence.SaveProtocolSession(expired))
+
+	unresumable := &persistence.ProtocolSessionState{
+		SessionTimestamp:  live.SessionTimestamp + 1,
+		Type:              "dkg",
+		Phase:             2,
+		StartTime:         time.Now().Unix(),
+		OperatorAddresses: []string{operators[0].OperatorAddress.Hex()},
+	}
+	require.NoError(t, n.persistence.SaveProtocolSession(unresumable))
+
+	n.activeSessions = make(map[int64]*ProtocolSession)
+	require.NoError(t, n.RestoreState())
+
+	states, err := n.persistence.ListProtocolSessions()
+	require.NoError(t, err)
+	require.Len(t, states, 1)
+	assert.Equal(t, live.SessionTimestamp, states[0].SessionTimestamp)
+
+	restored := n.getSession(live.SessionTimestamp)
+	require.NotNil(t, restored)
+	require.Len(t, n.restoredSessions, 1)
+	assert.Same(t, restored, n.restoredSessions[0])
+
+	// The first run for the timestamp picks the restored session up; later runs do not
+	session, resumed, err := n.openSession("dkg", operators, live.SessionTimestamp)
+	require.NoError(t, err)
+	assert.True(t, resumed)
+	assert.Same(t, restored, session)
+
+	n.cleanupSession(live.SessionTimestamp)
+	session, resumed, err = n.openSession("dkg", operators, live.SessionTimestamp)
+	require.NoError(t, err)
+	assert.False(t, resumed)
+	assert.NotSame(t, restored, session)
+}
+
+func TestOpenSession_DiscardsMismatchedRestoredSession(t *testing.T) {
+	n, operators := newResumeTestNode(t)
+	restored, err := n.restoreSession(newDealtSession(t, n, operators).toPersistenceState(), operators)
+	require.NoError(t, err)
+	n.activeSessions[restored.SessionTimestamp] = restored
+
+	session, resumed, err := n.openSession("reshare", operators, restored.SessionTimestamp)
+	require.NoError(t, err)
+	assert.False(t, resumed)
+	assert.Equal(t, "reshare", session.Type)
+	assert.Same(t, session, n.getSession(restored.SessionTimestamp))
+}
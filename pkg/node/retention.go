@@ -0,0 +1,191 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/keystore"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// Key version retention.
+//
+// A reshare stores a new key version at every interval boundary. At each boundary
+// applyKeyRetention prunes the versions Config.Retention no longer keeps from the
+// keystore and from persistence. MaxAge is also the attestation-time window keyVersionFor
+// serves: a version is pruned only once no attestation time inside the window resolves
+// to it, and every operator refuses attestation times older than the window, however
+// recently it last pruned. The active, pending and last-known-good versions are never
+// pruned; the last-known-good version is the auto-heal rollback target.
+
+// retentionCutoff returns the oldest attestation time served at now, or 0 when the
+// window is unlimited or retention only reports what it would prune.
+func (n *Node) retentionCutoff(now int64) int64 {
+	if n.retention.DryRun {
+		return 0
+	}
+	return retentionCutoff(n.retention, now)
+}
+
+func retentionCutoff(retention config.RetentionConfig, now int64) int64 {
+	if retention.MaxAge <= 0 {
+		return 0
+	}
+	return now - int64(retention.MaxAge/time.Second)
+}
+
+// applyKeyRetention prunes the key versions outside the retention policy, or only logs
+// them in dry-run mode.
+func (n *Node) applyKeyRetention(now int64) {
+	if !n.retention.Enabled() {
+		return
+	}
+	report, err := runKeyRetention(n.keyStore, n.persistence, n.retention, now, n.lastKnownGoodVersion(), n.retention.DryRun)
+	if err != nil {
+		// The versions are gone from the keystore; RestoreState reloads the ones left in
+		// persistence after a restart and the next pass deletes them again.
+		n.logger.Sugar().Errorw("Failed to delete pruned key share versions",
+			"operator_address", n.OperatorAddress.Hex(),
+			"error", err)
+	}
+	if len(report.Pruned) == 0 {
+		return
+	}
+
+	msg := "Pruned key versions outside the retention policy"
+	if report.DryRun {
+		msg = "Key retention dry run: versions would be pruned"
+	}
+	n.logger.Sugar().Infow(msg,
+		"operator_address", n.OperatorAddress.Hex(),
+		"pruned", len(report.Pruned),
+		"oldest", report.Pruned[0].Version,
+		"newest", report.Pruned[len(report.Pruned)-1].Version,
+		"retained", report.Retained,
+		"cutoff", report.Cutoff,
+		"max_versions", report.MaxVersions)
+}
+
+// KeyRetentionReport reports what the retention policy would prune at now, without
+// pruning anything.
+func (n *Node) KeyRetentionReport(now int64) *types.KeyRetentionReport {
+	report, _ := runKeyRetention(n.keyStore, n.persistence, n.retention, now, n.lastKnownGoodVersion(), true)
+	report.KeyID = n.KeyID
+	return report
+}
+
+// lastKnownGoodVersion returns the persisted last-known-good source version, or 0.
+func (n *Node) lastKnownGoodVersion() int64 {
+	st, err := n.persistence.LoadNodeState()
+	if err != nil || st == nil {
+		return 0
+	}
+	return st.LastKnownGoodSourceVersion
+}
+
+// PruneKeyVersions applies the retention policy to the key versions persisted in p. It
+// is for use while no node runs on p; a running node prunes its own versions at every
+// interval boundary. With dryRun nothing is deleted.
+func PruneKeyVersions(p persistence.INodePersistence, retention config.RetentionConfig, now int64, dryRun bool) (*types.KeyRetentionReport, error) {
+	versions, err := p.ListKeyShareVersions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load key share versions: %w", err)
+	}
+	active, err := p.GetActiveVersionTimestamp()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load active version timestamp: %w", err)
+	}
+	poisoned, err := p.ListPoisonedVersions()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load poisoned versions: %w", err)
+	}
+	st, err := p.LoadNodeState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node state: %w", err)
+	}
+	var lkg int64
+	if st != nil {
+		lkg = st.LastKnownGoodSourceVersion
+	}
+
+	// Restore the keystore as RestoreState does: the active pointer is set before the
+	// poisoned set, so a poisoned active version (the auto-heal floor) stays active.
+	ks := keystore.NewKeyStore()
+	for _, version := range versions {
+		ks.AddVersion(version)
+	}
+	for _, version := range versions {
+		if version.Version == active {
+			ks.SetActiveVersion(version)
+			break
+		}
+	}
+	for _, v := range poisoned {
+		ks.MarkPoisoned(v)
+	}
+
+	return runKeyRetention(ks, p, retention, now, lkg, dryRun)
+}
+
+// runKeyRetention selects the versions of ks the retention policy prunes and, unless
+// dryRun, removes them from ks and deletes them from p. lkg is the last-known-good
+// version (0 = none). The returned error lists the versions that could not be deleted
+// from p; the report includes them.
+func runKeyRetention(ks *keystore.KeyStore, p persistence.INodePersistence, retention config.RetentionConfig, now int64, lkg int64, dryRun bool) (*types.KeyRetentionReport, error) {
+	protected := make([]int64, 0, 3)
+	for _, version := range []*types.KeyShareVersion{ks.GetActiveVersion(), ks.GetPendingVersion()} {
+		if version != nil {
+			protected = append(protected, version.Version)
+		}
+	}
+	if lkg > 0 {
+		protected = append(protected, lkg)
+	}
+	sort.Slice(protected, func(i, j int) bool { return protected[i] < protected[j] })
+
+	policy := keystore.RetentionPolicy{
+		Cutoff:      retentionCutoff(retention, now),
+		MaxVersions: retention.MaxVersions,
+		Protected:   protected,
+	}
+	var pruned []keystore.PrunedVersion
+	if dryRun {
+		pruned = ks.SelectForPruning(policy)
+	} else {
+		pruned = ks.PruneVersions(policy)
+	}
+
+	report := &types.KeyRetentionReport{
+		DryRun:      dryRun,
+		Cutoff:      policy.Cutoff,
+		MaxVersions: policy.MaxVersions,
+		Protected:   protected,
+		Pruned:      make([]types.PrunedKeyVersion, 0, len(pruned)),
+		Retained:    ks.NumVersions(),
+	}
+	if dryRun {
+		report.Retained -= len(pruned)
+	}
+	for _, version := range pruned {
+		report.Pruned = append(report.Pruned, types.PrunedKeyVersion{
+			Version:    version.Version.Version,
+			Generation: version.Version.Generation,
+			Reason:     string(version.Reason),
+		})
+	}
+	if dryRun {
+		return report, nil
+	}
+
+	var errs []error
+	for _, version := range pruned {
+		if err := p.DeleteKeyShareVersion(version.Version.Version); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete key share version %d: %w", version.Version.Version, err))
+		}
+	}
+	return report, errors.Join(errs...)
+}
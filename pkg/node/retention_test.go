@@ -0,0 +1,143 @@
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/keystore"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRetentionTestNode returns a node holding versions 1000, 2000, ..., 6000 in its
+// keystore and persistence, with 6000 active and 5000 last-known-good.
+func newRetentionTestNode(t *testing.T, retention config.RetentionConfig) *Node {
+	t.Helper()
+	n := newReadyTestNode(t)
+	n.keyStore = newRetentionKeyStore(t, n.persistence)
+	n.retention = retention
+	require.NoError(t, n.persistence.SaveNodeState(&persistence.NodeState{
+		OperatorAddress:            n.OperatorAddress.Hex(),
+		LastKnownGoodSourceVersion: 5000,
+	}))
+	return n
+}
+
+func newRetentionKeyStore(t *testing.T, p persistence.INodePersistence) *keystore.KeyStore {
+	t.Helper()
+	ks := keystore.NewKeyStore()
+	for v := int64(1000); v <= 6000; v += 1000 {
+		version := &types.KeyShareVersion{
+			Version:      v,
+			PrivateShare: new(fr.Element).SetInt64(v),
+			IsActive:     v == 6000,
+		}
+		require.NoError(t, p.SaveKeyShareVersion(version))
+		ks.AddVersion(version)
+	}
+	require.NoError(t, p.SetActiveVersionTimestamp(6000))
+	return ks
+}
+
+func persistedVersions(t *testing.T, p persistence.INodePersistence) []int64 {
+	t.Helper()
+	versions, err := p.ListKeyShareVersions()
+	require.NoError(t, err)
+	out := make([]int64, len(versions))
+	for i, v := range versions {
+		out[i] = v.Version
+	}
+	return out
+}
+
+func TestApplyKeyRetention(t *testing.T) {
+	t.Run("prunes memory and persistence", func(t *testing.T) {
+		n := newRetentionTestNode(t, config.RetentionConfig{MaxAge: 1000 * time.Second})
+		n.applyKeyRetention(4500)
+
+		assert.Equal(t, []int64{3000, 4000, 5000, 6000}, persistedVersions(t, n.persistence))
+		assert.Equal(t, 4, n.keyStore.NumVersions())
+		version := n.keyStore.GetKeyVersionAtTime(3500)
+		require.NotNil(t, version)
+		assert.Equal(t, int64(3000), version.Version)
+	})
+
+	t.Run("count never prunes protected versions", func(t *testing.T) {
+		n := newRetentionTestNode(t, config.RetentionConfig{MaxVersions: 1})
+		n.keyStore.SetPendingVersion(&types.KeyShareVersion{Version: 1000})
+		n.applyKeyRetention(10_000)
+
+		assert.Equal(t, []int64{1000, 5000, 6000}, persistedVersions(t, n.persistence))
+	})
+
+	t.Run("dry run deletes nothing", func(t *testing.T) {
+		n := newRetentionTestNode(t, config.RetentionConfig{MaxVersions: 2, DryRun: true})
+		n.applyKeyRetention(10_000)
+		assert.Len(t, persistedVersions(t, n.persistence), 6)
+		assert.Equal(t, 6, n.keyStore.NumVersions())
+	})
+
+	t.Run("disabled keeps everything", func(t *testing.T) {
+		n := newRetentionTestNode(t, config.RetentionConfig{})
+		n.applyKeyRetention(10_000)
+		assert.Len(t, persistedVersions(t, n.persistence), 6)
+	})
+}
+
+func TestKeyRetentionReport(t *testing.T) {
+	n := newRetentionTestNode(t, config.RetentionConfig{MaxAge: 2500 * time.Second, MaxVersions: 4})
+	n.KeyID = "default"
+
+	report := n.KeyRetentionReport(5000)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, "default", report.KeyID)
+	assert.Equal(t, int64(2500), report.Cutoff)
+	assert.Equal(t, []int64{5000, 6000}, report.Protected)
+	assert.Equal(t, []types.PrunedKeyVersion{
+		{Version: 1000, Generation: 0, Reason: "count"},
+		{Version: 2000, Generation: 0, Reason: "count"},
+	}, report.Pruned)
+	assert.Equal(t, 4, report.Retained)
+	assert.Len(t, persistedVersions(t, n.persistence), 6)
+}
+
+func TestKeyVersionFor_RetentionWindow(t *testing.T) {
+	now := time.Now().Unix()
+	n := newReadyTestNode(t)
+	n.keyStore.AddVersion(&types.KeyShareVersion{Version: now - 7200, PrivateShare: new(fr.Element).SetInt64(1)})
+	n.retention = config.RetentionConfig{MaxAge: time.Hour}
+
+	_, err := n.keyVersionFor(nil, now-2*3600+60)
+	require.ErrorIs(t, err, errNoVersionAtTime)
+
+	version, err := n.keyVersionFor(nil, now-60)
+	require.NoError(t, err)
+	require.NotNil(t, version)
+
+	// A dry run does not narrow the window
+	n.retention.DryRun = true
+	_, err = n.keyVersionFor(nil, now-2*3600+60)
+	require.NoError(t, err)
+}
+
+func TestPruneKeyVersions(t *testing.T) {
+	n := newRetentionTestNode(t, config.RetentionConfig{})
+	retention := config.RetentionConfig{MaxVersions: 1}
+
+	report, err := PruneKeyVersions(n.persistence, retention, 10_000, true)
+	require.NoError(t, err)
+	assert.Len(t, report.Pruned, 4)
+	assert.Len(t, persistedVersions(t, n.persistence), 6)
+
+	// The auto-heal floor leaves a poisoned version active; it is still kept
+	require.NoError(t, n.persistence.SetActiveVersionTimestamp(3000))
+	require.NoError(t, n.persistence.AddPoisonedVersion(3000))
+	report, err = PruneKeyVersions(n.persistence, retention, 10_000, false)
+	require.NoError(t, err)
+	assert.Equal(t, []int64{3000, 5000}, report.Protected)
+	assert.Equal(t, []int64{3000, 5000, 6000}, persistedVersions(t, n.persistence))
+}
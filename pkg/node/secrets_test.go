@@ -2,12 +2,15 @@ package node
 
 import (
 	"bytes"
+	"crypto/mlkem"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -15,9 +18,11 @@ import (
 	"github.com/Layr-Labs/crypto-libs/pkg/bn254"
 	"github.com/Layr-Labs/eigenx-kms-go/internal/tests"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/attestation"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/authz"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/blockHandler"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/contractCaller"
+	eigenxcrypto "github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/logger"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
@@ -29,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"go.uber.org/zap"
 )
 
 // testSecretsFixture holds the common objects needed by secrets endpoint tests.
@@ -102,6 +108,9 @@ func newTestSecretsFixture(t *testing.T) *testSecretsFixture {
 
 func Test_SecretsEndpoint(t *testing.T) {
 	t.Run("Flow", func(t *testing.T) { testSecretsEndpointFlow(t) })
+	t.Run("DecryptionShares", func(t *testing.T) { testSecretsEndpointDecryptionShares(t) })
+	t.Run("SignMessage", func(t *testing.T) { testSecretsEndpointSignMessage(t) })
+	t.Run("PQKey", func(t *testing.T) { testSecretsEndpointPQKey(t) })
 	t.Run("Validation", func(t *testing.T) { testSecretsEndpointValidation(t) })
 	t.Run("ImageDigestMismatch", func(t *testing.T) { testSecretsEndpointImageDigestMismatch(t) })
 	t.Run("RegistryMismatch", func(t *testing.T) { testSecretsEndpointRegistryMismatch(t) })
@@ -115,10 +124,16 @@ func Test_SecretsEndpoint(t *testing.T) {
 	t.Run("ContainerPolicyEnvOverrideMismatch", func(t *testing.T) { testSecretsEndpointEnvOverrideMismatch(t) })
 	t.Run("ContainerPolicyEnvOverrideSuccess", func(t *testing.T) { testSecretsEndpointEnvOverrideSuccess(t) })
 	t.Run("ContainerPolicySuccess", func(t *testing.T) { testSecretsEndpointContainerPolicySuccess(t) })
+	t.Run("ContainerPolicyUnsurfacedMethod", func(t *testing.T) { testSecretsEndpointContainerPolicyUnsurfacedMethod(t) })
+	t.Run("SNPContainerPolicy", func(t *testing.T) { testSecretsEndpointSNPContainerPolicy(t) })
 	t.Run("TwoPhaseUpgrade", func(t *testing.T) { testSecretsEndpointTwoPhaseUpgrade(t) })
 	t.Run("AllowlistBlocked", func(t *testing.T) { testSecretsEndpointAllowlistBlocked(t) })
 	t.Run("AllowlistAllowed", func(t *testing.T) { testSecretsEndpointAllowlistAllowed(t) })
 	t.Run("AllowlistNilAllowsAll", func(t *testing.T) { testSecretsEndpointAllowlistNilAllowsAll(t) })
+	t.Run("AuthzPolicyDenied", func(t *testing.T) { testSecretsEndpointAuthzPolicyDenied(t) })
+	t.Run("AuthzPolicyAllowed", func(t *testing.T) { testSecretsEndpointAuthzPolicyAllowed(t) })
+	t.Run("AuthzPolicyEvalError", func(t *testing.T) { testSecretsEndpointAuthzPolicyEvalError(t) })
+	t.Run("AuthzPolicyReplacesDefaults", func(t *testing.T) { testSecretsEndpointAuthzPolicyReplacesDefaults(t) })
 	t.Run("ExtraDataEchoBehavior", func(t *testing.T) { testSecretsEndpointExtraDataEchoBehavior(t) })
 	t.Run("ExtraDataTooLarge", func(t *testing.T) { testSecretsEndpointExtraDataTooLarge(t) })
 	t.Run("ECDSAOwnerWithEnv", func(t *testing.T) { testSecretsECDSAOwnerWithEnv(t) })
@@ -256,6 +271,355 @@ func testSecretsEndpointFlow(t *testing.T) {
 	t.Log("Successfully retrieved and decrypted secrets for test-app")
 }
 
+// testSecretsEndpointDecryptionShares tests a request presenting ciphertext C1s: the
+// response carries decryption shares of them and of the encrypted environment instead
+// of the partial signature.
+func testSecretsEndpointDecryptionShares(t *testing.T) {
+	f := newTestSecretsFixture(t)
+
+	// The fixture's key share is 42, so with a single share the master public key is 42·G2
+	masterPubKey, err := eigenxcrypto.ScalarMulG2(eigenxcrypto.G2Generator, new(fr.Element).SetInt64(42))
+	if err != nil {
+		t.Fatalf("Failed to compute master public key: %v", err)
+	}
+	env, err := eigenxcrypto.EncryptForApp("test-app", *masterPubKey, []byte("DB_PASSWORD=hunter2"))
+	if err != nil {
+		t.Fatalf("Failed to encrypt env: %v", err)
+	}
+	data, err := eigenxcrypto.EncryptForApp("test-app", *masterPubKey, []byte("model key"))
+	if err != nil {
+		t.Fatalf("Failed to encrypt data: %v", err)
+	}
+	f.contractCallerStub.AddTestRelease("test-app", &kmsTypes.Release{
+		ImageDigest:  "sha256:test123",
+		EncryptedEnv: hex.EncodeToString(env),
+		Timestamp:    time.Now().Unix(),
+	})
+
+	rsaEncrypt := encryption.NewRSAEncryption()
+	privKeyPEM, pubKeyPEM, err := encryption.GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key pair: %v", err)
+	}
+	c1, err := eigenxcrypto.CiphertextC1(data)
+	if err != nil {
+		t.Fatalf("Failed to extract C1: %v", err)
+	}
+	// The attestation covers extra_data, which names the presented ciphertexts
+	extraData := eigenxcrypto.CiphertextBinding([]kmsTypes.G2Point{*c1})
+	h := sha256.Sum256(append(bytes.Clone(pubKeyPEM), extraData...))
+	attestationBytes, err := json.Marshal(kmsTypes.AttestationClaims{
+		AppID:       "test-app",
+		ImageDigest: "sha256:test123",
+		IssuedAt:    time.Now().Unix(),
+		PublicKey:   pubKeyPEM,
+		Nonce:       hex.EncodeToString(h[:]),
+		JTI:         "decryption-shares-jti",
+		ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal attestation claims: %v", err)
+	}
+
+	req := kmsTypes.SecretsRequestV1{
+		AppID:             "test-app",
+		AttestationMethod: "gcp",
+		Attestation:       attestationBytes,
+		RSAPubKeyTmp:      pubKeyPEM,
+		AttestationTime:   time.Now().Unix(),
+		CiphertextC1s:     []kmsTypes.G2Point{*c1},
+	}
+	// Without the binding the request is refused before its attestation is used
+	reqBody, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	w := httptest.NewRecorder()
+	f.server.handleSecretsRequest(w, httptest.NewRequest(http.MethodPost, "/secrets", bytes.NewBuffer(reqBody)))
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), "ciphertext binding") {
+		t.Fatalf("Expected status 400 for a missing ciphertext binding, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	req.ExtraData = extraData
+	reqBody, err = json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	w = httptest.NewRecorder()
+	f.server.handleSecretsRequest(w, httptest.NewRequest(http.MethodPost, "/secrets", bytes.NewBuffer(reqBody)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	var resp kmsTypes.SecretsResponseV1
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if len(resp.EncryptedPartialSig) != 0 {
+		t.Fatal("Expected no partial signature when ciphertext C1s are presented")
+	}
+	payloadBytes, err := rsaEncrypt.DecryptHybrid(resp.EncryptedDecryptionShares, privKeyPEM)
+	if err != nil {
+		t.Fatalf("Failed to decrypt decryption shares: %v", err)
+	}
+	var payload kmsTypes.SecretsDecryptionShares
+	if err := json.Unmarshal(payloadBytes, &payload); err != nil {
+		t.Fatalf("Failed to parse decryption shares: %v", err)
+	}
+	if len(payload.Shares) != 1 || payload.EnvShare == nil {
+		t.Fatalf("Expected one data share and an env share, got %d and %v", len(payload.Shares), payload.EnvShare)
+	}
+
+	operator := f.node.OperatorAddress
+	envC1, err := eigenxcrypto.CiphertextC1(env)
+	if err != nil {
+		t.Fatalf("Failed to extract env C1: %v", err)
+	}
+	for _, tc := range []struct {
+		name       string
+		ciphertext []byte
+		c1         kmsTypes.G2Point
+		share      kmsTypes.DecryptionShare
+		want       string
+	}{
+		{"data", data, *c1, payload.Shares[0], "model key"},
+		{"env", env, *envC1, *payload.EnvShare, "DB_PASSWORD=hunter2"},
+	} {
+		ok, err := eigenxcrypto.VerifyDecryptionShare("test-app", tc.c1, tc.share, *masterPubKey)
+		if err != nil || !ok {
+			t.Fatalf("%s share failed verification: ok=%v err=%v", tc.name, ok, err)
+		}
+		key, err := eigenxcrypto.CombineDecryptionShares(tc.c1, map[common.Address]kmsTypes.DecryptionShare{operator: tc.share}, 1)
+		if err != nil {
+			t.Fatalf("Failed to combine %s share: %v", tc.name, err)
+		}
+		plaintext, err := key.Decrypt("test-app", tc.ciphertext)
+		if err != nil {
+			t.Fatalf("Failed to decrypt %s: %v", tc.name, err)
+		}
+		if string(plaintext) != tc.want {
+			t.Errorf("Expected %s plaintext %q, got %q", tc.name, tc.want, plaintext)
+		}
+	}
+}
+
+// testSecretsEndpointSignMessage tests /v1/app/sign-message, which is authorized like
+// /secrets and returns an encrypted partial signature over the message
+func testSecretsEndpointSignMessage(t *testing.T) {
+	f := newTestSecretsFixture(t)
+	f.contractCallerStub.AddTestRelease("test-app", &kmsTypes.Release{
+		ImageDigest: "sha256:test123",
+		Timestamp:   time.Now().Unix(),
+	})
+	rsaEncrypt := encryption.NewRSAEncryption()
+	privKeyPEM, pubKeyPEM, err := encryption.GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key pair: %v", err)
+	}
+
+	signMessage := func(jti, imageDigest string, message []byte) *httptest.ResponseRecorder {
+		h := sha256.Sum256(pubKeyPEM)
+		attestationBytes, err := json.Marshal(kmsTypes.AttestationClaims{
+			AppID:       "test-app",
+			ImageDigest: imageDigest,
+			IssuedAt:    time.Now().Unix(),
+			PublicKey:   pubKeyPEM,
+			Nonce:       hex.EncodeToString(h[:]),
+			JTI:         jti,
+			ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal attestation claims: %v", err)
+		}
+		reqBody, err := json.Marshal(kmsTypes.AppSignMessageRequest{
+			SecretsRequestV1: kmsTypes.SecretsRequestV1{
+				AppID:             "test-app",
+				AttestationMethod: "gcp",
+				Attestation:       attestationBytes,
+				RSAPubKeyTmp:      pubKeyPEM,
+				AttestationTime:   time.Now().Unix(),
+			},
+			Message: message,
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		w := httptest.NewRecorder()
+		f.server.handleAppSignMessage(w, httptest.NewRequest(http.MethodPost, "/v1/app/sign-message", bytes.NewBuffer(reqBody)))
+		return w
+	}
+
+	message := []byte("attest: model hash 0xabc")
+	w := signMessage("sign-message-jti", "sha256:test123", message)
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp kmsTypes.AppSignMessageResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	if resp.OperatorAddress != f.node.OperatorAddress.Hex() {
+		t.Errorf("Expected operator %s, got %s", f.node.OperatorAddress.Hex(), resp.OperatorAddress)
+	}
+	partialSigBytes, err := rsaEncrypt.Decrypt(resp.EncryptedPartialSig, privKeyPEM)
+	if err != nil {
+		t.Fatalf("Failed to decrypt partial signature: %v", err)
+	}
+	var partialSig kmsTypes.G1Point
+	if err := json.Unmarshal(partialSigBytes, &partialSig); err != nil {
+		t.Fatalf("Failed to parse partial signature: %v", err)
+	}
+
+	// The fixture's key share is 42, so with a single share the master public key is 42·G2
+	masterPubKey, err := eigenxcrypto.ScalarMulG2(eigenxcrypto.G2Generator, new(fr.Element).SetInt64(42))
+	if err != nil {
+		t.Fatalf("Failed to compute master public key: %v", err)
+	}
+	appPubKey, err := eigenxcrypto.AppSigningPublicKey("test-app", *masterPubKey)
+	if err != nil {
+		t.Fatalf("Failed to compute app signing key: %v", err)
+	}
+	sig, err := eigenxcrypto.CombineAppMessageSignatures(map[common.Address]kmsTypes.G1Point{f.node.OperatorAddress: partialSig}, 1)
+	if err != nil {
+		t.Fatalf("Failed to combine partial signature: %v", err)
+	}
+	if ok, err := eigenxcrypto.VerifyAppMessageSignature("test-app", message, *sig, *appPubKey); err != nil || !ok {
+		t.Fatalf("Signature failed verification: ok=%v err=%v", ok, err)
+	}
+	if ok, _ := eigenxcrypto.VerifyAppMessageSignature("test-app", []byte("other"), *sig, *appPubKey); ok {
+		t.Error("Signature verified for another message")
+	}
+
+	// The /secrets checks apply: replayed tokens and unreleased images are refused
+	if w := signMessage("sign-message-jti", "sha256:test123", message); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for a replayed token, got %d", w.Code)
+	}
+	if w := signMessage("sign-message-digest-jti", "sha256:other", message); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for an image digest mismatch, got %d", w.Code)
+	}
+	if w := signMessage("sign-message-size-jti", "sha256:test123", make([]byte, kmsTypes.MaxAppMessageSize+1)); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an oversized message, got %d", w.Code)
+	}
+}
+
+// testSecretsEndpointPQKey tests /v1/app/pq-public-key and /v1/app/pq-key: the released
+// decapsulation key matches the announced encapsulation key, and is only released under
+// the /secrets checks
+func testSecretsEndpointPQKey(t *testing.T) {
+	f := newTestSecretsFixture(t)
+	f.contractCallerStub.AddTestRelease("test-app", &kmsTypes.Release{
+		ImageDigest: "sha256:test123",
+		Timestamp:   time.Now().Unix(),
+	})
+	rsaEncrypt := encryption.NewRSAEncryption()
+	privKeyPEM, pubKeyPEM, err := encryption.GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key pair: %v", err)
+	}
+	requesterKey, err := mlkem.GenerateKey768()
+	if err != nil {
+		t.Fatalf("Failed to generate ML-KEM key: %v", err)
+	}
+
+	announced := func(appID string) kmsTypes.AppPQPublicKey {
+		w := httptest.NewRecorder()
+		f.server.handleAppPQPublicKey(w, httptest.NewRequest(http.MethodGet, "/v1/app/pq-public-key?app_id="+appID, nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+		}
+		var authMsg kmsTypes.AuthenticatedMessage
+		if err := json.NewDecoder(w.Body).Decode(&authMsg); err != nil {
+			t.Fatalf("Failed to parse response: %v", err)
+		}
+		var key kmsTypes.AppPQPublicKey
+		if err := json.Unmarshal(authMsg.Payload, &key); err != nil {
+			t.Fatalf("Failed to parse announcement: %v", err)
+		}
+		return key
+	}
+	requestKey := func(jti, imageDigest string, encapsulationKey []byte) *httptest.ResponseRecorder {
+		h := sha256.Sum256(pubKeyPEM)
+		attestationBytes, err := json.Marshal(kmsTypes.AttestationClaims{
+			AppID:       "test-app",
+			ImageDigest: imageDigest,
+			IssuedAt:    time.Now().Unix(),
+			PublicKey:   pubKeyPEM,
+			Nonce:       hex.EncodeToString(h[:]),
+			JTI:         jti,
+			ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal attestation claims: %v", err)
+		}
+		reqBody, err := json.Marshal(kmsTypes.AppPQKeyRequest{
+			SecretsRequestV1: kmsTypes.SecretsRequestV1{
+				AppID:             "test-app",
+				AttestationMethod: "gcp",
+				Attestation:       attestationBytes,
+				RSAPubKeyTmp:      pubKeyPEM,
+				AttestationTime:   time.Now().Unix(),
+			},
+			EncapsulationKey: encapsulationKey,
+		})
+		if err != nil {
+			t.Fatalf("Failed to marshal request: %v", err)
+		}
+		w := httptest.NewRecorder()
+		f.server.handleAppPQKey(w, httptest.NewRequest(http.MethodPost, "/v1/app/pq-key", bytes.NewBuffer(reqBody)))
+		return w
+	}
+
+	key := announced("test-app")
+	if key.OperatorAddress != f.node.OperatorAddress || key.AppID != "test-app" {
+		t.Fatalf("Announcement names %s/%q, expected %s/test-app", key.OperatorAddress.Hex(), key.AppID, f.node.OperatorAddress.Hex())
+	}
+	if other := announced("other-app"); bytes.Equal(other.EncapsulationKey, key.EncapsulationKey) {
+		t.Error("Two apps were announced the same PQ key")
+	}
+
+	w := requestKey("pq-key-jti", "sha256:test123", requesterKey.EncapsulationKey().Bytes())
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	var resp kmsTypes.AppPQKeyResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to parse response: %v", err)
+	}
+	sealedKey, err := rsaEncrypt.DecryptHybrid(resp.EncryptedKey, privKeyPEM)
+	if err != nil {
+		t.Fatalf("Failed to decrypt PQ key: %v", err)
+	}
+	dk, err := eigenxcrypto.OpenAppPQKey(requesterKey, "test-app", f.node.OperatorAddress, resp.KEMCiphertext, sealedKey)
+	if err != nil {
+		t.Fatalf("Failed to open PQ key: %v", err)
+	}
+	if !bytes.Equal(dk.EncapsulationKey().Bytes(), key.EncapsulationKey) {
+		t.Fatal("Released decapsulation key does not match the announced encapsulation key")
+	}
+
+	// The seed is persisted, so a restarted node announces the same key
+	f.node.pqKeySeed = nil
+	if restarted := announced("test-app"); !bytes.Equal(restarted.EncapsulationKey, key.EncapsulationKey) {
+		t.Error("PQ key changed after reloading the seed")
+	}
+
+	// The /secrets checks apply: replayed tokens and unreleased images are refused
+	if w := requestKey("pq-key-jti", "sha256:test123", requesterKey.EncapsulationKey().Bytes()); w.Code != http.StatusUnauthorized {
+		t.Errorf("Expected status 401 for a replayed token, got %d", w.Code)
+	}
+	if w := requestKey("pq-key-digest-jti", "sha256:other", requesterKey.EncapsulationKey().Bytes()); w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for an image digest mismatch, got %d", w.Code)
+	}
+	if w := requestKey("pq-key-ek-jti", "sha256:test123", []byte("short")); w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid encapsulation key, got %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	f.server.handleAppPQPublicKey(w, httptest.NewRequest(http.MethodGet, "/v1/app/pq-public-key?app_id=ab", nil))
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid app_id, got %d", w.Code)
+	}
+}
+
 // testSecretsEndpointValidation tests various validation scenarios
 func testSecretsEndpointValidation(t *testing.T) {
 	f := newTestSecretsFixture(t)
@@ -368,8 +732,8 @@ func testSecretsEndpointRegistryMismatch(t *testing.T) {
 	if w.Code != http.StatusForbidden {
 		t.Errorf("Expected status 403 for registry mismatch, got %d", w.Code)
 	}
-	if !strings.Contains(w.Body.String(), "Registry mismatch") {
-		t.Errorf("Expected body to mention 'Registry mismatch', got %q", w.Body.String())
+	if !strings.Contains(w.Body.String(), `rule "registry"`) {
+		t.Errorf("Expected the registry rule to deny, got %q", w.Body.String())
 	}
 }
 
@@ -768,6 +1132,129 @@ func testSecretsEndpointContainerPolicySuccess(t *testing.T) {
 	}
 }
 
+// fixedClaimsMethod is an attestation method that returns the same claims for
+// every request, registered under the name of a real method.
+type fixedClaimsMethod struct {
+	name   string
+	claims kmsTypes.AttestationClaims
+}
+
+func (m *fixedClaimsMethod) Name() string { return m.name }
+
+func (m *fixedClaimsMethod) Verify(*attestation.AttestationRequest) (*kmsTypes.AttestationClaims, error) {
+	claims := m.claims
+	return &claims, nil
+}
+
+// testSecretsEndpointContainerPolicyUnsurfacedMethod tests that a release pinning a
+// ContainerPolicy is refused for eigenx-tdx, whose evidence measures no launch spec
+// the guest enforces, even when its claims state the pinned policy.
+func testSecretsEndpointContainerPolicyUnsurfacedMethod(t *testing.T) {
+	f := newTestSecretsFixture(t)
+
+	policy := kmsTypes.ContainerPolicy{
+		Args:          []string{"/entrypoint.sh", "start"},
+		RestartPolicy: "Never",
+	}
+	f.contractCallerStub.AddTestRelease("my-app", &kmsTypes.Release{
+		ImageDigest:     "sha256:app-digest",
+		EncryptedEnv:    "encrypted-env-data",
+		PublicEnv:       "PUBLIC=value",
+		Timestamp:       time.Now().Unix(),
+		ContainerPolicy: policy,
+	})
+	if err := f.node.attestationManager.RegisterMethod(&fixedClaimsMethod{
+		name: "eigenx-tdx",
+		claims: kmsTypes.AttestationClaims{
+			AppID:           "my-app",
+			ImageDigest:     "sha256:app-digest",
+			ContainerPolicy: policy,
+		},
+	}); err != nil {
+		t.Fatalf("Failed to register eigenx-tdx method: %v", err)
+	}
+
+	req := kmsTypes.SecretsRequestV1{
+		AppID:             "my-app",
+		AttestationMethod: "eigenx-tdx",
+		Attestation:       []byte("evidence"),
+		RSAPubKeyTmp:      []byte("test-key"),
+	}
+	reqBody, _ := json.Marshal(req)
+	httpReq := httptest.NewRequest(http.MethodPost, "/secrets", bytes.NewBuffer(reqBody))
+	w := httptest.NewRecorder()
+
+	f.server.handleSecretsRequest(w, httpReq)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected status 403 for eigenx-tdx with a pinned container policy, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `rule "container-policy-unsurfaced"`) {
+		t.Errorf("Expected the unsurfaced-policy refusal, got: %s", w.Body.String())
+	}
+}
+
+// testSecretsEndpointSNPContainerPolicy tests that eigenx-snp's launch spec, derived
+// from the policy.rego kata-agent enforces, is checked field by field: pinned args
+// and env are served when they match, and a pinned restart policy, which the rego
+// cannot express, is refused.
+func testSecretsEndpointSNPContainerPolicy(t *testing.T) {
+	f := newTestSecretsFixture(t)
+	if err := f.node.attestationManager.RegisterMethod(&fixedClaimsMethod{
+		name: "eigenx-snp",
+		claims: kmsTypes.AttestationClaims{
+			AppID:       "my-app",
+			ImageDigest: "sha256:app-digest",
+			ContainerPolicy: kmsTypes.ContainerPolicy{
+				Args: []string{"/entrypoint.sh", "start"},
+				Env:  map[string]string{"PATH": "/usr/bin", "MODE": "prod"},
+			},
+		},
+	}); err != nil {
+		t.Fatalf("Failed to register eigenx-snp method: %v", err)
+	}
+	_, pubKeyPEM, err := encryption.GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key pair: %v", err)
+	}
+	request := func(policy kmsTypes.ContainerPolicy) *httptest.ResponseRecorder {
+		f.contractCallerStub.AddTestRelease("my-app", &kmsTypes.Release{
+			ImageDigest:     "sha256:app-digest",
+			Timestamp:       time.Now().Unix(),
+			ContainerPolicy: policy,
+		})
+		reqBody, _ := json.Marshal(kmsTypes.SecretsRequestV1{
+			AppID:             "my-app",
+			AttestationMethod: "eigenx-snp",
+			Attestation:       []byte("evidence"),
+			RSAPubKeyTmp:      pubKeyPEM,
+			AttestationTime:   time.Now().Unix(),
+		})
+		w := httptest.NewRecorder()
+		f.server.handleSecretsRequest(w, httptest.NewRequest(http.MethodPost, "/secrets", bytes.NewBuffer(reqBody)))
+		return w
+	}
+
+	pinned := kmsTypes.ContainerPolicy{
+		Args: []string{"/entrypoint.sh", "start"},
+		Env:  map[string]string{"MODE": "prod"},
+	}
+	if w := request(pinned); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for eigenx-snp matching the pinned args and env, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	pinned.Env = map[string]string{"MODE": "debug"}
+	if w := request(pinned); w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), `rule "container-env"`) {
+		t.Fatalf("Expected the container-env rule to deny a different env, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	pinned.Env = nil
+	pinned.RestartPolicy = "Never"
+	if w := request(pinned); w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), `rule "container-restart-policy"`) {
+		t.Fatalf("Expected the container-restart-policy rule to deny, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
 // testSecretsEndpointTwoPhaseUpgrade verifies Fix 3 for KMS-009: in-flight requests that were
 // issued before an app upgrade completes are not rejected after the developer calls upgradeApp().
 //
@@ -878,13 +1365,15 @@ func testSecretsEndpointTwoPhaseUpgrade(t *testing.T) {
 	}
 }
 
-// makeSecretsAllowlistRequest posts a SecretsRequestV1 and returns the recorder.
+// makeSecretsAllowlistRequest posts a SecretsRequestV1 attested for appID and returns
+// the recorder. The allowlist is a policy rule, so it judges authenticated requests.
 func makeSecretsAllowlistRequest(t *testing.T, server *Server, appID string) *httptest.ResponseRecorder {
 	t.Helper()
+	attestation, _ := json.Marshal(kmsTypes.AttestationClaims{AppID: appID, ImageDigest: "sha256:test123"})
 	req := kmsTypes.SecretsRequestV1{
 		AppID:             appID,
 		AttestationMethod: "gcp",
-		Attestation:       []byte("{}"),
+		Attestation:       attestation,
 		RSAPubKeyTmp:      []byte("test-key"),
 	}
 	body, _ := json.Marshal(req)
@@ -904,8 +1393,8 @@ func testSecretsEndpointAllowlistBlocked(t *testing.T) {
 	if w.Code != http.StatusForbidden {
 		t.Errorf("Expected 403 for blocked app, got %d", w.Code)
 	}
-	if !strings.Contains(w.Body.String(), "app not allowed") {
-		t.Errorf("Expected allowlist rejection message, got: %s", w.Body.String())
+	if !strings.Contains(w.Body.String(), `rule "app-allowlist"`) {
+		t.Errorf("Expected the app-allowlist rule to deny, got: %s", w.Body.String())
 	}
 }
 
@@ -916,7 +1405,7 @@ func testSecretsEndpointAllowlistAllowed(t *testing.T) {
 	f.node.appAllowlist = map[string]bool{"test-app": true}
 
 	w := makeSecretsAllowlistRequest(t, f.server, "test-app")
-	if strings.Contains(w.Body.String(), "app not allowed") {
+	if strings.Contains(w.Body.String(), `rule "app-allowlist"`) {
 		t.Errorf("Allowed app should not be rejected by allowlist, got: %s", w.Body.String())
 	}
 }
@@ -927,11 +1416,142 @@ func testSecretsEndpointAllowlistNilAllowsAll(t *testing.T) {
 	f.node.appAllowlist = nil
 
 	w := makeSecretsAllowlistRequest(t, f.server, "any-random-app")
-	if strings.Contains(w.Body.String(), "app not allowed") {
+	if strings.Contains(w.Body.String(), `rule "app-allowlist"`) {
 		t.Errorf("Nil allowlist should not reject any app, got: %s", w.Body.String())
 	}
 }
 
+// newTestAuthzPolicy writes doc to a policy file and loads it.
+func newTestAuthzPolicy(t *testing.T, doc string) *authz.Engine {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "authz.yaml")
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatalf("Failed to write policy: %v", err)
+	}
+	e, err := authz.NewEngine(path, zap.NewNop())
+	if err != nil {
+		t.Fatalf("Failed to load policy: %v", err)
+	}
+	return e
+}
+
+// makeSecretsPolicyRequest sends a gcp request that passes every default rule.
+func makeSecretsPolicyRequest(t *testing.T, f *testSecretsFixture, jti string) *httptest.ResponseRecorder {
+	t.Helper()
+	return makeSecretsPolicyRequestForRelease(t, f, jti, "sha256:app-digest")
+}
+
+// makeSecretsPolicyRequestForRelease sends a gcp request attesting sha256:app-digest
+// for an app whose release pins releaseDigest.
+func makeSecretsPolicyRequestForRelease(t *testing.T, f *testSecretsFixture, jti, releaseDigest string) *httptest.ResponseRecorder {
+	t.Helper()
+	f.contractCallerStub.AddTestRelease("my-app", &kmsTypes.Release{
+		ImageDigest: releaseDigest,
+		Timestamp:   time.Now().Unix(),
+	})
+
+	_, pubKeyPEM, err := encryption.GenerateKeyPair(2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key pair: %v", err)
+	}
+	h := sha256.Sum256(pubKeyPEM)
+	attestationBytes, _ := json.Marshal(kmsTypes.AttestationClaims{
+		AppID:       "my-app",
+		ImageDigest: "sha256:app-digest",
+		Nonce:       hex.EncodeToString(h[:]),
+		JTI:         jti,
+		ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+	})
+	reqBody, _ := json.Marshal(kmsTypes.SecretsRequestV1{
+		AppID:             "my-app",
+		AttestationMethod: "gcp",
+		Attestation:       attestationBytes,
+		RSAPubKeyTmp:      pubKeyPEM,
+		AttestationTime:   time.Now().Unix(),
+	})
+	w := httptest.NewRecorder()
+	f.server.handleSecretsRequest(w, httptest.NewRequest(http.MethodPost, "/secrets", bytes.NewBuffer(reqBody)))
+	return w
+}
+
+// testSecretsEndpointAuthzPolicyDenied verifies that an operator rule refuses a
+// request the default rules admit, and names the rule.
+func testSecretsEndpointAuthzPolicyDenied(t *testing.T) {
+	f := newTestSecretsFixture(t)
+	f.node.authzPolicy = newTestAuthzPolicy(t, `
+rules:
+  - name: no-gcp-for-my-app
+    effect: deny
+    match: request.attestation_method == "gcp" && request.app_id == "my-app" && release.image_digest == claims.image_digest
+`)
+
+	w := makeSecretsPolicyRequest(t, f, "authz-denied-jti")
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected 403 from authorization policy, got %d. Body: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), "no-gcp-for-my-app") {
+		t.Errorf("Expected rejection to name the rule, got: %s", w.Body.String())
+	}
+}
+
+// testSecretsEndpointAuthzPolicyReplacesDefaults verifies that the policy alone
+// decides past authentication: an operator allow rule cannot admit an image the
+// default rules refuse, and a policy that replaces the default rules can.
+func testSecretsEndpointAuthzPolicyReplacesDefaults(t *testing.T) {
+	const allowAll = `
+rules:
+  - name: allow-everything
+    effect: allow
+    match: "true"
+`
+	f := newTestSecretsFixture(t)
+	f.node.authzPolicy = newTestAuthzPolicy(t, allowAll)
+	w := makeSecretsPolicyRequestForRelease(t, f, "authz-extend-jti", "sha256:other-digest")
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), `rule "image-digest"`) {
+		t.Fatalf("Expected the default image-digest rule to deny, got %d. Body: %s", w.Code, w.Body.String())
+	}
+
+	f.node.authzPolicy = newTestAuthzPolicy(t, "replace_default_rules: true\n"+allowAll)
+	w = makeSecretsPolicyRequestForRelease(t, f, "authz-replace-jti", "sha256:other-digest")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected a policy replacing the default rules to admit the request, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+// testSecretsEndpointAuthzPolicyAllowed verifies that a policy whose rules do not
+// match leaves the request to the default effect.
+func testSecretsEndpointAuthzPolicyAllowed(t *testing.T) {
+	f := newTestSecretsFixture(t)
+	f.node.authzPolicy = newTestAuthzPolicy(t, `
+rules:
+  - name: deny-ecdsa-on-mainnet
+    effect: deny
+    match: request.attestation_method == "ecdsa" && node.chain_id == 1
+`)
+
+	w := makeSecretsPolicyRequest(t, f, "authz-allowed-jti")
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 when no rule matches, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
+// testSecretsEndpointAuthzPolicyEvalError verifies that a rule that fails to
+// evaluate denies rather than being skipped.
+func testSecretsEndpointAuthzPolicyEvalError(t *testing.T) {
+	f := newTestSecretsFixture(t)
+	f.node.authzPolicy = newTestAuthzPolicy(t, `
+rules:
+  - name: min-snp-firmware
+    effect: deny
+    match: claims.tcb.snp < 22
+`)
+
+	w := makeSecretsPolicyRequest(t, f, "authz-error-jti")
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("Expected 403 when a rule fails to evaluate, got %d. Body: %s", w.Code, w.Body.String())
+	}
+}
+
 // testSecretsEndpointExtraDataEchoBehavior verifies that extra_data is echoed
 // back in the response when present, and remains nil when omitted (backward compat).
 func testSecretsEndpointExtraDataEchoBehavior(t *testing.T) {
@@ -1200,7 +1820,7 @@ func testSecretsECDSABadPubKey(t *testing.T) {
 }
 
 func testSecretsNonECDSAStillRequiresRelease(t *testing.T) {
-	// Regression guard: gcp method with NO release must still 404 — the
+	// Regression guard: gcp method with NO release must still be refused — the
 	// release requirement is intact for non-ECDSA methods.
 	f := newTestSecretsFixture(t)
 	_, pubKeyPEM, err := encryption.GenerateKeyPair(2048)
@@ -1227,7 +1847,7 @@ func testSecretsNonECDSAStillRequiresRelease(t *testing.T) {
 	httpReq := httptest.NewRequest(http.MethodPost, "/secrets", bytes.NewBuffer(reqBody))
 	w := httptest.NewRecorder()
 	f.server.handleSecretsRequest(w, httpReq)
-	if w.Code != http.StatusNotFound {
-		t.Fatalf("expected 404 for gcp with no release, got %d body=%s", w.Code, w.Body.String())
+	if w.Code != http.StatusForbidden || !strings.Contains(w.Body.String(), `rule "release-required"`) {
+		t.Fatalf("expected the release-required rule to deny gcp with no release, got %d body=%s", w.Code, w.Body.String())
 	}
 }
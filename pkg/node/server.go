@@ -6,6 +6,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/metrics"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/time/rate"
 )
 
@@ -16,16 +21,28 @@ DKG Protocol Flow (Genesis - All operators participate):
   Phase 1: Share Distribution
     - Each node generates random polynomial f_i(z) and commitments
     - POST /dkg/commitment: Broadcast commitments to all operators
-    - POST /dkg/share: Send encrypted shares to each operator
-    - Each node waits for shares + commitments from ALL operators
+    - GET /share/key?session=: Fetch each recipient's share encryption key, signed for
+      this key and session
+    - POST /dkg/share: Send shares to each operator, ECIES-encrypted to the recipient
+      and bound to (key, sender, recipient, session, operator set)
+    - Each node waits (up to the protocol timeout) for shares + commitments from ALL operators
 
-  Phase 2: Verification & Acknowledgement
+  Phase 2: Complaints, Justification & Acknowledgement
     - Each node verifies received shares against commitments
-    - POST /dkg/ack: Send acknowledgement to each operator whose share was valid
-    - Each node waits for acknowledgements from ALL operators (100% required)
+    - POST /dkg/complaint: Every node sends every peer its signed complaints (possibly
+      none) against dealers whose share or commitments are missing or invalid
+    - POST /dkg/justification: An accused dealer answers to ALL operators. An invalid
+      share is revealed so anyone can check it against the dealer's commitments; a
+      missing share is re-sent sealed to the complainer, which alone checks it
+    - Dealers with an unanswered or failed complaint are dropped from the qualified set;
+      a complainer adopts the share re-sent to it
+    - POST /dkg/qualified: Every node announces its qualified set; the DKG aborts unless
+      every set received matches
+    - POST /dkg/ack: Send acknowledgement to each qualified dealer
+    - Each dealer waits for a threshold of acknowledgements
 
   Phase 3: Finalization
-    - Compute final key share: sum of all received shares
+    - Compute final key share: sum of the qualified dealers' shares
     - Store KeyShareVersion with IsActive=true
     - Master secret = Σ f_i(0) across all operators
 
@@ -48,6 +65,22 @@ Reshare Protocol Flow (New Operators):
   - Computes share via Lagrange interpolation
   - Stores first KeyShareVersion with IsActive=true
 
+Health Probes:
+  GET /healthz:
+    - Liveness: 200 whenever the process serves HTTP
+  GET /readyz:
+    - Readiness: 503 unless there is an active key version, persistence is healthy,
+      the chain poller is delivering blocks, and no auto-heal rollback is pending
+    - Both return { status, checks: [{ name, healthy, message }] }
+
+Multiple Keys:
+  - A process may hold one key per operator set, each served by its own Node behind a
+    KeyRouter: separate key versions, sessions and persistence namespace
+  - Every route is served under /v1/keys/{id}, e.g. POST /v1/keys/{id}/secrets; the
+    default key's routes are also served at the root
+  - GET /v1/keys lists the keys with their operator set and active version
+  - Apps are bound to a key by their operator set in the AppController
+
 Client Request Flow:
   GET /pubkey:
     - Returns operator's current commitments and key version
@@ -59,6 +92,37 @@ Client Request Flow:
     - Response: { partialSignature, operatorAddress }
     - Client collects ⌈2n/3⌉ signatures to recover app private key
 
+  POST /app/decrypt-share:
+    - Request: the /secrets request fields with ciphertext_c1s set, and extra_data
+      beginning with crypto.CiphertextBinding(ciphertext_c1s)
+    - Authorized exactly as /secrets (attestation, replay protection, release checks)
+    - Computes a decryption share e(Sign(H_1(appID)), C1) per ciphertext, with a
+      Chaum-Pedersen proof against the operator's public key share
+    - Response: { encrypted_decryption_shares, operator_address }, the shares hybrid
+      RSA encrypted to rsa_pubkey_tmp
+    - Client combines ⌈2n/3⌉ verified shares per ciphertext; the app private key is
+      never reconstructed
+
+  POST /v1/app/sign-message:
+    - Request: the /secrets request fields plus { message }
+    - Authorized exactly as /secrets (attestation, replay protection, release checks)
+    - Returns the RSA-encrypted partial signature over H_m(appID || message) under the
+      app's derived signing key t_app·s; ⌈2n/3⌉ of them combine into a standard BLS
+      signature verifiable against crypto.AppSigningPublicKey(appID, masterPublicKey)
+
+  GET /v1/app/pq-public-key?app_id=:
+    - Returns this operator's ML-KEM-768 encapsulation key for the app, signed by its
+      transport key; encryptors collect one per operator and share the post-quantum
+      secret of a version 3 ciphertext across them
+    - Derived from a seed this operator generates and never shares, not from its key share
+
+  POST /v1/app/pq-key:
+    - Request: the /secrets request fields plus { encapsulationKey } (ephemeral ML-KEM-768)
+    - Authorized exactly as /secrets
+    - Returns this operator's decapsulation key for the app, sealed to encapsulationKey
+      and then hybrid RSA-encrypted to rsaPubKey; with the app key, those of a threshold
+      of the operators a version 3 ciphertext names decrypt it
+
   POST /secrets:
     - Request: { appID, attestationMethod, attestation, rsaPubKey, attestTime, challenge?, publicKey?, extraData? }
     - attestationMethod: "gcp" (default), "intel", "ecdsa", or any registered method
@@ -66,6 +130,8 @@ Client Request Flow:
     - extraData: optional caller-supplied data (max 1 MB) bound into attestation by supporting methods
     - JTI replay protection applies automatically to any method that sets claims.JTI
     - Returns encrypted environment + RSA-encrypted partial signature + echoed extraData
+    - ciphertextC1s: optional; returns hybrid RSA-encrypted decryption shares of those
+      ciphertexts (and of the encrypted environment) instead of the partial signature
     - Used by TEE applications for secret retrieval
 
     Examples:
@@ -90,6 +156,9 @@ Authentication:
     { payload: []byte, hash: [32]byte, signature: []byte }
   - Signature verified using sender's BN254 public key from peering data
   - Payload contains fromOperatorAddress, toOperatorAddress, sessionTimestamp
+  - Share payloads are additionally encrypted to the recipient's share encryption key,
+    which the recipient announces per session, signed by its transport key
+    (GET /share/key?session=)
 */
 
 // Server handles HTTP requests for the node
@@ -115,8 +184,9 @@ func maxBodySize(maxBytes int64, next http.HandlerFunc) http.HandlerFunc {
 }
 
 // concurrencyLimit wraps a handler with a buffered channel semaphore.
-// Returns 503 Service Unavailable when the concurrency limit is reached.
-func concurrencyLimit(maxConcurrent int, next http.HandlerFunc) http.HandlerFunc {
+// Returns 503 Service Unavailable when the concurrency limit is reached,
+// calling onReject (if non-nil) for each rejected request.
+func concurrencyLimit(maxConcurrent int, onReject func(), next http.HandlerFunc) http.HandlerFunc {
 	sem := make(chan struct{}, maxConcurrent)
 	return func(w http.ResponseWriter, r *http.Request) {
 		select {
@@ -124,17 +194,24 @@ func concurrencyLimit(maxConcurrent int, next http.HandlerFunc) http.HandlerFunc
 			defer func() { <-sem }()
 			next(w, r)
 		default:
+			if onReject != nil {
+				onReject()
+			}
 			http.Error(w, "service unavailable", http.StatusServiceUnavailable)
 		}
 	}
 }
 
 // rateLimited wraps a handler with a token bucket rate limiter.
-// Returns 429 Too Many Requests when the rate limit is exceeded.
-func rateLimited(rps float64, burst int, next http.HandlerFunc) http.HandlerFunc {
+// Returns 429 Too Many Requests when the rate limit is exceeded,
+// calling onReject (if non-nil) for each rejected request.
+func rateLimited(rps float64, burst int, onReject func(), next http.HandlerFunc) http.HandlerFunc {
 	limiter := rate.NewLimiter(rate.Limit(rps), burst)
 	return func(w http.ResponseWriter, r *http.Request) {
 		if !limiter.Allow() {
+			if onReject != nil {
+				onReject()
+			}
 			http.Error(w, "too many requests", http.StatusTooManyRequests)
 			return
 		}
@@ -142,6 +219,95 @@ func rateLimited(rps float64, burst int, next http.HandlerFunc) http.HandlerFunc
 	}
 }
 
+// statusRecorder captures the status code written by a handler. Handlers that
+// serve /secrets also record the attestation method here for the per-method metric.
+type statusRecorder struct {
+	http.ResponseWriter
+	code              int
+	wroteHeader       bool
+	attestationMethod string
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	if !r.wroteHeader {
+		r.code = code
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(code)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	if !r.wroteHeader {
+		r.code = http.StatusOK
+		r.wroteHeader = true
+	}
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// setAttestationMethodLabel records the attestation method of a /secrets request on
+// the instrumented response writer. It is a no-op for uninstrumented writers.
+func setAttestationMethodLabel(w http.ResponseWriter, method string) {
+	if rec, ok := w.(*statusRecorder); ok {
+		rec.attestationMethod = method
+	}
+}
+
+// metrics returns the node's metrics, or nil (a valid no-op) if there are none.
+func (s *Server) metrics() *metrics.Metrics {
+	if s.node == nil {
+		return nil
+	}
+	return s.node.metrics
+}
+
+// instrument records request count, status and latency for handler.
+func (s *Server) instrument(handler string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		rec := &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+		next(rec, r)
+
+		m := s.metrics()
+		m.ObserveHTTPRequest(handler, r.Method, rec.code, time.Since(start))
+		if rec.attestationMethod != "" {
+			m.ObserveSecretsRequest(rec.attestationMethod, rec.code)
+		}
+	}
+}
+
+// traced runs next in a server span, recording the response status on it. Rejections
+// by the rate and concurrency limits happen outside the span.
+func (s *Server) traced(name string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx, span := tracing.Tracer().Start(r.Context(), name,
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				tracing.AttrKeyID.String(s.node.KeyID),
+				tracing.AttrOperatorAddress.String(s.node.OperatorAddress.Hex()),
+			))
+		defer span.End()
+
+		next(w, r.WithContext(ctx))
+
+		if rec, ok := w.(*statusRecorder); ok {
+			span.SetAttributes(attribute.Int("http.response.status_code", rec.code))
+			if rec.code >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, http.StatusText(rec.code))
+			}
+		}
+	}
+}
+
+// rejected returns an onReject callback counting rejections of handler for reason.
+func (s *Server) rejected(handler, reason string) func() {
+	return func() { s.metrics().IncHTTPRejection(handler, reason) }
+}
+
 // NewServer creates a new server instance
 func NewServer(node *Node, port int) *Server {
 	s := &Server{
@@ -151,21 +317,38 @@ func NewServer(node *Node, port int) *Server {
 	}
 
 	mux := http.NewServeMux()
+	handle := func(pattern string, h http.HandlerFunc) {
+		mux.HandleFunc(pattern, s.instrument(pattern, h))
+	}
 
 	// DKG endpoints
-	mux.HandleFunc("/dkg/share", maxBodySize(64<<10, s.handleDKGShare))
-	mux.HandleFunc("/dkg/commitment", maxBodySize(256<<10, s.handleDKGCommitment))
-	mux.HandleFunc("/dkg/ack", maxBodySize(64<<10, s.handleDKGAck))
-	mux.HandleFunc("/dkg/broadcast", maxBodySize(1<<20, s.handleCommitmentBroadcast))
+	handle("/dkg/share", maxBodySize(64<<10, s.handleDKGShare))
+	handle("/dkg/commitment", maxBodySize(256<<10, s.handleDKGCommitment))
+	handle("/dkg/ack", maxBodySize(64<<10, s.handleDKGAck))
+	handle("/dkg/complaint", maxBodySize(256<<10, s.handleDKGComplaint))
+	handle("/dkg/justification", maxBodySize(256<<10, s.handleDKGJustification))
+	handle("/dkg/qualified", maxBodySize(256<<10, s.handleDKGQualifiedSet))
+	handle("/dkg/broadcast", maxBodySize(1<<20, s.handleCommitmentBroadcast))
 
 	// Reshare endpoints
-	mux.HandleFunc("/reshare/share", maxBodySize(64<<10, s.handleReshareShare))
-	mux.HandleFunc("/reshare/share/request", maxBodySize(64<<10, s.handleReshareShareRequest))
-	mux.HandleFunc("/reshare/commitment", maxBodySize(256<<10, s.handleReshareCommitment))
-	mux.HandleFunc("/reshare/ack", maxBodySize(64<<10, s.handleReshareAck))
+	handle("/reshare/share", maxBodySize(64<<10, s.handleReshareShare))
+	handle("/reshare/share/request", maxBodySize(64<<10, s.handleReshareShareRequest))
+	handle("/reshare/commitment", maxBodySize(256<<10, s.handleReshareCommitment))
+	handle("/reshare/ack", maxBodySize(64<<10, s.handleReshareAck))
+
+	// Share encryption key (peers encrypt DKG/reshare shares to it)
+	handle("/share/key", s.handleShareEncryptionKey)
 
 	// App signing endpoint
-	mux.HandleFunc("/app/sign", rateLimited(50, 100, concurrencyLimit(20, maxBodySize(16<<10, s.handleAppSign))))
+	handle("/app/sign", rateLimited(50, 100, s.rejected("/app/sign", metrics.RejectionRateLimit),
+		concurrencyLimit(20, s.rejected("/app/sign", metrics.RejectionConcurrencyLimit),
+			maxBodySize(16<<10, s.handleAppSign))))
+
+	// Attested decryption shares for TEE applications; each share costs a few pairings,
+	// and the body carries the same attestation fields as /secrets
+	handle("/app/decrypt-share", rateLimited(10, 20, s.rejected("/app/decrypt-share", metrics.RejectionRateLimit),
+		concurrencyLimit(10, s.rejected("/app/decrypt-share", metrics.RejectionConcurrencyLimit),
+			maxBodySize(2<<20, s.traced("app_decrypt_share", s.handleAppDecryptShare)))))
 
 	// Secrets endpoint for TEE applications.
 	//
@@ -178,10 +361,29 @@ func NewServer(node *Node, port int) *Server {
 	// size check against types.MaxExtraDataSize still happens in the
 	// handler on the decoded bytes — this middleware limit is just
 	// "the JSON body must physically fit."
-	mux.HandleFunc("/secrets", rateLimited(10, 20, concurrencyLimit(10, maxBodySize(2<<20, s.handleSecretsRequest))))
+	handle("/secrets", rateLimited(10, 20, s.rejected("/secrets", metrics.RejectionRateLimit),
+		concurrencyLimit(10, s.rejected("/secrets", metrics.RejectionConcurrencyLimit),
+			maxBodySize(2<<20, s.traced("secrets", s.handleSecretsRequest)))))
+
+	// Attested message signing for TEE applications; same body budget as /secrets
+	handle("/v1/app/sign-message", rateLimited(10, 20, s.rejected("/v1/app/sign-message", metrics.RejectionRateLimit),
+		concurrencyLimit(10, s.rejected("/v1/app/sign-message", metrics.RejectionConcurrencyLimit),
+			maxBodySize(2<<20, s.traced("app_sign_message", s.handleAppSignMessage)))))
+
+	// Post-quantum ciphertext keys: the public keys are cheap to serve but each derives
+	// an ML-KEM key; the decapsulation key release is authorized like /secrets
+	handle("/v1/app/pq-public-key", rateLimited(20, 40, s.rejected("/v1/app/pq-public-key", metrics.RejectionRateLimit),
+		s.handleAppPQPublicKey))
+	handle("/v1/app/pq-key", rateLimited(10, 20, s.rejected("/v1/app/pq-key", metrics.RejectionRateLimit),
+		concurrencyLimit(10, s.rejected("/v1/app/pq-key", metrics.RejectionConcurrencyLimit),
+			maxBodySize(2<<20, s.traced("app_pq_key", s.handleAppPQKey)))))
 
 	// Public key endpoint for clients
-	mux.HandleFunc("/pubkey", s.handleGetCommitments)
+	handle("/pubkey", s.handleGetCommitments)
+
+	// Liveness and readiness probes
+	handle("/healthz", s.handleHealthz)
+	handle("/readyz", s.handleReadyz)
 
 	s.httpServer = &http.Server{
 		Addr:              fmt.Sprintf(":%d", port),
@@ -7,6 +7,8 @@ import (
 	"strings"
 	"sync"
 	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/metrics"
 )
 
 func TestMaxBodySize(t *testing.T) {
@@ -43,7 +45,7 @@ func TestConcurrencyLimit(t *testing.T) {
 	// entered signals that a handler goroutine is inside the handler.
 	entered := make(chan struct{}, 3)
 
-	handler := concurrencyLimit(2, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := concurrencyLimit(2, nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		entered <- struct{}{}
 		<-blocker
 		w.WriteHeader(http.StatusOK)
@@ -79,7 +81,7 @@ func TestConcurrencyLimit(t *testing.T) {
 }
 
 func TestRateLimited(t *testing.T) {
-	handler := rateLimited(1, 1, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := rateLimited(1, 1, nil, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
 
@@ -99,3 +101,58 @@ func TestRateLimited(t *testing.T) {
 		t.Fatalf("second request: expected 429, got %d", rec.Code)
 	}
 }
+
+func TestRateLimited_CallsOnReject(t *testing.T) {
+	rejections := 0
+	handler := rateLimited(1, 1, func() { rejections++ }, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < 3; i++ {
+		handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	}
+	if rejections != 2 {
+		t.Fatalf("expected 2 rejections, got %d", rejections)
+	}
+}
+
+// scrapeMetrics returns the Prometheus exposition text for m.
+func scrapeMetrics(t *testing.T, m *metrics.Metrics) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	return rec.Body.String()
+}
+
+func TestInstrument_RecordsStatusAndAttestationMethod(t *testing.T) {
+	m := metrics.NewMetrics()
+	s := &Server{node: &Node{metrics: m}}
+
+	handler := s.instrument("/secrets", func(w http.ResponseWriter, r *http.Request) {
+		setAttestationMethodLabel(w, "gcp")
+		http.Error(w, "forbidden", http.StatusForbidden)
+	})
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/secrets", nil))
+
+	text := scrapeMetrics(t, m)
+	for _, want := range []string{
+		`kms_http_requests_total{code="403",handler="/secrets",method="POST"} 1`,
+		`kms_tee_secrets_requests_total{attestation_method="gcp",code="403"} 1`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Fatalf("expected metrics to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestInstrument_WithoutMetrics(t *testing.T) {
+	s := &Server{}
+	handler := s.instrument("/pubkey", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	rec := httptest.NewRecorder()
+	handler(rec, httptest.NewRequest(http.MethodGet, "/pubkey", nil))
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+}
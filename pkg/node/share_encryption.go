@@ -0,0 +1,107 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+)
+
+// shareEncryptionPublicKey returns this node's share encryption public key in the
+// encoding peers expect on the wire.
+func (n *Node) shareEncryptionPublicKey() []byte {
+	return encryption.MarshalSharePublicKey(&n.shareEncryptionKey.PublicKey)
+}
+
+// shareEncryptionKeyAnnouncement builds the signed announcement served on /share/key
+// for one session of this node's key.
+func (n *Node) shareEncryptionKeyAnnouncement(sessionTimestamp int64) ([]byte, error) {
+	msg := types.ShareEncryptionKeyMessage{
+		OperatorAddress:  n.OperatorAddress,
+		KeyID:            n.KeyID,
+		SessionTimestamp: sessionTimestamp,
+		PublicKey:        n.shareEncryptionPublicKey(),
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal share encryption key: %w", err)
+	}
+	authMsg, err := n.transportSigner.CreateAuthenticatedMessage(msgBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign share encryption key: %w", err)
+	}
+	return json.Marshal(authMsg)
+}
+
+// resolveShareEncryptionKey fetches the key `peer` receives shares under in
+// sessionTimestamp and verifies the announcement is signed by the peer's registered
+// transport key and names the peer, this node's key and the session. A key served by
+// anyone else (a MITM on the socket address, or another operator replaying its own
+// announcement) is rejected, and so is an announcement captured from another session or
+// key, so a share is only ever encrypted to a key its intended recipient holds now.
+func (n *Node) resolveShareEncryptionKey(peer *peering.OperatorSetPeer, sessionTimestamp int64) (*ecies.PublicKey, error) {
+	authMsg, err := n.transport.FetchShareEncryptionKey(peer, sessionTimestamp)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.verifyMessage(authMsg, peer); err != nil {
+		return nil, fmt.Errorf("share encryption key from %s failed authentication: %w", peer.OperatorAddress.Hex(), err)
+	}
+
+	var keyMsg types.ShareEncryptionKeyMessage
+	if err := json.Unmarshal(authMsg.Payload, &keyMsg); err != nil {
+		return nil, fmt.Errorf("failed to parse share encryption key from %s: %w", peer.OperatorAddress.Hex(), err)
+	}
+	if keyMsg.OperatorAddress != peer.OperatorAddress {
+		return nil, fmt.Errorf("share encryption key announced for %s, expected %s",
+			keyMsg.OperatorAddress.Hex(), peer.OperatorAddress.Hex())
+	}
+	if keyMsg.KeyID != n.KeyID || keyMsg.SessionTimestamp != sessionTimestamp {
+		return nil, fmt.Errorf("share encryption key from %s announced for key %q session %d, expected key %q session %d",
+			peer.OperatorAddress.Hex(), keyMsg.KeyID, keyMsg.SessionTimestamp, n.KeyID, sessionTimestamp)
+	}
+	return encryption.ParseSharePublicKey(keyMsg.PublicKey)
+}
+
+// shareBinding returns the binding of a share dealt from `from` to `to` in session.
+func (n *Node) shareBinding(label string, from, to common.Address, session *ProtocolSession) encryption.ShareBinding {
+	session.mu.RLock()
+	operators := sessionParticipantIDs(session.Operators)
+	session.mu.RUnlock()
+	return encryption.ShareBinding{
+		Label:            label,
+		KeyID:            n.KeyID,
+		From:             from,
+		To:               to,
+		SessionTimestamp: session.SessionTimestamp,
+		Operators:        operators,
+	}
+}
+
+// decryptShareMessage opens the encrypted share in msg, which must come from `sender`
+// and be addressed to this node in session. The ciphertext's binding is rebuilt from
+// the authenticated sender, this node's address, its key and the session, so a share
+// captured from another session, key or operator set (or meant for another recipient)
+// fails to decrypt.
+func (n *Node) decryptShareMessage(label string, sender common.Address, session *ProtocolSession, msg *types.ShareMessage) (*fr.Element, error) {
+	if msg.FromOperatorAddress != sender {
+		return nil, fmt.Errorf("share sender %s does not match authenticated sender %s",
+			msg.FromOperatorAddress.Hex(), sender.Hex())
+	}
+	if msg.ToOperatorAddress != n.OperatorAddress {
+		return nil, fmt.Errorf("share addressed to %s, not this node %s",
+			msg.ToOperatorAddress.Hex(), n.OperatorAddress.Hex())
+	}
+	if msg.SessionTimestamp != session.SessionTimestamp {
+		return nil, fmt.Errorf("share is for session %d, not %d", msg.SessionTimestamp, session.SessionTimestamp)
+	}
+	if len(msg.EncryptedShare) == 0 {
+		return nil, fmt.Errorf("encrypted share is required")
+	}
+	return encryption.DecryptShare(n.shareEncryptionKey, msg.EncryptedShare, n.shareBinding(label, sender, n.OperatorAddress, session))
+}
@@ -0,0 +1,193 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/crypto-libs/pkg/bn254"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/transport"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/transportSigner/inMemoryTransportSigner"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// newShareEncryptionTestNode builds a node with a BN254 transport signer and a share
+// encryption key, plus the peer record other nodes would see for it.
+func newShareEncryptionTestNode(t *testing.T, addr common.Address, keyByte byte) (*Node, *peering.OperatorSetPeer) {
+	t.Helper()
+	logger := zap.NewNop()
+	skBytes := make([]byte, 32)
+	skBytes[31] = keyByte
+
+	signer, err := inMemoryTransportSigner.NewBn254InMemoryTransportSigner(skBytes, logger)
+	require.NoError(t, err)
+	sk, err := bn254.NewPrivateKeyFromBytes(skBytes)
+	require.NoError(t, err)
+	shareKey, err := encryption.GenerateShareKey()
+	require.NoError(t, err)
+
+	n := &Node{
+		logger:             logger,
+		OperatorAddress:    addr,
+		KeyID:              types.DefaultKeyID,
+		transportSigner:    signer,
+		shareEncryptionKey: shareKey,
+		transport:          transport.NewClient(addr, signer),
+		activeSessions:     make(map[int64]*ProtocolSession),
+		sessionNotify:      make(map[int64]chan struct{}),
+	}
+	peer := &peering.OperatorSetPeer{
+		OperatorAddress: addr,
+		CurveType:       config.CurveTypeBN254,
+		WrappedPublicKey: peering.WrappedPublicKey{
+			PublicKey: sk.Public(),
+		},
+	}
+	return n, peer
+}
+
+func serveShareKey(t *testing.T, n *Node) string {
+	t.Helper()
+	s := &Server{node: n}
+	srv := httptest.NewServer(http.HandlerFunc(s.handleShareEncryptionKey))
+	t.Cleanup(srv.Close)
+	return srv.URL
+}
+
+// openShareSession opens a DKG session over operators on every node.
+func openShareSession(t *testing.T, sessionTimestamp int64, operators []*peering.OperatorSetPeer, nodes ...*Node) map[*Node]*ProtocolSession {
+	t.Helper()
+	sessions := make(map[*Node]*ProtocolSession, len(nodes))
+	for _, n := range nodes {
+		session, err := n.createSession("dkg", operators, sessionTimestamp)
+		require.NoError(t, err)
+		sessions[n] = session
+	}
+	return sessions
+}
+
+func TestResolveShareEncryptionKey_VerifiesAnnouncement(t *testing.T) {
+	dealerAddr := common.HexToAddress("0x000000000000000000000000000000000000000A")
+	recipientAddr := common.HexToAddress("0x000000000000000000000000000000000000000B")
+
+	dealer, dealerPeer := newShareEncryptionTestNode(t, dealerAddr, 1)
+	recipient, recipientPeer := newShareEncryptionTestNode(t, recipientAddr, 2)
+	recipientPeer.SocketAddress = serveShareKey(t, recipient)
+	operators := []*peering.OperatorSetPeer{dealerPeer, recipientPeer}
+	sessions := openShareSession(t, 100, operators, dealer, recipient)
+
+	key, err := dealer.resolveShareEncryptionKey(recipientPeer, 100)
+	require.NoError(t, err)
+	require.Equal(t, recipient.shareEncryptionPublicKey(), encryption.MarshalSharePublicKey(key))
+
+	// End to end: a share encrypted by the dealer opens only for this session.
+	share := new(fr.Element).SetUint64(31337)
+	ct, err := encryption.EncryptShare(key, share, dealer.shareBinding(encryption.ShareLabelDKG, dealerAddr, recipientAddr, sessions[dealer]))
+	require.NoError(t, err)
+
+	msg := &types.ShareMessage{
+		FromOperatorAddress: dealerAddr,
+		ToOperatorAddress:   recipientAddr,
+		SessionTimestamp:    100,
+		EncryptedShare:      ct,
+	}
+	got, err := recipient.decryptShareMessage(encryption.ShareLabelDKG, dealerAddr, sessions[recipient], msg)
+	require.NoError(t, err)
+	require.True(t, got.Equal(share))
+
+	other := openShareSession(t, 200, operators, recipient)[recipient]
+	replayed := *msg
+	replayed.SessionTimestamp = 200
+	_, err = recipient.decryptShareMessage(encryption.ShareLabelDKG, dealerAddr, other, &replayed)
+	require.Error(t, err, "a ciphertext replayed into another session must not decrypt")
+}
+
+// An announcement is only served for a session the node is running, and one fetched for
+// one session or key is not accepted for another.
+func TestResolveShareEncryptionKey_BoundToSessionAndKey(t *testing.T) {
+	dealerAddr := common.HexToAddress("0x000000000000000000000000000000000000000A")
+	recipientAddr := common.HexToAddress("0x000000000000000000000000000000000000000B")
+
+	dealer, dealerPeer := newShareEncryptionTestNode(t, dealerAddr, 1)
+	recipient, recipientPeer := newShareEncryptionTestNode(t, recipientAddr, 2)
+	recipientPeer.SocketAddress = serveShareKey(t, recipient)
+	openShareSession(t, 100, []*peering.OperatorSetPeer{dealerPeer, recipientPeer}, recipient)
+
+	t.Run("replayed into another session", func(t *testing.T) {
+		authMsg, err := dealer.transport.FetchShareEncryptionKey(recipientPeer, 100)
+		require.NoError(t, err)
+		replay := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_ = json.NewEncoder(w).Encode(authMsg)
+		}))
+		defer replay.Close()
+		replayPeer := *recipientPeer
+		replayPeer.SocketAddress = replay.URL
+
+		_, err = dealer.resolveShareEncryptionKey(&replayPeer, 300)
+		require.ErrorContains(t, err, "session 100")
+	})
+	t.Run("another key", func(t *testing.T) {
+		dealer.KeyID = "treasury"
+		defer func() { dealer.KeyID = types.DefaultKeyID }()
+		_, err := dealer.resolveShareEncryptionKey(recipientPeer, 100)
+		require.ErrorContains(t, err, `key "default"`)
+	})
+}
+
+// An announcement signed by any key other than the recipient's registered key (e.g. an
+// attacker serving its own key on the recipient's socket) must be rejected.
+func TestResolveShareEncryptionKey_RejectsForeignSigner(t *testing.T) {
+	dealerAddr := common.HexToAddress("0x000000000000000000000000000000000000000A")
+	recipientAddr := common.HexToAddress("0x000000000000000000000000000000000000000B")
+
+	dealer, _ := newShareEncryptionTestNode(t, dealerAddr, 1)
+	_, recipientPeer := newShareEncryptionTestNode(t, recipientAddr, 2)
+	impostor, _ := newShareEncryptionTestNode(t, recipientAddr, 3)
+	recipientPeer.SocketAddress = serveShareKey(t, impostor)
+	openShareSession(t, 100, []*peering.OperatorSetPeer{recipientPeer}, impostor)
+
+	_, err := dealer.resolveShareEncryptionKey(recipientPeer, 100)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "failed authentication")
+}
+
+func TestDecryptShareMessage_RejectsMisaddressed(t *testing.T) {
+	dealerAddr := common.HexToAddress("0x000000000000000000000000000000000000000A")
+	recipientAddr := common.HexToAddress("0x000000000000000000000000000000000000000B")
+	recipient, recipientPeer := newShareEncryptionTestNode(t, recipientAddr, 2)
+	_, dealerPeer := newShareEncryptionTestNode(t, dealerAddr, 1)
+	session := openShareSession(t, 100, []*peering.OperatorSetPeer{dealerPeer, recipientPeer}, recipient)[recipient]
+
+	ct, err := encryption.EncryptShare(&recipient.shareEncryptionKey.PublicKey, new(fr.Element).SetUint64(5),
+		recipient.shareBinding(encryption.ShareLabelReshare, dealerAddr, recipientAddr, session))
+	require.NoError(t, err)
+
+	t.Run("wrong recipient", func(t *testing.T) {
+		msg := &types.ShareMessage{FromOperatorAddress: dealerAddr, ToOperatorAddress: common.HexToAddress("0xC"), SessionTimestamp: 100, EncryptedShare: ct}
+		_, err := recipient.decryptShareMessage(encryption.ShareLabelReshare, dealerAddr, session, msg)
+		require.Error(t, err)
+	})
+	t.Run("sender mismatch", func(t *testing.T) {
+		msg := &types.ShareMessage{FromOperatorAddress: dealerAddr, ToOperatorAddress: recipientAddr, SessionTimestamp: 100, EncryptedShare: ct}
+		_, err := recipient.decryptShareMessage(encryption.ShareLabelReshare, common.HexToAddress("0xD"), session, msg)
+		require.Error(t, err)
+	})
+	t.Run("missing ciphertext", func(t *testing.T) {
+		msg := &types.ShareMessage{FromOperatorAddress: dealerAddr, ToOperatorAddress: recipientAddr, SessionTimestamp: 100}
+		_, err := recipient.decryptShareMessage(encryption.ShareLabelReshare, dealerAddr, session, msg)
+		require.Error(t, err)
+	})
+	t.Run("wrong protocol label", func(t *testing.T) {
+		msg := &types.ShareMessage{FromOperatorAddress: dealerAddr, ToOperatorAddress: recipientAddr, SessionTimestamp: 100, EncryptedShare: ct}
+		_, err := recipient.decryptShareMessage(encryption.ShareLabelDKG, dealerAddr, session, msg)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+package node
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+)
+
+// status reports everything the node believes about its key: the admin state, every
+// key version with its master public key, and per-operator progress of the sessions
+// in flight. The last processed boundary is the persisted one, which the block handler
+// writes at every boundary.
+func (n *Node) status() (*types.NodeStatus, error) {
+	state, err := n.adminState()
+	if err != nil {
+		return nil, err
+	}
+	st, err := n.persistence.LoadNodeState()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load node state: %w", err)
+	}
+
+	status := &types.NodeStatus{
+		AdminKeyState:   *state,
+		OperatorAddress: n.OperatorAddress.Hex(),
+		PlatformRpcURL:  n.PlatformRpcURL(),
+		Sessions:        n.adminSessions(),
+	}
+	if st != nil {
+		status.LastProcessedBoundary = st.LastProcessedBoundary
+	}
+
+	poisoned := make(map[int64]bool, len(state.PoisonedVersions))
+	for _, v := range state.PoisonedVersions {
+		poisoned[v] = true
+	}
+	versions := n.keyStore.Versions()
+	status.Versions = make([]types.KeyVersionStatus, 0, len(versions))
+	for _, v := range versions {
+		status.Versions = append(status.Versions, types.KeyVersionStatus{
+			Version:         v.Version,
+			Generation:      v.Generation,
+			Active:          v.Version == state.ActiveVersion,
+			Poisoned:        poisoned[v.Version],
+			MasterPublicKey: v.MasterPublicKey,
+			Participants:    len(v.ParticipantIDs),
+		})
+	}
+	return status, nil
+}
+
+// handleStatus reports the status of every key. It is served on the admin listener
+// only: the report names peers, versions and the platform RPC endpoint.
+func (as *AdminServer) handleStatus(w http.ResponseWriter, r *http.Request) {
+	statuses := make([]*types.NodeStatus, 0, len(as.keyIDs))
+	for _, id := range as.keyIDs {
+		status, err := as.nodes[id].status()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		statuses = append(statuses, status)
+	}
+	writeAdminJSON(w, statuses)
+}
@@ -0,0 +1,66 @@
+package node
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/crypto"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdminServer_Status(t *testing.T) {
+	as, n := newAdminTestServer(t)
+	mpk := &types.G2Point{CompressedBytes: crypto.G2Generator.CompressedBytes}
+	n.keyStore.AddVersion(&types.KeyShareVersion{Version: 7000, MasterPublicKey: mpk})
+	n.keyStore.MarkPoisoned(4000)
+	require.NoError(t, n.persistence.AddPoisonedVersion(4000))
+
+	st, err := n.persistence.LoadNodeState()
+	require.NoError(t, err)
+	st.LastProcessedBoundary = 120
+	require.NoError(t, n.persistence.SaveNodeState(st))
+	n.platformURL.Store("platform.example:443")
+
+	rec := adminRequest(t, as, http.MethodGet, "/v1/status", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var statuses []types.NodeStatus
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&statuses))
+	require.Len(t, statuses, 1)
+	status := statuses[0]
+
+	assert.Equal(t, "default", status.KeyID)
+	assert.Equal(t, n.OperatorAddress.Hex(), status.OperatorAddress)
+	assert.Equal(t, int64(6000), status.ActiveVersion)
+	assert.Equal(t, int64(5000), status.LastKnownGoodVersion)
+	assert.Equal(t, 2, status.ConsecutiveMPKAborts)
+	assert.Equal(t, int64(120), status.LastProcessedBoundary)
+	assert.Equal(t, "platform.example:443", status.PlatformRpcURL)
+	assert.Equal(t, []int64{4000}, status.PoisonedVersions)
+	assert.Empty(t, status.Sessions)
+
+	require.Len(t, status.Versions, 7)
+	byVersion := make(map[int64]types.KeyVersionStatus, len(status.Versions))
+	for _, v := range status.Versions {
+		byVersion[v.Version] = v
+	}
+	assert.True(t, byVersion[6000].Active)
+	assert.False(t, byVersion[5000].Active)
+	assert.True(t, byVersion[4000].Poisoned)
+	require.NotNil(t, byVersion[7000].MasterPublicKey)
+	assert.Equal(t, mpk.CompressedBytes, byVersion[7000].MasterPublicKey.CompressedBytes)
+}
+
+func TestNodeStatus_NoPersistedState(t *testing.T) {
+	n := newReadyTestNode(t)
+	require.NoError(t, n.persistence.SaveNodeState(&persistence.NodeState{}))
+
+	status, err := n.status()
+	require.NoError(t, err)
+	assert.Zero(t, status.LastProcessedBoundary)
+	require.Len(t, status.Versions, 1)
+	assert.True(t, status.Versions[0].Active)
+}
@@ -0,0 +1,50 @@
+package node
+
+import (
+	"context"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// protocolRun traces one DKG or reshare run: a span for the whole run, in the session's
+// trace (see tracing.SessionContext), and a child span per phase. The phase context is
+// what the phase's messages, waits and contract calls carry, so a stalled run shows
+// which phase it stalled in on every operator.
+type protocolRun struct {
+	protocol string
+	ctx      context.Context
+	span     trace.Span
+	phase    trace.Span
+}
+
+// startProtocolRun starts the span for a run of protocol in the given session.
+func (n *Node) startProtocolRun(protocol string, sessionTimestamp int64, attrs ...attribute.KeyValue) *protocolRun {
+	ctx := tracing.SessionContext(context.Background(), n.AVSAddress, n.OperatorSetId, sessionTimestamp)
+	attrs = append(attrs,
+		tracing.AttrKeyID.String(n.KeyID),
+		tracing.AttrOperatorAddress.String(n.OperatorAddress.Hex()),
+		tracing.AttrSessionTimestamp.Int64(sessionTimestamp),
+	)
+	ctx, span := tracing.Tracer().Start(ctx, protocol, trace.WithAttributes(attrs...))
+	return &protocolRun{protocol: protocol, ctx: ctx, span: span}
+}
+
+// startPhase ends the current phase span, if any, and starts the next one.
+func (r *protocolRun) startPhase(name string) context.Context {
+	if r.phase != nil {
+		r.phase.End()
+	}
+	var ctx context.Context
+	ctx, r.phase = tracing.Tracer().Start(r.ctx, r.protocol+"."+name)
+	return ctx
+}
+
+// end ends the current phase and the run, recording err on both.
+func (r *protocolRun) end(err error) {
+	if r.phase != nil {
+		tracing.End(r.phase, err)
+	}
+	tracing.End(r.span, err)
+}
@@ -0,0 +1,60 @@
+package peering
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/Layr-Labs/crypto-libs/pkg/bn254"
+	"github.com/Layr-Labs/crypto-libs/pkg/ecdsa"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// VerifyMessage checks that authMsg was signed by the peer's registered transport key:
+// its hash must match its payload, and its signature must verify against the peer's
+// BN254 public key or ECDSA address, depending on the peer's curve type.
+func (p *OperatorSetPeer) VerifyMessage(authMsg *types.AuthenticatedMessage) error {
+	actualHash := crypto.Keccak256(authMsg.Payload)
+	if !bytes.Equal(actualHash, authMsg.Hash[:]) {
+		return fmt.Errorf("payload digest mismatch")
+	}
+
+	switch p.CurveType {
+	case config.CurveTypeBN254:
+		// Verify signature using BN254 (must use VerifySolidityCompatible to match SignSolidityCompatible)
+		sig, err := bn254.NewSignatureFromBytes(authMsg.Signature)
+		if err != nil {
+			return fmt.Errorf("invalid signature format: %w", err)
+		}
+
+		// Type assert to BN254 public key
+		bn254PubKey, ok := p.WrappedPublicKey.PublicKey.(*bn254.PublicKey)
+		if !ok {
+			return fmt.Errorf("sender public key is not BN254 type")
+		}
+
+		isValid, err := sig.VerifySolidityCompatible(bn254PubKey, authMsg.Hash)
+		if err != nil {
+			return fmt.Errorf("signature verification error: %w", err)
+		}
+		if !isValid {
+			return fmt.Errorf("signature verification failed")
+		}
+	case config.CurveTypeECDSA:
+		sig, err := ecdsa.NewSignatureFromBytes(authMsg.Signature)
+		if err != nil {
+			return fmt.Errorf("invalid ECDSA signature format: %w", err)
+		}
+		verified, err := sig.VerifyWithAddress(actualHash, p.WrappedPublicKey.ECDSAAddress)
+		if err != nil {
+			return fmt.Errorf("ECDSA signature verification error: %w", err)
+		}
+		if !verified {
+			return fmt.Errorf("ECDSA signature verification failed")
+		}
+	default:
+		return fmt.Errorf("unsupported curve type for sender: %v", p.CurveType)
+	}
+	return nil
+}
@@ -22,10 +22,12 @@ const (
 	keyPrefixKeyShare      = "keyshare:"
 	keyPrefixActiveVersion = "active:version"
 	keyPrefixNodeState     = "nodestate:main"
+	keyPrefixPQKeySeed     = "pqseed:main"
 	keyPrefixSession       = "session:"
 	keyPrefixBlockRecord   = "blockRecord:"
 	keyPrefixLastBlock     = "lastBlock:"
 	keyPrefixPoisoned      = "poisoned:"
+	keyPrefixFraudEvidence = "fraud:"
 	keySchemaVersion       = "metadata:schema_version"
 	currentSchemaVersion   = "v1"
 )
@@ -467,6 +469,77 @@ func (b *BadgerPersistence) LoadNodeState() (*persistence.NodeState, error) {
 	return state, nil
 }
 
+// SavePQKeySeed persists the post-quantum key seed
+func (b *BadgerPersistence) SavePQKeySeed(seed *types.PQKeySeed) error {
+	if seed == nil {
+		return fmt.Errorf("cannot save nil PQKeySeed")
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return fmt.Errorf("persistence layer is closed")
+	}
+
+	// Serialize to JSON
+	data, err := json.Marshal(seed)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PQKeySeed: %w", err)
+	}
+
+	return b.db.Update(func(txn *badgerdb.Txn) error {
+		return txn.Set([]byte(keyPrefixPQKeySeed), data)
+	})
+}
+
+// LoadPQKeySeed retrieves the post-quantum key seed
+func (b *BadgerPersistence) LoadPQKeySeed() (*types.PQKeySeed, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return nil, fmt.Errorf("persistence layer is closed")
+	}
+
+	var data []byte
+
+	err := b.db.View(func(txn *badgerdb.Txn) error {
+		item, err := txn.Get([]byte(keyPrefixPQKeySeed))
+		if err == badgerdb.ErrKeyNotFound {
+			return nil // Not found is not an error
+		}
+		if err != nil {
+			return err
+		}
+
+		return item.Value(func(val []byte) error {
+			data = append([]byte{}, val...) // Copy value
+			return nil
+		})
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PQKeySeed: %w", err)
+	}
+
+	if data == nil {
+		return nil, nil // Not found
+	}
+
+	// Deserialize from JSON
+	var seed *types.PQKeySeed
+	err = json.Unmarshal(data, &seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PQKeySeed: %w", err)
+	}
+	if seed == nil {
+		return nil, fmt.Errorf("stored PQKeySeed at key %q is a JSON null", keyPrefixPQKeySeed)
+	}
+
+	return seed, nil
+}
+
 // SaveProtocolSession persists protocol session state
 func (b *BadgerPersistence) SaveProtocolSession(session *persistence.ProtocolSessionState) error {
 	if session == nil {
@@ -757,6 +830,84 @@ func (b *BadgerPersistence) DeleteBlockRecord(chainId uint64, blockNumber uint64
 	})
 }
 
+// SaveFraudEvidence persists a fraud evidence bundle, overwriting any with the same ID
+func (b *BadgerPersistence) SaveFraudEvidence(evidence *types.FraudEvidence) error {
+	if evidence == nil {
+		return fmt.Errorf("cannot save nil FraudEvidence")
+	}
+	if evidence.ID == "" {
+		return fmt.Errorf("fraud evidence ID is required")
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return fmt.Errorf("persistence layer is closed")
+	}
+
+	data, err := json.Marshal(evidence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal FraudEvidence: %w", err)
+	}
+
+	key := keyPrefixFraudEvidence + evidence.ID
+
+	return b.db.Update(func(txn *badgerdb.Txn) error {
+		return txn.Set([]byte(key), data)
+	})
+}
+
+// ListFraudEvidence returns all fraud evidence bundles sorted by session timestamp, then ID
+func (b *BadgerPersistence) ListFraudEvidence() ([]*types.FraudEvidence, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		return nil, fmt.Errorf("persistence layer is closed")
+	}
+
+	evidence := make([]*types.FraudEvidence, 0)
+
+	err := b.db.View(func(txn *badgerdb.Txn) error {
+		opts := badgerdb.DefaultIteratorOptions
+		opts.Prefix = []byte(keyPrefixFraudEvidence)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+
+			var data []byte
+			err := item.Value(func(val []byte) error {
+				data = append([]byte{}, val...) // Copy value
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("failed to read value: %w", err)
+			}
+
+			var ev *types.FraudEvidence
+			if err := json.Unmarshal(data, &ev); err != nil || ev == nil {
+				b.logger.Sugar().Warnw("Failed to unmarshal FraudEvidence, skipping",
+					"key", string(item.Key()), "error", err)
+				continue
+			}
+
+			evidence = append(evidence, ev)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fraud evidence: %w", err)
+	}
+
+	persistence.SortFraudEvidence(evidence)
+	return evidence, nil
+}
+
 // Close shuts down the persistence layer
 func (b *BadgerPersistence) Close() error {
 	b.mu.Lock()
@@ -258,6 +258,33 @@ func TestBadgerPersistence_NodeState_Nil(t *testing.T) {
 	assert.Contains(t, err.Error(), "nil NodeState")
 }
 
+func TestBadgerPersistence_PQKeySeed(t *testing.T) {
+	tmpDir := t.TempDir()
+	testLogger, _ := logger.NewLogger(&logger.LoggerConfig{Debug: false})
+
+	bp, err := NewBadgerPersistence(tmpDir, testLogger)
+	require.NoError(t, err)
+	defer func() { _ = bp.Close() }()
+
+	// Initially no seed (first run)
+	seed, err := bp.LoadPQKeySeed()
+	require.NoError(t, err)
+	assert.Nil(t, seed)
+
+	err = bp.SavePQKeySeed(&types.PQKeySeed{Seed: []byte("0123456789abcdef0123456789abcdef"), CreatedAt: 1700000000})
+	require.NoError(t, err)
+
+	loaded, err := bp.LoadPQKeySeed()
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, []byte("0123456789abcdef0123456789abcdef"), loaded.Seed)
+	assert.Equal(t, int64(1700000000), loaded.CreatedAt)
+
+	err = bp.SavePQKeySeed(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nil PQKeySeed")
+}
+
 func TestBadgerPersistence_ProtocolSessions(t *testing.T) {
 	tmpDir := t.TempDir()
 	testLogger, _ := logger.NewLogger(&logger.LoggerConfig{Debug: false})
@@ -765,3 +792,42 @@ func TestBadgerPersistence_PoisonedVersions(t *testing.T) {
 	require.NoError(t, err)
 	require.ElementsMatch(t, []int64{1783944564, 1783944800}, got)
 }
+
+func TestBadgerPersistence_FraudEvidence(t *testing.T) {
+	tmpDir := t.TempDir()
+	testLogger, _ := logger.NewLogger(&logger.LoggerConfig{Debug: false})
+
+	bp, err := NewBadgerPersistence(tmpDir, testLogger)
+	require.NoError(t, err)
+
+	shareValue := fr.NewElement(7)
+	share := types.SerializeFr(&shareValue)
+	evidence := &types.FraudEvidence{
+		ID:               "invalid_share-1",
+		Kind:             types.FraudKindInvalidShare,
+		SessionTimestamp: 1234567890,
+		DealerAddress:    common.HexToAddress("0x01"),
+		ReporterAddress:  common.HexToAddress("0x02"),
+		Commitments:      []types.G2Point{{CompressedBytes: []byte{1, 2, 3}}},
+		Share:            share,
+		SignedShare:      &types.AuthenticatedMessage{Payload: []byte("payload"), Signature: []byte{9}},
+	}
+	require.NoError(t, bp.SaveFraudEvidence(evidence))
+	require.NoError(t, bp.SaveFraudEvidence(&types.FraudEvidence{ID: "equivocation-0", SessionTimestamp: 1}))
+	writeRawBytes(t, bp, keyPrefixFraudEvidence+"corrupt", []byte("null"))
+
+	// Evidence survives a restart.
+	require.NoError(t, bp.Close())
+	bp, err = NewBadgerPersistence(tmpDir, testLogger)
+	require.NoError(t, err)
+	defer func() { _ = bp.Close() }()
+
+	got, err := bp.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "equivocation-0", got[0].ID)
+	assert.Equal(t, evidence.DealerAddress, got[1].DealerAddress)
+	assert.Equal(t, evidence.Share.Data, got[1].Share.Data)
+	assert.Equal(t, evidence.SignedShare.Payload, got[1].SignedShare.Payload)
+	assert.Equal(t, evidence.Commitments, got[1].Commitments)
+}
@@ -0,0 +1,85 @@
+package encrypted
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+)
+
+// kmsEncryptionContextKey is the EncryptionContext entry that binds a wrapped DEK
+// to its record. KMS requires the same context on Decrypt, so a wrapped DEK
+// copied onto another record fails to unwrap.
+const kmsEncryptionContextKey = "eigenx-kms-record"
+
+// IKMSAPI is the subset of the AWS KMS client used to wrap DEKs. Any endpoint that
+// speaks the AWS KMS Encrypt/Decrypt API (AWS itself, LocalStack, local-kms) works.
+type IKMSAPI interface {
+	Encrypt(ctx context.Context, params *kms.EncryptInput, optFns ...func(*kms.Options)) (*kms.EncryptOutput, error)
+	Decrypt(ctx context.Context, params *kms.DecryptInput, optFns ...func(*kms.Options)) (*kms.DecryptOutput, error)
+}
+
+// AWSKMSKeyEncryptionKey wraps DEKs with a symmetric key held in an AWS KMS
+// compatible service. The KEK never leaves the KMS.
+type AWSKMSKeyEncryptionKey struct {
+	client IKMSAPI
+	keyID  string
+}
+
+// NewAWSKMSKEK creates a KEK backed by the KMS key keyID (key ID, ARN or alias).
+func NewAWSKMSKEK(client IKMSAPI, keyID string) (*AWSKMSKeyEncryptionKey, error) {
+	if client == nil {
+		return nil, fmt.Errorf("KMS client cannot be nil")
+	}
+	if keyID == "" {
+		return nil, fmt.Errorf("KMS key ID cannot be empty")
+	}
+	return &AWSKMSKeyEncryptionKey{client: client, keyID: keyID}, nil
+}
+
+// NewAWSKMSClient builds a KMS client from awsCfg. A non-empty endpoint overrides
+// the service endpoint, for KMS-compatible services other than AWS.
+func NewAWSKMSClient(awsCfg aws.Config, endpoint string) *kms.Client {
+	return kms.NewFromConfig(awsCfg, func(o *kms.Options) {
+		if endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+	})
+}
+
+// ID returns the KEK identifier.
+func (k *AWSKMSKeyEncryptionKey) ID() string {
+	return "aws-kms:" + k.keyID
+}
+
+// WrapKey encrypts dek with the KMS key.
+func (k *AWSKMSKeyEncryptionKey) WrapKey(ctx context.Context, dek, aad []byte) ([]byte, error) {
+	out, err := k.client.Encrypt(ctx, &kms.EncryptInput{
+		KeyId:             aws.String(k.keyID),
+		Plaintext:         dek,
+		EncryptionContext: kmsEncryptionContext(aad),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("KMS encrypt failed: %w", err)
+	}
+	return out.CiphertextBlob, nil
+}
+
+// UnwrapKey decrypts a DEK produced by WrapKey.
+func (k *AWSKMSKeyEncryptionKey) UnwrapKey(ctx context.Context, wrapped, aad []byte) ([]byte, error) {
+	out, err := k.client.Decrypt(ctx, &kms.DecryptInput{
+		KeyId:             aws.String(k.keyID),
+		CiphertextBlob:    wrapped,
+		EncryptionContext: kmsEncryptionContext(aad),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("KMS decrypt failed: %w", err)
+	}
+	return out.Plaintext, nil
+}
+
+func kmsEncryptionContext(aad []byte) map[string]string {
+	return map[string]string{kmsEncryptionContextKey: hex.EncodeToString(aad)}
+}
@@ -0,0 +1,166 @@
+package encrypted
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/kms"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// localKMS is a minimal stand-in for an AWS KMS endpoint. It speaks the awsJson1.1
+// Encrypt/Decrypt protocol the SDK uses and enforces EncryptionContext the way KMS
+// does, so the real SDK client can be exercised without network access.
+type localKMS struct {
+	keyID string
+	aead  cipher.AEAD
+}
+
+func newLocalKMS(t *testing.T, keyID string) (*localKMS, *kms.Client) {
+	t.Helper()
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+
+	l := &localKMS{keyID: keyID, aead: aead}
+	srv := httptest.NewServer(l)
+	t.Cleanup(srv.Close)
+
+	client := kms.New(kms.Options{
+		BaseEndpoint: aws.String(srv.URL),
+		Region:       "us-east-1",
+		Credentials: aws.CredentialsProviderFunc(func(context.Context) (aws.Credentials, error) {
+			return aws.Credentials{AccessKeyID: "test", SecretAccessKey: "test"}, nil
+		}),
+	})
+	return l, client
+}
+
+type localKMSRequest struct {
+	KeyId             string
+	Plaintext         []byte
+	CiphertextBlob    []byte
+	EncryptionContext map[string]string
+}
+
+func (l *localKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req localKMSRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		l.fail(w, "SerializationException", err.Error())
+		return
+	}
+	if req.KeyId != l.keyID {
+		l.fail(w, "NotFoundException", "key not found")
+		return
+	}
+	aad := canonicalContext(req.EncryptionContext)
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "TrentService.") {
+	case "Encrypt":
+		nonce := make([]byte, l.aead.NonceSize())
+		_, _ = rand.Read(nonce)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"CiphertextBlob": l.aead.Seal(nonce, nonce, req.Plaintext, aad),
+			"KeyId":          l.keyID,
+		})
+	case "Decrypt":
+		n := l.aead.NonceSize()
+		if len(req.CiphertextBlob) < n {
+			l.fail(w, "InvalidCiphertextException", "ciphertext too short")
+			return
+		}
+		pt, err := l.aead.Open(nil, req.CiphertextBlob[:n], req.CiphertextBlob[n:], aad)
+		if err != nil {
+			l.fail(w, "InvalidCiphertextException", "decryption failed")
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"Plaintext": pt, "KeyId": l.keyID})
+	default:
+		l.fail(w, "UnknownOperationException", r.Header.Get("X-Amz-Target"))
+	}
+}
+
+func (l *localKMS) fail(w http.ResponseWriter, code, msg string) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.WriteHeader(http.StatusBadRequest)
+	_ = json.NewEncoder(w).Encode(map[string]string{"__type": code, "message": msg})
+}
+
+func canonicalContext(ctx map[string]string) []byte {
+	keys := make([]string, 0, len(ctx))
+	for k := range ctx {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		b.WriteString(k + "=" + ctx[k] + ";")
+	}
+	return []byte(b.String())
+}
+
+func TestAWSKMSKEK_WrapUnwrap(t *testing.T) {
+	_, client := newLocalKMS(t, "alias/eigenx-kms")
+	kek, err := NewAWSKMSKEK(client, "alias/eigenx-kms")
+	require.NoError(t, err)
+	require.Equal(t, "aws-kms:alias/eigenx-kms", kek.ID())
+
+	dek := make([]byte, dekSize)
+	wrapped, err := kek.WrapKey(context.Background(), dek, []byte("record-1"))
+	require.NoError(t, err)
+
+	got, err := kek.UnwrapKey(context.Background(), wrapped, []byte("record-1"))
+	require.NoError(t, err)
+	require.Equal(t, dek, got)
+
+	_, err = kek.UnwrapKey(context.Background(), wrapped, []byte("record-2"))
+	require.ErrorContains(t, err, "KMS decrypt failed")
+}
+
+func TestAWSKMSKEK_UnknownKey(t *testing.T) {
+	_, client := newLocalKMS(t, "alias/eigenx-kms")
+	kek, err := NewAWSKMSKEK(client, "alias/other")
+	require.NoError(t, err)
+
+	_, err = kek.WrapKey(context.Background(), make([]byte, dekSize), []byte("record-1"))
+	require.ErrorContains(t, err, "KMS encrypt failed")
+}
+
+func TestAWSKMSKEK_EncryptedPersistence(t *testing.T) {
+	_, client := newLocalKMS(t, "alias/eigenx-kms")
+	kek, err := NewAWSKMSKEK(client, "alias/eigenx-kms")
+	require.NoError(t, err)
+
+	ep, err := NewEncryptedPersistence(memory.NewMemoryPersistence(), kek, testScope, zap.NewNop())
+	require.NoError(t, err)
+
+	version := newTestKeyShareVersion(100, 1234)
+	require.NoError(t, ep.SaveKeyShareVersion(version))
+	loaded, err := ep.LoadKeyShareVersion(100)
+	require.NoError(t, err)
+	require.True(t, loaded.PrivateShare.Equal(version.PrivateShare))
+}
+
+func TestNewAWSKMSKEK_Validation(t *testing.T) {
+	_, err := NewAWSKMSKEK(nil, "alias/x")
+	require.Error(t, err)
+	_, client := newLocalKMS(t, "alias/x")
+	_, err = NewAWSKMSKEK(client, "")
+	require.Error(t, err)
+}
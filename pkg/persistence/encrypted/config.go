@@ -0,0 +1,70 @@
+package encrypted
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	internalAWS "github.com/Layr-Labs/eigenx-kms-go/internal/aws"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+)
+
+// NewKEKsFromConfig builds the current KEK and any previous KEKs described by cfg.
+// passphraseSalt salts passphrase-derived KEKs; it must not change across restarts.
+func NewKEKsFromConfig(ctx context.Context, cfg *config.EncryptionConfig, passphraseSalt []byte) (IKeyEncryptionKey, []IKeyEncryptionKey, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	var current IKeyEncryptionKey
+	var err error
+	switch cfg.KEKSource {
+	case config.KEKSourceKeyfile:
+		current, err = NewKeyfileKEK(cfg.KeyfilePath)
+	case config.KEKSourcePassphrase:
+		current, err = NewPassphraseKEK(cfg.Passphrase, passphraseSalt)
+	case config.KEKSourceAWSKMS:
+		current, err = newAWSKMSKEKFromConfig(ctx, cfg, cfg.AWSKMSKeyID)
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load %s KEK: %w", cfg.KEKSource, err)
+	}
+
+	previous := make([]IKeyEncryptionKey, 0, len(cfg.PreviousKEKs))
+	for _, spec := range cfg.PreviousKEKs {
+		kek, err := newPreviousKEK(ctx, cfg, spec, passphraseSalt)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load previous KEK %q: %w", spec, err)
+		}
+		previous = append(previous, kek)
+	}
+	return current, previous, nil
+}
+
+func newPreviousKEK(ctx context.Context, cfg *config.EncryptionConfig, spec string, passphraseSalt []byte) (IKeyEncryptionKey, error) {
+	kind, value, _ := strings.Cut(spec, ":")
+	switch kind {
+	case config.KEKSourceKeyfile:
+		return NewKeyfileKEK(value)
+	case config.KEKSourceAWSKMS:
+		return newAWSKMSKEKFromConfig(ctx, cfg, value)
+	case "passphrase-env":
+		// Passphrases are named by env var so they never appear in flags or logs.
+		passphrase := os.Getenv(value)
+		if passphrase == "" {
+			return nil, fmt.Errorf("environment variable %s is empty", value)
+		}
+		return NewPassphraseKEK(passphrase, passphraseSalt)
+	default:
+		return nil, fmt.Errorf("unknown KEK kind %q", kind)
+	}
+}
+
+func newAWSKMSKEKFromConfig(ctx context.Context, cfg *config.EncryptionConfig, keyID string) (IKeyEncryptionKey, error) {
+	awsCfg, err := internalAWS.LoadAWSConfig(ctx, cfg.AWSRegion)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS config: %w", err)
+	}
+	return NewAWSKMSKEK(NewAWSKMSClient(awsCfg, cfg.AWSKMSEndpoint), keyID)
+}
@@ -0,0 +1,726 @@
+// Package encrypted provides encryption at rest for node persistence. It wraps any
+// persistence.INodePersistence backend and envelope-encrypts the secrets that backend
+// would otherwise store in plaintext: KeyShareVersion.PrivateShare, the received and
+// dealt shares in ProtocolSessionState, the node's post-quantum key seed, and the share
+// recorded in fraud evidence. Each record gets a fresh AES-256-GCM data-encryption
+// key (DEK), which is in turn wrapped by a pluggable key-encryption key (KEK): a local
+// keyfile, a passphrase-derived key, or an AWS-KMS-compatible service.
+package encrypted
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+)
+
+// dekSize is the size of the per-record AES-256 data-encryption key.
+const dekSize = 32
+
+// kekOperationTimeout bounds a single wrap/unwrap call (a network round trip for KMS).
+const kekOperationTimeout = 30 * time.Second
+
+// ErrPlaintextRecord is returned when a stored record carries its secret in
+// plaintext. Once a KEK is configured only sealed records are trusted: a plaintext
+// record is either left over from before encryption was enabled (seal it with
+// MigratePlaintext) or was planted by someone with write access to the store.
+var ErrPlaintextRecord = errors.New("record is stored in plaintext; seal it with a one-time plaintext migration")
+
+// EncryptedPersistence decorates an INodePersistence with encryption at rest. All
+// methods that do not touch secret material pass straight through to the wrapped
+// backend.
+//
+// Records are sealed with the current KEK on every write. Reads accept records sealed
+// under the current KEK or any previous KEK supplied at construction and reject
+// plaintext records with ErrPlaintextRecord, so write access to the store is not
+// enough to plant a share. MigratePlaintext seals the records of a store written
+// before encryption was enabled and Rewrap completes a KEK rotation; both run while
+// the node keeps running.
+type EncryptedPersistence struct {
+	persistence.INodePersistence
+
+	kek      IKeyEncryptionKey
+	keksByID map[string]IKeyEncryptionKey
+	scope    RecordScope
+	logger   *zap.Logger
+
+	// writeMu serializes writes of sealed records with the Migrate/Rewrap
+	// read-modify-write passes, so a concurrent node write is never overwritten
+	// by a stale copy.
+	writeMu sync.Mutex
+}
+
+// RecordScope identifies whose records a store holds. It is bound into the AAD of every
+// sealed record, so a record copied to another operator's store, another key's
+// namespace or another Redis prefix fails to open even when the same KEK is shared.
+type RecordScope struct {
+	OperatorAddress common.Address
+	KeyID           string
+	// Namespace is the backend namespace the records live in (the Redis key prefix).
+	// Empty for backends that are not shared, such as a node's own Badger directory.
+	Namespace string
+}
+
+// aad returns the additional authenticated data for record within the scope. Fields are
+// NUL-separated so no two scopes produce the same bytes.
+func (s RecordScope) aad(record string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("eigenx-kms/sealed/v2")
+	for _, field := range []string{s.OperatorAddress.Hex(), s.KeyID, s.Namespace, record} {
+		buf.WriteByte(0)
+		buf.WriteString(field)
+	}
+	return buf.Bytes()
+}
+
+// NewEncryptedPersistence wraps inner so secrets are sealed under kek and bound to
+// scope. previous lists retired KEKs that may still have sealed existing records; they
+// are only used to read.
+func NewEncryptedPersistence(
+	inner persistence.INodePersistence,
+	kek IKeyEncryptionKey,
+	scope RecordScope,
+	logger *zap.Logger,
+	previous ...IKeyEncryptionKey,
+) (*EncryptedPersistence, error) {
+	if inner == nil {
+		return nil, fmt.Errorf("inner persistence cannot be nil")
+	}
+	if kek == nil {
+		return nil, fmt.Errorf("key-encryption key cannot be nil")
+	}
+	if scope.OperatorAddress == (common.Address{}) {
+		return nil, fmt.Errorf("record scope requires an operator address")
+	}
+	if scope.KeyID == "" {
+		scope.KeyID = types.DefaultKeyID
+	}
+	keksByID := map[string]IKeyEncryptionKey{kek.ID(): kek}
+	for _, p := range previous {
+		if p == nil {
+			continue
+		}
+		if _, exists := keksByID[p.ID()]; !exists {
+			keksByID[p.ID()] = p
+		}
+	}
+	return &EncryptedPersistence{
+		INodePersistence: inner,
+		kek:              kek,
+		keksByID:         keksByID,
+		scope:            scope,
+		logger:           logger,
+	}, nil
+}
+
+func (e *EncryptedPersistence) keyShareAAD(version int64) []byte {
+	return e.scope.aad(fmt.Sprintf("keyshare/%d", version))
+}
+
+func (e *EncryptedPersistence) sessionAAD(sessionTimestamp int64) []byte {
+	return e.scope.aad(fmt.Sprintf("session/%d", sessionTimestamp))
+}
+
+func (e *EncryptedPersistence) generatedSharesAAD(sessionTimestamp int64) []byte {
+	return e.scope.aad(fmt.Sprintf("session/%d/generated", sessionTimestamp))
+}
+
+func (e *EncryptedPersistence) fraudEvidenceAAD(id string) []byte {
+	return e.scope.aad("fraud/" + id)
+}
+
+// pqKeySeedAAD binds the node's single PQ key seed record.
+func (e *EncryptedPersistence) pqKeySeedAAD() []byte {
+	return e.scope.aad("pqseed")
+}
+
+// seal envelope-encrypts plaintext under a fresh DEK wrapped by the current KEK.
+func (e *EncryptedPersistence) seal(plaintext, aad []byte) (*types.SealedSecret, error) {
+	dek := make([]byte, dekSize)
+	if _, err := rand.Read(dek); err != nil {
+		return nil, fmt.Errorf("failed to generate data-encryption key: %w", err)
+	}
+	aead, err := newGCM(dek)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	ciphertext := aead.Seal(nil, nonce, plaintext, aad)
+
+	ctx, cancel := context.WithTimeout(context.Background(), kekOperationTimeout)
+	defer cancel()
+	wrapped, err := e.kek.WrapKey(ctx, dek, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data-encryption key: %w", err)
+	}
+
+	return &types.SealedSecret{
+		KEKID:      e.kek.ID(),
+		WrappedDEK: wrapped,
+		Nonce:      nonce,
+		Ciphertext: ciphertext,
+	}, nil
+}
+
+// open reverses seal, using whichever configured KEK sealed the record.
+func (e *EncryptedPersistence) open(sealed *types.SealedSecret, aad []byte) ([]byte, error) {
+	dek, err := e.unwrapDEK(sealed, aad)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := newGCM(dek)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt sealed secret: %w", err)
+	}
+	return plaintext, nil
+}
+
+func (e *EncryptedPersistence) unwrapDEK(sealed *types.SealedSecret, aad []byte) ([]byte, error) {
+	kek, ok := e.keksByID[sealed.KEKID]
+	if !ok {
+		return nil, fmt.Errorf("record sealed under unknown key-encryption key %q (configure it as a previous KEK to read it)", sealed.KEKID)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), kekOperationTimeout)
+	defer cancel()
+	dek, err := kek.UnwrapKey(ctx, sealed.WrappedDEK, aad)
+	if err != nil {
+		return nil, err
+	}
+	if len(dek) != dekSize {
+		return nil, fmt.Errorf("unwrapped data-encryption key has invalid length %d", len(dek))
+	}
+	return dek, nil
+}
+
+// rewrap re-wraps a sealed record's DEK under the current KEK. The ciphertext is
+// left untouched.
+func (e *EncryptedPersistence) rewrap(sealed *types.SealedSecret, aad []byte) (*types.SealedSecret, error) {
+	dek, err := e.unwrapDEK(sealed, aad)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), kekOperationTimeout)
+	defer cancel()
+	wrapped, err := e.kek.WrapKey(ctx, dek, aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to wrap data-encryption key: %w", err)
+	}
+	return &types.SealedSecret{
+		KEKID:      e.kek.ID(),
+		WrappedDEK: wrapped,
+		Nonce:      sealed.Nonce,
+		Ciphertext: sealed.Ciphertext,
+	}, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return aead, nil
+}
+
+// sealKeyShareVersion returns a copy of version with PrivateShare replaced by its
+// sealed form. Versions without a private share are returned unchanged.
+func (e *EncryptedPersistence) sealKeyShareVersion(version *types.KeyShareVersion) (*types.KeyShareVersion, error) {
+	if version.PrivateShare == nil {
+		return version, nil
+	}
+	shareBytes := version.PrivateShare.Bytes()
+	sealed, err := e.seal(shareBytes[:], e.keyShareAAD(version.Version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal private share for version %d: %w", version.Version, err)
+	}
+	out := *version
+	out.PrivateShare = nil
+	out.SealedPrivateShare = sealed
+	return &out, nil
+}
+
+// openKeyShareVersion restores PrivateShare in place from its sealed form.
+func (e *EncryptedPersistence) openKeyShareVersion(version *types.KeyShareVersion) error {
+	if version.PrivateShare != nil {
+		return fmt.Errorf("private share for version %d: %w", version.Version, ErrPlaintextRecord)
+	}
+	if version.SealedPrivateShare == nil {
+		return nil
+	}
+	plaintext, err := e.open(version.SealedPrivateShare, e.keyShareAAD(version.Version))
+	if err != nil {
+		return fmt.Errorf("failed to open private share for version %d: %w", version.Version, err)
+	}
+	share := new(fr.Element)
+	if err := share.SetBytesCanonical(plaintext); err != nil {
+		return fmt.Errorf("sealed private share for version %d is not a valid field element: %w", version.Version, err)
+	}
+	version.PrivateShare = share
+	version.SealedPrivateShare = nil
+	return nil
+}
+
+// sealProtocolSession returns a copy of session with Shares and GeneratedShares
+// replaced by their sealed forms.
+func (e *EncryptedPersistence) sealProtocolSession(session *persistence.ProtocolSessionState) (*persistence.ProtocolSessionState, error) {
+	if len(session.Shares) == 0 && len(session.GeneratedShares) == 0 {
+		return session, nil
+	}
+	out := *session
+	if len(session.Shares) > 0 {
+		sealed, err := e.sealShareMap(session.Shares, e.sessionAAD(session.SessionTimestamp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to seal shares for session %d: %w", session.SessionTimestamp, err)
+		}
+		out.Shares = nil
+		out.SealedShares = sealed
+	}
+	if len(session.GeneratedShares) > 0 {
+		sealed, err := e.sealShareMap(session.GeneratedShares, e.generatedSharesAAD(session.SessionTimestamp))
+		if err != nil {
+			return nil, fmt.Errorf("failed to seal generated shares for session %d: %w", session.SessionTimestamp, err)
+		}
+		out.GeneratedShares = nil
+		out.SealedGeneratedShares = sealed
+	}
+	return &out, nil
+}
+
+func (e *EncryptedPersistence) sealShareMap(shares map[string]string, aad []byte) (*types.SealedSecret, error) {
+	sharesJSON, err := json.Marshal(shares)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal session shares: %w", err)
+	}
+	return e.seal(sharesJSON, aad)
+}
+
+// openProtocolSession restores Shares and GeneratedShares in place from their sealed forms.
+func (e *EncryptedPersistence) openProtocolSession(session *persistence.ProtocolSessionState) error {
+	if len(session.Shares) > 0 || len(session.GeneratedShares) > 0 {
+		return fmt.Errorf("shares for session %d: %w", session.SessionTimestamp, ErrPlaintextRecord)
+	}
+	if session.SealedShares != nil {
+		shares, err := e.openShareMap(session.SealedShares, e.sessionAAD(session.SessionTimestamp))
+		if err != nil {
+			return fmt.Errorf("failed to open shares for session %d: %w", session.SessionTimestamp, err)
+		}
+		session.Shares = shares
+		session.SealedShares = nil
+	}
+	if session.SealedGeneratedShares != nil {
+		shares, err := e.openShareMap(session.SealedGeneratedShares, e.generatedSharesAAD(session.SessionTimestamp))
+		if err != nil {
+			return fmt.Errorf("failed to open generated shares for session %d: %w", session.SessionTimestamp, err)
+		}
+		session.GeneratedShares = shares
+		session.SealedGeneratedShares = nil
+	}
+	return nil
+}
+
+func (e *EncryptedPersistence) openShareMap(sealed *types.SealedSecret, aad []byte) (map[string]string, error) {
+	plaintext, err := e.open(sealed, aad)
+	if err != nil {
+		return nil, err
+	}
+	var shares map[string]string
+	if err := json.Unmarshal(plaintext, &shares); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal shares: %w", err)
+	}
+	return shares, nil
+}
+
+// SaveKeyShareVersion seals the private share and persists the version.
+func (e *EncryptedPersistence) SaveKeyShareVersion(version *types.KeyShareVersion) error {
+	if version == nil {
+		return fmt.Errorf("cannot save nil KeyShareVersion")
+	}
+	sealed, err := e.sealKeyShareVersion(version)
+	if err != nil {
+		return err
+	}
+	e.writeMu.Lock()
+	defer e.writeMu.Unlock()
+	return e.INodePersistence.SaveKeyShareVersion(sealed)
+}
+
+// LoadKeyShareVersion loads a version and opens its private share.
+func (e *EncryptedPersistence) LoadKeyShareVersion(timestamp int64) (*types.KeyShareVersion, error) {
+	version, err := e.INodePersistence.LoadKeyShareVersion(timestamp)
+	if err != nil || version == nil {
+		return version, err
+	}
+	if err := e.openKeyShareVersion(version); err != nil {
+		return nil, err
+	}
+	return version, nil
+}
+
+// ListKeyShareVersions lists all versions with their private shares opened.
+func (e *EncryptedPersistence) ListKeyShareVersions() ([]*types.KeyShareVersion, error) {
+	versions, err := e.INodePersistence.ListKeyShareVersions()
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range versions {
+		if err := e.openKeyShareVersion(v); err != nil {
+			return nil, err
+		}
+	}
+	return versions, nil
+}
+
+// SaveProtocolSession seals the session's received and dealt shares and persists it.
+func (e *EncryptedPersistence) SaveProtocolSession(session *persistence.ProtocolSessionState) error {
+	if session == nil {
+		return fmt.Errorf("cannot save nil ProtocolSessionState")
+	}
+	sealed, err := e.sealProtocolSession(session)
+	if err != nil {
+		return err
+	}
+	e.writeMu.Lock()
+	defer e.writeMu.Unlock()
+	return e.INodePersistence.SaveProtocolSession(sealed)
+}
+
+// LoadProtocolSession loads a session and opens its shares.
+func (e *EncryptedPersistence) LoadProtocolSession(sessionTimestamp int64) (*persistence.ProtocolSessionState, error) {
+	session, err := e.INodePersistence.LoadProtocolSession(sessionTimestamp)
+	if err != nil || session == nil {
+		return session, err
+	}
+	if err := e.openProtocolSession(session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// ListProtocolSessions lists all sessions with their shares opened.
+func (e *EncryptedPersistence) ListProtocolSessions() ([]*persistence.ProtocolSessionState, error) {
+	sessions, err := e.INodePersistence.ListProtocolSessions()
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range sessions {
+		if err := e.openProtocolSession(s); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+// SavePQKeySeed seals the seed and persists it.
+func (e *EncryptedPersistence) SavePQKeySeed(seed *types.PQKeySeed) error {
+	if seed == nil {
+		return fmt.Errorf("cannot save nil PQKeySeed")
+	}
+	sealed, err := e.sealPQKeySeed(seed)
+	if err != nil {
+		return err
+	}
+	e.writeMu.Lock()
+	defer e.writeMu.Unlock()
+	return e.INodePersistence.SavePQKeySeed(sealed)
+}
+
+// LoadPQKeySeed loads the seed and opens it.
+func (e *EncryptedPersistence) LoadPQKeySeed() (*types.PQKeySeed, error) {
+	seed, err := e.INodePersistence.LoadPQKeySeed()
+	if err != nil || seed == nil {
+		return seed, err
+	}
+	if seed.SealedSeed == nil {
+		return nil, fmt.Errorf("PQ key seed: %w", ErrPlaintextRecord)
+	}
+	plaintext, err := e.open(seed.SealedSeed, e.pqKeySeedAAD())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open PQ key seed: %w", err)
+	}
+	seed.Seed = plaintext
+	seed.SealedSeed = nil
+	return seed, nil
+}
+
+// sealPQKeySeed returns a copy of seed with Seed replaced by its sealed form. A seed
+// that is already sealed is returned unchanged.
+func (e *EncryptedPersistence) sealPQKeySeed(seed *types.PQKeySeed) (*types.PQKeySeed, error) {
+	if seed.SealedSeed != nil {
+		return seed, nil
+	}
+	sealed, err := e.seal(seed.Seed, e.pqKeySeedAAD())
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal PQ key seed: %w", err)
+	}
+	out := *seed
+	out.Seed = nil
+	out.SealedSeed = sealed
+	return &out, nil
+}
+
+// SaveFraudEvidence seals the evidence's share and persists the bundle.
+func (e *EncryptedPersistence) SaveFraudEvidence(evidence *types.FraudEvidence) error {
+	if evidence == nil {
+		return fmt.Errorf("cannot save nil FraudEvidence")
+	}
+	sealed, err := e.sealFraudEvidence(evidence)
+	if err != nil {
+		return err
+	}
+	e.writeMu.Lock()
+	defer e.writeMu.Unlock()
+	return e.INodePersistence.SaveFraudEvidence(sealed)
+}
+
+// ListFraudEvidence lists all evidence bundles with their shares opened.
+func (e *EncryptedPersistence) ListFraudEvidence() ([]*types.FraudEvidence, error) {
+	evidence, err := e.INodePersistence.ListFraudEvidence()
+	if err != nil {
+		return nil, err
+	}
+	for _, ev := range evidence {
+		if ev.Share != nil {
+			return nil, fmt.Errorf("share in fraud evidence %s: %w", ev.ID, ErrPlaintextRecord)
+		}
+		if ev.SealedShare == nil {
+			continue
+		}
+		plaintext, err := e.open(ev.SealedShare, e.fraudEvidenceAAD(ev.ID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to open share in fraud evidence %s: %w", ev.ID, err)
+		}
+		var share types.SerializedFrElement
+		if err := json.Unmarshal(plaintext, &share); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal share in fraud evidence %s: %w", ev.ID, err)
+		}
+		ev.Share = &share
+		ev.SealedShare = nil
+	}
+	return evidence, nil
+}
+
+// sealFraudEvidence returns a copy of evidence with Share replaced by its sealed form.
+// Evidence without a share is returned unchanged.
+func (e *EncryptedPersistence) sealFraudEvidence(evidence *types.FraudEvidence) (*types.FraudEvidence, error) {
+	if evidence.Share == nil {
+		return evidence, nil
+	}
+	shareJSON, err := json.Marshal(evidence.Share)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal share in fraud evidence %s: %w", evidence.ID, err)
+	}
+	sealed, err := e.seal(shareJSON, e.fraudEvidenceAAD(evidence.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal share in fraud evidence %s: %w", evidence.ID, err)
+	}
+	out := *evidence
+	out.Share = nil
+	out.SealedShare = sealed
+	return &out, nil
+}
+
+// MigratePlaintext seals every record still stored in plaintext (written before
+// encryption at rest was enabled). It trusts whatever plaintext the store holds, so
+// it is meant to run once, at the operator's explicit request, when encryption is
+// first enabled. Safe to run while the node is live and idempotent; returns the
+// number of records sealed.
+func (e *EncryptedPersistence) MigratePlaintext() (int, error) {
+	e.writeMu.Lock()
+	defer e.writeMu.Unlock()
+
+	migrated := 0
+	versions, err := e.INodePersistence.ListKeyShareVersions()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list key share versions: %w", err)
+	}
+	for _, v := range versions {
+		if v.SealedPrivateShare != nil || v.PrivateShare == nil {
+			continue
+		}
+		sealed, err := e.sealKeyShareVersion(v)
+		if err != nil {
+			return migrated, err
+		}
+		if err := e.INodePersistence.SaveKeyShareVersion(sealed); err != nil {
+			return migrated, fmt.Errorf("failed to save sealed version %d: %w", v.Version, err)
+		}
+		migrated++
+	}
+
+	sessions, err := e.INodePersistence.ListProtocolSessions()
+	if err != nil {
+		return migrated, fmt.Errorf("failed to list protocol sessions: %w", err)
+	}
+	for _, s := range sessions {
+		if len(s.Shares) == 0 && len(s.GeneratedShares) == 0 {
+			continue
+		}
+		sealed, err := e.sealProtocolSession(s)
+		if err != nil {
+			return migrated, err
+		}
+		if err := e.INodePersistence.SaveProtocolSession(sealed); err != nil {
+			return migrated, fmt.Errorf("failed to save sealed session %d: %w", s.SessionTimestamp, err)
+		}
+		migrated++
+	}
+
+	seed, err := e.INodePersistence.LoadPQKeySeed()
+	if err != nil {
+		return migrated, fmt.Errorf("failed to load PQ key seed: %w", err)
+	}
+	if seed != nil && seed.SealedSeed == nil {
+		sealed, err := e.sealPQKeySeed(seed)
+		if err != nil {
+			return migrated, err
+		}
+		if err := e.INodePersistence.SavePQKeySeed(sealed); err != nil {
+			return migrated, fmt.Errorf("failed to save sealed PQ key seed: %w", err)
+		}
+		migrated++
+	}
+
+	evidence, err := e.INodePersistence.ListFraudEvidence()
+	if err != nil {
+		return migrated, fmt.Errorf("failed to list fraud evidence: %w", err)
+	}
+	for _, ev := range evidence {
+		if ev.SealedShare != nil || ev.Share == nil {
+			continue
+		}
+		sealed, err := e.sealFraudEvidence(ev)
+		if err != nil {
+			return migrated, err
+		}
+		if err := e.INodePersistence.SaveFraudEvidence(sealed); err != nil {
+			return migrated, fmt.Errorf("failed to save sealed fraud evidence %s: %w", ev.ID, err)
+		}
+		migrated++
+	}
+
+	if migrated > 0 {
+		e.logger.Sugar().Infow("Sealed plaintext records", "count", migrated, "kek_id", e.kek.ID())
+	}
+	return migrated, nil
+}
+
+// Rewrap re-wraps the DEK of every record sealed under a previous KEK with the
+// current KEK, completing a KEK rotation. Only the wrapped DEKs change. Safe to run
+// while the node is live and idempotent; returns the number of records re-wrapped.
+func (e *EncryptedPersistence) Rewrap() (int, error) {
+	e.writeMu.Lock()
+	defer e.writeMu.Unlock()
+
+	currentID := e.kek.ID()
+	rewrapped := 0
+
+	versions, err := e.INodePersistence.ListKeyShareVersions()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list key share versions: %w", err)
+	}
+	for _, v := range versions {
+		if v.SealedPrivateShare == nil || v.SealedPrivateShare.KEKID == currentID {
+			continue
+		}
+		sealed, err := e.rewrap(v.SealedPrivateShare, e.keyShareAAD(v.Version))
+		if err != nil {
+			return rewrapped, fmt.Errorf("failed to re-wrap version %d: %w", v.Version, err)
+		}
+		v.SealedPrivateShare = sealed
+		if err := e.INodePersistence.SaveKeyShareVersion(v); err != nil {
+			return rewrapped, fmt.Errorf("failed to save re-wrapped version %d: %w", v.Version, err)
+		}
+		rewrapped++
+	}
+
+	sessions, err := e.INodePersistence.ListProtocolSessions()
+	if err != nil {
+		return rewrapped, fmt.Errorf("failed to list protocol sessions: %w", err)
+	}
+	for _, s := range sessions {
+		stale := func(sealed *types.SealedSecret) bool { return sealed != nil && sealed.KEKID != currentID }
+		if !stale(s.SealedShares) && !stale(s.SealedGeneratedShares) {
+			continue
+		}
+		if stale(s.SealedShares) {
+			sealed, err := e.rewrap(s.SealedShares, e.sessionAAD(s.SessionTimestamp))
+			if err != nil {
+				return rewrapped, fmt.Errorf("failed to re-wrap session %d: %w", s.SessionTimestamp, err)
+			}
+			s.SealedShares = sealed
+		}
+		if stale(s.SealedGeneratedShares) {
+			sealed, err := e.rewrap(s.SealedGeneratedShares, e.generatedSharesAAD(s.SessionTimestamp))
+			if err != nil {
+				return rewrapped, fmt.Errorf("failed to re-wrap generated shares of session %d: %w", s.SessionTimestamp, err)
+			}
+			s.SealedGeneratedShares = sealed
+		}
+		if err := e.INodePersistence.SaveProtocolSession(s); err != nil {
+			return rewrapped, fmt.Errorf("failed to save re-wrapped session %d: %w", s.SessionTimestamp, err)
+		}
+		rewrapped++
+	}
+
+	seed, err := e.INodePersistence.LoadPQKeySeed()
+	if err != nil {
+		return rewrapped, fmt.Errorf("failed to load PQ key seed: %w", err)
+	}
+	if seed != nil && seed.SealedSeed != nil && seed.SealedSeed.KEKID != currentID {
+		sealed, err := e.rewrap(seed.SealedSeed, e.pqKeySeedAAD())
+		if err != nil {
+			return rewrapped, fmt.Errorf("failed to re-wrap PQ key seed: %w", err)
+		}
+		seed.SealedSeed = sealed
+		if err := e.INodePersistence.SavePQKeySeed(seed); err != nil {
+			return rewrapped, fmt.Errorf("failed to save re-wrapped PQ key seed: %w", err)
+		}
+		rewrapped++
+	}
+
+	evidence, err := e.INodePersistence.ListFraudEvidence()
+	if err != nil {
+		return rewrapped, fmt.Errorf("failed to list fraud evidence: %w", err)
+	}
+	for _, ev := range evidence {
+		if ev.SealedShare == nil || ev.SealedShare.KEKID == currentID {
+			continue
+		}
+		sealed, err := e.rewrap(ev.SealedShare, e.fraudEvidenceAAD(ev.ID))
+		if err != nil {
+			return rewrapped, fmt.Errorf("failed to re-wrap fraud evidence %s: %w", ev.ID, err)
+		}
+		ev.SealedShare = sealed
+		if err := e.INodePersistence.SaveFraudEvidence(ev); err != nil {
+			return rewrapped, fmt.Errorf("failed to save re-wrapped fraud evidence %s: %w", ev.ID, err)
+		}
+		rewrapped++
+	}
+
+	if rewrapped > 0 {
+		e.logger.Sugar().Infow("Re-wrapped sealed records under current KEK", "count", rewrapped, "kek_id", currentID)
+	}
+	return rewrapped, nil
+}
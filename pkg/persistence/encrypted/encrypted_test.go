@@ -0,0 +1,381 @@
+package encrypted
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
+	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+var testScope = RecordScope{
+	OperatorAddress: common.HexToAddress("0x1111111111111111111111111111111111111111"),
+	KeyID:           types.DefaultKeyID,
+}
+
+func newTestKEK(t *testing.T, b byte) *AESKeyEncryptionKey {
+	t.Helper()
+	key := make([]byte, KEKSize)
+	key[0] = b
+	kek, err := NewAESKeyEncryptionKey("keyfile", key)
+	require.NoError(t, err)
+	return kek
+}
+
+func newTestKeyShareVersion(version int64, share uint64) *types.KeyShareVersion {
+	s := fr.NewElement(share)
+	return &types.KeyShareVersion{
+		Version:      version,
+		PrivateShare: &s,
+		Commitments:  []types.G2Point{{CompressedBytes: []byte{1, 2, 3}}},
+		IsActive:     true,
+	}
+}
+
+func TestEncryptedPersistence_KeyShareRoundTrip(t *testing.T) {
+	inner := memory.NewMemoryPersistence()
+	ep, err := NewEncryptedPersistence(inner, newTestKEK(t, 1), testScope, zap.NewNop())
+	require.NoError(t, err)
+
+	version := newTestKeyShareVersion(100, 424242)
+	require.NoError(t, ep.SaveKeyShareVersion(version))
+	require.NotNil(t, version.PrivateShare, "saving must not mutate the caller's version")
+
+	// The backend only ever sees the sealed share.
+	stored, err := inner.LoadKeyShareVersion(100)
+	require.NoError(t, err)
+	require.Nil(t, stored.PrivateShare)
+	require.NotNil(t, stored.SealedPrivateShare)
+
+	loaded, err := ep.LoadKeyShareVersion(100)
+	require.NoError(t, err)
+	require.True(t, loaded.PrivateShare.Equal(version.PrivateShare))
+	require.Nil(t, loaded.SealedPrivateShare)
+
+	listed, err := ep.ListKeyShareVersions()
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.True(t, listed[0].PrivateShare.Equal(version.PrivateShare))
+
+	// Pass-through methods still work through the embedded backend.
+	require.NoError(t, ep.SetActiveVersionTimestamp(100))
+	active, err := ep.GetActiveVersionTimestamp()
+	require.NoError(t, err)
+	require.Equal(t, int64(100), active)
+}
+
+func TestEncryptedPersistence_SealedShareBoundToVersion(t *testing.T) {
+	inner := memory.NewMemoryPersistence()
+	ep, err := NewEncryptedPersistence(inner, newTestKEK(t, 1), testScope, zap.NewNop())
+	require.NoError(t, err)
+
+	require.NoError(t, ep.SaveKeyShareVersion(newTestKeyShareVersion(100, 1)))
+	stored, err := inner.LoadKeyShareVersion(100)
+	require.NoError(t, err)
+
+	// Copy the sealed share onto another version: it must not open there.
+	moved := *stored
+	moved.Version = 200
+	require.NoError(t, inner.SaveKeyShareVersion(&moved))
+
+	_, err = ep.LoadKeyShareVersion(200)
+	require.Error(t, err)
+}
+
+func TestEncryptedPersistence_SealedRecordsBoundToScope(t *testing.T) {
+	kek := newTestKEK(t, 1)
+	source := memory.NewMemoryPersistence()
+	ep, err := NewEncryptedPersistence(source, kek, testScope, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ep.SaveKeyShareVersion(newTestKeyShareVersion(100, 7)))
+	require.NoError(t, ep.SavePQKeySeed(&types.PQKeySeed{Seed: []byte("seed")}))
+	storedVersion, err := source.LoadKeyShareVersion(100)
+	require.NoError(t, err)
+	storedSeed, err := source.LoadPQKeySeed()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		scope RecordScope
+	}{
+		{"other operator", RecordScope{OperatorAddress: common.HexToAddress("0x2222222222222222222222222222222222222222"), KeyID: testScope.KeyID}},
+		{"other key", RecordScope{OperatorAddress: testScope.OperatorAddress, KeyID: "payments"}},
+		{"other namespace", RecordScope{OperatorAddress: testScope.OperatorAddress, KeyID: testScope.KeyID, Namespace: "tenant-b:"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The same KEK, but the records were copied into another scope's store.
+			target := memory.NewMemoryPersistence()
+			require.NoError(t, target.SaveKeyShareVersion(storedVersion))
+			require.NoError(t, target.SavePQKeySeed(storedSeed))
+			other, err := NewEncryptedPersistence(target, kek, tt.scope, zap.NewNop())
+			require.NoError(t, err)
+
+			_, err = other.LoadKeyShareVersion(100)
+			require.Error(t, err)
+			_, err = other.LoadPQKeySeed()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestEncryptedPersistence_ProtocolSessionRoundTrip(t *testing.T) {
+	inner := memory.NewMemoryPersistence()
+	ep, err := NewEncryptedPersistence(inner, newTestKEK(t, 1), testScope, zap.NewNop())
+	require.NoError(t, err)
+
+	session := &persistence.ProtocolSessionState{
+		SessionTimestamp: 500,
+		Type:             "dkg",
+		Phase:            2,
+		Shares:           map[string]string{"0x01": "share-1", "0x02": "share-2"},
+		GeneratedShares:  map[string]string{"0x02": "dealt-2"},
+	}
+	require.NoError(t, ep.SaveProtocolSession(session))
+
+	stored, err := inner.LoadProtocolSession(500)
+	require.NoError(t, err)
+	require.Empty(t, stored.Shares)
+	require.NotNil(t, stored.SealedShares)
+	require.Empty(t, stored.GeneratedShares)
+	require.NotNil(t, stored.SealedGeneratedShares)
+
+	loaded, err := ep.LoadProtocolSession(500)
+	require.NoError(t, err)
+	require.Equal(t, session.Shares, loaded.Shares)
+	require.Nil(t, loaded.SealedShares)
+	require.Equal(t, session.GeneratedShares, loaded.GeneratedShares)
+	require.Nil(t, loaded.SealedGeneratedShares)
+
+	listed, err := ep.ListProtocolSessions()
+	require.NoError(t, err)
+	require.Len(t, listed, 1)
+	require.Equal(t, session.Shares, listed[0].Shares)
+}
+
+func TestEncryptedPersistence_MigratePlaintext(t *testing.T) {
+	inner := memory.NewMemoryPersistence()
+
+	// Records written before encryption at rest was enabled.
+	legacy := newTestKeyShareVersion(100, 7)
+	require.NoError(t, inner.SaveKeyShareVersion(legacy))
+	require.NoError(t, inner.SaveProtocolSession(&persistence.ProtocolSessionState{
+		SessionTimestamp: 500,
+		Type:             "reshare",
+		Shares:           map[string]string{"0x01": "share-1"},
+	}))
+
+	ep, err := NewEncryptedPersistence(inner, newTestKEK(t, 1), testScope, zap.NewNop())
+	require.NoError(t, err)
+
+	// Plaintext records are refused until the explicit migration has sealed them.
+	_, err = ep.LoadKeyShareVersion(100)
+	require.ErrorIs(t, err, ErrPlaintextRecord)
+	_, err = ep.LoadProtocolSession(500)
+	require.ErrorIs(t, err, ErrPlaintextRecord)
+
+	migrated, err := ep.MigratePlaintext()
+	require.NoError(t, err)
+	require.Equal(t, 2, migrated)
+
+	stored, err := inner.LoadKeyShareVersion(100)
+	require.NoError(t, err)
+	require.Nil(t, stored.PrivateShare)
+	require.NotNil(t, stored.SealedPrivateShare)
+
+	storedSession, err := inner.LoadProtocolSession(500)
+	require.NoError(t, err)
+	require.Empty(t, storedSession.Shares)
+	require.NotNil(t, storedSession.SealedShares)
+
+	loaded, err := ep.LoadKeyShareVersion(100)
+	require.NoError(t, err)
+	require.True(t, loaded.PrivateShare.Equal(legacy.PrivateShare))
+
+	again, err := ep.MigratePlaintext()
+	require.NoError(t, err)
+	require.Zero(t, again, "migration must be idempotent")
+}
+
+func TestEncryptedPersistence_RejectsPlantedPlaintext(t *testing.T) {
+	inner := memory.NewMemoryPersistence()
+	ep, err := NewEncryptedPersistence(inner, newTestKEK(t, 1), testScope, zap.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, ep.SaveKeyShareVersion(newTestKeyShareVersion(100, 7)))
+
+	// Someone with write access to the store swaps in a plaintext share of their choosing.
+	require.NoError(t, inner.SaveKeyShareVersion(newTestKeyShareVersion(100, 666)))
+
+	_, err = ep.LoadKeyShareVersion(100)
+	require.ErrorIs(t, err, ErrPlaintextRecord)
+	_, err = ep.ListKeyShareVersions()
+	require.ErrorIs(t, err, ErrPlaintextRecord)
+
+	share := types.SerializedFrElement{Data: "01"}
+	require.NoError(t, inner.SaveFraudEvidence(&types.FraudEvidence{ID: "planted", Share: &share}))
+	_, err = ep.ListFraudEvidence()
+	require.ErrorIs(t, err, ErrPlaintextRecord)
+}
+
+func TestEncryptedPersistence_Rewrap(t *testing.T) {
+	inner := memory.NewMemoryPersistence()
+	oldKEK := newTestKEK(t, 1)
+	newKEK := newTestKEK(t, 2)
+
+	epOld, err := NewEncryptedPersistence(inner, oldKEK, testScope, zap.NewNop())
+	require.NoError(t, err)
+	version := newTestKeyShareVersion(100, 99)
+	require.NoError(t, epOld.SaveKeyShareVersion(version))
+	require.NoError(t, epOld.SaveProtocolSession(&persistence.ProtocolSessionState{
+		SessionTimestamp: 500,
+		Shares:           map[string]string{"0x01": "share-1"},
+	}))
+
+	// Without the old KEK the record cannot be read.
+	epNewOnly, err := NewEncryptedPersistence(inner, newKEK, testScope, zap.NewNop())
+	require.NoError(t, err)
+	_, err = epNewOnly.LoadKeyShareVersion(100)
+	require.ErrorContains(t, err, "unknown key-encryption key")
+
+	epRotated, err := NewEncryptedPersistence(inner, newKEK, testScope, zap.NewNop(), oldKEK)
+	require.NoError(t, err)
+
+	rewrapped, err := epRotated.Rewrap()
+	require.NoError(t, err)
+	require.Equal(t, 2, rewrapped)
+
+	stored, err := inner.LoadKeyShareVersion(100)
+	require.NoError(t, err)
+	require.Equal(t, newKEK.ID(), stored.SealedPrivateShare.KEKID)
+
+	// After re-wrapping, the new KEK alone is enough.
+	loaded, err := epNewOnly.LoadKeyShareVersion(100)
+	require.NoError(t, err)
+	require.True(t, loaded.PrivateShare.Equal(version.PrivateShare))
+
+	session, err := epNewOnly.LoadProtocolSession(500)
+	require.NoError(t, err)
+	require.Equal(t, "share-1", session.Shares["0x01"])
+
+	again, err := epRotated.Rewrap()
+	require.NoError(t, err)
+	require.Zero(t, again)
+}
+
+func TestEncryptedPersistence_PQKeySeed(t *testing.T) {
+	inner := memory.NewMemoryPersistence()
+	oldKEK := newTestKEK(t, 1)
+	newKEK := newTestKEK(t, 2)
+	plaintext := []byte("0123456789abcdef0123456789abcdef")
+
+	// A seed written before encryption at rest was enabled.
+	require.NoError(t, inner.SavePQKeySeed(&types.PQKeySeed{Seed: plaintext, CreatedAt: 1700000000}))
+
+	ep, err := NewEncryptedPersistence(inner, oldKEK, testScope, zap.NewNop())
+	require.NoError(t, err)
+	_, err = ep.LoadPQKeySeed()
+	require.ErrorIs(t, err, ErrPlaintextRecord)
+
+	migrated, err := ep.MigratePlaintext()
+	require.NoError(t, err)
+	require.Equal(t, 1, migrated)
+
+	// The backend only ever sees the sealed seed.
+	stored, err := inner.LoadPQKeySeed()
+	require.NoError(t, err)
+	require.Nil(t, stored.Seed)
+	require.Equal(t, oldKEK.ID(), stored.SealedSeed.KEKID)
+
+	loaded, err := ep.LoadPQKeySeed()
+	require.NoError(t, err)
+	require.Equal(t, plaintext, loaded.Seed)
+	require.Equal(t, int64(1700000000), loaded.CreatedAt)
+	require.Nil(t, loaded.SealedSeed)
+
+	epRotated, err := NewEncryptedPersistence(inner, newKEK, testScope, zap.NewNop(), oldKEK)
+	require.NoError(t, err)
+	rewrapped, err := epRotated.Rewrap()
+	require.NoError(t, err)
+	require.Equal(t, 1, rewrapped)
+
+	epNewOnly, err := NewEncryptedPersistence(inner, newKEK, testScope, zap.NewNop())
+	require.NoError(t, err)
+	loaded, err = epNewOnly.LoadPQKeySeed()
+	require.NoError(t, err)
+	require.Equal(t, plaintext, loaded.Seed)
+
+	// A seed saved through the wrapper is sealed straight away.
+	require.NoError(t, epNewOnly.SavePQKeySeed(&types.PQKeySeed{Seed: plaintext, CreatedAt: 1800000000}))
+	stored, err = inner.LoadPQKeySeed()
+	require.NoError(t, err)
+	require.Nil(t, stored.Seed)
+	require.NotNil(t, stored.SealedSeed)
+}
+
+func TestEncryptedPersistence_FraudEvidence(t *testing.T) {
+	inner := memory.NewMemoryPersistence()
+	oldKEK := newTestKEK(t, 1)
+	newKEK := newTestKEK(t, 2)
+	share := fr.NewElement(31337)
+	newEvidence := func(id string) *types.FraudEvidence {
+		return &types.FraudEvidence{ID: id, Kind: types.FraudKindInvalidShare, SessionTimestamp: 100, Share: types.SerializeFr(&share)}
+	}
+
+	// Evidence written before encryption at rest was enabled.
+	require.NoError(t, inner.SaveFraudEvidence(newEvidence("legacy")))
+
+	ep, err := NewEncryptedPersistence(inner, oldKEK, testScope, zap.NewNop())
+	require.NoError(t, err)
+	evidence := newEvidence("fresh")
+	require.NoError(t, ep.SaveFraudEvidence(evidence))
+	require.NotNil(t, evidence.Share, "saving must not mutate the caller's evidence")
+
+	migrated, err := ep.MigratePlaintext()
+	require.NoError(t, err)
+	require.Equal(t, 1, migrated)
+
+	// The backend only ever sees sealed shares.
+	stored, err := inner.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, stored, 2)
+	for _, ev := range stored {
+		require.Nil(t, ev.Share, ev.ID)
+		require.NotNil(t, ev.SealedShare, ev.ID)
+	}
+
+	epRotated, err := NewEncryptedPersistence(inner, newKEK, testScope, zap.NewNop(), oldKEK)
+	require.NoError(t, err)
+	rewrapped, err := epRotated.Rewrap()
+	require.NoError(t, err)
+	require.Equal(t, 2, rewrapped)
+
+	epNewOnly, err := NewEncryptedPersistence(inner, newKEK, testScope, zap.NewNop())
+	require.NoError(t, err)
+	loaded, err := epNewOnly.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, loaded, 2)
+	for _, ev := range loaded {
+		require.Nil(t, ev.SealedShare, ev.ID)
+		require.True(t, types.DeserializeFr(ev.Share).Equal(&share), ev.ID)
+	}
+
+	// A sealed share moved onto another bundle does not open there.
+	moved := *stored[0]
+	moved.ID = "moved"
+	require.NoError(t, inner.SaveFraudEvidence(&moved))
+	_, err = epRotated.ListFraudEvidence()
+	require.ErrorContains(t, err, "fraud evidence moved")
+}
+
+func TestNewEncryptedPersistence_Validation(t *testing.T) {
+	_, err := NewEncryptedPersistence(nil, newTestKEK(t, 1), testScope, zap.NewNop())
+	require.Error(t, err)
+	_, err = NewEncryptedPersistence(memory.NewMemoryPersistence(), nil, testScope, zap.NewNop())
+	require.Error(t, err)
+	_, err = NewEncryptedPersistence(memory.NewMemoryPersistence(), newTestKEK(t, 1), RecordScope{KeyID: "default"}, zap.NewNop())
+	require.Error(t, err)
+}
@@ -0,0 +1,140 @@
+package encrypted
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+// KEKSize is the size in bytes of a local AES-256 key-encryption key.
+const KEKSize = 32
+
+// IKeyEncryptionKey wraps and unwraps the per-record data-encryption keys (DEKs)
+// that protect persisted secrets. Implementations must be safe for concurrent use.
+type IKeyEncryptionKey interface {
+	// ID identifies this KEK. It is stored next to every DEK it wraps so the
+	// matching KEK can be selected on read and stale records found on rotation.
+	ID() string
+
+	// WrapKey encrypts dek, binding it to aad (the record the DEK protects).
+	WrapKey(ctx context.Context, dek, aad []byte) ([]byte, error)
+
+	// UnwrapKey reverses WrapKey. It must fail if aad does not match.
+	UnwrapKey(ctx context.Context, wrapped, aad []byte) ([]byte, error)
+}
+
+// AESKeyEncryptionKey is a KEK held in process memory, used for keyfile and
+// passphrase-derived keys. DEKs are wrapped with AES-256-GCM.
+type AESKeyEncryptionKey struct {
+	id   string
+	aead cipher.AEAD
+}
+
+// NewAESKeyEncryptionKey creates a KEK from 32 bytes of key material. idPrefix
+// names the key's origin ("keyfile", "passphrase") in its ID; the rest of the ID
+// is a fingerprint of the key, so two different keys never share an ID.
+func NewAESKeyEncryptionKey(idPrefix string, key []byte) (*AESKeyEncryptionKey, error) {
+	if len(key) != KEKSize {
+		return nil, fmt.Errorf("key-encryption key must be %d bytes, got %d", KEKSize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AES cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	fingerprint := sha256.Sum256(append([]byte("eigenx-kms/kek-fingerprint/v1"), key...))
+	return &AESKeyEncryptionKey{
+		id:   fmt.Sprintf("%s:%x", idPrefix, fingerprint[:8]),
+		aead: aead,
+	}, nil
+}
+
+// NewKeyfileKEK loads a KEK from a file holding either 32 raw bytes or 64 hex
+// characters (surrounding whitespace and an optional 0x prefix are ignored).
+func NewKeyfileKEK(path string) (*AESKeyEncryptionKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read KEK keyfile: %w", err)
+	}
+	key, err := parseKeyfile(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid KEK keyfile %s: %w", path, err)
+	}
+	return NewAESKeyEncryptionKey("keyfile", key)
+}
+
+func parseKeyfile(data []byte) ([]byte, error) {
+	if len(data) == KEKSize {
+		return data, nil
+	}
+	text := strings.TrimPrefix(strings.TrimSpace(string(data)), "0x")
+	key, err := hex.DecodeString(text)
+	if err != nil {
+		return nil, fmt.Errorf("expected %d raw bytes or %d hex characters", KEKSize, 2*KEKSize)
+	}
+	if len(key) != KEKSize {
+		return nil, fmt.Errorf("expected %d bytes, got %d", KEKSize, len(key))
+	}
+	return key, nil
+}
+
+// Argon2id parameters for passphrase-derived KEKs (RFC 9106 second recommended
+// option: 64 MiB, 3 passes).
+const (
+	passphraseArgonTime    = 3
+	passphraseArgonMemory  = 64 * 1024
+	passphraseArgonThreads = 4
+)
+
+// NewPassphraseKEK derives a KEK from a passphrase with Argon2id. salt must be
+// stable for a given database (the operator address is a good choice), otherwise
+// a restart derives a different key and cannot read existing records.
+func NewPassphraseKEK(passphrase string, salt []byte) (*AESKeyEncryptionKey, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("KEK passphrase cannot be empty")
+	}
+	if len(salt) == 0 {
+		return nil, fmt.Errorf("KEK passphrase salt cannot be empty")
+	}
+	fullSalt := append([]byte("eigenx-kms/kek/passphrase/v1:"), salt...)
+	key := argon2.IDKey([]byte(passphrase), fullSalt, passphraseArgonTime, passphraseArgonMemory, passphraseArgonThreads, KEKSize)
+	return NewAESKeyEncryptionKey("passphrase", key)
+}
+
+// ID returns the KEK identifier.
+func (k *AESKeyEncryptionKey) ID() string {
+	return k.id
+}
+
+// WrapKey encrypts dek under the KEK; the output is nonce || ciphertext.
+func (k *AESKeyEncryptionKey) WrapKey(_ context.Context, dek, aad []byte) ([]byte, error) {
+	nonce := make([]byte, k.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	return k.aead.Seal(nonce, nonce, dek, aad), nil
+}
+
+// UnwrapKey decrypts a DEK produced by WrapKey.
+func (k *AESKeyEncryptionKey) UnwrapKey(_ context.Context, wrapped, aad []byte) ([]byte, error) {
+	nonceSize := k.aead.NonceSize()
+	if len(wrapped) < nonceSize {
+		return nil, fmt.Errorf("wrapped key too short")
+	}
+	dek, err := k.aead.Open(nil, wrapped[:nonceSize], wrapped[nonceSize:], aad)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data-encryption key: %w", err)
+	}
+	return dek, nil
+}
@@ -0,0 +1,89 @@
+package encrypted
+
+import (
+	"context"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAESKeyEncryptionKey_WrapUnwrap(t *testing.T) {
+	key := make([]byte, KEKSize)
+	key[0] = 1
+	kek, err := NewAESKeyEncryptionKey("keyfile", key)
+	require.NoError(t, err)
+
+	dek := []byte("0123456789abcdef0123456789abcdef")
+	wrapped, err := kek.WrapKey(context.Background(), dek, []byte("record-1"))
+	require.NoError(t, err)
+
+	got, err := kek.UnwrapKey(context.Background(), wrapped, []byte("record-1"))
+	require.NoError(t, err)
+	require.Equal(t, dek, got)
+
+	_, err = kek.UnwrapKey(context.Background(), wrapped, []byte("record-2"))
+	require.Error(t, err, "a wrapped DEK must not unwrap for a different record")
+}
+
+func TestNewAESKeyEncryptionKey_IDsDifferPerKey(t *testing.T) {
+	a, err := NewAESKeyEncryptionKey("keyfile", make([]byte, KEKSize))
+	require.NoError(t, err)
+	other := make([]byte, KEKSize)
+	other[31] = 1
+	b, err := NewAESKeyEncryptionKey("keyfile", other)
+	require.NoError(t, err)
+
+	require.NotEqual(t, a.ID(), b.ID())
+	require.Contains(t, a.ID(), "keyfile:")
+
+	_, err = NewAESKeyEncryptionKey("keyfile", make([]byte, 16))
+	require.Error(t, err)
+}
+
+func TestNewKeyfileKEK(t *testing.T) {
+	dir := t.TempDir()
+	key := make([]byte, KEKSize)
+	for i := range key {
+		key[i] = byte(i)
+	}
+
+	rawPath := filepath.Join(dir, "raw.key")
+	require.NoError(t, os.WriteFile(rawPath, key, 0600))
+	hexPath := filepath.Join(dir, "hex.key")
+	require.NoError(t, os.WriteFile(hexPath, []byte("0x"+hex.EncodeToString(key)+"\n"), 0600))
+
+	raw, err := NewKeyfileKEK(rawPath)
+	require.NoError(t, err)
+	fromHex, err := NewKeyfileKEK(hexPath)
+	require.NoError(t, err)
+	require.Equal(t, raw.ID(), fromHex.ID(), "raw and hex encodings of the same key must yield the same KEK")
+
+	badPath := filepath.Join(dir, "bad.key")
+	require.NoError(t, os.WriteFile(badPath, []byte("not a key"), 0600))
+	_, err = NewKeyfileKEK(badPath)
+	require.Error(t, err)
+
+	_, err = NewKeyfileKEK(filepath.Join(dir, "missing.key"))
+	require.Error(t, err)
+}
+
+func TestNewPassphraseKEK(t *testing.T) {
+	salt := []byte("0x0000000000000000000000000000000000000001")
+	a, err := NewPassphraseKEK("correct horse battery staple", salt)
+	require.NoError(t, err)
+	b, err := NewPassphraseKEK("correct horse battery staple", salt)
+	require.NoError(t, err)
+	require.Equal(t, a.ID(), b.ID(), "derivation must be deterministic for a fixed salt")
+
+	c, err := NewPassphraseKEK("correct horse battery staple", []byte("other-salt"))
+	require.NoError(t, err)
+	require.NotEqual(t, a.ID(), c.ID())
+
+	_, err = NewPassphraseKEK("", salt)
+	require.Error(t, err)
+	_, err = NewPassphraseKEK("passphrase", nil)
+	require.Error(t, err)
+}
@@ -9,6 +9,7 @@ import "github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 // - Key share version management (save, load, list, delete)
 // - Active version tracking (which key version is currently in use)
 // - Node operational state (lastProcessedBoundary, etc.)
+// - The post-quantum key seed
 // - Protocol session management (in-progress DKG/reshare state)
 // - Lifecycle management (close, health check)
 type INodePersistence interface {
@@ -69,6 +70,16 @@ type INodePersistence interface {
 	// Returns nil state if none exists (first run), error only on storage failure.
 	LoadNodeState() (*NodeState, error)
 
+	// Post-Quantum Key Seed
+
+	// SavePQKeySeed persists the seed of this node's per-app ML-KEM keys.
+	// Overwrites any existing seed; the node only writes one when none is stored.
+	SavePQKeySeed(seed *types.PQKeySeed) error
+
+	// LoadPQKeySeed retrieves the PQ key seed.
+	// Returns nil if none exists (first run), error only on storage failure.
+	LoadPQKeySeed() (*types.PQKeySeed, error)
+
 	// Protocol Session Management
 
 	// SaveProtocolSession persists ephemeral protocol state for crash recovery.
@@ -117,6 +128,18 @@ type INodePersistence interface {
 	// Returns error only on storage failure.
 	DeleteBlockRecord(chainId uint64, blockNumber uint64) error
 
+	// Fraud Evidence
+
+	// SaveFraudEvidence persists a fraud evidence bundle keyed by evidence.ID.
+	// Overwrites any existing bundle with the same ID (used to record submission).
+	// Returns error only on storage failure.
+	SaveFraudEvidence(evidence *types.FraudEvidence) error
+
+	// ListFraudEvidence returns all persisted evidence bundles sorted by
+	// session timestamp, then ID. Returns empty slice if none exist.
+	// Returns error only on storage failure.
+	ListFraudEvidence() ([]*types.FraudEvidence, error)
+
 	// Lifecycle Management
 
 	// Close cleanly shuts down the persistence layer.
@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"encoding/json"
 	"fmt"
 	"sort"
 	"sync"
@@ -29,6 +30,9 @@ type MemoryPersistence struct {
 	// Node state
 	nodeState *persistence.NodeState
 
+	// Post-quantum key seed (nil until saved)
+	pqKeySeed *types.PQKeySeed
+
 	// Protocol sessions: sessionTimestamp -> ProtocolSessionState
 	sessions map[int64]*persistence.ProtocolSessionState
 
@@ -42,6 +46,9 @@ type MemoryPersistence struct {
 	// from, activated, or served (cross-node-inconsistent shares).
 	poisoned map[int64]struct{}
 
+	// Fraud evidence bundles: evidence ID -> FraudEvidence
+	fraudEvidence map[string]*types.FraudEvidence
+
 	// Closed flag
 	closed bool
 }
@@ -64,6 +71,7 @@ func NewMemoryPersistence() *MemoryPersistence {
 		blockRecords:        make(map[blockRecordKey]*persistence.BlockRecord),
 		lastProcessedBlocks: make(map[uint64]uint64),
 		poisoned:            make(map[int64]struct{}),
+		fraudEvidence:       make(map[string]*types.FraudEvidence),
 		nodeState:           &persistence.NodeState{},
 	}
 }
@@ -223,6 +231,8 @@ func (m *MemoryPersistence) SaveNodeState(state *persistence.NodeState) error {
 		TrackedSourceVersion:       state.TrackedSourceVersion,
 		ConsecutiveMPKAborts:       state.ConsecutiveMPKAborts,
 		LastKnownGoodSourceVersion: state.LastKnownGoodSourceVersion,
+		RetiringGenerations:        copyRetiringGenerations(state.RetiringGenerations),
+		AutoResharePaused:          state.AutoResharePaused,
 	}
 
 	return nil
@@ -256,9 +266,54 @@ func (m *MemoryPersistence) LoadNodeState() (*persistence.NodeState, error) {
 		TrackedSourceVersion:       m.nodeState.TrackedSourceVersion,
 		ConsecutiveMPKAborts:       m.nodeState.ConsecutiveMPKAborts,
 		LastKnownGoodSourceVersion: m.nodeState.LastKnownGoodSourceVersion,
+		RetiringGenerations:        copyRetiringGenerations(m.nodeState.RetiringGenerations),
+		AutoResharePaused:          m.nodeState.AutoResharePaused,
 	}, nil
 }
 
+func copyRetiringGenerations(retiring map[uint32]int64) map[uint32]int64 {
+	if retiring == nil {
+		return nil
+	}
+	out := make(map[uint32]int64, len(retiring))
+	for generation, retireAt := range retiring {
+		out[generation] = retireAt
+	}
+	return out
+}
+
+// SavePQKeySeed persists the post-quantum key seed.
+func (m *MemoryPersistence) SavePQKeySeed(seed *types.PQKeySeed) error {
+	if seed == nil {
+		return fmt.Errorf("cannot save nil PQKeySeed")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return fmt.Errorf("persistence layer is closed")
+	}
+
+	m.pqKeySeed = deepCopyPQKeySeed(seed)
+	return nil
+}
+
+// LoadPQKeySeed retrieves the post-quantum key seed.
+func (m *MemoryPersistence) LoadPQKeySeed() (*types.PQKeySeed, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		return nil, fmt.Errorf("persistence layer is closed")
+	}
+
+	if m.pqKeySeed == nil {
+		return nil, nil
+	}
+	return deepCopyPQKeySeed(m.pqKeySeed), nil
+}
+
 // SaveProtocolSession persists protocol session state.
 func (m *MemoryPersistence) SaveProtocolSession(session *persistence.ProtocolSessionState) error {
 	if session == nil {
@@ -404,6 +459,70 @@ func (m *MemoryPersistence) DeleteBlockRecord(chainId uint64, blockNumber uint64
 	return nil
 }
 
+// SaveFraudEvidence persists a fraud evidence bundle, overwriting any with the same ID.
+func (m *MemoryPersistence) SaveFraudEvidence(evidence *types.FraudEvidence) error {
+	if evidence == nil {
+		return fmt.Errorf("cannot save nil FraudEvidence")
+	}
+	if evidence.ID == "" {
+		return fmt.Errorf("fraud evidence ID is required")
+	}
+
+	// Deep copy to prevent external mutation
+	stored, err := deepCopyFraudEvidence(evidence)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return fmt.Errorf("persistence layer is closed")
+	}
+
+	if m.fraudEvidence == nil {
+		m.fraudEvidence = make(map[string]*types.FraudEvidence)
+	}
+	m.fraudEvidence[evidence.ID] = stored
+	return nil
+}
+
+// ListFraudEvidence returns all fraud evidence bundles sorted by session timestamp, then ID.
+func (m *MemoryPersistence) ListFraudEvidence() ([]*types.FraudEvidence, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.closed {
+		return nil, fmt.Errorf("persistence layer is closed")
+	}
+
+	out := make([]*types.FraudEvidence, 0, len(m.fraudEvidence))
+	for _, ev := range m.fraudEvidence {
+		evCopy, err := deepCopyFraudEvidence(ev)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, evCopy)
+	}
+	persistence.SortFraudEvidence(out)
+	return out, nil
+}
+
+// deepCopyFraudEvidence copies an evidence bundle through its JSON form, which is also
+// how the durable backends store it.
+func deepCopyFraudEvidence(ev *types.FraudEvidence) (*types.FraudEvidence, error) {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal FraudEvidence: %w", err)
+	}
+	var out types.FraudEvidence
+	if err := json.Unmarshal(data, &out); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal FraudEvidence: %w", err)
+	}
+	return &out, nil
+}
+
 // Close shuts down the persistence layer.
 func (m *MemoryPersistence) Close() error {
 	m.mu.Lock()
@@ -447,6 +566,28 @@ func deepCopyKeyShareVersion(v *types.KeyShareVersion) *types.KeyShareVersion {
 		commitments[i] = types.G2Point{CompressedBytes: compressedCopy}
 	}
 
+	// Copy group commitments (nil stays nil for versions that predate them)
+	var groupCommitments []types.G2Point
+	if v.GroupCommitments != nil {
+		groupCommitments = make([]types.G2Point, len(v.GroupCommitments))
+		for i, c := range v.GroupCommitments {
+			groupCommitments[i] = types.G2Point{CompressedBytes: append([]byte(nil), c.CompressedBytes...)}
+		}
+	}
+
+	// Copy dealer commitments, likewise
+	var dealerCommitments map[common.Address][]types.G2Point
+	if v.DealerCommitments != nil {
+		dealerCommitments = make(map[common.Address][]types.G2Point, len(v.DealerCommitments))
+		for dealer, cs := range v.DealerCommitments {
+			copied := make([]types.G2Point, len(cs))
+			for i, c := range cs {
+				copied[i] = types.G2Point{CompressedBytes: append([]byte(nil), c.CompressedBytes...)}
+			}
+			dealerCommitments[dealer] = copied
+		}
+	}
+
 	// Copy master public key
 	var masterPublicKeyCopy *types.G2Point
 	if v.MasterPublicKey != nil {
@@ -460,12 +601,38 @@ func deepCopyKeyShareVersion(v *types.KeyShareVersion) *types.KeyShareVersion {
 	copy(participantIDs, v.ParticipantIDs)
 
 	return &types.KeyShareVersion{
-		Version:         v.Version,
-		PrivateShare:    privateShareCopy,
-		Commitments:     commitments,
-		MasterPublicKey: masterPublicKeyCopy,
-		IsActive:        v.IsActive,
-		ParticipantIDs:  participantIDs,
+		Version:            v.Version,
+		PrivateShare:       privateShareCopy,
+		Commitments:        commitments,
+		GroupCommitments:   groupCommitments,
+		DealerCommitments:  dealerCommitments,
+		SourceVersion:      v.SourceVersion,
+		MasterPublicKey:    masterPublicKeyCopy,
+		IsActive:           v.IsActive,
+		ParticipantIDs:     participantIDs,
+		Generation:         v.Generation,
+		SealedPrivateShare: deepCopySealedSecret(v.SealedPrivateShare),
+	}
+}
+
+// deepCopyPQKeySeed creates a deep copy of a PQKeySeed
+func deepCopyPQKeySeed(s *types.PQKeySeed) *types.PQKeySeed {
+	return &types.PQKeySeed{
+		Seed:       append([]byte(nil), s.Seed...),
+		CreatedAt:  s.CreatedAt,
+		SealedSeed: deepCopySealedSecret(s.SealedSeed),
+	}
+}
+
+func deepCopySealedSecret(s *types.SealedSecret) *types.SealedSecret {
+	if s == nil {
+		return nil
+	}
+	return &types.SealedSecret{
+		KEKID:      s.KEKID,
+		WrappedDEK: append([]byte(nil), s.WrappedDEK...),
+		Nonce:      append([]byte(nil), s.Nonce...),
+		Ciphertext: append([]byte(nil), s.Ciphertext...),
 	}
 }
 
@@ -527,14 +694,40 @@ func deepCopyProtocolSessionState(s *persistence.ProtocolSessionState) *persiste
 		acknowledgements[dealerAddr] = ackMapCopy
 	}
 
+	var generatedShares map[string]string
+	if s.GeneratedShares != nil {
+		generatedShares = make(map[string]string, len(s.GeneratedShares))
+		for k, v := range s.GeneratedShares {
+			generatedShares[k] = v
+		}
+	}
+	var sourceVersions map[string]int64
+	if s.SourceVersions != nil {
+		sourceVersions = make(map[string]int64, len(s.SourceVersions))
+		for k, v := range s.SourceVersions {
+			sourceVersions[k] = v
+		}
+	}
+
 	return &persistence.ProtocolSessionState{
-		SessionTimestamp:  s.SessionTimestamp,
-		Type:              s.Type,
-		Phase:             s.Phase,
-		StartTime:         s.StartTime,
-		OperatorAddresses: operatorAddresses,
-		Shares:            shares,
-		Commitments:       commitments,
-		Acknowledgements:  acknowledgements,
+		SessionTimestamp:      s.SessionTimestamp,
+		Type:                  s.Type,
+		Phase:                 s.Phase,
+		Generation:            s.Generation,
+		TriggerBlockNumber:    s.TriggerBlockNumber,
+		StartTime:             s.StartTime,
+		OperatorAddresses:     operatorAddresses,
+		Shares:                shares,
+		SealedShares:          deepCopySealedSecret(s.SealedShares),
+		Commitments:           commitments,
+		Acknowledgements:      acknowledgements,
+		GeneratedShares:       generatedShares,
+		SealedGeneratedShares: deepCopySealedSecret(s.SealedGeneratedShares),
+		SourceVersions:        sourceVersions,
+		QualifiedDealers:      append([]string(nil), s.QualifiedDealers...),
+		VerifiedOperators:     append([]string(nil), s.VerifiedOperators...),
+		ContractSubmitted:     s.ContractSubmitted,
+		AckMerklePlayers:      append([]string(nil), s.AckMerklePlayers...),
+		AckMerkleRoot:         s.AckMerkleRoot,
 	}
 }
@@ -217,6 +217,31 @@ func TestMemoryPersistence_NodeState_Nil(t *testing.T) {
 	assert.Contains(t, err.Error(), "nil NodeState")
 }
 
+func TestMemoryPersistence_PQKeySeed(t *testing.T) {
+	mp := NewMemoryPersistence()
+	defer func() { _ = mp.Close() }()
+
+	// Initially no seed (first run)
+	seed, err := mp.LoadPQKeySeed()
+	require.NoError(t, err)
+	assert.Nil(t, seed)
+
+	saved := &types.PQKeySeed{Seed: []byte("0123456789abcdef0123456789abcdef"), CreatedAt: 1700000000}
+	require.NoError(t, mp.SavePQKeySeed(saved))
+
+	// The stored seed is a copy, not the caller's buffer
+	saved.Seed[0] = 'X'
+	loaded, err := mp.LoadPQKeySeed()
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, []byte("0123456789abcdef0123456789abcdef"), loaded.Seed)
+	assert.Equal(t, int64(1700000000), loaded.CreatedAt)
+
+	err = mp.SavePQKeySeed(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "nil PQKeySeed")
+}
+
 func TestMemoryPersistence_ProtocolSessions(t *testing.T) {
 	mp := NewMemoryPersistence()
 	defer func() { _ = mp.Close() }()
@@ -451,6 +476,10 @@ func TestMemoryPersistence_DeepCopy_Mutation(t *testing.T) {
 		Commitments:    []types.G2Point{{CompressedBytes: []byte{1, 2, 3}}},
 		IsActive:       true,
 		ParticipantIDs: []common.Address{common.HexToAddress("0x01"), common.HexToAddress("0x02"), common.HexToAddress("0x03")},
+		DealerCommitments: map[common.Address][]types.G2Point{
+			common.HexToAddress("0x01"): {{CompressedBytes: []byte{4, 5, 6}}},
+		},
+		SourceVersion: 100,
 	}
 	err := mp.SaveKeyShareVersion(version)
 	require.NoError(t, err)
@@ -461,6 +490,7 @@ func TestMemoryPersistence_DeepCopy_Mutation(t *testing.T) {
 	loaded.IsActive = false
 	loaded.ParticipantIDs[0] = common.HexToAddress("0x999")
 	loaded.Commitments[0].CompressedBytes[0] = 255
+	loaded.DealerCommitments[common.HexToAddress("0x01")][0].CompressedBytes[0] = 255
 
 	// Load again and verify original is unchanged
 	loaded2, err := mp.LoadKeyShareVersion(123)
@@ -468,6 +498,8 @@ func TestMemoryPersistence_DeepCopy_Mutation(t *testing.T) {
 	assert.True(t, loaded2.IsActive)
 	assert.Equal(t, common.HexToAddress("0x01"), loaded2.ParticipantIDs[0])
 	assert.Equal(t, byte(1), loaded2.Commitments[0].CompressedBytes[0])
+	assert.Equal(t, byte(4), loaded2.DealerCommitments[common.HexToAddress("0x01")][0].CompressedBytes[0])
+	assert.Equal(t, int64(100), loaded2.SourceVersion)
 }
 
 func TestMemoryPersistence_BlockRecordRoundTrip(t *testing.T) {
@@ -528,3 +560,37 @@ func TestMemoryPersistence_SaveBlockRecord_Nil(t *testing.T) {
 	err := mp.SaveBlockRecord(nil)
 	assert.Error(t, err)
 }
+
+func TestMemoryPersistence_FraudEvidence(t *testing.T) {
+	mp := NewMemoryPersistence()
+	defer func() { _ = mp.Close() }()
+
+	empty, err := mp.ListFraudEvidence()
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+
+	later := &types.FraudEvidence{ID: "b", Kind: types.FraudKindEquivocation, SessionTimestamp: 200, DealerAddress: common.HexToAddress("0x02")}
+	earlier := &types.FraudEvidence{ID: "a", Kind: types.FraudKindInvalidShare, SessionTimestamp: 100, DealerAddress: common.HexToAddress("0x01")}
+	require.NoError(t, mp.SaveFraudEvidence(later))
+	require.NoError(t, mp.SaveFraudEvidence(earlier))
+
+	// Saving the same ID again overwrites.
+	later.SubmissionTxHash = "0xabc"
+	require.NoError(t, mp.SaveFraudEvidence(later))
+
+	got, err := mp.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "a", got[0].ID)
+	assert.Equal(t, "b", got[1].ID)
+	assert.Equal(t, "0xabc", got[1].SubmissionTxHash)
+
+	// Mutating a listed bundle does not reach the store.
+	got[0].Kind = "tampered"
+	again, err := mp.ListFraudEvidence()
+	require.NoError(t, err)
+	assert.Equal(t, types.FraudKindInvalidShare, again[0].Kind)
+
+	assert.Error(t, mp.SaveFraudEvidence(nil))
+	assert.Error(t, mp.SaveFraudEvidence(&types.FraudEvidence{}))
+}
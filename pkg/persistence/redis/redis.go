@@ -21,6 +21,7 @@ const (
 	keyPrefixKeyShare      = "kms:keyshare:"
 	keyPrefixActiveVersion = "kms:active:version"
 	keyPrefixNodeState     = "kms:nodestate:main"
+	keyPrefixPQKeySeed     = "kms:pqseed:main"
 	keyPrefixSession       = "kms:session:"
 	keyPrefixBlockRecord   = "kms:blockRecord:"
 	keyPrefixLastBlock     = "kms:lastBlock:"
@@ -33,6 +34,9 @@ const (
 
 	// Redis SET of poisoned key-share versions.
 	keyPrefixPoisoned = "kms:poisoned"
+
+	// Redis HASH of fraud evidence bundles: evidence ID -> JSON.
+	keyFraudEvidence = "kms:fraud"
 )
 
 // RedisPersistence is a production-ready persistence implementation using Redis.
@@ -447,6 +451,64 @@ func (r *RedisPersistence) LoadNodeState() (*persistence.NodeState, error) {
 	return state, nil
 }
 
+// SavePQKeySeed persists the post-quantum key seed
+func (r *RedisPersistence) SavePQKeySeed(seed *types.PQKeySeed) error {
+	if seed == nil {
+		return fmt.Errorf("cannot save nil PQKeySeed")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return fmt.Errorf("persistence layer is closed")
+	}
+
+	ctx := context.Background()
+	key := r.prefixKey(keyPrefixPQKeySeed)
+
+	// Serialize to JSON
+	data, err := json.Marshal(seed)
+	if err != nil {
+		return fmt.Errorf("failed to marshal PQKeySeed: %w", err)
+	}
+
+	return r.client.Set(ctx, key, data, 0).Err()
+}
+
+// LoadPQKeySeed retrieves the post-quantum key seed
+func (r *RedisPersistence) LoadPQKeySeed() (*types.PQKeySeed, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return nil, fmt.Errorf("persistence layer is closed")
+	}
+
+	ctx := context.Background()
+	key := r.prefixKey(keyPrefixPQKeySeed)
+
+	data, err := r.client.Get(ctx, key).Bytes()
+	if err == redis.Nil {
+		return nil, nil // Not found is not an error
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load PQKeySeed: %w", err)
+	}
+
+	// Deserialize from JSON
+	var seed *types.PQKeySeed
+	err = json.Unmarshal(data, &seed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal PQKeySeed: %w", err)
+	}
+	if seed == nil {
+		return nil, fmt.Errorf("stored PQKeySeed at key %q is a JSON null", key)
+	}
+
+	return seed, nil
+}
+
 // SaveProtocolSession persists protocol session state
 func (r *RedisPersistence) SaveProtocolSession(session *persistence.ProtocolSessionState) error {
 	if session == nil {
@@ -727,6 +789,62 @@ func (r *RedisPersistence) DeleteBlockRecord(chainId uint64, blockNumber uint64)
 	return r.client.Del(ctx, key).Err()
 }
 
+// SaveFraudEvidence persists a fraud evidence bundle, overwriting any with the same ID
+func (r *RedisPersistence) SaveFraudEvidence(evidence *types.FraudEvidence) error {
+	if evidence == nil {
+		return fmt.Errorf("cannot save nil FraudEvidence")
+	}
+	if evidence.ID == "" {
+		return fmt.Errorf("fraud evidence ID is required")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return fmt.Errorf("persistence layer is closed")
+	}
+
+	data, err := json.Marshal(evidence)
+	if err != nil {
+		return fmt.Errorf("failed to marshal FraudEvidence: %w", err)
+	}
+
+	if err := r.client.HSet(context.Background(), r.prefixKey(keyFraudEvidence), evidence.ID, data).Err(); err != nil {
+		return fmt.Errorf("failed to save FraudEvidence: %w", err)
+	}
+	return nil
+}
+
+// ListFraudEvidence returns all fraud evidence bundles sorted by session timestamp, then ID
+func (r *RedisPersistence) ListFraudEvidence() ([]*types.FraudEvidence, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return nil, fmt.Errorf("persistence layer is closed")
+	}
+
+	values, err := r.client.HGetAll(context.Background(), r.prefixKey(keyFraudEvidence)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list fraud evidence: %w", err)
+	}
+
+	evidence := make([]*types.FraudEvidence, 0, len(values))
+	for id, data := range values {
+		var ev *types.FraudEvidence
+		if err := json.Unmarshal([]byte(data), &ev); err != nil || ev == nil {
+			r.logger.Sugar().Warnw("Failed to unmarshal FraudEvidence, skipping",
+				"id", id, "error", err)
+			continue
+		}
+		evidence = append(evidence, ev)
+	}
+
+	persistence.SortFraudEvidence(evidence)
+	return evidence, nil
+}
+
 // Close shuts down the persistence layer
 func (r *RedisPersistence) Close() error {
 	r.mu.Lock()
@@ -536,3 +536,26 @@ func TestRedisPersistence_PoisonedVersions(t *testing.T) {
 	require.NoError(t, err)
 	require.ElementsMatch(t, []int64{1783944564, 1783944800}, got)
 }
+
+func TestRedisPersistence_FraudEvidence(t *testing.T) {
+	rp := requireRedis(t)
+	defer func() { _ = rp.Close() }()
+
+	ctx := context.Background()
+	require.NoError(t, rp.client.Del(ctx, rp.prefixKey(keyFraudEvidence)).Err())
+	defer func() { _ = rp.client.Del(ctx, rp.prefixKey(keyFraudEvidence)).Err() }()
+
+	empty, err := rp.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Empty(t, empty)
+
+	require.NoError(t, rp.SaveFraudEvidence(&types.FraudEvidence{ID: "b", SessionTimestamp: 2}))
+	require.NoError(t, rp.SaveFraudEvidence(&types.FraudEvidence{ID: "a", SessionTimestamp: 2}))
+	require.NoError(t, rp.SaveFraudEvidence(&types.FraudEvidence{ID: "b", SessionTimestamp: 2, SubmissionTxHash: "0x01"}))
+
+	got, err := rp.ListFraudEvidence()
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, "a", got[0].ID)
+	assert.Equal(t, "0x01", got[1].SubmissionTxHash)
+}
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"encoding/json"
+	"sort"
 	"time"
 
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
@@ -35,6 +36,14 @@ type NodeState struct {
 	// of the most recent reshare round that passed MPK validation and persisted.
 	// 0 means none recorded yet. Used as the preferred auto-heal rollback target.
 	LastKnownGoodSourceVersion int64 `json:"lastKnownGoodSourceVersion"`
+
+	// RetiringGenerations maps each rotated-out master secret generation to the unix
+	// time at which it stops being served and its key versions are deleted.
+	RetiringGenerations map[uint32]int64 `json:"retiringGenerations,omitempty"`
+
+	// AutoResharePaused is set while an admin has paused the scheduled reshare, so
+	// the pause survives a restart.
+	AutoResharePaused bool `json:"autoResharePaused,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler. The Alias type strips the method
@@ -66,8 +75,9 @@ type BlockRecord struct {
 }
 
 // ProtocolSessionState captures ephemeral state of a DKG or reshare session.
-// This enables crash recovery - if a node restarts mid-protocol, it can
-// detect incomplete sessions and clean them up appropriately.
+// This enables crash recovery - if a node restarts mid-protocol before the session
+// deadline, it rebuilds the session from this state and rejoins the protocol at the
+// persisted phase. Expired or unrestorable sessions are cleaned up.
 type ProtocolSessionState struct {
 	// SessionTimestamp is the block timestamp for this session.
 	// This serves as the primary key for session storage.
@@ -79,10 +89,17 @@ type ProtocolSessionState struct {
 	// Phase indicates the current phase of the protocol (1-4)
 	// Phase 1: Share distribution
 	// Phase 2: Verification and acknowledgement
-	// Phase 3: Finalization
-	// Phase 4: Merkle tree building (future)
+	// Phase 3: Merkle tree building and contract submission
+	// Phase 4: Commitment broadcast, verification and finalization
 	Phase int `json:"phase"`
 
+	// Generation is the master secret generation a DKG session deals; 0 for genesis
+	// and reshare.
+	Generation uint32 `json:"generation,omitempty"`
+
+	// TriggerBlockNumber is the interval-boundary block that triggered the session.
+	TriggerBlockNumber int64 `json:"triggerBlockNumber,omitempty"`
+
 	// StartTime is the Unix timestamp when this session began
 	StartTime int64 `json:"startTime"`
 
@@ -94,6 +111,10 @@ type ProtocolSessionState struct {
 	// This captures shares received during the protocol.
 	Shares map[string]string `json:"shares"`
 
+	// SealedShares is the JSON encoding of Shares, encrypted at rest by the persistence
+	// encryption layer (pkg/persistence/encrypted). When set, Shares is empty in storage.
+	SealedShares *types.SealedSecret `json:"sealedShares,omitempty"`
+
 	// Commitments maps operator address (hex) to their broadcast commitments.
 	// G2Points are JSON-serializable via CompressedBytes.
 	Commitments map[string][]types.G2Point `json:"commitments"`
@@ -101,6 +122,37 @@ type ProtocolSessionState struct {
 	// Acknowledgements maps dealer address (hex) -> receiver address (hex) -> acknowledgement.
 	// This tracks which operators have acknowledged which shares.
 	Acknowledgements map[string]map[string]*types.Acknowledgement `json:"acknowledgements"`
+
+	// GeneratedShares maps recipient address (hex) to the share this node dealt it
+	// (SerializeFr string format). A resumed dealer re-sends these rather than dealing a
+	// second polynomial. Empty until this node has dealt. Together these shares determine
+	// this node's contribution to the secret, so without encryption at rest they sit in
+	// storage in plaintext, like Shares, until the session completes.
+	GeneratedShares map[string]string `json:"generatedShares,omitempty"`
+
+	// SealedGeneratedShares is the JSON encoding of GeneratedShares, encrypted at rest
+	// like SealedShares. When set, GeneratedShares is empty in storage. Nil when
+	// encryption at rest is disabled.
+	SealedGeneratedShares *types.SealedSecret `json:"sealedGeneratedShares,omitempty"`
+
+	// SourceVersions maps reshare dealer address (hex) to the key version it dealt from.
+	SourceVersions map[string]int64 `json:"sourceVersions,omitempty"`
+
+	// QualifiedDealers lists the dealers (hex) that survived the DKG complaint round.
+	// Nil until the round has closed.
+	QualifiedDealers []string `json:"qualifiedDealers,omitempty"`
+
+	// VerifiedOperators lists the operators (hex) whose commitment broadcast verified
+	// against its merkle proof.
+	VerifiedOperators []string `json:"verifiedOperators,omitempty"`
+
+	// ContractSubmitted is set once this node's commitment and acknowledgement merkle
+	// root are on-chain. AckMerklePlayers lists the players (hex) whose acknowledgements
+	// are the tree's leaves and AckMerkleRoot its root, so a resumed node rebuilds the
+	// identical tree instead of submitting again.
+	ContractSubmitted bool     `json:"contractSubmitted,omitempty"`
+	AckMerklePlayers  []string `json:"ackMerklePlayers,omitempty"`
+	AckMerkleRoot     string   `json:"ackMerkleRoot,omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler. The Alias type strips the method
@@ -127,3 +179,14 @@ func (pss *ProtocolSessionState) IsExpired(timeoutSeconds int64) bool {
 	elapsed := currentTime - pss.StartTime
 	return elapsed > timeoutSeconds
 }
+
+// SortFraudEvidence orders evidence bundles by session timestamp, then ID, the order
+// every backend's ListFraudEvidence returns.
+func SortFraudEvidence(evidence []*types.FraudEvidence) {
+	sort.Slice(evidence, func(i, j int) bool {
+		if evidence[i].SessionTimestamp != evidence[j].SessionTimestamp {
+			return evidence[i].SessionTimestamp < evidence[j].SessionTimestamp
+		}
+		return evidence[i].ID < evidence[j].ID
+	})
+}
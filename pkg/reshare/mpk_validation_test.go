@@ -95,3 +95,57 @@ func TestValidateReshareMasterPublicKey_MissingCommitmentErrors(t *testing.T) {
 		t.Fatal("missing dealer commitment must error, got nil")
 	}
 }
+
+// TestComputeGroupCommitments_YieldRefreshedPublicShares checks that the group commitments
+// of a reshare round commit to the refreshed sharing: G_0 is the unchanged MPK and
+// evaluating them at each operator's address gives that operator's refreshed share·G2,
+// which is what clients verify partial signatures against.
+func TestComputeGroupCommitments_YieldRefreshedPublicShares(t *testing.T) {
+	ops, cur, S, threshold := setupThreeOpSharing(t)
+	dealers := []common.Address{ops[0].OperatorAddress, ops[1].OperatorAddress, ops[2].OperatorAddress}
+
+	commitmentsByDealer := map[common.Address][]types.G2Point{}
+	sharesByRecipient := map[common.Address]map[common.Address]*fr.Element{}
+	for _, d := range ops {
+		r := NewReshare(d.OperatorAddress, ops)
+		shares, commitments, err := r.GenerateNewShares(cur[d.OperatorAddress], threshold)
+		if err != nil {
+			t.Fatalf("GenerateNewShares(%s): %v", d.OperatorAddress.Hex(), err)
+		}
+		commitmentsByDealer[d.OperatorAddress] = commitments
+		for recipient, share := range shares {
+			if sharesByRecipient[recipient] == nil {
+				sharesByRecipient[recipient] = map[common.Address]*fr.Element{}
+			}
+			sharesByRecipient[recipient][d.OperatorAddress] = share
+		}
+	}
+
+	group, err := ComputeGroupCommitments(dealers, commitmentsByDealer)
+	if err != nil {
+		t.Fatalf("ComputeGroupCommitments: %v", err)
+	}
+	if !group[0].IsEqual(mpkOf(t, S)) {
+		t.Fatal("group commitment 0 must equal the master public key")
+	}
+
+	for _, op := range ops {
+		r := NewReshare(op.OperatorAddress, ops)
+		kv, err := r.ComputeNewKeyShare(dealers, sharesByRecipient[op.OperatorAddress], nil)
+		if err != nil {
+			t.Fatalf("ComputeNewKeyShare(%s): %v", op.OperatorAddress.Hex(), err)
+		}
+		pkShare, err := crypto.ComputeOperatorPublicKeyShare(group, op.OperatorAddress)
+		if err != nil {
+			t.Fatalf("ComputeOperatorPublicKeyShare: %v", err)
+		}
+		if !pkShare.IsEqual(mpkOf(t, kv.PrivateShare)) {
+			t.Fatalf("public key share for %s does not match its refreshed share", op.OperatorAddress.Hex())
+		}
+	}
+
+	delete(commitmentsByDealer, dealers[2])
+	if _, err := ComputeGroupCommitments(dealers, commitmentsByDealer); err == nil {
+		t.Fatal("expected an error when a dealer's commitments are missing")
+	}
+}
@@ -218,6 +218,48 @@ func ValidateReshareMasterPublicKey(
 	return nil
 }
 
+// ComputeGroupCommitments returns the commitments to the refreshed sharing polynomial:
+// G_k = Σ_{d∈D} λ_d(D)·C_d[k]. The refreshed share of operator j is Σ_d λ_d·f_d(x_j), so
+// evaluating these commitments at x_j yields that operator's public key share, and
+// G_0 is the (unchanged) master public key. Every dealer must have published the same
+// number of commitments (the new threshold).
+func ComputeGroupCommitments(
+	dealers []common.Address,
+	commitmentsByDealer map[common.Address][]types.G2Point,
+) ([]types.G2Point, error) {
+	if len(dealers) == 0 {
+		return nil, fmt.Errorf("no dealers provided")
+	}
+	degree := len(commitmentsByDealer[dealers[0]])
+	if degree == 0 {
+		return nil, fmt.Errorf("missing commitments for dealer %s", dealers[0].Hex())
+	}
+
+	group := make([]types.G2Point, degree)
+	for k := range group {
+		group[k] = *types.ZeroG2Point()
+	}
+	for _, dealer := range dealers {
+		commitments := commitmentsByDealer[dealer]
+		if len(commitments) != degree {
+			return nil, fmt.Errorf("dealer %s published %d commitments, expected %d", dealer.Hex(), len(commitments), degree)
+		}
+		lambda := crypto.ComputeLagrangeCoefficient(dealer, dealers)
+		for k, c := range commitments {
+			term, err := crypto.ScalarMulG2(c, lambda)
+			if err != nil {
+				return nil, fmt.Errorf("failed to scale commitment %d for dealer %s: %w", k, dealer.Hex(), err)
+			}
+			sum, err := crypto.AddG2(group[k], *term)
+			if err != nil {
+				return nil, fmt.Errorf("failed to accumulate commitment %d for dealer %s: %w", k, dealer.Hex(), err)
+			}
+			group[k] = *sum
+		}
+	}
+	return group, nil
+}
+
 // VerifyDealerSourceVersions keeps only agreed dealers whose P2P (commitments, sourceVersion)
 // hash to the dealer's ON-CHAIN commitment hash, and returns the verified dealers (in input
 // order) plus their verified source-version map (docs/013 Change 2).
@@ -21,6 +21,7 @@ import (
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/node"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering/localPeeringDataFetcher"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/persistence/memory"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/transportSigner/inMemoryTransportSigner"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
@@ -55,6 +56,23 @@ type TestCluster struct {
 	// model an operator that failed to submit (partition), exercising the agreement +
 	// abort-retry behavior. See docs/011_reshareDealerSetAgreement.md.
 	CommitmentRegistry *MockCommitmentRegistry
+
+	// Persistences holds each node's persistence, which RestartNode hands to the
+	// restarted node.
+	Persistences []persistence.INodePersistence
+
+	// deps holds what RestartNode needs to build a node again.
+	deps []nodeDeps
+}
+
+// nodeDeps are the dependencies a cluster node was built with.
+type nodeDeps struct {
+	cfg                node.Config
+	peeringDataFetcher peering.IPeeringDataFetcher
+	blockHandler       blockHandler.IBlockHandler
+	signer             *inMemoryTransportSigner.InMemoryTransportSigner
+	contractCaller     contractCaller.IContractCaller
+	registryAddress    common.Address
 }
 
 // MockCommitmentRegistry is a thread-safe in-memory stand-in for the on-chain
@@ -130,6 +148,14 @@ func (r *MockCommitmentRegistry) Clear() {
 
 // NewTestCluster creates a test cluster of KMS nodes with completed DKG
 func NewTestCluster(t *testing.T, numNodes int) *TestCluster {
+	return NewTestClusterWithPersistence(t, numNodes, func(int) persistence.INodePersistence {
+		return memory.NewMemoryPersistence()
+	})
+}
+
+// NewTestClusterWithPersistence creates a test cluster like NewTestCluster, with the
+// persistence of node i returned by newPersistence(i).
+func NewTestClusterWithPersistence(t *testing.T, numNodes int, newPersistence func(i int) persistence.INodePersistence) *TestCluster {
 	if numNodes > 5 {
 		t.Fatalf("Cannot create more than 5 nodes (limited by ChainConfig)")
 	}
@@ -163,6 +189,8 @@ func NewTestCluster(t *testing.T, numNodes int) *TestCluster {
 		ServerURLs:         make([]string, numNodes),
 		NumNodes:           numNodes,
 		CommitmentRegistry: NewMockCommitmentRegistry(),
+		Persistences:       make([]persistence.INodePersistence, numNodes),
+		deps:               make([]nodeDeps, numNodes),
 	}
 
 	// Create nodes with real addresses and keys
@@ -257,10 +285,17 @@ func NewTestCluster(t *testing.T, numNodes int) *TestCluster {
 
 		mockRegistryAddress := common.HexToAddress("0x1111111111111111111111111111111111111111")
 
-		// Create in-memory persistence for each test node
-		persistence := memory.NewMemoryPersistence()
+		cluster.Persistences[i] = newPersistence(i)
+		cluster.deps[i] = nodeDeps{
+			cfg:                cfg,
+			peeringDataFetcher: peeringDataFetcher,
+			blockHandler:       nodeBlockHandlers[i],
+			signer:             imts,
+			contractCaller:     mockBaseContractCaller,
+			registryAddress:    mockRegistryAddress,
+		}
 
-		n, err := node.NewNode(cfg, peeringDataFetcher, nodeBlockHandlers[i], cluster.MockPoller, imts, mockManager, mockBaseContractCaller, nil, mockRegistryAddress, persistence, testLogger)
+		n, err := node.NewNode(cfg, peeringDataFetcher, nodeBlockHandlers[i], cluster.MockPoller, imts, mockManager, mockBaseContractCaller, nil, mockRegistryAddress, cluster.Persistences[i], testLogger)
 		if err != nil {
 			t.Fatalf("Failed to create node %d: %v", i+1, err)
 		}
@@ -319,6 +354,28 @@ func NewTestCluster(t *testing.T, numNodes int) *TestCluster {
 	return cluster
 }
 
+// RestartNode stops node i and starts a new node in its place, built from the same
+// configuration, keys and persistence, as a restarted process would be. The new node
+// restores its state, including any protocol session it can resume, and serves at the
+// same URL.
+func (c *TestCluster) RestartNode(t *testing.T, i int) *node.Node {
+	t.Helper()
+	_ = c.Nodes[i].Stop()
+
+	testLogger, _ := logger.NewLogger(&logger.LoggerConfig{Debug: false})
+	deps := c.deps[i]
+	n, err := node.NewNode(deps.cfg, deps.peeringDataFetcher, deps.blockHandler, c.MockPoller, deps.signer, attestation.NewStubManager(), deps.contractCaller, nil, deps.registryAddress, c.Persistences[i], testLogger)
+	if err != nil {
+		t.Fatalf("Failed to recreate node %d: %v", i+1, err)
+	}
+	c.Nodes[i] = n
+	c.Servers[i].Config.Handler = node.NewServer(n, 0).GetHandler()
+	if err := n.Start(); err != nil {
+		t.Fatalf("Failed to restart node %d: %v", i+1, err)
+	}
+	return n
+}
+
 // createTestPeeringDataFetcherWithURLs creates a peering data fetcher with actual test server URLs
 func createTestPeeringDataFetcherWithURLs(t *testing.T, addresses, privateKeys, serverURLs []string, numNodes int) peering.IPeeringDataFetcher {
 	peers := make([]*peering.OperatorSetPeer, numNodes)
@@ -0,0 +1,144 @@
+// Package tracing sets up OpenTelemetry tracing for the KMS server and carries trace
+// context between operators.
+//
+// Until Setup installs an exporter, the global tracer provider is OpenTelemetry's
+// no-op provider: spans cost nothing and no trace context is put on the wire.
+//
+// Every operator starts a DKG or reshare session on its own, at the same interval
+// boundary, so their root spans cannot be linked by propagation alone. SessionContext
+// instead derives the trace ID from the session itself, which puts every operator's
+// run of a session in one trace. Messages between operators carry the sender's span
+// context inside their signed payload (see Inject and Extract), so a receiver's
+// handling of a message is parented to the phase that sent it.
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/ethereum/go-ethereum/crypto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/Layr-Labs/eigenx-kms-go"
+	serviceName         = "eigenx-kms"
+)
+
+// Span attribute keys shared by the node's spans.
+const (
+	AttrKeyID            = attribute.Key("kms.key_id")
+	AttrOperatorAddress  = attribute.Key("kms.operator_address")
+	AttrSessionTimestamp = attribute.Key("kms.session_timestamp")
+	AttrPeerAddress      = attribute.Key("kms.peer_address")
+	AttrAppID            = attribute.Key("kms.app_id")
+)
+
+// propagator encodes span context as W3C traceparent/tracestate. It is used directly
+// rather than through the global propagator so that operators always agree on the
+// format, whatever else the process installs.
+var propagator = propagation.TraceContext{}
+
+// Setup installs a tracer provider exporting over OTLP as cfg describes and returns
+// the function that flushes and stops it. When cfg is not enabled nothing is installed
+// and the returned function does nothing.
+func Setup(ctx context.Context, cfg config.TracingConfig, operatorAddress string) (func(context.Context) error, error) {
+	if !cfg.Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", serviceName),
+		AttrOperatorAddress.String(operatorAddress),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracing resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+func newExporter(ctx context.Context, cfg config.TracingConfig) (*otlptrace.Exporter, error) {
+	if cfg.Protocol == config.OTLPProtocolHTTP {
+		opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		return otlptracehttp.New(ctx, opts...)
+	}
+	opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+	if cfg.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	return otlptracegrpc.New(ctx, opts...)
+}
+
+// Tracer returns the KMS tracer from the global provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// SessionContext returns ctx with a remote parent whose trace ID is derived from the
+// protocol session (AVS, operator set and session timestamp), so every operator's
+// spans for the session share one trace. The parent span itself is never recorded.
+func SessionContext(ctx context.Context, avsAddress string, operatorSetID uint32, sessionTimestamp int64) context.Context {
+	digest := crypto.Keccak256([]byte(fmt.Sprintf("eigenx-kms/session/%s/%d/%d", avsAddress, operatorSetID, sessionTimestamp)))
+	var traceID trace.TraceID
+	var spanID trace.SpanID
+	copy(traceID[:], digest[:16])
+	copy(spanID[:], digest[16:24])
+	return trace.ContextWithRemoteSpanContext(ctx, trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+}
+
+// Inject returns the span context of ctx for a message payload, or nil when ctx holds
+// no valid span context (always the case while tracing is disabled).
+func Inject(ctx context.Context) map[string]string {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return nil
+	}
+	return carrier
+}
+
+// Extract returns ctx with the remote span context carried by a message payload. Only
+// call it with the payload of a message whose signature has been verified.
+func Extract(ctx context.Context, carrier map[string]string) context.Context {
+	if len(carrier) == 0 {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier(carrier))
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,63 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/config"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func Test_SessionContext(t *testing.T) {
+	t.Run("same session, same trace", func(t *testing.T) {
+		a := trace.SpanContextFromContext(SessionContext(context.Background(), "0xavs", 1, 1000))
+		b := trace.SpanContextFromContext(SessionContext(context.Background(), "0xavs", 1, 1000))
+		require.True(t, a.IsValid())
+		require.True(t, a.IsSampled())
+		require.Equal(t, a.TraceID(), b.TraceID())
+		require.Equal(t, a.SpanID(), b.SpanID())
+	})
+
+	t.Run("different sessions, different traces", func(t *testing.T) {
+		base := trace.SpanContextFromContext(SessionContext(context.Background(), "0xavs", 1, 1000))
+		for _, other := range []context.Context{
+			SessionContext(context.Background(), "0xavs", 1, 1001),
+			SessionContext(context.Background(), "0xavs", 2, 1000),
+			SessionContext(context.Background(), "0xother", 1, 1000),
+		} {
+			require.NotEqual(t, base.TraceID(), trace.SpanContextFromContext(other).TraceID())
+		}
+	})
+}
+
+func Test_InjectExtract(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		ctx := SessionContext(context.Background(), "0xavs", 1, 1000)
+		carrier := Inject(ctx)
+		require.Contains(t, carrier, "traceparent")
+
+		got := trace.SpanContextFromContext(Extract(context.Background(), carrier))
+		want := trace.SpanContextFromContext(ctx)
+		require.Equal(t, want.TraceID(), got.TraceID())
+		require.Equal(t, want.SpanID(), got.SpanID())
+		require.True(t, got.IsRemote())
+	})
+
+	t.Run("no span context", func(t *testing.T) {
+		require.Nil(t, Inject(context.Background()))
+		require.Equal(t, context.Background(), Extract(context.Background(), nil))
+	})
+}
+
+func Test_Setup(t *testing.T) {
+	t.Run("disabled is a no-op", func(t *testing.T) {
+		shutdown, err := Setup(context.Background(), config.TracingConfig{}, "0x1111")
+		require.NoError(t, err)
+		require.NoError(t, shutdown(context.Background()))
+
+		_, span := Tracer().Start(context.Background(), "test")
+		defer span.End()
+		require.False(t, span.IsRecording())
+	})
+}
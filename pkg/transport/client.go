@@ -2,18 +2,22 @@ package transport
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/encryption"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/merkle"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/tracing"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/transportSigner"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 	"github.com/consensys/gnark-crypto/ecc/bls12-381/fr"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
 )
 
 // RetryConfig configures retry behavior
@@ -37,6 +41,12 @@ type Client struct {
 	operatorAddr common.Address
 	signer       transportSigner.ITransportSigner
 	retryConfig  RetryConfig
+
+	// keyID is the key this client's sessions belong to; routePrefix scopes every
+	// request to that key's routes on the peer (types.KeyRoutePrefix), empty for the
+	// default key.
+	keyID       string
+	routePrefix string
 }
 
 // NewClient creates a new transport client
@@ -45,17 +55,27 @@ func NewClient(operatorAddr common.Address, signer transportSigner.ITransportSig
 		operatorAddr: operatorAddr,
 		signer:       signer,
 		retryConfig:  DefaultRetryConfig,
+		keyID:        types.DefaultKeyID,
 	}
 }
 
+// NewKeyClient creates a transport client that talks to peers on keyID's routes.
+// Every operator in the key's operator set must serve it under the same key ID.
+func NewKeyClient(operatorAddr common.Address, signer transportSigner.ITransportSigner, keyID string) *Client {
+	c := NewClient(operatorAddr, signer)
+	c.keyID = keyID
+	c.routePrefix = types.KeyRoutePrefix(keyID)
+	return c
+}
+
 // buildRequestURL constructs a full URL for an operator endpoint
-func buildRequestURL(socketAddress, path string) string {
-	return fmt.Sprintf("%s%s", socketAddress, path)
+func (c *Client) buildRequestURL(socketAddress, path string) string {
+	return fmt.Sprintf("%s%s%s", socketAddress, c.routePrefix, path)
 }
 
 // QueryOperatorPubkey queries an operator's /pubkey endpoint for commitments
 func (c *Client) QueryOperatorPubkey(operator *peering.OperatorSetPeer) ([]types.G2Point, error) {
-	url := buildRequestURL(operator.SocketAddress, "/pubkey")
+	url := c.buildRequestURL(operator.SocketAddress, "/pubkey")
 	resp, err := http.Get(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to contact operator: %w", err)
@@ -76,39 +96,22 @@ func (c *Client) QueryOperatorPubkey(operator *peering.OperatorSetPeer) ([]types
 	return response.Commitments, nil
 }
 
-// SendDKGShare sends an authenticated DKG share to another node with retries
-func (c *Client) SendDKGShare(toOperator *peering.OperatorSetPeer, share *fr.Element, sessionTimestamp int64) error {
-	msg := types.ShareMessage{
-		FromOperatorAddress: c.operatorAddr,
-		ToOperatorAddress:   toOperator.OperatorAddress,
-		SessionTimestamp:    sessionTimestamp,
-		Share:               types.SerializeFr(share),
-	}
-
-	msgBytes, err := json.Marshal(msg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal payload: %w", err)
-	}
-
-	// Create authenticated message
-	authMsg, err := c.signer.CreateAuthenticatedMessage(msgBytes)
-	if err != nil {
-		return fmt.Errorf("failed to create authenticated message: %w", err)
-	}
-
-	data, err := json.Marshal(authMsg)
-	if err != nil {
-		return fmt.Errorf("failed to marshal authenticated message: %w", err)
-	}
+// FetchShareEncryptionKey retrieves the operator's signed share encryption key for
+// sessionTimestamp from its /share/key endpoint, retrying while the operator may still be
+// starting up or opening the session. Like RequestReshareShare, this returns the raw
+// authenticated message: the CALLER must verify it against the operator's peer key, and
+// check it names this key and session, before encrypting anything to the announced key.
+func (c *Client) FetchShareEncryptionKey(operator *peering.OperatorSetPeer, sessionTimestamp int64) (*types.AuthenticatedMessage, error) {
+	url := c.buildRequestURL(operator.SocketAddress, fmt.Sprintf("/share/key?session=%d", sessionTimestamp))
 
+	var lastErr error
 	backoff := c.retryConfig.InitialBackoff
 	for attempt := 0; attempt < c.retryConfig.MaxAttempts; attempt++ {
-		url := buildRequestURL(toOperator.SocketAddress, "/dkg/share")
-		resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+		authMsg, err := fetchAuthenticatedMessage(url)
 		if err == nil {
-			_ = resp.Body.Close()
-			return nil
+			return authMsg, nil
 		}
+		lastErr = err
 
 		if attempt < c.retryConfig.MaxAttempts-1 {
 			time.Sleep(backoff)
@@ -119,16 +122,84 @@ func (c *Client) SendDKGShare(toOperator *peering.OperatorSetPeer, share *fr.Ele
 		}
 	}
 
-	return fmt.Errorf("failed to send DKG share after %d attempts", c.retryConfig.MaxAttempts)
+	return nil, fmt.Errorf("failed to fetch share encryption key from %s after %d attempts: %w",
+		operator.OperatorAddress.Hex(), c.retryConfig.MaxAttempts, lastErr)
+}
+
+// fetchAuthenticatedMessage GETs url and decodes an AuthenticatedMessage response.
+func fetchAuthenticatedMessage(url string) (*types.AuthenticatedMessage, error) {
+	resp, err := http.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to contact operator: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("operator returned status %d", resp.StatusCode)
+	}
+
+	var authMsg types.AuthenticatedMessage
+	if err := json.NewDecoder(resp.Body).Decode(&authMsg); err != nil {
+		return nil, fmt.Errorf("failed to decode authenticated message: %w", err)
+	}
+	return &authMsg, nil
 }
 
-// SendReshareShare sends an authenticated reshare share to another node with retries
-func (c *Client) SendReshareShare(toOperator *peering.OperatorSetPeer, share *fr.Element, sessionTimestamp int64) error {
+// SendDKGShare encrypts a DKG share to the recipient's share encryption key and sends it
+// as an authenticated message with retries. operators is the session's operator set.
+func (c *Client) SendDKGShare(ctx context.Context, toOperator *peering.OperatorSetPeer, operators []*peering.OperatorSetPeer, recipientKey *ecies.PublicKey, share *fr.Element, sessionTimestamp int64) error {
+	if err := c.sendShare(ctx, toOperator, operators, recipientKey, share, sessionTimestamp, encryption.ShareLabelDKG, "/dkg/share"); err != nil {
+		return fmt.Errorf("failed to send DKG share: %w", err)
+	}
+	return nil
+}
+
+// SendReshareShare encrypts a reshare share to the recipient's share encryption key and
+// sends it as an authenticated message with retries. operators is the session's operator
+// set.
+func (c *Client) SendReshareShare(ctx context.Context, toOperator *peering.OperatorSetPeer, operators []*peering.OperatorSetPeer, recipientKey *ecies.PublicKey, share *fr.Element, sessionTimestamp int64) error {
+	if err := c.sendShare(ctx, toOperator, operators, recipientKey, share, sessionTimestamp, encryption.ShareLabelReshare, "/reshare/share"); err != nil {
+		return fmt.Errorf("failed to send reshare share: %w", err)
+	}
+	return nil
+}
+
+// sendShare builds the encrypted ShareMessage for toOperator, signs it and POSTs it to
+// path. The ciphertext is bound to (label, key, this operator, toOperator,
+// sessionTimestamp, operator set).
+func (c *Client) sendShare(
+	ctx context.Context,
+	toOperator *peering.OperatorSetPeer,
+	operators []*peering.OperatorSetPeer,
+	recipientKey *ecies.PublicKey,
+	share *fr.Element,
+	sessionTimestamp int64,
+	label string,
+	path string,
+) error {
+	operatorAddrs := make([]common.Address, 0, len(operators))
+	for _, op := range operators {
+		operatorAddrs = append(operatorAddrs, op.OperatorAddress)
+	}
+	encryptedShare, err := encryption.EncryptShare(recipientKey, share, encryption.ShareBinding{
+		Label:            label,
+		KeyID:            c.keyID,
+		From:             c.operatorAddr,
+		To:               toOperator.OperatorAddress,
+		SessionTimestamp: sessionTimestamp,
+		Operators:        operatorAddrs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encrypt share: %w", err)
+	}
+
 	msg := types.ShareMessage{
 		FromOperatorAddress: c.operatorAddr,
 		ToOperatorAddress:   toOperator.OperatorAddress,
+		KeyID:               c.keyID,
 		SessionTimestamp:    sessionTimestamp,
-		Share:               types.SerializeFr(share),
+		EncryptedShare:      encryptedShare,
+		TraceContext:        tracing.Inject(ctx),
 	}
 
 	msgBytes, err := json.Marshal(msg)
@@ -149,7 +220,7 @@ func (c *Client) SendReshareShare(toOperator *peering.OperatorSetPeer, share *fr
 
 	backoff := c.retryConfig.InitialBackoff
 	for attempt := 0; attempt < c.retryConfig.MaxAttempts; attempt++ {
-		url := buildRequestURL(toOperator.SocketAddress, "/reshare/share")
+		url := c.buildRequestURL(toOperator.SocketAddress, path)
 		resp, err := http.Post(url, "application/json", bytes.NewReader(data))
 		if err == nil {
 			_ = resp.Body.Close()
@@ -165,23 +236,30 @@ func (c *Client) SendReshareShare(toOperator *peering.OperatorSetPeer, share *fr
 		}
 	}
 
-	return fmt.Errorf("failed to send reshare share after %d attempts", c.retryConfig.MaxAttempts)
+	return fmt.Errorf("no response after %d attempts", c.retryConfig.MaxAttempts)
 }
 
 // RequestReshareShare asks `dealer` for the reshare share it generated for THIS node in
 // the given session, on demand. Used during dealer-set-agreement finalization when this
 // node is missing a dealer's share that the on-chain registry shows did participate.
 //
+// encryptionPublicKey is this node's share encryption key (see
+// encryption.MarshalSharePublicKey); it travels inside the signed request and the dealer
+// encrypts the returned share to it.
+//
 // The dealer responds with a BN254-AuthenticatedMessage wrapping a ShareMessage. This
 // function returns the raw authenticated message; the CALLER must verify it against the
 // dealer's peer key (and check it is addressed to this node) before trusting the share —
 // see Node.fetchAndVerifyReshareShare. We do not unwrap here because the transport client
 // does not hold the peering keys needed to verify. See docs/011_reshareDealerSetAgreement.md.
-func (c *Client) RequestReshareShare(dealer *peering.OperatorSetPeer, sessionTimestamp int64) (*types.AuthenticatedMessage, error) {
+func (c *Client) RequestReshareShare(ctx context.Context, dealer *peering.OperatorSetPeer, sessionTimestamp int64, encryptionPublicKey []byte) (*types.AuthenticatedMessage, error) {
 	req := types.ShareRequestMessage{
 		FromOperatorAddress: c.operatorAddr,
 		ToOperatorAddress:   dealer.OperatorAddress,
+		KeyID:               c.keyID,
 		SessionTimestamp:    sessionTimestamp,
+		EncryptionPublicKey: encryptionPublicKey,
+		TraceContext:        tracing.Inject(ctx),
 	}
 	msgBytes, err := json.Marshal(req)
 	if err != nil {
@@ -196,7 +274,7 @@ func (c *Client) RequestReshareShare(dealer *peering.OperatorSetPeer, sessionTim
 		return nil, fmt.Errorf("failed to marshal authenticated message: %w", err)
 	}
 
-	url := buildRequestURL(dealer.SocketAddress, "/reshare/share/request")
+	url := c.buildRequestURL(dealer.SocketAddress, "/reshare/share/request")
 	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
 	if err != nil {
 		return nil, fmt.Errorf("share request to %s failed: %w", dealer.OperatorAddress.Hex(), err)
@@ -214,7 +292,7 @@ func (c *Client) RequestReshareShare(dealer *peering.OperatorSetPeer, sessionTim
 }
 
 // BroadcastDKGCommitments broadcasts authenticated DKG commitments to all operators
-func (c *Client) BroadcastDKGCommitments(operators []*peering.OperatorSetPeer, commitments []types.G2Point, sessionTimestamp int64) error {
+func (c *Client) BroadcastDKGCommitments(ctx context.Context, operators []*peering.OperatorSetPeer, commitments []types.G2Point, sessionTimestamp int64) error {
 
 	// Send to all other operators
 	for _, op := range operators {
@@ -224,8 +302,10 @@ func (c *Client) BroadcastDKGCommitments(operators []*peering.OperatorSetPeer, c
 		msg := types.CommitmentMessage{
 			FromOperatorAddress: c.operatorAddr,
 			ToOperatorAddress:   op.OperatorAddress,
+			KeyID:               c.keyID,
 			SessionTimestamp:    sessionTimestamp,
 			Commitments:         commitments,
+			TraceContext:        tracing.Inject(ctx),
 		}
 
 		msgBytes, err := json.Marshal(msg)
@@ -244,7 +324,7 @@ func (c *Client) BroadcastDKGCommitments(operators []*peering.OperatorSetPeer, c
 			return fmt.Errorf("failed to marshal authenticated message: %w", err)
 		}
 
-		url := buildRequestURL(op.SocketAddress, "/dkg/commitment")
+		url := c.buildRequestURL(op.SocketAddress, "/dkg/commitment")
 		_, _ = http.Post(url, "application/json", bytes.NewReader(data))
 	}
 	return nil
@@ -253,7 +333,7 @@ func (c *Client) BroadcastDKGCommitments(operators []*peering.OperatorSetPeer, c
 // BroadcastReshareCommitments broadcasts authenticated reshare commitments to all operators.
 // sourceVersion is the key version the sender is resharing FROM; recipients use it to drop
 // dealers on a stale source version at finalize (docs/012 Layer 2).
-func (c *Client) BroadcastReshareCommitments(operators []*peering.OperatorSetPeer, commitments []types.G2Point, sessionTimestamp int64, sourceVersion int64) error {
+func (c *Client) BroadcastReshareCommitments(ctx context.Context, operators []*peering.OperatorSetPeer, commitments []types.G2Point, sessionTimestamp int64, sourceVersion int64) error {
 
 	// Send to all other operators
 	for _, op := range operators {
@@ -263,9 +343,11 @@ func (c *Client) BroadcastReshareCommitments(operators []*peering.OperatorSetPee
 		msg := types.CommitmentMessage{
 			FromOperatorAddress: c.operatorAddr,
 			ToOperatorAddress:   op.OperatorAddress,
+			KeyID:               c.keyID,
 			SessionTimestamp:    sessionTimestamp,
 			Commitments:         commitments,
 			SourceVersion:       sourceVersion,
+			TraceContext:        tracing.Inject(ctx),
 		}
 
 		msgBytes, err := json.Marshal(msg)
@@ -283,19 +365,141 @@ func (c *Client) BroadcastReshareCommitments(operators []*peering.OperatorSetPee
 		if err != nil {
 			return fmt.Errorf("failed to marshal authenticated message: %w", err)
 		}
-		url := buildRequestURL(op.SocketAddress, "/reshare/commitment")
+		url := c.buildRequestURL(op.SocketAddress, "/reshare/commitment")
 		_, _ = http.Post(url, "application/json", bytes.NewReader(data))
 	}
 	return nil
 }
 
+// BroadcastDKGComplaints sends this operator's complaints for a DKG session to all other
+// operators. It is sent even when complaints is empty: peers wait for one complaint
+// message from every operator before closing the complaint round.
+func (c *Client) BroadcastDKGComplaints(ctx context.Context, operators []*peering.OperatorSetPeer, complaints []*types.Complaint, sessionTimestamp int64) error {
+	if complaints == nil {
+		complaints = []*types.Complaint{}
+	}
+	var broadcastErrs []error
+	for _, op := range operators {
+		if op.OperatorAddress == c.operatorAddr {
+			continue // Skip self
+		}
+		msg := types.ComplaintMessage{
+			FromOperatorAddress: c.operatorAddr,
+			ToOperatorAddress:   op.OperatorAddress,
+			KeyID:               c.keyID,
+			SessionTimestamp:    sessionTimestamp,
+			Complaints:          complaints,
+			TraceContext:        tracing.Inject(ctx),
+		}
+		if err := c.postAuthenticated(op, "/dkg/complaint", msg); err != nil {
+			broadcastErrs = append(broadcastErrs, fmt.Errorf("failed to send complaints to %s: %w", op.OperatorAddress.Hex(), err))
+		}
+	}
+	return errors.Join(broadcastErrs...)
+}
+
+// BroadcastDKGJustification sends this operator's answer to a complaint from complainer
+// to all other operators: either share, revealed in cleartext, or encryptedShare, the
+// share sealed to the complainer. Exactly one of them is set.
+func (c *Client) BroadcastDKGJustification(
+	ctx context.Context,
+	operators []*peering.OperatorSetPeer,
+	complainer common.Address,
+	share *fr.Element,
+	encryptedShare []byte,
+	sessionTimestamp int64,
+) error {
+	if (share == nil) == (len(encryptedShare) == 0) {
+		return fmt.Errorf("justification needs exactly one of a revealed or an encrypted share")
+	}
+	var revealed *types.SerializedFrElement
+	if share != nil {
+		revealed = types.SerializeFr(share)
+	}
+	var broadcastErrs []error
+	for _, op := range operators {
+		if op.OperatorAddress == c.operatorAddr {
+			continue // Skip self
+		}
+		msg := types.JustificationMessage{
+			FromOperatorAddress: c.operatorAddr,
+			ToOperatorAddress:   op.OperatorAddress,
+			KeyID:               c.keyID,
+			SessionTimestamp:    sessionTimestamp,
+			ComplainerAddress:   complainer,
+			Share:               revealed,
+			EncryptedShare:      encryptedShare,
+			TraceContext:        tracing.Inject(ctx),
+		}
+		if err := c.postAuthenticated(op, "/dkg/justification", msg); err != nil {
+			broadcastErrs = append(broadcastErrs, fmt.Errorf("failed to send justification to %s: %w", op.OperatorAddress.Hex(), err))
+		}
+	}
+	return errors.Join(broadcastErrs...)
+}
+
+// BroadcastDKGQualifiedSet sends the qualified dealer set this operator computed for a
+// DKG session to all other operators, so they can check that everyone agrees on it.
+func (c *Client) BroadcastDKGQualifiedSet(ctx context.Context, operators []*peering.OperatorSetPeer, qualified []common.Address, sessionTimestamp int64) error {
+	var broadcastErrs []error
+	for _, op := range operators {
+		if op.OperatorAddress == c.operatorAddr {
+			continue // Skip self
+		}
+		msg := types.QualifiedSetMessage{
+			FromOperatorAddress: c.operatorAddr,
+			ToOperatorAddress:   op.OperatorAddress,
+			KeyID:               c.keyID,
+			SessionTimestamp:    sessionTimestamp,
+			QualifiedDealers:    qualified,
+			TraceContext:        tracing.Inject(ctx),
+		}
+		if err := c.postAuthenticated(op, "/dkg/qualified", msg); err != nil {
+			broadcastErrs = append(broadcastErrs, fmt.Errorf("failed to send qualified set to %s: %w", op.OperatorAddress.Hex(), err))
+		}
+	}
+	return errors.Join(broadcastErrs...)
+}
+
+// postAuthenticated signs msg and POSTs it to path on toOperator, failing on a non-200 reply.
+func (c *Client) postAuthenticated(toOperator *peering.OperatorSetPeer, path string, msg interface{}) error {
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	authMsg, err := c.signer.CreateAuthenticatedMessage(msgBytes)
+	if err != nil {
+		return fmt.Errorf("failed to create authenticated message: %w", err)
+	}
+
+	data, err := json.Marshal(authMsg)
+	if err != nil {
+		return fmt.Errorf("failed to marshal authenticated message: %w", err)
+	}
+
+	url := c.buildRequestURL(toOperator.SocketAddress, path)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request failed with status %d", resp.StatusCode)
+	}
+	return nil
+}
+
 // SendDKGAcknowledgement sends an authenticated DKG acknowledgement to a specific operator
-func (c *Client) SendDKGAcknowledgement(ack *types.Acknowledgement, toOperator *peering.OperatorSetPeer, sessionTimestamp int64) error {
+func (c *Client) SendDKGAcknowledgement(ctx context.Context, ack *types.Acknowledgement, toOperator *peering.OperatorSetPeer, sessionTimestamp int64) error {
 	msg := types.AcknowledgementMessage{
 		FromOperatorAddress: c.operatorAddr,
 		ToOperatorAddress:   toOperator.OperatorAddress,
+		KeyID:               c.keyID,
 		SessionTimestamp:    sessionTimestamp,
 		Ack:                 ack,
+		TraceContext:        tracing.Inject(ctx),
 	}
 
 	msgBytes, err := json.Marshal(msg)
@@ -314,7 +518,7 @@ func (c *Client) SendDKGAcknowledgement(ack *types.Acknowledgement, toOperator *
 		return fmt.Errorf("failed to marshal authenticated message: %w", err)
 	}
 
-	url := buildRequestURL(toOperator.SocketAddress, "/dkg/ack")
+	url := c.buildRequestURL(toOperator.SocketAddress, "/dkg/ack")
 	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
 	if err != nil {
 		return err
@@ -324,12 +528,14 @@ func (c *Client) SendDKGAcknowledgement(ack *types.Acknowledgement, toOperator *
 }
 
 // SendReshareAcknowledgement sends an authenticated reshare acknowledgement to a specific operator
-func (c *Client) SendReshareAcknowledgement(ack *types.Acknowledgement, toOperator *peering.OperatorSetPeer, sessionTimestamp int64) error {
+func (c *Client) SendReshareAcknowledgement(ctx context.Context, ack *types.Acknowledgement, toOperator *peering.OperatorSetPeer, sessionTimestamp int64) error {
 	msg := types.AcknowledgementMessage{
 		FromOperatorAddress: c.operatorAddr,
 		ToOperatorAddress:   toOperator.OperatorAddress,
+		KeyID:               c.keyID,
 		SessionTimestamp:    sessionTimestamp,
 		Ack:                 ack,
+		TraceContext:        tracing.Inject(ctx),
 	}
 
 	msgBytes, err := json.Marshal(msg)
@@ -348,7 +554,7 @@ func (c *Client) SendReshareAcknowledgement(ack *types.Acknowledgement, toOperat
 		return fmt.Errorf("failed to marshal authenticated message: %w", err)
 	}
 
-	url := buildRequestURL(toOperator.SocketAddress, "/reshare/ack")
+	url := c.buildRequestURL(toOperator.SocketAddress, "/reshare/ack")
 	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
 	if err != nil {
 		return err
@@ -360,6 +566,7 @@ func (c *Client) SendReshareAcknowledgement(ack *types.Acknowledgement, toOperat
 // BroadcastCommitmentsWithProofs broadcasts commitments and acknowledgements with operator-specific merkle proofs (Phase 5)
 // Each operator receives a broadcast containing all acks and a merkle proof for their specific ack
 func (c *Client) BroadcastCommitmentsWithProofs(
+	ctx context.Context,
 	operators []*peering.OperatorSetPeer,
 	epoch int64,
 	commitments []types.G2Point,
@@ -412,7 +619,7 @@ func (c *Client) BroadcastCommitmentsWithProofs(
 		}
 
 		// Send to operator
-		if err := c.sendCommitmentBroadcast(op, broadcast, epoch); err != nil {
+		if err := c.sendCommitmentBroadcast(ctx, op, broadcast, epoch); err != nil {
 			broadcastErrs = append(broadcastErrs, fmt.Errorf("failed to send commitment broadcast to %s: %w", op.OperatorAddress.Hex(), err))
 		}
 	}
@@ -422,6 +629,7 @@ func (c *Client) BroadcastCommitmentsWithProofs(
 
 // sendCommitmentBroadcast sends an authenticated commitment broadcast to a specific operator (Phase 5)
 func (c *Client) sendCommitmentBroadcast(
+	ctx context.Context,
 	toOperator *peering.OperatorSetPeer,
 	broadcast *types.CommitmentBroadcast,
 	sessionTimestamp int64,
@@ -430,8 +638,10 @@ func (c *Client) sendCommitmentBroadcast(
 	msg := types.CommitmentBroadcastMessage{
 		FromOperatorAddress: c.operatorAddr,
 		ToOperatorAddress:   toOperator.OperatorAddress,
+		KeyID:               c.keyID,
 		SessionTimestamp:    sessionTimestamp,
 		Broadcast:           broadcast,
+		TraceContext:        tracing.Inject(ctx),
 	}
 
 	// Serialize message
@@ -452,7 +662,7 @@ func (c *Client) sendCommitmentBroadcast(
 	}
 
 	// Send to operator
-	url := buildRequestURL(toOperator.SocketAddress, "/dkg/broadcast")
+	url := c.buildRequestURL(toOperator.SocketAddress, "/dkg/broadcast")
 	resp, err := http.Post(url, "application/json", bytes.NewReader(data))
 	if err != nil {
 		return fmt.Errorf("failed to send broadcast: %w", err)
@@ -1,11 +1,16 @@
 package transport
 
 import (
+	"context"
+	"encoding/json"
 	"math/big"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/merkle"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/peering"
+	"github.com/Layr-Labs/eigenx-kms-go/pkg/tracing"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/transportSigner"
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/types"
 	"github.com/ethereum/go-ethereum/common"
@@ -33,6 +38,7 @@ func TestBroadcastCommitmentsWithProofs_NilTree(t *testing.T) {
 	}
 
 	err := client.BroadcastCommitmentsWithProofs(
+		context.Background(),
 		operators,
 		5,
 		[]types.G2Point{},
@@ -124,6 +130,7 @@ func TestBroadcastCommitmentsWithProofs_SkipsSelf(t *testing.T) {
 	// This will skip self, then try to broadcast to the other operator
 	// It will fail the HTTP request but the function collects and returns errors
 	err = client.BroadcastCommitmentsWithProofs(
+		context.Background(),
 		operators,
 		5, // epoch
 		[]types.G2Point{},
@@ -181,6 +188,7 @@ func TestBroadcastCommitmentsWithProofs_NoAckForOperator(t *testing.T) {
 	// Should fail because we can't broadcast to any operators successfully
 	// (one has no ack, one will fail to connect)
 	err = client.BroadcastCommitmentsWithProofs(
+		context.Background(),
 		operators,
 		5, // epoch
 		[]types.G2Point{},
@@ -190,3 +198,46 @@ func TestBroadcastCommitmentsWithProofs_NoAckForOperator(t *testing.T) {
 
 	require.Error(t, err)
 }
+
+func TestBuildRequestURL_KeyScoped(t *testing.T) {
+	addr := common.HexToAddress("0x1111")
+	socket := "http://operator:8000"
+
+	assert.Equal(t, socket+"/dkg/share", NewClient(addr, nil).buildRequestURL(socket, "/dkg/share"))
+	assert.Equal(t, socket+"/dkg/share", NewKeyClient(addr, nil, types.DefaultKeyID).buildRequestURL(socket, "/dkg/share"))
+	assert.Equal(t, socket+"/v1/keys/tenant-b/dkg/share", NewKeyClient(addr, nil, "tenant-b").buildRequestURL(socket, "/dkg/share"))
+}
+
+// TestBroadcastDKGComplaints_SignsTraceContext tests that the sender's trace context is
+// part of the signed payload, and absent when there is no span
+func TestBroadcastDKGComplaints_SignsTraceContext(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	var signed [][]byte
+	mockSigner := transportSigner.NewMockITransportSigner(t)
+	mockSigner.EXPECT().CreateAuthenticatedMessage(mock.Anything).RunAndReturn(func(payload []byte) (*transportSigner.SignedMessage, error) {
+		signed = append(signed, payload)
+		return &transportSigner.SignedMessage{Payload: payload, Signature: []byte("sig")}, nil
+	})
+	client := NewClient(common.HexToAddress("0x1111111111111111111111111111111111111111"), mockSigner)
+	operators := []*peering.OperatorSetPeer{{
+		OperatorAddress: common.HexToAddress("0x2222222222222222222222222222222222222222"),
+		SocketAddress:   srv.URL,
+	}}
+
+	ctx := tracing.SessionContext(context.Background(), "0xavs", 0, 1000)
+	require.NoError(t, client.BroadcastDKGComplaints(ctx, operators, nil, 1000))
+	require.NoError(t, client.BroadcastDKGComplaints(context.Background(), operators, nil, 1000))
+	require.Len(t, signed, 2)
+
+	var msg types.ComplaintMessage
+	require.NoError(t, json.Unmarshal(signed[0], &msg))
+	require.NotEmpty(t, msg.TraceContext)
+	extracted := tracing.Extract(context.Background(), msg.TraceContext)
+	assert.Equal(t, tracing.Inject(ctx), tracing.Inject(extracted))
+
+	assert.NotContains(t, string(signed[1]), "traceContext")
+}
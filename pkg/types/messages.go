@@ -5,7 +5,9 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
-// AuthenticatedMessage wraps all inter-node communications with cryptographic authentication
+// AuthenticatedMessage wraps all inter-node communications with cryptographic authentication.
+// Protocol messages carry the sender's trace context as a TraceContext field of the
+// payload, so it is covered by the signature like the rest of the message.
 type AuthenticatedMessage struct {
 	Payload   []byte   `json:"payload"`   // Raw message bytes (contains from/to addresses)
 	Hash      [32]byte `json:"hash"`      // keccak256(payload)
@@ -17,29 +19,60 @@ type SerializedFrElement struct {
 	Data string
 }
 
-// ShareMessage is sent between nodes during DKG/Reshare
+// ShareMessage is sent between nodes during DKG/Reshare. The share itself is never
+// carried in cleartext: EncryptedShare is an ECIES ciphertext under the recipient's share
+// encryption key, bound to the protocol, key, sender, recipient, session and operator set
+// so it cannot be replayed anywhere else. See encryption.ShareBinding.
 type ShareMessage struct {
-	FromOperatorAddress common.Address       `json:"fromOperatorAddress"`
-	ToOperatorAddress   common.Address       `json:"toOperatorAddress"`
-	SessionTimestamp    int64                `json:"sessionTimestamp"`
-	Share               *SerializedFrElement `json:"share"`
+	FromOperatorAddress common.Address    `json:"fromOperatorAddress"`
+	ToOperatorAddress   common.Address    `json:"toOperatorAddress"`
+	KeyID               string            `json:"keyId,omitempty"` // empty means DefaultKeyID
+	SessionTimestamp    int64             `json:"sessionTimestamp"`
+	EncryptedShare      []byte            `json:"encryptedShare"`
+	TraceContext        map[string]string `json:"traceContext,omitempty"` // sender's W3C trace context
+}
+
+// ShareEncryptionKeyMessage announces the key an operator receives shares under in one
+// session of one key. It is served signed by the operator's transport key, so a sender
+// verifies it against the operator's registered key before encrypting anything to it,
+// and it names the session and key so it cannot be replayed into another one.
+type ShareEncryptionKeyMessage struct {
+	OperatorAddress  common.Address `json:"operatorAddress"`
+	KeyID            string         `json:"keyId"`
+	SessionTimestamp int64          `json:"sessionTimestamp"`
+	PublicKey        []byte         `json:"publicKey"` // uncompressed secp256k1 point
+}
+
+// AppPQPublicKey announces an operator's ML-KEM-768 encapsulation key for an app. It is
+// served signed by the operator's transport key, so an encryptor verifies it against the
+// operator's registered key before encapsulating to it.
+type AppPQPublicKey struct {
+	OperatorAddress  common.Address `json:"operatorAddress"`
+	AppID            string         `json:"appId"`
+	EncapsulationKey []byte         `json:"encapsulationKey"` // ML-KEM-768 encapsulation key
 }
 
 // ShareRequestMessage requests, on demand, the reshare share that `Dealer` generated
 // for `Requester` in session `SessionTimestamp`. Used during dealer-set-agreement
 // finalization when a node is missing a share for a dealer that the on-chain registry
 // shows did participate. The dealer responds with an authenticated ShareMessage
-// containing only the requester's share. See docs/011_reshareDealerSetAgreement.md.
+// containing only the requester's share, encrypted to EncryptionPublicKey. The key
+// travels inside the signed request, so it is authenticated by the requester's
+// transport signature. See docs/011_reshareDealerSetAgreement.md.
 type ShareRequestMessage struct {
-	FromOperatorAddress common.Address `json:"fromOperatorAddress"` // requester
-	ToOperatorAddress   common.Address `json:"toOperatorAddress"`   // dealer being asked
-	SessionTimestamp    int64          `json:"sessionTimestamp"`
+	FromOperatorAddress common.Address    `json:"fromOperatorAddress"` // requester
+	ToOperatorAddress   common.Address    `json:"toOperatorAddress"`   // dealer being asked
+	KeyID               string            `json:"keyId,omitempty"`     // empty means DefaultKeyID
+	SessionTimestamp    int64             `json:"sessionTimestamp"`
+	EncryptionPublicKey []byte            `json:"encryptionPublicKey"`
+	TraceContext        map[string]string `json:"traceContext,omitempty"` // sender's W3C trace context
 }
 
 // CommitmentMessage broadcasts commitments to all nodes
 type CommitmentMessage struct {
 	FromOperatorAddress common.Address `json:"fromOperatorAddress"`
 	ToOperatorAddress   common.Address `json:"toOperatorAddress"` // 0x0 for broadcast
+	KeyID               string         `json:"keyId,omitempty"`   // empty means DefaultKeyID
 	SessionTimestamp    int64          `json:"sessionTimestamp"`
 	Commitments         []G2Point      `json:"commitments"`
 
@@ -50,14 +83,80 @@ type CommitmentMessage struct {
 	// (no source) and for pre-Layer-2 peers; a zero from a reshare dealer is treated as
 	// "unknown" and excludes it from the source-version-agreed set.
 	SourceVersion int64 `json:"sourceVersion,omitempty"`
+
+	TraceContext map[string]string `json:"traceContext,omitempty"` // sender's W3C trace context
 }
 
 // AcknowledgementMessage contains an acknowledgement
 type AcknowledgementMessage struct {
-	FromOperatorAddress common.Address   `json:"fromOperatorAddress"`
-	ToOperatorAddress   common.Address   `json:"toOperatorAddress"`
-	SessionTimestamp    int64            `json:"sessionTimestamp"`
-	Ack                 *Acknowledgement `json:"ack"`
+	FromOperatorAddress common.Address    `json:"fromOperatorAddress"`
+	ToOperatorAddress   common.Address    `json:"toOperatorAddress"`
+	KeyID               string            `json:"keyId,omitempty"` // empty means DefaultKeyID
+	SessionTimestamp    int64             `json:"sessionTimestamp"`
+	Ack                 *Acknowledgement  `json:"ack"`
+	TraceContext        map[string]string `json:"traceContext,omitempty"` // sender's W3C trace context
+}
+
+// Complaint reasons. A receiver complains when a dealer's share is missing or fails
+// verification, or when the dealer's commitments never arrived.
+const (
+	ComplaintReasonInvalidShare       = "invalid_share"
+	ComplaintReasonMissingShare       = "missing_share"
+	ComplaintReasonMissingCommitments = "missing_commitments"
+)
+
+// Complaint accuses DealerAddress of not dealing ComplainerAddress a share that verifies
+// against its commitments. Signature is the complainer's transport signature over
+// (dealer || complainer || session || commitmentHash || keyID || reason), so a complaint
+// stays attributable when relayed and cannot be replayed into another key's session. CommitmentHash is zero when no commitments were received.
+type Complaint struct {
+	DealerAddress     common.Address `json:"dealerAddress"`
+	ComplainerAddress common.Address `json:"complainerAddress"`
+	SessionTimestamp  int64          `json:"sessionTimestamp"`
+	Reason            string         `json:"reason"`
+	CommitmentHash    [32]byte       `json:"commitmentHash"`
+	Signature         []byte         `json:"signature"`
+}
+
+// ComplaintMessage carries every complaint the sender raised in a DKG session. Each
+// operator sends exactly one to every peer, with an empty Complaints list when all
+// dealers verified, so peers know when the complaint round is over.
+type ComplaintMessage struct {
+	FromOperatorAddress common.Address    `json:"fromOperatorAddress"`
+	ToOperatorAddress   common.Address    `json:"toOperatorAddress"`
+	KeyID               string            `json:"keyId,omitempty"` // empty means DefaultKeyID
+	SessionTimestamp    int64             `json:"sessionTimestamp"`
+	Complaints          []*Complaint      `json:"complaints"`
+	TraceContext        map[string]string `json:"traceContext,omitempty"` // sender's W3C trace context
+}
+
+// JustificationMessage is a dealer's answer to a complaint, sent to every operator so all
+// of them see the complaint answered. The share is revealed in cleartext (Share) only
+// for an invalid_share complaint, where every operator must be able to check it against
+// the dealer's commitments. A missing share is re-sent as EncryptedShare, sealed to
+// ComplainerAddress like the original share message, so only the complainer can open
+// and check it.
+type JustificationMessage struct {
+	FromOperatorAddress common.Address       `json:"fromOperatorAddress"`
+	ToOperatorAddress   common.Address       `json:"toOperatorAddress"`
+	KeyID               string               `json:"keyId,omitempty"` // empty means DefaultKeyID
+	SessionTimestamp    int64                `json:"sessionTimestamp"`
+	ComplainerAddress   common.Address       `json:"complainerAddress"`
+	Share               *SerializedFrElement `json:"share,omitempty"`
+	EncryptedShare      []byte               `json:"encryptedShare,omitempty"`
+	TraceContext        map[string]string    `json:"traceContext,omitempty"` // sender's W3C trace context
+}
+
+// QualifiedSetMessage carries the qualified dealer set the sender computed when its
+// complaint round closed. Every operator sends one to every peer, and a DKG only
+// finalizes once the sets agree, so honest operators never sum different dealers.
+type QualifiedSetMessage struct {
+	FromOperatorAddress common.Address    `json:"fromOperatorAddress"`
+	ToOperatorAddress   common.Address    `json:"toOperatorAddress"`
+	KeyID               string            `json:"keyId,omitempty"` // empty means DefaultKeyID
+	SessionTimestamp    int64             `json:"sessionTimestamp"`
+	QualifiedDealers    []common.Address  `json:"qualifiedDealers"`
+	TraceContext        map[string]string `json:"traceContext,omitempty"` // sender's W3C trace context
 }
 
 // SerializeFr serializes a field element
@@ -2,6 +2,8 @@ package types
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
 	"encoding/json"
 
 	"github.com/Layr-Labs/eigenx-kms-go/pkg/bls"
@@ -29,13 +31,45 @@ type KeyShareVersion struct {
 	MasterPublicKey *G2Point         // Pre-computed master public key for threshold agreement
 	IsActive        bool             // Whether this version is the active one
 	ParticipantIDs  []common.Address // Which participants were in the operator set for this version
+
+	// GroupCommitments commit to the combined sharing polynomial of this version
+	// (GroupCommitments[0] is the master public key). Every operator holding the
+	// version agrees on them, and evaluating them at an operator's address yields
+	// that operator's public key share, which clients use to verify individual
+	// partial signatures. Nil for versions created before they were recorded.
+	GroupCommitments []G2Point `json:",omitempty"`
+
+	// DealerCommitments are the commitments of each dealer whose sharing produced
+	// this version, and SourceVersion the version a reshare dealt from (0 for a
+	// DKG). The registry holds each dealer's hash of them for the version's epoch,
+	// which lets clients check the master public key against the chain. Nil for
+	// versions created before they were recorded.
+	DealerCommitments map[common.Address][]G2Point `json:",omitempty"`
+	SourceVersion     int64                        `json:",omitempty"`
+
+	// Generation identifies the master secret this version shares. The genesis DKG
+	// creates generation 0 and every rotation DKG the next one; reshares carry the
+	// source version's generation forward.
+	Generation uint32 `json:",omitempty"`
+
+	// SealedPrivateShare is PrivateShare encrypted at rest by the persistence
+	// encryption layer (pkg/persistence/encrypted). When set, PrivateShare is
+	// never serialized alongside it. Nil for in-memory versions and for records
+	// written before encryption at rest was enabled.
+	SealedPrivateShare *SealedSecret `json:",omitempty"`
 }
 
 // MarshalJSON implements json.Marshaler. The Alias type strips the method
-// set so default encoding is used, avoiding infinite recursion.
-// TODO: wrap PrivateShare with an encrypted field before persisting.
+// set so default encoding is used, avoiding infinite recursion. If the share
+// has been sealed, the plaintext PrivateShare is dropped from the output so a
+// sealed record can never leak the share it protects.
 func (ksv *KeyShareVersion) MarshalJSON() ([]byte, error) {
 	type Alias KeyShareVersion
+	if ksv.SealedPrivateShare != nil && ksv.PrivateShare != nil {
+		sealed := *ksv
+		sealed.PrivateShare = nil
+		return json.Marshal((*Alias)(&sealed))
+	}
 	return json.Marshal((*Alias)(ksv))
 }
 
@@ -46,6 +80,29 @@ func (ksv *KeyShareVersion) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, (*Alias)(ksv))
 }
 
+// PQKeySeed is the seed an operator derives its per-app ML-KEM-768 keys from. It is
+// random and independent of every other key the operator holds, and never leaves the
+// node; only keys derived from it are released, one app at a time.
+type PQKeySeed struct {
+	Seed      []byte `json:",omitempty"`
+	CreatedAt int64  // Unix timestamp (seconds) the seed was generated
+
+	// SealedSeed is Seed encrypted at rest by the persistence encryption layer, which
+	// clears Seed before the record is written.
+	SealedSeed *SealedSecret `json:",omitempty"`
+}
+
+// SealedSecret is an envelope-encrypted secret: Ciphertext is AES-256-GCM under a
+// random data-encryption key (DEK), and WrappedDEK is that DEK wrapped by the
+// key-encryption key (KEK) identified by KEKID. Rotating the KEK only re-wraps the
+// DEK; the ciphertext itself is untouched.
+type SealedSecret struct {
+	KEKID      string `json:"kekId"`
+	WrappedDEK []byte `json:"wrappedDek"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
 // G1Point represents a point on BLS12-381 G1 (used for signatures)
 type G1Point struct {
 	CompressedBytes []byte
@@ -102,10 +159,11 @@ func (p *G2Point) IsZero() (bool, error) {
 type Acknowledgement struct {
 	DealerAddress    common.Address
 	PlayerAddress    common.Address
+	KeyID            string   `json:",omitempty"` // Key of the session; empty means DefaultKeyID
 	SessionTimestamp int64    // Block timestamp of the protocol session (Phase 3)
 	ShareHash        [32]byte // keccak256(share) - commits to received share (Phase 3)
 	CommitmentHash   [32]byte
-	Signature        []byte // Sign(p2p_privkey, dealerAddress || playerAddress || session_timestamp || shareHash || commitment_hash)
+	Signature        []byte // Sign(p2p_privkey, dealerAddress || playerAddress || CommitmentEpoch(keyID, session_timestamp) || shareHash || commitment_hash || keyID)
 }
 
 // CompletionSignature signals reshare completion
@@ -120,6 +178,7 @@ type CompletionSignature struct {
 type AppSignRequest struct {
 	AppID           string
 	AttestationTime int64
+	Generation      *uint32 `json:",omitempty"` // Master secret generation to sign with; nil = the active one
 }
 
 // AppSignResponse contains a partial signature from a node
@@ -128,6 +187,212 @@ type AppSignResponse struct {
 	PartialSignature G1Point
 }
 
+// MaxCiphertextsPerRequest bounds the ciphertexts a single /app/decrypt-share or
+// /secrets request may ask decryption shares for.
+const MaxCiphertextsPerRequest = 64
+
+// DecryptionShare is an operator's share of the key seed of one IBE ciphertext,
+// e(partial_sig, C1), together with a proof that it was computed with the operator's
+// key share (see crypto.VerifyDecryptionShare).
+type DecryptionShare struct {
+	Share []byte // GT element, 576 bytes
+	Proof []byte // Chaum-Pedersen proof: challenge || response, 32 bytes each
+}
+
+// AppDecryptShareRequest asks for an application's decryption shares of the
+// ciphertexts with CiphertextC1s, for threshold decryption without recovering the
+// application private key. It is authorized exactly as a /secrets request, from the
+// same attestation fields, and ExtraData must begin with
+// crypto.CiphertextBinding(CiphertextC1s).
+type AppDecryptShareRequest struct {
+	SecretsRequestV1
+}
+
+// AppDecryptShareResponse carries a node's decryption shares of the requested
+// ciphertexts
+type AppDecryptShareResponse struct {
+	OperatorAddress string `json:"operator_address"`
+	// Hybrid RSA encrypted SecretsDecryptionShares; Shares[i] is for CiphertextC1s[i]
+	// of the request and EnvShare is never set
+	EncryptedDecryptionShares []byte `json:"encrypted_decryption_shares"`
+}
+
+// Health status values reported by /healthz and /readyz
+const (
+	HealthStatusOK          = "ok"
+	HealthStatusUnavailable = "unavailable"
+)
+
+// HealthCheck is the outcome of a single health or readiness check
+type HealthCheck struct {
+	Name    string `json:"name"`
+	Healthy bool   `json:"healthy"`
+	Message string `json:"message,omitempty"`
+}
+
+// HealthResponse is the body of /healthz and /readyz. Status is HealthStatusOK
+// only when every check is healthy.
+type HealthResponse struct {
+	Status string        `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
+
+// DefaultKeyID names the key a server serves on its unscoped routes (/secrets,
+// /dkg/share, ...), so single-key clients and peers keep working unchanged.
+const DefaultKeyID = "default"
+
+// KeyRoutePrefix returns the path prefix of keyID's routes: empty for the default key,
+// "/v1/keys/<id>" for any other.
+func KeyRoutePrefix(keyID string) string {
+	if keyID == "" || keyID == DefaultKeyID {
+		return ""
+	}
+	return "/v1/keys/" + keyID
+}
+
+// SameKeyID reports whether a and b name the same key. An empty key ID, as sent by
+// peers from before a server could hold more than one key, names DefaultKeyID.
+func SameKeyID(a, b string) bool {
+	if a == "" {
+		a = DefaultKeyID
+	}
+	if b == "" {
+		b = DefaultKeyID
+	}
+	return a == b
+}
+
+// commitmentEpochTagShift places a key's epoch tag above any block timestamp while
+// leaving bit 63 clear, so the epoch stays positive as an int64.
+const commitmentEpochTagShift = 40
+
+// CommitmentEpochTag returns the 23-bit tag keyID's sessions are namespaced under in the
+// commitment registry: 0 for the default key, nonzero for every other.
+func CommitmentEpochTag(keyID string) uint32 {
+	if keyID == "" || keyID == DefaultKeyID {
+		return 0
+	}
+	sum := sha256.Sum256([]byte("eigenx-kms/commitment-epoch/" + keyID))
+	tag := binary.BigEndian.Uint32(sum[:4]) & (1<<23 - 1)
+	if tag == 0 {
+		tag = 1
+	}
+	return tag
+}
+
+// CommitmentEpoch returns the registry epoch keyID's session at sessionTimestamp submits
+// its commitment, and signs its acks, under. The registry keys commitments by (epoch,
+// operator), and every key of a server starts its sessions at the same reshare boundaries
+// from the same operator address, so each key needs its own epochs. The default key's
+// epoch is the session timestamp itself, as it was before a server could hold more than
+// one key.
+func CommitmentEpoch(keyID string, sessionTimestamp int64) int64 {
+	return int64(CommitmentEpochTag(keyID))<<commitmentEpochTagShift | sessionTimestamp
+}
+
+// KeyInfo describes one key a server holds (GET /v1/keys)
+type KeyInfo struct {
+	ID               string `json:"id"`
+	OperatorSetId    uint32 `json:"operator_set_id"`
+	ActiveVersion    int64  `json:"active_version"`    // 0 until the key's first DKG completes
+	ActiveGeneration uint32 `json:"active_generation"` // Master secret generation of the active version
+}
+
+// KeyRetentionReport describes a key version retention pass: the versions it pruned,
+// or would prune when DryRun is set
+type KeyRetentionReport struct {
+	KeyID       string             `json:"key_id"`
+	DryRun      bool               `json:"dry_run"`
+	Cutoff      int64              `json:"cutoff,omitempty"`       // Oldest attestation time still served; 0 = no age limit
+	MaxVersions int                `json:"max_versions,omitempty"` // Per generation; 0 = no count limit
+	Protected   []int64            `json:"protected"`              // Active, pending and last-known-good versions
+	Pruned      []PrunedKeyVersion `json:"pruned"`
+	Retained    int                `json:"retained"` // Versions left after the pass
+}
+
+// PrunedKeyVersion is one key version in a KeyRetentionReport
+type PrunedKeyVersion struct {
+	Version    int64  `json:"version"`
+	Generation uint32 `json:"generation"`
+	Reason     string `json:"reason"` // "age" or "count"
+}
+
+// AdminKeyState is one key's scheduling and auto-heal state (admin GET
+// /v1/admin/keys/{id}/state)
+type AdminKeyState struct {
+	KeyID                string  `json:"key_id"`
+	AutoResharePaused    bool    `json:"auto_reshare_paused"`
+	ReshareTriggerBlock  int64   `json:"reshare_trigger_block,omitempty"` // -1 = next block; omitted when none is pending
+	LastBlockNumber      int64   `json:"last_block_number"`
+	ActiveVersion        int64   `json:"active_version"`
+	ActiveGeneration     uint32  `json:"active_generation"`
+	PendingVersion       int64   `json:"pending_version,omitempty"`
+	LastKnownGoodVersion int64   `json:"last_known_good_version"`
+	PoisonedVersions     []int64 `json:"poisoned_versions"`
+	TrackedSourceVersion int64   `json:"tracked_source_version"` // Version the MPK abort counter counts against
+	ConsecutiveMPKAborts int     `json:"consecutive_mpk_aborts"`
+	DemotionThreshold    int     `json:"demotion_threshold"`
+	AutoHealRollback     bool    `json:"auto_heal_rollback"` // The active version was chosen by an auto-heal rollback
+}
+
+// AdminSession describes an in-progress DKG or reshare session
+type AdminSession struct {
+	SessionTimestamp   int64                  `json:"session_timestamp"`
+	Type               string                 `json:"type"`
+	Phase              int                    `json:"phase"`
+	Generation         uint32                 `json:"generation"`
+	TriggerBlockNumber int64                  `json:"trigger_block_number"`
+	StartTime          int64                  `json:"start_time"`
+	Restored           bool                   `json:"restored"` // Resumed after a restart
+	Operators          []AdminSessionOperator `json:"operators"`
+}
+
+// AdminSessionOperator counts the protocol messages a session holds from one operator
+type AdminSessionOperator struct {
+	Address     string `json:"address"`
+	Shares      int    `json:"shares"`      // Shares it dealt to this node
+	Commitments int    `json:"commitments"` // Commitment vectors it broadcast
+	Acks        int    `json:"acks"`        // Acknowledgements it signed
+}
+
+// NodeStatus is a node's full view of one key (admin GET /v1/status)
+type NodeStatus struct {
+	AdminKeyState
+	OperatorAddress       string             `json:"operator_address"`
+	LastProcessedBoundary int64              `json:"last_processed_boundary"`
+	PlatformRpcURL        string             `json:"platform_rpc_url"`
+	Versions              []KeyVersionStatus `json:"versions"`
+	Sessions              []AdminSession     `json:"sessions"`
+}
+
+// KeyVersionStatus describes one key version held by a node, without its share
+type KeyVersionStatus struct {
+	Version         int64    `json:"version"`
+	Generation      uint32   `json:"generation"`
+	Active          bool     `json:"active"`
+	Poisoned        bool     `json:"poisoned"`
+	MasterPublicKey *G2Point `json:"master_public_key"`
+	Participants    int      `json:"participants"`
+}
+
+// RetainedShareRound lists the recipients of the shares a node dealt in one reshare
+// session and still serves to lagging peers. Share values are never exposed.
+type RetainedShareRound struct {
+	SessionTimestamp int64    `json:"session_timestamp"`
+	Recipients       []string `json:"recipients"`
+}
+
+// AdminReshareTriggerRequest asks a node to run a reshare at BlockNumber, or at the
+// next block when it is 0
+type AdminReshareTriggerRequest struct {
+	BlockNumber int64 `json:"block_number,omitempty"`
+}
+
+// AdminVersionRequest names the key version an admin rollback or poison acts on
+type AdminVersionRequest struct {
+	Version int64 `json:"version"`
+}
+
 // SecretsRequestV1 represents a request for application secrets
 type SecretsRequestV1 struct {
 	AppID string `json:"app_id"`
@@ -138,12 +403,19 @@ type SecretsRequestV1 struct {
 	Attestation       []byte `json:"attestation"`        // Attestation data (JWT for GCP/Intel, signature for ECDSA)
 	RSAPubKeyTmp      []byte `json:"rsa_pubkey_tmp"`     // Ephemeral RSA public key
 	AttestationTime   int64  `json:"attestation_time"`   // For key versioning
+	// Generation selects the master secret generation to serve; nil = the active one.
+	Generation *uint32 `json:"generation,omitempty"`
 	// ECDSA-specific fields (only used when attestation_method is "ecdsa")
 	Challenge []byte `json:"challenge,omitempty"`  // Challenge for ECDSA attestation
 	PublicKey []byte `json:"public_key,omitempty"` // Public key for ECDSA attestation
 	ExtraData []byte `json:"extra_data,omitempty"` // optional caller-supplied data bound into attestation nonce (max 1 MB)
-	// eigenx-snp-specific field (only used when attestation_method is "eigenx-snp")
+	// eigenx-snp/eigenx-tdx field (only used when attestation_method is one of them)
 	CCInitData []byte `json:"cc_init_data,omitempty"` // CoCo init-data document bytes (e.g. /run/peerpod/initdata)
+	// CiphertextC1s, when set, asks for decryption shares of the ciphertexts with these
+	// C1s instead of the partial signature (at most MaxCiphertextsPerRequest).
+	// ExtraData must then begin with crypto.CiphertextBinding(CiphertextC1s), so the
+	// attestation names the ciphertexts it may decrypt.
+	CiphertextC1s []G2Point `json:"ciphertext_c1s,omitempty"`
 }
 
 // SecretsResponseV1 represents the response with encrypted secrets
@@ -152,6 +424,53 @@ type SecretsResponseV1 struct {
 	PublicEnv           string `json:"public_env"`            // Plain text env
 	EncryptedPartialSig []byte `json:"encrypted_partial_sig"` // RSA encrypted partial sig
 	ExtraData           []byte `json:"extra_data,omitempty"`  // echoed from request when present
+
+	// Hybrid RSA encrypted SecretsDecryptionShares, in place of EncryptedPartialSig
+	// when the request set CiphertextC1s
+	EncryptedDecryptionShares []byte `json:"encrypted_decryption_shares,omitempty"`
+}
+
+// SecretsDecryptionShares is the plaintext of SecretsResponseV1.EncryptedDecryptionShares
+type SecretsDecryptionShares struct {
+	// Shares holds the decryption share of each of the request's CiphertextC1s, in order
+	Shares []DecryptionShare `json:"shares"`
+	// EnvShare is the decryption share of EncryptedEnv, when that is an IBE ciphertext
+	EnvShare *DecryptionShare `json:"env_share,omitempty"`
+}
+
+// MaxAppMessageSize bounds the message of a /v1/app/sign-message request
+const MaxAppMessageSize = 64 << 10
+
+// AppSignMessageRequest asks for a partial signature over Message under the app's
+// derived signing key. It is authorized exactly as a /secrets request, from the same
+// attestation fields.
+type AppSignMessageRequest struct {
+	SecretsRequestV1
+	Message []byte `json:"message"` // at most MaxAppMessageSize bytes
+}
+
+// AppSignMessageResponse carries a node's partial signature over the requested message
+type AppSignMessageResponse struct {
+	OperatorAddress     string `json:"operator_address"`
+	EncryptedPartialSig []byte `json:"encrypted_partial_sig"` // RSA encrypted partial sig (a G1Point)
+}
+
+// AppPQKeyRequest asks for the app's ML-KEM-768 decapsulation key. It is
+// authorized exactly as a /secrets request, from the same attestation fields.
+type AppPQKeyRequest struct {
+	SecretsRequestV1
+	// EncapsulationKey is the requester's ephemeral ML-KEM-768 encapsulation key. The
+	// released key is sealed to it inside the RSA encryption to RSAPubKeyTmp, so the
+	// response stays confidential against an adversary who can break RSA.
+	EncapsulationKey []byte `json:"encapsulation_key"`
+}
+
+// AppPQKeyResponse carries the app's decapsulation key seed, sealed to the request's
+// EncapsulationKey and then RSA encrypted to its RSAPubKeyTmp
+type AppPQKeyResponse struct {
+	OperatorAddress string `json:"operator_address"`
+	KEMCiphertext   []byte `json:"kem_ciphertext"` // ML-KEM-768 ciphertext to the request's EncapsulationKey
+	EncryptedKey    []byte `json:"encrypted_key"`  // hybrid RSA encrypted, sealed decapsulation key seed
 }
 
 // ContainerPolicy defines the expected container execution parameters for an app release.
@@ -179,10 +498,23 @@ type AttestationClaims struct {
 	ExtraData       []byte // caller-supplied data bound into attestation
 	// Registry is the OCI registry+repo (e.g. "ghcr.io/example/app") that the
 	// running workload was launched from. Currently populated only by the
-	// eigenx-snp method (parsed from cc_init_data's policy.rego); other
+	// eigenx-snp method (parsed from cc_init_data's policy.rego) and the
+	// eigenx-tdx method (from the RTMR[3]-measured image reference). The nitro
+	// method sets "nitro-pcr8:<hex>", the signer of a signed enclave image; other
 	// methods leave it empty, in which case the handler skips the
 	// registry-binding check and falls back to digest-only enforcement.
 	Registry string
+	// TCB holds the TEE's reported security patch levels by component, for
+	// authorization policies that require a minimum platform version. eigenx-snp
+	// reports "bootloader", "tee", "snp", "microcode" (plus "fmc" on Turin);
+	// eigenx-tdx reports "tdx_module", "tdx_module_major", "qe" and "pce". A
+	// Nitro document carries no security version, so nitro leaves it empty and
+	// a policy pins the enclave's boot chain through Measurements instead.
+	TCB map[string]uint64
+	// Measurements holds the TEE's launch measurements by register, hex
+	// encoded: "measurement" for eigenx-snp, "mrtd" and "rtmr0".."rtmr3" for
+	// eigenx-tdx, "pcr0".."pcr4" and "pcr8" for nitro.
+	Measurements map[string]string
 }
 
 // Release represents application release data from on-chain registry
@@ -208,6 +540,78 @@ type CommitmentBroadcast struct {
 type CommitmentBroadcastMessage struct {
 	FromOperatorAddress common.Address       `json:"fromOperatorAddress"`
 	ToOperatorAddress   common.Address       `json:"toOperatorAddress"`
+	KeyID               string               `json:"keyId,omitempty"` // empty means DefaultKeyID
 	SessionTimestamp    int64                `json:"sessionTimestamp"`
 	Broadcast           *CommitmentBroadcast `json:"broadcast"`
+	TraceContext        map[string]string    `json:"traceContext,omitempty"` // sender's W3C trace context
+}
+
+// Fraud evidence kinds (docs/003_fraudProofs.md).
+const (
+	FraudKindInvalidShare = "invalid_share"
+	FraudKindEquivocation = "equivocation"
+)
+
+// How an invalid-share complaint on the commitment registry ended.
+const (
+	// FraudResolutionProven: the dealer left the complaint unanswered and the invalid
+	// share is recorded against it on-chain.
+	FraudResolutionProven = "proven"
+	// FraudResolutionAnswered: the dealer revealed a share that verifies against its
+	// on-chain commitments, so the accusation does not stand.
+	FraudResolutionAnswered = "answered"
+	// FraudResolutionExpired: the complaint period closed before a complaint was opened.
+	FraudResolutionExpired = "expired"
+)
+
+// AcknowledgementProof is an acknowledgement together with its inclusion proof in the
+// dealer's ack merkle tree.
+type AcknowledgementProof struct {
+	Ack       *Acknowledgement `json:"ack"`
+	LeafIndex int              `json:"leafIndex"`
+	Proof     [][32]byte       `json:"proof"`
+}
+
+// FraudEvidence is the bundle an operator records when it catches a dealer misbehaving:
+// the dealer-signed messages as received plus whatever a third party needs to re-check
+// the accusation. Built and verified by pkg/fraud.
+type FraudEvidence struct {
+	// ID is derived from (kind, dealer, session), so re-recording the same fraud
+	// overwrites rather than duplicates.
+	ID               string         `json:"id"`
+	Kind             string         `json:"kind"`
+	Protocol         string         `json:"protocol"`        // "dkg", "reshare", ...
+	KeyID            string         `json:"keyId,omitempty"` // empty means DefaultKeyID
+	SessionTimestamp int64          `json:"sessionTimestamp"`
+	DealerAddress    common.Address `json:"dealerAddress"`
+	ReporterAddress  common.Address `json:"reporterAddress"`
+
+	// SignedCommitments is the authenticated message the dealer's commitments arrived
+	// in (a commitment message, or a commitment broadcast for equivocation).
+	SignedCommitments *AuthenticatedMessage `json:"signedCommitments,omitempty"`
+	// SignedShare is the dealer's authenticated share message as received. The share
+	// inside is encrypted to the reporter; Share carries the decrypted value.
+	SignedShare *AuthenticatedMessage `json:"signedShare,omitempty"`
+	Commitments []G2Point             `json:"commitments,omitempty"`
+	Share       *SerializedFrElement  `json:"share,omitempty"`
+	// SealedShare is Share encrypted at rest by the persistence encryption layer, which
+	// clears Share before the record is written.
+	SealedShare *SealedSecret `json:"sealedShare,omitempty"`
+
+	// Acknowledgements is the ack transcript the reporter holds for this dealer.
+	Acknowledgements []*Acknowledgement `json:"acknowledgements,omitempty"`
+	// ConflictingAcks are two acks from different players that commit to different
+	// commitment hashes, each with its proof against AckMerkleRoot (equivocation only).
+	ConflictingAcks []*AcknowledgementProof `json:"conflictingAcks,omitempty"`
+	AckMerkleRoot   [32]byte                `json:"ackMerkleRoot"`
+
+	RecordedAt int64 `json:"recordedAt"`
+	// SubmissionTxHash is set once the evidence has been accepted on-chain: the
+	// equivocation proof, or the complaint that opens an invalid-share dispute.
+	SubmissionTxHash string `json:"submissionTxHash,omitempty"`
+	// Resolution is how an invalid-share complaint ended (FraudResolution*); empty while
+	// the complaint is still open.
+	Resolution string `json:"resolution,omitempty"`
+	// ResolutionTxHash is the transaction that proved an unanswered complaint.
+	ResolutionTxHash string `json:"resolutionTxHash,omitempty"`
 }